@@ -25,6 +25,7 @@ import (
 	"github.com/dapr/components-contrib/secretstores/hashicorp/vault"
 	sercetstores_kubernetes "github.com/dapr/components-contrib/secretstores/kubernetes"
 	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+	secretstores_localfile "github.com/dapr/dapr/pkg/components/secretstores/localfile"
 
 	// State Stores
 	"github.com/dapr/components-contrib/state"
@@ -67,8 +68,8 @@ import (
 	// Service Discovery
 	"github.com/dapr/components-contrib/servicediscovery"
 	servicediscovery_kubernetes "github.com/dapr/components-contrib/servicediscovery/kubernetes"
-	"github.com/dapr/components-contrib/servicediscovery/mdns"
 	servicediscovery_loader "github.com/dapr/dapr/pkg/components/servicediscovery"
+	"github.com/dapr/dapr/pkg/discovery"
 
 	// Bindings
 	"github.com/dapr/components-contrib/bindings"
@@ -105,6 +106,10 @@ import (
 	http_middleware_loader "github.com/dapr/dapr/pkg/components/middleware/http"
 	http_middleware "github.com/dapr/dapr/pkg/middleware/http"
 	"github.com/valyala/fasthttp"
+
+	// Actor Middleware
+	actor_middleware_loader "github.com/dapr/dapr/pkg/components/middleware/actor"
+	actor_middleware "github.com/dapr/dapr/pkg/middleware/actor"
 )
 
 var log = logger.NewLogger("dapr.runtime")
@@ -117,7 +122,7 @@ func main() {
 
 	var logContrib = logger.NewLogger("dapr.contrib")
 
-	err = rt.Run(
+	opts := []runtime.Option{
 		runtime.WithSecretStores(
 			secretstores_loader.New("kubernetes", func() secretstores.SecretStore {
 				return sercetstores_kubernetes.NewKubernetesSecretStore(logContrib)
@@ -134,6 +139,9 @@ func main() {
 			secretstores_loader.New("gcp.secretmanager", func() secretstores.SecretStore {
 				return gcp_secretmanager.NewSecreteManager(logContrib)
 			}),
+			secretstores_loader.New("local.file", func() secretstores.SecretStore {
+				return secretstores_localfile.NewSecretStore()
+			}),
 		),
 		runtime.WithStates(
 			state_loader.New("redis", func() state.Store {
@@ -221,7 +229,13 @@ func main() {
 		),
 		runtime.WithServiceDiscovery(
 			servicediscovery_loader.New("mdns", func() servicediscovery.Resolver {
-				return mdns.NewMDNSResolver(logContrib)
+				return discovery.NewMDNSResolver()
+			}),
+			servicediscovery_loader.New("dns", func() servicediscovery.Resolver {
+				return discovery.NewDNSResolver()
+			}),
+			servicediscovery_loader.New("consul", func() servicediscovery.Resolver {
+				return discovery.NewConsulResolver()
 			}),
 			servicediscovery_loader.New("kubernetes", func() servicediscovery.Resolver {
 				return servicediscovery_kubernetes.NewKubernetesResolver(logContrib)
@@ -353,16 +367,41 @@ func main() {
 				return handler
 			}),
 		),
-	)
+		runtime.WithActorMiddleware(
+			actor_middleware_loader.New("methods", actor_middleware.NewMethodsMiddleware),
+		),
+	}
+
+	if rt.ConformanceMode() {
+		report, err := rt.RunConformance(opts...)
+		if err != nil {
+			log.Fatalf("fatal error running conformance checks: %s", err)
+		}
+		if err := rt.WriteConformanceReport(os.Stdout, report); err != nil {
+			log.Fatalf("error writing conformance report: %s", err)
+		}
+		if report.Failed() {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	err = rt.Run(opts...)
 	if err != nil {
 		log.Fatalf("fatal error from runtime: %s", err)
 	}
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
-	<-stop
+	exitCode := 0
+	select {
+	case <-stop:
+	case exitCode = <-rt.AppExited():
+	case <-rt.ShutdownRequested():
+	}
 	gracefulShutdownDuration := 5 * time.Second
 	log.Info("dapr shutting down. Waiting 5 seconds to finish outstanding operations")
 	rt.Stop()
 	<-time.After(gracefulShutdownDuration)
+	os.Exit(exitCode)
 }
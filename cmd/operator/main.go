@@ -11,11 +11,14 @@ import (
 
 	scheme "github.com/dapr/dapr/pkg/client/clientset/versioned"
 	"github.com/dapr/dapr/pkg/credentials"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	k8s "github.com/dapr/dapr/pkg/kubernetes"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/metrics"
 	"github.com/dapr/dapr/pkg/operator"
 	"github.com/dapr/dapr/pkg/operator/monitoring"
+	"github.com/dapr/dapr/pkg/operator/validation"
 	"github.com/dapr/dapr/pkg/signals"
 	"github.com/dapr/dapr/pkg/version"
 	"github.com/dapr/dapr/utils"
@@ -24,10 +27,15 @@ import (
 
 var log = logger.NewLogger("dapr.operator")
 var config string
+var defaultConfig string
 var certChainPath string
+var webhookPort int
+var webhookCertFile string
+var webhookKeyFile string
 
 const (
 	defaultCredentialsPath = "/var/run/dapr/credentials"
+	defaultWebhookPort     = 4001
 )
 
 func main() {
@@ -50,6 +58,11 @@ func main() {
 		log.Fatal(err)
 	}
 	config.Credentials = credentials.NewTLSCredentials(certChainPath)
+	config.DefaultConfig = defaultConfig
+
+	if webhookCertFile != "" {
+		go validation.NewWebhook(kubeClient, webhookPort).Run(ctx, webhookCertFile, webhookKeyFile)
+	}
 
 	operator.NewOperator(kubeAPI, config).Run(ctx)
 
@@ -66,12 +79,17 @@ func init() {
 
 	loggerOptions := logger.DefaultOptions()
 	loggerOptions.AttachCmdFlags(flag.StringVar, flag.BoolVar)
+	fips.AttachCmdFlags(flag.BoolVar)
 
 	metricsExporter := metrics.NewExporter(metrics.DefaultMetricNamespace)
 	metricsExporter.Options().AttachCmdFlags(flag.StringVar, flag.BoolVar)
 
 	flag.StringVar(&config, "config", "default", "Path to config file, or name of a configuration object")
+	flag.StringVar(&defaultConfig, "default-config", "", "Name of a configuration object in the namespace of the operator the configurations of the apps are layered on")
 	flag.StringVar(&certChainPath, "certchain", defaultCredentialsPath, "Path to the credentials directory holding the cert chain")
+	flag.IntVar(&webhookPort, "webhook-port", defaultWebhookPort, "Port of the webhook validating Components and Configurations")
+	flag.StringVar(&webhookCertFile, "webhook-tls-cert-file", "", "Path to the TLS certificate of the validating webhook. The webhook is disabled when empty")
+	flag.StringVar(&webhookKeyFile, "webhook-tls-key-file", "", "Path to the TLS key of the validating webhook")
 	flag.Parse()
 
 	// Apply options to all loggers
@@ -80,6 +98,9 @@ func init() {
 	} else {
 		log.Infof("log level set to: %s", loggerOptions.OutputLevel)
 	}
+	if fips.Enabled() {
+		log.Info("fips mode enabled")
+	}
 
 	// Initialize dapr metrics exporter
 	if err := metricsExporter.Init(); err != nil {
@@ -89,4 +110,7 @@ func init() {
 	if err := monitoring.InitMetrics(); err != nil {
 		log.Fatal(err)
 	}
+	if err := diag.DefaultGRPCMonitoring.Init("dapr-operator"); err != nil {
+		log.Fatal(err)
+	}
 }
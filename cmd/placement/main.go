@@ -12,6 +12,8 @@ import (
 	"os/signal"
 
 	"github.com/dapr/dapr/pkg/credentials"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	"github.com/dapr/dapr/pkg/fswatcher"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/metrics"
@@ -33,6 +35,7 @@ func main() {
 
 	loggerOptions := logger.DefaultOptions()
 	loggerOptions.AttachCmdFlags(flag.StringVar, flag.BoolVar)
+	fips.AttachCmdFlags(flag.BoolVar)
 
 	metricsExporter := metrics.NewExporter(metrics.DefaultMetricNamespace)
 	metricsExporter.Options().AttachCmdFlags(flag.StringVar, flag.BoolVar)
@@ -48,6 +51,9 @@ func main() {
 
 	log.Infof("starting Dapr Placement Service -- version %s -- commit %s", version.Version(), version.Commit())
 	log.Infof("log level set to: %s", loggerOptions.OutputLevel)
+	if fips.Enabled() {
+		log.Info("fips mode enabled")
+	}
 
 	// Initialize dapr metrics exporter
 	if err := metricsExporter.Init(); err != nil {
@@ -57,6 +63,9 @@ func main() {
 	if err := monitoring.InitMetrics(); err != nil {
 		log.Fatal(err)
 	}
+	if err := diag.DefaultGRPCMonitoring.Init("dapr-placement"); err != nil {
+		log.Fatal(err)
+	}
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
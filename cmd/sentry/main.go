@@ -14,6 +14,8 @@ import (
 	"time"
 
 	"github.com/dapr/dapr/pkg/credentials"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	"github.com/dapr/dapr/pkg/fswatcher"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/metrics"
@@ -37,6 +39,7 @@ func main() {
 
 	loggerOptions := logger.DefaultOptions()
 	loggerOptions.AttachCmdFlags(flag.StringVar, flag.BoolVar)
+	fips.AttachCmdFlags(flag.BoolVar)
 
 	metricsExporter := metrics.NewExporter(metrics.DefaultMetricNamespace)
 	metricsExporter.Options().AttachCmdFlags(flag.StringVar, flag.BoolVar)
@@ -50,6 +53,9 @@ func main() {
 
 	log.Infof("starting sentry certificate authority -- version %s -- commit %s", version.Version(), version.Commit())
 	log.Infof("log level set to: %s", loggerOptions.OutputLevel)
+	if fips.Enabled() {
+		log.Info("fips mode enabled")
+	}
 
 	// Initialize dapr metrics exporter
 	if err := metricsExporter.Init(); err != nil {
@@ -59,6 +65,9 @@ func main() {
 	if err := monitoring.InitMetrics(); err != nil {
 		log.Fatal(err)
 	}
+	if err := diag.DefaultGRPCMonitoring.Init("dapr-sentry"); err != nil {
+		log.Fatal(err)
+	}
 
 	issuerCertPath := filepath.Join(*credsPath, credentials.IssuerCertFilename)
 	issuerKeyPath := filepath.Join(*credsPath, credentials.IssuerKeyFilename)
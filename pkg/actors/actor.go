@@ -15,4 +15,6 @@ type actor struct {
 	lastUsedTime time.Time
 	busy         bool
 	busyCh       chan (bool)
+	// state caches the state of the actor while it's active, when the state cache is enabled
+	state *stateCache
 }
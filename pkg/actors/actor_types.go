@@ -0,0 +1,108 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RegisterActorType adds an actor type hosted by the app and advertises it to the placement service
+func (a *actorsRuntime) RegisterActorType(ctx context.Context, actorType string) error {
+	if actorType == "" {
+		return fmt.Errorf("actor type is empty")
+	}
+
+	a.hostedTypesLock.Lock()
+	for _, t := range a.config.HostedActorTypes {
+		if t == actorType {
+			a.hostedTypesLock.Unlock()
+			return nil
+		}
+	}
+	a.config.HostedActorTypes = append(a.config.HostedActorTypes, actorType)
+	a.hostedTypesLock.Unlock()
+
+	log.Infof("registered actor type %s", actorType)
+	go a.startAppHealthCheck()
+	a.advertiseHostedTypes()
+	return nil
+}
+
+// UnregisterActorType stops hosting an actor type. Its active actors are deactivated, its reminders and timers
+// are stopped and the type is removed from the types advertised to the placement service.
+func (a *actorsRuntime) UnregisterActorType(ctx context.Context, actorType string) error {
+	a.hostedTypesLock.Lock()
+	found := false
+	types := make([]string, 0, len(a.config.HostedActorTypes))
+	for _, t := range a.config.HostedActorTypes {
+		if t == actorType {
+			found = true
+			continue
+		}
+		types = append(types, t)
+	}
+	a.config.HostedActorTypes = types
+	a.hostedTypesLock.Unlock()
+
+	if !found {
+		return fmt.Errorf("actor type %s is not registered", actorType)
+	}
+
+	a.advertiseHostedTypes()
+
+	prefix := a.constructCompositeKey(actorType, "")
+	stopAll := func(key, value interface{}) bool {
+		if strings.HasPrefix(key.(string), prefix) {
+			close(value.(chan bool))
+			a.activeReminders.Delete(key)
+			a.activeTimers.Delete(key)
+			a.timers.Delete(key)
+		}
+		return true
+	}
+	a.activeReminders.Range(stopAll)
+	a.activeTimers.Range(stopAll)
+
+	a.remindersLock.Lock()
+	delete(a.reminders, actorType)
+	a.remindersLock.Unlock()
+
+	a.actorsTable.Range(func(key, value interface{}) bool {
+		if t, actorID := a.getActorTypeAndIDFromKey(key.(string)); t == actorType {
+			if err := a.deactivateActor(t, actorID); err != nil {
+				log.Warnf("failed to deactivate actor %s: %s", key, err)
+			}
+		}
+		return true
+	})
+
+	log.Infof("unregistered actor type %s", actorType)
+	return nil
+}
+
+// GetHostedActorTypes returns the actor types hosted by the app
+func (a *actorsRuntime) GetHostedActorTypes(ctx context.Context) []string {
+	return a.hostedActorTypes()
+}
+
+func (a *actorsRuntime) hostedActorTypes() []string {
+	a.hostedTypesLock.RLock()
+	defer a.hostedTypesLock.RUnlock()
+
+	types := make([]string, len(a.config.HostedActorTypes))
+	copy(types, a.config.HostedActorTypes)
+	return types
+}
+
+// advertiseHostedTypes reports the hosted actor types to the placement service without waiting for the next heartbeat
+func (a *actorsRuntime) advertiseHostedTypes() {
+	select {
+	case a.hostedTypesChanged <- struct{}{}:
+	default:
+	}
+}
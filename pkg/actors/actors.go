@@ -10,11 +10,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	nethttp "net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/config"
@@ -39,6 +43,7 @@ import (
 const (
 	daprSeparator             = "||"
 	callRemoteActorRetryCount = 3
+	defaultListRemindersLimit = 100
 )
 
 var log = logger.NewLogger("dapr.runtime.actor")
@@ -52,12 +57,18 @@ type Actors interface {
 	DeleteState(ctx context.Context, req *DeleteStateRequest) error
 	TransactionalStateOperation(ctx context.Context, req *TransactionalRequest) error
 	GetReminder(ctx context.Context, req *GetReminderRequest) (*Reminder, error)
+	ListReminders(ctx context.Context, req *ListRemindersRequest) (*ListRemindersResponse, error)
+	ListTimers(ctx context.Context, req *ListTimersRequest) ([]ActorTimer, error)
 	CreateReminder(ctx context.Context, req *CreateReminderRequest) error
 	DeleteReminder(ctx context.Context, req *DeleteReminderRequest) error
 	CreateTimer(ctx context.Context, req *CreateTimerRequest) error
 	DeleteTimer(ctx context.Context, req *DeleteTimerRequest) error
 	IsActorHosted(ctx context.Context, req *ActorHostedRequest) bool
 	GetActiveActorsCount(ctx context.Context) []ActiveActorsCount
+	RegisterActorType(ctx context.Context, actorType string) error
+	UnregisterActorType(ctx context.Context, actorType string) error
+	GetHostedActorTypes(ctx context.Context) []string
+	Stop()
 }
 
 type actorsRuntime struct {
@@ -72,6 +83,7 @@ type actorsRuntime struct {
 	config              Config
 	actorsTable         *sync.Map
 	activeTimers        *sync.Map
+	timers              *sync.Map
 	activeReminders     *sync.Map
 	remindersLock       *sync.RWMutex
 	reminders           map[string][]Reminder
@@ -80,7 +92,15 @@ type actorsRuntime struct {
 	evaluationChan      chan bool
 	appHealthy          bool
 	certChain           *dapr_credentials.CertChain
+	publishFn           func(*pubsub.PublishRequest) error
 	tracingSpec         config.TracingSpec
+	hostedTypesLock     *sync.RWMutex
+	hostedTypesChanged  chan struct{}
+	healthCheckOnce     *sync.Once
+	stopCh              chan struct{}
+	stopOnce            *sync.Once
+	resiliency          *resiliency
+	staleReads          *staleReads
 }
 
 // ActiveActorsCount contain actorType and count of actors each type has
@@ -104,8 +124,9 @@ func NewActors(
 	grpcConnectionFn func(address, id string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error),
 	config Config,
 	certChain *dapr_credentials.CertChain,
+	publishFn func(*pubsub.PublishRequest) error,
 	tracingSpec config.TracingSpec) Actors {
-	return &actorsRuntime{
+	a := &actorsRuntime{
 		appChannel:          appChannel,
 		config:              config,
 		store:               stateStore,
@@ -115,6 +136,7 @@ func NewActors(
 		grpcConnectionFn:    grpcConnectionFn,
 		actorsTable:         &sync.Map{},
 		activeTimers:        &sync.Map{},
+		timers:              &sync.Map{},
 		activeReminders:     &sync.Map{},
 		remindersLock:       &sync.RWMutex{},
 		reminders:           map[string][]Reminder{},
@@ -123,8 +145,21 @@ func NewActors(
 		evaluationChan:      make(chan bool),
 		appHealthy:          true,
 		certChain:           certChain,
+		publishFn:           publishFn,
 		tracingSpec:         tracingSpec,
+		hostedTypesLock:     &sync.RWMutex{},
+		hostedTypesChanged:  make(chan struct{}, 1),
+		healthCheckOnce:     &sync.Once{},
+		stopCh:              make(chan struct{}),
+		stopOnce:            &sync.Once{},
 	}
+	if len(config.Policies) > 0 {
+		a.resiliency = newResiliency(config.Policies)
+	}
+	if len(config.MaxStaleness) > 0 {
+		a.staleReads = newStaleReads()
+	}
+	return a
 }
 
 func (a *actorsRuntime) Init() error {
@@ -140,7 +175,14 @@ func (a *actorsRuntime) Init() error {
 		return errors.New(incompatibleStateStore)
 	}
 
-	go a.connectToPlacementService(a.config.PlacementServiceAddress, a.config.HostAddress, a.config.HeartbeatInterval)
+	if a.config.LifecycleEventsTopic != "" && a.publishFn == nil {
+		log.Warnf("actors: a pub/sub component is required to publish lifecycle events to topic %s", a.config.LifecycleEventsTopic)
+	}
+
+	// the host is named by its address and port in the placement tables, so that the sidecars of a machine are
+	// different hosts
+	hostName := net.JoinHostPort(a.config.HostAddress, strconv.Itoa(a.config.Port))
+	go a.connectToPlacementService(a.config.PlacementServiceAddress, hostName, a.config.HeartbeatInterval)
 	a.startDeactivationTicker(a.config.ActorDeactivationScanInterval, a.config.ActorIdleTimeout)
 
 	log.Infof("actor runtime started. actor idle timeout: %s. actor scan interval: %s",
@@ -150,16 +192,19 @@ func (a *actorsRuntime) Init() error {
 	return nil
 }
 
+// startAppHealthCheck checks the health of the app once it hosts actor types
 func (a *actorsRuntime) startAppHealthCheck(opts ...health.Option) {
-	if len(a.config.HostedActorTypes) == 0 {
+	if len(a.hostedActorTypes()) == 0 {
 		return
 	}
 
-	healthAddress := fmt.Sprintf("%s/healthz", a.appChannel.GetBaseAddress())
-	ch := health.StartEndpointHealthCheck(healthAddress, opts...)
-	for {
-		a.appHealthy = <-ch
-	}
+	a.healthCheckOnce.Do(func() {
+		healthAddress := fmt.Sprintf("%s/healthz", a.appChannel.GetBaseAddress())
+		ch := health.StartEndpointHealthCheck(healthAddress, opts...)
+		for {
+			a.appHealthy = <-ch
+		}
+	})
 }
 
 func (a *actorsRuntime) constructCompositeKey(keys ...string) string {
@@ -176,7 +221,7 @@ func (a *actorsRuntime) deactivateActor(actorType, actorID string) error {
 	req.WithRawData(nil, invokev1.JSONContentType)
 
 	// TODO Propagate context
-	ctx := context.Background()
+	ctx := channel.WithOperation(context.Background(), channel.OperationActor)
 	resp, err := a.appChannel.InvokeMethod(ctx, req)
 	if err != nil {
 		diag.DefaultMonitoring.ActorDeactivationFailed(actorType, "invoke")
@@ -192,6 +237,7 @@ func (a *actorsRuntime) deactivateActor(actorType, actorID string) error {
 	actorKey := a.constructCompositeKey(actorType, actorID)
 	a.actorsTable.Delete(actorKey)
 	diag.DefaultMonitoring.ActorDeactivated(actorType)
+	a.publishLifecycleEvent(ActorDeactivatedEvent, actorType, actorID)
 	return nil
 }
 
@@ -204,6 +250,10 @@ func (a *actorsRuntime) startDeactivationTicker(interval, actorIdleTimeout time.
 	ticker := time.NewTicker(interval)
 	go func() {
 		for t := range ticker.C {
+			a.recordActiveActorsCount()
+			if a.staleReads != nil {
+				a.staleReads.evictExpired(t)
+			}
 			a.actorsTable.Range(func(key, value interface{}) bool {
 				actorInstance := value.(*actor)
 
@@ -229,6 +279,15 @@ func (a *actorsRuntime) startDeactivationTicker(interval, actorIdleTimeout time.
 }
 
 func (a *actorsRuntime) Call(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	if a.resiliency == nil {
+		return a.call(ctx, req)
+	}
+	return a.resiliency.call(ctx, req.Actor().GetActorType(), req.Message().GetMethod(), func(ctx context.Context) (*invokev1.InvokeMethodResponse, error) {
+		return a.call(ctx, req)
+	})
+}
+
+func (a *actorsRuntime) call(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
 	actor := req.Actor()
 	targetActorAddress, appID := a.lookupActorAddress(actor.GetActorType(), actor.GetActorId())
 	if targetActorAddress == "" {
@@ -245,7 +304,16 @@ func (a *actorsRuntime) Call(ctx context.Context, req *invokev1.InvokeMethodRequ
 	if a.isActorLocal(targetActorAddress, a.config.HostAddress, a.config.Port) {
 		resp, err = a.callLocalActor(ctx, req)
 	} else {
-		resp, err = a.callRemoteActorWithRetry(ctx, callRemoteActorRetryCount, a.callRemoteActor, targetActorAddress, appID, req)
+		call := func(ctx context.Context) (*invokev1.InvokeMethodResponse, error) {
+			return a.callRemoteActorWithRetry(ctx, callRemoteActorRetryCount, a.callRemoteActor, targetActorAddress, appID, req)
+		}
+		if delay, ok := req.HedgeDelay(); ok {
+			resp, err = invokev1.Hedge(ctx, delay, call, func(won bool) {
+				diag.DefaultMonitoring.RequestHedged("actor", won)
+			})
+		} else {
+			resp, err = call(ctx)
+		}
 	}
 
 	if err != nil {
@@ -283,17 +351,23 @@ func (a *actorsRuntime) callLocalActor(ctx context.Context, req *invokev1.Invoke
 	actorTypeID := req.Actor()
 	key := a.constructCompositeKey(actorTypeID.GetActorType(), actorTypeID.GetActorId())
 
-	val, exists := a.actorsTable.LoadOrStore(key, &actor{
+	newActor := &actor{
 		lock:         &sync.RWMutex{},
 		busy:         true,
 		lastUsedTime: time.Now().UTC(),
 		busyCh:       make(chan bool, 1),
-	})
+	}
+	if a.config.StateCache {
+		newActor.state = newStateCache()
+	}
+	val, exists := a.actorsTable.LoadOrStore(key, newActor)
 
 	act := val.(*actor)
 	lock := act.lock
+	lockStart := time.Now()
 	lock.Lock()
 	defer lock.Unlock()
+	lockWait := time.Since(lockStart)
 
 	if !exists {
 		err := a.tryActivateActor(actorTypeID.GetActorType(), actorTypeID.GetActorId())
@@ -307,15 +381,10 @@ func (a *actorsRuntime) callLocalActor(ctx context.Context, req *invokev1.Invoke
 		act.lastUsedTime = time.Now().UTC()
 	}
 
-	// Replace method to actors method
-	req.Message().Method = fmt.Sprintf("actors/%s/%s/method/%s", actorTypeID.GetActorType(), actorTypeID.GetActorId(), req.Message().Method)
-	// Original code overrides method with PUT. Why?
-	if req.Message().GetHttpExtension() == nil {
-		req.WithHTTPExtension(nethttp.MethodPut, "")
-	} else {
-		req.Message().HttpExtension.Verb = commonv1pb.HTTPExtension_PUT
-	}
-	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	method := req.Message().Method
+	turnStart := time.Now()
+	resp, err := a.config.Pipeline.Apply(a.dispatchLocalActor)(ctx, req)
+	a.turnCompleted(actorTypeID.GetActorType(), actorTypeID.GetActorId(), method, lockWait, time.Since(turnStart))
 
 	if act.busy {
 		act.busy = false
@@ -335,6 +404,30 @@ func (a *actorsRuntime) callLocalActor(ctx context.Context, req *invokev1.Invoke
 	return resp, nil
 }
 
+// dispatchLocalActor invokes the method of the request on the actor of the app
+func (a *actorsRuntime) dispatchLocalActor(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	actorTypeID := req.Actor()
+	// Replace method to actors method, restoring it for the middleware and the retries of the call
+	method := req.Message().Method
+	req.Message().Method = fmt.Sprintf("actors/%s/%s/method/%s", actorTypeID.GetActorType(), actorTypeID.GetActorId(), method)
+	defer func() { req.Message().Method = method }()
+	// Original code overrides method with PUT. Why?
+	if req.Message().GetHttpExtension() == nil {
+		req.WithHTTPExtension(nethttp.MethodPut, "")
+	} else {
+		req.Message().HttpExtension.Verb = commonv1pb.HTTPExtension_PUT
+	}
+	return a.appChannel.InvokeMethod(channel.WithOperation(ctx, channel.OperationActor), req)
+}
+
+// turnCompleted records the metrics of an actor turn and logs it if it's slow
+func (a *actorsRuntime) turnCompleted(actorType, actorID, method string, lockWait, elapsed time.Duration) {
+	diag.DefaultMonitoring.ActorTurnCompleted(actorType, float64(lockWait)/float64(time.Millisecond), float64(elapsed)/float64(time.Millisecond))
+	if a.config.SlowTurnThreshold > 0 && elapsed > a.config.SlowTurnThreshold {
+		log.Warnf("slow actor turn: method %s of actor %s/%s took %s after waiting %s for its turn", method, actorType, actorID, elapsed, lockWait)
+	}
+}
+
 func (a *actorsRuntime) callRemoteActor(
 	ctx context.Context,
 	targetAddress, targetID string,
@@ -369,7 +462,7 @@ func (a *actorsRuntime) tryActivateActor(actorType, actorID string) error {
 	req.WithRawData(nil, invokev1.JSONContentType)
 
 	// TODO Propagate context
-	ctx := context.Background()
+	ctx := channel.WithOperation(context.Background(), channel.OperationActor)
 	resp, err := a.appChannel.InvokeMethod(ctx, req)
 	if err != nil {
 		return err
@@ -383,13 +476,23 @@ func (a *actorsRuntime) tryActivateActor(actorType, actorID string) error {
 	}
 
 	diag.DefaultMonitoring.ActorActivated(actorType)
+	a.publishLifecycleEvent(ActorActivatedEvent, actorType, actorID)
 
 	return nil
 }
 
+// isActorLocal returns true if the target address is the internal server of this sidecar. Loopback addresses are
+// local only on its port, other sidecars of the machine listening on other ports.
 func (a *actorsRuntime) isActorLocal(targetActorAddress, hostAddress string, grpcPort int) bool {
-	return strings.Contains(targetActorAddress, "localhost") || strings.Contains(targetActorAddress, "127.0.0.1") ||
-		targetActorAddress == fmt.Sprintf("%s:%v", hostAddress, grpcPort)
+	host, port, err := net.SplitHostPort(targetActorAddress)
+	if err != nil || port != strconv.Itoa(grpcPort) {
+		return false
+	}
+	if host == hostAddress || host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
 
 func (a *actorsRuntime) GetState(ctx context.Context, req *GetStateRequest) (*StateResponse, error) {
@@ -397,12 +500,38 @@ func (a *actorsRuntime) GetState(ctx context.Context, req *GetStateRequest) (*St
 		return nil, errors.New("actors: state store does not exist or incorrectly configured")
 	}
 	key := a.constructActorStateKey(req.ActorType, req.ActorID, req.Key)
+	cache := a.stateCache(req.ActorType, req.ActorID)
+	if cache != nil {
+		if data, ok := cache.get(key); ok {
+			return &StateResponse{
+				Data: data,
+			}, nil
+		}
+	}
+	maxStaleness := a.maxStaleness(req)
+	if maxStaleness > 0 {
+		if data, ok := a.staleReads.get(key, maxStaleness, time.Now()); ok {
+			return &StateResponse{
+				Data: data,
+			}, nil
+		}
+	}
+	readAt := time.Now()
 	resp, err := a.store.Get(&state.GetRequest{
 		Key: key,
+		Options: state.GetStateOption{
+			Consistency: req.Consistency,
+		},
 	})
 	if err != nil {
 		return nil, err
 	}
+	if cache != nil {
+		cache.set(key, resp.Data)
+	}
+	if budget := a.config.MaxStaleness[req.ActorType]; budget > 0 && a.staleReads != nil {
+		a.staleReads.set(key, resp.Data, readAt, budget)
+	}
 
 	return &StateResponse{
 		Data: resp.Data,
@@ -455,6 +584,32 @@ func (a *actorsRuntime) TransactionalStateOperation(ctx context.Context, req *Tr
 	}
 
 	err := transactionalStore.Multi(requests)
+	for _, r := range requests {
+		switch o := r.Request.(type) {
+		case state.SetRequest:
+			a.invalidateStaleRead(o.Key)
+		case state.DeleteRequest:
+			a.invalidateStaleRead(o.Key)
+		}
+	}
+	if cache := a.stateCache(req.ActorType, req.ActorID); cache != nil {
+		for _, r := range requests {
+			switch o := r.Request.(type) {
+			case state.SetRequest:
+				if err != nil {
+					cache.invalidate(o.Key)
+				} else {
+					cache.setValue(o.Key, o.Value)
+				}
+			case state.DeleteRequest:
+				if err != nil {
+					cache.invalidate(o.Key)
+				} else {
+					cache.set(o.Key, nil)
+				}
+			}
+		}
+	}
 	return err
 }
 
@@ -473,6 +628,14 @@ func (a *actorsRuntime) SaveState(ctx context.Context, req *SaveStateRequest) er
 		Value: req.Value,
 		Key:   key,
 	})
+	a.invalidateStaleRead(key)
+	if cache := a.stateCache(req.ActorType, req.ActorID); cache != nil {
+		if err != nil {
+			cache.invalidate(key)
+		} else {
+			cache.setValue(key, req.Value)
+		}
+	}
 	return err
 }
 
@@ -484,9 +647,48 @@ func (a *actorsRuntime) DeleteState(ctx context.Context, req *DeleteStateRequest
 	err := a.store.Delete(&state.DeleteRequest{
 		Key: key,
 	})
+	a.invalidateStaleRead(key)
+	if cache := a.stateCache(req.ActorType, req.ActorID); cache != nil {
+		if err != nil {
+			cache.invalidate(key)
+		} else {
+			cache.set(key, nil)
+		}
+	}
 	return err
 }
 
+// stateCache returns the state cache of the actor, or nil when the state cache is disabled or the actor isn't active.
+// Deactivated and rebalanced actors are removed from the actors table, dropping their state.
+func (a *actorsRuntime) stateCache(actorType, actorID string) *stateCache {
+	if !a.config.StateCache {
+		return nil
+	}
+	val, ok := a.actorsTable.Load(a.constructCompositeKey(actorType, actorID))
+	if !ok {
+		return nil
+	}
+	return val.(*actor).state
+}
+
+// maxStaleness returns the stale-read budget of the state read: none for strongly consistent reads, the budget of the
+// call when set, or else the budget of the actor type
+func (a *actorsRuntime) maxStaleness(req *GetStateRequest) time.Duration {
+	if a.staleReads == nil || req.Consistency == state.Strong {
+		return 0
+	}
+	if req.MaxStaleness > 0 {
+		return req.MaxStaleness
+	}
+	return a.config.MaxStaleness[req.ActorType]
+}
+
+func (a *actorsRuntime) invalidateStaleRead(key string) {
+	if a.staleReads != nil {
+		a.staleReads.invalidate(key)
+	}
+}
+
 func (a *actorsRuntime) constructActorStateKey(actorType, actorID, key string) string {
 	return a.constructCompositeKey(a.config.AppID, actorType, actorID, key)
 }
@@ -501,7 +703,7 @@ func (a *actorsRuntime) connectToPlacementService(placementAddress, hostAddress
 			host := placementv1pb.Host{
 				Name:     hostAddress,
 				Load:     1,
-				Entities: a.config.HostedActorTypes,
+				Entities: a.hostedActorTypes(),
 				Port:     int64(a.config.Port),
 				Id:       a.config.AppID,
 			}
@@ -522,7 +724,20 @@ func (a *actorsRuntime) connectToPlacementService(placementAddress, hostAddress
 					stream = a.getPlacementClientPersistently(placementAddress, hostAddress)
 				}
 			}
-			time.Sleep(heartbeatInterval)
+
+			// hosted actor types registered or unregistered by the app are reported right away
+			select {
+			case <-time.After(heartbeatInterval):
+			case <-a.hostedTypesChanged:
+			case <-a.stopCh:
+				// closing the stream removes the host from the placement tables of the other sidecars
+				if stream != nil {
+					if err := stream.CloseSend(); err != nil {
+						log.Errorf("error closing stream to placement service: %s", err)
+					}
+				}
+				return
+			}
 		}
 	}()
 
@@ -530,6 +745,11 @@ func (a *actorsRuntime) connectToPlacementService(placementAddress, hostAddress
 		for {
 			resp, err := stream.Recv()
 			if err != nil {
+				select {
+				case <-a.stopCh:
+					return
+				default:
+				}
 				diag.DefaultMonitoring.ActorStatusReportFailed("recv", "status")
 				log.Warnf("failed to receive the response of status report from placement service: %v", err)
 				stream = a.getPlacementClientPersistently(placementAddress, hostAddress)
@@ -543,13 +763,25 @@ func (a *actorsRuntime) connectToPlacementService(placementAddress, hostAddress
 	}()
 }
 
+// Stop disconnects from the placement service, so that the actors of the host are placed on other hosts
+func (a *actorsRuntime) Stop() {
+	a.stopOnce.Do(func() {
+		close(a.stopCh)
+	})
+}
+
 func (a *actorsRuntime) getPlacementClientPersistently(placementAddress, hostAddress string) placementv1pb.PlacementService_ReportDaprStatusClient {
+	ctx, call := diag.StartControlPlaneCall(context.Background(), diag.ControlPlanePlacement, "ReportDaprStatus", a.tracingSpec)
+	attempt := 0
+
 	for {
 		retryInterval := time.Millisecond * 250
+		attempt++
 
 		opts, err := dapr_credentials.GetClientOptions(a.certChain, security.TLSServerName)
 		if err != nil {
 			log.Errorf("failed to establish TLS credentials for actor placement service: %s", err)
+			call.End(err)
 			return nil
 		}
 		opts = append(
@@ -563,22 +795,24 @@ func (a *actorsRuntime) getPlacementClientPersistently(placementAddress, hostAdd
 		if err != nil {
 			log.Warnf("error connecting to placement service: %v", err)
 			diag.DefaultMonitoring.ActorStatusReportFailed("dial", "placement")
+			call.Annotate("attempt %d: error connecting to placement service: %v", attempt, err)
 			time.Sleep(retryInterval)
 			conn.Close()
 			continue
 		}
 
-		header := metadata.New(map[string]string{idHeader: hostAddress})
-		ctx := metadata.NewOutgoingContext(context.Background(), header)
+		streamCtx := metadata.AppendToOutgoingContext(ctx, idHeader, hostAddress)
 		client := placementv1pb.NewPlacementServiceClient(conn)
-		stream, err := client.ReportDaprStatus(ctx)
+		stream, err := client.ReportDaprStatus(streamCtx)
 		if err != nil {
 			log.Warnf("error establishing client to placement service: %v", err)
 			diag.DefaultMonitoring.ActorStatusReportFailed("establish", "status")
+			call.Annotate("attempt %d: error establishing client to placement service: %v", attempt, err)
 			time.Sleep(retryInterval)
 			conn.Close()
 			continue
 		}
+		call.End(nil)
 		return stream
 	}
 }
@@ -689,6 +923,7 @@ func (a *actorsRuntime) drainRebalancedActors() {
 				a.actorsTable.Delete(key)
 
 				diag.DefaultMonitoring.ActorRebalanced(actorType)
+				a.publishLifecycleEvent(ActorRebalancedEvent, actorType, actorID)
 
 				for {
 					// wait until actor is not busy, then deactivate
@@ -715,7 +950,7 @@ func (a *actorsRuntime) evaluateReminders() {
 	a.evaluationChan = make(chan bool)
 
 	var wg sync.WaitGroup
-	for _, t := range a.config.HostedActorTypes {
+	for _, t := range a.hostedActorTypes() {
 		vals, err := a.getRemindersForActorType(t)
 		if err != nil {
 			log.Debugf("error getting reminders for actor type %s: %s", t, err)
@@ -768,7 +1003,11 @@ func (a *actorsRuntime) lookupActorAddress(actorType, actorID string) (string, s
 	if err != nil || host == nil {
 		return "", ""
 	}
-	return fmt.Sprintf("%s:%v", host.Name, host.Port), host.AppID
+	// hosts are named by their address and port, or by their address only by older sidecars
+	if _, _, err := net.SplitHostPort(host.Name); err == nil {
+		return host.Name, host.AppID
+	}
+	return net.JoinHostPort(host.Name, strconv.FormatInt(host.Port, 10)), host.AppID
 }
 
 func (a *actorsRuntime) getReminderTrack(actorKey, name string) (*ReminderTrack, error) {
@@ -1033,6 +1272,15 @@ func (a *actorsRuntime) CreateTimer(ctx context.Context, req *CreateTimerRequest
 	t := a.configureTicker(d)
 	stop := make(chan bool, 1)
 	a.activeTimers.Store(timerKey, stop)
+	a.timers.Store(timerKey, ActorTimer{
+		ActorID:        req.ActorID,
+		ActorType:      req.ActorType,
+		Name:           req.Name,
+		Callback:       req.Callback,
+		Period:         req.Period,
+		DueTime:        req.DueTime,
+		RegisteredTime: time.Now().UTC().Format(time.RFC3339),
+	})
 
 	go func(ticker *time.Ticker, stop chan (bool), actorType, actorID, name, dueTime, period, callback string, data interface{}) {
 		if dueTime != "" {
@@ -1196,10 +1444,139 @@ func (a *actorsRuntime) DeleteTimer(ctx context.Context, req *DeleteTimerRequest
 		close(stopChan.(chan bool))
 		a.activeTimers.Delete(timerKey)
 	}
+	a.timers.Delete(timerKey)
 
 	return nil
 }
 
+// ListReminders returns the reminders of the actor, or a page of the reminders of the actor type ordered by actor ID
+// and name, with the times they last fired and fire next
+func (a *actorsRuntime) ListReminders(ctx context.Context, req *ListRemindersRequest) (*ListRemindersResponse, error) {
+	reminders, err := a.getRemindersForActorType(req.ActorType)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := []Reminder{}
+	for _, r := range reminders {
+		if req.ActorID == "" || r.ActorID == req.ActorID {
+			matching = append(matching, r)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].ActorID != matching[j].ActorID {
+			return matching[i].ActorID < matching[j].ActorID
+		}
+		return matching[i].Name < matching[j].Name
+	})
+
+	start := 0
+	if req.ContinuationToken != "" {
+		start, err = strconv.Atoi(req.ContinuationToken)
+		if err != nil || start < 0 || start > len(matching) {
+			return nil, fmt.Errorf("invalid continuation token %s", req.ContinuationToken)
+		}
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListRemindersLimit
+	}
+	end := start + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	resp := &ListRemindersResponse{Reminders: make([]ActorReminder, 0, end-start)}
+	for i := range matching[start:end] {
+		r := matching[start+i]
+		reminder := ActorReminder{Reminder: r}
+		track, err := a.getReminderTrack(a.constructCompositeKey(r.ActorType, r.ActorID), r.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error getting reminder track: %s", err)
+		}
+		reminder.LastFiredTime = track.LastFiredTime
+		if next, err := a.getUpcomingReminderInvokeTime(&r); err == nil {
+			reminder.NextFireTime = next.UTC().Format(time.RFC3339)
+		}
+		resp.Reminders = append(resp.Reminders, reminder)
+	}
+	if end < len(matching) {
+		resp.ContinuationToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+// ListTimers returns the active timers of the actor ordered by name. Timers aren't persisted, so only the timers of
+// actors activated on this host are listed.
+func (a *actorsRuntime) ListTimers(ctx context.Context, req *ListTimersRequest) ([]ActorTimer, error) {
+	prefix := a.constructCompositeKey(req.ActorType, req.ActorID, "")
+	now := time.Now().UTC()
+
+	timers := []ActorTimer{}
+	a.activeTimers.Range(func(key, value interface{}) bool {
+		if !strings.HasPrefix(key.(string), prefix) {
+			return true
+		}
+		t, ok := a.timers.Load(key)
+		if !ok {
+			return true
+		}
+		timer := t.(ActorTimer)
+		if next, err := getUpcomingTimerInvokeTime(&timer, now); err == nil {
+			timer.NextFireTime = next.Format(time.RFC3339)
+		}
+		timers = append(timers, timer)
+		return true
+	})
+	sort.Slice(timers, func(i, j int) bool {
+		return timers[i].Name < timers[j].Name
+	})
+	return timers, nil
+}
+
+// getUpcomingTimerInvokeTime returns the next time the timer fires after now. The ticker of a timer ticks every period
+// from its registration, and the timer fires on the first tick after its due time, or on a tick missed during it.
+func getUpcomingTimerInvokeTime(timer *ActorTimer, now time.Time) (time.Time, error) {
+	registeredTime, err := time.Parse(time.RFC3339, timer.RegisteredTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing timer registered time: %s", err)
+	}
+	period, err := time.ParseDuration(timer.Period)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing timer period: %s", err)
+	}
+	var dueTime time.Duration
+	if timer.DueTime != "" {
+		if dueTime, err = time.ParseDuration(timer.DueTime); err != nil {
+			return time.Time{}, fmt.Errorf("error parsing timer due time: %s", err)
+		}
+	}
+
+	first := registeredTime.Add(period)
+	if dueTime >= period {
+		first = registeredTime.Add(dueTime)
+	}
+	if now.Before(first) || period <= 0 {
+		return first, nil
+	}
+	ticks := now.Sub(registeredTime)/period + 1
+	return registeredTime.Add(ticks * period), nil
+}
+
+// recordActiveActorsCount records the number of active actors of each hosted actor type
+func (a *actorsRuntime) recordActiveActorsCount() {
+	counts := map[string]int{}
+	for _, t := range a.hostedActorTypes() {
+		counts[t] = 0
+	}
+	for _, c := range a.GetActiveActorsCount(context.Background()) {
+		counts[c.Type] = c.Count
+	}
+	for t, c := range counts {
+		diag.DefaultMonitoring.ActorActiveCount(t, int64(c))
+	}
+}
+
 func (a *actorsRuntime) GetActiveActorsCount(ctx context.Context) []ActiveActorsCount {
 	var actorCountMap = map[string]int{}
 	a.actorsTable.Range(func(key, value interface{}) bool {
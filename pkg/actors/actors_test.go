@@ -8,17 +8,22 @@ package actors
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/channel"
 	channelt "github.com/dapr/dapr/pkg/channel/testing"
 	"github.com/dapr/dapr/pkg/config"
 	"github.com/dapr/dapr/pkg/health"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	actor_middleware "github.com/dapr/dapr/pkg/middleware/actor"
+	"github.com/dapr/dapr/pkg/placement"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -79,12 +84,15 @@ func newTestActorsRuntime() *actorsRuntime {
 	mockAppChannel.On("GetBaseAddress").Return("http://127.0.0.1", nil)
 	mockAppChannel.On(
 		"InvokeMethod",
-		mock.AnythingOfType("*context.emptyCtx"),
+		mock.MatchedBy(func(ctx context.Context) bool {
+			op, _ := channel.OperationFromContext(ctx)
+			return op == channel.OperationActor
+		}),
 		mock.AnythingOfType("*v1.InvokeMethodRequest")).Return(fakeResp, nil)
 
 	store := fakeStore()
 	config := NewConfig("", TestAppID, "", nil, 0, "", "", "", false)
-	a := NewActors(store, mockAppChannel, nil, config, nil, spec)
+	a := NewActors(store, mockAppChannel, nil, config, nil, nil, spec)
 
 	return a.(*actorsRuntime)
 }
@@ -324,6 +332,91 @@ func TestGetReminder(t *testing.T) {
 	assert.Equal(t, r.DueTime, "1s")
 }
 
+func TestListReminders(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+	actorType, actorID := getTestActorTypeAndID()
+	ctx := context.Background()
+	for _, r := range []CreateReminderRequest{
+		createReminderData(actorID, actorType, "reminder2", "1h", "1h", "a"),
+		createReminderData(actorID, actorType, "reminder1", "1h", "1h", "b"),
+		createReminderData("other", actorType, "reminder1", "", "1h", "c"),
+	} {
+		r := r
+		assert.NoError(t, testActorsRuntime.CreateReminder(ctx, &r))
+	}
+
+	t.Run("reminders of an actor", func(t *testing.T) {
+		resp, err := testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{ActorType: actorType, ActorID: actorID})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Reminders, 2)
+		assert.Equal(t, "reminder1", resp.Reminders[0].Name)
+		assert.Empty(t, resp.ContinuationToken)
+
+		registered, _ := time.Parse(time.RFC3339, resp.Reminders[0].RegisteredTime)
+		assert.Equal(t, registered.Add(time.Hour).Format(time.RFC3339), resp.Reminders[0].NextFireTime)
+		assert.Empty(t, resp.Reminders[0].LastFiredTime)
+	})
+
+	t.Run("pages of an actor type", func(t *testing.T) {
+		resp, err := testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{ActorType: actorType, Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Reminders, 2)
+		assert.Equal(t, "2", resp.ContinuationToken)
+
+		resp, err = testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{ActorType: actorType, Limit: 2, ContinuationToken: resp.ContinuationToken})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Reminders, 1)
+		assert.Equal(t, "other", resp.Reminders[0].ActorID)
+		assert.Empty(t, resp.ContinuationToken)
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, err := testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{ActorType: actorType, ContinuationToken: "a"})
+		assert.Error(t, err)
+	})
+}
+
+func TestListTimers(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+	actorType, actorID := getTestActorTypeAndID()
+	ctx := context.Background()
+	actorKey := testActorsRuntime.constructCompositeKey(actorType, actorID)
+	fakeCallAndActivateActor(testActorsRuntime, actorKey)
+
+	timer := createTimerData(actorID, actorType, "timer1", "1h", "2h", "callback", "")
+	assert.NoError(t, testActorsRuntime.CreateTimer(ctx, &timer))
+
+	timers, err := testActorsRuntime.ListTimers(ctx, &ListTimersRequest{ActorType: actorType, ActorID: actorID})
+	assert.NoError(t, err)
+	assert.Len(t, timers, 1)
+	assert.Equal(t, "callback", timers[0].Callback)
+	registered, _ := time.Parse(time.RFC3339, timers[0].RegisteredTime)
+	assert.Equal(t, registered.Add(2*time.Hour).Format(time.RFC3339), timers[0].NextFireTime)
+
+	assert.NoError(t, testActorsRuntime.DeleteTimer(ctx, &DeleteTimerRequest{Name: "timer1", ActorType: actorType, ActorID: actorID}))
+	timers, err = testActorsRuntime.ListTimers(ctx, &ListTimersRequest{ActorType: actorType, ActorID: actorID})
+	assert.NoError(t, err)
+	assert.Empty(t, timers)
+}
+
+func TestGetUpcomingTimerInvokeTime(t *testing.T) {
+	registered := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	timer := &ActorTimer{RegisteredTime: registered.Format(time.RFC3339), Period: "10s", DueTime: "5s"}
+
+	next, err := getUpcomingTimerInvokeTime(timer, registered.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, registered.Add(10*time.Second), next)
+
+	next, err = getUpcomingTimerInvokeTime(timer, registered.Add(25*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, registered.Add(30*time.Second), next)
+
+	timer.DueTime = "15s"
+	next, err = getUpcomingTimerInvokeTime(timer, registered.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, registered.Add(15*time.Second), next)
+}
+
 func TestDeleteTimer(t *testing.T) {
 	testActorsRuntime := newTestActorsRuntime()
 	actorType, actorID := getTestActorTypeAndID()
@@ -690,3 +783,314 @@ func TestActorsAppHealthCheck(t *testing.T) {
 	time.Sleep(time.Second * 2)
 	assert.False(t, testActorRuntime.appHealthy)
 }
+
+func TestPublishLifecycleEvent(t *testing.T) {
+	t.Run("no topic configured", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		published := make(chan *pubsub.PublishRequest, 1)
+		testActorRuntime.publishFn = func(req *pubsub.PublishRequest) error {
+			published <- req
+			return nil
+		}
+
+		testActorRuntime.publishLifecycleEvent(ActorActivatedEvent, "cat", "abcd")
+
+		select {
+		case <-published:
+			assert.Fail(t, "event must not be published without a topic")
+		case <-time.After(time.Millisecond * 100):
+		}
+	})
+
+	t.Run("event is published to topic", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		testActorRuntime.config.LifecycleEventsTopic = "actor-lifecycle"
+		testActorRuntime.config.HostAddress = "10.0.0.1"
+		published := make(chan *pubsub.PublishRequest, 1)
+		testActorRuntime.publishFn = func(req *pubsub.PublishRequest) error {
+			published <- req
+			return nil
+		}
+
+		testActorRuntime.publishLifecycleEvent(ActorDeactivatedEvent, "cat", "abcd")
+
+		select {
+		case req := <-published:
+			assert.Equal(t, "actor-lifecycle", req.Topic)
+
+			var envelope pubsub.CloudEventsEnvelope
+			assert.NoError(t, json.Unmarshal(req.Data, &envelope))
+			assert.Equal(t, ActorLifecycleCloudEventType, envelope.Type)
+
+			var event LifecycleEvent
+			b, _ := json.Marshal(envelope.Data)
+			assert.NoError(t, json.Unmarshal(b, &event))
+			assert.Equal(t, ActorDeactivatedEvent, event.Event)
+			assert.Equal(t, "cat", event.ActorType)
+			assert.Equal(t, "abcd", event.ActorID)
+			assert.Equal(t, TestAppID, event.AppID)
+			assert.Equal(t, "10.0.0.1", event.Host)
+		case <-time.After(time.Second):
+			assert.Fail(t, "event was not published")
+		}
+	})
+}
+
+func TestRegisterActorType(t *testing.T) {
+	testActorRuntime := newTestActorsRuntime()
+	ctx := context.Background()
+
+	t.Run("register advertises the type", func(t *testing.T) {
+		assert.NoError(t, testActorRuntime.RegisterActorType(ctx, "cat"))
+		assert.NoError(t, testActorRuntime.RegisterActorType(ctx, "cat"))
+		assert.NoError(t, testActorRuntime.RegisterActorType(ctx, "dog"))
+
+		assert.Equal(t, []string{"cat", "dog"}, testActorRuntime.GetHostedActorTypes(ctx))
+		assert.Len(t, testActorRuntime.hostedTypesChanged, 1)
+	})
+
+	t.Run("empty type", func(t *testing.T) {
+		assert.Error(t, testActorRuntime.RegisterActorType(ctx, ""))
+	})
+
+	t.Run("unregister stops timers and reminders", func(t *testing.T) {
+		<-testActorRuntime.hostedTypesChanged
+		timer := make(chan bool, 1)
+		testActorRuntime.activeTimers.Store(testActorRuntime.constructCompositeKey("cat", "1", "timer"), timer)
+		dogTimer := make(chan bool, 1)
+		testActorRuntime.activeTimers.Store(testActorRuntime.constructCompositeKey("dog", "1", "timer"), dogTimer)
+
+		assert.NoError(t, testActorRuntime.UnregisterActorType(ctx, "cat"))
+		assert.Equal(t, []string{"dog"}, testActorRuntime.GetHostedActorTypes(ctx))
+		assert.Len(t, testActorRuntime.hostedTypesChanged, 1)
+
+		_, open := <-timer
+		assert.False(t, open)
+		_, exists := testActorRuntime.activeTimers.Load(testActorRuntime.constructCompositeKey("dog", "1", "timer"))
+		assert.True(t, exists)
+	})
+
+	t.Run("unregister unknown type", func(t *testing.T) {
+		assert.Error(t, testActorRuntime.UnregisterActorType(ctx, "cat"))
+	})
+}
+
+func TestStop(t *testing.T) {
+	testActorRuntime := newTestActorsRuntime()
+	testActorRuntime.Stop()
+	testActorRuntime.Stop()
+
+	select {
+	case <-testActorRuntime.stopCh:
+	default:
+		assert.Fail(t, "stop channel isn't closed")
+	}
+}
+
+func TestIsActorLocal(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+
+	assert.True(t, testActorsRuntime.isActorLocal("10.0.0.1:50001", "10.0.0.1", 50001))
+	assert.True(t, testActorsRuntime.isActorLocal("localhost:50001", "10.0.0.1", 50001))
+	assert.True(t, testActorsRuntime.isActorLocal("127.0.0.1:50001", "10.0.0.1", 50001))
+	// other sidecars of the machine
+	assert.False(t, testActorsRuntime.isActorLocal("127.0.0.1:50002", "127.0.0.1", 50001))
+	assert.False(t, testActorsRuntime.isActorLocal("10.0.0.1:50002", "10.0.0.1", 50001))
+	assert.False(t, testActorsRuntime.isActorLocal("10.0.0.2:50001", "10.0.0.1", 50001))
+}
+
+func TestLookupActorAddress(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+	actorType, actorID := getTestActorTypeAndID()
+
+	t.Run("host named by its address and port", func(t *testing.T) {
+		c := placement.NewConsistentHash()
+		c.Add("127.0.0.1:50002", "app", 50002)
+		testActorsRuntime.placementTables.Entries[actorType] = c
+
+		address, appID := testActorsRuntime.lookupActorAddress(actorType, actorID)
+		assert.Equal(t, "127.0.0.1:50002", address)
+		assert.Equal(t, "app", appID)
+	})
+
+	t.Run("host named by its address", func(t *testing.T) {
+		c := placement.NewConsistentHash()
+		c.Add("10.0.0.2", "app", 50002)
+		testActorsRuntime.placementTables.Entries[actorType] = c
+
+		address, _ := testActorsRuntime.lookupActorAddress(actorType, actorID)
+		assert.Equal(t, "10.0.0.2:50002", address)
+	})
+}
+
+func TestCallLocalActorPipeline(t *testing.T) {
+	mockAppChannel := new(channelt.MockAppChannel)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.AnythingOfType("*v1.InvokeMethodRequest")).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil)
+
+	var calls []string
+	actorConfig := NewConfig("", TestAppID, "", nil, 0, "", "", "", false)
+	actorConfig.Pipeline.Handlers = []actor_middleware.Middleware{
+		func(h actor_middleware.Handler) actor_middleware.Handler {
+			return func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+				if req.Message().Method == "Forbidden" {
+					return nil, errors.New("forbidden")
+				}
+				calls = append(calls, "pre "+req.Actor().GetActorType()+"."+req.Message().Method)
+				resp, err := h(ctx, req)
+				calls = append(calls, "post "+req.Message().Method)
+				return resp, err
+			}
+		},
+	}
+	testActorsRuntime := NewActors(fakeStore(), mockAppChannel, nil, actorConfig, nil, nil, config.TracingSpec{}).(*actorsRuntime)
+	actorType, actorID := getTestActorTypeAndID()
+
+	_, err := testActorsRuntime.callLocalActor(context.Background(), invokev1.NewInvokeMethodRequest("Eat").WithActor(actorType, actorID))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pre cat.Eat", "post Eat"}, calls)
+
+	_, err = testActorsRuntime.callLocalActor(context.Background(), invokev1.NewInvokeMethodRequest("Forbidden").WithActor(actorType, actorID))
+	assert.EqualError(t, err, "forbidden")
+	// activation and the allowed call
+	mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 2)
+}
+
+// countingStateStore counts the reads of the state store
+type countingStateStore struct {
+	*fakeStateStore
+	gets int
+}
+
+func (c *countingStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	c.gets++
+	return c.fakeStateStore.Get(req)
+}
+
+func TestStateCache(t *testing.T) {
+	ctx := context.Background()
+	actorType, actorID := getTestActorTypeAndID()
+	newRuntime := func() (*actorsRuntime, *countingStateStore) {
+		store := &countingStateStore{fakeStateStore: fakeStore().(*fakeStateStore)}
+		actorConfig := NewConfig("", TestAppID, "", nil, 0, "", "", "", false)
+		actorConfig.StateCache = true
+		testActorRuntime := NewActors(store, nil, nil, actorConfig, nil, nil, config.TracingSpec{}).(*actorsRuntime)
+		testActorRuntime.actorsTable.Store(testActorRuntime.constructCompositeKey(actorType, actorID), &actor{
+			lastUsedTime: time.Now().UTC(),
+			lock:         &sync.RWMutex{},
+			busyCh:       make(chan bool, 1),
+			state:        newStateCache(),
+		})
+		return testActorRuntime, store
+	}
+	get := func(testActorRuntime *actorsRuntime, key string) []byte {
+		response, err := testActorRuntime.GetState(ctx, &GetStateRequest{ActorID: actorID, ActorType: actorType, Key: key})
+		assert.NoError(t, err)
+		return response.Data
+	}
+
+	t.Run("writes are read from the cache", func(t *testing.T) {
+		testActorRuntime, store := newRuntime()
+
+		assert.NoError(t, testActorRuntime.SaveState(ctx, &SaveStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1", Value: "fakeData"}))
+		assert.NoError(t, testActorRuntime.TransactionalStateOperation(ctx, &TransactionalRequest{
+			ActorType: actorType,
+			ActorID:   actorID,
+			Operations: []TransactionalOperation{
+				{Operation: Upsert, Request: TransactionalUpsert{Key: "key2", Value: map[string]interface{}{"count": 2}}},
+			},
+		}))
+
+		assert.Equal(t, `"fakeData"`, string(get(testActorRuntime, "key1")))
+		assert.Equal(t, `{"count":2}`, string(get(testActorRuntime, "key2")))
+		assert.Equal(t, 0, store.gets)
+
+		assert.NoError(t, testActorRuntime.DeleteState(ctx, &DeleteStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1"}))
+		assert.Nil(t, get(testActorRuntime, "key1"))
+		assert.Equal(t, 0, store.gets)
+	})
+
+	t.Run("reads are cached until the actor is deactivated", func(t *testing.T) {
+		testActorRuntime, store := newRuntime()
+		store.items[testActorRuntime.constructActorStateKey(actorType, actorID, "key1")] = []byte(`"fakeData"`)
+
+		assert.Equal(t, `"fakeData"`, string(get(testActorRuntime, "key1")))
+		assert.Equal(t, `"fakeData"`, string(get(testActorRuntime, "key1")))
+		assert.Nil(t, get(testActorRuntime, "missing"))
+		assert.Nil(t, get(testActorRuntime, "missing"))
+		assert.Equal(t, 2, store.gets)
+
+		testActorRuntime.actorsTable.Delete(testActorRuntime.constructCompositeKey(actorType, actorID))
+		assert.Equal(t, `"fakeData"`, string(get(testActorRuntime, "key1")))
+		assert.Equal(t, 3, store.gets)
+	})
+}
+
+func TestStaleReads(t *testing.T) {
+	ctx := context.Background()
+	actorType, actorID := getTestActorTypeAndID()
+	newRuntime := func() (*actorsRuntime, *countingStateStore) {
+		store := &countingStateStore{fakeStateStore: fakeStore().(*fakeStateStore)}
+		actorConfig := NewConfig("", TestAppID, "", nil, 0, "", "", "", false)
+		actorConfig.MaxStaleness = map[string]time.Duration{actorType: time.Minute}
+		testActorRuntime := NewActors(store, nil, nil, actorConfig, nil, nil, config.TracingSpec{}).(*actorsRuntime)
+		store.items[testActorRuntime.constructActorStateKey(actorType, actorID, "key1")] = []byte(`"fakeData"`)
+		return testActorRuntime, store
+	}
+	get := func(testActorRuntime *actorsRuntime, req *GetStateRequest) []byte {
+		response, err := testActorRuntime.GetState(ctx, req)
+		assert.NoError(t, err)
+		return response.Data
+	}
+
+	t.Run("reads within the budget of the actor type skip the store", func(t *testing.T) {
+		testActorRuntime, store := newRuntime()
+		req := &GetStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1"}
+
+		assert.Equal(t, `"fakeData"`, string(get(testActorRuntime, req)))
+		assert.Equal(t, `"fakeData"`, string(get(testActorRuntime, req)))
+		assert.Equal(t, 1, store.gets)
+
+		get(testActorRuntime, &GetStateRequest{ActorID: actorID, ActorType: "otherType", Key: "key1"})
+		get(testActorRuntime, &GetStateRequest{ActorID: actorID, ActorType: "otherType", Key: "key1"})
+		assert.Equal(t, 3, store.gets, "actor types without budget read the store")
+	})
+
+	t.Run("calls override the budget", func(t *testing.T) {
+		testActorRuntime, store := newRuntime()
+		get(testActorRuntime, &GetStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1"})
+
+		get(testActorRuntime, &GetStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1", Consistency: state.Strong})
+		assert.Equal(t, 2, store.gets)
+
+		time.Sleep(2 * time.Millisecond)
+		get(testActorRuntime, &GetStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1", MaxStaleness: time.Millisecond})
+		assert.Equal(t, 3, store.gets)
+	})
+
+	t.Run("writes invalidate the stale reads", func(t *testing.T) {
+		testActorRuntime, store := newRuntime()
+		req := &GetStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1"}
+		get(testActorRuntime, req)
+
+		assert.NoError(t, testActorRuntime.SaveState(ctx, &SaveStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1", Value: "newData"}))
+		assert.Equal(t, `"newData"`, string(get(testActorRuntime, req)))
+		assert.Equal(t, 2, store.gets)
+
+		assert.NoError(t, testActorRuntime.DeleteState(ctx, &DeleteStateRequest{ActorID: actorID, ActorType: actorType, Key: "key1"}))
+		assert.Nil(t, get(testActorRuntime, req))
+		assert.Equal(t, 3, store.gets)
+	})
+
+	t.Run("expired reads are evicted", func(t *testing.T) {
+		reads := newStaleReads()
+		now := time.Now()
+		reads.set("key1", []byte("data"), now, time.Second)
+
+		_, ok := reads.get("key1", time.Minute, now.Add(2*time.Second))
+		assert.False(t, ok, "reads older than the budget of their actor type are stale")
+		reads.evictExpired(now.Add(500 * time.Millisecond))
+		assert.Len(t, reads.items, 1)
+		reads.evictExpired(now.Add(time.Second))
+		assert.Empty(t, reads.items)
+	})
+}
@@ -5,7 +5,11 @@
 
 package actors
 
-import "time"
+import (
+	"time"
+
+	actor_middleware "github.com/dapr/dapr/pkg/middleware/actor"
+)
 
 // Config is the actor runtime configuration
 type Config struct {
@@ -19,6 +23,17 @@ type Config struct {
 	ActorIdleTimeout              time.Duration
 	DrainOngoingCallTimeout       time.Duration
 	DrainRebalancedActors         bool
+	LifecycleEventsTopic          string
+	// SlowTurnThreshold logs the actor turns that take longer than this when set
+	SlowTurnThreshold time.Duration
+	// Policies are the resiliency policies of actor method invocations
+	Policies []Policy
+	// Pipeline runs around the dispatch of the method calls to the actors of the app
+	Pipeline actor_middleware.Pipeline
+	// StateCache caches the state of the active actors in memory, writing through to the state store
+	StateCache bool
+	// MaxStaleness is the stale-read budget of the state reads of the actor types accepting stale reads
+	MaxStaleness map[string]time.Duration
 }
 
 const (
@@ -5,9 +5,17 @@
 
 package actors
 
+import (
+	"time"
+)
+
 // GetStateRequest is the request object for getting actor state
 type GetStateRequest struct {
 	ActorID   string `json:"actorId"`
 	ActorType string `json:"actorType"`
 	Key       string `json:"key"`
+	// Consistency is the consistency of the read, strong reads skipping the stale reads
+	Consistency string `json:"consistency,omitempty"`
+	// MaxStaleness overrides the stale-read budget of the actor type for the read
+	MaxStaleness time.Duration `json:"-"`
 }
@@ -0,0 +1,73 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/google/uuid"
+)
+
+// Actor lifecycle event types
+const (
+	ActorActivatedEvent   = "activated"
+	ActorDeactivatedEvent = "deactivated"
+	ActorRebalancedEvent  = "rebalanced"
+)
+
+// ActorLifecycleCloudEventType is the cloud event type of published actor lifecycle events
+const ActorLifecycleCloudEventType = "com.dapr.actor.lifecycle"
+
+// LifecycleEvent is published to the lifecycle events topic when an actor changes state on this host
+type LifecycleEvent struct {
+	Event     string    `json:"event"`
+	ActorType string    `json:"actorType"`
+	ActorID   string    `json:"actorId"`
+	AppID     string    `json:"appId"`
+	Host      string    `json:"host"`
+	Time      time.Time `json:"time"`
+}
+
+// publishLifecycleEvent publishes an actor lifecycle event if a lifecycle events topic is configured.
+// Events are published asynchronously so actor calls never wait on the pub/sub component.
+func (a *actorsRuntime) publishLifecycleEvent(event, actorType, actorID string) {
+	if a.config.LifecycleEventsTopic == "" || a.publishFn == nil {
+		return
+	}
+
+	e := LifecycleEvent{
+		Event:     event,
+		ActorType: actorType,
+		ActorID:   actorID,
+		AppID:     a.config.AppID,
+		Host:      a.config.HostAddress,
+		Time:      time.Now().UTC(),
+	}
+	go func() {
+		data, err := json.Marshal(e)
+		if err != nil {
+			log.Warnf("failed to serialize actor lifecycle event: %s", err)
+			return
+		}
+
+		envelope := pubsub.NewCloudEventsEnvelope(uuid.New().String(), a.config.AppID, ActorLifecycleCloudEventType, "", data)
+		b, err := json.Marshal(envelope)
+		if err != nil {
+			log.Warnf("failed to serialize actor lifecycle event: %s", err)
+			return
+		}
+
+		err = a.publishFn(&pubsub.PublishRequest{
+			Topic: a.config.LifecycleEventsTopic,
+			Data:  b,
+		})
+		if err != nil {
+			log.Warnf("failed to publish %s event for actor %s/%s: %s", event, actorType, actorID, err)
+		}
+	}()
+}
@@ -0,0 +1,21 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+// ListRemindersRequest is the request object to list the reminders of an actor, or a page of the reminders of an
+// actor type if ActorID is empty
+type ListRemindersRequest struct {
+	ActorType         string
+	ActorID           string
+	ContinuationToken string
+	Limit             int
+}
+
+// ListRemindersResponse is the response object of the reminders listing. An empty continuation token ends the listing.
+type ListRemindersResponse struct {
+	Reminders         []ActorReminder `json:"reminders"`
+	ContinuationToken string          `json:"continuationToken,omitempty"`
+}
@@ -0,0 +1,12 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+// ListTimersRequest is the request object to list the active timers of an actor
+type ListTimersRequest struct {
+	ActorType string
+	ActorID   string
+}
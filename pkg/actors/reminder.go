@@ -15,3 +15,10 @@ type Reminder struct {
 	DueTime        string      `json:"dueTime"`
 	RegisteredTime string      `json:"registeredTime,omitempty"`
 }
+
+// ActorReminder is a reminder of an actor with its schedule
+type ActorReminder struct {
+	Reminder
+	LastFiredTime string `json:"lastFiredTime,omitempty"`
+	NextFireTime  string `json:"nextFireTime,omitempty"`
+}
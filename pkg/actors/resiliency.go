@@ -0,0 +1,208 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+// ErrCircuitOpen is returned for the invocations rejected by an open circuit breaker
+var ErrCircuitOpen = errors.New("actor invocation rejected by an open circuit breaker")
+
+// Policy is the resiliency policy of the method invocations of an actor type
+type Policy struct {
+	ActorType string
+	// Method is a glob pattern of method names, matching all the methods when empty
+	Method        string
+	Timeout       time.Duration
+	MaxRetries    int
+	RetryInterval time.Duration
+	// CircuitBreakerFailures is the number of consecutive failures opening the circuit, the breaker being disabled when 0
+	CircuitBreakerFailures     int
+	CircuitBreakerOpenDuration time.Duration
+}
+
+// NewPolicies returns the policies of the actor resiliency spec
+func NewPolicies(spec config.ActorResiliency) ([]Policy, error) {
+	policies := make([]Policy, 0, len(spec.Policies))
+	for _, s := range spec.Policies {
+		p := Policy{
+			ActorType:  s.ActorType,
+			Method:     s.Method,
+			MaxRetries: s.MaxRetries,
+		}
+		if _, err := path.Match(p.Method, ""); err != nil {
+			return nil, fmt.Errorf("invalid method pattern %s of actor type %s: %s", p.Method, p.ActorType, err)
+		}
+
+		var err error
+		if p.Timeout, err = parsePolicyDuration(s.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid timeout of actor type %s: %s", p.ActorType, err)
+		}
+		if p.RetryInterval, err = parsePolicyDuration(s.RetryInterval); err != nil {
+			return nil, fmt.Errorf("invalid retry interval of actor type %s: %s", p.ActorType, err)
+		}
+		if cb := s.CircuitBreaker; cb != nil {
+			p.CircuitBreakerFailures = cb.ConsecutiveFailures
+			if p.CircuitBreakerOpenDuration, err = parsePolicyDuration(cb.OpenDuration); err != nil {
+				return nil, fmt.Errorf("invalid circuit breaker open duration of actor type %s: %s", p.ActorType, err)
+			}
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+func parsePolicyDuration(val string) (time.Duration, error) {
+	if val == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(val)
+}
+
+// matches returns whether the policy applies to the method of the actor type
+func (p Policy) matches(actorType, method string) bool {
+	if p.ActorType != actorType {
+		return false
+	}
+	if p.Method == "" {
+		return true
+	}
+	ok, _ := path.Match(p.Method, method)
+	return ok
+}
+
+// resiliency applies the first matching policy to actor invocations.
+// Each policy has its own circuit breaker, shared by the actors of its type.
+type resiliency struct {
+	policies []Policy
+	breakers []*circuitBreaker
+}
+
+func newResiliency(policies []Policy) *resiliency {
+	r := &resiliency{policies: policies}
+	for _, p := range policies {
+		r.breakers = append(r.breakers, &circuitBreaker{
+			failures:     p.CircuitBreakerFailures,
+			openDuration: p.CircuitBreakerOpenDuration,
+		})
+	}
+	return r
+}
+
+// call calls fn with the policy of the method of the actor type, or once if no policy applies
+func (r *resiliency) call(ctx context.Context, actorType, method string, fn func(ctx context.Context) (*invokev1.InvokeMethodResponse, error)) (*invokev1.InvokeMethodResponse, error) {
+	for i, p := range r.policies {
+		if p.matches(actorType, method) {
+			return r.callWithPolicy(ctx, p, r.breakers[i], fn)
+		}
+	}
+	return fn(ctx)
+}
+
+func (r *resiliency) callWithPolicy(ctx context.Context, p Policy, breaker *circuitBreaker, fn func(ctx context.Context) (*invokev1.InvokeMethodResponse, error)) (*invokev1.InvokeMethodResponse, error) {
+	var err error
+	for attempt := 0; attempt <= p.MaxRetries; attempt++ {
+		if attempt > 0 && p.RetryInterval > 0 {
+			select {
+			case <-time.After(p.RetryInterval):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		if !breaker.allow() {
+			return nil, ErrCircuitOpen
+		}
+
+		var resp *invokev1.InvokeMethodResponse
+		resp, err = attemptWithTimeout(ctx, p.Timeout, fn)
+		if ctx.Err() != nil {
+			// the caller gave up, which says nothing about the health of the actor type
+			breaker.release()
+			return resp, err
+		}
+		breaker.record(err == nil)
+		if err == nil {
+			return resp, nil
+		}
+		log.Debugf("attempt %d of actor type %s invocation failed: %s", attempt+1, p.ActorType, err)
+	}
+	return nil, err
+}
+
+func attemptWithTimeout(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (*invokev1.InvokeMethodResponse, error)) (*invokev1.InvokeMethodResponse, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+// circuitBreaker opens after consecutive failures. Once the open duration elapsed, a single trial call is let
+// through, closing the circuit when it succeeds and opening it again when it fails.
+type circuitBreaker struct {
+	failures     int
+	openDuration time.Duration
+
+	lock        sync.Mutex
+	consecutive int
+	openUntil   time.Time
+	trial       bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	if b.failures <= 0 {
+		return true
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.consecutive < b.failures {
+		return true
+	}
+	if b.trial || time.Now().Before(b.openUntil) {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *circuitBreaker) record(success bool) {
+	if b.failures <= 0 {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.trial = false
+	if success {
+		b.consecutive = 0
+		return
+	}
+	b.consecutive++
+	if b.consecutive >= b.failures {
+		b.openUntil = time.Now().Add(b.openDuration)
+	}
+}
+
+// release ends a call without recording its outcome
+func (b *circuitBreaker) release() {
+	if b.failures <= 0 {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.trial = false
+}
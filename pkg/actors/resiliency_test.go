@@ -0,0 +1,152 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPolicies(t *testing.T) {
+	t.Run("valid policies", func(t *testing.T) {
+		policies, err := NewPolicies(config.ActorResiliency{
+			Policies: []config.ActorPolicySpec{
+				{
+					ActorType:      "cart",
+					Method:         "get*",
+					Timeout:        "2s",
+					MaxRetries:     3,
+					RetryInterval:  "100ms",
+					CircuitBreaker: &config.CircuitBreakerSpec{ConsecutiveFailures: 5, OpenDuration: "30s"},
+				},
+				{ActorType: "cart"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []Policy{
+			{
+				ActorType:                  "cart",
+				Method:                     "get*",
+				Timeout:                    time.Second * 2,
+				MaxRetries:                 3,
+				RetryInterval:              time.Millisecond * 100,
+				CircuitBreakerFailures:     5,
+				CircuitBreakerOpenDuration: time.Second * 30,
+			},
+			{ActorType: "cart"},
+		}, policies)
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		_, err := NewPolicies(config.ActorResiliency{
+			Policies: []config.ActorPolicySpec{{ActorType: "cart", Timeout: "soon"}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid method pattern", func(t *testing.T) {
+		_, err := NewPolicies(config.ActorResiliency{
+			Policies: []config.ActorPolicySpec{{ActorType: "cart", Method: "[get"}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestResiliencyCall(t *testing.T) {
+	errFailed := errors.New("failed")
+	failing := func(failures int, calls *int) func(ctx context.Context) (*invokev1.InvokeMethodResponse, error) {
+		return func(ctx context.Context) (*invokev1.InvokeMethodResponse, error) {
+			*calls++
+			if *calls <= failures {
+				return nil, errFailed
+			}
+			return invokev1.NewInvokeMethodResponse(200, "OK", nil), nil
+		}
+	}
+
+	t.Run("first matching policy applies", func(t *testing.T) {
+		r := newResiliency([]Policy{
+			{ActorType: "cart", Method: "get*", MaxRetries: 2},
+			{ActorType: "cart"},
+		})
+
+		calls := 0
+		resp, err := r.call(context.Background(), "cart", "getItems", failing(2, &calls))
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, 3, calls)
+
+		calls = 0
+		_, err = r.call(context.Background(), "cart", "checkout", failing(2, &calls))
+		assert.Equal(t, errFailed, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("no matching policy", func(t *testing.T) {
+		r := newResiliency([]Policy{{ActorType: "cart", MaxRetries: 2}})
+
+		calls := 0
+		_, err := r.call(context.Background(), "order", "get", failing(1, &calls))
+		assert.Equal(t, errFailed, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("attempts time out", func(t *testing.T) {
+		r := newResiliency([]Policy{{ActorType: "cart", Timeout: time.Millisecond * 10, MaxRetries: 1}})
+
+		calls := 0
+		_, err := r.call(context.Background(), "cart", "get", func(ctx context.Context) (*invokev1.InvokeMethodResponse, error) {
+			calls++
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("circuit breaker opens after consecutive failures", func(t *testing.T) {
+		r := newResiliency([]Policy{{ActorType: "cart", CircuitBreakerFailures: 2, CircuitBreakerOpenDuration: time.Millisecond * 50}})
+
+		calls := 0
+		call := failing(2, &calls)
+		for i := 0; i < 2; i++ {
+			_, err := r.call(context.Background(), "cart", "get", call)
+			assert.Equal(t, errFailed, err)
+		}
+
+		_, err := r.call(context.Background(), "cart", "get", call)
+		assert.Equal(t, ErrCircuitOpen, err)
+		assert.Equal(t, 2, calls)
+
+		// the trial call closes the circuit
+		time.Sleep(time.Millisecond * 60)
+		_, err = r.call(context.Background(), "cart", "get", call)
+		assert.NoError(t, err)
+		_, err = r.call(context.Background(), "cart", "get", call)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, calls)
+	})
+}
+
+func TestCircuitBreakerTrialFailure(t *testing.T) {
+	b := &circuitBreaker{failures: 1, openDuration: time.Millisecond * 20}
+	assert.True(t, b.allow())
+	b.record(false)
+	assert.False(t, b.allow())
+
+	time.Sleep(time.Millisecond * 30)
+	assert.True(t, b.allow())
+	// a single trial call is let through
+	assert.False(t, b.allow())
+	b.record(false)
+	assert.False(t, b.allow())
+}
@@ -0,0 +1,65 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"sync"
+	"time"
+)
+
+// staleReads holds the state read from the store by the actor types accepting stale reads, with the time of the
+// read. Unlike the state cache it isn't limited to the active actors, so the entries are evicted once older than the
+// stale-read budget of their actor type.
+type staleReads struct {
+	lock  sync.RWMutex
+	items map[string]staleRead
+}
+
+type staleRead struct {
+	data    []byte
+	readAt  time.Time
+	expires time.Time
+}
+
+func newStaleReads() *staleReads {
+	return &staleReads{items: map[string]staleRead{}}
+}
+
+// get returns the data of the key when it was read at most maxStaleness before now
+func (s *staleReads) get(key string, maxStaleness time.Duration, now time.Time) ([]byte, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	r, ok := s.items[key]
+	if !ok || now.Sub(r.readAt) > maxStaleness || !now.Before(r.expires) {
+		return nil, false
+	}
+	return r.data, true
+}
+
+// set records the data of the key read from the store at readAt, kept for the budget of its actor type
+func (s *staleReads) set(key string, data []byte, readAt time.Time, budget time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.items[key] = staleRead{data: data, readAt: readAt, expires: readAt.Add(budget)}
+}
+
+// invalidate drops the key, written by this host, so that its reads don't return the previous value
+func (s *staleReads) invalidate(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.items, key)
+}
+
+// evictExpired drops the entries older than the budget of their actor type
+func (s *staleReads) evictExpired(now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for key, r := range s.items {
+		if !now.Before(r.expires) {
+			delete(s.items, key)
+		}
+	}
+}
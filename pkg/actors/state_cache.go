@@ -0,0 +1,55 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// stateCache holds the state of an active actor read from, or written through to, the state store. Keys missing in
+// the store are cached with nil data.
+type stateCache struct {
+	lock  sync.RWMutex
+	items map[string][]byte
+}
+
+func newStateCache() *stateCache {
+	return &stateCache{items: map[string][]byte{}}
+}
+
+func (c *stateCache) get(key string) ([]byte, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	data, ok := c.items[key]
+	return data, ok
+}
+
+func (c *stateCache) set(key string, data []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.items[key] = data
+}
+
+// setValue caches a value written to the store as the store returns it, raw when it's bytes and JSON otherwise
+func (c *stateCache) setValue(key string, value interface{}) {
+	data, ok := value.([]byte)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			c.invalidate(key)
+			return
+		}
+	}
+	c.set(key, data)
+}
+
+// invalidate drops the key, read from the store again on its next read
+func (c *stateCache) invalidate(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.items, key)
+}
@@ -0,0 +1,18 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+// ActorTimer is an active timer of an actor with its schedule
+type ActorTimer struct {
+	ActorID        string `json:"actorID"`
+	ActorType      string `json:"actorType"`
+	Name           string `json:"name"`
+	Callback       string `json:"callback"`
+	Period         string `json:"period"`
+	DueTime        string `json:"dueTime"`
+	RegisteredTime string `json:"registeredTime"`
+	NextFireTime   string `json:"nextFireTime,omitempty"`
+}
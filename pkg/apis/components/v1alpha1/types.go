@@ -10,7 +10,6 @@ import (
 )
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // Component describes an Dapr component type
@@ -24,11 +23,19 @@ type Component struct {
 	Auth `json:"auth,omitempty"`
 	// +optional
 	Scopes []string `json:"scopes,omitempty"`
+	// Residency is the region the data of the component resides in
+	// +optional
+	Residency string `json:"residency,omitempty"`
+	// +optional
+	Status ComponentStatus `json:"status,omitempty"`
 }
 
 // ComponentSpec is the spec for a component
 type ComponentSpec struct {
-	Type     string         `json:"type"`
+	Type string `json:"type"`
+	// Version is the version of the implementation of the type, e.g. v2. Defaults to v1.
+	// +optional
+	Version  string         `json:"version,omitempty"`
 	Metadata []MetadataItem `json:"metadata"`
 }
 
@@ -50,6 +57,25 @@ type Auth struct {
 	SecretStore string `json:"secretStore"`
 }
 
+// ComponentStatus is the adoption of the component by the sidecars, as they report it to the operator
+type ComponentStatus struct {
+	// Ready is the number of sidecars that loaded the component
+	Ready int `json:"ready"`
+	// Failed is the number of sidecars that failed to load the component
+	Failed int `json:"failed"`
+	// +optional
+	Sidecars []SidecarStatus `json:"sidecars,omitempty"`
+}
+
+// SidecarStatus is the outcome of loading the component in the sidecar of a pod
+type SidecarStatus struct {
+	Pod    string `json:"pod"`
+	AppID  string `json:"appID"`
+	Loaded bool   `json:"loaded"`
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ComponentList is a list of Dapr components
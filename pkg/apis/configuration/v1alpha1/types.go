@@ -27,9 +27,43 @@ type ConfigurationSpec struct {
 	// +optional
 	HTTPPipelineSpec PipelineSpec `json:"httpPipeline,omitempty"`
 	// +optional
+	ActorPipelineSpec PipelineSpec `json:"actorPipeline,omitempty"`
+	// +optional
 	TracingSpec TracingSpec `json:"tracing,omitempty"`
 	// +optional
 	MTLSSpec MTLSSpec `json:"mtls,omitempty"`
+	// +optional
+	StartupSpec StartupSpec `json:"startup,omitempty"`
+	// +optional
+	ActorLifecycleSpec ActorLifecycleSpec `json:"actorLifecycle,omitempty"`
+	// +optional
+	ActorTurnsSpec ActorTurnsSpec `json:"actorTurns,omitempty"`
+	// +optional
+	ActorStateSpec ActorStateSpec `json:"actorState,omitempty"`
+	// +optional
+	GRPCServerSpec GRPCServerSpec `json:"grpcServer,omitempty"`
+	// +optional
+	GRPCClientSpec GRPCClientSpec `json:"grpcClient,omitempty"`
+	// +optional
+	NameResolutionSpec NameResolutionSpec `json:"nameResolution,omitempty"`
+	// +optional
+	PubSubSpec PubSubSpec `json:"pubsub,omitempty"`
+	// +optional
+	InvocationSpec InvocationSpec `json:"serviceInvocation,omitempty"`
+	// +optional
+	DataResidencySpec DataResidencySpec `json:"dataResidency,omitempty"`
+	// +optional
+	ActorResiliency ActorResiliency `json:"actorResiliency,omitempty"`
+	// +optional
+	Bulkheads []BulkheadSpec `json:"bulkheads,omitempty"`
+	// +optional
+	JSONSpec JSONSpec `json:"json,omitempty"`
+	// +optional
+	MemoryBudgetSpec MemoryBudgetSpec `json:"memoryBudget,omitempty"`
+	// +optional
+	EgressSpec EgressSpec `json:"egress,omitempty"`
+	// +optional
+	SecretsSpec SecretsSpec `json:"secrets,omitempty"`
 }
 
 // PipelineSpec defines the middleware pipeline
@@ -65,6 +99,351 @@ type SelectorField struct {
 // TracingSpec is the spec object in ConfigurationSpec
 type TracingSpec struct {
 	SamplingRate string `json:"samplingRate"`
+	// +optional
+	Propagators []string `json:"propagators,omitempty"`
+	// +optional
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// GRPCServerSpec defines the limits of the public API and internal gRPC servers
+type GRPCServerSpec struct {
+	// +optional
+	API GRPCServerLimits `json:"api,omitempty"`
+	// +optional
+	Internal GRPCServerLimits `json:"internal,omitempty"`
+	// +optional
+	DrainGracePeriod string `json:"drainGracePeriod,omitempty"`
+	// +optional
+	CallLocal CallLocalSpec `json:"callLocal,omitempty"`
+	// +optional
+	InvokePayload InvokePayloadSpec `json:"invokePayload,omitempty"`
+}
+
+// InvokePayloadSpec defines the limits of the Any payloads of the invocations from other sidecars
+type InvokePayloadSpec struct {
+	// +optional
+	MaxDepth int `json:"maxDepth,omitempty"`
+	// +optional
+	MaxSize string `json:"maxSize,omitempty"`
+}
+
+// CallLocalSpec defines the flow control of the calls to the app from other sidecars
+type CallLocalSpec struct {
+	// +optional
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// +optional
+	MaxConcurrencyPerPeer int `json:"maxConcurrencyPerPeer,omitempty"`
+	// +optional
+	MaxQueuedPerPeer int `json:"maxQueuedPerPeer,omitempty"`
+	// +optional
+	AllowedCallers []string `json:"allowedCallers,omitempty"`
+	// +optional
+	RequireTarget bool `json:"requireTarget,omitempty"`
+}
+
+// GRPCClientSpec defines the connection timeouts of the gRPC clients calling other Dapr sidecars
+type GRPCClientSpec struct {
+	// +optional
+	DialTimeout string `json:"dialTimeout,omitempty"`
+	// +optional
+	HandshakeTimeout string `json:"handshakeTimeout,omitempty"`
+	// +optional
+	BlacklistDuration string `json:"blacklistDuration,omitempty"`
+}
+
+// GRPCServerLimits defines the stream and connection limits of a gRPC server
+type GRPCServerLimits struct {
+	// +optional
+	MaxConcurrentStreams uint32 `json:"maxConcurrentStreams,omitempty"`
+	// +optional
+	MaxConnections int `json:"maxConnections,omitempty"`
+	// +optional
+	MaxConnectionsPerPeer int `json:"maxConnectionsPerPeer,omitempty"`
+	// +optional
+	MaxMessageSize string `json:"maxMessageSize,omitempty"`
+}
+
+// NameResolutionSpec configures how app ids are resolved to the addresses of their sidecars
+type NameResolutionSpec struct {
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+	// +optional
+	DNS DNSResolverSpec `json:"dns,omitempty"`
+	// +optional
+	Consul ConsulResolverSpec `json:"consul,omitempty"`
+}
+
+// DNSResolverSpec configures the DNS name resolver
+type DNSResolverSpec struct {
+	// +optional
+	Template string `json:"template,omitempty"`
+	// +optional
+	SRV bool `json:"srv,omitempty"`
+	// +optional
+	Servers []string `json:"servers,omitempty"`
+}
+
+// ConsulResolverSpec configures the Consul name resolver and the registration of the sidecar
+type ConsulResolverSpec struct {
+	// +optional
+	Address string `json:"address,omitempty"`
+	// +optional
+	Datacenter string `json:"datacenter,omitempty"`
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// +optional
+	CheckInterval string `json:"checkInterval,omitempty"`
+	// +optional
+	DeregisterCriticalServiceAfter string `json:"deregisterCriticalServiceAfter,omitempty"`
+	// +optional
+	SkipRegistration bool `json:"skipRegistration,omitempty"`
+}
+
+// PubSubSpec defines publishing to the pub/sub components
+type PubSubSpec struct {
+	// +optional
+	FanOut []FanOutSpec `json:"fanOut,omitempty"`
+	// +optional
+	DualRead DualReadSpec `json:"dualRead,omitempty"`
+	// +optional
+	Transforms []TransformSpec `json:"transforms,omitempty"`
+	// +optional
+	ExactlyOnce ExactlyOnceSpec `json:"exactlyOnce,omitempty"`
+	// +optional
+	MaxRetryAfter string `json:"maxRetryAfter,omitempty"`
+}
+
+// DualReadSpec defines the second pub/sub component subscriptions are read from
+type DualReadSpec struct {
+	// +optional
+	PubSub string `json:"pubsub,omitempty"`
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+	// +optional
+	DeduplicationWindow string `json:"deduplicationWindow,omitempty"`
+}
+
+// ExactlyOnceSpec defines the state store the ids of the events delivered to the app are deduplicated with
+type ExactlyOnceSpec struct {
+	// +optional
+	StateStore string `json:"stateStore,omitempty"`
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+	// +optional
+	Window string `json:"window,omitempty"`
+}
+
+// FanOutSpec defines the pub/sub components and topics the events of a topic are published to
+type FanOutSpec struct {
+	Topic   string          `json:"topic"`
+	Targets []PublishTarget `json:"targets"`
+}
+
+// TransformSpec defines the template the data of the events of a topic is rewritten with before it's published or delivered
+type TransformSpec struct {
+	Topic    string `json:"topic"`
+	Stage    string `json:"stage"`
+	Template string `json:"template"`
+}
+
+// PublishTarget defines a pub/sub component and topic events are published to
+type PublishTarget struct {
+	// +optional
+	PubSub string `json:"pubsub,omitempty"`
+	// +optional
+	Topic string `json:"topic,omitempty"`
+}
+
+// DataResidencySpec defines the regions of the components an app may use
+type DataResidencySpec struct {
+	// +optional
+	Region string `json:"region,omitempty"`
+	// +optional
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+	// +optional
+	Policy string `json:"policy,omitempty"`
+}
+
+// InvocationSpec defines the service invocation of other apps
+type InvocationSpec struct {
+	// +optional
+	PriorityClasses []PriorityClassSpec `json:"priorityClasses,omitempty"`
+	// +optional
+	HTTPMappings []HTTPMappingSpec `json:"httpMappings,omitempty"`
+	// +optional
+	AdaptiveConcurrency AdaptiveConcurrencySpec `json:"adaptiveConcurrency,omitempty"`
+	// +optional
+	GRPCProxy bool `json:"grpcProxy,omitempty"`
+}
+
+// AdaptiveConcurrencySpec defines the limit of the concurrent calls to the app adapted to its latency
+type AdaptiveConcurrencySpec struct {
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+	// +optional
+	InitialLimit int `json:"initialLimit,omitempty"`
+	// +optional
+	MinLimit int `json:"minLimit,omitempty"`
+	// +optional
+	MaxLimit int `json:"maxLimit,omitempty"`
+	// +optional
+	LatencyThreshold string `json:"latencyThreshold,omitempty"`
+	// +optional
+	BackoffPercent int `json:"backoffPercent,omitempty"`
+}
+
+// PriorityClassSpec defines the concurrency limit of a priority class of invocations
+type PriorityClassSpec struct {
+	Name string `json:"name"`
+	// +optional
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+}
+
+// HTTPMappingSpec defines the HTTP request of the invocations of a method without an HTTP verb
+type HTTPMappingSpec struct {
+	Method string `json:"method"`
+	Verb   string `json:"verb"`
+	Path   string `json:"path"`
+	// +optional
+	Query map[string]string `json:"query,omitempty"`
+	// +optional
+	Body string `json:"body,omitempty"`
+}
+
+// ActorLifecycleSpec configures publishing of actor lifecycle events
+type ActorLifecycleSpec struct {
+	// +optional
+	Topic string `json:"topic,omitempty"`
+}
+
+// ActorTurnsSpec defines the monitoring of actor turns
+type ActorTurnsSpec struct {
+	// +optional
+	SlowTurnThreshold string `json:"slowTurnThreshold,omitempty"`
+}
+
+// ActorStateSpec defines the caching of the state of the actors
+type ActorStateSpec struct {
+	// +optional
+	Cache bool `json:"cache,omitempty"`
+	// +optional
+	StaleReads []ActorStaleReadSpec `json:"staleReads,omitempty"`
+}
+
+// ActorStaleReadSpec defines the stale-read budget of the state reads of an actor type
+type ActorStaleReadSpec struct {
+	ActorType    string `json:"actorType"`
+	MaxStaleness string `json:"maxStaleness"`
+}
+
+// ActorResiliency defines the timeouts, retries and circuit breakers of actor method invocations
+type ActorResiliency struct {
+	// +optional
+	Policies []ActorPolicySpec `json:"policies,omitempty"`
+}
+
+// ActorPolicySpec defines the resiliency policy of the methods of an actor type
+type ActorPolicySpec struct {
+	ActorType string `json:"actorType"`
+	// +optional
+	Method string `json:"method,omitempty"`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+	// +optional
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// +optional
+	RetryInterval string `json:"retryInterval,omitempty"`
+	// +optional
+	CircuitBreaker *CircuitBreakerSpec `json:"circuitBreaker,omitempty"`
+}
+
+// CircuitBreakerSpec defines when the circuit breaker of an actor policy opens
+type CircuitBreakerSpec struct {
+	ConsecutiveFailures int    `json:"consecutiveFailures"`
+	OpenDuration        string `json:"openDuration"`
+}
+
+// BulkheadSpec defines the worker pool of the API calls of a building block
+type BulkheadSpec struct {
+	BuildingBlock  string `json:"buildingBlock"`
+	MaxConcurrency int    `json:"maxConcurrency"`
+	// +optional
+	MaxQueued int `json:"maxQueued,omitempty"`
+	// +optional
+	QueueTimeout string `json:"queueTimeout,omitempty"`
+}
+
+// JSONSpec defines the JSON serialization of the API envelopes and CloudEvents
+type JSONSpec struct {
+	// +optional
+	PreserveInt64 bool `json:"preserveInt64,omitempty"`
+	// +optional
+	EscapeHTML bool `json:"escapeHTML,omitempty"`
+	// +optional
+	UnknownFields string `json:"unknownFields,omitempty"`
+}
+
+// MemoryBudgetSpec defines the memory budget of the sidecar and its protection under memory pressure
+type MemoryBudgetSpec struct {
+	// +optional
+	Limit string `json:"limit,omitempty"`
+	// +optional
+	PressurePercent int `json:"pressurePercent,omitempty"`
+	// +optional
+	CriticalPercent int `json:"criticalPercent,omitempty"`
+	// +optional
+	MaxPayloadSize string `json:"maxPayloadSize,omitempty"`
+	// +optional
+	CheckInterval string `json:"checkInterval,omitempty"`
+	// +optional
+	Topic string `json:"topic,omitempty"`
+}
+
+// EgressSpec defines the destinations the output bindings of an app may reach, a best-effort restriction
+type EgressSpec struct {
+	// +optional
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+}
+
+// SecretsSpec defines the secrets an app may read from its secret stores
+type SecretsSpec struct {
+	// +optional
+	Scopes []SecretsScope `json:"scopes,omitempty"`
+}
+
+// SecretsScope defines the secrets an app may read from a secret store
+type SecretsScope struct {
+	StoreName string `json:"storeName"`
+	// +optional
+	DefaultAccess string `json:"defaultAccess,omitempty"`
+	// +optional
+	AllowedSecrets []string `json:"allowedSecrets,omitempty"`
+	// +optional
+	DeniedSecrets []string `json:"deniedSecrets,omitempty"`
+}
+
+// StartupSpec defines the startup policy of the runtime subsystems
+type StartupSpec struct {
+	// +optional
+	Placement string `json:"placement,omitempty"`
+	// +optional
+	Operator string `json:"operator,omitempty"`
+	// +optional
+	AppChannel string `json:"appChannel,omitempty"`
+	// +optional
+	Components []ComponentStartupSpec `json:"components,omitempty"`
+	// +optional
+	RetryInterval string `json:"retryInterval,omitempty"`
+	// +optional
+	WaitForAppHealth string `json:"waitForAppHealth,omitempty"`
+}
+
+// ComponentStartupSpec defines the startup policy of a single component
+type ComponentStartupSpec struct {
+	Name   string `json:"name"`
+	Policy string `json:"policy"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
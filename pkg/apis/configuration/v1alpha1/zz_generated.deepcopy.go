@@ -51,6 +51,204 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActorLifecycleSpec) DeepCopyInto(out *ActorLifecycleSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActorLifecycleSpec.
+func (in *ActorLifecycleSpec) DeepCopy() *ActorLifecycleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActorLifecycleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActorPolicySpec) DeepCopyInto(out *ActorPolicySpec) {
+	*out = *in
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActorPolicySpec.
+func (in *ActorPolicySpec) DeepCopy() *ActorPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActorPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActorResiliency) DeepCopyInto(out *ActorResiliency) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ActorPolicySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActorResiliency.
+func (in *ActorResiliency) DeepCopy() *ActorResiliency {
+	if in == nil {
+		return nil
+	}
+	out := new(ActorResiliency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActorStaleReadSpec) DeepCopyInto(out *ActorStaleReadSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActorStaleReadSpec.
+func (in *ActorStaleReadSpec) DeepCopy() *ActorStaleReadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActorStaleReadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActorStateSpec) DeepCopyInto(out *ActorStateSpec) {
+	*out = *in
+	if in.StaleReads != nil {
+		in, out := &in.StaleReads, &out.StaleReads
+		*out = make([]ActorStaleReadSpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActorStateSpec.
+func (in *ActorStateSpec) DeepCopy() *ActorStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActorStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActorTurnsSpec) DeepCopyInto(out *ActorTurnsSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActorTurnsSpec.
+func (in *ActorTurnsSpec) DeepCopy() *ActorTurnsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActorTurnsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveConcurrencySpec) DeepCopyInto(out *AdaptiveConcurrencySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveConcurrencySpec.
+func (in *AdaptiveConcurrencySpec) DeepCopy() *AdaptiveConcurrencySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveConcurrencySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkheadSpec) DeepCopyInto(out *BulkheadSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkheadSpec.
+func (in *BulkheadSpec) DeepCopy() *BulkheadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkheadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CallLocalSpec) DeepCopyInto(out *CallLocalSpec) {
+	*out = *in
+	if in.AllowedCallers != nil {
+		in, out := &in.AllowedCallers, &out.AllowedCallers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CallLocalSpec.
+func (in *CallLocalSpec) DeepCopy() *CallLocalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CallLocalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerSpec) DeepCopyInto(out *CircuitBreakerSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerSpec.
+func (in *CircuitBreakerSpec) DeepCopy() *CircuitBreakerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStartupSpec) DeepCopyInto(out *ComponentStartupSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStartupSpec.
+func (in *ComponentStartupSpec) DeepCopy() *ComponentStartupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStartupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigurationList) DeepCopyInto(out *ConfigurationList) {
 	*out = *in
@@ -88,8 +286,29 @@ func (in *ConfigurationList) DeepCopyObject() runtime.Object {
 func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	*out = *in
 	in.HTTPPipelineSpec.DeepCopyInto(&out.HTTPPipelineSpec)
-	out.TracingSpec = in.TracingSpec
+	in.ActorPipelineSpec.DeepCopyInto(&out.ActorPipelineSpec)
+	in.TracingSpec.DeepCopyInto(&out.TracingSpec)
 	out.MTLSSpec = in.MTLSSpec
+	in.StartupSpec.DeepCopyInto(&out.StartupSpec)
+	out.ActorLifecycleSpec = in.ActorLifecycleSpec
+	out.ActorTurnsSpec = in.ActorTurnsSpec
+	in.ActorStateSpec.DeepCopyInto(&out.ActorStateSpec)
+	in.GRPCServerSpec.DeepCopyInto(&out.GRPCServerSpec)
+	out.GRPCClientSpec = in.GRPCClientSpec
+	in.NameResolutionSpec.DeepCopyInto(&out.NameResolutionSpec)
+	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	in.InvocationSpec.DeepCopyInto(&out.InvocationSpec)
+	in.DataResidencySpec.DeepCopyInto(&out.DataResidencySpec)
+	in.ActorResiliency.DeepCopyInto(&out.ActorResiliency)
+	if in.Bulkheads != nil {
+		in, out := &in.Bulkheads, &out.Bulkheads
+		*out = make([]BulkheadSpec, len(*in))
+		copy(*out, *in)
+	}
+	out.JSONSpec = in.JSONSpec
+	out.MemoryBudgetSpec = in.MemoryBudgetSpec
+	in.EgressSpec.DeepCopyInto(&out.EgressSpec)
+	in.SecretsSpec.DeepCopyInto(&out.SecretsSpec)
 	return
 }
 
@@ -103,6 +322,233 @@ func (in *ConfigurationSpec) DeepCopy() *ConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulResolverSpec) DeepCopyInto(out *ConsulResolverSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulResolverSpec.
+func (in *ConsulResolverSpec) DeepCopy() *ConsulResolverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulResolverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSResolverSpec) DeepCopyInto(out *DNSResolverSpec) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSResolverSpec.
+func (in *DNSResolverSpec) DeepCopy() *DNSResolverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSResolverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataResidencySpec) DeepCopyInto(out *DataResidencySpec) {
+	*out = *in
+	if in.AllowedRegions != nil {
+		in, out := &in.AllowedRegions, &out.AllowedRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataResidencySpec.
+func (in *DataResidencySpec) DeepCopy() *DataResidencySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataResidencySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DualReadSpec) DeepCopyInto(out *DualReadSpec) {
+	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DualReadSpec.
+func (in *DualReadSpec) DeepCopy() *DualReadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DualReadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressSpec) DeepCopyInto(out *EgressSpec) {
+	*out = *in
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressSpec.
+func (in *EgressSpec) DeepCopy() *EgressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExactlyOnceSpec) DeepCopyInto(out *ExactlyOnceSpec) {
+	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExactlyOnceSpec.
+func (in *ExactlyOnceSpec) DeepCopy() *ExactlyOnceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExactlyOnceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FanOutSpec) DeepCopyInto(out *FanOutSpec) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]PublishTarget, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FanOutSpec.
+func (in *FanOutSpec) DeepCopy() *FanOutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FanOutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCClientSpec) DeepCopyInto(out *GRPCClientSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCClientSpec.
+func (in *GRPCClientSpec) DeepCopy() *GRPCClientSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCClientSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCServerLimits) DeepCopyInto(out *GRPCServerLimits) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCServerLimits.
+func (in *GRPCServerLimits) DeepCopy() *GRPCServerLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCServerLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCServerSpec) DeepCopyInto(out *GRPCServerSpec) {
+	*out = *in
+	out.API = in.API
+	out.Internal = in.Internal
+	in.CallLocal.DeepCopyInto(&out.CallLocal)
+	out.InvokePayload = in.InvokePayload
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCServerSpec.
+func (in *GRPCServerSpec) DeepCopy() *GRPCServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPMappingSpec) DeepCopyInto(out *HTTPMappingSpec) {
+	*out = *in
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPMappingSpec.
+func (in *HTTPMappingSpec) DeepCopy() *HTTPMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HandlerSpec) DeepCopyInto(out *HandlerSpec) {
 	*out = *in
@@ -120,6 +566,67 @@ func (in *HandlerSpec) DeepCopy() *HandlerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvocationSpec) DeepCopyInto(out *InvocationSpec) {
+	*out = *in
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = make([]PriorityClassSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPMappings != nil {
+		in, out := &in.HTTPMappings, &out.HTTPMappings
+		*out = make([]HTTPMappingSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.AdaptiveConcurrency = in.AdaptiveConcurrency
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvocationSpec.
+func (in *InvocationSpec) DeepCopy() *InvocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InvocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvokePayloadSpec) DeepCopyInto(out *InvokePayloadSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvokePayloadSpec.
+func (in *InvokePayloadSpec) DeepCopy() *InvokePayloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InvokePayloadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSpec) DeepCopyInto(out *JSONSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONSpec.
+func (in *JSONSpec) DeepCopy() *JSONSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
 	*out = *in
@@ -136,6 +643,40 @@ func (in *MTLSSpec) DeepCopy() *MTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryBudgetSpec) DeepCopyInto(out *MemoryBudgetSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryBudgetSpec.
+func (in *MemoryBudgetSpec) DeepCopy() *MemoryBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NameResolutionSpec) DeepCopyInto(out *NameResolutionSpec) {
+	*out = *in
+	in.DNS.DeepCopyInto(&out.DNS)
+	in.Consul.DeepCopyInto(&out.Consul)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NameResolutionSpec.
+func (in *NameResolutionSpec) DeepCopy() *NameResolutionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NameResolutionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 	*out = *in
@@ -159,6 +700,117 @@ func (in *PipelineSpec) DeepCopy() *PipelineSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityClassSpec) DeepCopyInto(out *PriorityClassSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityClassSpec.
+func (in *PriorityClassSpec) DeepCopy() *PriorityClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PubSubSpec) DeepCopyInto(out *PubSubSpec) {
+	*out = *in
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = make([]FanOutSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.DualRead.DeepCopyInto(&out.DualRead)
+	if in.Transforms != nil {
+		in, out := &in.Transforms, &out.Transforms
+		*out = make([]TransformSpec, len(*in))
+		copy(*out, *in)
+	}
+	in.ExactlyOnce.DeepCopyInto(&out.ExactlyOnce)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PubSubSpec.
+func (in *PubSubSpec) DeepCopy() *PubSubSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PubSubSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishTarget) DeepCopyInto(out *PublishTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishTarget.
+func (in *PublishTarget) DeepCopy() *PublishTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsScope) DeepCopyInto(out *SecretsScope) {
+	*out = *in
+	if in.AllowedSecrets != nil {
+		in, out := &in.AllowedSecrets, &out.AllowedSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedSecrets != nil {
+		in, out := &in.DeniedSecrets, &out.DeniedSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsScope.
+func (in *SecretsScope) DeepCopy() *SecretsScope {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsSpec) DeepCopyInto(out *SecretsSpec) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]SecretsScope, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsSpec.
+func (in *SecretsSpec) DeepCopy() *SecretsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorField) DeepCopyInto(out *SelectorField) {
 	*out = *in
@@ -196,9 +848,40 @@ func (in *SelectorSpec) DeepCopy() *SelectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartupSpec) DeepCopyInto(out *StartupSpec) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStartupSpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupSpec.
+func (in *StartupSpec) DeepCopy() *StartupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StartupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracingSpec) DeepCopyInto(out *TracingSpec) {
 	*out = *in
+	if in.Propagators != nil {
+		in, out := &in.Propagators, &out.Propagators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -211,3 +894,19 @@ func (in *TracingSpec) DeepCopy() *TracingSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformSpec) DeepCopyInto(out *TransformSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformSpec.
+func (in *TransformSpec) DeepCopy() *TransformSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,136 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bulkhead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+// Reasons of rejected calls
+const (
+	rejectedQueueFull    = "queue_full"
+	rejectedQueueTimeout = "queue_timeout"
+)
+
+// ErrFull is returned for the calls rejected by a bulkhead whose workers are busy
+var ErrFull = errors.New("bulkhead is full")
+
+// Bulkhead is a bounded worker pool of the calls of a building block. Calls wait in a bounded queue while all the
+// workers are busy, and are rejected once the queue is full or they waited for the queue timeout.
+// A nil bulkhead is unlimited.
+type Bulkhead struct {
+	name         string
+	workers      chan struct{}
+	maxQueued    int
+	queueTimeout time.Duration
+
+	lock   sync.Mutex
+	queued int
+}
+
+// New returns a bulkhead with maxConcurrency workers
+func New(name string, maxConcurrency, maxQueued int, queueTimeout time.Duration) *Bulkhead {
+	return &Bulkhead{
+		name:         name,
+		workers:      make(chan struct{}, maxConcurrency),
+		maxQueued:    maxQueued,
+		queueTimeout: queueTimeout,
+	}
+}
+
+// Acquire waits for a worker. It returns ErrFull if the call is rejected, or the error of the context if it's done first.
+// Calls that acquired a worker must release it.
+func (b *Bulkhead) Acquire(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	select {
+	case b.workers <- struct{}{}:
+		b.record(0)
+		return nil
+	default:
+	}
+
+	b.lock.Lock()
+	if b.queued >= b.maxQueued {
+		b.lock.Unlock()
+		diag.DefaultMonitoring.BulkheadRejected(b.name, rejectedQueueFull)
+		return ErrFull
+	}
+	b.queued++
+	b.lock.Unlock()
+	b.record(0)
+	defer b.record(-1)
+
+	var timeout <-chan time.Time
+	if b.queueTimeout > 0 {
+		timer := time.NewTimer(b.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case b.workers <- struct{}{}:
+		return nil
+	case <-timeout:
+		diag.DefaultMonitoring.BulkheadRejected(b.name, rejectedQueueTimeout)
+		return ErrFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release releases the worker of a call
+func (b *Bulkhead) Release() {
+	if b == nil {
+		return
+	}
+	<-b.workers
+	b.record(0)
+}
+
+// record updates the number of queued calls by delta and records the state of the bulkhead
+func (b *Bulkhead) record(delta int) {
+	b.lock.Lock()
+	b.queued += delta
+	queued := b.queued
+	b.lock.Unlock()
+	diag.DefaultMonitoring.BulkheadChanged(b.name, int64(len(b.workers)), int64(queued))
+}
+
+// Bulkheads are the bulkheads of the building blocks, by building block
+type Bulkheads map[string]*Bulkhead
+
+// NewBulkheads returns the bulkheads of the spec
+func NewBulkheads(specs []config.BulkheadSpec) (Bulkheads, error) {
+	bulkheads := Bulkheads{}
+	for _, s := range specs {
+		if s.MaxConcurrency <= 0 {
+			return nil, fmt.Errorf("max concurrency of the %s bulkhead is not positive", s.BuildingBlock)
+		}
+		var queueTimeout time.Duration
+		if s.QueueTimeout != "" {
+			d, err := time.ParseDuration(s.QueueTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("invalid queue timeout of the %s bulkhead: %s", s.BuildingBlock, err)
+			}
+			queueTimeout = d
+		}
+		bulkheads[s.BuildingBlock] = New(s.BuildingBlock, s.MaxConcurrency, s.MaxQueued, queueTimeout)
+	}
+	return bulkheads, nil
+}
+
+// For returns the bulkhead of a building block, nil when it has none
+func (b Bulkheads) For(buildingBlock string) *Bulkhead {
+	return b[buildingBlock]
+}
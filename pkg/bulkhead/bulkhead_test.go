@@ -0,0 +1,81 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bulkhead
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkhead(t *testing.T) {
+	t.Run("nil bulkhead is unlimited", func(t *testing.T) {
+		var b *Bulkhead
+		assert.NoError(t, b.Acquire(context.Background()))
+		b.Release()
+	})
+
+	t.Run("calls are rejected when the workers are busy and there's no queue", func(t *testing.T) {
+		b := New("state", 1, 0, 0)
+		assert.NoError(t, b.Acquire(context.Background()))
+		assert.Equal(t, ErrFull, b.Acquire(context.Background()))
+
+		b.Release()
+		assert.NoError(t, b.Acquire(context.Background()))
+	})
+
+	t.Run("queued calls wait for a worker", func(t *testing.T) {
+		b := New("state", 1, 1, 0)
+		assert.NoError(t, b.Acquire(context.Background()))
+
+		acquired := make(chan error)
+		go func() {
+			acquired <- b.Acquire(context.Background())
+		}()
+		assert.Eventually(t, func() bool {
+			b.lock.Lock()
+			defer b.lock.Unlock()
+			return b.queued == 1
+		}, time.Second, 10*time.Millisecond)
+		// the queue is full
+		assert.Equal(t, ErrFull, b.Acquire(context.Background()))
+
+		b.Release()
+		assert.NoError(t, <-acquired)
+	})
+
+	t.Run("queued calls time out", func(t *testing.T) {
+		b := New("state", 1, 1, time.Millisecond*10)
+		assert.NoError(t, b.Acquire(context.Background()))
+		assert.Equal(t, ErrFull, b.Acquire(context.Background()))
+		assert.Zero(t, b.queued)
+	})
+
+	t.Run("queued calls are cancelled", func(t *testing.T) {
+		b := New("state", 1, 1, 0)
+		assert.NoError(t, b.Acquire(context.Background()))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Equal(t, context.Canceled, b.Acquire(ctx))
+	})
+}
+
+func TestNewBulkheads(t *testing.T) {
+	bulkheads, err := NewBulkheads([]config.BulkheadSpec{
+		{BuildingBlock: config.BulkheadState, MaxConcurrency: 2, QueueTimeout: "1s"},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, bulkheads.For(config.BulkheadState))
+	assert.Nil(t, bulkheads.For(config.BulkheadInvocation))
+
+	_, err = NewBulkheads([]config.BulkheadSpec{{BuildingBlock: config.BulkheadState}})
+	assert.Error(t, err)
+	_, err = NewBulkheads([]config.BulkheadSpec{{BuildingBlock: config.BulkheadState, MaxConcurrency: 1, QueueTimeout: "soon"}})
+	assert.Error(t, err)
+}
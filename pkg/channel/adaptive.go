@@ -0,0 +1,73 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package channel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dapr/dapr/pkg/concurrency"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"google.golang.org/grpc/codes"
+)
+
+// adaptiveChannel limits the concurrent calls to the app with a limit adapted to its latency
+type adaptiveChannel struct {
+	AppChannel
+	limiter *concurrency.Limiter
+}
+
+// WithAdaptiveConcurrency returns a channel whose calls wait for the limiter. It returns ch when the limiter is nil.
+func WithAdaptiveConcurrency(ch AppChannel, limiter *concurrency.Limiter) AppChannel {
+	if limiter == nil {
+		return ch
+	}
+	return &adaptiveChannel{AppChannel: ch, limiter: limiter}
+}
+
+func (a *adaptiveChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	release, err := a.limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	rsp, err := a.AppChannel.InvokeMethod(ctx, req)
+	release(time.Since(start), overloaded(rsp, err))
+	return rsp, err
+}
+
+// InvokeMethodStream holds the limiter until the response body is closed, and adapts the limit to the latency of the
+// response headers as the transfer time depends on the size of the bodies
+func (a *adaptiveChannel) InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	release, err := a.limiter.Acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	rsp, rspBody, err := InvokeMethodStream(ctx, a.AppChannel, req, body)
+	latency := time.Since(start)
+	if err != nil {
+		release(latency, overloaded(rsp, err))
+		return nil, nil, err
+	}
+	return rsp, WithCloseHook(rspBody, func() {
+		release(latency, overloaded(rsp, nil))
+	}), nil
+}
+
+// overloaded returns whether a call failed because the app is overloaded
+func overloaded(rsp *invokev1.InvokeMethodResponse, err error) bool {
+	if err != nil || rsp == nil {
+		return true
+	}
+	code := rsp.Status().Code
+	if rsp.IsHTTPResponse() {
+		return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+	}
+	return code == int32(codes.ResourceExhausted) || code == int32(codes.Unavailable) || code == int32(codes.DeadlineExceeded)
+}
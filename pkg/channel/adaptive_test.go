@@ -0,0 +1,86 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package channel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/concurrency"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithAdaptiveConcurrency(t *testing.T) {
+	mockChannel := &channelt.MockAppChannel{}
+	assert.Equal(t, mockChannel, WithAdaptiveConcurrency(mockChannel, nil))
+
+	limiter, err := concurrency.New(config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyAIMD, InitialLimit: 10})
+	require.NoError(t, err)
+	ch := WithAdaptiveConcurrency(mockChannel, limiter)
+
+	req := invokev1.NewInvokeMethodRequest("method")
+	mockChannel.On("InvokeMethod", mock.Anything, req).Return(invokev1.NewInvokeMethodResponse(http.StatusServiceUnavailable, "", nil), nil).Once()
+	rsp, err := ch.InvokeMethod(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(http.StatusServiceUnavailable), rsp.Status().Code)
+	assert.Equal(t, 9, limiter.Limit(), "limit didn't back off from the overloaded app")
+	mockChannel.AssertExpectations(t)
+}
+
+// streamingAppChannel streams the invocations of an overloaded app
+type streamingAppChannel struct {
+	channelt.MockAppChannel
+}
+
+func (s *streamingAppChannel) InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	return invokev1.NewInvokeMethodResponse(http.StatusServiceUnavailable, "", nil), ioutil.NopCloser(body), nil
+}
+
+func TestAdaptiveInvokeMethodStream(t *testing.T) {
+	limiter, err := concurrency.New(config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyAIMD, InitialLimit: 10})
+	require.NoError(t, err)
+	req := invokev1.NewInvokeMethodRequest("method")
+
+	t.Run("streaming channel", func(t *testing.T) {
+		ch := WithAdaptiveConcurrency(&streamingAppChannel{}, limiter)
+		rsp, body, err := InvokeMethodStream(context.Background(), ch, req, strings.NewReader("data"))
+		require.NoError(t, err)
+		assert.Equal(t, int32(http.StatusServiceUnavailable), rsp.Status().Code)
+		assert.Equal(t, 10, limiter.Limit(), "limiter released before the body was closed")
+
+		data, _ := ioutil.ReadAll(body)
+		assert.Equal(t, "data", string(data))
+		body.Close()
+		body.Close()
+		assert.Equal(t, 9, limiter.Limit(), "limit didn't back off from the overloaded app")
+	})
+
+	t.Run("non streaming channel", func(t *testing.T) {
+		ch := WithAdaptiveConcurrency(&channelt.MockAppChannel{}, limiter)
+		_, _, err := InvokeMethodStream(context.Background(), ch, req, strings.NewReader("data"))
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestOverloaded(t *testing.T) {
+	assert.True(t, overloaded(nil, errors.New("connection refused")))
+	assert.True(t, overloaded(invokev1.NewInvokeMethodResponse(http.StatusTooManyRequests, "", nil), nil))
+	assert.False(t, overloaded(invokev1.NewInvokeMethodResponse(http.StatusInternalServerError, "", nil), nil))
+	assert.True(t, overloaded(invokev1.NewInvokeMethodResponse(int32(codes.ResourceExhausted), "", nil), nil))
+	assert.False(t, overloaded(invokev1.NewInvokeMethodResponse(int32(codes.OK), "", nil), nil))
+}
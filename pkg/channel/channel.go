@@ -7,9 +7,13 @@ package channel
 
 import (
 	"context"
+	"io"
+	"sync"
 	"time"
 
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -25,3 +29,36 @@ type AppChannel interface {
 	GetBaseAddress() string
 	InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error)
 }
+
+// StreamingAppChannel is implemented by the app channels streaming the invocation data, instead of buffering it.
+// The response body must be closed.
+type StreamingAppChannel interface {
+	InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error)
+}
+
+// InvokeMethodStream invokes the app streaming the bodies, when the app channel supports it
+func InvokeMethodStream(ctx context.Context, ch AppChannel, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	sc, ok := ch.(StreamingAppChannel)
+	if !ok {
+		return nil, nil, status.Error(codes.Unimplemented, "the app channel doesn't stream invocations")
+	}
+	return sc.InvokeMethodStream(ctx, req, body)
+}
+
+// closeHook calls hook once when the body is closed
+type closeHook struct {
+	io.ReadCloser
+	hook func()
+	once sync.Once
+}
+
+// WithCloseHook returns the body calling hook once when it's closed, e.g. to release the resources held by the call
+func WithCloseHook(body io.ReadCloser, hook func()) io.ReadCloser {
+	return &closeHook{ReadCloser: body, hook: hook}
+}
+
+func (c *closeHook) Close() error {
+	err := c.ReadCloser.Close()
+	c.once.Do(c.hook)
+	return err
+}
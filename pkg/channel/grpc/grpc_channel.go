@@ -26,14 +26,16 @@ type Channel struct {
 	client      *grpc.ClientConn
 	baseAddress string
 	ch          chan int
+	timeouts    channel.Timeouts
 	tracingSpec config.TracingSpec
 }
 
 // CreateLocalChannel creates a gRPC connection with user code
-func CreateLocalChannel(port, maxConcurrency int, conn *grpc.ClientConn, spec config.TracingSpec) *Channel {
+func CreateLocalChannel(port, maxConcurrency int, conn *grpc.ClientConn, timeouts channel.Timeouts, spec config.TracingSpec) *Channel {
 	c := &Channel{
 		client:      conn,
 		baseAddress: fmt.Sprintf("%s:%d", channel.DefaultChannelAddress, port),
+		timeouts:    timeouts,
 		tracingSpec: spec,
 	}
 	if maxConcurrency > 0 {
@@ -42,6 +44,13 @@ func CreateLocalChannel(port, maxConcurrency int, conn *grpc.ClientConn, spec co
 	return c
 }
 
+// CreatePipeChannel creates a gRPC AppChannel over a connection to user code listening on a Windows named pipe
+func CreatePipeChannel(pipe string, maxConcurrency int, conn *grpc.ClientConn, timeouts channel.Timeouts, spec config.TracingSpec) *Channel {
+	c := CreateLocalChannel(0, maxConcurrency, conn, timeouts, spec)
+	c.baseAddress = pipe
+	return c
+}
+
 // GetBaseAddress returns the application base address
 func (g *Channel) GetBaseAddress() string {
 	return g.baseAddress
@@ -71,15 +80,16 @@ func (g *Channel) invokeMethodV1(ctx context.Context, req *invokev1.InvokeMethod
 		g.ch <- 1
 	}
 	sc := diag.FromContext(ctx)
+	timeout := g.timeouts.Timeout(ctx)
 
 	clientV1 := clientv1pb.NewDaprClientClient(g.client)
 	grpcMetadata := invokev1.InternalMetadataToGrpcMetadata(req.Metadata(), true)
 	// Prepare gRPC Metadata
 	ctx = metadata.NewOutgoingContext(context.Background(), grpcMetadata)
 	// populate span context
-	ctx = diag.AppendToOutgoingGRPCContext(ctx, sc)
+	ctx = diag.InjectToOutgoingGRPCContext(ctx, sc, g.tracingSpec)
 
-	ctx, cancel := context.WithTimeout(ctx, channel.DefaultChannelRequestTimeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	var header, trailer metadata.MD
 	resp, err := clientV1.OnInvoke(ctx, req.Message(), grpc.Header(&header), grpc.Trailer(&trailer))
@@ -9,13 +9,20 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	nethttp "net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/dapr/pkg/namedpipe"
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
 	"github.com/valyala/fasthttp"
@@ -30,23 +37,33 @@ const (
 
 // Channel is an HTTP implementation of an AppChannel
 type Channel struct {
-	client      *fasthttp.Client
-	baseAddress string
-	ch          chan int
-	tracingSpec config.TracingSpec
+	client *fasthttp.Client
+	// streamClient streams the invocation data, which the fasthttp client buffers
+	streamClient *nethttp.Client
+	baseAddress  string
+	ch           chan int
+	timeouts     channel.Timeouts
+	tracingSpec  config.TracingSpec
 }
 
 // CreateLocalChannel creates an HTTP AppChannel
 // nolint:gosec
-func CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error) {
+func CreateLocalChannel(port, maxConcurrency int, timeouts channel.Timeouts, spec config.TracingSpec) (channel.AppChannel, error) {
 	c := &Channel{
 		client: &fasthttp.Client{
 			MaxConnsPerHost:           1000000,
-			TLSConfig:                 &tls.Config{InsecureSkipVerify: true},
-			ReadTimeout:               channel.DefaultChannelRequestTimeout,
+			TLSConfig:                 fips.ConfigureTLS(&tls.Config{InsecureSkipVerify: true}),
+			ReadTimeout:               timeouts.Max(),
 			MaxIdemponentCallAttempts: 0,
 		},
+		streamClient: &nethttp.Client{
+			Transport: &nethttp.Transport{
+				TLSClientConfig:       fips.ConfigureTLS(&tls.Config{InsecureSkipVerify: true}),
+				ResponseHeaderTimeout: timeouts.Max(),
+			},
+		},
 		baseAddress: fmt.Sprintf("http://%s:%d", channel.DefaultChannelAddress, port),
+		timeouts:    timeouts,
 		tracingSpec: spec,
 	}
 
@@ -56,6 +73,23 @@ func CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec) (chan
 	return c, nil
 }
 
+// CreatePipeChannel creates an HTTP AppChannel to an app listening on a Windows named pipe
+func CreatePipeChannel(pipe string, maxConcurrency int, timeouts channel.Timeouts, spec config.TracingSpec) (channel.AppChannel, error) {
+	ch, err := CreateLocalChannel(0, maxConcurrency, timeouts, spec)
+	if err != nil {
+		return nil, err
+	}
+	c := ch.(*Channel)
+	c.client.Dial = func(addr string) (net.Conn, error) {
+		return namedpipe.Dial(context.Background(), pipe)
+	}
+	c.streamClient.Transport.(*nethttp.Transport).DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return namedpipe.Dial(ctx, pipe)
+	}
+	c.baseAddress = fmt.Sprintf("http://%s", channel.DefaultChannelAddress)
+	return c, nil
+}
+
 // GetBaseAddress returns the application base address
 func (h *Channel) GetBaseAddress() string {
 	return h.baseAddress
@@ -86,6 +120,64 @@ func (h *Channel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRe
 	return rsp, err
 }
 
+// InvokeMethodStream invokes user code via HTTP, streaming the request and response bodies
+func (h *Channel) InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	httpExt := req.Message().GetHttpExtension()
+	if httpExt == nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "missing HTTP extension field")
+	}
+	if httpExt.GetVerb() == commonv1pb.HTTPExtension_NONE {
+		return nil, nil, status.Error(codes.InvalidArgument, "invalid HTTP verb")
+	}
+	if req.APIVersion() != internalv1pb.APIVersion_V1 {
+		return nil, nil, status.Error(codes.Unimplemented, fmt.Sprintf("Unsupported spec version: %d", req.APIVersion()))
+	}
+
+	uri := fmt.Sprintf("%s/%s", h.baseAddress, req.Message().GetMethod())
+	if qs := req.EncodeHTTPQueryString(); qs != "" {
+		uri += "?" + qs
+	}
+	channelReq, err := nethttp.NewRequestWithContext(ctx, httpExt.GetVerb().String(), uri, body)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	invokev1.InternalMetadataToHTTPHeader(req.Metadata(), channelReq.Header.Set)
+	diag.SpanContextToHTTPHeader(diag.FromContext(ctx), channelReq.Header, h.tracingSpec)
+	if contentType := req.Message().GetContentType(); contentType != "" {
+		channelReq.Header.Set("Content-Type", contentType)
+	}
+
+	// the concurrency slot is held until the response body is closed, as the app still works on the request
+	if h.ch != nil {
+		h.ch <- 1
+	}
+	release := func() {
+		if h.ch != nil {
+			<-h.ch
+		}
+	}
+
+	diag.DefaultHTTPMonitoring.ClientRequestStarted(ctx, req.Message().Method, req.Message().Method, -1)
+	startRequest := time.Now()
+
+	resp, err := h.streamClient.Do(channelReq)
+	if err != nil {
+		release()
+		rsp := invokev1.NewInvokeMethodResponse(fasthttp.StatusInternalServerError, "", nil)
+		rsp.WithRawData(nil, string(invokev1.JSONContentType))
+		return rsp, ioutil.NopCloser(strings.NewReader(fmt.Sprintf("{\"error\": \"client error: %s\"}", err))), nil
+	}
+
+	rsp := invokev1.NewInvokeMethodResponse(int32(resp.StatusCode), "", nil)
+	rsp.WithHTTPHeaders(resp.Header).WithRawData(nil, resp.Header.Get("Content-Type"))
+
+	return rsp, channel.WithCloseHook(resp.Body, func() {
+		release()
+		elapsedMs := float64(time.Since(startRequest) / time.Millisecond)
+		diag.DefaultHTTPMonitoring.ClientRequestCompleted(ctx, req.Message().GetMethod(), req.Message().GetMethod(), strconv.Itoa(resp.StatusCode), resp.ContentLength, elapsedMs)
+	}), nil
+}
+
 func (h *Channel) invokeMethodV1(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
 	channelReq := h.constructRequest(ctx, req)
 
@@ -99,7 +191,7 @@ func (h *Channel) invokeMethodV1(ctx context.Context, req *invokev1.InvokeMethod
 
 	// Send request to user application
 	var resp = fasthttp.AcquireResponse()
-	err := h.client.DoTimeout(channelReq, resp, channel.DefaultChannelRequestTimeout)
+	err := h.client.DoTimeout(channelReq, resp, h.timeouts.Timeout(ctx))
 	defer func() {
 		fasthttp.ReleaseRequest(channelReq)
 		fasthttp.ReleaseResponse(resp)
@@ -130,7 +222,7 @@ func (h *Channel) constructRequest(ctx context.Context, req *invokev1.InvokeMeth
 	invokev1.InternalMetadataToHTTPHeader(req.Metadata(), channelReq.Header.Set)
 
 	sc := diag.FromContext(ctx)
-	diag.SpanContextToRequest(sc, channelReq)
+	diag.SpanContextToRequest(sc, channelReq, h.tracingSpec)
 
 	// Set Content body and types
 	contentType, body := req.RawData()
@@ -9,14 +9,19 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/config"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 )
 
@@ -269,3 +274,97 @@ func TestContentType(t *testing.T) {
 		testServer.Close()
 	})
 }
+
+type testSlowHandler struct {
+	delay time.Duration
+}
+
+func (t *testSlowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	time.Sleep(t.delay)
+	io.WriteString(w, "done")
+}
+
+func TestInvokeMethodTimeouts(t *testing.T) {
+	server := httptest.NewServer(&testSlowHandler{delay: time.Millisecond * 200})
+	defer server.Close()
+	c := Channel{
+		baseAddress: server.URL,
+		client:      &fasthttp.Client{},
+		timeouts:    channel.Timeouts{channel.OperationPubSub: time.Millisecond * 50},
+	}
+	req := invokev1.NewInvokeMethodRequest("method")
+	req.WithHTTPExtension(http.MethodPost, "")
+
+	t.Run("operation timeout", func(t *testing.T) {
+		ctx := channel.WithOperation(context.Background(), channel.OperationPubSub)
+		response, err := c.InvokeMethod(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(http.StatusInternalServerError), response.Status().Code)
+	})
+
+	t.Run("request timeout overrides the operation timeout", func(t *testing.T) {
+		ctx := channel.WithRequestTimeout(channel.WithOperation(context.Background(), channel.OperationPubSub), time.Second)
+		response, err := c.InvokeMethod(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(http.StatusOK), response.Status().Code)
+	})
+}
+
+// testStreamHandler echoes the request body, with the query string in a header
+type testStreamHandler struct {
+}
+
+func (t *testStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+	w.Header().Set("X-Query", r.URL.RawQuery)
+	// the HTTP/1 server stops reading the request once the response is flushed
+	body, _ := ioutil.ReadAll(r.Body)
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+}
+
+func TestInvokeMethodStream(t *testing.T) {
+	server := httptest.NewServer(&testStreamHandler{})
+	defer server.Close()
+	ctx := context.Background()
+	c := Channel{baseAddress: server.URL, client: &fasthttp.Client{}, streamClient: &http.Client{}}
+	c.ch = make(chan int, 1)
+
+	t.Run("streams the bodies", func(t *testing.T) {
+		data := strings.Repeat("x", 3*invokev1.StreamChunkSize)
+		fakeReq := invokev1.NewInvokeMethodRequest("method")
+		fakeReq.WithHTTPExtension(http.MethodPost, "param1=val1")
+		fakeReq.WithRawData(nil, "application/octet-stream")
+
+		response, body, err := c.InvokeMethodStream(ctx, fakeReq, strings.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, int32(http.StatusCreated), response.Status().Code)
+		contentType, _ := response.RawData()
+		assert.Equal(t, "application/octet-stream", contentType)
+		assert.Equal(t, "param1=val1", response.Headers()["X-Query"].GetValues()[0].GetStringValue())
+		assert.Len(t, c.ch, 1, "the concurrency slot is held until the body is closed")
+
+		got, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, data, string(got))
+		body.Close()
+		assert.Len(t, c.ch, 0)
+	})
+
+	t.Run("app unreachable", func(t *testing.T) {
+		c := Channel{baseAddress: "http://127.0.0.1:1", client: &fasthttp.Client{}, streamClient: &http.Client{}}
+		fakeReq := invokev1.NewInvokeMethodRequest("method")
+		fakeReq.WithHTTPExtension(http.MethodPost, "")
+
+		response, body, err := c.InvokeMethodStream(ctx, fakeReq, strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Equal(t, int32(http.StatusInternalServerError), response.Status().Code)
+		got, _ := ioutil.ReadAll(body)
+		assert.Contains(t, string(got), "client error")
+	})
+
+	t.Run("missing HTTP extension", func(t *testing.T) {
+		_, _, err := c.InvokeMethodStream(ctx, invokev1.NewInvokeMethodRequest("method"), strings.NewReader(""))
+		assert.Error(t, err)
+	})
+}
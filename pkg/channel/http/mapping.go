@@ -0,0 +1,202 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/channel"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// wholeMessageBody sends the whole request message as the body of the mapped request
+const wholeMessageBody = "*"
+
+// mappingChannel maps the invocations of methods without an HTTP verb, e.g. gRPC InvokeService calls, to the HTTP
+// requests of their mapping rules
+type mappingChannel struct {
+	channel.AppChannel
+	mappings map[string]*mapping
+}
+
+// mapping is a parsed mapping rule
+type mapping struct {
+	verb commonv1pb.HTTPExtension_Verb
+	// path alternates literals and parameters, starting with a literal
+	path  []string
+	query map[string]string
+	body  string
+}
+
+// WithMappings returns a channel mapping the invocations of methods without an HTTP verb to the requests described by
+// the mapping rules. It returns ch when there are no rules.
+func WithMappings(ch channel.AppChannel, specs []config.HTTPMappingSpec) (channel.AppChannel, error) {
+	if len(specs) == 0 {
+		return ch, nil
+	}
+
+	mappings := map[string]*mapping{}
+	for _, s := range specs {
+		m, err := parseMapping(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP mapping of method %s: %s", s.Method, err)
+		}
+		mappings[s.Method] = m
+	}
+	return &mappingChannel{AppChannel: ch, mappings: mappings}, nil
+}
+
+func parseMapping(s config.HTTPMappingSpec) (*mapping, error) {
+	verb, ok := commonv1pb.HTTPExtension_Verb_value[strings.ToUpper(s.Verb)]
+	if !ok || verb == int32(commonv1pb.HTTPExtension_NONE) {
+		return nil, fmt.Errorf("%s is not an HTTP verb", s.Verb)
+	}
+	if !strings.HasPrefix(s.Path, "/") {
+		return nil, fmt.Errorf("path %s doesn't start with /", s.Path)
+	}
+
+	path := []string{}
+	rest := s.Path
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed parameter in path %s", s.Path)
+		}
+		param := rest[start+1 : start+end]
+		if param == "" || strings.ContainsAny(param, "{/") {
+			return nil, fmt.Errorf("invalid parameter %s in path %s", param, s.Path)
+		}
+		path = append(path, rest[:start], param)
+		rest = rest[start+end+1:]
+	}
+	if strings.Contains(rest, "}") {
+		return nil, fmt.Errorf("unopened parameter in path %s", s.Path)
+	}
+	path = append(path, rest)
+
+	return &mapping{
+		verb:  commonv1pb.HTTPExtension_Verb(verb),
+		path:  path,
+		query: s.Query,
+		body:  s.Body,
+	}, nil
+}
+
+// InvokeMethod maps the request when its method has a mapping rule and it has no HTTP verb
+func (c *mappingChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	msg := req.Message()
+	if m, ok := c.mappings[msg.GetMethod()]; ok && msg.GetHttpExtension().GetVerb() == commonv1pb.HTTPExtension_NONE {
+		if err := m.apply(req); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "can't map method %s to an HTTP request: %s", msg.GetMethod(), err)
+		}
+	}
+	return c.AppChannel.InvokeMethod(ctx, req)
+}
+
+// apply replaces the method, HTTP extension and data of the request with the ones of the mapped HTTP request
+func (m *mapping) apply(req *invokev1.InvokeMethodRequest) error {
+	contentType, data := req.RawData()
+	var message map[string]interface{}
+	if len(bytes.TrimSpace(data)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&message); err != nil {
+			return fmt.Errorf("request message is not a JSON object: %s", err)
+		}
+	}
+
+	var path strings.Builder
+	for i, p := range m.path {
+		if i%2 == 0 {
+			path.WriteString(p)
+			continue
+		}
+		v, ok := field(message, p)
+		if !ok {
+			return fmt.Errorf("request message has no field %s", p)
+		}
+		s, err := scalar(v)
+		if err != nil {
+			return fmt.Errorf("field %s: %s", p, err)
+		}
+		path.WriteString(url.PathEscape(s))
+	}
+
+	query := map[string]string{}
+	for param, f := range m.query {
+		v, ok := field(message, f)
+		if !ok || v == nil {
+			continue
+		}
+		s, err := scalar(v)
+		if err != nil {
+			return fmt.Errorf("field %s: %s", f, err)
+		}
+		query[param] = s
+	}
+
+	var body []byte
+	switch m.body {
+	case "":
+	case wholeMessageBody:
+		body = data
+	default:
+		if v, ok := field(message, m.body); ok {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			body = b
+		}
+	}
+
+	msg := req.Message()
+	msg.Method = strings.TrimPrefix(path.String(), "/")
+	msg.HttpExtension = &commonv1pb.HTTPExtension{Verb: m.verb, Querystring: query}
+	req.WithRawData(body, contentType)
+	return nil
+}
+
+// field returns the value of a field of the message, with dots separating nested fields
+func field(message map[string]interface{}, name string) (interface{}, bool) {
+	var v interface{} = message
+	for _, f := range strings.Split(name, ".") {
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = object[f]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// scalar formats a string, number or boolean field for a path or query parameter
+func scalar(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case json.Number:
+		return t.String(), nil
+	case bool:
+		return fmt.Sprint(t), nil
+	}
+	return "", fmt.Errorf("%v is not a string, number or boolean", v)
+}
@@ -0,0 +1,111 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testEchoHandler responds with the verb, URI and body of the request
+type testEchoHandler struct{}
+
+func (testEchoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	json.NewEncoder(w).Encode(map[string]string{"verb": r.Method, "uri": r.URL.RequestURI(), "body": string(body)})
+}
+
+func TestWithMappings(t *testing.T) {
+	server := httptest.NewServer(testEchoHandler{})
+	defer server.Close()
+	ch := &Channel{
+		baseAddress: server.URL,
+		client:      &fasthttp.Client{},
+		tracingSpec: config.TracingSpec{SamplingRate: "0"},
+	}
+
+	mapped, err := WithMappings(ch, []config.HTTPMappingSpec{
+		{Method: "GetOrder", Verb: "get", Path: "/orders/{id}/lines/{line.sku}", Query: map[string]string{"expand": "expand", "page": "page"}},
+		{Method: "UpdateOrder", Verb: "PUT", Path: "/orders/{id}", Body: "order"},
+		{Method: "CreateOrder", Verb: "PUT", Path: "/orders", Body: "*"},
+	})
+	assert.NoError(t, err)
+
+	invoke := func(req *invokev1.InvokeMethodRequest) (map[string]string, error) {
+		resp, err := mapped.InvokeMethod(context.Background(), req)
+		if err != nil {
+			return nil, err
+		}
+		_, body := resp.RawData()
+		echo := map[string]string{}
+		assert.NoError(t, json.Unmarshal(body, &echo))
+		return echo, nil
+	}
+
+	t.Run("path and query parameters", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("GetOrder").WithRawData([]byte(`{"id":9007199254740993,"line":{"sku":"a b"},"expand":true}`), "")
+		echo, err := invoke(req)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"verb": "GET", "uri": "/orders/9007199254740993/lines/a%20b?expand=true", "body": ""}, echo)
+	})
+
+	t.Run("field body", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("UpdateOrder").WithRawData([]byte(`{"id":"o1","order":{"quantity":2}}`), "")
+		echo, err := invoke(req)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"verb": "PUT", "uri": "/orders/o1", "body": `{"quantity":2}`}, echo)
+	})
+
+	t.Run("whole message body", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("CreateOrder").WithRawData([]byte(`{"id":"o1"}`), "")
+		echo, err := invoke(req)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"verb": "PUT", "uri": "/orders", "body": `{"id":"o1"}`}, echo)
+	})
+
+	t.Run("requests with an HTTP verb aren't mapped", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("GetOrder").WithHTTPExtension(http.MethodPost, "").WithRawData([]byte(`{}`), "")
+		echo, err := invoke(req)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"verb": "POST", "uri": "/GetOrder", "body": "{}"}, echo)
+	})
+
+	t.Run("missing path parameter", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("GetOrder").WithRawData([]byte(`{"id":"o1"}`), "")
+		_, err := invoke(req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("message is not a JSON object", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("GetOrder").WithRawData([]byte(`order`), "text/plain")
+		_, err := invoke(req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		_, err := WithMappings(ch, []config.HTTPMappingSpec{{Method: "A", Verb: "FETCH", Path: "/a"}})
+		assert.Error(t, err)
+		_, err = WithMappings(ch, []config.HTTPMappingSpec{{Method: "A", Verb: "GET", Path: "/a/{id"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		c, err := WithMappings(ch, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, ch, c)
+	})
+}
@@ -0,0 +1,77 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package channel
+
+import (
+	"context"
+	"time"
+)
+
+// Operation is the kind of app callback an app channel request is made for
+type Operation string
+
+const (
+	// OperationInvocation is a service invocation of the app
+	OperationInvocation Operation = "invocation"
+	// OperationPubSub is a pub/sub message delivered to the app
+	OperationPubSub Operation = "pubsub"
+	// OperationBinding is an input binding event delivered to the app
+	OperationBinding Operation = "binding"
+	// OperationActor is an actor method, reminder or timer call of the app
+	OperationActor Operation = "actor"
+
+	// TimeoutMetadataKey is the subscription metadata key of the timeout of the deliveries of its messages.
+	// The responses of HTTP apps are read for the longest operation timeout at most.
+	TimeoutMetadataKey = "timeout"
+)
+
+type operationKey struct{}
+
+type timeoutKey struct{}
+
+// Timeouts are the timeouts of app channel requests per operation.
+// Requests of other operations, or of operations without a timeout, time out after DefaultChannelRequestTimeout.
+type Timeouts map[Operation]time.Duration
+
+// WithOperation returns a context whose app channel requests are made for the operation
+func WithOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFromContext returns the operation the app channel requests made with the context are for, if any
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// WithRequestTimeout returns a context whose app channel requests time out after timeout, whatever their operation
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, timeout)
+}
+
+// Timeout returns the timeout of an app channel request made with the context
+func (t Timeouts) Timeout(ctx context.Context) time.Duration {
+	if d, ok := ctx.Value(timeoutKey{}).(time.Duration); ok && d > 0 {
+		return d
+	}
+	if op, ok := OperationFromContext(ctx); ok {
+		if d := t[op]; d > 0 {
+			return d
+		}
+	}
+	return DefaultChannelRequestTimeout
+}
+
+// Max returns the longest timeout of the operations, DefaultChannelRequestTimeout if it's longer
+func (t Timeouts) Max() time.Duration {
+	max := DefaultChannelRequestTimeout
+	for _, d := range t {
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
@@ -0,0 +1,37 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package channel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeouts(t *testing.T) {
+	timeouts := Timeouts{
+		OperationPubSub: time.Second * 30,
+		OperationActor:  time.Minute * 5,
+	}
+
+	assert.Equal(t, DefaultChannelRequestTimeout, timeouts.Timeout(context.Background()))
+	assert.Equal(t, DefaultChannelRequestTimeout, timeouts.Timeout(WithOperation(context.Background(), OperationBinding)))
+
+	ctx := WithOperation(context.Background(), OperationPubSub)
+	assert.Equal(t, time.Second*30, timeouts.Timeout(ctx))
+	assert.Equal(t, time.Second*5, timeouts.Timeout(WithRequestTimeout(ctx, time.Second*5)))
+
+	op, ok := OperationFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, OperationPubSub, op)
+	_, ok = OperationFromContext(context.Background())
+	assert.False(t, ok)
+
+	assert.Equal(t, time.Minute*5, timeouts.Max())
+	assert.Equal(t, DefaultChannelRequestTimeout, Timeouts(nil).Max())
+}
@@ -39,6 +39,7 @@ type ComponentsGetter interface {
 type ComponentInterface interface {
 	Create(*v1alpha1.Component) (*v1alpha1.Component, error)
 	Update(*v1alpha1.Component) (*v1alpha1.Component, error)
+	UpdateStatus(*v1alpha1.Component) (*v1alpha1.Component, error)
 	Delete(name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.Component, error)
@@ -132,6 +133,22 @@ func (c *components) Update(component *v1alpha1.Component) (result *v1alpha1.Com
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *components) UpdateStatus(component *v1alpha1.Component) (result *v1alpha1.Component, err error) {
+	result = &v1alpha1.Component{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("components").
+		Name(component.Name).
+		SubResource("status").
+		Body(component).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the component and deletes it. Returns an error if one occurs.
 func (c *components) Delete(name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -100,6 +100,18 @@ func (c *FakeComponents) Update(component *v1alpha1.Component) (result *v1alpha1
 	return obj.(*v1alpha1.Component), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeComponents) UpdateStatus(component *v1alpha1.Component) (*v1alpha1.Component, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(componentsResource, "status", c.ns, component), &v1alpha1.Component{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Component), err
+}
+
 // Delete takes name of the component and deletes it. Returns an error if one occurs.
 func (c *FakeComponents) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
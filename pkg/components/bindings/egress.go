@@ -0,0 +1,143 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+// egressMetadataKeys are the component and request metadata keys holding the destination of output bindings. The
+// destinations of the bindings in other keys, e.g. connection strings or region names, aren't checked.
+var egressMetadataKeys = []string{"url", "endpoint", "host", "address", "brokers"}
+
+// EgressPolicy is the allow-list of the destinations output bindings may reach. It's best-effort: only the destinations
+// in egressMetadataKeys are checked, and host names are resolved separately from the connections of the bindings, so a
+// name resolving to other addresses by the time a binding connects isn't caught. Network policies are needed to
+// enforce the egress of the sidecar.
+type EgressPolicy struct {
+	hosts    []string
+	networks []*net.IPNet
+	lookupIP func(host string) ([]net.IP, error)
+}
+
+// NewEgressPolicy returns the policy allowing the hosts, either exact names or *.domain wildcards matching its
+// subdomains, and the IP addresses in the CIDRs. Names that aren't allowed hosts are allowed when all their addresses
+// are in the CIDRs.
+func NewEgressPolicy(hosts, cidrs []string) (*EgressPolicy, error) {
+	p := &EgressPolicy{lookupIP: net.LookupIP}
+	for _, h := range hosts {
+		p.hosts = append(p.hosts, strings.ToLower(h))
+	}
+	for _, c := range cidrs {
+		_, network, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid egress cidr %s: %s", c, err)
+		}
+		p.networks = append(p.networks, network)
+	}
+	return p, nil
+}
+
+// IsEmpty returns whether the policy allows all destinations
+func (p *EgressPolicy) IsEmpty() bool {
+	return len(p.hosts) == 0 && len(p.networks) == 0
+}
+
+// Allowed returns an error when the destination, a URL, host:port or host, isn't allowed
+func (p *EgressPolicy) Allowed(destination string) error {
+	host := destinationHost(destination)
+	if host == "" || p.IsEmpty() || p.allowedHost(host) {
+		return nil
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if p.allowedIP(ip) {
+			return nil
+		}
+		return fmt.Errorf("egress to %s is not allowed", host)
+	}
+	if len(p.networks) > 0 {
+		ips, err := p.lookupIP(host)
+		if err != nil {
+			return fmt.Errorf("egress to %s is not allowed: %s", host, err)
+		}
+		allowed := len(ips) > 0
+		for _, ip := range ips {
+			allowed = allowed && p.allowedIP(ip)
+		}
+		if allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("egress to %s is not allowed", host)
+}
+
+func (p *EgressPolicy) allowedHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, h := range p.hosts {
+		if h == host || (strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *EgressPolicy) allowedIP(ip net.IP) bool {
+	for _, n := range p.networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// destinationHost returns the host of the URL, host:port or host
+func destinationHost(destination string) string {
+	destination = strings.TrimSpace(destination)
+	if strings.Contains(destination, "://") {
+		if u, err := url.Parse(destination); err == nil {
+			return u.Hostname()
+		}
+	}
+	if host, _, err := net.SplitHostPort(destination); err == nil {
+		return host
+	}
+	return strings.Trim(destination, "[]")
+}
+
+// WithEgressPolicy returns the output binding checking its destinations against the policy before each write. The
+// destinations are the values of egressMetadataKeys in its component metadata and in the request metadata, which some
+// bindings take over the component metadata. Bindings with destinations in other keys aren't restricted.
+func WithEgressPolicy(binding bindings.OutputBinding, properties map[string]string, policy *EgressPolicy) bindings.OutputBinding {
+	if policy == nil || policy.IsEmpty() {
+		return binding
+	}
+	return &egressBinding{OutputBinding: binding, properties: properties, policy: policy}
+}
+
+type egressBinding struct {
+	bindings.OutputBinding
+	properties map[string]string
+	policy     *EgressPolicy
+}
+
+func (e *egressBinding) Write(req *bindings.WriteRequest) error {
+	for _, k := range egressMetadataKeys {
+		for _, m := range []map[string]string{e.properties, req.Metadata} {
+			for _, d := range strings.Split(m[k], ",") {
+				if err := e.policy.Allowed(d); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return e.OutputBinding.Write(req)
+}
@@ -0,0 +1,100 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOutputBinding struct {
+	bindings.OutputBinding
+	writes int
+}
+
+func (f *fakeOutputBinding) Write(req *bindings.WriteRequest) error {
+	f.writes++
+	return nil
+}
+
+func TestEgressPolicy(t *testing.T) {
+	policy, err := NewEgressPolicy([]string{"api.example.com", "*.internal.example.com"}, []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	policy.lookupIP = func(host string) ([]net.IP, error) {
+		switch host {
+		case "private.local":
+			return []net.IP{net.ParseIP("10.1.2.3")}, nil
+		case "mixed.local":
+			return []net.IP{net.ParseIP("10.1.2.3"), net.ParseIP("169.254.169.254")}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	assert.NoError(t, policy.Allowed("https://api.example.com/v1/orders"))
+	assert.NoError(t, policy.Allowed("API.example.com:443"))
+	assert.NoError(t, policy.Allowed("https://db.internal.example.com"))
+	assert.NoError(t, policy.Allowed("10.0.0.1:9092"))
+	assert.NoError(t, policy.Allowed("http://private.local:8080"))
+	assert.NoError(t, policy.Allowed(""))
+
+	assert.Error(t, policy.Allowed("https://internal.example.com"))
+	assert.Error(t, policy.Allowed("http://169.254.169.254/latest/meta-data"))
+	assert.Error(t, policy.Allowed("http://mixed.local"))
+	assert.Error(t, policy.Allowed("http://unknown.local"))
+	assert.Error(t, policy.Allowed("http://[::1]:8080"))
+
+	_, err = NewEgressPolicy(nil, []string{"10.0.0.0"})
+	assert.Error(t, err)
+}
+
+func TestWithEgressPolicy(t *testing.T) {
+	t.Run("empty policy", func(t *testing.T) {
+		policy, err := NewEgressPolicy(nil, nil)
+		require.NoError(t, err)
+		binding := &fakeOutputBinding{}
+		assert.Equal(t, binding, WithEgressPolicy(binding, map[string]string{"url": "http://169.254.169.254"}, policy))
+	})
+
+	policy, err := NewEgressPolicy([]string{"api.example.com", "kafka.example.com"}, nil)
+	require.NoError(t, err)
+
+	t.Run("allowed destinations", func(t *testing.T) {
+		binding := &fakeOutputBinding{}
+		b := WithEgressPolicy(binding, map[string]string{"brokers": "kafka.example.com:9092,kafka.example.com:9093"}, policy)
+		assert.NoError(t, b.Write(&bindings.WriteRequest{Metadata: map[string]string{"url": "https://api.example.com"}}))
+		assert.Equal(t, 1, binding.writes)
+	})
+
+	t.Run("component destination not allowed", func(t *testing.T) {
+		binding := &fakeOutputBinding{}
+		b := WithEgressPolicy(binding, map[string]string{"url": "http://169.254.169.254"}, policy)
+		assert.Error(t, b.Write(&bindings.WriteRequest{}))
+		assert.Equal(t, 0, binding.writes)
+	})
+
+	t.Run("request destination not allowed", func(t *testing.T) {
+		binding := &fakeOutputBinding{}
+		b := WithEgressPolicy(binding, map[string]string{"url": "https://api.example.com"}, policy)
+		assert.Error(t, b.Write(&bindings.WriteRequest{Metadata: map[string]string{"url": "http://localhost:3500"}}))
+		assert.Equal(t, 0, binding.writes)
+	})
+
+	t.Run("request override of the component url to a disallowed host", func(t *testing.T) {
+		binding := &fakeOutputBinding{}
+		b := WithEgressPolicy(binding, map[string]string{"url": "https://api.example.com"}, policy)
+		err := b.Write(&bindings.WriteRequest{Metadata: map[string]string{"url": "https://attacker.example.org/collect"}})
+		assert.EqualError(t, err, "egress to attacker.example.org is not allowed")
+		assert.Equal(t, 0, binding.writes)
+
+		assert.NoError(t, b.Write(&bindings.WriteRequest{Metadata: map[string]string{"url": "https://api.example.com/v2"}}))
+		assert.Equal(t, 1, binding.writes)
+	})
+}
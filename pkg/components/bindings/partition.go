@@ -0,0 +1,96 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"sync"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+const (
+	// PartitionKeyMetadataKey is the component metadata key of the name of the event metadata holding the partition,
+	// or session, of the events of an input binding
+	PartitionKeyMetadataKey = "partitionMetadataKey"
+	// PartitionIDHeader is the event metadata, or header of HTTP apps, of the partition of the events delivered to the app
+	PartitionIDHeader = "dapr-partition-id"
+)
+
+// WithPartitionOrdering returns the input binding delivering the events of a partition one at a time and in the order
+// they're read, when its component metadata names the event metadata holding the partition. The events of different
+// partitions are delivered concurrently as far as the binding reads them concurrently.
+func WithPartitionOrdering(binding bindings.InputBinding, properties map[string]string) bindings.InputBinding {
+	key := properties[PartitionKeyMetadataKey]
+	if key == "" {
+		return binding
+	}
+	return &partitionOrderingBinding{InputBinding: binding, key: key, lanes: map[string]*partitionLane{}}
+}
+
+// partitionLane is the queue of the events of a partition waiting for their delivery
+type partitionLane struct {
+	waiting []chan struct{}
+}
+
+type partitionOrderingBinding struct {
+	bindings.InputBinding
+	key   string
+	lock  sync.Mutex
+	lanes map[string]*partitionLane
+}
+
+func (p *partitionOrderingBinding) Read(handler func(*bindings.ReadResponse) error) error {
+	return p.InputBinding.Read(func(resp *bindings.ReadResponse) error {
+		if resp == nil {
+			return handler(resp)
+		}
+		partition, ok := resp.Metadata[p.key]
+		if !ok {
+			return handler(resp)
+		}
+
+		metadata := make(map[string]string, len(resp.Metadata)+1)
+		for k, v := range resp.Metadata {
+			metadata[k] = v
+		}
+		metadata[PartitionIDHeader] = partition
+		resp.Metadata = metadata
+
+		p.acquire(partition)
+		defer p.release(partition)
+		return handler(resp)
+	})
+}
+
+// acquire waits for the earlier events of the partition to be delivered
+func (p *partitionOrderingBinding) acquire(partition string) {
+	p.lock.Lock()
+	lane, ok := p.lanes[partition]
+	if !ok {
+		p.lanes[partition] = &partitionLane{}
+		p.lock.Unlock()
+		return
+	}
+	turn := make(chan struct{})
+	lane.waiting = append(lane.waiting, turn)
+	p.lock.Unlock()
+	<-turn
+}
+
+// release hands the partition over to its next event, if any
+func (p *partitionOrderingBinding) release(partition string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	lane := p.lanes[partition]
+	if len(lane.waiting) == 0 {
+		delete(p.lanes, partition)
+		return
+	}
+	next := lane.waiting[0]
+	lane.waiting = lane.waiting[1:]
+	close(next)
+}
@@ -0,0 +1,91 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeInputBinding reads its events concurrently, each from its own goroutine, in order of their start
+type fakeInputBinding struct {
+	bindings.InputBinding
+	events []*bindings.ReadResponse
+}
+
+func (f *fakeInputBinding) Read(handler func(*bindings.ReadResponse) error) error {
+	var wg sync.WaitGroup
+	for _, e := range f.events {
+		wg.Add(1)
+		go func(e *bindings.ReadResponse) {
+			defer wg.Done()
+			handler(e)
+		}(e)
+		time.Sleep(time.Millisecond * 5)
+	}
+	wg.Wait()
+	return nil
+}
+
+func event(partition, data string) *bindings.ReadResponse {
+	resp := &bindings.ReadResponse{Data: []byte(data)}
+	if partition != "" {
+		resp.Metadata = map[string]string{"partitionId": partition}
+	}
+	return resp
+}
+
+func TestWithPartitionOrdering(t *testing.T) {
+	t.Run("disabled without partition metadata key", func(t *testing.T) {
+		binding := &fakeInputBinding{}
+		assert.Equal(t, binding, WithPartitionOrdering(binding, map[string]string{}))
+	})
+
+	t.Run("events of a partition are delivered one at a time in order", func(t *testing.T) {
+		binding := WithPartitionOrdering(&fakeInputBinding{events: []*bindings.ReadResponse{
+			event("0", "a1"), event("1", "b1"), event("0", "a2"), event("1", "b2"), event("0", "a3"), event("", "c1"),
+		}}, map[string]string{PartitionKeyMetadataKey: "partitionId"})
+
+		var lock sync.Mutex
+		delivered := map[string][]string{}
+		active := map[string]int{}
+		maxActive, overlapping := 0, false
+		binding.Read(func(resp *bindings.ReadResponse) error {
+			partition := resp.Metadata[PartitionIDHeader]
+			lock.Lock()
+			active[partition]++
+			if active[partition] > 1 {
+				overlapping = true
+			}
+			total := 0
+			for _, n := range active {
+				total += n
+			}
+			if total > maxActive {
+				maxActive = total
+			}
+			lock.Unlock()
+
+			time.Sleep(time.Millisecond * 30)
+
+			lock.Lock()
+			active[partition]--
+			delivered[partition] = append(delivered[partition], string(resp.Data))
+			lock.Unlock()
+			return nil
+		})
+
+		assert.False(t, overlapping)
+		assert.True(t, maxActive > 1)
+		assert.Equal(t, []string{"a1", "a2", "a3"}, delivered["0"])
+		assert.Equal(t, []string{"b1", "b2"}, delivered["1"])
+		assert.Equal(t, []string{"c1"}, delivered[""])
+	})
+}
@@ -9,18 +9,21 @@ import (
 	"fmt"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/components"
 )
 
 type (
 	// InputBinding is an input binding component definition.
 	InputBinding struct {
 		Name          string
+		Version       string
 		FactoryMethod func() bindings.InputBinding
 	}
 
 	// OutputBinding is an output binding component definition.
 	OutputBinding struct {
 		Name          string
+		Version       string
 		FactoryMethod func() bindings.OutputBinding
 	}
 
@@ -28,8 +31,8 @@ type (
 	Registry interface {
 		RegisterInputBindings(components ...InputBinding)
 		RegisterOutputBindings(components ...OutputBinding)
-		CreateInputBinding(name string) (bindings.InputBinding, error)
-		CreateOutputBinding(name string) (bindings.OutputBinding, error)
+		CreateInputBinding(name, version string) (bindings.InputBinding, error)
+		CreateOutputBinding(name, version string) (bindings.OutputBinding, error)
 	}
 
 	bindingsRegistry struct {
@@ -54,6 +57,24 @@ func NewOutput(name string, factoryMethod func() bindings.OutputBinding) OutputB
 	}
 }
 
+// NewVersionedInput creates a version of an InputBinding, e.g. v2.
+func NewVersionedInput(name, version string, factoryMethod func() bindings.InputBinding) InputBinding {
+	return InputBinding{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
+// NewVersionedOutput creates a version of an OutputBinding, e.g. v2.
+func NewVersionedOutput(name, version string, factoryMethod func() bindings.OutputBinding) OutputBinding {
+	return OutputBinding{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
 // NewRegistry is used to create new bindings.
 func NewRegistry() Registry {
 	return &bindingsRegistry{
@@ -63,33 +84,33 @@ func NewRegistry() Registry {
 }
 
 // RegisterInputBindings registers one or more new input bindings.
-func (b *bindingsRegistry) RegisterInputBindings(components ...InputBinding) {
-	for _, component := range components {
-		b.inputBindings[createFullName(component.Name)] = component.FactoryMethod
+func (b *bindingsRegistry) RegisterInputBindings(definitions ...InputBinding) {
+	for _, component := range definitions {
+		b.inputBindings[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
 	}
 }
 
 // RegisterOutputBindings registers one or more new output bindings.
-func (b *bindingsRegistry) RegisterOutputBindings(components ...OutputBinding) {
-	for _, component := range components {
-		b.outputBindings[createFullName(component.Name)] = component.FactoryMethod
+func (b *bindingsRegistry) RegisterOutputBindings(definitions ...OutputBinding) {
+	for _, component := range definitions {
+		b.outputBindings[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
 	}
 }
 
-// Create instantiates an input binding based on `name`.
-func (b *bindingsRegistry) CreateInputBinding(name string) (bindings.InputBinding, error) {
-	if method, ok := b.inputBindings[name]; ok {
+// Create instantiates a version of an input binding based on `name`.
+func (b *bindingsRegistry) CreateInputBinding(name, version string) (bindings.InputBinding, error) {
+	if method, ok := b.inputBindings[components.VersionedName(name, version)]; ok {
 		return method(), nil
 	}
-	return nil, fmt.Errorf("couldn't find input binding %s", name)
+	return nil, fmt.Errorf("couldn't find input binding %s", components.TypeVersion(name, version))
 }
 
-// Create instantiates an output binding based on `name`.
-func (b *bindingsRegistry) CreateOutputBinding(name string) (bindings.OutputBinding, error) {
-	if method, ok := b.outputBindings[name]; ok {
+// Create instantiates a version of an output binding based on `name`.
+func (b *bindingsRegistry) CreateOutputBinding(name, version string) (bindings.OutputBinding, error) {
+	if method, ok := b.outputBindings[components.VersionedName(name, version)]; ok {
 		return method(), nil
 	}
-	return nil, fmt.Errorf("couldn't find output binding %s", name)
+	return nil, fmt.Errorf("couldn't find output binding %s", components.TypeVersion(name, version))
 }
 
 func createFullName(name string) string {
@@ -0,0 +1,119 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/leadership"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+var log = logger.NewLogger("dapr.runtime.bindings")
+
+const (
+	// SingletonStoreMetadataKey is the component metadata key of the state store electing the one sidecar of the app
+	// reading an input binding, e.g. a cron binding that must trigger once for all the replicas of the app.
+	// The store must have the insert feature.
+	SingletonStoreMetadataKey = "singletonStore"
+	// SingletonTTLMetadataKey is the component metadata key of the duration of the lease of the elected sidecar,
+	// bounding the time the binding isn't read after the elected sidecar fails
+	SingletonTTLMetadataKey = "singletonTTL"
+)
+
+// ErrNotLeader is returned to the input binding for the events it reads after the sidecar lost its leadership
+var ErrNotLeader = errors.New("the sidecar is not the leader of the singleton input binding")
+
+// campaignRetryInterval is the wait before campaigning again after a failed campaign
+const campaignRetryInterval = time.Second
+
+// WithSingleton returns the input binding reading its events only once the candidate is elected leader of the
+// election. The candidate keeps campaigning while it's not the leader, and rejects the events read after it lost its
+// leadership since input bindings can't stop reading.
+func WithSingleton(binding bindings.InputBinding, elector *leadership.Elector, election, candidate string, ttl time.Duration) bindings.InputBinding {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &singletonBinding{
+		InputBinding: binding,
+		elector:      elector,
+		election:     election,
+		candidate:    candidate,
+		ttl:          ttl,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+type singletonBinding struct {
+	bindings.InputBinding
+	elector   *leadership.Elector
+	election  string
+	candidate string
+	ttl       time.Duration
+	leader    int32
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+func (s *singletonBinding) Read(handler func(*bindings.ReadResponse) error) error {
+	elected := make(chan struct{})
+	var once sync.Once
+	go s.campaign(func() {
+		once.Do(func() { close(elected) })
+	})
+
+	select {
+	case <-elected:
+	case <-s.ctx.Done():
+		return nil
+	}
+	return s.InputBinding.Read(func(resp *bindings.ReadResponse) error {
+		if atomic.LoadInt32(&s.leader) == 0 {
+			return ErrNotLeader
+		}
+		return handler(resp)
+	})
+}
+
+// campaign campaigns for the leadership until the binding is closed
+func (s *singletonBinding) campaign(elected func()) {
+	for {
+		err := s.elector.Campaign(s.ctx, s.election, s.candidate, s.ttl, func() {
+			atomic.StoreInt32(&s.leader, 1)
+			log.Infof("elected leader of singleton input binding %s", s.election)
+			elected()
+		})
+		atomic.StoreInt32(&s.leader, 0)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		default:
+		}
+		if err != nil {
+			log.Warnf("campaign for singleton input binding %s ended: %s", s.election, err)
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(campaignRetryInterval):
+		}
+	}
+}
+
+// Close ends the campaign, releasing the leadership to another sidecar, and closes the binding
+func (s *singletonBinding) Close() error {
+	s.cancel()
+	if closer, ok := s.InputBinding.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/components/state/inmemory"
+	"github.com/dapr/dapr/pkg/leadership"
+	"github.com/stretchr/testify/assert"
+)
+
+// tickingInputBinding reads an event every few milliseconds, like a cron binding, until it's closed
+type tickingInputBinding struct {
+	bindings.InputBinding
+	reading chan struct{}
+	closed  chan struct{}
+}
+
+func newTickingInputBinding() *tickingInputBinding {
+	return &tickingInputBinding{reading: make(chan struct{}), closed: make(chan struct{})}
+}
+
+func (f *tickingInputBinding) Read(handler func(*bindings.ReadResponse) error) error {
+	close(f.reading)
+	for {
+		select {
+		case <-f.closed:
+			return nil
+		case <-time.After(time.Millisecond):
+			handler(&bindings.ReadResponse{})
+		}
+	}
+}
+
+func (f *tickingInputBinding) Close() error {
+	close(f.closed)
+	return nil
+}
+
+// racingStore holds the gets of an in-memory store until its gate is closed, to have candidates race on the same ETag
+type racingStore struct {
+	*inmemory.StateStore
+	gate  chan struct{}
+	reads chan struct{}
+}
+
+func (r *racingStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	resp, err := r.StateStore.Get(req)
+	select {
+	case r.reads <- struct{}{}:
+	default:
+	}
+	<-r.gate
+	return resp, err
+}
+
+func TestWithSingleton(t *testing.T) {
+	store := inmemory.NewStateStore()
+	first, second := newTickingInputBinding(), newTickingInputBinding()
+	a := WithSingleton(first, leadership.NewElector(store), "app||bindings||cron", "10.0.0.1:50002", 30*time.Millisecond)
+	b := WithSingleton(second, leadership.NewElector(store), "app||bindings||cron", "10.0.0.2:50002", 30*time.Millisecond)
+
+	go a.Read(func(*bindings.ReadResponse) error { return nil })
+	<-first.reading
+	go b.Read(func(*bindings.ReadResponse) error { return nil })
+
+	t.Run("only the leader reads", func(t *testing.T) {
+		select {
+		case <-second.reading:
+			t.Fatal("the binding of the other candidate is read")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("closing the leader releases the leadership", func(t *testing.T) {
+		assert.NoError(t, a.(*singletonBinding).Close())
+		select {
+		case <-second.reading:
+		case <-time.After(time.Second):
+			t.Fatal("the binding of the other candidate isn't read")
+		}
+		b.(*singletonBinding).Close()
+	})
+
+	t.Run("candidates racing on an empty store elect one reader", func(t *testing.T) {
+		store := &racingStore{StateStore: inmemory.NewStateStore(), gate: make(chan struct{}), reads: make(chan struct{}, 2)}
+		first, second := newTickingInputBinding(), newTickingInputBinding()
+		a := WithSingleton(first, leadership.NewElector(store), "app||bindings||cron", "10.0.0.1:50002", time.Minute)
+		b := WithSingleton(second, leadership.NewElector(store), "app||bindings||cron", "10.0.0.2:50002", time.Minute)
+		defer a.(*singletonBinding).Close()
+		defer b.(*singletonBinding).Close()
+		go a.Read(func(*bindings.ReadResponse) error { return nil })
+		go b.Read(func(*bindings.ReadResponse) error { return nil })
+
+		// both candidates read the missing lease before any of them creates it
+		<-store.reads
+		<-store.reads
+		close(store.gate)
+
+		reading := 0
+		for _, binding := range []*tickingInputBinding{first, second} {
+			select {
+			case <-binding.reading:
+				reading++
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+		assert.Equal(t, 1, reading)
+	})
+}
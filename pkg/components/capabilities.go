@@ -0,0 +1,62 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package components
+
+const (
+	// FeatureTransactional is the feature of state stores that execute multiple operations in a transaction
+	FeatureTransactional = "transactional"
+	// FeatureETag is the feature of state stores that enforce ETags for optimistic concurrency
+	FeatureETag = "etag"
+	// FeatureQuery is the feature of state stores that query values
+	FeatureQuery = "query"
+	// FeatureTTL is the feature of state stores that expire keys
+	FeatureTTL = "ttl"
+	// FeatureStreaming is the feature of components that push changes or events to the sidecar
+	FeatureStreaming = "streaming"
+	// FeatureBulkDelete is the feature of state stores that delete multiple keys in one operation
+	FeatureBulkDelete = "bulkDelete"
+	// FeatureDeleteByPrefix is the feature of state stores that list their keys and opted in deleting keys by prefix
+	FeatureDeleteByPrefix = "deleteByPrefix"
+	// FeatureInsert is the feature of state stores that create keys only if they don't exist, for leases and sequences
+	FeatureInsert = "insert"
+	// FeatureBulkGet is the feature of secret stores that read all their secrets at once
+	FeatureBulkGet = "bulkGet"
+)
+
+// Capabilities are the features of a loaded component, so that apps can adapt to them
+type Capabilities struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Features []string `json:"features"`
+	// Aliases are the other names of the component, including an empty name for the default state store
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// FeaturesProvider is implemented by components that declare their features.
+// The features of other components are detected from the interfaces they implement.
+type FeaturesProvider interface {
+	Features() []string
+}
+
+// HasFeature returns whether the component, called by its name or an alias, has the feature
+func HasFeature(capabilities []Capabilities, name, feature string) bool {
+	for _, c := range capabilities {
+		if c.Name != name && !contains(c.Aliases, name) {
+			continue
+		}
+		return contains(c.Features, feature)
+	}
+	return false
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package configuration
+
+import (
+	"context"
+)
+
+// Metadata is the metadata of a configuration store component
+type Metadata struct {
+	Properties map[string]string
+}
+
+// Item is the value of a configuration key. The version changes each time the value changes.
+type Item struct {
+	Key      string
+	Value    string
+	Version  string
+	Metadata map[string]string
+}
+
+// GetRequest gets the items of the keys, or all the items when there are no keys
+type GetRequest struct {
+	Keys     []string
+	Metadata map[string]string
+}
+
+// GetResponse is the items of the keys found in the store
+type GetResponse struct {
+	Items []*Item
+}
+
+// SubscribeRequest subscribes to the changes of the keys, or of all the keys when there are no keys
+type SubscribeRequest struct {
+	Keys     []string
+	Metadata map[string]string
+}
+
+// UpdateEvent is the items changed together. Deleted keys have an item with an empty value and version.
+type UpdateEvent struct {
+	Items []*Item
+}
+
+// Store is a configuration store component, serving read-only keys of the apps, e.g. feature flags
+type Store interface {
+	Init(metadata Metadata) error
+	Get(ctx context.Context, req *GetRequest) (*GetResponse, error)
+	// Subscribe calls handler with the changes of the keys of the request until ctx is done
+	Subscribe(ctx context.Context, req *SubscribeRequest, handler func(*UpdateEvent)) error
+}
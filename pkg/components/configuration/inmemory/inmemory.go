@@ -0,0 +1,131 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package inmemory is a configuration store serving the metadata of its component as configuration keys, for tests
+// and local development. Updating the component updates the keys and notifies the subscribers.
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/dapr/dapr/pkg/components/configuration"
+)
+
+// Store is an in-memory configuration store
+type Store struct {
+	lock        sync.RWMutex
+	items       map[string]*configuration.Item
+	version     uint64
+	subscribers map[*subscriber]bool
+}
+
+type subscriber struct {
+	keys    map[string]bool
+	handler func(*configuration.UpdateEvent)
+}
+
+// NewStore returns an empty in-memory configuration store
+func NewStore() *Store {
+	return &Store{
+		items:       map[string]*configuration.Item{},
+		subscribers: map[*subscriber]bool{},
+	}
+}
+
+// Init replaces the keys with the metadata properties, and notifies the subscribers of the changed keys
+func (s *Store) Init(metadata configuration.Metadata) error {
+	s.lock.Lock()
+	var changed []*configuration.Item
+	for k, v := range metadata.Properties {
+		if item, ok := s.items[k]; ok && item.Value == v {
+			continue
+		}
+		s.version++
+		item := &configuration.Item{Key: k, Value: v, Version: strconv.FormatUint(s.version, 10)}
+		s.items[k] = item
+		changed = append(changed, item)
+	}
+	for k := range s.items {
+		if _, ok := metadata.Properties[k]; !ok {
+			delete(s.items, k)
+			changed = append(changed, &configuration.Item{Key: k})
+		}
+	}
+	notify := s.notifying(changed)
+	s.lock.Unlock()
+
+	notify()
+	return nil
+}
+
+// Get returns the items of the keys, or all the items sorted by key when there are no keys
+func (s *Store) Get(ctx context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	resp := &configuration.GetResponse{Items: []*configuration.Item{}}
+	if len(req.Keys) == 0 {
+		for _, item := range s.items {
+			resp.Items = append(resp.Items, copyItem(item))
+		}
+		sort.Slice(resp.Items, func(i, j int) bool { return resp.Items[i].Key < resp.Items[j].Key })
+		return resp, nil
+	}
+	for _, k := range req.Keys {
+		if item, ok := s.items[k]; ok {
+			resp.Items = append(resp.Items, copyItem(item))
+		}
+	}
+	return resp, nil
+}
+
+// Subscribe calls handler with the changes of the keys until ctx is done
+func (s *Store) Subscribe(ctx context.Context, req *configuration.SubscribeRequest, handler func(*configuration.UpdateEvent)) error {
+	sub := &subscriber{keys: map[string]bool{}, handler: handler}
+	for _, k := range req.Keys {
+		sub.keys[k] = true
+	}
+
+	s.lock.Lock()
+	s.subscribers[sub] = true
+	s.lock.Unlock()
+
+	<-ctx.Done()
+
+	s.lock.Lock()
+	delete(s.subscribers, sub)
+	s.lock.Unlock()
+	return nil
+}
+
+// notifying returns a function calling the subscribers of the changed items, to be called without holding the lock
+func (s *Store) notifying(changed []*configuration.Item) func() {
+	calls := []func(){}
+	for sub := range s.subscribers {
+		e := &configuration.UpdateEvent{}
+		for _, item := range changed {
+			if len(sub.keys) == 0 || sub.keys[item.Key] {
+				e.Items = append(e.Items, copyItem(item))
+			}
+		}
+		if len(e.Items) > 0 {
+			handler := sub.handler
+			calls = append(calls, func() { handler(e) })
+		}
+	}
+	return func() {
+		for _, call := range calls {
+			call()
+		}
+	}
+}
+
+func copyItem(item *configuration.Item) *configuration.Item {
+	c := *item
+	return &c
+}
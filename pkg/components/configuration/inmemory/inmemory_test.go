@@ -0,0 +1,84 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/components/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	s := NewStore()
+	assert.NoError(t, s.Init(configuration.Metadata{Properties: map[string]string{"b": "2", "a": "1"}}))
+
+	t.Run("all keys", func(t *testing.T) {
+		resp, err := s.Get(context.Background(), &configuration.GetRequest{})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, 2)
+		assert.Equal(t, "a", resp.Items[0].Key)
+		assert.Equal(t, "1", resp.Items[0].Value)
+	})
+
+	t.Run("missing keys are skipped", func(t *testing.T) {
+		resp, err := s.Get(context.Background(), &configuration.GetRequest{Keys: []string{"b", "c"}})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, 1)
+		assert.Equal(t, "2", resp.Items[0].Value)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	s := NewStore()
+	assert.NoError(t, s.Init(configuration.Metadata{Properties: map[string]string{"a": "1", "b": "2"}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan *configuration.UpdateEvent, 10)
+	done := make(chan error)
+	go func() {
+		done <- s.Subscribe(ctx, &configuration.SubscribeRequest{Keys: []string{"a", "c"}}, func(e *configuration.UpdateEvent) {
+			events <- e
+		})
+	}()
+	// wait for the subscription
+	for {
+		s.lock.RLock()
+		n := len(s.subscribers)
+		s.lock.RUnlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.NoError(t, s.Init(configuration.Metadata{Properties: map[string]string{"b": "3", "c": "4"}}))
+	e := <-events
+	assert.Len(t, e.Items, 2)
+	for _, item := range e.Items {
+		switch item.Key {
+		case "a":
+			assert.Equal(t, "", item.Value, "deleted keys have an empty value")
+		case "c":
+			assert.Equal(t, "4", item.Value)
+			assert.NotEmpty(t, item.Version)
+		default:
+			t.Fatalf("unexpected key %s", item.Key)
+		}
+	}
+
+	assert.NoError(t, s.Init(configuration.Metadata{Properties: map[string]string{"b": "5", "c": "4"}}))
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event %v", e)
+	default:
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
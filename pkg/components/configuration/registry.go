@@ -0,0 +1,75 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package configuration
+
+import (
+	"fmt"
+
+	"github.com/dapr/dapr/pkg/components"
+)
+
+type (
+	// Configuration is a configuration store component definition.
+	Configuration struct {
+		Name          string
+		Version       string
+		FactoryMethod func() Store
+	}
+
+	// Registry is used to get registered configuration store implementations
+	Registry interface {
+		Register(components ...Configuration)
+		Create(name, version string) (Store, error)
+	}
+
+	configurationStoreRegistry struct {
+		configurationStores map[string]func() Store
+	}
+)
+
+// New creates a Configuration.
+func New(name string, factoryMethod func() Store) Configuration {
+	return Configuration{
+		Name:          name,
+		FactoryMethod: factoryMethod,
+	}
+}
+
+// NewVersioned creates a version of a Configuration, e.g. v2.
+func NewVersioned(name, version string, factoryMethod func() Store) Configuration {
+	return Configuration{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
+// NewRegistry returns a new configuration store registry.
+func NewRegistry() Registry {
+	return &configurationStoreRegistry{
+		configurationStores: map[string]func() Store{},
+	}
+}
+
+// Register adds one or many new configuration stores to the registry.
+func (s *configurationStoreRegistry) Register(definitions ...Configuration) {
+	for _, component := range definitions {
+		s.configurationStores[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
+	}
+}
+
+// Create instantiates a version of a configuration store based on `name`.
+func (s *configurationStoreRegistry) Create(name, version string) (Store, error) {
+	if method, ok := s.configurationStores[components.VersionedName(name, version)]; ok {
+		return method(), nil
+	}
+
+	return nil, fmt.Errorf("couldn't find configuration store %s", components.TypeVersion(name, version))
+}
+
+func createFullName(name string) string {
+	return fmt.Sprintf("configuration.%s", name)
+}
@@ -0,0 +1,95 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package components
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+var envVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// expandComponentEnv expands the environment variables of the metadata values of a component.
+// Errors name the metadata items and variables, never their values, so that they can be logged.
+func expandComponentEnv(component *components_v1alpha1.Component) error {
+	for i, m := range component.Spec.Metadata {
+		v, err := expandEnv(m.Value, os.LookupEnv)
+		if err != nil {
+			return fmt.Errorf("component %s: metadata item %s: %s", component.Name, m.Name, err)
+		}
+		component.Spec.Metadata[i].Value = v
+	}
+	return nil
+}
+
+// expandEnv expands the environment variables of a value. ${VAR} is required and fails when VAR isn't set, and
+// ${VAR:?message} fails with the message when VAR isn't set or empty. ${VAR:-default} is optional and expands to
+// default when VAR isn't set or empty. $${ is a literal ${.
+func expandEnv(value string, lookup func(string) (string, bool)) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(value, "${")
+		if i < 0 {
+			b.WriteString(value)
+			return b.String(), nil
+		}
+		if i > 0 && value[i-1] == '$' {
+			b.WriteString(value[:i-1])
+			b.WriteString("${")
+			value = value[i+2:]
+			continue
+		}
+		b.WriteString(value[:i])
+
+		end := strings.Index(value[i:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unclosed ${ in value")
+		}
+		v, err := expandExpression(value[i+2:i+end], lookup)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(v)
+		value = value[i+end+1:]
+	}
+}
+
+func expandExpression(expression string, lookup func(string) (string, bool)) (string, error) {
+	name, operator, operand := expression, "", ""
+	if i := strings.Index(expression, ":"); i >= 0 && i+1 < len(expression) {
+		name, operator, operand = expression[:i], expression[i:i+2], expression[i+2:]
+	}
+	if !envVarName.MatchString(name) {
+		return "", fmt.Errorf("invalid environment variable %q", name)
+	}
+
+	v, ok := lookup(name)
+	switch operator {
+	case "":
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+	case ":?":
+		if !ok || v == "" {
+			return "", fmt.Errorf("environment variable %s is not set: %s", name, operand)
+		}
+	case ":-":
+		if !ok || v == "" {
+			return operand, nil
+		}
+	default:
+		return "", fmt.Errorf("invalid expansion of environment variable %s", name)
+	}
+	return v, nil
+}
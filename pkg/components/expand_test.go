@@ -0,0 +1,88 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package components
+
+import (
+	"os"
+	"testing"
+
+	config "github.com/dapr/dapr/pkg/config/modes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{"HOST": "redis", "PORT": "6379", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		value    string
+		expected string
+		err      bool
+	}{
+		{value: "localhost:6379", expected: "localhost:6379"},
+		{value: "${HOST}:${PORT}", expected: "redis:6379"},
+		{value: "${EMPTY}", expected: ""},
+		{value: "${MISSING}", err: true},
+		{value: "${MISSING:-localhost}", expected: "localhost"},
+		{value: "${EMPTY:-localhost}", expected: "localhost"},
+		{value: "${HOST:-localhost}", expected: "redis"},
+		{value: "${HOST:?the redis host is required}", expected: "redis"},
+		{value: "${EMPTY:?the redis host is required}", err: true},
+		{value: "$${HOST}", expected: "${HOST}"},
+		{value: "$5", expected: "$5"},
+		{value: "${HOST", err: true},
+		{value: "${1HOST}", err: true},
+		{value: "${HOST:+x}", err: true},
+	}
+	for _, tt := range tests {
+		v, err := expandEnv(tt.value, lookup)
+		if tt.err {
+			assert.Error(t, err, tt.value)
+		} else {
+			assert.NoError(t, err, tt.value)
+			assert.Equal(t, tt.expected, v, tt.value)
+		}
+	}
+}
+
+func TestStandaloneDecodeYamlExpandsEnv(t *testing.T) {
+	os.Setenv("DAPR_TEST_REDIS_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("DAPR_TEST_REDIS_PASSWORD")
+
+	request := &StandaloneComponents{
+		config: config.StandaloneConfig{
+			ComponentsPath: "test_component_path",
+		},
+	}
+	yaml := `
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: statestore
+spec:
+   type: state.redis
+   metadata:
+   - name: redisPassword
+     value: ${DAPR_TEST_REDIS_PASSWORD}
+---
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: pubsub
+spec:
+   type: pubsub.redis
+   metadata:
+   - name: redisPassword
+     value: ${DAPR_TEST_MISSING_PASSWORD}`
+	components, errs := request.decodeYaml("components/redis.yaml", []byte(yaml))
+	assert.Len(t, components, 1)
+	assert.Equal(t, "s3cr3t", components[0].Spec.Metadata[0].Value)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "DAPR_TEST_MISSING_PASSWORD")
+}
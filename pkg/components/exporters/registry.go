@@ -9,19 +9,21 @@ import (
 	"fmt"
 
 	"github.com/dapr/components-contrib/exporters"
+	"github.com/dapr/dapr/pkg/components"
 )
 
 type (
 	// Exporter is an exporter component definition.
 	Exporter struct {
 		Name          string
+		Version       string
 		FactoryMethod func() exporters.Exporter
 	}
 
 	// Registry is the interface for callers to get registered exporter components
 	Registry interface {
 		Register(components ...Exporter)
-		Create(name string) (exporters.Exporter, error)
+		Create(name, version string) (exporters.Exporter, error)
 	}
 
 	exporterRegistry struct {
@@ -37,6 +39,15 @@ func New(name string, factoryMethod func() exporters.Exporter) Exporter {
 	}
 }
 
+// NewVersioned creates a version of an Exporter, e.g. v2.
+func NewVersioned(name, version string, factoryMethod func() exporters.Exporter) Exporter {
+	return Exporter{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
 // NewRegistry returns a new exporter registry.
 func NewRegistry() Registry {
 	return &exporterRegistry{
@@ -45,18 +56,18 @@ func NewRegistry() Registry {
 }
 
 // Register registers one or more new exporters.
-func (p *exporterRegistry) Register(components ...Exporter) {
-	for _, component := range components {
-		p.exporters[createFullName(component.Name)] = component.FactoryMethod
+func (p *exporterRegistry) Register(definitions ...Exporter) {
+	for _, component := range definitions {
+		p.exporters[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
 	}
 }
 
-// Create instantiates an exporter based on `name`.
-func (p *exporterRegistry) Create(name string) (exporters.Exporter, error) {
-	if method, ok := p.exporters[name]; ok {
+// Create instantiates a version of an exporter based on `name`.
+func (p *exporterRegistry) Create(name, version string) (exporters.Exporter, error) {
+	if method, ok := p.exporters[components.VersionedName(name, version)]; ok {
 		return method(), nil
 	}
-	return nil, fmt.Errorf("couldn't find exporter %s", name)
+	return nil, fmt.Errorf("couldn't find exporter %s", components.TypeVersion(name, version))
 }
 
 func createFullName(name string) string {
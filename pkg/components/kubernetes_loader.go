@@ -11,7 +11,9 @@ import (
 	"time"
 
 	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	global_config "github.com/dapr/dapr/pkg/config"
 	config "github.com/dapr/dapr/pkg/config/modes"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/logger"
 	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -27,21 +29,25 @@ const (
 
 // KubernetesComponents loads components in a kubernetes environment
 type KubernetesComponents struct {
-	config config.KubernetesConfig
-	client operatorv1pb.OperatorClient
+	config      config.KubernetesConfig
+	client      operatorv1pb.OperatorClient
+	tracingSpec global_config.TracingSpec
 }
 
 // NewKubernetesComponents returns a new kubernetes loader
-func NewKubernetesComponents(configuration config.KubernetesConfig, operatorClient operatorv1pb.OperatorClient) *KubernetesComponents {
+func NewKubernetesComponents(configuration config.KubernetesConfig, operatorClient operatorv1pb.OperatorClient, tracingSpec global_config.TracingSpec) *KubernetesComponents {
 	return &KubernetesComponents{
-		config: configuration,
-		client: operatorClient,
+		config:      configuration,
+		client:      operatorClient,
+		tracingSpec: tracingSpec,
 	}
 }
 
 // LoadComponents returns components from a given control plane address
 func (k *KubernetesComponents) LoadComponents() ([]components_v1alpha1.Component, error) {
-	resp, err := k.client.GetComponents(context.Background(), &empty.Empty{}, grpc_retry.WithMax(operatorMaxRetries), grpc_retry.WithPerRetryTimeout(operatorCallTimeout))
+	ctx, call := diag.StartControlPlaneCall(context.Background(), diag.ControlPlaneOperator, "GetComponents", k.tracingSpec)
+	resp, err := k.client.GetComponents(ctx, &empty.Empty{}, grpc_retry.WithMax(operatorMaxRetries), grpc_retry.WithPerRetryTimeout(operatorCallTimeout))
+	call.End(err)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +46,14 @@ func (o *mockOperator) ComponentUpdate(in *empty.Empty, srv operatorv1pb.Operato
 	return nil
 }
 
+func (o *mockOperator) ReportComponentStatus(ctx context.Context, in *operatorv1pb.ReportComponentStatusRequest) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (o *mockOperator) SetTopicPaused(ctx context.Context, in *operatorv1pb.SetTopicPausedRequest) (*operatorv1pb.SetTopicPausedResponse, error) {
+	return &operatorv1pb.SetTopicPausedResponse{}, nil
+}
+
 func getOperatorClient(address string) operatorv1pb.OperatorClient {
 	conn, _ := grpc.Dial(address, grpc.WithInsecure())
 	return operatorv1pb.NewOperatorClient(conn)
@@ -0,0 +1,76 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actor
+
+import (
+	"fmt"
+
+	middleware "github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/components"
+	actor_middleware "github.com/dapr/dapr/pkg/middleware/actor"
+)
+
+type (
+	// Middleware is an actor middleware component definition.
+	Middleware struct {
+		Name          string
+		Version       string
+		FactoryMethod func(metadata middleware.Metadata) (actor_middleware.Middleware, error)
+	}
+
+	// Registry is the interface for callers to get registered actor middleware
+	Registry interface {
+		Register(components ...Middleware)
+		Create(name, version string, metadata middleware.Metadata) (actor_middleware.Middleware, error)
+	}
+
+	actorMiddlewareRegistry struct {
+		middleware map[string]func(middleware.Metadata) (actor_middleware.Middleware, error)
+	}
+)
+
+// New creates a Middleware.
+func New(name string, factoryMethod func(metadata middleware.Metadata) (actor_middleware.Middleware, error)) Middleware {
+	return Middleware{
+		Name:          name,
+		FactoryMethod: factoryMethod,
+	}
+}
+
+// NewVersioned creates a version of a Middleware, e.g. v2.
+func NewVersioned(name, version string, factoryMethod func(metadata middleware.Metadata) (actor_middleware.Middleware, error)) Middleware {
+	return Middleware{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
+// NewRegistry returns a new actor middleware registry.
+func NewRegistry() Registry {
+	return &actorMiddlewareRegistry{
+		middleware: map[string]func(middleware.Metadata) (actor_middleware.Middleware, error){},
+	}
+}
+
+// Register registers one or more new actor middlewares.
+func (p *actorMiddlewareRegistry) Register(definitions ...Middleware) {
+	for _, component := range definitions {
+		p.middleware[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
+	}
+}
+
+// Create instantiates a version of an actor middleware based on `name`.
+func (p *actorMiddlewareRegistry) Create(name, version string, metadata middleware.Metadata) (actor_middleware.Middleware, error) {
+	if method, ok := p.middleware[components.VersionedName(name, version)]; ok {
+		return method(metadata)
+	}
+	return nil, fmt.Errorf("actor middleware %s has not been registered", components.TypeVersion(name, version))
+}
+
+func createFullName(name string) string {
+	return fmt.Sprintf("middleware.actor.%s", name)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	middleware "github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/components"
 	http_middleware "github.com/dapr/dapr/pkg/middleware/http"
 )
 
@@ -16,13 +17,14 @@ type (
 	// Middleware is a HTTP middleware component definition.
 	Middleware struct {
 		Name          string
+		Version       string
 		FactoryMethod func(metadata middleware.Metadata) http_middleware.Middleware
 	}
 
 	// Registry is the interface for callers to get registered HTTP middleware
 	Registry interface {
 		Register(components ...Middleware)
-		Create(name string, metadata middleware.Metadata) (http_middleware.Middleware, error)
+		Create(name, version string, metadata middleware.Metadata) (http_middleware.Middleware, error)
 	}
 
 	httpMiddlewareRegistry struct {
@@ -38,6 +40,15 @@ func New(name string, factoryMethod func(metadata middleware.Metadata) http_midd
 	}
 }
 
+// NewVersioned creates a version of a Middleware, e.g. v2.
+func NewVersioned(name, version string, factoryMethod func(metadata middleware.Metadata) http_middleware.Middleware) Middleware {
+	return Middleware{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
 // NewRegistry returns a new HTTP middleware registry.
 func NewRegistry() Registry {
 	return &httpMiddlewareRegistry{
@@ -46,18 +57,18 @@ func NewRegistry() Registry {
 }
 
 // Register registers one or more new HTTP middlewares.
-func (p *httpMiddlewareRegistry) Register(components ...Middleware) {
-	for _, component := range components {
-		p.middleware[createFullName(component.Name)] = component.FactoryMethod
+func (p *httpMiddlewareRegistry) Register(definitions ...Middleware) {
+	for _, component := range definitions {
+		p.middleware[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
 	}
 }
 
-// Create instantiates a HTTP middleware based on `name`.
-func (p *httpMiddlewareRegistry) Create(name string, metadata middleware.Metadata) (http_middleware.Middleware, error) {
-	if method, ok := p.middleware[name]; ok {
+// Create instantiates a version of a HTTP middleware based on `name`.
+func (p *httpMiddlewareRegistry) Create(name, version string, metadata middleware.Metadata) (http_middleware.Middleware, error) {
+	if method, ok := p.middleware[components.VersionedName(name, version)]; ok {
 		return method(metadata), nil
 	}
-	return nil, fmt.Errorf("HTTP middleware %s has not been registered", name)
+	return nil, fmt.Errorf("HTTP middleware %s has not been registered", components.TypeVersion(name, version))
 }
 
 func createFullName(name string) string {
@@ -0,0 +1,466 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// avroSchema is a parsed Avro schema. Values are encoded from and decoded to the types of encoding/json:
+// records and maps are map[string]interface{}, arrays []interface{}, bytes and fixed strings,
+// and unions the value of their branch.
+type avroSchema struct {
+	typ     string
+	name    string
+	fields  []avroField
+	symbols []string
+	// items is the schema of the items of arrays and of the values of maps
+	items *avroSchema
+	union []*avroSchema
+	size  int
+}
+
+type avroField struct {
+	name       string
+	schema     *avroSchema
+	def        interface{}
+	hasDefault bool
+}
+
+var avroPrimitives = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true, "float": true, "double": true, "bytes": true, "string": true,
+}
+
+func parseAvroSchema(schema string) (*avroSchema, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(schema), &v); err != nil {
+		return nil, fmt.Errorf("avro schema is not JSON: %s", err)
+	}
+	return newAvroParser().parse(v, "")
+}
+
+type avroParser struct {
+	// named holds the named types by full name, and by name for references without namespace
+	named map[string]*avroSchema
+}
+
+func newAvroParser() *avroParser {
+	return &avroParser{named: map[string]*avroSchema{}}
+}
+
+func (p *avroParser) parse(v interface{}, namespace string) (*avroSchema, error) {
+	switch s := v.(type) {
+	case string:
+		if avroPrimitives[s] {
+			return &avroSchema{typ: s}, nil
+		}
+		if named, ok := p.named[s]; ok {
+			return named, nil
+		}
+		if named, ok := p.named[fullName(s, namespace)]; ok {
+			return named, nil
+		}
+		return nil, fmt.Errorf("unknown avro type %s", s)
+	case []interface{}:
+		union := &avroSchema{typ: "union"}
+		for _, b := range s {
+			branch, err := p.parse(b, namespace)
+			if err != nil {
+				return nil, err
+			}
+			union.union = append(union.union, branch)
+		}
+		return union, nil
+	case map[string]interface{}:
+		return p.parseComplex(s, namespace)
+	}
+	return nil, fmt.Errorf("invalid avro schema %v", v)
+}
+
+func (p *avroParser) parseComplex(s map[string]interface{}, namespace string) (*avroSchema, error) {
+	typ, _ := s["type"].(string)
+	switch typ {
+	case "record", "error", "enum", "fixed":
+		name, _ := s["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("avro %s has no name", typ)
+		}
+		if ns, ok := s["namespace"].(string); ok && !strings.Contains(name, ".") {
+			namespace = ns
+		}
+		full := fullName(name, namespace)
+		if i := strings.LastIndex(full, "."); i >= 0 {
+			namespace = full[:i]
+		}
+
+		schema := &avroSchema{typ: typ, name: full}
+		if typ == "error" {
+			schema.typ = "record"
+		}
+		// named types are registered before their fields are parsed, so that records can be recursive
+		p.named[full] = schema
+		p.named[full[strings.LastIndex(full, ".")+1:]] = schema
+
+		switch schema.typ {
+		case "record":
+			fields, _ := s["fields"].([]interface{})
+			for _, f := range fields {
+				field, ok := f.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("invalid field of avro record %s", full)
+				}
+				fieldName, _ := field["name"].(string)
+				fieldSchema, err := p.parse(field["type"], namespace)
+				if err != nil {
+					return nil, fmt.Errorf("invalid field %s of avro record %s: %s", fieldName, full, err)
+				}
+				def, hasDefault := field["default"]
+				schema.fields = append(schema.fields, avroField{name: fieldName, schema: fieldSchema, def: def, hasDefault: hasDefault})
+			}
+		case "enum":
+			symbols, _ := s["symbols"].([]interface{})
+			for _, sym := range symbols {
+				str, _ := sym.(string)
+				schema.symbols = append(schema.symbols, str)
+			}
+		case "fixed":
+			size, _ := s["size"].(float64)
+			schema.size = int(size)
+		}
+		return schema, nil
+	case "array":
+		items, err := p.parse(s["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return &avroSchema{typ: typ, items: items}, nil
+	case "map":
+		values, err := p.parse(s["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return &avroSchema{typ: typ, items: values}, nil
+	default:
+		// primitive types may be written as {"type": "string"}, possibly with a logical type
+		return p.parse(s["type"], namespace)
+	}
+}
+
+func fullName(name, namespace string) string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// encode appends the Avro binary encoding of the value to buf
+func (s *avroSchema) encode(buf *bytes.Buffer, v interface{}) error {
+	switch s.typ {
+	case "null":
+		if v != nil {
+			return fmt.Errorf("%v is not null", v)
+		}
+	case "boolean":
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("%v is not a boolean", v)
+		}
+		if b {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	case "int", "long":
+		n, err := avroInteger(v)
+		if err != nil {
+			return err
+		}
+		if s.typ == "int" && (n < math.MinInt32 || n > math.MaxInt32) {
+			return fmt.Errorf("%d overflows an int", n)
+		}
+		writeAvroLong(buf, n)
+	case "float", "double":
+		f, err := avroNumber(v)
+		if err != nil {
+			return err
+		}
+		if s.typ == "float" {
+			var b [4]byte
+			binary.LittleEndian.PutUint32(b[:], math.Float32bits(float32(f)))
+			buf.Write(b[:])
+		} else {
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+			buf.Write(b[:])
+		}
+	case "bytes", "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%v is not a string", v)
+		}
+		writeAvroLong(buf, int64(len(str)))
+		buf.WriteString(str)
+	case "fixed":
+		str, ok := v.(string)
+		if !ok || len(str) != s.size {
+			return fmt.Errorf("%v is not a fixed of size %d", v, s.size)
+		}
+		buf.WriteString(str)
+	case "enum":
+		str, _ := v.(string)
+		for i, sym := range s.symbols {
+			if sym == str {
+				writeAvroLong(buf, int64(i))
+				return nil
+			}
+		}
+		return fmt.Errorf("%v is not a symbol of enum %s", v, s.name)
+	case "record":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not a record %s", v, s.name)
+		}
+		for _, f := range s.fields {
+			val, ok := m[f.name]
+			if !ok {
+				if !f.hasDefault {
+					return fmt.Errorf("field %s of record %s is missing", f.name, s.name)
+				}
+				val = f.def
+			}
+			if err := f.schema.encode(buf, val); err != nil {
+				return fmt.Errorf("field %s: %s", f.name, err)
+			}
+		}
+	case "array":
+		items, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an array", v)
+		}
+		if len(items) > 0 {
+			writeAvroLong(buf, int64(len(items)))
+			for _, item := range items {
+				if err := s.items.encode(buf, item); err != nil {
+					return err
+				}
+			}
+		}
+		writeAvroLong(buf, 0)
+	case "map":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not a map", v)
+		}
+		if len(m) > 0 {
+			writeAvroLong(buf, int64(len(m)))
+			for k, val := range m {
+				writeAvroLong(buf, int64(len(k)))
+				buf.WriteString(k)
+				if err := s.items.encode(buf, val); err != nil {
+					return fmt.Errorf("key %s: %s", k, err)
+				}
+			}
+		}
+		writeAvroLong(buf, 0)
+	case "union":
+		return s.encodeUnion(buf, v)
+	default:
+		return fmt.Errorf("unsupported avro type %s", s.typ)
+	}
+	return nil
+}
+
+// encodeUnion encodes the value with the first branch it's valid for.
+// Values in the Avro JSON encoding of unions, e.g. {"string": "a"}, select their branch by name.
+func (s *avroSchema) encodeUnion(buf *bytes.Buffer, v interface{}) error {
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 1 {
+		for name, val := range m {
+			for i, b := range s.union {
+				if b.typeName() == name {
+					writeAvroLong(buf, int64(i))
+					return b.encode(buf, val)
+				}
+			}
+		}
+	}
+
+	for i, b := range s.union {
+		var branch bytes.Buffer
+		if b.encode(&branch, v) == nil {
+			writeAvroLong(buf, int64(i))
+			buf.Write(branch.Bytes())
+			return nil
+		}
+	}
+	return fmt.Errorf("%v matches no branch of the union", v)
+}
+
+func (s *avroSchema) typeName() string {
+	if s.name != "" {
+		return s.name
+	}
+	return s.typ
+}
+
+// decode reads a value from the Avro binary encoding
+func (s *avroSchema) decode(r *bytes.Reader) (interface{}, error) {
+	switch s.typ {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := r.ReadByte()
+		return b == 1, err
+	case "int", "long":
+		return binary.ReadVarint(r)
+	case "float":
+		var b [4]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b[:]))), nil
+	case "double":
+		var b [8]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), nil
+	case "bytes", "string":
+		return readAvroString(r)
+	case "fixed":
+		b := make([]byte, s.size)
+		_, err := io.ReadFull(r, b)
+		return string(b), err
+	case "enum":
+		i, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || int(i) >= len(s.symbols) {
+			return nil, fmt.Errorf("invalid symbol %d of enum %s", i, s.name)
+		}
+		return s.symbols[i], nil
+	case "record":
+		m := make(map[string]interface{}, len(s.fields))
+		for _, f := range s.fields {
+			val, err := f.schema.decode(r)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %s", f.name, err)
+			}
+			m[f.name] = val
+		}
+		return m, nil
+	case "array":
+		items := []interface{}{}
+		err := readAvroBlocks(r, func() error {
+			item, err := s.items.decode(r)
+			items = append(items, item)
+			return err
+		})
+		return items, err
+	case "map":
+		m := map[string]interface{}{}
+		err := readAvroBlocks(r, func() error {
+			k, err := readAvroString(r)
+			if err != nil {
+				return err
+			}
+			m[k], err = s.items.decode(r)
+			return err
+		})
+		return m, err
+	case "union":
+		i, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || int(i) >= len(s.union) {
+			return nil, fmt.Errorf("invalid union branch %d", i)
+		}
+		return s.union[i].decode(r)
+	}
+	return nil, fmt.Errorf("unsupported avro type %s", s.typ)
+}
+
+func writeAvroLong(buf *bytes.Buffer, n int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], n)])
+}
+
+func readAvroString(r *bytes.Reader) (string, error) {
+	n, err := binary.ReadVarint(r)
+	if err != nil {
+		return "", err
+	}
+	if n < 0 || n > int64(r.Len()) {
+		return "", errors.New("invalid string length")
+	}
+	b := make([]byte, n)
+	_, err = io.ReadFull(r, b)
+	return string(b), err
+}
+
+// readAvroBlocks reads the blocks of arrays and maps, calling read for each item
+func readAvroBlocks(r *bytes.Reader, read func() error) error {
+	for {
+		n, err := binary.ReadVarint(r)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if n < 0 {
+			// negative counts are followed by the size of the block in bytes
+			n = -n
+			if _, err := binary.ReadVarint(r); err != nil {
+				return err
+			}
+		}
+		for i := int64(0); i < n; i++ {
+			if err := read(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func avroInteger(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case json.Number:
+		return n.Int64()
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case int:
+		return int64(n), nil
+	}
+	return 0, fmt.Errorf("%v is not an integer", v)
+}
+
+func avroNumber(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case json.Number:
+		return n.Float64()
+	case float64:
+		return n, nil
+	case int64:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	}
+	return 0, fmt.Errorf("%v is not a number", v)
+}
@@ -0,0 +1,90 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const orderAvroSchema = `{
+	"type": "record",
+	"name": "Order",
+	"namespace": "com.contoso",
+	"fields": [
+		{"name": "id", "type": "string"},
+		{"name": "quantity", "type": "int"},
+		{"name": "price", "type": "double"},
+		{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "SHIPPED"]}},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "attributes", "type": {"type": "map", "values": "long"}},
+		{"name": "note", "type": ["null", "string"], "default": null},
+		{"name": "parent", "type": ["null", "Order"], "default": null}
+	]
+}`
+
+func avroRoundTrip(t *testing.T, schema *avroSchema, value string) (interface{}, error) {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.UseNumber()
+	assert.NoError(t, decoder.Decode(&v))
+
+	var buf bytes.Buffer
+	if err := schema.encode(&buf, v); err != nil {
+		return nil, err
+	}
+	return schema.decode(bytes.NewReader(buf.Bytes()))
+}
+
+func TestAvroSchema(t *testing.T) {
+	schema, err := parseAvroSchema(orderAvroSchema)
+	assert.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		decoded, err := avroRoundTrip(t, schema, `{
+			"id": "o1", "quantity": 2, "price": 9.5, "status": "NEW", "tags": ["a", "b"], "attributes": {"weight": 3},
+			"note": "fragile", "parent": {"id": "o0", "quantity": 1, "price": 1, "status": "SHIPPED", "tags": [], "attributes": {}}
+		}`)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"id": "o1", "quantity": int64(2), "price": 9.5, "status": "NEW", "tags": []interface{}{"a", "b"},
+			"attributes": map[string]interface{}{"weight": int64(3)}, "note": "fragile",
+			"parent": map[string]interface{}{
+				"id": "o0", "quantity": int64(1), "price": float64(1), "status": "SHIPPED", "tags": []interface{}{},
+				"attributes": map[string]interface{}{}, "note": nil, "parent": nil,
+			},
+		}, decoded)
+	})
+
+	t.Run("union in the avro JSON encoding", func(t *testing.T) {
+		decoded, err := avroRoundTrip(t, schema, `{"id": "o1", "quantity": 2, "price": 9.5, "status": "NEW", "tags": [], "attributes": {}, "note": {"string": "fragile"}}`)
+		assert.NoError(t, err)
+		assert.Equal(t, "fragile", decoded.(map[string]interface{})["note"])
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		_, err := avroRoundTrip(t, schema, `{"id": "o1"}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		_, err := avroRoundTrip(t, schema, `{"id": "o1", "quantity": 2.5, "price": 9.5, "status": "NEW", "tags": [], "attributes": {}}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown enum symbol", func(t *testing.T) {
+		_, err := avroRoundTrip(t, schema, `{"id": "o1", "quantity": 2, "price": 9.5, "status": "LOST", "tags": [], "attributes": {}}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := parseAvroSchema(`{"type": "record", "name": "A", "fields": [{"name": "b", "type": "B"}]}`)
+		assert.Error(t, err)
+	})
+}
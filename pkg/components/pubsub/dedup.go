@@ -0,0 +1,87 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// DefaultDeduplicationWindow is how long the ids of delivered events are remembered
+const DefaultDeduplicationWindow = time.Minute * 10
+
+// Deduplicator delivers events received from several pub/sub components once, based on their CloudEvents id
+type Deduplicator struct {
+	window time.Duration
+	lock   sync.Mutex
+	seen   map[string]time.Time
+	// pruned is when expired ids were last removed
+	pruned time.Time
+}
+
+// NewDeduplicator returns a deduplicator that remembers event ids for window
+func NewDeduplicator(window time.Duration) *Deduplicator {
+	return &Deduplicator{
+		window: window,
+		seen:   map[string]time.Time{},
+		pruned: time.Now(),
+	}
+}
+
+// Wrap returns a handler that drops the events already delivered or being delivered by the handler.
+// Events without an id are always delivered.
+// An event is forgotten when the handler fails so that its redelivery from any component is delivered.
+func (d *Deduplicator) Wrap(handler func(msg *pubsub.NewMessage) error) func(msg *pubsub.NewMessage) error {
+	return func(msg *pubsub.NewMessage) error {
+		var event struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(msg.Data, &event); err != nil || event.ID == "" {
+			return handler(msg)
+		}
+
+		key := msg.Topic + "/" + event.ID
+		if !d.claim(key) {
+			return nil
+		}
+		err := handler(msg)
+		if err != nil {
+			d.forget(key)
+		}
+		return err
+	}
+}
+
+// claim records the event and returns whether it wasn't seen in the deduplication window
+func (d *Deduplicator) claim(key string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(d.pruned) > d.window {
+		for k, t := range d.seen {
+			if now.Sub(t) > d.window {
+				delete(d.seen, k)
+			}
+		}
+		d.pruned = now
+	}
+
+	if t, ok := d.seen[key]; ok && now.Sub(t) <= d.window {
+		return false
+	}
+	d.seen[key] = now
+	return true
+}
+
+func (d *Deduplicator) forget(key string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.seen, key)
+}
@@ -0,0 +1,81 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicator(t *testing.T) {
+	event := func(id string) *pubsub.NewMessage {
+		return &pubsub.NewMessage{Topic: "orders", Data: []byte(`{"id": "` + id + `", "data": "event"}`)}
+	}
+
+	t.Run("events from both components are delivered once", func(t *testing.T) {
+		delivered := 0
+		d := NewDeduplicator(time.Minute)
+		kafka := d.Wrap(func(msg *pubsub.NewMessage) error {
+			delivered++
+			return nil
+		})
+		pulsar := d.Wrap(func(msg *pubsub.NewMessage) error {
+			delivered++
+			return nil
+		})
+
+		assert.NoError(t, kafka(event("1")))
+		assert.NoError(t, pulsar(event("1")))
+		assert.NoError(t, pulsar(event("2")))
+		assert.Equal(t, 2, delivered)
+	})
+
+	t.Run("failed events are delivered again", func(t *testing.T) {
+		fail := true
+		delivered := 0
+		handler := NewDeduplicator(time.Minute).Wrap(func(msg *pubsub.NewMessage) error {
+			delivered++
+			if fail {
+				return errors.New("app is unavailable")
+			}
+			return nil
+		})
+
+		assert.Error(t, handler(event("1")))
+		fail = false
+		assert.NoError(t, handler(event("1")))
+		assert.Equal(t, 2, delivered)
+	})
+
+	t.Run("ids are forgotten after the window", func(t *testing.T) {
+		delivered := 0
+		handler := NewDeduplicator(time.Millisecond).Wrap(func(msg *pubsub.NewMessage) error {
+			delivered++
+			return nil
+		})
+
+		assert.NoError(t, handler(event("1")))
+		time.Sleep(time.Millisecond * 5)
+		assert.NoError(t, handler(event("1")))
+		assert.Equal(t, 2, delivered)
+	})
+
+	t.Run("events without an id are always delivered", func(t *testing.T) {
+		delivered := 0
+		handler := NewDeduplicator(time.Minute).Wrap(func(msg *pubsub.NewMessage) error {
+			delivered++
+			return nil
+		})
+
+		assert.NoError(t, handler(&pubsub.NewMessage{Data: []byte("not a cloud event")}))
+		assert.NoError(t, handler(&pubsub.NewMessage{Data: []byte("not a cloud event")}))
+		assert.Equal(t, 2, delivered)
+	})
+}
@@ -0,0 +1,24 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"github.com/dapr/components-contrib/pubsub"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// NewCloudEventsEnvelope returns a new CloudEventsEnvelope whose JSON data is decoded by json, so that the numbers of
+// the data keep their precision when json preserves them
+func NewCloudEventsEnvelope(json jsoniter.API, id, source, eventType, subject string, data []byte) *pubsub.CloudEventsEnvelope {
+	envelope := pubsub.NewCloudEventsEnvelope(id, source, eventType, subject, data)
+	if envelope.DataContentType == "application/json" {
+		var i interface{}
+		if err := json.Unmarshal(data, &i); err == nil {
+			envelope.Data = i
+		}
+	}
+	return envelope
+}
@@ -0,0 +1,36 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCloudEventsEnvelope(t *testing.T) {
+	data := []byte(`[9007199254740993]`)
+
+	t.Run("numbers are preserved", func(t *testing.T) {
+		json := config.NewJSONAPI(config.JSONSpec{PreserveInt64: true})
+		envelope := NewCloudEventsEnvelope(json, "1", "app", "", "", data)
+		b, err := json.Marshal(envelope)
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), `"data":[9007199254740993]`)
+	})
+
+	t.Run("numbers are decoded as float64 by default", func(t *testing.T) {
+		envelope := NewCloudEventsEnvelope(config.NewJSONAPI(config.JSONSpec{}), "1", "app", "", "", data)
+		assert.Equal(t, []interface{}{float64(9007199254740993)}, envelope.Data)
+	})
+
+	t.Run("text data", func(t *testing.T) {
+		envelope := NewCloudEventsEnvelope(config.NewJSONAPI(config.JSONSpec{PreserveInt64: true}), "1", "app", "", "", []byte("hello"))
+		assert.Equal(t, "text/plain", envelope.DataContentType)
+		assert.Equal(t, "hello", envelope.Data)
+	})
+}
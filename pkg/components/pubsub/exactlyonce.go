@@ -0,0 +1,143 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+const (
+	exactlyOnceKeyPrefix = "exactly-once"
+	// ttlMetadataKey expires the delivery records in the state stores supporting it
+	ttlMetadataKey = "ttlInSeconds"
+)
+
+// ExactlyOnce drops the redeliveries of the events of subscriptions within a window, keeping a record of the events
+// delivered to the app in a state store so that redeliveries are dropped across restarts and instances of the app
+type ExactlyOnce struct {
+	store  state.Store
+	appID  string
+	window time.Duration
+
+	lock sync.Mutex
+	// inflight holds the events being delivered by the sidecar
+	inflight map[string]bool
+}
+
+// delivery is the record of an event delivered to the app
+type delivery struct {
+	DeliveredAt time.Time `json:"deliveredAt"`
+}
+
+// NewExactlyOnce returns a deduplicator keeping the records of the events delivered to the app in the store for window
+func NewExactlyOnce(store state.Store, appID string, window time.Duration) *ExactlyOnce {
+	return &ExactlyOnce{
+		store:    store,
+		appID:    appID,
+		window:   window,
+		inflight: map[string]bool{},
+	}
+}
+
+// Wrap returns a handler of the subscription to the topics of the pub/sub that drops the events delivered in the
+// window, or being delivered. Events without an id are always delivered.
+// An event is recorded once the handler succeeds, so that the redelivery of a failed event is delivered. Events fail
+// while the records can't be read, so that they're redelivered rather than delivered twice.
+func (e *ExactlyOnce) Wrap(pubsubName string, handler func(msg *pubsub.NewMessage) error) func(msg *pubsub.NewMessage) error {
+	return func(msg *pubsub.NewMessage) error {
+		var event struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(msg.Data, &event); err != nil || event.ID == "" {
+			return handler(msg)
+		}
+
+		key := e.key(pubsubName, msg.Topic, event.ID)
+		if !e.claim(key) {
+			diag.DefaultMonitoring.PubSubDuplicateDropped(pubsubName, msg.Topic)
+			return nil
+		}
+		defer e.release(key)
+
+		delivered, err := e.delivered(key)
+		if err != nil {
+			return fmt.Errorf("failed to read the delivery record of event %s on topic %s: %s", event.ID, msg.Topic, err)
+		}
+		if delivered {
+			log.Debugf("dropping event %s on topic %s of pub/sub %s, already delivered", event.ID, msg.Topic, pubsubName)
+			diag.DefaultMonitoring.PubSubDuplicateDropped(pubsubName, msg.Topic)
+			return nil
+		}
+
+		if err := handler(msg); err != nil {
+			return err
+		}
+		if err := e.record(key); err != nil {
+			log.Warnf("failed to record the delivery of event %s on topic %s, its redeliveries will be delivered: %s", event.ID, msg.Topic, err)
+		}
+		return nil
+	}
+}
+
+// key returns the state key of the delivery record of the event of the subscription
+func (e *ExactlyOnce) key(pubsubName, topic, id string) string {
+	return e.appID + "||" + exactlyOnceKeyPrefix + "||" + pubsubName + "||" + topic + "||" + id
+}
+
+// claim returns whether the event isn't being delivered, and marks it as being delivered
+func (e *ExactlyOnce) claim(key string) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.inflight[key] {
+		return false
+	}
+	e.inflight[key] = true
+	return true
+}
+
+func (e *ExactlyOnce) release(key string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	delete(e.inflight, key)
+}
+
+// delivered returns whether the event was delivered in the window
+func (e *ExactlyOnce) delivered(key string) (bool, error) {
+	resp, err := e.store.Get(&state.GetRequest{Key: key})
+	if err != nil {
+		return false, err
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		return false, nil
+	}
+	var d delivery
+	if err := json.Unmarshal(resp.Data, &d); err != nil {
+		log.Debugf("ignoring unreadable delivery record %s: %s", key, err)
+		return false, nil
+	}
+	return time.Since(d.DeliveredAt) <= e.window, nil
+}
+
+func (e *ExactlyOnce) record(key string) error {
+	data, err := json.Marshal(delivery{DeliveredAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	return e.store.Set(&state.SetRequest{
+		Key:      key,
+		Value:    data,
+		Metadata: map[string]string{ttlMetadataKey: strconv.FormatInt(int64(math.Ceil(e.window.Seconds())), 10)},
+	})
+}
@@ -0,0 +1,112 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/components/state/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStore fails to read the delivery records
+type failingStore struct {
+	*inmemory.StateStore
+}
+
+func (f failingStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return nil, errors.New("store is unavailable")
+}
+
+func TestExactlyOnce(t *testing.T) {
+	event := func(id string) *pubsub.NewMessage {
+		return &pubsub.NewMessage{Topic: "orders", Data: []byte(`{"id": "` + id + `", "data": "event"}`)}
+	}
+
+	t.Run("redeliveries are dropped across instances", func(t *testing.T) {
+		store := inmemory.NewStateStore()
+		delivered := 0
+		deliver := func(msg *pubsub.NewMessage) error {
+			delivered++
+			return nil
+		}
+		first := NewExactlyOnce(store, "app", time.Minute).Wrap("kafka", deliver)
+		second := NewExactlyOnce(store, "app", time.Minute).Wrap("kafka", deliver)
+
+		assert.NoError(t, first(event("1")))
+		assert.NoError(t, second(event("1")))
+		assert.NoError(t, second(event("2")))
+		assert.Equal(t, 2, delivered)
+
+		resp, err := store.Get(&state.GetRequest{Key: "app||exactly-once||kafka||orders||1"})
+		require.NoError(t, err)
+		var d delivery
+		require.NoError(t, json.Unmarshal(resp.Data, &d))
+		assert.WithinDuration(t, time.Now(), d.DeliveredAt, time.Minute)
+	})
+
+	t.Run("subscriptions are deduplicated separately", func(t *testing.T) {
+		delivered := 0
+		deliver := func(msg *pubsub.NewMessage) error {
+			delivered++
+			return nil
+		}
+		e := NewExactlyOnce(inmemory.NewStateStore(), "app", time.Minute)
+
+		assert.NoError(t, e.Wrap("kafka", deliver)(event("1")))
+		assert.NoError(t, e.Wrap("pulsar", deliver)(event("1")))
+		assert.Equal(t, 2, delivered)
+	})
+
+	t.Run("failed events are delivered again", func(t *testing.T) {
+		fail := true
+		delivered := 0
+		handler := NewExactlyOnce(inmemory.NewStateStore(), "app", time.Minute).Wrap("kafka", func(msg *pubsub.NewMessage) error {
+			delivered++
+			if fail {
+				return errors.New("app is unavailable")
+			}
+			return nil
+		})
+
+		assert.Error(t, handler(event("1")))
+		fail = false
+		assert.NoError(t, handler(event("1")))
+		assert.NoError(t, handler(event("1")))
+		assert.Equal(t, 2, delivered)
+	})
+
+	t.Run("events are delivered again after the window", func(t *testing.T) {
+		delivered := 0
+		handler := NewExactlyOnce(inmemory.NewStateStore(), "app", time.Millisecond).Wrap("kafka", func(msg *pubsub.NewMessage) error {
+			delivered++
+			return nil
+		})
+
+		assert.NoError(t, handler(event("1")))
+		time.Sleep(5 * time.Millisecond)
+		assert.NoError(t, handler(event("1")))
+		assert.Equal(t, 2, delivered)
+	})
+
+	t.Run("events fail while the records can't be read", func(t *testing.T) {
+		delivered := 0
+		handler := NewExactlyOnce(failingStore{inmemory.NewStateStore()}, "app", time.Minute).Wrap("kafka", func(msg *pubsub.NewMessage) error {
+			delivered++
+			return nil
+		})
+
+		assert.Error(t, handler(event("1")))
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "orders", Data: []byte("no id")}))
+		assert.Equal(t, 1, delivered)
+	})
+}
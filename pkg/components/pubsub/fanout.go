@@ -0,0 +1,74 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// TargetStatus is the result of publishing an event to a target of a fan-out
+type TargetStatus struct {
+	PubSub string `json:"pubsub"`
+	Topic  string `json:"topic"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (s TargetStatus) String() string {
+	if s.Error == "" {
+		return fmt.Sprintf("%s/%s: published", s.PubSub, s.Topic)
+	}
+	return fmt.Sprintf("%s/%s: %s", s.PubSub, s.Topic, s.Error)
+}
+
+// FanOutError is returned when an event failed to publish to some of the targets of a fan-out
+type FanOutError struct {
+	Statuses []TargetStatus
+}
+
+func (e *FanOutError) Error() string {
+	failed := []string{}
+	for _, s := range e.Statuses {
+		if s.Error != "" {
+			failed = append(failed, s.String())
+		}
+	}
+	return fmt.Sprintf("failed to publish to %d of %d targets: %s", len(failed), len(e.Statuses), strings.Join(failed, "; "))
+}
+
+// FanOut publishes the event to all the targets concurrently. Targets without a topic use the topic of the event.
+// Every target is attempted; a FanOutError with the status of each target is returned if any of them failed.
+func FanOut(req *pubsub.PublishRequest, targets []config.PublishTarget, publish func(pubsubName string, req *pubsub.PublishRequest) error) error {
+	statuses := make([]TargetStatus, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		statuses[i] = TargetStatus{PubSub: t.PubSub, Topic: t.Topic}
+		if statuses[i].Topic == "" {
+			statuses[i].Topic = req.Topic
+		}
+
+		wg.Add(1)
+		go func(status *TargetStatus) {
+			defer wg.Done()
+			err := publish(status.PubSub, &pubsub.PublishRequest{Topic: status.Topic, Data: req.Data})
+			if err != nil {
+				status.Error = err.Error()
+			}
+		}(&statuses[i])
+	}
+	wg.Wait()
+
+	for _, s := range statuses {
+		if s.Error != "" {
+			return &FanOutError{Statuses: statuses}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanOut(t *testing.T) {
+	targets := []config.PublishTarget{
+		{PubSub: "kafka"},
+		{PubSub: "nats", Topic: "orders-v2"},
+	}
+	req := &pubsub.PublishRequest{Topic: "orders", Data: []byte("event")}
+
+	t.Run("publishes to all targets", func(t *testing.T) {
+		var lock sync.Mutex
+		published := map[string]string{}
+		err := FanOut(req, targets, func(name string, r *pubsub.PublishRequest) error {
+			lock.Lock()
+			defer lock.Unlock()
+			assert.Equal(t, req.Data, r.Data)
+			published[name] = r.Topic
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"kafka": "orders", "nats": "orders-v2"}, published)
+	})
+
+	t.Run("reports the status of each target", func(t *testing.T) {
+		err := FanOut(req, targets, func(name string, r *pubsub.PublishRequest) error {
+			if name == "nats" {
+				return errors.New("broker is unreachable")
+			}
+			return nil
+		})
+
+		fanOutErr, ok := err.(*FanOutError)
+		assert.True(t, ok)
+		assert.Equal(t, []TargetStatus{
+			{PubSub: "kafka", Topic: "orders"},
+			{PubSub: "nats", Topic: "orders-v2", Error: "broker is unreachable"},
+		}, fanOutErr.Statuses)
+		assert.Equal(t, "failed to publish to 1 of 2 targets: nats/orders-v2: broker is unreachable", err.Error())
+	})
+}
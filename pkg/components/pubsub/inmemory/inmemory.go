@@ -0,0 +1,128 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package inmemory is a pub/sub delivering the events within the sidecar, for tests and local development.
+// Events aren't persisted, and aren't shared between sidecars.
+package inmemory
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// RedeliverIntervalMetadataKey is the component metadata key of the delay before an event is delivered again
+	// after its handler failed
+	RedeliverIntervalMetadataKey = "redeliverInterval"
+	// MaxDeliveryAttemptsMetadataKey is the component metadata key of the number of times an event is delivered before
+	// it's dropped. Events are delivered until they succeed by default.
+	MaxDeliveryAttemptsMetadataKey = "maxDeliveryAttempts"
+
+	defaultRedeliverInterval = time.Second
+)
+
+// PubSub is an in-memory pub/sub. Every subscription to a topic receives the events published to the topic after it
+// subscribed, in order: an event is delivered to the subscription once the previous events succeeded or were dropped.
+type PubSub struct {
+	redeliverInterval   time.Duration
+	maxDeliveryAttempts int
+
+	lock          sync.Mutex
+	subscriptions map[string][]*subscription
+}
+
+// subscription is the queue of the events of a topic waiting for a handler
+type subscription struct {
+	handler func(msg *pubsub.NewMessage) error
+
+	lock    sync.Mutex
+	pending []*pubsub.NewMessage
+	ready   *sync.Cond
+}
+
+// NewPubSub returns an in-memory pub/sub without subscriptions
+func NewPubSub() *PubSub {
+	return &PubSub{
+		redeliverInterval: defaultRedeliverInterval,
+		subscriptions:     map[string][]*subscription{},
+	}
+}
+
+// Init reads the redelivery settings of the metadata
+func (p *PubSub) Init(metadata pubsub.Metadata) error {
+	if val := metadata.Properties[RedeliverIntervalMetadataKey]; val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid %s: %s", RedeliverIntervalMetadataKey, val)
+		}
+		p.redeliverInterval = d
+	}
+	if val := metadata.Properties[MaxDeliveryAttemptsMetadataKey]; val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid %s: %s", MaxDeliveryAttemptsMetadataKey, val)
+		}
+		p.maxDeliveryAttempts = n
+	}
+	return nil
+}
+
+// Publish queues the event for the subscriptions of its topic. Events of topics without subscriptions are dropped.
+func (p *PubSub) Publish(req *pubsub.PublishRequest) error {
+	p.lock.Lock()
+	subscriptions := p.subscriptions[req.Topic]
+	p.lock.Unlock()
+
+	for _, s := range subscriptions {
+		s.lock.Lock()
+		s.pending = append(s.pending, &pubsub.NewMessage{Topic: req.Topic, Data: req.Data})
+		s.lock.Unlock()
+		s.ready.Signal()
+	}
+	return nil
+}
+
+// Subscribe adds a subscription to the topic, delivering its events to handler
+func (p *PubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	s := &subscription{handler: handler}
+	s.ready = sync.NewCond(&s.lock)
+
+	p.lock.Lock()
+	p.subscriptions[req.Topic] = append(p.subscriptions[req.Topic], s)
+	p.lock.Unlock()
+
+	go p.deliver(s)
+	return nil
+}
+
+// deliver delivers the events of a subscription one at a time, redelivering the failed ones
+func (p *PubSub) deliver(s *subscription) {
+	for {
+		s.lock.Lock()
+		for len(s.pending) == 0 {
+			s.ready.Wait()
+		}
+		msg := s.pending[0]
+		s.lock.Unlock()
+
+		for attempt := 1; ; attempt++ {
+			if err := s.handler(msg); err == nil {
+				break
+			}
+			if p.maxDeliveryAttempts > 0 && attempt >= p.maxDeliveryAttempts {
+				break
+			}
+			time.Sleep(p.redeliverInterval)
+		}
+
+		s.lock.Lock()
+		s.pending = s.pending[1:]
+		s.lock.Unlock()
+	}
+}
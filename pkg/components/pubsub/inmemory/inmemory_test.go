@@ -0,0 +1,81 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package inmemory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/conformance"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPubSub(t *testing.T, properties map[string]string) *PubSub {
+	p := NewPubSub()
+	assert.NoError(t, p.Init(pubsub.Metadata{Properties: properties}))
+	return p
+}
+
+func TestConformance(t *testing.T) {
+	report := &conformance.Report{}
+	conformance.CheckPubSub("in-memory", newTestPubSub(t, map[string]string{RedeliverIntervalMetadataKey: "10ms"}), conformance.DefaultPubSubOptions(), report)
+
+	for _, r := range report.Results {
+		assert.Equal(t, conformance.StatusPassed, r.Status, "%s: %s", r.Check, r.Message)
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	p := newTestPubSub(t, nil)
+	first := make(chan string, 1)
+	second := make(chan string, 1)
+	assert.NoError(t, p.Subscribe(pubsub.SubscribeRequest{Topic: "orders"}, func(msg *pubsub.NewMessage) error {
+		first <- string(msg.Data)
+		return nil
+	}))
+	assert.NoError(t, p.Subscribe(pubsub.SubscribeRequest{Topic: "orders"}, func(msg *pubsub.NewMessage) error {
+		second <- string(msg.Data)
+		return nil
+	}))
+
+	assert.NoError(t, p.Publish(&pubsub.PublishRequest{Topic: "orders", Data: []byte("1")}))
+	assert.NoError(t, p.Publish(&pubsub.PublishRequest{Topic: "payments", Data: []byte("2")}))
+
+	assert.Equal(t, "1", <-first)
+	assert.Equal(t, "1", <-second)
+}
+
+func TestMaxDeliveryAttempts(t *testing.T) {
+	p := newTestPubSub(t, map[string]string{RedeliverIntervalMetadataKey: "1ms", MaxDeliveryAttemptsMetadataKey: "2"})
+	delivered := make(chan string, 10)
+	assert.NoError(t, p.Subscribe(pubsub.SubscribeRequest{Topic: "orders"}, func(msg *pubsub.NewMessage) error {
+		delivered <- string(msg.Data)
+		if string(msg.Data) == "poison" {
+			return errors.New("failed")
+		}
+		return nil
+	}))
+
+	assert.NoError(t, p.Publish(&pubsub.PublishRequest{Topic: "orders", Data: []byte("poison")}))
+	assert.NoError(t, p.Publish(&pubsub.PublishRequest{Topic: "orders", Data: []byte("next")}))
+
+	// the poison event is dropped after two attempts, and the next one is delivered
+	for _, expected := range []string{"poison", "poison", "next"} {
+		select {
+		case d := <-delivered:
+			assert.Equal(t, expected, d)
+		case <-time.After(time.Second):
+			assert.Fail(t, "event not delivered", expected)
+		}
+	}
+}
+
+func TestInit(t *testing.T) {
+	assert.Error(t, NewPubSub().Init(pubsub.Metadata{Properties: map[string]string{RedeliverIntervalMetadataKey: "soon"}}))
+	assert.Error(t, NewPubSub().Init(pubsub.Metadata{Properties: map[string]string{MaxDeliveryAttemptsMetadataKey: "-1"}}))
+}
@@ -0,0 +1,114 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// Pauser pauses and resumes the delivery of the events of topics to the app, without unsubscribing from them.
+// The deliveries of a paused topic block until it's resumed, so that its backlog stays in the broker.
+type Pauser struct {
+	lock sync.Mutex
+	// paused holds a channel per paused topic, closed when it's resumed
+	paused map[string]chan struct{}
+	// all is closed when every topic is resumed, nil when they're not paused
+	all chan struct{}
+}
+
+// NewPauser returns a pauser with no paused topics
+func NewPauser() *Pauser {
+	return &Pauser{
+		paused: map[string]chan struct{}{},
+	}
+}
+
+// Pause pauses the delivery of the events of the topic. It returns false if the topic was already paused.
+func (p *Pauser) Pause(topic string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.paused[topic]; ok {
+		return false
+	}
+	p.paused[topic] = make(chan struct{})
+	return true
+}
+
+// Resume resumes the delivery of the events of the topic. It returns false if the topic wasn't paused.
+func (p *Pauser) Resume(topic string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	resumed, ok := p.paused[topic]
+	if !ok {
+		return false
+	}
+	delete(p.paused, topic)
+	close(resumed)
+	return true
+}
+
+// PauseAll pauses the delivery of the events of every topic, independently of the topics paused one by one.
+// It returns false if they were already paused.
+func (p *Pauser) PauseAll() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.all != nil {
+		return false
+	}
+	p.all = make(chan struct{})
+	return true
+}
+
+// ResumeAll resumes the delivery of the events of the topics paused by PauseAll. The topics paused one by one stay
+// paused. It returns false if they weren't paused.
+func (p *Pauser) ResumeAll() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.all == nil {
+		return false
+	}
+	close(p.all)
+	p.all = nil
+	return true
+}
+
+// Paused returns the paused topics, sorted
+func (p *Pauser) Paused() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	topics := make([]string, 0, len(p.paused))
+	for t := range p.paused {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Wrap returns a handler that waits for the topic of an event to be resumed before delivering it
+func (p *Pauser) Wrap(handler func(msg *pubsub.NewMessage) error) func(msg *pubsub.NewMessage) error {
+	return func(msg *pubsub.NewMessage) error {
+		for {
+			p.lock.Lock()
+			resumed, ok := p.paused[msg.Topic]
+			if !ok && p.all != nil {
+				resumed, ok = p.all, true
+			}
+			p.lock.Unlock()
+			if !ok {
+				return handler(msg)
+			}
+			<-resumed
+		}
+	}
+}
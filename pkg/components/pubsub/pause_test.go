@@ -0,0 +1,79 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauser(t *testing.T) {
+	p := NewPauser()
+	delivered := make(chan string, 2)
+	handler := p.Wrap(func(msg *pubsub.NewMessage) error {
+		delivered <- msg.Topic
+		return nil
+	})
+
+	t.Run("topics aren't paused by default", func(t *testing.T) {
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "orders"}))
+		assert.Equal(t, "orders", <-delivered)
+		assert.Empty(t, p.Paused())
+	})
+
+	t.Run("deliveries of a paused topic wait for it to be resumed", func(t *testing.T) {
+		assert.True(t, p.Pause("orders"))
+		assert.False(t, p.Pause("orders"))
+		assert.Equal(t, []string{"orders"}, p.Paused())
+
+		done := make(chan error)
+		go func() {
+			done <- handler(&pubsub.NewMessage{Topic: "orders"})
+		}()
+
+		// other topics are delivered
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "payments"}))
+		assert.Equal(t, "payments", <-delivered)
+
+		select {
+		case <-delivered:
+			assert.Fail(t, "paused topic was delivered")
+		case <-time.After(time.Millisecond * 50):
+		}
+
+		assert.True(t, p.Resume("orders"))
+		assert.False(t, p.Resume("orders"))
+		assert.NoError(t, <-done)
+		assert.Equal(t, "orders", <-delivered)
+		assert.Empty(t, p.Paused())
+	})
+
+	t.Run("pausing every topic keeps the topics paused one by one", func(t *testing.T) {
+		assert.True(t, p.Pause("orders"))
+		assert.True(t, p.PauseAll())
+		assert.False(t, p.PauseAll())
+
+		done := make(chan error)
+		go func() {
+			done <- handler(&pubsub.NewMessage{Topic: "payments"})
+		}()
+		select {
+		case <-delivered:
+			assert.Fail(t, "paused topic was delivered")
+		case <-time.After(time.Millisecond * 50):
+		}
+
+		assert.True(t, p.ResumeAll())
+		assert.False(t, p.ResumeAll())
+		assert.NoError(t, <-done)
+		assert.Equal(t, "payments", <-delivered)
+		assert.Equal(t, []string{"orders"}, p.Paused())
+		p.Resume("orders")
+	})
+}
@@ -9,19 +9,21 @@ import (
 	"fmt"
 
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/components"
 )
 
 type (
 	// PubSub is a pub/sub component definition.
 	PubSub struct {
 		Name          string
+		Version       string
 		FactoryMethod func() pubsub.PubSub
 	}
 
 	// Registry is the interface for callers to get registered pub-sub components
 	Registry interface {
 		Register(components ...PubSub)
-		Create(name string) (pubsub.PubSub, error)
+		Create(name, version string) (pubsub.PubSub, error)
 	}
 
 	pubSubRegistry struct {
@@ -37,6 +39,15 @@ func New(name string, factoryMethod func() pubsub.PubSub) PubSub {
 	}
 }
 
+// NewVersioned creates a version of a PubSub, e.g. v2.
+func NewVersioned(name, version string, factoryMethod func() pubsub.PubSub) PubSub {
+	return PubSub{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
 // NewRegistry returns a new pub sub registry
 func NewRegistry() Registry {
 	return &pubSubRegistry{
@@ -45,18 +56,18 @@ func NewRegistry() Registry {
 }
 
 // Register registers one or more new message buses.
-func (p *pubSubRegistry) Register(components ...PubSub) {
-	for _, component := range components {
-		p.messageBuses[createFullName(component.Name)] = component.FactoryMethod
+func (p *pubSubRegistry) Register(definitions ...PubSub) {
+	for _, component := range definitions {
+		p.messageBuses[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
 	}
 }
 
-// Create instantiates a pub/sub based on `name`.
-func (p *pubSubRegistry) Create(name string) (pubsub.PubSub, error) {
-	if method, ok := p.messageBuses[name]; ok {
+// Create instantiates a version of a pub/sub based on `name`.
+func (p *pubSubRegistry) Create(name, version string) (pubsub.PubSub, error) {
+	if method, ok := p.messageBuses[components.VersionedName(name, version)]; ok {
 		return method(), nil
 	}
-	return nil, fmt.Errorf("couldn't find message bus %s", name)
+	return nil, fmt.Errorf("couldn't find message bus %s", components.TypeVersion(name, version))
 }
 
 func createFullName(name string) string {
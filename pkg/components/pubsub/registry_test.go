@@ -51,7 +51,7 @@ func TestCreatePubSub(t *testing.T) {
 		testRegistry.Register(New(PubSubName, func() pubsub.PubSub {
 			return mockPubSub
 		}))
-		p, e := testRegistry.Create(createFullName(PubSubName))
+		p, e := testRegistry.Create(createFullName(PubSubName), "")
 
 		// assert
 		assert.Equal(t, mockPubSub, p)
@@ -62,10 +62,41 @@ func TestCreatePubSub(t *testing.T) {
 		const PubSubName = "fakeBus"
 
 		// act
-		p, e := testRegistry.Create(createFullName(PubSubName))
+		p, e := testRegistry.Create(createFullName(PubSubName), "")
 
 		// assert
 		assert.Nil(t, p)
 		assert.Equal(t, fmt.Errorf("couldn't find message bus %s", createFullName(PubSubName)), e)
 	})
 }
+
+func TestCreateVersionedPubSub(t *testing.T) {
+	testRegistry := NewRegistry()
+	v1 := new(daprt.MockPubSub)
+	v2 := new(daprt.MockPubSub)
+	testRegistry.Register(
+		New("mockPubSub", func() pubsub.PubSub { return v1 }),
+		NewVersioned("mockPubSub", "v2", func() pubsub.PubSub { return v2 }),
+	)
+
+	t.Run("components without a version are v1", func(t *testing.T) {
+		p, err := testRegistry.Create(createFullName("mockPubSub"), "")
+		assert.NoError(t, err)
+		assert.True(t, p == v1)
+
+		p, err = testRegistry.Create(createFullName("mockPubSub"), "V1")
+		assert.NoError(t, err)
+		assert.True(t, p == v1)
+	})
+
+	t.Run("v2", func(t *testing.T) {
+		p, err := testRegistry.Create(createFullName("mockPubSub"), "v2")
+		assert.NoError(t, err)
+		assert.True(t, p == v2)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := testRegistry.Create(createFullName("mockPubSub"), "v3")
+		assert.EqualError(t, err, "couldn't find message bus pubsub.mockPubSub v3")
+	})
+}
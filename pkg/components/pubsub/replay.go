@@ -0,0 +1,75 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ReplayHeader is the header of the events delivered to the app during a replay, set to the id of the replay.
+// The app can use it as a hint that the events may have been delivered before.
+const ReplayHeader = "dapr-replay-id"
+
+// ErrSeekNotSupported is returned when a topic is replayed from a pub/sub that can't seek
+var ErrSeekNotSupported = errors.New("pub sub doesn't support seeking")
+
+// SeekRequest rewinds the subscription of the app to a topic, to an offset or to a timestamp
+type SeekRequest struct {
+	Topic string
+	// Offset is the broker specific position to seek to, e.g. a Kafka offset or an Event Hubs sequence number
+	Offset string
+	// Timestamp is the time of the first event to seek to when Offset is empty
+	Timestamp time.Time
+}
+
+// Seeker is a pub/sub that can rewind the consumer group of the app, e.g. Kafka or Event Hubs
+type Seeker interface {
+	Seek(req SeekRequest) error
+}
+
+// Replays tracks the topics being replayed, whose deliveries carry the replay id for the duration of their hint window
+type Replays struct {
+	lock    sync.Mutex
+	replays map[string]replay
+}
+
+type replay struct {
+	id    string
+	until time.Time
+}
+
+// NewReplays returns a tracker with no replays
+func NewReplays() *Replays {
+	return &Replays{
+		replays: map[string]replay{},
+	}
+}
+
+// Start records the replay of the topic, replacing the previous replay of the topic if any
+func (r *Replays) Start(topic, id string, window time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.replays[topic] = replay{id: id, until: time.Now().Add(window)}
+}
+
+// Active returns the id of the replay of the topic, if it's in its hint window
+func (r *Replays) Active(topic string) (string, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	replay, ok := r.replays[topic]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(replay.until) {
+		delete(r.replays, topic)
+		return "", false
+	}
+	return replay.id, true
+}
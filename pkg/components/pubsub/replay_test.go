@@ -0,0 +1,35 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplays(t *testing.T) {
+	r := NewReplays()
+
+	_, ok := r.Active("orders")
+	assert.False(t, ok)
+
+	r.Start("orders", "1", time.Minute)
+	id, ok := r.Active("orders")
+	assert.True(t, ok)
+	assert.Equal(t, "1", id)
+
+	// a new replay replaces the previous one
+	r.Start("orders", "2", time.Minute)
+	id, _ = r.Active("orders")
+	assert.Equal(t, "2", id)
+
+	r.Start("payments", "3", time.Millisecond)
+	time.Sleep(time.Millisecond * 5)
+	_, ok = r.Active("payments")
+	assert.False(t, ok)
+}
@@ -0,0 +1,76 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// RetryAfterHeader is the header, or gRPC metadata key, of the delay after which the app asks to redeliver an event
+	RetryAfterHeader = "Retry-After"
+	// DefaultMaxRetryAfter caps the delays asked by the app
+	DefaultMaxRetryAfter = time.Minute
+)
+
+// RetryAfterError is the failure of a delivery the app asked to retry after a delay
+type RetryAfterError struct {
+	Err   error
+	After time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+// ParseRetryAfter parses a Retry-After value, as seconds or as an HTTP date
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := date.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// WithRetryAfter returns the handler holding the failure of the deliveries the app asked to retry after a delay, at
+// most max, so that the event is redelivered by the broker after the delay rather than right away. The other events
+// of the topic keep being delivered as far as the pub/sub delivers events concurrently.
+func WithRetryAfter(handler func(msg *pubsub.NewMessage) error, max time.Duration) func(msg *pubsub.NewMessage) error {
+	if max <= 0 {
+		return handler
+	}
+	return func(msg *pubsub.NewMessage) error {
+		err := handler(msg)
+		retryAfter, ok := err.(*RetryAfterError)
+		if !ok || retryAfter.After <= 0 {
+			return err
+		}
+		after := retryAfter.After
+		if after > max {
+			after = max
+		}
+		log.Debugf("app asked to redeliver an event of topic %s after %v", msg.Topic, retryAfter.After)
+		time.Sleep(after)
+		return err
+	}
+}
@@ -0,0 +1,88 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("seconds", func(t *testing.T) {
+		d, ok := ParseRetryAfter(" 30 ", now)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second*30, d)
+	})
+
+	t.Run("http date", func(t *testing.T) {
+		d, ok := ParseRetryAfter("Mon, 01 Jun 2020 12:00:10 GMT", now)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second*10, d)
+
+		d, ok = ParseRetryAfter("Mon, 01 Jun 2020 11:00:00 GMT", now)
+		assert.True(t, ok)
+		assert.Zero(t, d)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for _, v := range []string{"", "-1", "soon"} {
+			_, ok := ParseRetryAfter(v, now)
+			assert.False(t, ok, v)
+		}
+	})
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	failure := errors.New("throttled")
+
+	t.Run("failures asking a delay are held for the delay", func(t *testing.T) {
+		handler := WithRetryAfter(func(msg *pubsub.NewMessage) error {
+			return &RetryAfterError{Err: failure, After: time.Millisecond * 50}
+		}, time.Minute)
+
+		start := time.Now()
+		err := handler(&pubsub.NewMessage{Topic: "orders"})
+		assert.Error(t, err)
+		assert.Equal(t, "throttled", err.Error())
+		assert.True(t, time.Since(start) >= time.Millisecond*50)
+	})
+
+	t.Run("delays are capped", func(t *testing.T) {
+		handler := WithRetryAfter(func(msg *pubsub.NewMessage) error {
+			return &RetryAfterError{Err: failure, After: time.Hour}
+		}, time.Millisecond*10)
+
+		start := time.Now()
+		assert.Error(t, handler(&pubsub.NewMessage{Topic: "orders"}))
+		assert.True(t, time.Since(start) < time.Second)
+	})
+
+	t.Run("other results are returned right away", func(t *testing.T) {
+		handler := WithRetryAfter(func(msg *pubsub.NewMessage) error {
+			if msg.Topic == "orders" {
+				return failure
+			}
+			return nil
+		}, time.Hour)
+
+		assert.Equal(t, failure, handler(&pubsub.NewMessage{Topic: "orders"}))
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "payments"}))
+	})
+
+	t.Run("delays aren't honored when disabled", func(t *testing.T) {
+		handler := WithRetryAfter(func(msg *pubsub.NewMessage) error {
+			return &RetryAfterError{Err: failure, After: time.Hour}
+		}, 0)
+
+		assert.Error(t, handler(&pubsub.NewMessage{Topic: "orders"}))
+	})
+}
@@ -0,0 +1,477 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	// SchemaRegistryURLMetadataKey is the component metadata key of the URL of a Confluent compatible schema registry.
+	// The data of the events of the topics is enforced against the latest schema of the <topic>-value subject.
+	SchemaRegistryURLMetadataKey = "schemaRegistryURL"
+	// SchemaRegistryUsernameMetadataKey and SchemaRegistryPasswordMetadataKey are the basic auth credentials of the registry
+	SchemaRegistryUsernameMetadataKey = "schemaRegistryUsername"
+	SchemaRegistryPasswordMetadataKey = "schemaRegistryPassword"
+	// SchemaRegistryModeMetadataKey is the component metadata key of the schema enforcement mode
+	SchemaRegistryModeMetadataKey = "schemaRegistryMode"
+	// SchemaRegistryTopicsMetadataKey is the component metadata key of the comma separated topics schemas are enforced on.
+	// Schemas are enforced on all the topics when it's empty.
+	SchemaRegistryTopicsMetadataKey = "schemaRegistryTopics"
+	// SchemaRegistryCacheTTLMetadataKey is the component metadata key of how long the latest schema of subjects is cached
+	SchemaRegistryCacheTTLMetadataKey = "schemaRegistryCacheTTL"
+
+	// SchemaModeValidate publishes CloudEvents whose data is valid against the schema
+	SchemaModeValidate = "validate"
+	// SchemaModeEncode publishes the data encoded with the schema in the schema registry wire format instead of
+	// CloudEvents, for consumers outside of Dapr. Deliveries are decoded back to CloudEvents.
+	SchemaModeEncode = "encode"
+
+	// SchemaIDHeader is the header of the events delivered to the app carrying the id of their schema
+	SchemaIDHeader = "dapr-schema-id"
+	// SchemaURLAttribute is the CloudEvent attribute holding the URL of the schema of the data in the registry
+	SchemaURLAttribute = "schemaurl"
+
+	defaultSchemaCacheTTL = time.Minute * 5
+	schemaRegistryTimeout = time.Second * 10
+	schemaIDsPath         = "/schemas/ids/"
+	// the wire format is a zero magic byte, the big endian schema id and the encoded data
+	wireFormatMagicByte = 0
+	wireFormatHeaderLen = 5
+)
+
+// schema types of the registry
+const (
+	avroSchemaType = "AVRO"
+	jsonSchemaType = "JSON"
+)
+
+// registeredSchema is a parsed schema of the registry
+type registeredSchema struct {
+	id         int
+	schemaType string
+	avro       *avroSchema
+	json       *gojsonschema.Schema
+}
+
+func newRegisteredSchema(id int, schemaType, schema string) (*registeredSchema, error) {
+	s := &registeredSchema{id: id, schemaType: schemaType}
+	if s.schemaType == "" {
+		s.schemaType = avroSchemaType
+	}
+
+	var err error
+	switch s.schemaType {
+	case avroSchemaType:
+		s.avro, err = parseAvroSchema(schema)
+	case jsonSchemaType:
+		s.json, err = gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema))
+	default:
+		err = fmt.Errorf("%s schemas are not supported", s.schemaType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %d: %s", id, err)
+	}
+	return s, nil
+}
+
+// encode returns the data encoded with the schema, failing when the data isn't valid against it
+func (s *registeredSchema) encode(data interface{}) ([]byte, error) {
+	if s.avro != nil {
+		var buf bytes.Buffer
+		if err := s.avro.encode(&buf, data); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	result, err := s.json.Validate(gojsonschema.NewGoLoader(data))
+	if err != nil {
+		return nil, err
+	}
+	if !result.Valid() {
+		errs := []string{}
+		for _, e := range result.Errors() {
+			errs = append(errs, e.String())
+		}
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return json.Marshal(data)
+}
+
+// decode returns the data encoded with the schema
+func (s *registeredSchema) decode(b []byte) (interface{}, error) {
+	if s.avro != nil {
+		return s.avro.decode(bytes.NewReader(b))
+	}
+
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	_, err := s.encode(data)
+	return data, err
+}
+
+type cachedSchema struct {
+	id      int
+	expires time.Time
+}
+
+// schemaRegistry is a client of a Confluent compatible schema registry, caching the schemas it fetched
+type schemaRegistry struct {
+	url      string
+	username string
+	password string
+	ttl      time.Duration
+	client   *http.Client
+
+	lock   sync.Mutex
+	latest map[string]cachedSchema
+	byID   map[int]*registeredSchema
+}
+
+func newSchemaRegistry(registryURL, username, password string, ttl time.Duration) *schemaRegistry {
+	return &schemaRegistry{
+		url:      strings.TrimSuffix(registryURL, "/"),
+		username: username,
+		password: password,
+		ttl:      ttl,
+		client:   &http.Client{Timeout: schemaRegistryTimeout},
+		latest:   map[string]cachedSchema{},
+		byID:     map[int]*registeredSchema{},
+	}
+}
+
+type schemaResponse struct {
+	ID         int    `json:"id"`
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType"`
+}
+
+// latestSchema returns the latest schema of the subject
+func (r *schemaRegistry) latestSchema(subject string) (*registeredSchema, error) {
+	r.lock.Lock()
+	cached, ok := r.latest[subject]
+	r.lock.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return r.schema(cached.id)
+	}
+
+	var res schemaResponse
+	if err := r.get(fmt.Sprintf("/subjects/%s/versions/latest", url.PathEscape(subject)), &res); err != nil {
+		return nil, fmt.Errorf("error getting latest schema of subject %s: %s", subject, err)
+	}
+	schema, err := r.cache(res.ID, res.SchemaType, res.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	r.latest[subject] = cachedSchema{id: res.ID, expires: time.Now().Add(r.ttl)}
+	r.lock.Unlock()
+	return schema, nil
+}
+
+// schema returns the schema with the id. Schemas are immutable, so they're cached forever.
+func (r *schemaRegistry) schema(id int) (*registeredSchema, error) {
+	r.lock.Lock()
+	schema, ok := r.byID[id]
+	r.lock.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	var res schemaResponse
+	if err := r.get(schemaIDsPath+strconv.Itoa(id), &res); err != nil {
+		return nil, fmt.Errorf("error getting schema %d: %s", id, err)
+	}
+	return r.cache(id, res.SchemaType, res.Schema)
+}
+
+func (r *schemaRegistry) cache(id int, schemaType, schema string) (*registeredSchema, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if cached, ok := r.byID[id]; ok {
+		return cached, nil
+	}
+	parsed, err := newRegisteredSchema(id, schemaType, schema)
+	if err != nil {
+		return nil, err
+	}
+	r.byID[id] = parsed
+	return parsed, nil
+}
+
+// flush empties the caches of the registry, the schemas being fetched again when they're used
+func (r *schemaRegistry) flush() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.latest = map[string]cachedSchema{}
+	r.byID = map[int]*registeredSchema{}
+}
+
+func (r *schemaRegistry) get(path string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, r.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json")
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("schema registry returned status %d", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// schemaURL returns the URL of the schema with the id in the registry
+func (r *schemaRegistry) schemaURL(id int) string {
+	return r.url + schemaIDsPath + strconv.Itoa(id)
+}
+
+// WithSchemaRegistry returns the pub/sub with the data of the events of its topics enforced against the schemas of a
+// schema registry when its component metadata has a registry URL. Avro and JSON schemas are supported.
+func WithSchemaRegistry(name string, ps pubsub.PubSub, properties map[string]string) (pubsub.PubSub, error) {
+	registryURL := properties[SchemaRegistryURLMetadataKey]
+	if registryURL == "" {
+		return ps, nil
+	}
+
+	ttl := defaultSchemaCacheTTL
+	if val := properties[SchemaRegistryCacheTTLMetadataKey]; val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", SchemaRegistryCacheTTLMetadataKey, err)
+		}
+		ttl = d
+	}
+
+	s := &schemaPubSub{
+		PubSub:   ps,
+		name:     name,
+		mode:     SchemaModeValidate,
+		registry: newSchemaRegistry(registryURL, properties[SchemaRegistryUsernameMetadataKey], properties[SchemaRegistryPasswordMetadataKey], ttl),
+	}
+	if val := properties[SchemaRegistryModeMetadataKey]; val != "" {
+		if val != SchemaModeValidate && val != SchemaModeEncode {
+			return nil, fmt.Errorf("invalid %s: %s. supported modes are %s and %s", SchemaRegistryModeMetadataKey, val, SchemaModeValidate, SchemaModeEncode)
+		}
+		s.mode = val
+	}
+	if s.mode == SchemaModeEncode && properties[SigningKeyMetadataKey] != "" {
+		return nil, fmt.Errorf("events encoded with a schema can't be signed, %s can't be set with %s %s", SigningKeyMetadataKey, SchemaRegistryModeMetadataKey, SchemaModeEncode)
+	}
+	if val := properties[SchemaRegistryTopicsMetadataKey]; val != "" {
+		s.topics = map[string]bool{}
+		for _, t := range strings.Split(val, ",") {
+			s.topics[strings.TrimSpace(t)] = true
+		}
+	}
+	return s, nil
+}
+
+// FlushSchemas empties the schema cache of the pub/sub. It returns false if the pub/sub doesn't enforce schemas.
+func FlushSchemas(ps pubsub.PubSub) bool {
+	s, ok := ps.(*schemaPubSub)
+	if ok {
+		s.registry.flush()
+	}
+	return ok
+}
+
+type schemaPubSub struct {
+	pubsub.PubSub
+	name     string
+	mode     string
+	registry *schemaRegistry
+	// topics are the topics schemas are enforced on, all of them when nil
+	topics map[string]bool
+}
+
+func (s *schemaPubSub) enforced(topic string) bool {
+	return s.topics == nil || s.topics[topic]
+}
+
+func (s *schemaPubSub) Publish(req *pubsub.PublishRequest) error {
+	if !s.enforced(req.Topic) {
+		return s.PubSub.Publish(req)
+	}
+
+	schema, err := s.registry.latestSchema(req.Topic + "-value")
+	if err != nil {
+		return err
+	}
+	event, err := decodeEvent(req.Data)
+	if err != nil {
+		return fmt.Errorf("event is not a valid CloudEvent: %s", err)
+	}
+	encoded, err := schema.encode(event["data"])
+	if err != nil {
+		return fmt.Errorf("event data doesn't match schema %d of topic %s: %s", schema.id, req.Topic, err)
+	}
+
+	enforced := *req
+	if s.mode == SchemaModeEncode {
+		enforced.Data = make([]byte, wireFormatHeaderLen, wireFormatHeaderLen+len(encoded))
+		enforced.Data[0] = wireFormatMagicByte
+		binary.BigEndian.PutUint32(enforced.Data[1:wireFormatHeaderLen], uint32(schema.id))
+		enforced.Data = append(enforced.Data, encoded...)
+	} else {
+		event[SchemaURLAttribute] = s.registry.schemaURL(schema.id)
+		if enforced.Data, err = json.Marshal(event); err != nil {
+			return err
+		}
+	}
+	return s.PubSub.Publish(&enforced)
+}
+
+func (s *schemaPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	if !s.enforced(req.Topic) {
+		return s.PubSub.Subscribe(req, handler)
+	}
+
+	return s.PubSub.Subscribe(req, func(msg *pubsub.NewMessage) error {
+		data, err := s.enforce(msg)
+		if _, ok := err.(*invalidEventError); ok {
+			log.Errorf("dropping event on topic %s of pub/sub %s: %s", msg.Topic, s.name, err)
+			return nil
+		}
+		if err != nil {
+			// the registry is unavailable, the event is redelivered
+			return err
+		}
+		return handler(&pubsub.NewMessage{Topic: msg.Topic, Data: data})
+	})
+}
+
+// invalidEventError is returned for the delivered events that don't match their schema
+type invalidEventError struct {
+	err error
+}
+
+func (e *invalidEventError) Error() string {
+	return e.err.Error()
+}
+
+// enforce returns the delivered event if its data is valid against its schema, decoding it in encode mode
+func (s *schemaPubSub) enforce(msg *pubsub.NewMessage) ([]byte, error) {
+	if s.mode == SchemaModeEncode {
+		return s.decodeWireFormat(msg)
+	}
+
+	event, err := decodeEvent(msg.Data)
+	if err != nil {
+		return nil, &invalidEventError{fmt.Errorf("event is not a valid CloudEvent: %s", err)}
+	}
+	var schema *registeredSchema
+	if id, ok := schemaID(event); ok {
+		schema, err = s.registry.schema(id)
+	} else {
+		schema, err = s.registry.latestSchema(msg.Topic + "-value")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := schema.encode(event["data"]); err != nil {
+		return nil, &invalidEventError{fmt.Errorf("event data doesn't match schema %d: %s", schema.id, err)}
+	}
+	if _, ok := event[SchemaURLAttribute]; ok {
+		return msg.Data, nil
+	}
+	event[SchemaURLAttribute] = s.registry.schemaURL(schema.id)
+	return json.Marshal(event)
+}
+
+// decodeWireFormat returns the CloudEvent of data in the schema registry wire format
+func (s *schemaPubSub) decodeWireFormat(msg *pubsub.NewMessage) ([]byte, error) {
+	if len(msg.Data) < wireFormatHeaderLen || msg.Data[0] != wireFormatMagicByte {
+		return nil, &invalidEventError{errors.New("event is not in the schema registry wire format")}
+	}
+	id := int(binary.BigEndian.Uint32(msg.Data[1:wireFormatHeaderLen]))
+	schema, err := s.registry.schema(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := schema.decode(msg.Data[wireFormatHeaderLen:])
+	if err != nil {
+		return nil, &invalidEventError{fmt.Errorf("event data doesn't match schema %d: %s", id, err)}
+	}
+
+	// the producer of the event is unknown, it's identified by the subject of its schema
+	return json.Marshal(map[string]interface{}{
+		"id":               uuid.New().String(),
+		"source":           msg.Topic + "-value",
+		"type":             pubsub.DefaultCloudEventType,
+		"specversion":      pubsub.CloudEventsSpecVersion,
+		"datacontenttype":  "application/json",
+		"data":             data,
+		"subject":          "",
+		SchemaURLAttribute: s.registry.schemaURL(id),
+	})
+}
+
+// EventSchemaID returns the id of the schema of a CloudEvent whose data was enforced against a schema registry
+func EventSchemaID(data []byte) (string, bool) {
+	if !bytes.Contains(data, []byte(schemaIDsPath)) {
+		return "", false
+	}
+	var event struct {
+		SchemaURL string `json:"schemaurl"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return "", false
+	}
+	i := strings.LastIndex(event.SchemaURL, schemaIDsPath)
+	if i < 0 {
+		return "", false
+	}
+	return event.SchemaURL[i+len(schemaIDsPath):], true
+}
+
+func schemaID(event map[string]interface{}) (int, bool) {
+	schemaURL, _ := event[SchemaURLAttribute].(string)
+	i := strings.LastIndex(schemaURL, schemaIDsPath)
+	if i < 0 {
+		return 0, false
+	}
+	id, err := strconv.Atoi(schemaURL[i+len(schemaIDsPath):])
+	return id, err == nil
+}
+
+func decodeEvent(data []byte) (map[string]interface{}, error) {
+	event := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,159 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+const paymentAvroSchema = `{"type": "record", "name": "Payment", "fields": [{"name": "amount", "type": "long"}]}`
+
+// newTestSchemaRegistry serves the payment schema as schema 7, the latest schema of the payments-value subject
+func newTestSchemaRegistry(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		switch r.URL.Path {
+		case "/subjects/payments-value/versions/latest", "/schemas/ids/7":
+			json.NewEncoder(w).Encode(schemaResponse{ID: 7, Schema: paymentAvroSchema})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestWithSchemaRegistry(t *testing.T) {
+	var requests int32
+	registry := newTestSchemaRegistry(&requests)
+	defer registry.Close()
+
+	valid := []byte(`{"id":"1","source":"app","type":"com.dapr.event.sent","specversion":"0.3","datacontenttype":"application/json","data":{"amount":42}}`)
+	invalid := []byte(`{"id":"2","source":"app","type":"com.dapr.event.sent","specversion":"0.3","datacontenttype":"application/json","data":{"amount":"42"}}`)
+
+	subscribe := func(ps pubsub.PubSub, topic string) *[][]byte {
+		delivered := [][]byte{}
+		ps.Subscribe(pubsub.SubscribeRequest{Topic: topic}, func(msg *pubsub.NewMessage) error {
+			delivered = append(delivered, msg.Data)
+			return nil
+		})
+		return &delivered
+	}
+
+	t.Run("no registry leaves the pub/sub unchanged", func(t *testing.T) {
+		ps := &flakyPubSub{}
+		s, err := WithSchemaRegistry("pubsub", ps, map[string]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, ps, s)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := WithSchemaRegistry("pubsub", &flakyPubSub{}, map[string]string{
+			SchemaRegistryURLMetadataKey:  registry.URL,
+			SchemaRegistryModeMetadataKey: "transcode",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("encoded events can't be signed", func(t *testing.T) {
+		_, err := WithSchemaRegistry("pubsub", &flakyPubSub{}, map[string]string{
+			SchemaRegistryURLMetadataKey:  registry.URL,
+			SchemaRegistryModeMetadataKey: SchemaModeEncode,
+			SigningKeyMetadataKey:         "key",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("validate mode", func(t *testing.T) {
+		s, err := WithSchemaRegistry("pubsub", &loopbackPubSub{}, map[string]string{SchemaRegistryURLMetadataKey: registry.URL})
+		assert.NoError(t, err)
+		delivered := subscribe(s, "payments")
+
+		assert.NoError(t, s.Publish(&pubsub.PublishRequest{Topic: "payments", Data: valid}))
+		assert.Error(t, s.Publish(&pubsub.PublishRequest{Topic: "payments", Data: invalid}))
+		assert.Len(t, *delivered, 1)
+
+		id, ok := EventSchemaID((*delivered)[0])
+		assert.True(t, ok)
+		assert.Equal(t, "7", id)
+		assert.Contains(t, string((*delivered)[0]), `"amount":42`)
+	})
+
+	t.Run("invalid deliveries are dropped", func(t *testing.T) {
+		broker := &loopbackPubSub{}
+		s, err := WithSchemaRegistry("pubsub", broker, map[string]string{SchemaRegistryURLMetadataKey: registry.URL})
+		assert.NoError(t, err)
+		delivered := subscribe(s, "payments")
+
+		assert.NoError(t, broker.Publish(&pubsub.PublishRequest{Topic: "payments", Data: invalid}))
+		assert.Empty(t, *delivered)
+	})
+
+	t.Run("encode mode", func(t *testing.T) {
+		broker := &flakyPubSub{}
+		s, err := WithSchemaRegistry("pubsub", broker, map[string]string{
+			SchemaRegistryURLMetadataKey:  registry.URL,
+			SchemaRegistryModeMetadataKey: SchemaModeEncode,
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, s.Publish(&pubsub.PublishRequest{Topic: "payments", Data: valid}))
+		wire := []byte(broker.published[0])
+		assert.Equal(t, byte(0), wire[0])
+		assert.Equal(t, uint32(7), binary.BigEndian.Uint32(wire[1:5]))
+		// 42 zigzag encoded
+		assert.Equal(t, []byte{84}, wire[5:])
+
+		loopback := &loopbackPubSub{}
+		s, err = WithSchemaRegistry("pubsub", loopback, map[string]string{
+			SchemaRegistryURLMetadataKey:  registry.URL,
+			SchemaRegistryModeMetadataKey: SchemaModeEncode,
+		})
+		assert.NoError(t, err)
+		delivered := subscribe(s, "payments")
+		assert.NoError(t, loopback.Publish(&pubsub.PublishRequest{Topic: "payments", Data: wire}))
+		assert.Len(t, *delivered, 1)
+
+		var event pubsub.CloudEventsEnvelope
+		assert.NoError(t, json.Unmarshal((*delivered)[0], &event))
+		assert.Equal(t, map[string]interface{}{"amount": float64(42)}, event.Data)
+		id, _ := EventSchemaID((*delivered)[0])
+		assert.Equal(t, "7", id)
+	})
+
+	t.Run("topics without schema enforcement", func(t *testing.T) {
+		s, err := WithSchemaRegistry("pubsub", &loopbackPubSub{}, map[string]string{
+			SchemaRegistryURLMetadataKey:    registry.URL,
+			SchemaRegistryTopicsMetadataKey: "payments",
+		})
+		assert.NoError(t, err)
+		delivered := subscribe(s, "orders")
+
+		assert.NoError(t, s.Publish(&pubsub.PublishRequest{Topic: "orders", Data: invalid}))
+		assert.Equal(t, [][]byte{invalid}, *delivered)
+	})
+
+	t.Run("unknown subject", func(t *testing.T) {
+		s, err := WithSchemaRegistry("pubsub", &loopbackPubSub{}, map[string]string{SchemaRegistryURLMetadataKey: registry.URL})
+		assert.NoError(t, err)
+		assert.Error(t, s.Publish(&pubsub.PublishRequest{Topic: "orders", Data: valid}))
+	})
+
+	t.Run("schemas are cached", func(t *testing.T) {
+		s, err := WithSchemaRegistry("pubsub", &flakyPubSub{}, map[string]string{SchemaRegistryURLMetadataKey: registry.URL})
+		assert.NoError(t, err)
+		before := atomic.LoadInt32(&requests)
+		assert.NoError(t, s.Publish(&pubsub.PublishRequest{Topic: "payments", Data: valid}))
+		assert.NoError(t, s.Publish(&pubsub.PublishRequest{Topic: "payments", Data: valid}))
+		assert.Equal(t, before+1, atomic.LoadInt32(&requests))
+	})
+}
@@ -0,0 +1,253 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/fips"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+const (
+	// SigningKeyMetadataKey is the component metadata key of the PEM private key published events are signed with.
+	// It's usually set with a secretKeyRef to a secret store.
+	SigningKeyMetadataKey = "signingKey"
+	// VerificationKeyMetadataKey is the component metadata key of the PEM public key delivered events are verified with
+	VerificationKeyMetadataKey = "verificationKey"
+	// SignatureVerificationMetadataKey is the component metadata key of what to do with events that fail verification
+	SignatureVerificationMetadataKey = "signatureVerification"
+
+	// RejectPolicy drops the events that fail verification without delivering them to the app
+	RejectPolicy = "reject"
+	// WarnPolicy logs the events that fail verification and delivers them to the app
+	WarnPolicy = "warn"
+
+	// SignatureExtension is the CloudEvent extension attribute holding the detached JWS of the event
+	SignatureExtension = "daprsignature"
+)
+
+// WithSigning returns the pub/sub with signing of published events and verification of delivered events when its
+// component metadata has a signing or verification key. The signature is a detached JWS of the CloudEvent
+// without its signature attribute, added to the event as the SignatureExtension attribute.
+func WithSigning(name string, ps pubsub.PubSub, properties map[string]string) (pubsub.PubSub, error) {
+	signingKey := properties[SigningKeyMetadataKey]
+	verificationKey := properties[VerificationKeyMetadataKey]
+	if signingKey == "" && verificationKey == "" {
+		return ps, nil
+	}
+
+	s := &signingPubSub{PubSub: ps, name: name, policy: RejectPolicy}
+	if signingKey != "" {
+		signer, err := newSigner(signingKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", SigningKeyMetadataKey, err)
+		}
+		s.signer = signer
+	}
+	if verificationKey != "" {
+		key, err := parsePublicKey(verificationKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", VerificationKeyMetadataKey, err)
+		}
+		s.verificationKey = key
+	}
+	if val := properties[SignatureVerificationMetadataKey]; val != "" {
+		if val != RejectPolicy && val != WarnPolicy {
+			return nil, fmt.Errorf("invalid %s: %s. supported policies are %s and %s", SignatureVerificationMetadataKey, val, RejectPolicy, WarnPolicy)
+		}
+		s.policy = val
+	}
+	return s, nil
+}
+
+type signingPubSub struct {
+	pubsub.PubSub
+	name            string
+	signer          jose.Signer
+	verificationKey interface{}
+	policy          string
+}
+
+func (s *signingPubSub) Publish(req *pubsub.PublishRequest) error {
+	if s.signer == nil {
+		return s.PubSub.Publish(req)
+	}
+
+	event, payload, err := canonicalEvent(req.Data)
+	if err != nil {
+		return fmt.Errorf("failed to sign event: %s", err)
+	}
+	obj, err := s.signer.Sign(payload)
+	if err != nil {
+		return fmt.Errorf("failed to sign event: %s", err)
+	}
+	event[SignatureExtension], err = obj.DetachedCompactSerialize()
+	if err != nil {
+		return fmt.Errorf("failed to sign event: %s", err)
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	signed := *req
+	signed.Data = data
+	return s.PubSub.Publish(&signed)
+}
+
+func (s *signingPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	if s.verificationKey == nil {
+		return s.PubSub.Subscribe(req, handler)
+	}
+
+	return s.PubSub.Subscribe(req, func(msg *pubsub.NewMessage) error {
+		if err := s.verify(msg.Data); err != nil {
+			if s.policy == RejectPolicy {
+				log.Errorf("dropping event on topic %s of pub/sub %s: %s", msg.Topic, s.name, err)
+				return nil
+			}
+			log.Warnf("delivering event on topic %s of pub/sub %s: %s", msg.Topic, s.name, err)
+		}
+		return handler(msg)
+	})
+}
+
+func (s *signingPubSub) verify(data []byte) error {
+	event, payload, err := canonicalEvent(data)
+	if err != nil {
+		return fmt.Errorf("event is not a valid CloudEvent: %s", err)
+	}
+	signature, ok := event[SignatureExtension].(string)
+	if !ok || signature == "" {
+		return errors.New("event is not signed")
+	}
+	obj, err := jose.ParseDetached(signature, payload)
+	if err != nil {
+		return fmt.Errorf("invalid event signature: %s", err)
+	}
+	if _, err := obj.Verify(s.verificationKey); err != nil {
+		return fmt.Errorf("invalid event signature: %s", err)
+	}
+	return nil
+}
+
+// canonicalEvent returns the attributes of a CloudEvent and its signed payload: the event without its signature,
+// serialized with sorted attributes so that producers and consumers sign and verify the same bytes
+func canonicalEvent(data []byte) (map[string]interface{}, []byte, error) {
+	event := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		return nil, nil, err
+	}
+
+	signature, signed := event[SignatureExtension]
+	delete(event, SignatureExtension)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+	if signed {
+		event[SignatureExtension] = signature
+	}
+	return event, payload, nil
+}
+
+func newSigner(keyPEM string) (jose.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("key is not PEM encoded")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if fips.Enabled() {
+		if err := fips.ValidatePrivateKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	var alg jose.SignatureAlgorithm
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		alg = jose.RS256
+	case *ecdsa.PrivateKey:
+		alg, err = ecdsaAlgorithm(k.Curve)
+	case ed25519.PrivateKey:
+		alg = jose.EdDSA
+	default:
+		err = fmt.Errorf("unsupported key type %T", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, nil)
+}
+
+func parsePublicKey(keyPEM string) (interface{}, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("key is not PEM encoded")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			key = cert.PublicKey
+		}
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if fips.Enabled() {
+		if err := fips.ValidatePublicKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func ecdsaAlgorithm(curve elliptic.Curve) (jose.SignatureAlgorithm, error) {
+	switch curve {
+	case elliptic.P256():
+		return jose.ES256, nil
+	case elliptic.P384():
+		return jose.ES384, nil
+	case elliptic.P521():
+		return jose.ES512, nil
+	}
+	return "", fmt.Errorf("unsupported curve %s", curve.Params().Name)
+}
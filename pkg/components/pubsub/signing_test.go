@@ -0,0 +1,125 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+// loopbackPubSub delivers the published events to its subscriber
+type loopbackPubSub struct {
+	flakyPubSub
+	handler func(msg *pubsub.NewMessage) error
+}
+
+func (l *loopbackPubSub) Publish(req *pubsub.PublishRequest) error {
+	return l.handler(&pubsub.NewMessage{Topic: req.Topic, Data: req.Data})
+}
+
+func (l *loopbackPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	l.handler = handler
+	return nil
+}
+
+func signingKeys(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	private, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: private})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}))
+}
+
+func TestWithSigning(t *testing.T) {
+	signingKey, verificationKey := signingKeys(t)
+	_, otherKey := signingKeys(t)
+	event := []byte(`{"id":"1","source":"app","type":"com.dapr.event.sent","specversion":"1.0","data":{"amount":1.50}}`)
+
+	subscribe := func(ps pubsub.PubSub) *[]string {
+		delivered := []string{}
+		ps.Subscribe(pubsub.SubscribeRequest{Topic: "topic"}, func(msg *pubsub.NewMessage) error {
+			delivered = append(delivered, string(msg.Data))
+			return nil
+		})
+		return &delivered
+	}
+
+	t.Run("no keys leaves the pub/sub unchanged", func(t *testing.T) {
+		ps := &flakyPubSub{}
+		s, err := WithSigning("pubsub", ps, map[string]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, ps, s)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := WithSigning("pubsub", &flakyPubSub{}, map[string]string{SigningKeyMetadataKey: "not a key"})
+		assert.Error(t, err)
+	})
+
+	t.Run("signed events are verified", func(t *testing.T) {
+		s, err := WithSigning("pubsub", &loopbackPubSub{}, map[string]string{
+			SigningKeyMetadataKey:      signingKey,
+			VerificationKeyMetadataKey: verificationKey,
+		})
+		assert.NoError(t, err)
+		delivered := subscribe(s)
+
+		assert.NoError(t, s.Publish(&pubsub.PublishRequest{Topic: "topic", Data: event}))
+		assert.Len(t, *delivered, 1)
+		assert.Contains(t, (*delivered)[0], SignatureExtension)
+		assert.Contains(t, (*delivered)[0], `"amount":1.50`)
+	})
+
+	t.Run("events signed with another key are rejected", func(t *testing.T) {
+		s, err := WithSigning("pubsub", &loopbackPubSub{}, map[string]string{
+			SigningKeyMetadataKey:      signingKey,
+			VerificationKeyMetadataKey: otherKey,
+		})
+		assert.NoError(t, err)
+		delivered := subscribe(s)
+
+		assert.NoError(t, s.Publish(&pubsub.PublishRequest{Topic: "topic", Data: event}))
+		assert.Empty(t, *delivered)
+	})
+
+	t.Run("unsigned events are delivered with the warn policy", func(t *testing.T) {
+		s, err := WithSigning("pubsub", &loopbackPubSub{}, map[string]string{
+			VerificationKeyMetadataKey:       verificationKey,
+			SignatureVerificationMetadataKey: WarnPolicy,
+		})
+		assert.NoError(t, err)
+		delivered := subscribe(s)
+
+		assert.NoError(t, s.Publish(&pubsub.PublishRequest{Topic: "topic", Data: event}))
+		assert.Equal(t, []string{string(event)}, *delivered)
+	})
+
+	t.Run("tampered events are rejected", func(t *testing.T) {
+		broker := &flakyPubSub{}
+		signer, err := WithSigning("pubsub", broker, map[string]string{SigningKeyMetadataKey: signingKey})
+		assert.NoError(t, err)
+		assert.NoError(t, signer.Publish(&pubsub.PublishRequest{Topic: "topic", Data: event}))
+
+		s, err := WithSigning("pubsub", &loopbackPubSub{}, map[string]string{VerificationKeyMetadataKey: verificationKey})
+		assert.NoError(t, err)
+		verifier := s.(*signingPubSub)
+		assert.NoError(t, verifier.verify([]byte(broker.published[0])))
+
+		tampered := strings.Replace(broker.published[0], "1.50", "150", 1)
+		assert.Error(t, verifier.verify([]byte(tampered)))
+	})
+}
@@ -0,0 +1,220 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/wal"
+)
+
+const (
+	// SpoolMetadataKey is the component metadata key that enables spooling of events while the broker is unreachable
+	SpoolMetadataKey = "spool"
+	// SpoolMaxBytesMetadataKey is the component metadata key of the maximum size of the spooled events
+	SpoolMaxBytesMetadataKey = "spoolMaxBytes"
+	// SpoolEvictionPolicyMetadataKey is the component metadata key of what to drop when the spool is full
+	SpoolEvictionPolicyMetadataKey = "spoolEvictionPolicy"
+	// SpoolRetryIntervalMetadataKey is the component metadata key of the interval between replays of the spool
+	SpoolRetryIntervalMetadataKey = "spoolRetryInterval"
+
+	// DropOldestPolicy evicts the oldest spooled events to make room for new events
+	DropOldestPolicy = "dropOldest"
+	// RejectNewestPolicy fails the publishing of new events when the spool is full
+	RejectNewestPolicy = "rejectNewest"
+
+	defaultSpoolMaxBytes      = 64 * 1024 * 1024
+	defaultSpoolRetryInterval = time.Second * 5
+)
+
+var log = logger.NewLogger("dapr.runtime.pubsub")
+
+// WithSpool returns the pub/sub with store-and-forward of published events when its component metadata enables it.
+// Events that fail to publish are spooled to disk in dir and replayed in order in the background until they are published.
+// While events are spooled, newer events are spooled behind them so that they are published in order.
+func WithSpool(name string, ps pubsub.PubSub, properties map[string]string, dir string) (pubsub.PubSub, error) {
+	if enabled, _ := strconv.ParseBool(properties[SpoolMetadataKey]); !enabled {
+		return ps, nil
+	}
+
+	s := &spoolingPubSub{
+		PubSub:   ps,
+		name:     name,
+		maxBytes: defaultSpoolMaxBytes,
+		policy:   DropOldestPolicy,
+		interval: defaultSpoolRetryInterval,
+	}
+	if val := properties[SpoolMaxBytesMetadataKey]; val != "" {
+		maxBytes, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || maxBytes <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", SpoolMaxBytesMetadataKey, val)
+		}
+		s.maxBytes = maxBytes
+	}
+	if val := properties[SpoolEvictionPolicyMetadataKey]; val != "" {
+		if val != DropOldestPolicy && val != RejectNewestPolicy {
+			return nil, fmt.Errorf("invalid %s: %s. supported policies are %s and %s", SpoolEvictionPolicyMetadataKey, val, DropOldestPolicy, RejectNewestPolicy)
+		}
+		s.policy = val
+	}
+	if val := properties[SpoolRetryIntervalMetadataKey]; val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", SpoolRetryIntervalMetadataKey, val)
+		}
+		s.interval = d
+	}
+
+	l, err := wal.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := l.Entries()
+	if err != nil {
+		return nil, err
+	}
+	s.log = l
+	for _, e := range entries {
+		s.events = append(s.events, spooledEvent{id: e.ID, size: int64(len(e.Data))})
+		s.bytes += int64(len(e.Data))
+	}
+	s.recordDepth()
+
+	go s.replayLoop()
+	return s, nil
+}
+
+type spooledEvent struct {
+	id   string
+	size int64
+}
+
+type spoolingPubSub struct {
+	pubsub.PubSub
+	name     string
+	log      *wal.Log
+	maxBytes int64
+	policy   string
+	interval time.Duration
+
+	lock   sync.Mutex
+	events []spooledEvent
+	bytes  int64
+}
+
+func (s *spoolingPubSub) Publish(req *pubsub.PublishRequest) error {
+	s.lock.Lock()
+	spooled := len(s.events)
+	s.lock.Unlock()
+
+	if spooled == 0 {
+		err := s.PubSub.Publish(req)
+		if err == nil {
+			return nil
+		}
+		log.Warnf("failed to publish to topic %s, spooling the event: %s", req.Topic, err)
+	}
+	return s.spool(req)
+}
+
+func (s *spoolingPubSub) spool(req *pubsub.PublishRequest) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	size := int64(len(b))
+	if size > s.maxBytes {
+		diag.DefaultMonitoring.PubSubSpoolDropped(s.name, s.policy)
+		return fmt.Errorf("event of %d bytes exceeds the publish spool size of %d bytes", size, s.maxBytes)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for s.bytes+size > s.maxBytes {
+		if s.policy == RejectNewestPolicy {
+			diag.DefaultMonitoring.PubSubSpoolDropped(s.name, s.policy)
+			return fmt.Errorf("publish spool of pub/sub %s is full", s.name)
+		}
+		oldest := s.events[0]
+		if err := s.log.Remove(oldest.id); err != nil {
+			return err
+		}
+		s.events = s.events[1:]
+		s.bytes -= oldest.size
+		diag.DefaultMonitoring.PubSubSpoolDropped(s.name, s.policy)
+		log.Warnf("publish spool of pub/sub %s is full, dropped the oldest event", s.name)
+	}
+
+	id, err := s.log.Append(b)
+	if err != nil {
+		return fmt.Errorf("failed to spool event: %s", err)
+	}
+	s.events = append(s.events, spooledEvent{id: id, size: size})
+	s.bytes += size
+	s.recordDepth()
+	return nil
+}
+
+// recordDepth must be called with the lock held
+func (s *spoolingPubSub) recordDepth() {
+	diag.DefaultMonitoring.PubSubSpoolChanged(s.name, int64(len(s.events)), s.bytes)
+}
+
+func (s *spoolingPubSub) replayLoop() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.replay()
+	}
+}
+
+// replay publishes the spooled events in order until one fails
+func (s *spoolingPubSub) replay() {
+	for {
+		s.lock.Lock()
+		if len(s.events) == 0 {
+			s.lock.Unlock()
+			return
+		}
+		oldest := s.events[0]
+		s.lock.Unlock()
+
+		var req pubsub.PublishRequest
+		b, err := s.log.Read(oldest.id)
+		if err == nil {
+			err = json.Unmarshal(b, &req)
+		}
+		if err != nil {
+			// the event was evicted or can never be published
+			log.Debugf("dropping unreadable spooled event %s: %s", oldest.id, err)
+		} else if err = s.PubSub.Publish(&req); err != nil {
+			log.Debugf("replay of spooled event %s failed: %s", oldest.id, err)
+			return
+		}
+
+		// the event may have been evicted while it was published
+		s.lock.Lock()
+		if len(s.events) > 0 && s.events[0].id == oldest.id {
+			s.events = s.events[1:]
+			s.bytes -= oldest.size
+			s.recordDepth()
+		}
+		s.lock.Unlock()
+
+		if err := s.log.Remove(oldest.id); err != nil {
+			log.Errorf("failed to remove spooled event %s: %s", oldest.id, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+type flakyPubSub struct {
+	down      bool
+	published []string
+}
+
+func (f *flakyPubSub) Init(metadata pubsub.Metadata) error {
+	return nil
+}
+
+func (f *flakyPubSub) Publish(req *pubsub.PublishRequest) error {
+	if f.down {
+		return errors.New("broker is unreachable")
+	}
+	f.published = append(f.published, string(req.Data))
+	return nil
+}
+
+func (f *flakyPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	return nil
+}
+
+func spoolProperties(policy, maxBytes string) map[string]string {
+	return map[string]string{
+		SpoolMetadataKey:               "true",
+		SpoolEvictionPolicyMetadataKey: policy,
+		SpoolMaxBytesMetadataKey:       maxBytes,
+		SpoolRetryIntervalMetadataKey:  "1h",
+	}
+}
+
+func TestWithSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spool")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	publish := func(ps pubsub.PubSub, data string) error {
+		return ps.Publish(&pubsub.PublishRequest{Topic: "topic", Data: []byte(data)})
+	}
+
+	t.Run("disabled leaves the pub/sub unchanged", func(t *testing.T) {
+		ps := &flakyPubSub{}
+		s, err := WithSpool("pubsub", ps, map[string]string{}, dir)
+		assert.NoError(t, err)
+		assert.Equal(t, ps, s)
+	})
+
+	t.Run("invalid eviction policy", func(t *testing.T) {
+		_, err := WithSpool("pubsub", &flakyPubSub{}, spoolProperties("dropAll", ""), dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("events are spooled and replayed in order", func(t *testing.T) {
+		dir, _ := ioutil.TempDir(dir, "")
+		ps := &flakyPubSub{down: true}
+		s, err := WithSpool("pubsub", ps, spoolProperties("", ""), dir)
+		assert.NoError(t, err)
+
+		assert.NoError(t, publish(s, "1"))
+		assert.NoError(t, publish(s, "2"))
+
+		s.(*spoolingPubSub).replay()
+		assert.Empty(t, ps.published)
+
+		// events stay spooled behind older events once the broker is back
+		ps.down = false
+		assert.NoError(t, publish(s, "3"))
+		assert.Empty(t, ps.published)
+
+		s.(*spoolingPubSub).replay()
+		assert.Equal(t, []string{"1", "2", "3"}, ps.published)
+		assert.Empty(t, s.(*spoolingPubSub).events)
+	})
+
+	t.Run("spooled events survive restarts", func(t *testing.T) {
+		dir, _ := ioutil.TempDir(dir, "")
+		s, err := WithSpool("pubsub", &flakyPubSub{down: true}, spoolProperties("", ""), dir)
+		assert.NoError(t, err)
+		assert.NoError(t, publish(s, "1"))
+
+		ps := &flakyPubSub{}
+		restarted, err := WithSpool("pubsub", ps, spoolProperties("", ""), dir)
+		assert.NoError(t, err)
+		restarted.(*spoolingPubSub).replay()
+		assert.Equal(t, []string{"1"}, ps.published)
+	})
+
+	t.Run("full spool drops the oldest events", func(t *testing.T) {
+		dir, _ := ioutil.TempDir(dir, "")
+		ps := &flakyPubSub{down: true}
+		// room for two spooled events
+		s, err := WithSpool("pubsub", ps, spoolProperties(DropOldestPolicy, "80"), dir)
+		assert.NoError(t, err)
+
+		for _, data := range []string{"1", "2", "3"} {
+			assert.NoError(t, publish(s, data))
+		}
+		assert.Len(t, s.(*spoolingPubSub).events, 2)
+
+		ps.down = false
+		s.(*spoolingPubSub).replay()
+		assert.Equal(t, []string{"2", "3"}, ps.published)
+	})
+
+	t.Run("full spool rejects the newest events", func(t *testing.T) {
+		dir, _ := ioutil.TempDir(dir, "")
+		ps := &flakyPubSub{down: true}
+		s, err := WithSpool("pubsub", ps, spoolProperties(RejectNewestPolicy, "80"), dir)
+		assert.NoError(t, err)
+
+		assert.NoError(t, publish(s, "1"))
+		assert.NoError(t, publish(s, "2"))
+		assert.Error(t, publish(s, "3"))
+
+		ps.down = false
+		s.(*spoolingPubSub).replay()
+		assert.Equal(t, []string{"1", "2"}, ps.published)
+	})
+}
@@ -0,0 +1,178 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNoStream is returned for the events of a topic without an open stream, so that the broker redelivers them
+	ErrNoStream = errors.New("no open stream is subscribed to the topic")
+	// ErrStreamClosed is returned for the events of a stream closed before they were acked
+	ErrStreamClosed = errors.New("the stream closed before the event was acked")
+)
+
+// StreamEvent is an event of a topic delivered to a stream, acked with its ID
+type StreamEvent struct {
+	ID    string
+	Topic string
+	Data  []byte
+}
+
+// Streams delivers the events of topics to the streams the app opened to receive them, instead of calling the app.
+// The events of a topic are shared between the streams subscribed to it, and each event waits for the ack of its
+// stream.
+type Streams struct {
+	lock    sync.Mutex
+	streams map[string][]*Stream
+	// next is the index of the stream of a topic receiving its next event
+	next map[string]int
+}
+
+// NewStreams returns streams with no open stream
+func NewStreams() *Streams {
+	return &Streams{
+		streams: map[string][]*Stream{},
+		next:    map[string]int{},
+	}
+}
+
+// Open opens a stream receiving the events of the topics
+func (s *Streams) Open(topics []string) *Stream {
+	stream := &Stream{
+		streams: s,
+		topics:  topics,
+		events:  make(chan *StreamEvent),
+		pending: map[string]chan error{},
+		closed:  make(chan struct{}),
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, t := range topics {
+		s.streams[t] = append(s.streams[t], stream)
+	}
+	return stream
+}
+
+// Deliver delivers the event to a stream subscribed to its topic, and returns once the stream acked it
+func (s *Streams) Deliver(ctx context.Context, msg *pubsub.NewMessage) error {
+	stream := s.pick(msg.Topic)
+	if stream == nil {
+		return ErrNoStream
+	}
+	return stream.deliver(ctx, msg)
+}
+
+func (s *Streams) pick(topic string) *Stream {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	streams := s.streams[topic]
+	if len(streams) == 0 {
+		return nil
+	}
+	i := s.next[topic] % len(streams)
+	s.next[topic] = i + 1
+	return streams[i]
+}
+
+func (s *Streams) remove(stream *Stream) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, t := range stream.topics {
+		streams := s.streams[t][:0]
+		for _, other := range s.streams[t] {
+			if other != stream {
+				streams = append(streams, other)
+			}
+		}
+		if len(streams) == 0 {
+			delete(s.streams, t)
+			delete(s.next, t)
+		} else {
+			s.streams[t] = streams
+		}
+	}
+}
+
+// Stream is a stream of the app receiving the events of topics
+type Stream struct {
+	streams *Streams
+	topics  []string
+	events  chan *StreamEvent
+
+	lock sync.Mutex
+	// pending holds the channels receiving the acks of the delivered events
+	pending   map[string]chan error
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Events returns the channel of the events delivered to the stream, to be sent to the app
+func (s *Stream) Events() <-chan *StreamEvent {
+	return s.events
+}
+
+// Ack acks the event with the ID: the event is redelivered when err isn't nil. It returns false if the stream isn't
+// waiting for the ack of the event.
+func (s *Stream) Ack(id string, err error) bool {
+	s.lock.Lock()
+	acked, ok := s.pending[id]
+	delete(s.pending, id)
+	s.lock.Unlock()
+
+	if ok {
+		acked <- err
+	}
+	return ok
+}
+
+// Close closes the stream. The events waiting for their ack are redelivered.
+func (s *Stream) Close() {
+	s.closeOnce.Do(func() {
+		s.streams.remove(s)
+		close(s.closed)
+	})
+}
+
+func (s *Stream) deliver(ctx context.Context, msg *pubsub.NewMessage) error {
+	event := &StreamEvent{ID: uuid.New().String(), Topic: msg.Topic, Data: msg.Data}
+	acked := make(chan error, 1)
+	s.lock.Lock()
+	s.pending[event.ID] = acked
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		delete(s.pending, event.ID)
+		s.lock.Unlock()
+	}()
+
+	select {
+	case s.events <- event:
+	case <-s.closed:
+		return ErrStreamClosed
+	case <-ctx.Done():
+		return fmt.Errorf("error delivering the event to the stream: %s", ctx.Err())
+	}
+
+	select {
+	case err := <-acked:
+		return err
+	case <-s.closed:
+		return ErrStreamClosed
+	case <-ctx.Done():
+		return fmt.Errorf("the event wasn't acked: %s", ctx.Err())
+	}
+}
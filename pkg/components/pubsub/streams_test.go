@@ -0,0 +1,86 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreams(t *testing.T) {
+	deliver := func(s *Streams, topic string) chan error {
+		done := make(chan error, 1)
+		go func() {
+			done <- s.Deliver(context.Background(), &pubsub.NewMessage{Topic: topic, Data: []byte("event")})
+		}()
+		return done
+	}
+
+	t.Run("topic without stream", func(t *testing.T) {
+		s := NewStreams()
+		s.Open([]string{"orders"})
+		assert.Equal(t, ErrNoStream, s.Deliver(context.Background(), &pubsub.NewMessage{Topic: "payments"}))
+	})
+
+	t.Run("events wait for their ack", func(t *testing.T) {
+		s := NewStreams()
+		stream := s.Open([]string{"orders"})
+
+		done := deliver(s, "orders")
+		event := <-stream.Events()
+		assert.Equal(t, "orders", event.Topic)
+		assert.Equal(t, []byte("event"), event.Data)
+		assert.True(t, stream.Ack(event.ID, nil))
+		assert.NoError(t, <-done)
+		assert.False(t, stream.Ack(event.ID, nil))
+
+		done = deliver(s, "orders")
+		event = <-stream.Events()
+		retry := errors.New("retry")
+		stream.Ack(event.ID, retry)
+		assert.Equal(t, retry, <-done)
+	})
+
+	t.Run("events of a closed stream are redelivered", func(t *testing.T) {
+		s := NewStreams()
+		stream := s.Open([]string{"orders"})
+
+		done := deliver(s, "orders")
+		<-stream.Events()
+		stream.Close()
+		assert.Equal(t, ErrStreamClosed, <-done)
+		assert.Equal(t, ErrNoStream, <-deliver(s, "orders"))
+	})
+
+	t.Run("events not acked in time", func(t *testing.T) {
+		s := NewStreams()
+		stream := s.Open([]string{"orders"})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		go func() {
+			<-stream.Events()
+		}()
+		assert.Error(t, s.Deliver(ctx, &pubsub.NewMessage{Topic: "orders"}))
+	})
+
+	t.Run("events are shared between the streams", func(t *testing.T) {
+		s := NewStreams()
+		first := s.Open([]string{"orders"})
+		second := s.Open([]string{"orders", "payments"})
+
+		for _, stream := range []*Stream{first, second} {
+			done := deliver(s, "orders")
+			stream.Ack((<-stream.Events()).ID, nil)
+			assert.NoError(t, <-done)
+		}
+	})
+}
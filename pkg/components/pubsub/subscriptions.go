@@ -0,0 +1,145 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+// LagReporter is a pub/sub that can report the approximate number of events of a topic waiting for the app,
+// e.g. the consumer group lag of Kafka
+type LagReporter interface {
+	Lag(topic string) (int64, error)
+}
+
+// SubscriptionStatus is the status of the subscription of the app to a topic of a pub/sub
+type SubscriptionStatus struct {
+	PubSub    string `json:"pubsub"`
+	Topic     string `json:"topic"`
+	Connected bool   `json:"connected"`
+	// Error is the error of the subscription when it isn't connected
+	Error            string     `json:"error,omitempty"`
+	LastMessageTime  *time.Time `json:"lastMessageTime,omitempty"`
+	Delivered        int64      `json:"delivered"`
+	DeliveryFailures int64      `json:"deliveryFailures"`
+	// Lag is the approximate number of events waiting in the pub/sub, when the pub/sub reports it
+	Lag *int64 `json:"lag,omitempty"`
+}
+
+// Subscriptions tracks the status of the subscriptions of the app, so that a consumer that silently stops
+// receiving events can be noticed
+type Subscriptions struct {
+	lock          sync.Mutex
+	subscriptions map[subscriptionKey]*subscription
+}
+
+type subscriptionKey struct {
+	pubSub string
+	topic  string
+}
+
+type subscription struct {
+	status SubscriptionStatus
+	pubSub pubsub.PubSub
+}
+
+// NewSubscriptions returns a tracker with no subscriptions
+func NewSubscriptions() *Subscriptions {
+	return &Subscriptions{
+		subscriptions: map[subscriptionKey]*subscription{},
+	}
+}
+
+// Subscribed records the result of subscribing to a topic of a pub/sub, err being nil when it succeeded
+func (s *Subscriptions) Subscribed(name string, ps pubsub.PubSub, topic string, err error) {
+	s.lock.Lock()
+	sub := s.get(name, topic)
+	sub.pubSub = ps
+	sub.status.Connected = err == nil
+	sub.status.Error = ""
+	if err != nil {
+		sub.status.Error = err.Error()
+	}
+	s.lock.Unlock()
+
+	diag.DefaultMonitoring.SubscriptionConnected(name, topic, err == nil)
+}
+
+// Wrap returns a handler recording the deliveries of the events of a topic of a pub/sub
+func (s *Subscriptions) Wrap(name, topic string, handler func(msg *pubsub.NewMessage) error) func(msg *pubsub.NewMessage) error {
+	return func(msg *pubsub.NewMessage) error {
+		received := time.Now()
+		err := handler(msg)
+
+		s.lock.Lock()
+		sub := s.get(name, topic)
+		sub.status.LastMessageTime = &received
+		if err != nil {
+			sub.status.DeliveryFailures++
+		} else {
+			sub.status.Delivered++
+		}
+		s.lock.Unlock()
+
+		diag.DefaultMonitoring.SubscriptionDelivered(name, topic, err == nil, received)
+		return err
+	}
+}
+
+// get returns the subscription to a topic of a pub/sub, adding it if needed. The lock must be held.
+func (s *Subscriptions) get(name, topic string) *subscription {
+	key := subscriptionKey{pubSub: name, topic: topic}
+	sub, ok := s.subscriptions[key]
+	if !ok {
+		sub = &subscription{status: SubscriptionStatus{PubSub: name, Topic: topic}}
+		s.subscriptions[key] = sub
+	}
+	return sub
+}
+
+// Status returns the status of the subscriptions sorted by pub/sub and topic, with the lag of the pub/subs that
+// report it. A nil tracker has no subscriptions.
+func (s *Subscriptions) Status() []SubscriptionStatus {
+	if s == nil {
+		return nil
+	}
+
+	s.lock.Lock()
+	statuses := make([]SubscriptionStatus, 0, len(s.subscriptions))
+	reporters := make([]pubsub.PubSub, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		statuses = append(statuses, sub.status)
+		reporters = append(reporters, sub.pubSub)
+	}
+	s.lock.Unlock()
+
+	// the lag is queried without holding the lock, as it may call the broker
+	for i := range statuses {
+		reporter, ok := reporters[i].(LagReporter)
+		if !ok || !statuses[i].Connected {
+			continue
+		}
+		lag, err := reporter.Lag(statuses[i].Topic)
+		if err != nil {
+			continue
+		}
+		statuses[i].Lag = &lag
+		diag.DefaultMonitoring.SubscriptionLag(statuses[i].PubSub, statuses[i].Topic, lag)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].PubSub != statuses[j].PubSub {
+			return statuses[i].PubSub < statuses[j].PubSub
+		}
+		return statuses[i].Topic < statuses[j].Topic
+	})
+	return statuses
+}
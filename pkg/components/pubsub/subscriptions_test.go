@@ -0,0 +1,74 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+// lagPubSub is a pub/sub reporting a fixed lag
+type lagPubSub struct {
+	flakyPubSub
+	lag int64
+}
+
+func (p *lagPubSub) Lag(topic string) (int64, error) {
+	return p.lag, nil
+}
+
+func TestSubscriptions(t *testing.T) {
+	t.Run("nil tracker has no subscriptions", func(t *testing.T) {
+		var s *Subscriptions
+		assert.Empty(t, s.Status())
+	})
+
+	t.Run("status of subscriptions", func(t *testing.T) {
+		s := NewSubscriptions()
+		s.Subscribed("kafka", &lagPubSub{lag: 42}, "orders", nil)
+		s.Subscribed("redis", &flakyPubSub{}, "payments", nil)
+		s.Subscribed("kafka", &lagPubSub{lag: 7}, "audit", errors.New("unauthorized"))
+
+		handler := s.Wrap("kafka", "orders", func(msg *pubsub.NewMessage) error {
+			if string(msg.Data) == "fail" {
+				return errors.New("app error")
+			}
+			return nil
+		})
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "orders", Data: []byte("ok")}))
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "orders", Data: []byte("ok")}))
+		assert.Error(t, handler(&pubsub.NewMessage{Topic: "orders", Data: []byte("fail")}))
+
+		statuses := s.Status()
+		assert.Len(t, statuses, 3)
+
+		audit := statuses[0]
+		assert.Equal(t, "kafka", audit.PubSub)
+		assert.Equal(t, "audit", audit.Topic)
+		assert.False(t, audit.Connected)
+		assert.Equal(t, "unauthorized", audit.Error)
+		// the lag of disconnected subscriptions isn't queried
+		assert.Nil(t, audit.Lag)
+
+		orders := statuses[1]
+		assert.Equal(t, "orders", orders.Topic)
+		assert.True(t, orders.Connected)
+		assert.Equal(t, int64(2), orders.Delivered)
+		assert.Equal(t, int64(1), orders.DeliveryFailures)
+		assert.NotNil(t, orders.LastMessageTime)
+		assert.Equal(t, int64(42), *orders.Lag)
+
+		payments := statuses[2]
+		assert.Equal(t, "redis", payments.PubSub)
+		assert.True(t, payments.Connected)
+		assert.Nil(t, payments.LastMessageTime)
+		// the pub/sub doesn't report the lag
+		assert.Nil(t, payments.Lag)
+	})
+}
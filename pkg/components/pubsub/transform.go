@@ -0,0 +1,116 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// templateFuncs are the functions available to transform templates, in addition to the builtin ones
+var templateFuncs = template.FuncMap{
+	// json marshals a value, e.g. {"name": {{json .user.name}}}
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Transformer rewrites the data of the CloudEvents of topics before they're published or delivered to the app,
+// so that every consumer doesn't reimplement the same field mapping
+type Transformer struct {
+	publish map[string]*template.Template
+	deliver map[string]*template.Template
+}
+
+// NewTransformer parses the templates of the transforms
+func NewTransformer(specs []config.TransformSpec) (*Transformer, error) {
+	t := &Transformer{
+		publish: map[string]*template.Template{},
+		deliver: map[string]*template.Template{},
+	}
+	for _, s := range specs {
+		var stage map[string]*template.Template
+		switch s.Stage {
+		case config.TransformStagePublish:
+			stage = t.publish
+		case config.TransformStageDeliver:
+			stage = t.deliver
+		default:
+			return nil, fmt.Errorf("unknown stage %s of the transform of topic %s", s.Stage, s.Topic)
+		}
+		if _, ok := stage[s.Topic]; ok {
+			return nil, fmt.Errorf("topic %s has several %s transforms", s.Topic, s.Stage)
+		}
+
+		tmpl, err := template.New(s.Topic).Funcs(templateFuncs).Option("missingkey=zero").Parse(s.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s transform of topic %s: %s", s.Stage, s.Topic, err)
+		}
+		stage[s.Topic] = tmpl
+	}
+	return t, nil
+}
+
+// TransformPublish rewrites the event of a publish request if its topic has a publish transform
+func (t *Transformer) TransformPublish(req *pubsub.PublishRequest) error {
+	tmpl, ok := t.publish[req.Topic]
+	if !ok {
+		return nil
+	}
+	data, err := transformEvent(tmpl, req.Data)
+	if err != nil {
+		return fmt.Errorf("error transforming event of topic %s: %s", req.Topic, err)
+	}
+	req.Data = data
+	return nil
+}
+
+// Wrap returns a handler that rewrites the events of the topics with a deliver transform before delivering them
+func (t *Transformer) Wrap(handler func(msg *pubsub.NewMessage) error) func(msg *pubsub.NewMessage) error {
+	return func(msg *pubsub.NewMessage) error {
+		tmpl, ok := t.deliver[msg.Topic]
+		if !ok {
+			return handler(msg)
+		}
+		data, err := transformEvent(tmpl, msg.Data)
+		if err != nil {
+			return fmt.Errorf("error transforming event of topic %s: %s", msg.Topic, err)
+		}
+		return handler(&pubsub.NewMessage{Topic: msg.Topic, Data: data})
+	}
+}
+
+// transformEvent executes the template on the data of the CloudEvent and replaces the data with the output
+func transformEvent(tmpl *template.Template, event []byte) ([]byte, error) {
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(event, &envelope); err != nil {
+		return nil, fmt.Errorf("event is not a CloudEvent: %s", err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, envelope["data"]); err != nil {
+		return nil, err
+	}
+
+	// numbers of the output are kept as written by the template
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(out.Bytes()))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil || decoder.More() {
+		envelope["data"] = out.String()
+		envelope["datacontenttype"] = "text/plain"
+	} else {
+		envelope["data"] = data
+		envelope["datacontenttype"] = "application/json"
+	}
+	return json.Marshal(envelope)
+}
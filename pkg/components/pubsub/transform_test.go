@@ -0,0 +1,119 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEvent(t *testing.T, data string) []byte {
+	b, err := json.Marshal(pubsub.NewCloudEventsEnvelope("1", "app", "", "", []byte(data)))
+	assert.NoError(t, err)
+	return b
+}
+
+func eventData(t *testing.T, event []byte) (interface{}, string) {
+	var envelope pubsub.CloudEventsEnvelope
+	assert.NoError(t, json.Unmarshal(event, &envelope))
+	return envelope.Data, envelope.DataContentType
+}
+
+func TestNewTransformer(t *testing.T) {
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := NewTransformer([]config.TransformSpec{
+			{Topic: "orders", Stage: config.TransformStagePublish, Template: "{{.id"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown stage", func(t *testing.T) {
+		_, err := NewTransformer([]config.TransformSpec{
+			{Topic: "orders", Stage: "consume", Template: "{}"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("several transforms of a stage", func(t *testing.T) {
+		_, err := NewTransformer([]config.TransformSpec{
+			{Topic: "orders", Stage: config.TransformStageDeliver, Template: "{}"},
+			{Topic: "orders", Stage: config.TransformStageDeliver, Template: "{}"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestTransformPublish(t *testing.T) {
+	tr, err := NewTransformer([]config.TransformSpec{
+		{Topic: "orders", Stage: config.TransformStagePublish, Template: `{"orderId": {{json .id}}, "customer": {{json .user.name}}}`},
+		{Topic: "greetings", Stage: config.TransformStagePublish, Template: `hello {{.name}}`},
+	})
+	assert.NoError(t, err)
+
+	t.Run("json output", func(t *testing.T) {
+		req := &pubsub.PublishRequest{Topic: "orders", Data: newTestEvent(t, `{"id": "o1", "user": {"name": "ana"}}`)}
+		assert.NoError(t, tr.TransformPublish(req))
+		data, contentType := eventData(t, req.Data)
+		assert.Equal(t, map[string]interface{}{"orderId": "o1", "customer": "ana"}, data)
+		assert.Equal(t, "application/json", contentType)
+	})
+
+	t.Run("text output", func(t *testing.T) {
+		req := &pubsub.PublishRequest{Topic: "greetings", Data: newTestEvent(t, `{"name": "ana"}`)}
+		assert.NoError(t, tr.TransformPublish(req))
+		data, contentType := eventData(t, req.Data)
+		assert.Equal(t, "hello ana", data)
+		assert.Equal(t, "text/plain", contentType)
+	})
+
+	t.Run("topics without transform are unchanged", func(t *testing.T) {
+		event := newTestEvent(t, `{"id": "o1"}`)
+		req := &pubsub.PublishRequest{Topic: "payments", Data: event}
+		assert.NoError(t, tr.TransformPublish(req))
+		assert.Equal(t, event, req.Data)
+	})
+
+	t.Run("not a CloudEvent", func(t *testing.T) {
+		req := &pubsub.PublishRequest{Topic: "orders", Data: []byte("plain")}
+		assert.Error(t, tr.TransformPublish(req))
+	})
+}
+
+func TestTransformerWrap(t *testing.T) {
+	tr, err := NewTransformer([]config.TransformSpec{
+		{Topic: "orders", Stage: config.TransformStageDeliver, Template: `{"orderId": {{json .id}}}`},
+	})
+	assert.NoError(t, err)
+
+	var delivered *pubsub.NewMessage
+	handler := tr.Wrap(func(msg *pubsub.NewMessage) error {
+		delivered = msg
+		return nil
+	})
+
+	t.Run("delivered events are transformed", func(t *testing.T) {
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "orders", Data: newTestEvent(t, `{"id": "o1"}`)}))
+		assert.Equal(t, "orders", delivered.Topic)
+		data, _ := eventData(t, delivered.Data)
+		assert.Equal(t, map[string]interface{}{"orderId": "o1"}, data)
+	})
+
+	t.Run("events aren't delivered when the transform fails", func(t *testing.T) {
+		delivered = nil
+		assert.Error(t, handler(&pubsub.NewMessage{Topic: "orders", Data: []byte("plain")}))
+		assert.Nil(t, delivered)
+	})
+
+	t.Run("topics without transform are delivered as is", func(t *testing.T) {
+		event := newTestEvent(t, `{"id": "o1"}`)
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "payments", Data: event}))
+		assert.Equal(t, event, delivered.Data)
+	})
+}
@@ -0,0 +1,53 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package components
+
+import (
+	"fmt"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	global_config "github.com/dapr/dapr/pkg/config"
+	config "github.com/dapr/dapr/pkg/config/modes"
+	"github.com/ghodss/yaml"
+)
+
+// RemoteComponents loads components from the remote config service of a standalone sidecar
+type RemoteComponents struct {
+	config config.ConfigServiceConfig
+	appID  string
+}
+
+// NewRemoteComponents returns a new remote loader
+func NewRemoteComponents(configuration config.ConfigServiceConfig, appID string) *RemoteComponents {
+	return &RemoteComponents{
+		config: configuration,
+		appID:  appID,
+	}
+}
+
+// LoadComponents loads the components of the app from the config service
+func (r *RemoteComponents) LoadComponents() ([]components_v1alpha1.Component, error) {
+	client, err := global_config.NewConfigServiceClient(r.config, r.appID)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	b, err := client.Components()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the components from the config service: %s", err)
+	}
+	list := []components_v1alpha1.Component{}
+	if err := yaml.Unmarshal(b, &list); err != nil {
+		return nil, fmt.Errorf("invalid components of the config service: %s", err)
+	}
+	for i := range list {
+		if err := expandComponentEnv(&list[i]); err != nil {
+			return nil, fmt.Errorf("error expanding environment variables in component %s: %s", list[i].Name, err)
+		}
+	}
+	return list, nil
+}
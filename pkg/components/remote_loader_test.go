@@ -0,0 +1,76 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package components
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	config "github.com/dapr/dapr/pkg/config/modes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteLoadComponents(t *testing.T) {
+	components := `
+- apiVersion: dapr.io/v1alpha1
+  kind: Component
+  metadata:
+    name: statestore
+  spec:
+    type: state.redis
+    metadata:
+    - name: redisHost
+      value: localhost:6379
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "/v1.0/components", r.URL.Path)
+		assert.Equal(t, "app1", r.URL.Query().Get("appId"))
+		w.Write([]byte(components))
+	}))
+	defer server.Close()
+
+	cacheDir, err := ioutil.TempDir("", "config-service")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	t.Run("components are fetched from the config service", func(t *testing.T) {
+		loader := NewRemoteComponents(config.ConfigServiceConfig{Address: server.URL + "/v1.0/", Token: "secret", CacheDir: cacheDir}, "app1")
+		list, err := loader.LoadComponents()
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "statestore", list[0].Name)
+		assert.Equal(t, "state.redis", list[0].Spec.Type)
+		assert.Equal(t, "localhost:6379", list[0].Spec.Metadata[0].Value)
+	})
+
+	t.Run("fetches are refused without the token", func(t *testing.T) {
+		loader := NewRemoteComponents(config.ConfigServiceConfig{Address: server.URL + "/v1.0"}, "app1")
+		_, err := loader.LoadComponents()
+		assert.Error(t, err)
+	})
+
+	t.Run("cached components are loaded when the config service can't be reached", func(t *testing.T) {
+		loader := NewRemoteComponents(config.ConfigServiceConfig{Address: "http://127.0.0.1:1/v1.0", Token: "secret", CacheDir: cacheDir}, "app1")
+		list, err := loader.LoadComponents()
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "statestore", list[0].Name)
+	})
+
+	t.Run("unsupported address", func(t *testing.T) {
+		loader := NewRemoteComponents(config.ConfigServiceConfig{Address: "ftp://localhost"}, "app1")
+		_, err := loader.LoadComponents()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"errors"
+
+	"github.com/dapr/components-contrib/secretstores"
+)
+
+// ErrBulkGetNotSupported is returned when reading all the secrets of a secret store that doesn't read them at once
+var ErrBulkGetNotSupported = errors.New("reading all the secrets requires a secret store that reads them at once")
+
+// BulkGetter is implemented by secret stores that read all their secrets at once, e.g. a local secrets file
+type BulkGetter interface {
+	// BulkGetSecret returns the key/value pairs of all the secrets by name
+	BulkGetSecret(req BulkGetSecretRequest) (BulkGetSecretResponse, error)
+}
+
+// BulkGetSecretRequest reads all the secrets of a secret store
+type BulkGetSecretRequest struct {
+	Metadata map[string]string `json:"metadata"`
+}
+
+// BulkGetSecretResponse holds the key/value pairs of the secrets by name
+type BulkGetSecretResponse struct {
+	Data map[string]map[string]string `json:"data"`
+}
+
+// BulkGet returns the named secrets of the store, read one by one, or all its secrets when no names are given
+func BulkGet(store secretstores.SecretStore, names []string, metadata map[string]string) (map[string]map[string]string, error) {
+	if len(names) == 0 {
+		getter, ok := store.(BulkGetter)
+		if !ok {
+			return nil, ErrBulkGetNotSupported
+		}
+		resp, err := getter.BulkGetSecret(BulkGetSecretRequest{Metadata: metadata})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	}
+
+	data := make(map[string]map[string]string, len(names))
+	for _, name := range names {
+		resp, err := store.GetSecret(secretstores.GetSecretRequest{Name: name, Metadata: metadata})
+		if err != nil {
+			return nil, err
+		}
+		data[name] = resp.Data
+	}
+	return data, nil
+}
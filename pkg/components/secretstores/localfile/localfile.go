@@ -0,0 +1,90 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package localfile is a secret store reading its secrets from a JSON file, for local development. Unlike the secret
+// stores of the cloud providers, it reads all its secrets at once.
+package localfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/dapr/components-contrib/secretstores"
+	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+)
+
+// SecretsFileMetadataKey is the component metadata key of the path of the JSON file of the secrets
+const SecretsFileMetadataKey = "secretsFile"
+
+// SecretStore reads the secrets of a JSON file mapping the names of the secrets to their string values, or to objects
+// of key/value pairs. The file is read for each request, so that its changes are seen without restarting the sidecar.
+type SecretStore struct {
+	path string
+}
+
+// NewSecretStore returns a secret store reading the file of its component metadata
+func NewSecretStore() *SecretStore {
+	return &SecretStore{}
+}
+
+// Init checks that the secrets file can be read
+func (s *SecretStore) Init(metadata secretstores.Metadata) error {
+	s.path = metadata.Properties[SecretsFileMetadataKey]
+	if s.path == "" {
+		return errors.New("missing the secrets file path")
+	}
+	_, err := s.read()
+	return err
+}
+
+// GetSecret returns the key/value pairs of a secret. A secret with a string value has a single pair named after it.
+func (s *SecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	secrets, err := s.read()
+	if err != nil {
+		return secretstores.GetSecretResponse{}, err
+	}
+	secret, ok := secrets[req.Name]
+	if !ok {
+		return secretstores.GetSecretResponse{}, fmt.Errorf("secret %s not found", req.Name)
+	}
+	return secretstores.GetSecretResponse{Data: secret}, nil
+}
+
+// BulkGetSecret returns the key/value pairs of all the secrets of the file
+func (s *SecretStore) BulkGetSecret(req secretstores_loader.BulkGetSecretRequest) (secretstores_loader.BulkGetSecretResponse, error) {
+	secrets, err := s.read()
+	if err != nil {
+		return secretstores_loader.BulkGetSecretResponse{}, err
+	}
+	return secretstores_loader.BulkGetSecretResponse{Data: secrets}, nil
+}
+
+func (s *SecretStore) read() (map[string]map[string]string, error) {
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the secrets file: %s", err)
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse the secrets file %s: %s", s.path, err)
+	}
+
+	secrets := make(map[string]map[string]string, len(values))
+	for name, raw := range values {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			secrets[name] = map[string]string{name: value}
+			continue
+		}
+		var pairs map[string]string
+		if err := json.Unmarshal(raw, &pairs); err != nil {
+			return nil, fmt.Errorf("secret %s of the secrets file %s is neither a string nor an object of strings", name, s.path)
+		}
+		secrets[name] = pairs
+	}
+	return secrets, nil
+}
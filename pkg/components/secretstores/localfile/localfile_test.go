@@ -0,0 +1,55 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package localfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dapr/components-contrib/secretstores"
+	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "localfile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "secrets.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"token": "abc", "db": {"user": "admin", "password": "secret"}}`), 0600))
+
+	store := NewSecretStore()
+	require.NoError(t, store.Init(secretstores.Metadata{Properties: map[string]string{SecretsFileMetadataKey: path}}))
+
+	t.Run("missing file", func(t *testing.T) {
+		assert.Error(t, NewSecretStore().Init(secretstores.Metadata{Properties: map[string]string{SecretsFileMetadataKey: filepath.Join(dir, "missing.json")}}))
+		assert.Error(t, NewSecretStore().Init(secretstores.Metadata{}))
+	})
+
+	t.Run("get a secret", func(t *testing.T) {
+		resp, err := store.GetSecret(secretstores.GetSecretRequest{Name: "token"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"token": "abc"}, resp.Data)
+
+		resp, err = store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"user": "admin", "password": "secret"}, resp.Data)
+
+		_, err = store.GetSecret(secretstores.GetSecretRequest{Name: "missing"})
+		assert.Error(t, err)
+	})
+
+	t.Run("get all the secrets allowed by the scope", func(t *testing.T) {
+		scoped := secretstores_loader.WithScope(store, config.SecretsScope{DeniedSecrets: []string{"db"}})
+		data, err := secretstores_loader.BulkGet(scoped, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]map[string]string{"token": {"token": "abc"}}, data)
+	})
+}
@@ -9,19 +9,21 @@ import (
 	"fmt"
 
 	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/components"
 )
 
 type (
 	// SecretStore is a secret store component definition.
 	SecretStore struct {
 		Name          string
+		Version       string
 		FactoryMethod func() secretstores.SecretStore
 	}
 
 	// Registry is used to get registered secret store implementations
 	Registry interface {
 		Register(components ...SecretStore)
-		Create(name string) (secretstores.SecretStore, error)
+		Create(name, version string) (secretstores.SecretStore, error)
 	}
 
 	secretStoreRegistry struct {
@@ -37,6 +39,15 @@ func New(name string, factoryMethod func() secretstores.SecretStore) SecretStore
 	}
 }
 
+// NewVersioned creates a version of a SecretStore, e.g. v2.
+func NewVersioned(name, version string, factoryMethod func() secretstores.SecretStore) SecretStore {
+	return SecretStore{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
 // NewRegistry returns a new secret store registry.
 func NewRegistry() Registry {
 	return &secretStoreRegistry{
@@ -45,19 +56,19 @@ func NewRegistry() Registry {
 }
 
 // Register adds one or many new secret stores to the registry.
-func (s *secretStoreRegistry) Register(components ...SecretStore) {
-	for _, component := range components {
-		s.secretStores[createFullName(component.Name)] = component.FactoryMethod
+func (s *secretStoreRegistry) Register(definitions ...SecretStore) {
+	for _, component := range definitions {
+		s.secretStores[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
 	}
 }
 
-// Create instantiates a secret store based on `name`.
-func (s *secretStoreRegistry) Create(name string) (secretstores.SecretStore, error) {
-	if method, ok := s.secretStores[name]; ok {
+// Create instantiates a version of a secret store based on `name`.
+func (s *secretStoreRegistry) Create(name, version string) (secretstores.SecretStore, error) {
+	if method, ok := s.secretStores[components.VersionedName(name, version)]; ok {
 		return method(), nil
 	}
 
-	return nil, fmt.Errorf("couldn't find secret store %s", name)
+	return nil, fmt.Errorf("couldn't find secret store %s", components.TypeVersion(name, version))
 }
 
 func createFullName(name string) string {
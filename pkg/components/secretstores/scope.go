@@ -0,0 +1,86 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"fmt"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// ErrSecretNotAllowed is returned when reading a secret denied by the scope of the secret store
+type ErrSecretNotAllowed struct {
+	Name string
+}
+
+func (e *ErrSecretNotAllowed) Error() string {
+	return fmt.Sprintf("access denied to secret %s by the secrets scope", e.Name)
+}
+
+// scopedStore reads only the secrets allowed by the scope of the app
+type scopedStore struct {
+	secretstores.SecretStore
+	scope config.SecretsScope
+}
+
+// WithScope returns the store reading only the secrets allowed by the scope
+func WithScope(store secretstores.SecretStore, scope config.SecretsScope) secretstores.SecretStore {
+	return &scopedStore{SecretStore: store, scope: scope}
+}
+
+// Unwrap returns the secret store wrapped by its scope, if any
+func Unwrap(store secretstores.SecretStore) secretstores.SecretStore {
+	if s, ok := store.(*scopedStore); ok {
+		return s.SecretStore
+	}
+	return store
+}
+
+func (s *scopedStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	if !s.allowed(req.Name) {
+		return secretstores.GetSecretResponse{}, &ErrSecretNotAllowed{Name: req.Name}
+	}
+	return s.SecretStore.GetSecret(req)
+}
+
+// BulkGetSecret drops the secrets denied by the scope from all the secrets of the store
+func (s *scopedStore) BulkGetSecret(req BulkGetSecretRequest) (BulkGetSecretResponse, error) {
+	getter, ok := s.SecretStore.(BulkGetter)
+	if !ok {
+		return BulkGetSecretResponse{}, ErrBulkGetNotSupported
+	}
+	resp, err := getter.BulkGetSecret(req)
+	if err != nil {
+		return BulkGetSecretResponse{}, err
+	}
+	data := make(map[string]map[string]string, len(resp.Data))
+	for name, secret := range resp.Data {
+		if s.allowed(name) {
+			data[name] = secret
+		}
+	}
+	return BulkGetSecretResponse{Data: data}, nil
+}
+
+func (s *scopedStore) allowed(name string) bool {
+	if contains(s.scope.DeniedSecrets, name) {
+		return false
+	}
+	if len(s.scope.AllowedSecrets) > 0 {
+		return contains(s.scope.AllowedSecrets, name)
+	}
+	return s.scope.DefaultAccess != config.DenyAccess
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore reads its secrets one by one
+type fakeStore struct {
+	secrets map[string]map[string]string
+}
+
+func (f *fakeStore) Init(metadata secretstores.Metadata) error {
+	return nil
+}
+
+func (f *fakeStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	secret, ok := f.secrets[req.Name]
+	if !ok {
+		return secretstores.GetSecretResponse{}, errors.New("secret not found")
+	}
+	return secretstores.GetSecretResponse{Data: secret}, nil
+}
+
+// fakeBulkStore also reads all its secrets at once
+type fakeBulkStore struct {
+	fakeStore
+}
+
+func (f *fakeBulkStore) BulkGetSecret(req BulkGetSecretRequest) (BulkGetSecretResponse, error) {
+	return BulkGetSecretResponse{Data: f.secrets}, nil
+}
+
+func testSecrets() map[string]map[string]string {
+	return map[string]map[string]string{
+		"db":    {"password": "1"},
+		"api":   {"token": "2"},
+		"admin": {"password": "3"},
+	}
+}
+
+func TestBulkGet(t *testing.T) {
+	t.Run("named secrets", func(t *testing.T) {
+		data, err := BulkGet(&fakeStore{secrets: testSecrets()}, []string{"db", "api"}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]map[string]string{"db": {"password": "1"}, "api": {"token": "2"}}, data)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := BulkGet(&fakeStore{secrets: testSecrets()}, []string{"db", "missing"}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("all secrets", func(t *testing.T) {
+		data, err := BulkGet(&fakeBulkStore{fakeStore{secrets: testSecrets()}}, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, testSecrets(), data)
+	})
+
+	t.Run("all secrets of a store reading them one by one", func(t *testing.T) {
+		_, err := BulkGet(&fakeStore{secrets: testSecrets()}, nil, nil)
+		assert.Equal(t, ErrBulkGetNotSupported, err)
+	})
+}
+
+func TestWithScope(t *testing.T) {
+	t.Run("denied secrets", func(t *testing.T) {
+		store := WithScope(&fakeBulkStore{fakeStore{secrets: testSecrets()}}, config.SecretsScope{StoreName: "store", DeniedSecrets: []string{"admin"}})
+
+		_, err := store.GetSecret(secretstores.GetSecretRequest{Name: "admin"})
+		assert.Equal(t, &ErrSecretNotAllowed{Name: "admin"}, err)
+		resp, err := store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		assert.NoError(t, err)
+		assert.Equal(t, "1", resp.Data["password"])
+
+		data, err := BulkGet(store, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"api", "db"}, sortedNames(data))
+
+		_, err = BulkGet(store, []string{"db", "admin"}, nil)
+		assert.Equal(t, &ErrSecretNotAllowed{Name: "admin"}, err)
+	})
+
+	t.Run("allowed secrets", func(t *testing.T) {
+		store := WithScope(&fakeBulkStore{fakeStore{secrets: testSecrets()}}, config.SecretsScope{StoreName: "store", AllowedSecrets: []string{"db", "admin"}, DeniedSecrets: []string{"admin"}})
+
+		data, err := BulkGet(store, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"db"}, sortedNames(data))
+	})
+
+	t.Run("denied by default", func(t *testing.T) {
+		store := WithScope(&fakeBulkStore{fakeStore{secrets: testSecrets()}}, config.SecretsScope{StoreName: "store", DefaultAccess: config.DenyAccess})
+
+		data, err := BulkGet(store, nil, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, data)
+		_, err = store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		assert.Error(t, err)
+	})
+
+	t.Run("store reading secrets one by one", func(t *testing.T) {
+		inner := &fakeStore{secrets: testSecrets()}
+		store := WithScope(inner, config.SecretsScope{StoreName: "store"})
+
+		_, err := BulkGet(store, nil, nil)
+		assert.Equal(t, ErrBulkGetNotSupported, err)
+		assert.Equal(t, inner, Unwrap(store))
+	})
+}
+
+// sortedNames returns the names of the test secrets in data, sorted
+func sortedNames(data map[string]map[string]string) []string {
+	names := []string{}
+	for _, name := range []string{"admin", "api", "db"} {
+		if _, ok := data[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
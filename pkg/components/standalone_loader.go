@@ -33,9 +33,29 @@ func NewStandaloneComponents(configuration config.StandaloneConfig) *StandaloneC
 	}
 }
 
-// LoadComponents loads dapr components from a given directory
+// LoadComponents loads dapr components from a given directory, with the components of the profile directory, e.g.
+// components/prod for the prod profile, layered on them
 func (s *StandaloneComponents) LoadComponents() ([]components_v1alpha1.Component, error) {
 	dir := s.config.ComponentsPath
+	list, err := s.loadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.config.Profile != "" {
+		overrides, err := s.loadDir(filepath.Join(dir, s.config.Profile))
+		if err != nil {
+			log.Warnf("no components of the %s profile: %s", s.config.Profile, err)
+			return list, nil
+		}
+		list = applyProfile(list, overrides)
+	}
+
+	return list, nil
+}
+
+// loadDir loads the components of the yaml files of a directory
+func (s *StandaloneComponents) loadDir(dir string) ([]components_v1alpha1.Component, error) {
 	files, err := ioutil.ReadDir(dir)
 	if err != nil {
 		return nil, err
@@ -60,6 +80,67 @@ func (s *StandaloneComponents) LoadComponents() ([]components_v1alpha1.Component
 	return list, nil
 }
 
+// applyProfile layers the components of a profile on the base components. A profile component overrides the type,
+// auth, scopes and residency of the base component of the same name when it sets them, and its metadata items replace
+// the items of the same name. Profile components without a base component are added.
+func applyProfile(base, overrides []components_v1alpha1.Component) []components_v1alpha1.Component {
+	for _, o := range overrides {
+		i := 0
+		for ; i < len(base); i++ {
+			if base[i].Name == o.Name {
+				break
+			}
+		}
+		if i == len(base) {
+			base = append(base, o)
+			continue
+		}
+
+		c := &base[i]
+		if o.Spec.Type != "" {
+			c.Spec.Type = o.Spec.Type
+		}
+		if o.Auth.SecretStore != "" {
+			c.Auth = o.Auth
+		}
+		if o.Scopes != nil {
+			c.Scopes = o.Scopes
+		}
+		if o.Residency != "" {
+			c.Residency = o.Residency
+		}
+		metadata := append([]components_v1alpha1.MetadataItem{}, c.Spec.Metadata...)
+		for _, item := range o.Spec.Metadata {
+			j := 0
+			for ; j < len(metadata); j++ {
+				if metadata[j].Name == item.Name {
+					metadata[j] = item
+					break
+				}
+			}
+			if j == len(metadata) {
+				metadata = append(metadata, item)
+			}
+		}
+		c.Spec.Metadata = metadata
+	}
+	return base
+}
+
+// LoadComponentsFromFile loads dapr components from a single yaml file
+func (s *StandaloneComponents) LoadComponentsFromFile(filename string) ([]components_v1alpha1.Component, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	components, errs := s.decodeYaml(filename, b)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return components, nil
+}
+
 // isYaml checks whether the file is yaml or not
 func (s *StandaloneComponents) isYaml(fileName string) bool {
 	extension := strings.ToLower(filepath.Ext(fileName))
@@ -87,6 +168,11 @@ func (s *StandaloneComponents) decodeYaml(filename string, b []byte) ([]componen
 			errors = append(errors, err)
 			continue
 		}
+		if err = expandComponentEnv(&component); err != nil {
+			log.Warnf("error expanding environment variables in %s : %s", filename, err)
+			errors = append(errors, err)
+			continue
+		}
 		list = append(list, component)
 	}
 
@@ -1,6 +1,9 @@
 package components
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	config "github.com/dapr/dapr/pkg/config/modes"
@@ -169,3 +172,71 @@ spec:
 	assert.Equal(t, "prop3", components[1].Spec.Metadata[0].Name)
 	assert.Equal(t, "value3", components[1].Spec.Metadata[0].Value)
 }
+
+func TestStandaloneLoadComponentsWithProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "components")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "prod"), 0700))
+
+	base := `
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: statestore
+spec:
+   type: state.redis
+   metadata:
+   - name: redisHost
+     value: localhost:6379
+   - name: actorStateStore
+     value: "true"`
+	prod := `
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: statestore
+spec:
+   metadata:
+   - name: redisHost
+     value: redis.prod:6379
+   - name: enableTLS
+     value: "true"
+---
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: pubsub
+spec:
+   type: pubsub.redis`
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "statestore.yaml"), []byte(base), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "prod", "overrides.yaml"), []byte(prod), 0600))
+
+	t.Run("no profile", func(t *testing.T) {
+		loader := NewStandaloneComponents(config.StandaloneConfig{ComponentsPath: dir})
+		components, err := loader.LoadComponents()
+		assert.NoError(t, err)
+		assert.Len(t, components, 1)
+		assert.Equal(t, "localhost:6379", components[0].Spec.Metadata[0].Value)
+	})
+
+	t.Run("profile overrides", func(t *testing.T) {
+		loader := NewStandaloneComponents(config.StandaloneConfig{ComponentsPath: dir, Profile: "prod"})
+		components, err := loader.LoadComponents()
+		assert.NoError(t, err)
+		assert.Len(t, components, 2)
+		assert.Equal(t, "state.redis", components[0].Spec.Type)
+		assert.Len(t, components[0].Spec.Metadata, 3)
+		assert.Equal(t, "redis.prod:6379", components[0].Spec.Metadata[0].Value)
+		assert.Equal(t, "actorStateStore", components[0].Spec.Metadata[1].Name)
+		assert.Equal(t, "enableTLS", components[0].Spec.Metadata[2].Name)
+		assert.Equal(t, "pubsub", components[1].Name)
+	})
+
+	t.Run("missing profile", func(t *testing.T) {
+		loader := NewStandaloneComponents(config.StandaloneConfig{ComponentsPath: dir, Profile: "stage"})
+		components, err := loader.LoadComponents()
+		assert.NoError(t, err)
+		assert.Len(t, components, 1)
+	})
+}
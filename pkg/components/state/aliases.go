@@ -0,0 +1,46 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"strings"
+)
+
+const (
+	// DefaultStoreMetadataKey is the component metadata key marking the state store used by the API calls without
+	// store name
+	DefaultStoreMetadataKey = "defaultStateStore"
+	// AliasesMetadataKey is the component metadata key of the comma separated names the state store is also known by,
+	// e.g. its former names
+	AliasesMetadataKey = "aliases"
+	// DefaultStoreName is the store name of the API calls resolving to the default state store
+	DefaultStoreName = ""
+)
+
+// Aliases returns the other names the state store is called by: its aliases, and DefaultStoreName when it's the
+// default store
+func Aliases(name string, properties map[string]string) []string {
+	aliases := []string{}
+	for _, a := range strings.Split(properties[AliasesMetadataKey], ",") {
+		a = strings.TrimSpace(a)
+		if a != "" && a != name && !contains(aliases, a) {
+			aliases = append(aliases, a)
+		}
+	}
+	if strings.EqualFold(properties[DefaultStoreMetadataKey], "true") {
+		aliases = append(aliases, DefaultStoreName)
+	}
+	return aliases
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliases(t *testing.T) {
+	assert.Empty(t, Aliases("orders", map[string]string{}))
+	assert.Equal(t, []string{"statestore", "legacy"}, Aliases("orders", map[string]string{
+		AliasesMetadataKey: " statestore, legacy,,orders,statestore",
+	}))
+	assert.Equal(t, []string{"statestore", DefaultStoreName}, Aliases("orders", map[string]string{
+		AliasesMetadataKey:      "statestore",
+		DefaultStoreMetadataKey: "true",
+	}))
+	assert.Empty(t, Aliases("orders", map[string]string{DefaultStoreMetadataKey: "false"}))
+}
@@ -0,0 +1,54 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"errors"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// absentETag is the ETag of the first-write requests creating a key only if it doesn't exist. The stores with the
+// insert feature accept a first-write request with any ETag when the key doesn't exist, and never give a key this ETag.
+const absentETag = "-1"
+
+// insertStores are the state store types creating a key only if it doesn't exist with a first-write request with
+// absentETag. An empty ETag is an unconditional write for all the stores.
+var insertStores = map[string]bool{
+	"state.redis": true,
+}
+
+// ErrETagMismatch is returned by CompareAndSet when another write created or changed the key first
+var ErrETagMismatch = errors.New("etag mismatch")
+
+// CompareAndSet saves the value of the key if the key still has the ETag of the request, or creates the key if the
+// request has no ETag and the key doesn't exist. Creating keys needs a store with the insert feature.
+// It returns ErrETagMismatch if another write created or changed the key first, and the error of the store otherwise.
+func CompareAndSet(store state.Store, req *state.SetRequest) error {
+	r := *req
+	if r.ETag == "" {
+		r.ETag = absentETag
+	}
+	r.Options.Concurrency = state.FirstWrite
+	err := store.Set(&r)
+	if err == nil {
+		return nil
+	}
+
+	// stores fail mismatched ETags like any other write, the ETag of the key tells them apart
+	resp, getErr := store.Get(&state.GetRequest{Key: req.Key, Options: state.GetStateOption{Consistency: state.Strong}})
+	if getErr != nil {
+		return err
+	}
+	var etag string
+	if resp != nil {
+		etag = resp.ETag
+	}
+	if etag != req.ETag {
+		return ErrETagMismatch
+	}
+	return err
+}
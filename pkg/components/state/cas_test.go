@@ -0,0 +1,71 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeETagStore checks ETags like Redis: first-write requests with an ETag fail when the key exists with another ETag
+type fakeETagStore struct {
+	state.Store
+	versions map[string]int
+	err      error
+}
+
+func (f *fakeETagStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	v, ok := f.versions[req.Key]
+	if !ok {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{Data: []byte("{}"), ETag: strconv.Itoa(v)}, nil
+}
+
+func (f *fakeETagStore) Set(req *state.SetRequest) error {
+	if f.err != nil {
+		return f.err
+	}
+	v, ok := f.versions[req.Key]
+	if ok && req.ETag != "" && req.Options.Concurrency == state.FirstWrite && req.ETag != strconv.Itoa(v) {
+		return errors.New("failed to set key")
+	}
+	f.versions[req.Key] = v + 1
+	return nil
+}
+
+func TestCompareAndSet(t *testing.T) {
+	store := &fakeETagStore{versions: map[string]int{}}
+
+	t.Run("creates the key", func(t *testing.T) {
+		assert.NoError(t, CompareAndSet(store, &state.SetRequest{Key: "a", Value: "1"}))
+		assert.Equal(t, 1, store.versions["a"])
+	})
+
+	t.Run("creating an existing key is a mismatch", func(t *testing.T) {
+		assert.Equal(t, ErrETagMismatch, CompareAndSet(store, &state.SetRequest{Key: "a", Value: "2"}))
+		assert.Equal(t, 1, store.versions["a"])
+	})
+
+	t.Run("saves the key with its etag", func(t *testing.T) {
+		assert.NoError(t, CompareAndSet(store, &state.SetRequest{Key: "a", Value: "2", ETag: "1"}))
+		assert.Equal(t, 2, store.versions["a"])
+	})
+
+	t.Run("saving with another etag is a mismatch", func(t *testing.T) {
+		assert.Equal(t, ErrETagMismatch, CompareAndSet(store, &state.SetRequest{Key: "a", Value: "3", ETag: "1"}))
+	})
+
+	t.Run("store errors aren't mismatches", func(t *testing.T) {
+		failing := &fakeETagStore{versions: map[string]int{"a": 1}, err: errors.New("connection refused")}
+		assert.EqualError(t, CompareAndSet(failing, &state.SetRequest{Key: "a", Value: "2", ETag: "1"}), "connection refused")
+		assert.EqualError(t, CompareAndSet(failing, &state.SetRequest{Key: "b", Value: "1"}), "connection refused")
+	})
+}
@@ -0,0 +1,56 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// DeleteByPrefixMetadataKey is the component metadata key opting the state store in deleting the keys of the apps by
+// prefix, e.g. to offboard a tenant
+const DeleteByPrefixMetadataKey = "deleteByPrefix"
+
+// ErrDeleteByPrefixNotSupported is returned when deleting key prefixes of a state store that doesn't list its keys
+var ErrDeleteByPrefixNotSupported = errors.New("deleting key prefixes requires a state store that lists its keys")
+
+// DeleteByPrefixEnabled returns whether the state store opted in deleting keys by prefix and lists its keys
+func DeleteByPrefixEnabled(store state.Store, properties map[string]string) bool {
+	if !strings.EqualFold(properties[DeleteByPrefixMetadataKey], "true") {
+		return false
+	}
+	_, ok := Unwrap(store).(KeyLister)
+	return ok
+}
+
+// DeletePrefixPage deletes a page of the keys of the app starting with the prefix, and returns the number of deleted
+// keys and the cursor of the next page, empty after the last page. The cursors of the store must stay valid when the
+// listed keys are deleted, like the cursors of Redis SCAN.
+func DeletePrefixPage(store state.Store, appID, prefix, cursor string, count int) (int, string, error) {
+	lister, ok := Unwrap(store).(KeyLister)
+	if !ok {
+		return 0, "", ErrDeleteByPrefixNotSupported
+	}
+
+	keys, next, err := lister.ListKeys(keyPrefix(appID)+prefix, cursor, count)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(keys) == 0 {
+		return 0, next, nil
+	}
+
+	reqs := make([]state.DeleteRequest, len(keys))
+	for i, k := range keys {
+		reqs[i] = state.DeleteRequest{Key: k}
+	}
+	if err := store.BulkDelete(reqs); err != nil {
+		return 0, "", err
+	}
+	return len(keys), next, nil
+}
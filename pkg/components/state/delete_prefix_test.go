@@ -0,0 +1,68 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// keyCursorStore lists the keys of a memory store in order, its cursor being the last listed key
+type keyCursorStore struct {
+	*memoryStore
+}
+
+func (s keyCursorStore) ListKeys(prefix, cursor string, count int) ([]string, string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := []string{}
+	for k := range s.values {
+		if strings.HasPrefix(k, prefix) && k > cursor {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > count {
+		return keys[:count], keys[count-1], nil
+	}
+	return keys, "", nil
+}
+
+func TestDeletePrefixPage(t *testing.T) {
+	store := keyCursorStore{newMemoryStore()}
+	for _, k := range []string{"app||tenant1||a", "app||tenant1||b", "app||tenant1||c", "app||tenant2||a", "other||tenant1||a"} {
+		store.values[k] = []byte("v")
+	}
+
+	deleted, pages, cursor := 0, 0, ""
+	for {
+		n, next, err := DeletePrefixPage(store, "app", "tenant1||", cursor, 2)
+		assert.NoError(t, err)
+		deleted += n
+		pages++
+		if cursor = next; cursor == "" {
+			break
+		}
+	}
+	assert.Equal(t, 3, deleted)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, map[string][]byte{"app||tenant2||a": []byte("v"), "other||tenant1||a": []byte("v")}, store.values)
+
+	t.Run("store without key listing", func(t *testing.T) {
+		_, _, err := DeletePrefixPage(newMemoryStore(), "app", "tenant1||", "", 10)
+		assert.Equal(t, ErrDeleteByPrefixNotSupported, err)
+	})
+}
+
+func TestDeleteByPrefixEnabled(t *testing.T) {
+	opted := map[string]string{DeleteByPrefixMetadataKey: "true"}
+	assert.True(t, DeleteByPrefixEnabled(keyCursorStore{newMemoryStore()}, opted))
+	assert.False(t, DeleteByPrefixEnabled(keyCursorStore{newMemoryStore()}, map[string]string{}))
+	assert.False(t, DeleteByPrefixEnabled(newMemoryStore(), opted))
+}
@@ -0,0 +1,126 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// importBatchSize is the number of imported keys saved at once
+const importBatchSize = 100
+
+// ErrListKeysNotSupported is returned when exporting a state store that doesn't list its keys
+var ErrListKeysNotSupported = errors.New("exporting state requires a state store that lists its keys")
+
+// KeyLister is implemented by state stores that list their keys, e.g. with Redis SCAN
+type KeyLister interface {
+	// ListKeys returns up to count keys starting with prefix from the cursor, and the cursor of the next keys.
+	// The first page is listed with an empty cursor, and the last page returns an empty cursor.
+	ListKeys(prefix, cursor string, count int) ([]string, string, error)
+}
+
+// ExportedState is a line of a state export. Keys don't have the app prefix, so that they can be imported by another app.
+type ExportedState struct {
+	Key  string `json:"key"`
+	Data []byte `json:"data"`
+}
+
+// ExportPage returns the values of a page of the keys of the app as newline-delimited JSON, the number of exported keys
+// and the cursor of the next page, empty after the last page.
+// Keys are read one by one, so a page is only consistent per key unless the app stops writing during the export.
+func ExportPage(store state.Store, appID, cursor string, count int) ([]byte, int, string, error) {
+	lister, ok := Unwrap(store).(KeyLister)
+	if !ok {
+		return nil, 0, "", ErrListKeysNotSupported
+	}
+
+	prefix := keyPrefix(appID)
+	keys, next, err := lister.ListKeys(prefix, cursor, count)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	var buf bytes.Buffer
+	exported := 0
+	for _, key := range keys {
+		resp, err := store.Get(&state.GetRequest{Key: key})
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("failed to read key %s: %s", key, err)
+		}
+		// the key was deleted since it was listed
+		if resp == nil || len(resp.Data) == 0 {
+			continue
+		}
+
+		line, err := json.Marshal(ExportedState{Key: strings.TrimPrefix(key, prefix), Data: resp.Data})
+		if err != nil {
+			return nil, 0, "", err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		exported++
+	}
+	return buf.Bytes(), exported, next, nil
+}
+
+// Import saves the keys of a newline-delimited JSON export under the prefix of the app and returns the number of
+// imported keys. Importing the same export again overwrites the keys with the same values.
+func Import(store state.Store, appID string, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	// values are allowed up to the size of a Redis string
+	scanner.Buffer(make([]byte, 64*1024), 512*1024*1024)
+
+	imported := 0
+	batch := []state.SetRequest{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := store.BulkSet(batch); err != nil {
+			return fmt.Errorf("failed to import keys after %d imported keys: %s", imported, err)
+		}
+		imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var s ExportedState
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil || s.Key == "" {
+			return imported, fmt.Errorf("invalid export line %d", line)
+		}
+
+		batch = append(batch, state.SetRequest{Key: keyPrefix(appID) + s.Key, Value: s.Data})
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return imported, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return imported, err
+	}
+	return imported, flush()
+}
+
+// keyPrefix returns the prefix of the state keys of the app, which is empty without an app ID
+func keyPrefix(appID string) string {
+	if appID == "" {
+		return ""
+	}
+	return appID + keySeparator
+}
@@ -0,0 +1,83 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// listingStore lists the keys of a memory store in order, its cursor being the index of the next key
+type listingStore struct {
+	*memoryStore
+}
+
+func (s listingStore) ListKeys(prefix, cursor string, count int) ([]string, string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := []string{}
+	for k := range s.values {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	start, _ := strconv.Atoi(cursor)
+	end := start + count
+	if end >= len(keys) {
+		return keys[start:], "", nil
+	}
+	return keys[start:end], strconv.Itoa(end), nil
+}
+
+func TestExportImport(t *testing.T) {
+	source := listingStore{newMemoryStore()}
+	source.values["app||order"] = []byte(`{"id":1}`)
+	source.values["app||cart"] = []byte("2")
+	source.values["app||user||1"] = []byte("3")
+	source.values["other||order"] = []byte("4")
+
+	var export []byte
+	pages, cursor := 0, ""
+	for {
+		data, n, next, err := ExportPage(source, "app", cursor, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Count(string(data), "\n"), n)
+		export = append(export, data...)
+		pages++
+		if cursor = next; cursor == "" {
+			break
+		}
+	}
+	assert.Equal(t, 2, pages)
+	assert.NotContains(t, string(export), "app||")
+
+	target := newMemoryStore()
+	n, err := Import(target, "restored", strings.NewReader(string(export)))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, map[string][]byte{
+		"restored||order":   []byte(`{"id":1}`),
+		"restored||cart":    []byte("2"),
+		"restored||user||1": []byte("3"),
+	}, target.values)
+
+	t.Run("store without key listing", func(t *testing.T) {
+		_, _, _, err := ExportPage(newMemoryStore(), "app", "", 10)
+		assert.Equal(t, ErrListKeysNotSupported, err)
+	})
+
+	t.Run("invalid line", func(t *testing.T) {
+		n, err := Import(newMemoryStore(), "app", strings.NewReader("{\"key\":\"a\",\"data\":\"MQ==\"}\nnot json\n"))
+		assert.Error(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
@@ -0,0 +1,56 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/components"
+)
+
+// etagStores are the state store types that reject writes and deletes with a mismatched ETag
+var etagStores = map[string]bool{
+	"state.redis":           true,
+	"state.etcd":            true,
+	"state.zookeeper":       true,
+	"state.sqlserver":       true,
+	"state.couchbase":       true,
+	"state.aerospike":       true,
+	"state.cloudstate.crdt": true,
+}
+
+// bulkDeleteStores are the state store types that delete multiple keys in one operation, rather than one by one
+var bulkDeleteStores = map[string]bool{
+	"state.zookeeper": true,
+	"state.sqlserver": true,
+}
+
+// Features returns the features of a state store of the component type, before it's wrapped by the runtime
+func Features(componentType string, store state.Store) []string {
+	if p, ok := store.(components.FeaturesProvider); ok {
+		return p.Features()
+	}
+
+	features := []string{}
+	if etagStores[componentType] {
+		features = append(features, components.FeatureETag)
+	}
+	if insertStores[componentType] {
+		features = append(features, components.FeatureInsert)
+	}
+	if bulkDeleteStores[componentType] {
+		features = append(features, components.FeatureBulkDelete)
+	}
+	if _, ok := store.(state.TransactionalStore); ok {
+		features = append(features, components.FeatureTransactional)
+	}
+	if _, ok := store.(Querier); ok {
+		features = append(features, components.FeatureQuery)
+	}
+	if _, ok := store.(Watcher); ok {
+		features = append(features, components.FeatureStreaming)
+	}
+	return features
+}
@@ -0,0 +1,32 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeFeaturesStore struct {
+	fakeStore
+}
+
+func (f *fakeFeaturesStore) Features() []string {
+	return []string{"query", "ttl"}
+}
+
+func TestFeatures(t *testing.T) {
+	t.Run("detected features", func(t *testing.T) {
+		assert.Equal(t, []string{"etag", "insert", "transactional"}, Features("state.redis", &fakeTransactionalStore{}))
+		assert.Equal(t, []string{}, Features("state.consul", &fakeStore{}))
+		assert.Equal(t, []string{"etag", "bulkDelete"}, Features("state.zookeeper", &fakeStore{}))
+	})
+
+	t.Run("declared features", func(t *testing.T) {
+		assert.Equal(t, []string{"query", "ttl"}, Features("state.redis", &fakeFeaturesStore{}))
+	})
+}
@@ -0,0 +1,143 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+const (
+	// ReadReplicaMetadataKey is the component metadata key of the name of the state store reading from a replica
+	// of the store, which hedged reads are sent to
+	ReadReplicaMetadataKey = "readReplica"
+	// HedgeReadDelayMetadataKey is the component metadata key of the latency after which a read is hedged to the replica
+	HedgeReadDelayMetadataKey = "hedgeReadDelay"
+
+	defaultHedgeReadDelay = time.Millisecond * 100
+)
+
+// WithHedgedReads returns the store with its reads sent to the replica store named in its component metadata when
+// the store hasn't answered after the hedging delay. The first successful response is returned.
+// Replica responses are only used when they have an ETag, so that writes based on a stale read fail, and their ETags
+// are verified against the response of the store once it answers.
+// Strongly consistent reads are never hedged.
+func WithHedgedReads(store state.Store, properties map[string]string, name string, lookup func(name string) (state.Store, bool)) (state.Store, error) {
+	replica := properties[ReadReplicaMetadataKey]
+	if replica == "" {
+		return store, nil
+	}
+	if replica == name {
+		return nil, fmt.Errorf("state store %s can't be its own read replica", name)
+	}
+
+	delay := defaultHedgeReadDelay
+	if val, ok := properties[HedgeReadDelayMetadataKey]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", HedgeReadDelayMetadataKey, val)
+		}
+		delay = d
+	}
+
+	s := &hedgingStore{
+		Store:   store,
+		name:    name,
+		replica: replica,
+		lookup:  lookup,
+		delay:   delay,
+	}
+	if transactional, ok := store.(state.TransactionalStore); ok {
+		return &hedgingTransactionalStore{hedgingStore: s, transactional: transactional}, nil
+	}
+	return s, nil
+}
+
+type hedgingStore struct {
+	state.Store
+	name    string
+	replica string
+	lookup  func(name string) (state.Store, bool)
+	delay   time.Duration
+}
+
+type hedgedRead struct {
+	resp    *state.GetResponse
+	err     error
+	replica bool
+}
+
+func (s *hedgingStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	if req.Options.Consistency == state.Strong {
+		return s.Store.Get(req)
+	}
+
+	results := make(chan hedgedRead, 2)
+	go func() {
+		resp, err := s.Store.Get(req)
+		results <- hedgedRead{resp: resp, err: err}
+	}()
+
+	timer := time.NewTimer(s.delay)
+	defer timer.Stop()
+
+	hedged := false
+	pending := 1
+	for {
+		select {
+		case <-timer.C:
+			replica, ok := s.lookup(s.replica)
+			if !ok || replica == nil {
+				log.Debugf("read replica %s of state store %s not found", s.replica, s.name)
+				continue
+			}
+			hedged = true
+			pending++
+			go func() {
+				resp, err := Unwrap(replica).Get(req)
+				results <- hedgedRead{resp: resp, err: err, replica: true}
+			}()
+		case r := <-results:
+			pending--
+			if r.replica && r.err == nil && (r.resp == nil || r.resp.ETag == "") {
+				r.err = fmt.Errorf("read replica %s of state store %s returned no ETag", s.replica, s.name)
+			}
+			if r.err == nil || pending == 0 {
+				if hedged {
+					diag.DefaultMonitoring.StateReadHedged(s.name, r.replica)
+				}
+				if r.replica && r.err == nil && pending > 0 {
+					go s.verify(req.Key, r.resp.ETag, results)
+				}
+				return r.resp, r.err
+			}
+		}
+	}
+}
+
+// verify compares the ETag of a replica response with the ETag of the response of the store, once it answers
+func (s *hedgingStore) verify(key, etag string, results <-chan hedgedRead) {
+	r := <-results
+	if r.err != nil || r.resp == nil {
+		return
+	}
+	if r.resp.ETag != etag {
+		log.Debugf("read replica %s of state store %s returned a stale value of key %s", s.replica, s.name, key)
+		diag.DefaultMonitoring.StateHedgedReadStale(s.name)
+	}
+}
+
+type hedgingTransactionalStore struct {
+	*hedgingStore
+	transactional state.TransactionalStore
+}
+
+func (s *hedgingTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	return s.transactional.Multi(reqs)
+}
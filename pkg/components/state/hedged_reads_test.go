@@ -0,0 +1,118 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// delayedStore answers reads after a delay
+type delayedStore struct {
+	state.Store
+	delay time.Duration
+	data  string
+	etag  string
+	err   error
+	reads int32
+}
+
+func (d *delayedStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	atomic.AddInt32(&d.reads, 1)
+	time.Sleep(d.delay)
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &state.GetResponse{Data: []byte(d.data), ETag: d.etag}, nil
+}
+
+func TestWithHedgedReads(t *testing.T) {
+	hedged := func(primary, replica state.Store) state.Store {
+		s, err := WithHedgedReads(primary, map[string]string{
+			ReadReplicaMetadataKey:    "replica",
+			HedgeReadDelayMetadataKey: "20ms",
+		}, "primary", func(name string) (state.Store, bool) {
+			return replica, replica != nil
+		})
+		assert.NoError(t, err)
+		return s
+	}
+
+	t.Run("disabled leaves the store unchanged", func(t *testing.T) {
+		store := &fakeStore{}
+		s, err := WithHedgedReads(store, map[string]string{}, "primary", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, store, s)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := WithHedgedReads(&fakeStore{}, map[string]string{ReadReplicaMetadataKey: "primary"}, "primary", nil)
+		assert.Error(t, err)
+
+		_, err = WithHedgedReads(&fakeStore{}, map[string]string{
+			ReadReplicaMetadataKey:    "replica",
+			HedgeReadDelayMetadataKey: "soon",
+		}, "primary", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("fast reads aren't hedged", func(t *testing.T) {
+		replica := &delayedStore{data: "replica", etag: "1"}
+		s := hedged(&delayedStore{data: "primary", etag: "1"}, replica)
+
+		resp, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.NoError(t, err)
+		assert.Equal(t, "primary", string(resp.Data))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&replica.reads))
+	})
+
+	t.Run("slow reads are answered by the replica", func(t *testing.T) {
+		s := hedged(&delayedStore{delay: time.Millisecond * 200, data: "primary", etag: "1"}, &delayedStore{data: "replica", etag: "1"})
+
+		resp, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.NoError(t, err)
+		assert.Equal(t, "replica", string(resp.Data))
+	})
+
+	t.Run("replica responses without ETag are ignored", func(t *testing.T) {
+		s := hedged(&delayedStore{delay: time.Millisecond * 100, data: "primary", etag: "1"}, &delayedStore{data: "replica"})
+
+		resp, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.NoError(t, err)
+		assert.Equal(t, "primary", string(resp.Data))
+	})
+
+	t.Run("failed primary after the delay", func(t *testing.T) {
+		s := hedged(&delayedStore{delay: time.Millisecond * 50, err: errors.New("timeout")}, &delayedStore{delay: time.Millisecond * 100, data: "replica", etag: "1"})
+
+		resp, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.NoError(t, err)
+		assert.Equal(t, "replica", string(resp.Data))
+	})
+
+	t.Run("strong reads aren't hedged", func(t *testing.T) {
+		replica := &delayedStore{data: "replica", etag: "1"}
+		s := hedged(&delayedStore{delay: time.Millisecond * 50, data: "primary", etag: "1"}, replica)
+
+		resp, err := s.Get(&state.GetRequest{Key: "key", Options: state.GetStateOption{Consistency: state.Strong}})
+		assert.NoError(t, err)
+		assert.Equal(t, "primary", string(resp.Data))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&replica.reads))
+	})
+
+	t.Run("missing replica", func(t *testing.T) {
+		s := hedged(&delayedStore{delay: time.Millisecond * 50, data: "primary", etag: "1"}, nil)
+
+		resp, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.NoError(t, err)
+		assert.Equal(t, "primary", string(resp.Data))
+	})
+}
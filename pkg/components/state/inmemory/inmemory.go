@@ -0,0 +1,320 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package inmemory is a state store keeping its keys in the memory of the sidecar, for tests and local development.
+// Its keys are lost when the sidecar stops, and aren't shared between sidecars.
+package inmemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/components"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+)
+
+// TTLMetadataKey is the request metadata key of the number of seconds after which a saved key expires
+const TTLMetadataKey = "ttlInSeconds"
+
+// StateStore is an in-memory state store. Like the Redis state store, writes and deletes with an ETag fail when the
+// key exists with another ETag, unless their concurrency is last-write.
+type StateStore struct {
+	lock     sync.RWMutex
+	items    map[string]*item
+	version  uint64
+	watchers map[*watcher]bool
+}
+
+type item struct {
+	data    []byte
+	etag    string
+	expires time.Time
+}
+
+type watcher struct {
+	keys     map[string]bool
+	prefixes []string
+	handler  func(state_loader.StateChange)
+}
+
+// NewStateStore returns an empty in-memory state store
+func NewStateStore() *StateStore {
+	return &StateStore{
+		items:    map[string]*item{},
+		watchers: map[*watcher]bool{},
+	}
+}
+
+// Init does nothing, the store has no metadata
+func (s *StateStore) Init(metadata state.Metadata) error {
+	return nil
+}
+
+// Features returns the features of the store
+func (s *StateStore) Features() []string {
+	return []string{components.FeatureETag, components.FeatureTransactional, components.FeatureTTL, components.FeatureStreaming, components.FeatureBulkDelete, components.FeatureQuery, components.FeatureInsert}
+}
+
+// Get returns the value of a key, or an empty response if the key doesn't exist or expired
+func (s *StateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	i := s.get(req.Key)
+	if i == nil {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{Data: i.data, ETag: i.etag}, nil
+}
+
+// Set saves the value of a key
+func (s *StateStore) Set(req *state.SetRequest) error {
+	return state.SetWithRetries(func(req *state.SetRequest) error {
+		return s.apply([]state.TransactionalRequest{{Operation: state.Upsert, Request: *req}})
+	}, req)
+}
+
+// BulkSet saves the values of keys, all or none
+func (s *StateStore) BulkSet(req []state.SetRequest) error {
+	operations := make([]state.TransactionalRequest, 0, len(req))
+	for _, r := range req {
+		operations = append(operations, state.TransactionalRequest{Operation: state.Upsert, Request: r})
+	}
+	return s.apply(operations)
+}
+
+// Delete deletes a key
+func (s *StateStore) Delete(req *state.DeleteRequest) error {
+	return state.DeleteWithRetries(func(req *state.DeleteRequest) error {
+		return s.apply([]state.TransactionalRequest{{Operation: state.Delete, Request: *req}})
+	}, req)
+}
+
+// BulkDelete deletes keys, all or none
+func (s *StateStore) BulkDelete(req []state.DeleteRequest) error {
+	operations := make([]state.TransactionalRequest, 0, len(req))
+	for _, r := range req {
+		operations = append(operations, state.TransactionalRequest{Operation: state.Delete, Request: r})
+	}
+	return s.apply(operations)
+}
+
+// Multi applies the operations atomically: all of them if their ETags match, none otherwise
+func (s *StateStore) Multi(operations []state.TransactionalRequest) error {
+	return s.apply(operations)
+}
+
+// apply checks the operations and applies them atomically, then notifies the watchers of the changes
+func (s *StateStore) apply(operations []state.TransactionalRequest) error {
+	s.lock.Lock()
+
+	now := time.Now()
+	updates := map[string]*item{}
+	changes := []state_loader.StateChange{}
+	for _, o := range operations {
+		switch o.Operation {
+		case state.Upsert:
+			req, ok := o.Request.(state.SetRequest)
+			if !ok {
+				s.lock.Unlock()
+				return fmt.Errorf("invalid upsert request %v", o.Request)
+			}
+			if err := state.CheckSetRequestOptions(&req); err != nil {
+				s.lock.Unlock()
+				return err
+			}
+			if err := s.checkETag(updates, req.Key, req.ETag, req.Options.Concurrency); err != nil {
+				s.lock.Unlock()
+				return err
+			}
+			i, err := s.newItem(req, now)
+			if err != nil {
+				s.lock.Unlock()
+				return err
+			}
+			updates[req.Key] = i
+			changes = append(changes, state_loader.StateChange{Key: req.Key, Value: i.data, ETag: i.etag})
+		case state.Delete:
+			req, ok := o.Request.(state.DeleteRequest)
+			if !ok {
+				s.lock.Unlock()
+				return fmt.Errorf("invalid delete request %v", o.Request)
+			}
+			if err := state.CheckDeleteRequestOptions(&req); err != nil {
+				s.lock.Unlock()
+				return err
+			}
+			if err := s.checkETag(updates, req.Key, req.ETag, req.Options.Concurrency); err != nil {
+				s.lock.Unlock()
+				return err
+			}
+			updates[req.Key] = nil
+			changes = append(changes, state_loader.StateChange{Key: req.Key, Deleted: true})
+		default:
+			s.lock.Unlock()
+			return fmt.Errorf("unsupported operation %s", o.Operation)
+		}
+	}
+
+	for key, i := range updates {
+		if i == nil {
+			delete(s.items, key)
+		} else {
+			s.items[key] = i
+		}
+	}
+	notify := s.watching(changes)
+	s.lock.Unlock()
+
+	notify()
+	return nil
+}
+
+// checkETag returns an error if the key exists with another ETag than the one of a first-write request, considering
+// the pending updates of the operation. The lock must be held.
+func (s *StateStore) checkETag(updates map[string]*item, key, etag, concurrency string) error {
+	if etag == "" || concurrency == state.LastWrite {
+		return nil
+	}
+	current, pending := updates[key]
+	if !pending {
+		current = s.get(key)
+	}
+	if current != nil && current.etag != etag {
+		return fmt.Errorf("failed to change key %s: etag mismatch", key)
+	}
+	return nil
+}
+
+// newItem returns the item of a saved value, with a new ETag. The lock must be held.
+func (s *StateStore) newItem(req state.SetRequest, now time.Time) (*item, error) {
+	data, ok := req.Value.([]byte)
+	if !ok {
+		b, err := json.Marshal(req.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value of key %s: %s", req.Key, err)
+		}
+		data = b
+	}
+
+	s.version++
+	i := &item{data: data, etag: strconv.FormatUint(s.version, 10)}
+	if val, ok := req.Metadata[TTLMetadataKey]; ok && val != "" {
+		ttl, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %s of key %s", TTLMetadataKey, val, req.Key)
+		}
+		// a negative ttl never expires, like Redis
+		if ttl >= 0 {
+			i.expires = now.Add(time.Duration(ttl) * time.Second)
+		}
+	}
+	return i, nil
+}
+
+// get returns the item of a key, nil if it doesn't exist or expired. Expired items are removed by the next write of
+// their key or listing of the keys. The lock must be held.
+func (s *StateStore) get(key string) *item {
+	i, ok := s.items[key]
+	if !ok || (!i.expires.IsZero() && !time.Now().Before(i.expires)) {
+		return nil
+	}
+	return i
+}
+
+// ListKeys returns up to count keys starting with prefix after the cursor, in order, and the cursor of the next keys
+func (s *StateStore) ListKeys(prefix, cursor string, count int) ([]string, string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	keys := []string{}
+	for k := range s.items {
+		if s.get(k) == nil {
+			delete(s.items, k)
+			continue
+		}
+		if strings.HasPrefix(k, prefix) && k > cursor {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if count > 0 && len(keys) > count {
+		keys = keys[:count]
+		return keys, keys[count-1], nil
+	}
+	return keys, "", nil
+}
+
+// Query returns the keys starting with the prefix whose JSON values match the query
+func (s *StateStore) Query(req *state_loader.QueryRequest) (*state_loader.QueryResponse, error) {
+	s.lock.RLock()
+	items := make([]state_loader.QueryItem, 0, len(s.items))
+	for k := range s.items {
+		if !strings.HasPrefix(k, req.KeyPrefix) {
+			continue
+		}
+		if i := s.get(k); i != nil {
+			items = append(items, state_loader.QueryItem{Key: k, Data: i.data, ETag: i.etag})
+		}
+	}
+	s.lock.RUnlock()
+	return state_loader.RunQuery(&req.Query, items)
+}
+
+// Watch calls handler with the changes of the keys, and of the keys starting with the prefixes, until ctx is done
+func (s *StateStore) Watch(ctx context.Context, keys, prefixes []string, handler func(state_loader.StateChange)) error {
+	w := &watcher{keys: map[string]bool{}, prefixes: prefixes, handler: handler}
+	for _, k := range keys {
+		w.keys[k] = true
+	}
+
+	s.lock.Lock()
+	s.watchers[w] = true
+	s.lock.Unlock()
+
+	<-ctx.Done()
+
+	s.lock.Lock()
+	delete(s.watchers, w)
+	s.lock.Unlock()
+	return nil
+}
+
+// watching returns a function calling the watchers of the changes, to be called without holding the lock
+func (s *StateStore) watching(changes []state_loader.StateChange) func() {
+	calls := []func(){}
+	for w := range s.watchers {
+		for _, c := range changes {
+			if w.matches(c.Key) {
+				handler, change := w.handler, c
+				calls = append(calls, func() { handler(change) })
+			}
+		}
+	}
+	return func() {
+		for _, call := range calls {
+			call()
+		}
+	}
+}
+
+func (w *watcher) matches(key string) bool {
+	if w.keys[key] {
+		return true
+	}
+	for _, p := range w.prefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
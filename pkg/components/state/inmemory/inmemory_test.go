@@ -0,0 +1,136 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/conformance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConformance(t *testing.T) {
+	opts := conformance.DefaultStateOptions()
+	opts.TTL = true
+	report := &conformance.Report{}
+	conformance.CheckStateStore("in-memory", NewStateStore(), opts, report)
+
+	for _, r := range report.Results {
+		assert.Equal(t, conformance.StatusPassed, r.Status, "%s: %s", r.Check, r.Message)
+	}
+}
+
+func TestETags(t *testing.T) {
+	s := NewStateStore()
+	assert.NoError(t, s.Set(&state.SetRequest{Key: "k", Value: []byte("v1")}))
+	resp, err := s.Get(&state.GetRequest{Key: "k"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), resp.Data)
+
+	t.Run("stale etag is rejected", func(t *testing.T) {
+		assert.Error(t, s.Set(&state.SetRequest{Key: "k", Value: []byte("v2"), ETag: "stale"}))
+		assert.Error(t, s.Delete(&state.DeleteRequest{Key: "k", ETag: "stale"}))
+	})
+
+	t.Run("last-write ignores the etag", func(t *testing.T) {
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "k", Value: []byte("v2"), ETag: "stale", Options: state.SetStateOption{Concurrency: state.LastWrite}}))
+	})
+
+	t.Run("transactions are all or nothing", func(t *testing.T) {
+		err := s.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "other", Value: []byte("v")}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "k", ETag: "stale"}},
+		})
+		assert.Error(t, err)
+		resp, err := s.Get(&state.GetRequest{Key: "other"})
+		assert.NoError(t, err)
+		assert.Nil(t, resp.Data)
+	})
+}
+
+func TestTTL(t *testing.T) {
+	s := NewStateStore()
+	assert.NoError(t, s.Set(&state.SetRequest{Key: "k", Value: []byte("v"), Metadata: map[string]string{TTLMetadataKey: "0"}}))
+	resp, err := s.Get(&state.GetRequest{Key: "k"})
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Data)
+
+	keys, _, err := s.ListKeys("", "", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	assert.Error(t, s.Set(&state.SetRequest{Key: "k", Value: []byte("v"), Metadata: map[string]string{TTLMetadataKey: "soon"}}))
+}
+
+func TestListKeys(t *testing.T) {
+	s := NewStateStore()
+	for _, k := range []string{"app||c", "app||a", "other||a", "app||b"} {
+		assert.NoError(t, s.Set(&state.SetRequest{Key: k, Value: []byte("v")}))
+	}
+
+	keys, cursor, err := s.ListKeys("app||", "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app||a", "app||b"}, keys)
+	keys, cursor, err = s.ListKeys("app||", cursor, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app||c"}, keys)
+	assert.Empty(t, cursor)
+}
+
+func TestWatch(t *testing.T) {
+	s := NewStateStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan state_loader.StateChange, 10)
+	stopped := make(chan error)
+	go func() {
+		stopped <- s.Watch(ctx, []string{"key"}, []string{"prefix-"}, func(c state_loader.StateChange) {
+			changes <- c
+		})
+	}()
+	assert.Eventually(t, func() bool {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		return len(s.watchers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, s.Set(&state.SetRequest{Key: "key", Value: []byte("v")}))
+	assert.NoError(t, s.Set(&state.SetRequest{Key: "unwatched", Value: []byte("v")}))
+	assert.NoError(t, s.Delete(&state.DeleteRequest{Key: "prefix-1"}))
+
+	c := <-changes
+	assert.Equal(t, "key", c.Key)
+	assert.Equal(t, []byte("v"), c.Value)
+	assert.NotEmpty(t, c.ETag)
+	c = <-changes
+	assert.Equal(t, "prefix-1", c.Key)
+	assert.True(t, c.Deleted)
+
+	cancel()
+	assert.NoError(t, <-stopped)
+}
+
+func TestQuery(t *testing.T) {
+	s := NewStateStore()
+	assert.NoError(t, s.BulkSet([]state.SetRequest{
+		{Key: "a", Value: map[string]interface{}{"city": "Seattle"}},
+		{Key: "other-a", Value: map[string]interface{}{"city": "Seattle"}},
+		{Key: "b", Value: map[string]interface{}{"city": "Paris"}},
+		{Key: "c", Value: map[string]interface{}{"city": "Seattle"}, Metadata: map[string]string{TTLMetadataKey: "0"}},
+	}))
+
+	query, err := state_loader.ParseQuery([]byte(`{"filter": {"EQ": {"city": "Seattle"}}}`))
+	assert.NoError(t, err)
+	resp, err := s.Query(&state_loader.QueryRequest{Query: *query, KeyPrefix: "a"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Results, 1)
+	assert.Equal(t, "a", resp.Results[0].Key)
+	assert.Equal(t, []byte(`{"city":"Seattle"}`), resp.Results[0].Data)
+	assert.NotEmpty(t, resp.Results[0].ETag)
+}
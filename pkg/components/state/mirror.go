@@ -0,0 +1,360 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+const (
+	// MirrorMetadataKey is the component metadata key of the name of the secondary state store that writes are mirrored to
+	MirrorMetadataKey = "mirrorTo"
+	// MirrorQueueSizeMetadataKey is the component metadata key of the number of writes waiting to be mirrored
+	MirrorQueueSizeMetadataKey = "mirrorQueueSize"
+
+	defaultMirrorQueueSize = 1000
+)
+
+// MirrorReport is the reconciliation report of a state store mirrored to a secondary store
+type MirrorReport struct {
+	Secondary string `json:"secondary"`
+	Mirrored  int64  `json:"mirrored"`
+	Failed    int64  `json:"failed"`
+	Dropped   int64  `json:"dropped"`
+	Pending   int    `json:"pending"`
+	// DivergentKeys are the keys whose last write wasn't mirrored and whose values differ in the secondary store
+	DivergentKeys []string  `json:"divergentKeys"`
+	CheckedAt     time.Time `json:"checkedAt"`
+}
+
+// MirroringStore is implemented by state stores that mirror their writes to a secondary store
+type MirroringStore interface {
+	MirrorReport() (MirrorReport, error)
+}
+
+// Mirror returns the mirroring of the store, if it's enabled
+func Mirror(store state.Store) (MirroringStore, bool) {
+	m, ok := store.(MirroringStore)
+	return m, ok
+}
+
+// WithMirroring returns the store with its writes mirrored asynchronously to the secondary store named in its
+// component metadata. The secondary store is looked up by name on each write, so it may be loaded after the store.
+// Mirrored writes go to the secondary store without its own wrappers and without ETags.
+// Writes that can't be mirrored because the queue is full are dropped, and their keys reported as divergent.
+func WithMirroring(store state.Store, properties map[string]string, name string, lookup func(name string) (state.Store, bool)) (state.Store, error) {
+	secondary := properties[MirrorMetadataKey]
+	if secondary == "" {
+		return store, nil
+	}
+	if secondary == name {
+		return nil, fmt.Errorf("state store %s can't be mirrored to itself", name)
+	}
+
+	size := defaultMirrorQueueSize
+	if val, ok := properties[MirrorQueueSizeMetadataKey]; ok && val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", MirrorQueueSizeMetadataKey, val)
+		}
+		size = n
+	}
+
+	s := &mirroringStore{
+		Store:     store,
+		name:      name,
+		secondary: secondary,
+		lookup:    lookup,
+		queue:     make(chan mirroredWrite, size),
+		divergent: map[string]struct{}{},
+	}
+	go s.mirrorLoop()
+
+	if transactional, ok := store.(state.TransactionalStore); ok {
+		return &mirroringTransactionalStore{mirroringStore: s, transactional: transactional}, nil
+	}
+	return s, nil
+}
+
+// mirroredWrite is a successful write of the primary store to apply to the secondary store
+type mirroredWrite struct {
+	sets    []state.SetRequest
+	deletes []state.DeleteRequest
+	multi   []state.TransactionalRequest
+}
+
+func (w *mirroredWrite) keys() []string {
+	keys := []string{}
+	for _, r := range w.sets {
+		keys = append(keys, r.Key)
+	}
+	for _, r := range w.deletes {
+		keys = append(keys, r.Key)
+	}
+	for _, r := range w.multi {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			keys = append(keys, req.Key)
+		case *state.SetRequest:
+			keys = append(keys, req.Key)
+		case state.DeleteRequest:
+			keys = append(keys, req.Key)
+		case *state.DeleteRequest:
+			keys = append(keys, req.Key)
+		}
+	}
+	return keys
+}
+
+type mirroringStore struct {
+	state.Store
+	name      string
+	secondary string
+	lookup    func(name string) (state.Store, bool)
+	queue     chan mirroredWrite
+
+	lock      sync.Mutex
+	mirrored  int64
+	failed    int64
+	dropped   int64
+	divergent map[string]struct{}
+}
+
+// mirror queues the write for the secondary store, or drops it if the queue is full
+func (s *mirroringStore) mirror(w mirroredWrite) {
+	select {
+	case s.queue <- w:
+	default:
+		log.Warnf("mirror queue of state store %s is full, dropping write", s.name)
+		s.lock.Lock()
+		s.dropped++
+		s.markDivergent(w.keys(), true)
+		s.lock.Unlock()
+		diag.DefaultMonitoring.StateMirrored(s.name, false)
+	}
+}
+
+// markDivergent adds or removes the keys from the divergent keys. The lock must be held.
+func (s *mirroringStore) markDivergent(keys []string, divergent bool) {
+	for _, k := range keys {
+		if divergent {
+			s.divergent[k] = struct{}{}
+		} else {
+			delete(s.divergent, k)
+		}
+	}
+	diag.DefaultMonitoring.StateMirrorDivergence(s.name, int64(len(s.divergent)))
+}
+
+func (s *mirroringStore) mirrorLoop() {
+	for w := range s.queue {
+		err := s.apply(w)
+		if err != nil {
+			log.Debugf("failed to mirror write of state store %s to %s: %s", s.name, s.secondary, err)
+		}
+
+		s.lock.Lock()
+		if err != nil {
+			s.failed++
+		} else {
+			s.mirrored++
+		}
+		// a mirrored write brings its keys back in line with the primary store
+		s.markDivergent(w.keys(), err != nil)
+		s.lock.Unlock()
+		diag.DefaultMonitoring.StateMirrored(s.name, err == nil)
+	}
+}
+
+func (s *mirroringStore) secondaryStore() (state.Store, error) {
+	store, ok := s.lookup(s.secondary)
+	if !ok || store == nil {
+		return nil, fmt.Errorf("secondary state store %s not found", s.secondary)
+	}
+	return Unwrap(store), nil
+}
+
+func (s *mirroringStore) apply(w mirroredWrite) error {
+	store, err := s.secondaryStore()
+	if err != nil {
+		return err
+	}
+
+	if len(w.sets) > 0 {
+		if err := store.BulkSet(w.sets); err != nil {
+			return err
+		}
+	}
+	if len(w.deletes) > 0 {
+		if err := store.BulkDelete(w.deletes); err != nil {
+			return err
+		}
+	}
+	if len(w.multi) == 0 {
+		return nil
+	}
+	if transactional, ok := store.(state.TransactionalStore); ok {
+		return transactional.Multi(w.multi)
+	}
+	// a secondary store without transactions applies the operations one by one
+	for _, r := range w.multi {
+		var err error
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			err = store.Set(&req)
+		case state.DeleteRequest:
+			err = store.Delete(&req)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mirroredSet copies the request without its ETag, which only applies to the primary store
+func mirroredSet(req state.SetRequest) state.SetRequest {
+	req.ETag = ""
+	req.Options.Concurrency = ""
+	return req
+}
+
+func mirroredDelete(req state.DeleteRequest) state.DeleteRequest {
+	req.ETag = ""
+	req.Options.Concurrency = ""
+	return req
+}
+
+func (s *mirroringStore) Set(req *state.SetRequest) error {
+	if err := s.Store.Set(req); err != nil {
+		return err
+	}
+	s.mirror(mirroredWrite{sets: []state.SetRequest{mirroredSet(*req)}})
+	return nil
+}
+
+func (s *mirroringStore) BulkSet(req []state.SetRequest) error {
+	if err := s.Store.BulkSet(req); err != nil {
+		return err
+	}
+	w := mirroredWrite{}
+	for _, r := range req {
+		w.sets = append(w.sets, mirroredSet(r))
+	}
+	s.mirror(w)
+	return nil
+}
+
+func (s *mirroringStore) Delete(req *state.DeleteRequest) error {
+	if err := s.Store.Delete(req); err != nil {
+		return err
+	}
+	s.mirror(mirroredWrite{deletes: []state.DeleteRequest{mirroredDelete(*req)}})
+	return nil
+}
+
+func (s *mirroringStore) BulkDelete(req []state.DeleteRequest) error {
+	if err := s.Store.BulkDelete(req); err != nil {
+		return err
+	}
+	w := mirroredWrite{}
+	for _, r := range req {
+		w.deletes = append(w.deletes, mirroredDelete(r))
+	}
+	s.mirror(w)
+	return nil
+}
+
+// MirrorReport compares the divergent keys in both stores and returns the ones that still differ
+func (s *mirroringStore) MirrorReport() (MirrorReport, error) {
+	s.lock.Lock()
+	keys := make([]string, 0, len(s.divergent))
+	for k := range s.divergent {
+		keys = append(keys, k)
+	}
+	s.lock.Unlock()
+
+	secondary, err := s.secondaryStore()
+	if err != nil {
+		return MirrorReport{}, err
+	}
+	reconciled := []string{}
+	for _, k := range keys {
+		primaryResp, err := s.Store.Get(&state.GetRequest{Key: k})
+		if err != nil {
+			return MirrorReport{}, err
+		}
+		secondaryResp, err := secondary.Get(&state.GetRequest{Key: k})
+		if err != nil {
+			return MirrorReport{}, err
+		}
+		if bytes.Equal(responseData(primaryResp), responseData(secondaryResp)) {
+			reconciled = append(reconciled, k)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.markDivergent(reconciled, false)
+
+	report := MirrorReport{
+		Secondary:     s.secondary,
+		Mirrored:      s.mirrored,
+		Failed:        s.failed,
+		Dropped:       s.dropped,
+		Pending:       len(s.queue),
+		DivergentKeys: make([]string, 0, len(s.divergent)),
+		CheckedAt:     time.Now().UTC(),
+	}
+	for k := range s.divergent {
+		report.DivergentKeys = append(report.DivergentKeys, k)
+	}
+	sort.Strings(report.DivergentKeys)
+	return report, nil
+}
+
+func responseData(resp *state.GetResponse) []byte {
+	if resp == nil {
+		return nil
+	}
+	return resp.Data
+}
+
+type mirroringTransactionalStore struct {
+	*mirroringStore
+	transactional state.TransactionalStore
+}
+
+func (s *mirroringTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	if err := s.transactional.Multi(reqs); err != nil {
+		return err
+	}
+
+	w := mirroredWrite{}
+	for _, r := range reqs {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			r.Request = mirroredSet(req)
+		case *state.SetRequest:
+			r.Request = mirroredSet(*req)
+		case state.DeleteRequest:
+			r.Request = mirroredDelete(req)
+		case *state.DeleteRequest:
+			r.Request = mirroredDelete(*req)
+		}
+		w.multi = append(w.multi, r)
+	}
+	s.mirror(w)
+	return nil
+}
@@ -0,0 +1,177 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore keeps string and byte values in memory
+type memoryStore struct {
+	state.Store
+	lock   sync.Mutex
+	values map[string][]byte
+	etags  []string
+	down   bool
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{values: map[string][]byte{}}
+}
+
+func (m *memoryStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return &state.GetResponse{Data: m.values[req.Key]}, nil
+}
+
+func (m *memoryStore) Set(req *state.SetRequest) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.down {
+		return errors.New("store is unreachable")
+	}
+	switch v := req.Value.(type) {
+	case string:
+		m.values[req.Key] = []byte(v)
+	case []byte:
+		m.values[req.Key] = v
+	}
+	m.etags = append(m.etags, req.ETag)
+	return nil
+}
+
+func (m *memoryStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		if err := m.Set(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) Delete(req *state.DeleteRequest) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.down {
+		return errors.New("store is unreachable")
+	}
+	delete(m.values, req.Key)
+	return nil
+}
+
+func (m *memoryStore) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		if err := m.Delete(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) setDown(down bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.down = down
+}
+
+func TestWithMirroring(t *testing.T) {
+	lookup := func(stores map[string]state.Store) func(string) (state.Store, bool) {
+		return func(name string) (state.Store, bool) {
+			s, ok := stores[name]
+			return s, ok
+		}
+	}
+
+	t.Run("disabled leaves the store unchanged", func(t *testing.T) {
+		store := &fakeStore{}
+		s, err := WithMirroring(store, map[string]string{}, "primary", lookup(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, store, s)
+
+		_, ok := Mirror(s)
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := WithMirroring(&fakeStore{}, map[string]string{MirrorMetadataKey: "primary"}, "primary", lookup(nil))
+		assert.Error(t, err)
+
+		_, err = WithMirroring(&fakeStore{}, map[string]string{
+			MirrorMetadataKey:          "backup",
+			MirrorQueueSizeMetadataKey: "-1",
+		}, "primary", lookup(nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("writes are mirrored without ETags", func(t *testing.T) {
+		primary, secondary := newMemoryStore(), newMemoryStore()
+		s, err := WithMirroring(primary, map[string]string{MirrorMetadataKey: "backup"}, "primary", lookup(map[string]state.Store{"backup": secondary}))
+		assert.NoError(t, err)
+
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||order", Value: "1", ETag: "etag"}))
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||cart", Value: "2"}))
+		assert.NoError(t, s.Delete(&state.DeleteRequest{Key: "app||cart"}))
+
+		mirror, ok := Mirror(s)
+		assert.True(t, ok)
+		assert.Eventually(t, func() bool {
+			report, err := mirror.MirrorReport()
+			return err == nil && report.Mirrored == 3
+		}, time.Second, time.Millisecond*10)
+
+		resp, _ := secondary.Get(&state.GetRequest{Key: "app||order"})
+		assert.Equal(t, []byte("1"), resp.Data)
+		resp, _ = secondary.Get(&state.GetRequest{Key: "app||cart"})
+		assert.Nil(t, resp.Data)
+		assert.Equal(t, []string{"", ""}, secondary.etags)
+		assert.Equal(t, primary, Unwrap(s))
+	})
+
+	t.Run("failed writes are reported until the stores converge", func(t *testing.T) {
+		primary, secondary := newMemoryStore(), newMemoryStore()
+		secondary.setDown(true)
+		s, err := WithMirroring(primary, map[string]string{MirrorMetadataKey: "backup"}, "primary", lookup(map[string]state.Store{"backup": secondary}))
+		assert.NoError(t, err)
+		mirror, _ := Mirror(s)
+
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||order", Value: "1"}))
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||cart", Value: "2"}))
+
+		var report MirrorReport
+		assert.Eventually(t, func() bool {
+			report, err = mirror.MirrorReport()
+			return err == nil && report.Failed == 2
+		}, time.Second, time.Millisecond*10)
+		assert.Equal(t, "backup", report.Secondary)
+		assert.Equal(t, []string{"app||cart", "app||order"}, report.DivergentKeys)
+
+		// a later mirrored write and a manual repair both reconcile their key
+		secondary.setDown(false)
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||order", Value: "3"}))
+		assert.NoError(t, secondary.Set(&state.SetRequest{Key: "app||cart", Value: "2"}))
+		assert.Eventually(t, func() bool {
+			report, err = mirror.MirrorReport()
+			return err == nil && report.Mirrored == 1
+		}, time.Second, time.Millisecond*10)
+		assert.Empty(t, report.DivergentKeys)
+	})
+
+	t.Run("missing secondary store", func(t *testing.T) {
+		s, err := WithMirroring(newMemoryStore(), map[string]string{MirrorMetadataKey: "backup"}, "primary", lookup(nil))
+		assert.NoError(t, err)
+		mirror, _ := Mirror(s)
+
+		_, err = mirror.MirrorReport()
+		assert.Error(t, err)
+	})
+}
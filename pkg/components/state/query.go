@@ -0,0 +1,311 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Filter operators
+const (
+	// FilterEQ matches the values whose field equals the value, e.g. {"EQ": {"city": "Seattle"}}
+	FilterEQ = "EQ"
+	// FilterIN matches the values whose field equals one of the values, e.g. {"IN": {"city": ["Seattle", "Paris"]}}
+	FilterIN = "IN"
+	// FilterAND matches the values matching all the filters, e.g. {"AND": [{"EQ": ...}, {"IN": ...}]}
+	FilterAND = "AND"
+	// FilterOR matches the values matching one of the filters
+	FilterOR = "OR"
+)
+
+// Sort orders
+const (
+	SortASC  = "ASC"
+	SortDESC = "DESC"
+)
+
+// Querier is implemented by state stores that query their JSON values
+type Querier interface {
+	Query(req *QueryRequest) (*QueryResponse, error)
+}
+
+// Query queries the JSON values of a state store, e.g.
+// {"filter": {"EQ": {"address.city": "Seattle"}}, "sort": [{"key": "name", "order": "DESC"}], "page": {"limit": 10}}
+type Query struct {
+	Filter *Filter    `json:"filter,omitempty"`
+	Sort   []Sorting  `json:"sort,omitempty"`
+	Page   Pagination `json:"page,omitempty"`
+}
+
+// Filter matches the values on their fields. Fields are dotted paths in the values, e.g. address.city.
+type Filter struct {
+	Op string
+	// Key is the field of EQ and IN filters
+	Key string
+	// Value is the value of EQ filters
+	Value interface{}
+	// Values are the values of IN filters
+	Values []interface{}
+	// Filters are the filters combined by AND and OR filters
+	Filters []*Filter
+}
+
+// Sorting sorts the values on a field, in ascending order by default
+type Sorting struct {
+	Key   string `json:"key"`
+	Order string `json:"order,omitempty"`
+}
+
+// Pagination limits the number of values returned. Token is the token of the previous page, to get the next one.
+type Pagination struct {
+	Limit int    `json:"limit,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+// QueryRequest is the query of a state store. Only the keys starting with KeyPrefix are queried.
+type QueryRequest struct {
+	Query     Query
+	KeyPrefix string
+	Metadata  map[string]string
+}
+
+// QueryItem is a key matching a query and its value
+type QueryItem struct {
+	Key  string
+	Data []byte
+	ETag string
+}
+
+// QueryResponse is a page of the keys matching a query. Token is the token of the next page, empty on the last page.
+type QueryResponse struct {
+	Results []QueryItem
+	Token   string
+}
+
+// ParseQuery parses the JSON query
+func ParseQuery(data []byte) (*Query, error) {
+	var q Query
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("invalid query: %s", err)
+	}
+	for _, s := range q.Sort {
+		if s.Key == "" {
+			return nil, errors.New("invalid query: sort without key")
+		}
+		if s.Order != "" && s.Order != SortASC && s.Order != SortDESC {
+			return nil, fmt.Errorf("invalid query: sort order %s is not %s or %s", s.Order, SortASC, SortDESC)
+		}
+	}
+	if q.Page.Limit < 0 {
+		return nil, errors.New("invalid query: negative page limit")
+	}
+	return &q, nil
+}
+
+// UnmarshalJSON parses the filter object, with a single operator
+func (f *Filter) UnmarshalJSON(data []byte) error {
+	var operators map[string]json.RawMessage
+	if err := json.Unmarshal(data, &operators); err != nil {
+		return err
+	}
+	if len(operators) != 1 {
+		return errors.New("a filter has a single operator")
+	}
+
+	for op, arg := range operators {
+		f.Op = op
+		switch op {
+		case FilterEQ, FilterIN:
+			var fields map[string]interface{}
+			if err := json.Unmarshal(arg, &fields); err != nil {
+				return err
+			}
+			if len(fields) != 1 {
+				return fmt.Errorf("%s filter has a single field", op)
+			}
+			for k, v := range fields {
+				f.Key = k
+				if op == FilterEQ {
+					f.Value = v
+					continue
+				}
+				values, ok := v.([]interface{})
+				if !ok {
+					return fmt.Errorf("IN filter of field %s has no array of values", k)
+				}
+				f.Values = values
+			}
+		case FilterAND, FilterOR:
+			if err := json.Unmarshal(arg, &f.Filters); err != nil {
+				return err
+			}
+			if len(f.Filters) == 0 {
+				return fmt.Errorf("%s filter has no filters", op)
+			}
+		default:
+			return fmt.Errorf("unknown filter operator %s", op)
+		}
+	}
+	return nil
+}
+
+// Match returns whether the decoded JSON value matches the filter
+func (f *Filter) Match(value interface{}) bool {
+	switch f.Op {
+	case FilterEQ:
+		field, ok := lookupField(value, f.Key)
+		return ok && reflect.DeepEqual(field, f.Value)
+	case FilterIN:
+		field, ok := lookupField(value, f.Key)
+		if !ok {
+			return false
+		}
+		for _, v := range f.Values {
+			if reflect.DeepEqual(field, v) {
+				return true
+			}
+		}
+		return false
+	case FilterAND:
+		for _, filter := range f.Filters {
+			if !filter.Match(value) {
+				return false
+			}
+		}
+		return true
+	case FilterOR:
+		for _, filter := range f.Filters {
+			if filter.Match(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupField returns the field of the decoded JSON value at the dotted path
+func lookupField(value interface{}, path string) (interface{}, bool) {
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// RunQuery runs the query over the items, for state stores without native queries. Items whose values aren't JSON
+// only match queries without filter and sort. Items are in the order of their keys unless sorted otherwise, and the
+// page tokens are offsets in the results.
+func RunQuery(query *Query, items []QueryItem) (*QueryResponse, error) {
+	offset := 0
+	if query.Page.Token != "" {
+		o, err := strconv.Atoi(query.Page.Token)
+		if err != nil || o < 0 {
+			return nil, fmt.Errorf("invalid page token %s", query.Page.Token)
+		}
+		offset = o
+	}
+
+	type match struct {
+		item  QueryItem
+		value interface{}
+	}
+	matches := []match{}
+	for _, i := range items {
+		var value interface{}
+		if err := json.Unmarshal(i.Data, &value); err != nil {
+			if query.Filter != nil || len(query.Sort) > 0 {
+				continue
+			}
+		}
+		if query.Filter == nil || query.Filter.Match(value) {
+			matches = append(matches, match{item: i, value: value})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		for _, s := range query.Sort {
+			a, _ := lookupField(matches[i].value, s.Key)
+			b, _ := lookupField(matches[j].value, s.Key)
+			c := compareValues(a, b)
+			if s.Order == SortDESC {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return matches[i].item.Key < matches[j].item.Key
+	})
+
+	resp := &QueryResponse{Results: []QueryItem{}}
+	if offset >= len(matches) {
+		return resp, nil
+	}
+	end := len(matches)
+	if query.Page.Limit > 0 && offset+query.Page.Limit < end {
+		end = offset + query.Page.Limit
+		resp.Token = strconv.Itoa(end)
+	}
+	for _, m := range matches[offset:end] {
+		resp.Results = append(resp.Results, m.item)
+	}
+	return resp, nil
+}
+
+// compareValues orders decoded JSON values: missing and null values, then booleans, numbers, strings and the others,
+// which compare equal
+func compareValues(a, b interface{}) int {
+	if ra, rb := valueRank(a), valueRank(b); ra != rb {
+		return ra - rb
+	}
+	switch va := a.(type) {
+	case bool:
+		vb := b.(bool)
+		if va == vb {
+			return 0
+		}
+		if !va {
+			return -1
+		}
+		return 1
+	case float64:
+		vb := b.(float64)
+		if va < vb {
+			return -1
+		}
+		if va > vb {
+			return 1
+		}
+	case string:
+		return strings.Compare(va, b.(string))
+	}
+	return 0
+}
+
+func valueRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case float64:
+		return 2
+	case string:
+		return 3
+	}
+	return 4
+}
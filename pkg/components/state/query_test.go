@@ -0,0 +1,102 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queryItems() []QueryItem {
+	return []QueryItem{
+		{Key: "1", Data: []byte(`{"name": "alice", "age": 31, "address": {"city": "Seattle"}}`)},
+		{Key: "2", Data: []byte(`{"name": "bob", "age": 25, "address": {"city": "Paris"}}`)},
+		{Key: "3", Data: []byte(`{"name": "carol", "age": 42, "address": {"city": "Seattle"}}`)},
+		{Key: "4", Data: []byte(`{"name": "dave", "address": {"city": "London"}}`)},
+		{Key: "5", Data: []byte(`not json`)},
+	}
+}
+
+func resultKeys(resp *QueryResponse) []string {
+	keys := []string{}
+	for _, r := range resp.Results {
+		keys = append(keys, r.Key)
+	}
+	return keys
+}
+
+func runQuery(t *testing.T, query string) *QueryResponse {
+	q, err := ParseQuery([]byte(query))
+	require.NoError(t, err)
+	resp, err := RunQuery(q, queryItems())
+	require.NoError(t, err)
+	return resp
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery([]byte(`{"filter": {"AND": [{"EQ": {"address.city": "Seattle"}}, {"IN": {"age": [31, 42]}}]}, "sort": [{"key": "age", "order": "DESC"}], "page": {"limit": 2, "token": "2"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, FilterAND, q.Filter.Op)
+	assert.Len(t, q.Filter.Filters, 2)
+	assert.Equal(t, "address.city", q.Filter.Filters[0].Key)
+	assert.Equal(t, "Seattle", q.Filter.Filters[0].Value)
+	assert.Equal(t, []interface{}{float64(31), float64(42)}, q.Filter.Filters[1].Values)
+	assert.Equal(t, []Sorting{{Key: "age", Order: SortDESC}}, q.Sort)
+	assert.Equal(t, Pagination{Limit: 2, Token: "2"}, q.Page)
+
+	for _, invalid := range []string{
+		`not json`,
+		`{"filter": {"EQ": {"a": 1}, "IN": {"b": [1]}}}`,
+		`{"filter": {"EQ": {"a": 1, "b": 2}}}`,
+		`{"filter": {"IN": {"a": 1}}}`,
+		`{"filter": {"AND": []}}`,
+		`{"filter": {"LIKE": {"a": "b"}}}`,
+		`{"sort": [{"order": "ASC"}]}`,
+		`{"sort": [{"key": "a", "order": "UP"}]}`,
+		`{"page": {"limit": -1}}`,
+	} {
+		_, err := ParseQuery([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRunQuery(t *testing.T) {
+	t.Run("no filter", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "2", "3", "4", "5"}, resultKeys(runQuery(t, `{}`)))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "3"}, resultKeys(runQuery(t, `{"filter": {"EQ": {"address.city": "Seattle"}}}`)))
+		assert.Equal(t, []string{"2", "4"}, resultKeys(runQuery(t, `{"filter": {"IN": {"address.city": ["Paris", "London"]}}}`)))
+		assert.Equal(t, []string{"3"}, resultKeys(runQuery(t, `{"filter": {"AND": [{"EQ": {"address.city": "Seattle"}}, {"EQ": {"age": 42}}]}}`)))
+		assert.Equal(t, []string{"2", "3"}, resultKeys(runQuery(t, `{"filter": {"OR": [{"EQ": {"name": "bob"}}, {"EQ": {"age": 42}}]}}`)))
+		assert.Empty(t, resultKeys(runQuery(t, `{"filter": {"EQ": {"missing": "value"}}}`)))
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		assert.Equal(t, []string{"4", "2", "1", "3"}, resultKeys(runQuery(t, `{"sort": [{"key": "age"}]}`)))
+		assert.Equal(t, []string{"3", "1", "2", "4"}, resultKeys(runQuery(t, `{"sort": [{"key": "age", "order": "DESC"}]}`)))
+		assert.Equal(t, []string{"2", "1", "3"}, resultKeys(runQuery(t, `{"filter": {"IN": {"address.city": ["Paris", "Seattle"]}}, "sort": [{"key": "address.city"}, {"key": "name", "order": "ASC"}]}`)))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		resp := runQuery(t, `{"sort": [{"key": "name"}], "page": {"limit": 3}}`)
+		assert.Equal(t, []string{"1", "2", "3"}, resultKeys(resp))
+		assert.Equal(t, "3", resp.Token)
+
+		resp = runQuery(t, `{"sort": [{"key": "name"}], "page": {"limit": 3, "token": "3"}}`)
+		assert.Equal(t, []string{"4"}, resultKeys(resp))
+		assert.Empty(t, resp.Token)
+
+		resp = runQuery(t, `{"page": {"token": "10"}}`)
+		assert.Empty(t, resp.Results)
+
+		_, err := RunQuery(&Query{Page: Pagination{Token: "next"}}, queryItems())
+		assert.Error(t, err)
+	})
+}
@@ -9,10 +9,12 @@ import (
 	"fmt"
 
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/components"
 )
 
 type State struct {
 	Name          string
+	Version       string
 	FactoryMethod func() state.Store
 }
 
@@ -23,10 +25,19 @@ func New(name string, factoryMethod func() state.Store) State {
 	}
 }
 
+// NewVersioned creates a version of a State, e.g. v2
+func NewVersioned(name, version string, factoryMethod func() state.Store) State {
+	return State{
+		Name:          name,
+		Version:       version,
+		FactoryMethod: factoryMethod,
+	}
+}
+
 // Registry is an interface for a component that returns registered state store implementations
 type Registry interface {
 	Register(components ...State)
-	CreateStateStore(name string) (state.Store, error)
+	CreateStateStore(name, version string) (state.Store, error)
 }
 
 type stateStoreRegistry struct {
@@ -42,17 +53,17 @@ func NewRegistry() Registry {
 
 // // Register registers a new factory method that creates an instance of a StateStore.
 // // The key is the name of the state store, eg. redis.
-func (s *stateStoreRegistry) Register(components ...State) {
-	for _, component := range components {
-		s.stateStores[createFullName(component.Name)] = component.FactoryMethod
+func (s *stateStoreRegistry) Register(definitions ...State) {
+	for _, component := range definitions {
+		s.stateStores[components.VersionedName(createFullName(component.Name), component.Version)] = component.FactoryMethod
 	}
 }
 
-func (s *stateStoreRegistry) CreateStateStore(name string) (state.Store, error) {
-	if method, ok := s.stateStores[name]; ok {
+func (s *stateStoreRegistry) CreateStateStore(name, version string) (state.Store, error) {
+	if method, ok := s.stateStores[components.VersionedName(name, version)]; ok {
 		return method(), nil
 	}
-	return nil, fmt.Errorf("couldn't find state store %s", name)
+	return nil, fmt.Errorf("couldn't find state store %s", components.TypeVersion(name, version))
 }
 
 func createFullName(name string) string {
@@ -0,0 +1,165 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	// SchemaMetadataKey is the component metadata key of the JSON schema for all keys.
+	// Schemas for a key prefix are set with SchemaMetadataKey.<prefix>, e.g. jsonSchema.order-
+	SchemaMetadataKey = "jsonSchema"
+
+	// separates the app id from the key in state store keys
+	keySeparator = "||"
+)
+
+// SchemaValidationError is returned when a saved value doesn't match the JSON schema of its key
+type SchemaValidationError struct {
+	Key    string
+	Errors []string
+}
+
+func (e *SchemaValidationError) Error() string {
+	return fmt.Sprintf("value of key %s does not match its JSON schema: %s", e.Key, strings.Join(e.Errors, "; "))
+}
+
+type keySchema struct {
+	prefix string
+	schema *gojsonschema.Schema
+}
+
+// schemaValidator validates values against the schema with the longest key prefix matching their key
+type schemaValidator struct {
+	schemas []keySchema
+}
+
+func newSchemaValidator(properties map[string]string) (*schemaValidator, error) {
+	v := &schemaValidator{}
+	for k, val := range properties {
+		if k != SchemaMetadataKey && !strings.HasPrefix(k, SchemaMetadataKey+".") {
+			continue
+		}
+		schema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON schema in %s: %s", k, err)
+		}
+		v.schemas = append(v.schemas, keySchema{
+			prefix: strings.TrimPrefix(strings.TrimPrefix(k, SchemaMetadataKey), "."),
+			schema: schema,
+		})
+	}
+	sort.Slice(v.schemas, func(i, j int) bool {
+		return len(v.schemas[i].prefix) > len(v.schemas[j].prefix)
+	})
+	return v, nil
+}
+
+func (v *schemaValidator) validate(req *state.SetRequest) error {
+	key := req.Key
+	if i := strings.Index(key, keySeparator); i >= 0 {
+		key = key[i+len(keySeparator):]
+	}
+
+	for _, s := range v.schemas {
+		if !strings.HasPrefix(key, s.prefix) {
+			continue
+		}
+
+		var loader gojsonschema.JSONLoader
+		switch value := req.Value.(type) {
+		case []byte:
+			loader = gojsonschema.NewBytesLoader(value)
+		case string:
+			loader = gojsonschema.NewStringLoader(value)
+		default:
+			loader = gojsonschema.NewGoLoader(value)
+		}
+
+		result, err := s.schema.Validate(loader)
+		if err != nil {
+			return &SchemaValidationError{Key: key, Errors: []string{fmt.Sprintf("value is not valid JSON: %s", err)}}
+		}
+		if !result.Valid() {
+			validationErr := &SchemaValidationError{Key: key}
+			for _, e := range result.Errors() {
+				validationErr.Errors = append(validationErr.Errors, e.String())
+			}
+			return validationErr
+		}
+		return nil
+	}
+	return nil
+}
+
+// WithSchemaValidation returns the store with JSON schema validation of saved values when its component metadata defines schemas.
+// Transactional stores stay transactional and validate their upserts.
+func WithSchemaValidation(store state.Store, properties map[string]string) (state.Store, error) {
+	validator, err := newSchemaValidator(properties)
+	if err != nil {
+		return nil, err
+	}
+	if len(validator.schemas) == 0 {
+		return store, nil
+	}
+
+	s := &schemaValidatingStore{Store: store, validator: validator}
+	if transactional, ok := store.(state.TransactionalStore); ok {
+		return &schemaValidatingTransactionalStore{schemaValidatingStore: s, transactional: transactional}, nil
+	}
+	return s, nil
+}
+
+type schemaValidatingStore struct {
+	state.Store
+	validator *schemaValidator
+}
+
+func (s *schemaValidatingStore) Set(req *state.SetRequest) error {
+	if err := s.validator.validate(req); err != nil {
+		return err
+	}
+	return s.Store.Set(req)
+}
+
+func (s *schemaValidatingStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		if err := s.validator.validate(&req[i]); err != nil {
+			return err
+		}
+	}
+	return s.Store.BulkSet(req)
+}
+
+type schemaValidatingTransactionalStore struct {
+	*schemaValidatingStore
+	transactional state.TransactionalStore
+}
+
+func (s *schemaValidatingTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	for _, r := range reqs {
+		if r.Operation != state.Upsert {
+			continue
+		}
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			if err := s.validator.validate(&req); err != nil {
+				return err
+			}
+		case *state.SetRequest:
+			if err := s.validator.validate(req); err != nil {
+				return err
+			}
+		}
+	}
+	return s.transactional.Multi(reqs)
+}
@@ -0,0 +1,100 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	state.Store
+	saved []state.SetRequest
+	multi []state.TransactionalRequest
+}
+
+func (f *fakeStore) BulkSet(req []state.SetRequest) error {
+	f.saved = append(f.saved, req...)
+	return nil
+}
+
+type fakeTransactionalStore struct {
+	fakeStore
+}
+
+func (f *fakeTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	f.multi = append(f.multi, reqs...)
+	return nil
+}
+
+const orderSchema = `{
+	"type": "object",
+	"properties": {"id": {"type": "string"}, "amount": {"type": "number"}},
+	"required": ["id", "amount"]
+}`
+
+func TestWithSchemaValidation(t *testing.T) {
+	t.Run("no schemas leaves the store unchanged", func(t *testing.T) {
+		store := &fakeStore{}
+		s, err := WithSchemaValidation(store, map[string]string{"redisHost": "localhost"})
+		assert.NoError(t, err)
+		assert.Equal(t, store, s)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := WithSchemaValidation(&fakeStore{}, map[string]string{"jsonSchema.order-": "{"})
+		assert.Error(t, err)
+	})
+
+	t.Run("validates values by key prefix", func(t *testing.T) {
+		store := &fakeStore{}
+		s, err := WithSchemaValidation(store, map[string]string{"jsonSchema.order-": orderSchema})
+		assert.NoError(t, err)
+
+		err = s.BulkSet([]state.SetRequest{
+			{Key: "app||order-1", Value: map[string]interface{}{"id": "1", "amount": 10}},
+			{Key: "app||order-2", Value: []byte(`{"id": "2", "amount": 20}`)},
+			{Key: "app||customer-1", Value: "anything"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, store.saved, 3)
+
+		err = s.BulkSet([]state.SetRequest{
+			{Key: "app||order-3", Value: []byte(`{"id": "3"}`)},
+		})
+		validationErr, ok := err.(*SchemaValidationError)
+		assert.True(t, ok)
+		assert.Equal(t, "order-3", validationErr.Key)
+		assert.NotEmpty(t, validationErr.Errors)
+		assert.Len(t, store.saved, 3)
+	})
+
+	t.Run("malformed json is rejected", func(t *testing.T) {
+		s, err := WithSchemaValidation(&fakeStore{}, map[string]string{"jsonSchema": orderSchema})
+		assert.NoError(t, err)
+
+		err = s.BulkSet([]state.SetRequest{{Key: "app||key", Value: []byte("not json")}})
+		assert.IsType(t, &SchemaValidationError{}, err)
+	})
+
+	t.Run("transactional stores validate upserts", func(t *testing.T) {
+		store := &fakeTransactionalStore{}
+		s, err := WithSchemaValidation(store, map[string]string{"jsonSchema.order-": orderSchema})
+		assert.NoError(t, err)
+
+		transactional, ok := s.(state.TransactionalStore)
+		assert.True(t, ok)
+
+		err = transactional.Multi([]state.TransactionalRequest{
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "app||order-1"}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "app||order-2", Value: []byte(`{"amount": 1}`)}},
+		})
+		assert.IsType(t, &SchemaValidationError{}, err)
+		assert.Empty(t, store.multi)
+	})
+}
@@ -0,0 +1,364 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+const (
+	// KeyVersionsMetadataKey is the component metadata key of the number of previous versions of the keys kept
+	KeyVersionsMetadataKey = "keyVersions"
+	// SoftDeleteMetadataKey is the component metadata key that keeps the versions of deleted keys so that they can be
+	// restored
+	SoftDeleteMetadataKey = "softDelete"
+	// VersionMetadataKey is the get request metadata key selecting the version of the key to read
+	VersionMetadataKey = "version"
+
+	versionKeyPart  = "version"
+	versionsKeyPart = "versions"
+)
+
+// ErrKeyNotDeleted is returned when restoring a key that isn't soft deleted
+var ErrKeyNotDeleted = errors.New("key is not deleted")
+
+// KeyVersions are the versions of a key kept by a state store
+type KeyVersions struct {
+	// Latest is the version of the latest value of the key, or of its value when it was deleted
+	Latest int `json:"latest"`
+	// Versions are the versions that can be read, oldest first
+	Versions []int `json:"versions"`
+	Deleted  bool  `json:"deleted"`
+}
+
+// VersionedStore is implemented by state stores that keep the versions of their keys
+type VersionedStore interface {
+	// Versions returns the versions of the key
+	Versions(key string) (KeyVersions, error)
+	// Restore restores the latest value of the soft deleted key
+	Restore(key string) error
+}
+
+// Versioning returns the versioning of the store, if it's enabled
+func Versioning(store state.Store) (VersionedStore, bool) {
+	for {
+		switch s := store.(type) {
+		case *mirroringStore:
+			store = s.Store
+		case *mirroringTransactionalStore:
+			store = s.Store
+		case *hedgingStore:
+			store = s.Store
+		case *hedgingTransactionalStore:
+			store = s.Store
+		case *schemaValidatingStore:
+			store = s.Store
+		case *schemaValidatingTransactionalStore:
+			store = s.Store
+		case *writeBehindStore:
+			store = s.Store
+		case *writeBehindTransactionalStore:
+			store = s.Store
+		default:
+			v, ok := store.(VersionedStore)
+			return v, ok
+		}
+	}
+}
+
+// WithVersioning returns the store keeping the previous versions of the keys, and soft deleting them, when its
+// component metadata enables it. The versions are emulated by the runtime as keys of the store next to the key, with a
+// record of the versions of the key: writes of the same key by several instances of the app at once can lose versions.
+func WithVersioning(store state.Store, properties map[string]string) (state.Store, error) {
+	previous := 0
+	if val, ok := properties[KeyVersionsMetadataKey]; ok && val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid %s: %s", KeyVersionsMetadataKey, val)
+		}
+		previous = n
+	}
+	softDelete := false
+	if val, ok := properties[SoftDeleteMetadataKey]; ok && val != "" {
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", SoftDeleteMetadataKey, val)
+		}
+		softDelete = b
+	}
+	if previous == 0 && !softDelete {
+		return store, nil
+	}
+
+	s := &versioningStore{Store: store, previous: previous, softDelete: softDelete}
+	if transactional, ok := store.(state.TransactionalStore); ok {
+		return &versioningTransactionalStore{versioningStore: s, transactional: transactional}, nil
+	}
+	return s, nil
+}
+
+// keyVersionsRecord is the record of the versions of a key
+type keyVersionsRecord struct {
+	Latest  int  `json:"latest"`
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+type versioningStore struct {
+	state.Store
+	// previous is the number of versions kept before the latest one
+	previous   int
+	softDelete bool
+	// lock serializes the updates of the version records by the sidecar
+	lock sync.Mutex
+}
+
+func versionKey(key string, version int) string {
+	return key + keySeparator + versionKeyPart + keySeparator + strconv.Itoa(version)
+}
+
+func versionsKey(key string) string {
+	return key + keySeparator + versionsKeyPart
+}
+
+func (s *versioningStore) record(key string) (keyVersionsRecord, error) {
+	var r keyVersionsRecord
+	resp, err := s.Store.Get(&state.GetRequest{Key: versionsKey(key)})
+	if err != nil {
+		return r, fmt.Errorf("failed to read the versions of key %s: %s", key, err)
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		return r, nil
+	}
+	if err := json.Unmarshal(resp.Data, &r); err != nil {
+		return r, fmt.Errorf("invalid versions of key %s: %s", key, err)
+	}
+	return r, nil
+}
+
+// oldest returns the oldest version of the key kept
+func (s *versioningStore) oldest(r keyVersionsRecord) int {
+	if oldest := r.Latest - s.previous; oldest > 1 {
+		return oldest
+	}
+	return 1
+}
+
+// setWrites returns the writes recording value as the next version of the key, after the write of the key itself
+func (s *versioningStore) setWrites(key string, value interface{}, r *keyVersionsRecord) []state.TransactionalRequest {
+	r.Latest++
+	r.Deleted = false
+	writes := []state.TransactionalRequest{
+		{Operation: state.Upsert, Request: state.SetRequest{Key: versionKey(key, r.Latest), Value: value}},
+		{Operation: state.Upsert, Request: state.SetRequest{Key: versionsKey(key), Value: *r}},
+	}
+	if dropped := r.Latest - s.previous - 1; dropped > 0 {
+		writes = append(writes, state.TransactionalRequest{Operation: state.Delete, Request: state.DeleteRequest{Key: versionKey(key, dropped)}})
+	}
+	return writes
+}
+
+// deleteWrites returns the writes marking the key as deleted, or deleting its versions without soft delete, after the
+// delete of the key itself
+func (s *versioningStore) deleteWrites(key string, r *keyVersionsRecord) []state.TransactionalRequest {
+	if s.softDelete {
+		if r.Latest == 0 {
+			return nil
+		}
+		r.Deleted = true
+		return []state.TransactionalRequest{{Operation: state.Upsert, Request: state.SetRequest{Key: versionsKey(key), Value: *r}}}
+	}
+
+	var writes []state.TransactionalRequest
+	for v := s.oldest(*r); v <= r.Latest; v++ {
+		writes = append(writes, state.TransactionalRequest{Operation: state.Delete, Request: state.DeleteRequest{Key: versionKey(key, v)}})
+	}
+	*r = keyVersionsRecord{}
+	return append(writes, state.TransactionalRequest{Operation: state.Delete, Request: state.DeleteRequest{Key: versionsKey(key)}})
+}
+
+// apply applies the writes of the versions one at a time
+func (s *versioningStore) apply(writes []state.TransactionalRequest) error {
+	for _, w := range writes {
+		var err error
+		switch req := w.Request.(type) {
+		case state.SetRequest:
+			err = s.Store.Set(&req)
+		case state.DeleteRequest:
+			err = s.Store.Delete(&req)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record the version: %s", err)
+		}
+	}
+	return nil
+}
+
+func (s *versioningStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	selector := req.Metadata[VersionMetadataKey]
+	if selector == "" {
+		return s.Store.Get(req)
+	}
+	version, err := strconv.Atoi(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %s", selector)
+	}
+
+	r, err := s.record(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if version < s.oldest(r) || version > r.Latest {
+		return &state.GetResponse{}, nil
+	}
+	resp, err := s.Store.Get(&state.GetRequest{Key: versionKey(req.Key, version), Options: req.Options})
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	resp.Metadata = map[string]string{VersionMetadataKey: strconv.Itoa(version)}
+	return resp, nil
+}
+
+func (s *versioningStore) Set(req *state.SetRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r, err := s.record(req.Key)
+	if err != nil {
+		return err
+	}
+	if err := s.Store.Set(req); err != nil {
+		return err
+	}
+	return s.apply(s.setWrites(req.Key, req.Value, &r))
+}
+
+func (s *versioningStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		if err := s.Set(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *versioningStore) Delete(req *state.DeleteRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r, err := s.record(req.Key)
+	if err != nil {
+		return err
+	}
+	if err := s.Store.Delete(req); err != nil {
+		return err
+	}
+	return s.apply(s.deleteWrites(req.Key, &r))
+}
+
+func (s *versioningStore) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		if err := s.Delete(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *versioningStore) Versions(key string) (KeyVersions, error) {
+	r, err := s.record(key)
+	if err != nil {
+		return KeyVersions{}, err
+	}
+	v := KeyVersions{Latest: r.Latest, Versions: []int{}, Deleted: r.Deleted}
+	for i := s.oldest(r); i <= r.Latest; i++ {
+		v.Versions = append(v.Versions, i)
+	}
+	return v, nil
+}
+
+func (s *versioningStore) Restore(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r, err := s.record(key)
+	if err != nil {
+		return err
+	}
+	if !r.Deleted {
+		return ErrKeyNotDeleted
+	}
+	resp, err := s.Store.Get(&state.GetRequest{Key: versionKey(key, r.Latest)})
+	if err != nil {
+		return err
+	}
+	if err := s.Store.Set(&state.SetRequest{Key: key, Value: responseData(resp)}); err != nil {
+		return err
+	}
+	r.Deleted = false
+	return s.Store.Set(&state.SetRequest{Key: versionsKey(key), Value: r})
+}
+
+type versioningTransactionalStore struct {
+	*versioningStore
+	transactional state.TransactionalStore
+}
+
+// Multi records the versions of the keys in the transaction
+func (s *versioningTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	records := map[string]*keyVersionsRecord{}
+	record := func(key string) (*keyVersionsRecord, error) {
+		if r, ok := records[key]; ok {
+			return r, nil
+		}
+		r, err := s.record(key)
+		if err != nil {
+			return nil, err
+		}
+		records[key] = &r
+		return &r, nil
+	}
+
+	versioned := make([]state.TransactionalRequest, 0, len(reqs)*3)
+	for _, req := range reqs {
+		versioned = append(versioned, req)
+		switch o := req.Request.(type) {
+		case state.SetRequest:
+			r, err := record(o.Key)
+			if err != nil {
+				return err
+			}
+			versioned = append(versioned, s.setWrites(o.Key, o.Value, r)...)
+		case *state.SetRequest:
+			r, err := record(o.Key)
+			if err != nil {
+				return err
+			}
+			versioned = append(versioned, s.setWrites(o.Key, o.Value, r)...)
+		case state.DeleteRequest:
+			r, err := record(o.Key)
+			if err != nil {
+				return err
+			}
+			versioned = append(versioned, s.deleteWrites(o.Key, r)...)
+		case *state.DeleteRequest:
+			r, err := record(o.Key)
+			if err != nil {
+				return err
+			}
+			versioned = append(versioned, s.deleteWrites(o.Key, r)...)
+		}
+	}
+	return s.transactional.Multi(versioned)
+}
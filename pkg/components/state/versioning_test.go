@@ -0,0 +1,155 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonStore keeps values in memory, serializing the values that aren't bytes to JSON like the state stores
+type jsonStore struct {
+	state.Store
+	values map[string][]byte
+	multis int
+}
+
+func newJSONStore() *jsonStore {
+	return &jsonStore{values: map[string][]byte{}}
+}
+
+func (j *jsonStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return &state.GetResponse{Data: j.values[req.Key]}, nil
+}
+
+func (j *jsonStore) Set(req *state.SetRequest) error {
+	data, ok := req.Value.([]byte)
+	if !ok {
+		data, _ = json.Marshal(req.Value)
+	}
+	j.values[req.Key] = data
+	return nil
+}
+
+func (j *jsonStore) Delete(req *state.DeleteRequest) error {
+	delete(j.values, req.Key)
+	return nil
+}
+
+func (j *jsonStore) Multi(reqs []state.TransactionalRequest) error {
+	j.multis++
+	for _, r := range reqs {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			j.Set(&req)
+		case state.DeleteRequest:
+			j.Delete(&req)
+		}
+	}
+	return nil
+}
+
+func TestVersioning(t *testing.T) {
+	get := func(t *testing.T, store state.Store, key, version string) string {
+		req := &state.GetRequest{Key: key}
+		if version != "" {
+			req.Metadata = map[string]string{VersionMetadataKey: version}
+		}
+		resp, err := store.Get(req)
+		require.NoError(t, err)
+		return string(resp.Data)
+	}
+
+	t.Run("disabled without versions or soft delete", func(t *testing.T) {
+		store := newJSONStore()
+		versioned, err := WithVersioning(store, map[string]string{KeyVersionsMetadataKey: "0"})
+		assert.NoError(t, err)
+		assert.Equal(t, store, versioned)
+
+		_, err = WithVersioning(store, map[string]string{KeyVersionsMetadataKey: "-1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("previous versions are kept", func(t *testing.T) {
+		store := newJSONStore()
+		versioned, err := WithVersioning(store, map[string]string{KeyVersionsMetadataKey: "2"})
+		require.NoError(t, err)
+
+		for _, v := range []string{"v1", "v2", "v3", "v4"} {
+			require.NoError(t, versioned.Set(&state.SetRequest{Key: "app||order", Value: v}))
+		}
+
+		assert.Equal(t, `"v4"`, get(t, versioned, "app||order", ""))
+		assert.Equal(t, `"v3"`, get(t, versioned, "app||order", "3"))
+		assert.Equal(t, `"v2"`, get(t, versioned, "app||order", "2"))
+		assert.Empty(t, get(t, versioned, "app||order", "1"))
+		assert.Empty(t, get(t, versioned, "app||order", "5"))
+		assert.NotContains(t, store.values, "app||order||version||1")
+
+		v, ok := Versioning(versioned)
+		require.True(t, ok)
+		versions, err := v.Versions("app||order")
+		require.NoError(t, err)
+		assert.Equal(t, KeyVersions{Latest: 4, Versions: []int{2, 3, 4}}, versions)
+
+		_, err = versioned.Get(&state.GetRequest{Key: "app||order", Metadata: map[string]string{VersionMetadataKey: "latest"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("soft deleted keys are restored", func(t *testing.T) {
+		versioned, err := WithVersioning(newJSONStore(), map[string]string{SoftDeleteMetadataKey: "true"})
+		require.NoError(t, err)
+		v, _ := Versioning(versioned)
+
+		require.NoError(t, versioned.Set(&state.SetRequest{Key: "app||order", Value: map[string]interface{}{"id": 1}}))
+		assert.Equal(t, ErrKeyNotDeleted, v.Restore("app||order"))
+		require.NoError(t, versioned.Delete(&state.DeleteRequest{Key: "app||order"}))
+
+		assert.Empty(t, get(t, versioned, "app||order", ""))
+		assert.Equal(t, `{"id":1}`, get(t, versioned, "app||order", "1"))
+		versions, err := v.Versions("app||order")
+		require.NoError(t, err)
+		assert.True(t, versions.Deleted)
+
+		require.NoError(t, v.Restore("app||order"))
+		assert.Equal(t, `{"id":1}`, get(t, versioned, "app||order", ""))
+		versions, err = v.Versions("app||order")
+		require.NoError(t, err)
+		assert.False(t, versions.Deleted)
+	})
+
+	t.Run("deletes without soft delete drop the versions", func(t *testing.T) {
+		store := newJSONStore()
+		versioned, err := WithVersioning(store, map[string]string{KeyVersionsMetadataKey: "3"})
+		require.NoError(t, err)
+
+		require.NoError(t, versioned.Set(&state.SetRequest{Key: "app||order", Value: "v1"}))
+		require.NoError(t, versioned.Set(&state.SetRequest{Key: "app||order", Value: "v2"}))
+		require.NoError(t, versioned.Delete(&state.DeleteRequest{Key: "app||order"}))
+
+		assert.Empty(t, store.values)
+	})
+
+	t.Run("transactions record the versions atomically", func(t *testing.T) {
+		store := newJSONStore()
+		versioned, err := WithVersioning(store, map[string]string{KeyVersionsMetadataKey: "1"})
+		require.NoError(t, err)
+
+		require.NoError(t, versioned.(state.TransactionalStore).Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "app||order", Value: "v1"}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "app||order", Value: "v2"}},
+		}))
+
+		assert.Equal(t, 1, store.multis)
+		assert.Equal(t, `"v2"`, get(t, versioned, "app||order", ""))
+		assert.Equal(t, `"v1"`, get(t, versioned, "app||order", "1"))
+		assert.Equal(t, `"v2"`, get(t, versioned, "app||order", "2"))
+	})
+}
@@ -0,0 +1,110 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+const (
+	// WatchPollIntervalMetadataKey is the component metadata key of the interval between polls of watched keys,
+	// for state stores that don't notify changes
+	WatchPollIntervalMetadataKey = "watchPollInterval"
+
+	defaultWatchPollInterval = time.Second
+)
+
+// ErrWatchPrefixNotSupported is returned when watching key prefixes of a state store that doesn't notify changes
+var ErrWatchPrefixNotSupported = errors.New("watching key prefixes requires a state store that notifies changes")
+
+// StateChange is a change of the value of a key
+type StateChange struct {
+	Key     string
+	Value   []byte
+	ETag    string
+	Deleted bool
+}
+
+// Watcher delivers the changes of the keys of a state store
+type Watcher interface {
+	// Watch calls handler with the changes of the keys, and of the keys starting with the prefixes, until ctx is done
+	Watch(ctx context.Context, keys, prefixes []string, handler func(StateChange)) error
+}
+
+// NewWatcher returns the store if it notifies changes, e.g. with Redis keyspace events or etcd watches.
+// Otherwise it returns a watcher that polls the keys at the interval in the component metadata.
+func NewWatcher(store state.Store, properties map[string]string) (Watcher, error) {
+	if w, ok := store.(Watcher); ok {
+		return w, nil
+	}
+
+	interval := defaultWatchPollInterval
+	if val := properties[WatchPollIntervalMetadataKey]; val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", WatchPollIntervalMetadataKey, val)
+		}
+		interval = d
+	}
+	return &poller{store: store, interval: interval}, nil
+}
+
+// poller watches keys by polling their values. It can't list keys, so it can't watch key prefixes.
+type poller struct {
+	store    state.Store
+	interval time.Duration
+}
+
+func (p *poller) Watch(ctx context.Context, keys, prefixes []string, handler func(StateChange)) error {
+	if len(prefixes) > 0 {
+		return ErrWatchPrefixNotSupported
+	}
+
+	last := map[string]StateChange{}
+	poll := func() {
+		for _, key := range keys {
+			resp, err := p.store.Get(&state.GetRequest{Key: key})
+			if err != nil {
+				log.Debugf("failed to poll watched key %s: %s", key, err)
+				continue
+			}
+
+			current := StateChange{Key: key, Value: resp.Data, ETag: resp.ETag, Deleted: len(resp.Data) == 0}
+			previous, polled := last[key]
+			last[key] = current
+			// the first poll only records the current values
+			if polled && changed(previous, current) {
+				handler(current)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	poll()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
+
+func changed(previous, current StateChange) bool {
+	if previous.Deleted || current.Deleted {
+		return previous.Deleted != current.Deleted
+	}
+	return previous.ETag != current.ETag || !bytes.Equal(previous.Value, current.Value)
+}
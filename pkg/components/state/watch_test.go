@@ -0,0 +1,78 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type pollStore struct {
+	fakeStore
+	lock   sync.Mutex
+	values map[string]string
+}
+
+func (p *pollStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return &state.GetResponse{Data: []byte(p.values[req.Key])}, nil
+}
+
+func (p *pollStore) set(key, value string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.values[key] = value
+}
+
+func TestNewWatcher(t *testing.T) {
+	t.Run("invalid interval", func(t *testing.T) {
+		_, err := NewWatcher(&fakeStore{}, map[string]string{WatchPollIntervalMetadataKey: "soon"})
+		assert.Error(t, err)
+	})
+
+	t.Run("poller doesn't support prefixes", func(t *testing.T) {
+		w, err := NewWatcher(&fakeStore{}, map[string]string{})
+		assert.NoError(t, err)
+		err = w.Watch(context.Background(), nil, []string{"order-"}, nil)
+		assert.Equal(t, ErrWatchPrefixNotSupported, err)
+	})
+
+	t.Run("poller delivers changes", func(t *testing.T) {
+		store := &pollStore{values: map[string]string{"key1": "a"}}
+		w, err := NewWatcher(store, map[string]string{WatchPollIntervalMetadataKey: "5ms"})
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := make(chan StateChange, 10)
+		done := make(chan struct{})
+		go func() {
+			w.Watch(ctx, []string{"key1", "key2"}, nil, func(c StateChange) {
+				changes <- c
+			})
+			close(done)
+		}()
+
+		time.Sleep(time.Millisecond * 20)
+		store.set("key1", "b")
+		change := <-changes
+		assert.Equal(t, StateChange{Key: "key1", Value: []byte("b")}, change)
+
+		store.set("key1", "")
+		change = <-changes
+		assert.Equal(t, "key1", change.Key)
+		assert.True(t, change.Deleted)
+
+		cancel()
+		<-done
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,697 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/wal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// WriteBehindMetadataKey is the component metadata key that enables queueing of failed writes
+	WriteBehindMetadataKey = "writeBehind"
+	// WriteBehindRetryIntervalMetadataKey is the component metadata key of the interval between retries of queued writes
+	WriteBehindRetryIntervalMetadataKey = "writeBehindRetryInterval"
+	// WriteBehindMaxAttemptsMetadataKey is the component metadata key of the number of retries of a queued write before
+	// it's dead-lettered
+	WriteBehindMaxAttemptsMetadataKey = "writeBehindMaxAttempts"
+
+	defaultWriteBehindRetryInterval = time.Second * 5
+	defaultWriteBehindMaxAttempts   = 100
+	// writeBehindDeadLetterDir is the dir of the log of the dead-lettered writes, in the dir of the write-ahead log
+	writeBehindDeadLetterDir = "deadletter"
+	// writeBehindLockStripes is the number of locks ordering the writes of the keys with the queued writes
+	writeBehindLockStripes = 64
+)
+
+// ErrWriteBehindClosed is returned by the writes that must be queued once the write-behind queue is closed
+var ErrWriteBehindClosed = errors.New("write-behind queue is closed")
+
+// ErrWriteBehindPending is returned by the writes with an ETag while the queued writes can't be applied before them
+var ErrWriteBehindPending = errors.New("state writes are queued for retry")
+
+var errNotTransactional = errors.New("the queued transaction can't be applied: the state store is not transactional")
+
+// writeBehindOwners are closed once the retries of the last store opened on each write-ahead log dir, and of the stores
+// it replaced, stopped, so that the retries of a store replacing another start once those of the replaced store stopped
+var (
+	writeBehindOwnersLock sync.Mutex
+	writeBehindOwners     = map[string]chan struct{}{}
+)
+
+var log = logger.NewLogger("dapr.runtime.state")
+
+// WriteBehindStatus is the status of the queued writes of a state store
+type WriteBehindStatus struct {
+	Pending      int        `json:"pending"`
+	DeadLettered int        `json:"deadLettered,omitempty"`
+	OldestWrite  *time.Time `json:"oldestWrite,omitempty"`
+	LastRetry    *time.Time `json:"lastRetry,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+}
+
+// WriteBehindStore is implemented by state stores that queue failed writes
+type WriteBehindStore interface {
+	WriteBehindStatus() (WriteBehindStatus, error)
+	// Close stops the retries of the queued writes, which stay in the write-ahead log for the next store opening it
+	Close() error
+}
+
+// WriteBehind returns the write-behind queue of the store, if it's enabled
+func WriteBehind(store state.Store) (WriteBehindStore, bool) {
+	for {
+		switch s := store.(type) {
+		case *mirroringStore:
+			store = s.Store
+		case *mirroringTransactionalStore:
+			store = s.Store
+		case *hedgingStore:
+			store = s.Store
+		case *hedgingTransactionalStore:
+			store = s.Store
+		case *schemaValidatingStore:
+			store = s.Store
+		case *schemaValidatingTransactionalStore:
+			store = s.Store
+		default:
+			w, ok := store.(WriteBehindStore)
+			return w, ok
+		}
+	}
+}
+
+// Unwrap returns the state store wrapped by the mirroring, hedged read, schema validation, write-behind and versioning
+// wrappers of the store, if any
+func Unwrap(store state.Store) state.Store {
+	for {
+		switch s := store.(type) {
+		case *mirroringStore:
+			store = s.Store
+		case *mirroringTransactionalStore:
+			store = s.Store
+		case *hedgingStore:
+			store = s.Store
+		case *hedgingTransactionalStore:
+			store = s.Store
+		case *schemaValidatingStore:
+			store = s.Store
+		case *schemaValidatingTransactionalStore:
+			store = s.Store
+		case *writeBehindStore:
+			store = s.Store
+		case *writeBehindTransactionalStore:
+			store = s.Store
+		case *versioningStore:
+			store = s.Store
+		case *versioningTransactionalStore:
+			store = s.Store
+		default:
+			return store
+		}
+	}
+}
+
+// Close stops the retries of the write-behind queue of the store, if any, and closes the store wrapped by the wrappers
+// of the store when it holds resources
+func Close(store state.Store) error {
+	if w, ok := WriteBehind(store); ok {
+		w.Close()
+	}
+	if closer, ok := Unwrap(store).(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// WithWriteBehind returns the store with queueing of failed writes when its component metadata enables it.
+// The writes failing with a transient error are appended to a write-ahead log in dir and retried in the background, in
+// order, until they succeed. The queued writes failing with a permanent error, or failing their max attempts, are moved
+// to a dead-letter log so that the writes behind them are applied.
+// While writes are queued, later writes are queued behind them so that they are applied in order.
+// Writes with an ETag need a synchronous answer and are never queued: the queued writes are applied before them, and
+// they fail with ErrWriteBehindPending if the queued writes can't be applied.
+func WithWriteBehind(store state.Store, properties map[string]string, dir string) (state.Store, error) {
+	if enabled, _ := strconv.ParseBool(properties[WriteBehindMetadataKey]); !enabled {
+		return store, nil
+	}
+
+	interval := defaultWriteBehindRetryInterval
+	if val, ok := properties[WriteBehindRetryIntervalMetadataKey]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", WriteBehindRetryIntervalMetadataKey, val)
+		}
+		interval = d
+	}
+	maxAttempts := defaultWriteBehindMaxAttempts
+	if val, ok := properties[WriteBehindMaxAttemptsMetadataKey]; ok && val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", WriteBehindMaxAttemptsMetadataKey, val)
+		}
+		maxAttempts = n
+	}
+
+	l, err := wal.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters, err := wal.Open(filepath.Join(dir, writeBehindDeadLetterDir))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &writeBehindStore{
+		Store:       store,
+		log:         l,
+		deadLetters: deadLetters,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		attempts:    map[string]int{},
+		pending:     l.Len(),
+		stop:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	if transactional, ok := store.(state.TransactionalStore); ok {
+		s.transactional = transactional
+	}
+
+	released := make(chan struct{})
+	writeBehindOwnersLock.Lock()
+	replaced := writeBehindOwners[dir]
+	writeBehindOwners[dir] = released
+	writeBehindOwnersLock.Unlock()
+	go s.retryLoop(replaced)
+	go func() {
+		<-s.stopped
+		if replaced != nil {
+			<-replaced
+		}
+		close(released)
+	}()
+
+	if s.transactional != nil {
+		return &writeBehindTransactionalStore{writeBehindStore: s}, nil
+	}
+	return s, nil
+}
+
+// queuedSet is a set request that keeps the type of its value in the log
+type queuedSet struct {
+	Key      string               `json:"key"`
+	Bytes    []byte               `json:"bytes,omitempty"`
+	String   *string              `json:"string,omitempty"`
+	JSON     json.RawMessage      `json:"json,omitempty"`
+	Metadata map[string]string    `json:"metadata,omitempty"`
+	Options  state.SetStateOption `json:"options,omitempty"`
+}
+
+type queuedOperation struct {
+	Operation state.OperationType  `json:"operation"`
+	Set       *queuedSet           `json:"set,omitempty"`
+	Delete    *state.DeleteRequest `json:"delete,omitempty"`
+}
+
+// queuedWrite is an entry of the write-ahead log
+type queuedWrite struct {
+	Sets    []queuedSet           `json:"sets,omitempty"`
+	Deletes []state.DeleteRequest `json:"deletes,omitempty"`
+	Multi   []queuedOperation     `json:"multi,omitempty"`
+}
+
+func newQueuedSet(req *state.SetRequest) (queuedSet, error) {
+	q := queuedSet{Key: req.Key, Metadata: req.Metadata, Options: req.Options}
+	switch v := req.Value.(type) {
+	case []byte:
+		q.Bytes = v
+	case string:
+		q.String = &v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return q, err
+		}
+		q.JSON = b
+	}
+	return q, nil
+}
+
+func (q *queuedSet) request() state.SetRequest {
+	req := state.SetRequest{Key: q.Key, Metadata: q.Metadata, Options: q.Options}
+	switch {
+	case q.String != nil:
+		req.Value = *q.String
+	case q.JSON != nil:
+		req.Value = q.JSON
+	default:
+		req.Value = q.Bytes
+	}
+	return req
+}
+
+type writeBehindStore struct {
+	state.Store
+	transactional state.TransactionalStore
+	log           *wal.Log
+	deadLetters   *wal.Log
+	interval      time.Duration
+	maxAttempts   int
+
+	// keyLocks are held from checking the queued writes to queueing the write, so that the writes of a key are
+	// applied in the order they are made
+	keyLocks  [writeBehindLockStripes]sync.Mutex
+	lock      sync.Mutex
+	retryLock sync.Mutex
+	pending   int
+	attempts  map[string]int
+	closed    bool
+	lastRetry time.Time
+	lastError string
+	stop      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// lockKeys locks the stripes of the keys in order and returns the func unlocking them
+func (s *writeBehindStore) lockKeys(keys []string) func() {
+	stripes := map[uint32]bool{}
+	for _, k := range keys {
+		h := fnv.New32a()
+		h.Write([]byte(k))
+		stripes[h.Sum32()%writeBehindLockStripes] = true
+	}
+	ordered := make([]int, 0, len(stripes))
+	for i := range stripes {
+		ordered = append(ordered, int(i))
+	}
+	sort.Ints(ordered)
+
+	for _, i := range ordered {
+		s.keyLocks[i].Lock()
+	}
+	return func() {
+		for _, i := range ordered {
+			s.keyLocks[i].Unlock()
+		}
+	}
+}
+
+// isPermanentWriteError returns whether the write fails the same way when retried, e.g. because it's invalid
+func isPermanentWriteError(err error) bool {
+	if _, ok := err.(*SchemaValidationError); ok {
+		return true
+	}
+	if err == ErrETagMismatch || err == errNotTransactional {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.AlreadyExists, codes.PermissionDenied, codes.Unimplemented, codes.OutOfRange:
+		return true
+	}
+	return false
+}
+
+// write calls fn unless writes are queued, and queues the write if fn fails with a transient error
+func (s *writeBehindStore) write(keys []string, queued func() (*queuedWrite, error), fn func() error) error {
+	unlock := s.lockKeys(keys)
+	defer unlock()
+
+	s.lock.Lock()
+	pending, closed := s.pending, s.closed
+	s.lock.Unlock()
+
+	if pending == 0 {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if closed || isPermanentWriteError(err) {
+			return err
+		}
+		log.Warnf("state write failed, queueing it for retry: %s", err)
+	} else if closed {
+		return ErrWriteBehindClosed
+	}
+
+	w, err := queued()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	if _, err := s.log.Append(b); err != nil {
+		return fmt.Errorf("failed to queue state write: %s", err)
+	}
+
+	s.lock.Lock()
+	s.pending++
+	s.lock.Unlock()
+	return nil
+}
+
+// writeNow calls fn once the queued writes are applied, so that a write with an ETag isn't overwritten by the retry
+// of an earlier write of its keys
+func (s *writeBehindStore) writeNow(keys []string, fn func() error) error {
+	unlock := s.lockKeys(keys)
+	defer unlock()
+
+	s.lock.Lock()
+	pending, closed := s.pending, s.closed
+	s.lock.Unlock()
+
+	if pending > 0 {
+		if closed {
+			return ErrWriteBehindClosed
+		}
+		s.retry()
+		s.lock.Lock()
+		pending = s.pending
+		s.lock.Unlock()
+		if pending > 0 {
+			return ErrWriteBehindPending
+		}
+	}
+	return fn()
+}
+
+func (s *writeBehindStore) Set(req *state.SetRequest) error {
+	if req.ETag != "" {
+		return s.writeNow([]string{req.Key}, func() error {
+			return s.Store.Set(req)
+		})
+	}
+	return s.write([]string{req.Key}, func() (*queuedWrite, error) {
+		q, err := newQueuedSet(req)
+		return &queuedWrite{Sets: []queuedSet{q}}, err
+	}, func() error {
+		return s.Store.Set(req)
+	})
+}
+
+func (s *writeBehindStore) BulkSet(req []state.SetRequest) error {
+	keys := make([]string, len(req))
+	etag := false
+	for i := range req {
+		keys[i] = req[i].Key
+		etag = etag || req[i].ETag != ""
+	}
+	if etag {
+		return s.writeNow(keys, func() error {
+			return s.Store.BulkSet(req)
+		})
+	}
+	return s.write(keys, func() (*queuedWrite, error) {
+		w := &queuedWrite{}
+		for i := range req {
+			q, err := newQueuedSet(&req[i])
+			if err != nil {
+				return nil, err
+			}
+			w.Sets = append(w.Sets, q)
+		}
+		return w, nil
+	}, func() error {
+		return s.Store.BulkSet(req)
+	})
+}
+
+func (s *writeBehindStore) Delete(req *state.DeleteRequest) error {
+	if req.ETag != "" {
+		return s.writeNow([]string{req.Key}, func() error {
+			return s.Store.Delete(req)
+		})
+	}
+	return s.write([]string{req.Key}, func() (*queuedWrite, error) {
+		return &queuedWrite{Deletes: []state.DeleteRequest{*req}}, nil
+	}, func() error {
+		return s.Store.Delete(req)
+	})
+}
+
+func (s *writeBehindStore) BulkDelete(req []state.DeleteRequest) error {
+	keys := make([]string, len(req))
+	etag := false
+	for i := range req {
+		keys[i] = req[i].Key
+		etag = etag || req[i].ETag != ""
+	}
+	if etag {
+		return s.writeNow(keys, func() error {
+			return s.Store.BulkDelete(req)
+		})
+	}
+	return s.write(keys, func() (*queuedWrite, error) {
+		return &queuedWrite{Deletes: req}, nil
+	}, func() error {
+		return s.Store.BulkDelete(req)
+	})
+}
+
+// WriteBehindStatus returns the status of the queued writes
+func (s *writeBehindStore) WriteBehindStatus() (WriteBehindStatus, error) {
+	entries, err := s.log.Entries()
+	if err != nil {
+		return WriteBehindStatus{}, err
+	}
+	deadLettered := s.deadLetters.Len()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	status := WriteBehindStatus{Pending: len(entries), DeadLettered: deadLettered, LastError: s.lastError}
+	if len(entries) > 0 {
+		status.OldestWrite = &entries[0].Created
+	}
+	if !s.lastRetry.IsZero() {
+		lastRetry := s.lastRetry
+		status.LastRetry = &lastRetry
+	}
+	return status, nil
+}
+
+// Close stops the retries of the queued writes and waits for the current retry. The writes failing afterwards, or
+// made while writes are queued, aren't queued anymore and fail.
+func (s *writeBehindStore) Close() error {
+	s.closeOnce.Do(func() {
+		s.lock.Lock()
+		s.closed = true
+		s.lock.Unlock()
+		close(s.stop)
+		<-s.stopped
+	})
+	return nil
+}
+
+// retryLoop retries the queued writes every interval, once the retries of the replaced store on the same log stopped
+func (s *writeBehindStore) retryLoop(replaced chan struct{}) {
+	defer close(s.stopped)
+	if replaced != nil {
+		select {
+		case <-s.stop:
+			return
+		case <-replaced:
+		}
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+
+		s.lock.Lock()
+		pending := s.pending
+		s.lock.Unlock()
+
+		if pending > 0 {
+			s.retry()
+		}
+	}
+}
+
+// retry applies the queued writes in order until one fails with a transient error. The writes failing with a
+// permanent error, or failing their max attempts, are dead-lettered.
+func (s *writeBehindStore) retry() {
+	s.retryLock.Lock()
+	defer s.retryLock.Unlock()
+
+	entries, err := s.log.Entries()
+	if err != nil {
+		log.Errorf("failed to read queued state writes: %s", err)
+		return
+	}
+	if len(entries) == 0 {
+		// the writes counted when the log was opened may have been applied by the store this store replaced
+		s.lock.Lock()
+		if s.log.Len() == 0 {
+			s.pending = 0
+		}
+		s.lock.Unlock()
+		return
+	}
+
+	for _, e := range entries {
+		err := s.apply(e.Data)
+
+		s.lock.Lock()
+		s.lastRetry = time.Now().UTC()
+		if err != nil {
+			s.lastError = err.Error()
+			s.attempts[e.ID]++
+			attempts := s.attempts[e.ID]
+			s.lock.Unlock()
+			if !isPermanentWriteError(err) && attempts < s.maxAttempts {
+				log.Debugf("retry of queued state write %s failed: %s", e.ID, err)
+				return
+			}
+			log.Errorf("dead-lettering queued state write %s after %d attempts: %s", e.ID, attempts, err)
+			if _, err := s.deadLetters.Append(e.Data); err != nil {
+				log.Errorf("failed to dead-letter queued state write %s: %s", e.ID, err)
+				return
+			}
+		} else {
+			s.lastError = ""
+			s.lock.Unlock()
+		}
+
+		if err := s.log.Remove(e.ID); err != nil {
+			log.Errorf("failed to remove queued state write %s: %s", e.ID, err)
+			return
+		}
+		s.lock.Lock()
+		delete(s.attempts, e.ID)
+		if s.pending > 0 {
+			s.pending--
+		}
+		s.lock.Unlock()
+	}
+}
+
+func (s *writeBehindStore) apply(data []byte) error {
+	var w queuedWrite
+	if err := json.Unmarshal(data, &w); err != nil {
+		// a corrupted entry can never be applied
+		log.Errorf("dropping unreadable queued state write: %s", err)
+		return nil
+	}
+
+	if len(w.Sets) > 0 {
+		reqs := make([]state.SetRequest, len(w.Sets))
+		for i := range w.Sets {
+			reqs[i] = w.Sets[i].request()
+		}
+		if err := s.Store.BulkSet(reqs); err != nil {
+			return err
+		}
+	}
+	if len(w.Deletes) > 0 {
+		if err := s.Store.BulkDelete(w.Deletes); err != nil {
+			return err
+		}
+	}
+	if len(w.Multi) > 0 {
+		if s.transactional == nil {
+			return errNotTransactional
+		}
+		reqs := make([]state.TransactionalRequest, len(w.Multi))
+		for i, o := range w.Multi {
+			reqs[i].Operation = o.Operation
+			if o.Set != nil {
+				reqs[i].Request = o.Set.request()
+			} else if o.Delete != nil {
+				reqs[i].Request = *o.Delete
+			}
+		}
+		return s.transactional.Multi(reqs)
+	}
+	return nil
+}
+
+type writeBehindTransactionalStore struct {
+	*writeBehindStore
+}
+
+func (s *writeBehindTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	keys := make([]string, 0, len(reqs))
+	etag := false
+	for _, r := range reqs {
+		var set *state.SetRequest
+		var del *state.DeleteRequest
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			set = &req
+		case *state.SetRequest:
+			set = req
+		case state.DeleteRequest:
+			del = &req
+		case *state.DeleteRequest:
+			del = req
+		default:
+			// requests of unknown types can't be queued
+			return s.transactional.Multi(reqs)
+		}
+		if set != nil {
+			keys = append(keys, set.Key)
+			etag = etag || set.ETag != ""
+		} else {
+			keys = append(keys, del.Key)
+			etag = etag || del.ETag != ""
+		}
+	}
+	if etag {
+		return s.writeNow(keys, func() error {
+			return s.transactional.Multi(reqs)
+		})
+	}
+
+	return s.write(keys, func() (*queuedWrite, error) {
+		w := &queuedWrite{}
+		for _, r := range reqs {
+			o := queuedOperation{Operation: r.Operation}
+			switch req := r.Request.(type) {
+			case state.SetRequest:
+				q, err := newQueuedSet(&req)
+				if err != nil {
+					return nil, err
+				}
+				o.Set = &q
+			case *state.SetRequest:
+				q, err := newQueuedSet(req)
+				if err != nil {
+					return nil, err
+				}
+				o.Set = &q
+			case state.DeleteRequest:
+				o.Delete = &req
+			case *state.DeleteRequest:
+				o.Delete = req
+			}
+			w.Multi = append(w.Multi, o)
+		}
+		return w, nil
+	}, func() error {
+		return s.transactional.Multi(reqs)
+	})
+}
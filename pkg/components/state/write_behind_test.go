@@ -0,0 +1,387 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type flakyStore struct {
+	fakeStore
+	down    bool
+	deleted []state.DeleteRequest
+	// err fails the writes of failKey, or of all the keys when it's empty
+	err     error
+	failKey string
+}
+
+func (f *flakyStore) BulkSet(req []state.SetRequest) error {
+	if f.down {
+		return errors.New("store is unreachable")
+	}
+	for i := range req {
+		if f.err != nil && (f.failKey == "" || req[i].Key == f.failKey) {
+			return f.err
+		}
+	}
+	return f.fakeStore.BulkSet(req)
+}
+
+func (f *flakyStore) Set(req *state.SetRequest) error {
+	return f.BulkSet([]state.SetRequest{*req})
+}
+
+func (f *flakyStore) Delete(req *state.DeleteRequest) error {
+	if f.down {
+		return errors.New("store is unreachable")
+	}
+	f.deleted = append(f.deleted, *req)
+	return nil
+}
+
+func (f *flakyStore) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		if err := f.Delete(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockingStore fails the first write once its gate is closed, and saves the others
+type blockingStore struct {
+	fakeStore
+	lock    sync.Mutex
+	calls   int
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func (b *blockingStore) BulkSet(req []state.SetRequest) error {
+	b.lock.Lock()
+	b.calls++
+	first := b.calls == 1
+	b.lock.Unlock()
+	if first {
+		close(b.started)
+		<-b.gate
+		return errors.New("store is unreachable")
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.fakeStore.BulkSet(req)
+}
+
+func (b *blockingStore) Set(req *state.SetRequest) error {
+	return b.BulkSet([]state.SetRequest{*req})
+}
+
+func TestWithWriteBehind(t *testing.T) {
+	dir, err := ioutil.TempDir("", "writebehind")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("disabled leaves the store unchanged", func(t *testing.T) {
+		store := &fakeStore{}
+		s, err := WithWriteBehind(store, map[string]string{}, dir)
+		assert.NoError(t, err)
+		assert.Equal(t, store, s)
+
+		_, ok := WriteBehind(s)
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid retry interval", func(t *testing.T) {
+		_, err := WithWriteBehind(&fakeStore{}, map[string]string{
+			WriteBehindMetadataKey:              "true",
+			WriteBehindRetryIntervalMetadataKey: "soon",
+		}, dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed writes are queued and retried in order", func(t *testing.T) {
+		store := &flakyStore{down: true}
+		s, err := WithWriteBehind(store, map[string]string{
+			WriteBehindMetadataKey:              "true",
+			WriteBehindRetryIntervalMetadataKey: "1h",
+		}, dir)
+		assert.NoError(t, err)
+		w := s.(*writeBehindStore)
+
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||a", Value: []byte("1")}))
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||b", Value: map[string]interface{}{"v": 2}}))
+		assert.NoError(t, s.Delete(&state.DeleteRequest{Key: "app||a"}))
+
+		// writes with an ETag are never queued, and fail while the queued writes can't be applied
+		assert.Equal(t, ErrWriteBehindPending, s.Set(&state.SetRequest{Key: "app||c", Value: "3", ETag: "1"}))
+
+		status, err := w.WriteBehindStatus()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, status.Pending)
+		assert.NotNil(t, status.OldestWrite)
+
+		w.retry()
+		status, _ = w.WriteBehindStatus()
+		assert.Equal(t, 3, status.Pending)
+		assert.Equal(t, "store is unreachable", status.LastError)
+
+		// writes stay queued behind pending writes once the store is back
+		store.down = false
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||d", Value: "4"}))
+		assert.Empty(t, store.saved)
+
+		w.retry()
+		status, _ = w.WriteBehindStatus()
+		assert.Equal(t, 0, status.Pending)
+		assert.Empty(t, status.LastError)
+		assert.NotNil(t, status.LastRetry)
+
+		assert.Len(t, store.saved, 3)
+		assert.Equal(t, []byte("1"), store.saved[0].Value)
+		assert.JSONEq(t, `{"v": 2}`, string(store.saved[1].Value.(json.RawMessage)))
+		assert.Equal(t, "4", store.saved[2].Value)
+		assert.Len(t, store.deleted, 1)
+	})
+
+	t.Run("queued writes survive restarts", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "writebehind")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		props := map[string]string{WriteBehindMetadataKey: "true", WriteBehindRetryIntervalMetadataKey: "1h"}
+		s, err := WithWriteBehind(&flakyStore{down: true}, props, dir)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||a", Value: "1"}))
+
+		store := &flakyStore{}
+		restarted, err := WithWriteBehind(store, props, dir)
+		assert.NoError(t, err)
+		restarted.(*writeBehindStore).retry()
+		assert.Len(t, store.saved, 1)
+		assert.Equal(t, "1", store.saved[0].Value)
+	})
+
+	t.Run("status is found through schema validation", func(t *testing.T) {
+		s, err := WithWriteBehind(&fakeStore{}, map[string]string{WriteBehindMetadataKey: "true"}, dir)
+		assert.NoError(t, err)
+		s, err = WithSchemaValidation(s, map[string]string{SchemaMetadataKey: orderSchema})
+		assert.NoError(t, err)
+
+		_, ok := WriteBehind(s)
+		assert.True(t, ok)
+	})
+
+	t.Run("writes of a key during a failing write are queued behind it", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "writebehind")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		store := &blockingStore{started: make(chan struct{}), gate: make(chan struct{})}
+		s, err := WithWriteBehind(store, map[string]string{WriteBehindMetadataKey: "true", WriteBehindRetryIntervalMetadataKey: "1h"}, dir)
+		assert.NoError(t, err)
+		w := s.(*writeBehindStore)
+		defer w.Close()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.Set(&state.SetRequest{Key: "app||a", Value: "1"}))
+		}()
+		<-store.started
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.Set(&state.SetRequest{Key: "app||a", Value: "2"}))
+		}()
+		time.Sleep(time.Millisecond * 50)
+		close(store.gate)
+		wg.Wait()
+
+		assert.Empty(t, store.saved)
+		status, _ := w.WriteBehindStatus()
+		assert.Equal(t, 2, status.Pending)
+
+		w.retry()
+		assert.Len(t, store.saved, 2)
+		assert.Equal(t, "1", store.saved[0].Value)
+		assert.Equal(t, "2", store.saved[1].Value)
+	})
+
+	t.Run("writes with an ETag are applied after the queued writes of their key", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "writebehind")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		store := &flakyStore{down: true}
+		s, err := WithWriteBehind(store, map[string]string{WriteBehindMetadataKey: "true", WriteBehindRetryIntervalMetadataKey: "1h"}, dir)
+		assert.NoError(t, err)
+		w := s.(*writeBehindStore)
+		defer w.Close()
+
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||a", Value: "1"}))
+		assert.Equal(t, ErrWriteBehindPending, s.Set(&state.SetRequest{Key: "app||a", Value: "2", ETag: "1"}))
+		assert.Equal(t, ErrWriteBehindPending, s.Delete(&state.DeleteRequest{Key: "app||a", ETag: "1"}))
+		assert.Empty(t, store.saved)
+		assert.Empty(t, store.deleted)
+
+		store.down = false
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||a", Value: "2", ETag: "1"}))
+		status, _ := w.WriteBehindStatus()
+		assert.Equal(t, 0, status.Pending)
+
+		// the retry of the queued write doesn't overwrite the write with an ETag
+		w.retry()
+		assert.Len(t, store.saved, 2)
+		assert.Equal(t, "1", store.saved[0].Value)
+		assert.Equal(t, "2", store.saved[1].Value)
+	})
+
+	t.Run("closing stops the retries", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "writebehind")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		store := &flakyStore{down: true}
+		s, err := WithWriteBehind(store, map[string]string{WriteBehindMetadataKey: "true", WriteBehindRetryIntervalMetadataKey: "10ms"}, dir)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||a", Value: "1"}))
+
+		w, ok := WriteBehind(s)
+		assert.True(t, ok)
+		assert.NoError(t, w.Close())
+		assert.NoError(t, w.Close())
+
+		// the writes behind the queued writes fail instead of being queued
+		store.down = false
+		assert.Equal(t, ErrWriteBehindClosed, s.Set(&state.SetRequest{Key: "app||b", Value: "2"}))
+		time.Sleep(time.Millisecond * 50)
+		assert.Empty(t, store.saved)
+
+		// the queued writes stay in the log
+		reopened, err := WithWriteBehind(store, map[string]string{WriteBehindMetadataKey: "true", WriteBehindRetryIntervalMetadataKey: "1h"}, dir)
+		assert.NoError(t, err)
+		defer reopened.(*writeBehindStore).Close()
+		status, _ := reopened.(*writeBehindStore).WriteBehindStatus()
+		assert.Equal(t, 1, status.Pending)
+	})
+
+	t.Run("queued transactions fail on stores that aren't transactional", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "writebehind")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		s, err := WithWriteBehind(&flakyStore{}, map[string]string{WriteBehindMetadataKey: "true", WriteBehindRetryIntervalMetadataKey: "1h"}, dir)
+		assert.NoError(t, err)
+		w := s.(*writeBehindStore)
+		defer w.Close()
+
+		b, err := json.Marshal(&queuedWrite{Multi: []queuedOperation{{Operation: state.Upsert, Set: &queuedSet{Key: "app||a", Bytes: []byte("1")}}}})
+		assert.NoError(t, err)
+		_, err = w.log.Append(b)
+		assert.NoError(t, err)
+		w.pending++
+
+		w.retry()
+		status, _ := w.WriteBehindStatus()
+		assert.Equal(t, 0, status.Pending)
+		assert.Equal(t, 1, status.DeadLettered)
+		assert.Contains(t, status.LastError, "not transactional")
+	})
+
+	t.Run("invalid max attempts", func(t *testing.T) {
+		_, err := WithWriteBehind(&fakeStore{}, map[string]string{
+			WriteBehindMetadataKey:            "true",
+			WriteBehindMaxAttemptsMetadataKey: "0",
+		}, dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("writes failing with a permanent error aren't queued", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "writebehind")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		store := &flakyStore{}
+		store.err = status.Error(codes.InvalidArgument, "invalid value")
+		s, err := WithWriteBehind(store, map[string]string{WriteBehindMetadataKey: "true", WriteBehindRetryIntervalMetadataKey: "1h"}, dir)
+		assert.NoError(t, err)
+		w := s.(*writeBehindStore)
+		defer w.Close()
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(s.Set(&state.SetRequest{Key: "app||a", Value: "1"})))
+		writeBehindStatus, _ := w.WriteBehindStatus()
+		assert.Equal(t, 0, writeBehindStatus.Pending)
+	})
+
+	t.Run("writes failing their max attempts are dead-lettered", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "writebehind")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		store := &flakyStore{down: true}
+		s, err := WithWriteBehind(store, map[string]string{
+			WriteBehindMetadataKey:              "true",
+			WriteBehindRetryIntervalMetadataKey: "1h",
+			WriteBehindMaxAttemptsMetadataKey:   "2",
+		}, dir)
+		assert.NoError(t, err)
+		w := s.(*writeBehindStore)
+		defer w.Close()
+
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||a", Value: "1"}))
+		w.retry()
+		writeBehindStatus, _ := w.WriteBehindStatus()
+		assert.Equal(t, 1, writeBehindStatus.Pending)
+
+		// the write is dead-lettered at its last attempt, and the writes behind it are applied
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "app||b", Value: "2"}))
+		store.down = false
+		store.err = errors.New("timeout")
+		store.failKey = "app||a"
+		w.retry()
+		writeBehindStatus, _ = w.WriteBehindStatus()
+		assert.Equal(t, 0, writeBehindStatus.Pending)
+		assert.Equal(t, 1, writeBehindStatus.DeadLettered)
+		assert.Len(t, store.saved, 1)
+		assert.Equal(t, "2", store.saved[0].Value)
+	})
+
+	t.Run("retries of a replacing store start once the replaced store is closed", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "writebehind")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		props := map[string]string{WriteBehindMetadataKey: "true", WriteBehindRetryIntervalMetadataKey: "10ms"}
+		old, err := WithWriteBehind(&flakyStore{down: true}, props, dir)
+		assert.NoError(t, err)
+		assert.NoError(t, old.Set(&state.SetRequest{Key: "app||a", Value: "1"}))
+
+		store := &flakyStore{}
+		s, err := WithWriteBehind(store, props, dir)
+		assert.NoError(t, err)
+		defer s.(*writeBehindStore).Close()
+		time.Sleep(time.Millisecond * 50)
+		assert.Empty(t, store.saved)
+
+		assert.NoError(t, old.(*writeBehindStore).Close())
+		assert.Eventually(t, func() bool {
+			writeBehindStatus, _ := s.(*writeBehindStore).WriteBehindStatus()
+			return writeBehindStatus.Pending == 0
+		}, time.Second, time.Millisecond*10)
+	})
+}
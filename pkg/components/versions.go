@@ -0,0 +1,35 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FirstVersion is the version of the components and implementations that don't specify one
+const FirstVersion = "v1"
+
+// VersionedName returns the registry key of a version of a component type, e.g. state.redis/v2
+func VersionedName(name, version string) string {
+	return fmt.Sprintf("%s/%s", name, normalizeVersion(version))
+}
+
+// TypeVersion returns the component type with its version, when it has one, for messages
+func TypeVersion(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return fmt.Sprintf("%s %s", name, normalizeVersion(version))
+}
+
+func normalizeVersion(version string) string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if version == "" {
+		return FirstVersion
+	}
+	return version
+}
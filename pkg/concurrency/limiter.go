@@ -0,0 +1,229 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package concurrency limits the concurrent calls to the app with a limit adapted to the latency of its responses,
+// so that the limit doesn't have to be tuned per app.
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+const (
+	defaultInitialLimit   = 10
+	defaultMinLimit       = 1
+	defaultMaxLimit       = 1000
+	defaultBackoffPercent = 90
+)
+
+// algorithm returns the new limit after a call completed, inflight being the calls in flight when it started, and
+// whether it backs off from an overload
+type algorithm interface {
+	update(limit float64, inflight int, latency time.Duration, dropped bool) (float64, bool)
+}
+
+// Limiter limits the concurrent calls. Calls above the limit wait for a call to complete, in order.
+// The limit backs off at most once per round trip: only the calls started after a back off back it off again.
+// A nil limiter is unlimited.
+type Limiter struct {
+	algorithm algorithm
+	minLimit  float64
+	maxLimit  float64
+
+	lock     sync.Mutex
+	limit    float64
+	inflight int
+	// started counts the calls let through, and backedOff is the count when the limit last backed off
+	started   uint64
+	backedOff uint64
+	// waiting holds a channel per waiting call, closed when it may proceed
+	waiting *list.List
+}
+
+// New returns the limiter of the spec, nil if adaptive concurrency is disabled
+func New(spec config.AdaptiveConcurrencySpec) (*Limiter, error) {
+	var alg algorithm
+	switch spec.Algorithm {
+	case "":
+		return nil, nil
+	case config.AdaptiveConcurrencyAIMD:
+		a := &aimd{backoff: defaultBackoffPercent / 100.0}
+		if spec.BackoffPercent > 0 {
+			a.backoff = float64(spec.BackoffPercent) / 100
+		}
+		if spec.LatencyThreshold != "" {
+			d, err := time.ParseDuration(spec.LatencyThreshold)
+			if err != nil {
+				return nil, fmt.Errorf("invalid latency threshold of adaptive concurrency: %s", err)
+			}
+			a.latencyThreshold = d
+		}
+		alg = a
+	case config.AdaptiveConcurrencyGradient:
+		alg = &gradient{}
+	default:
+		return nil, fmt.Errorf("unknown adaptive concurrency algorithm %s", spec.Algorithm)
+	}
+
+	initial, min, max := orDefault(spec.InitialLimit, defaultInitialLimit), orDefault(spec.MinLimit, defaultMinLimit), orDefault(spec.MaxLimit, defaultMaxLimit)
+	if min > max {
+		return nil, fmt.Errorf("adaptive concurrency min limit %d is above its max limit %d", min, max)
+	}
+	return &Limiter{
+		algorithm: alg,
+		minLimit:  float64(min),
+		maxLimit:  float64(max),
+		limit:     math.Min(math.Max(float64(initial), float64(min)), float64(max)),
+		waiting:   list.New(),
+	}, nil
+}
+
+// Limit returns the current limit
+func (l *Limiter) Limit() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return int(l.limit)
+}
+
+// Release ends a call acquired from the limiter, adapting the limit to its latency. Dropped calls failed because the
+// app is overloaded, e.g. they were throttled.
+type Release func(latency time.Duration, dropped bool)
+
+// Acquire waits until the call is under the limit, and returns the function ending it. It returns the error of the
+// context if it's done first.
+func (l *Limiter) Acquire(ctx context.Context) (Release, error) {
+	if l == nil {
+		return func(time.Duration, bool) {}, nil
+	}
+	l.lock.Lock()
+	if l.waiting.Len() == 0 && l.inflight < int(l.limit) {
+		defer l.lock.Unlock()
+		return l.start(), nil
+	}
+	ready := make(chan Release, 1)
+	e := l.waiting.PushBack(ready)
+	l.lock.Unlock()
+
+	select {
+	case release := <-ready:
+		return release, nil
+	case <-ctx.Done():
+		l.lock.Lock()
+		select {
+		case release := <-ready:
+			// the call was let through while it was cancelled
+			l.lock.Unlock()
+			release(0, false)
+		default:
+			l.waiting.Remove(e)
+			l.lock.Unlock()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// start lets a call through. The lock must be held.
+func (l *Limiter) start() Release {
+	l.inflight++
+	l.started++
+	call, inflight := l.started, l.inflight
+	return func(latency time.Duration, dropped bool) {
+		l.release(call, inflight, latency, dropped)
+	}
+}
+
+func (l *Limiter) release(call uint64, inflight int, latency time.Duration, dropped bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.inflight--
+	limit, backoff := l.algorithm.update(l.limit, inflight, latency, dropped)
+	if backoff {
+		if call <= l.backedOff {
+			limit = l.limit
+		} else {
+			l.backedOff = l.started
+		}
+	}
+	l.limit = math.Min(math.Max(limit, l.minLimit), l.maxLimit)
+	for l.waiting.Len() > 0 && l.inflight < int(l.limit) {
+		l.waiting.Remove(l.waiting.Front()).(chan Release) <- l.start()
+	}
+	diag.DefaultMonitoring.AppConcurrencyChanged(int64(l.limit), int64(l.inflight))
+}
+
+// aimd increases the limit additively by one per limit of successful calls, and decreases it multiplicatively when a
+// call is dropped or slower than the latency threshold
+type aimd struct {
+	latencyThreshold time.Duration
+	backoff          float64
+}
+
+func (a *aimd) update(limit float64, inflight int, latency time.Duration, dropped bool) (float64, bool) {
+	if dropped || (a.latencyThreshold > 0 && latency > a.latencyThreshold) {
+		return limit * a.backoff, true
+	}
+	// the limit only grows while it's used, so that it doesn't grow unbounded under a light load
+	if float64(inflight)*2 >= limit {
+		return limit + 1/limit, false
+	}
+	return limit, false
+}
+
+const (
+	// gradientTolerance of the latency increase before the gradient limit decreases
+	gradientTolerance = 1.5
+	// gradientSmoothing of the changes of the gradient limit
+	gradientSmoothing = 0.2
+	// latencyWindow is the number of calls of the moving averages of the latencies
+	latencyWindow = 10
+)
+
+// gradient multiplies the limit by the ratio of the latency without load to the current latency, and adds a queue of
+// the square root of the limit so that it keeps probing for a higher limit. The latencies are moving averages, the
+// latency without load following the latency while the limit isn't used, and only decreasing while it is. Each call
+// applies its share of the update, so that the limit changes by one update per round trip; dropped calls halve it.
+type gradient struct {
+	noLoad  float64
+	current float64
+}
+
+func (g *gradient) update(limit float64, inflight int, latency time.Duration, dropped bool) (float64, bool) {
+	rtt := float64(latency)
+	if g.current == 0 {
+		g.current, g.noLoad = rtt, rtt
+	}
+	g.current += (rtt - g.current) / latencyWindow
+	loaded := float64(inflight)*2 >= limit
+	if !loaded || rtt < g.noLoad {
+		g.noLoad += (rtt - g.noLoad) / latencyWindow
+	}
+	if dropped {
+		return limit / 2, true
+	}
+	// the app isn't loaded enough to measure the limit
+	if !loaded {
+		return limit, false
+	}
+
+	ratio := math.Max(0.5, math.Min(1, gradientTolerance*g.noLoad/g.current))
+	next := limit*ratio + math.Sqrt(limit)
+	return limit + (next-limit)*gradientSmoothing/limit, false
+}
+
+func orDefault(v, d int) int {
+	if v > 0 {
+		return v
+	}
+	return d
+}
@@ -0,0 +1,160 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package concurrency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(t *testing.T, spec config.AdaptiveConcurrencySpec) *Limiter {
+	l, err := New(spec)
+	require.NoError(t, err)
+	return l
+}
+
+func TestNew(t *testing.T) {
+	l, err := New(config.AdaptiveConcurrencySpec{})
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+
+	l = newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyGradient})
+	assert.Equal(t, defaultInitialLimit, l.Limit())
+	l = newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyAIMD, InitialLimit: 50, MaxLimit: 20})
+	assert.Equal(t, 20, l.Limit())
+
+	for _, spec := range []config.AdaptiveConcurrencySpec{
+		{Algorithm: "vegas"},
+		{Algorithm: config.AdaptiveConcurrencyAIMD, LatencyThreshold: "slow"},
+		{Algorithm: config.AdaptiveConcurrencyAIMD, MinLimit: 10, MaxLimit: 5},
+	} {
+		_, err := New(spec)
+		assert.Error(t, err, "%+v", spec)
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	l := newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyAIMD, InitialLimit: 1, MaxLimit: 1})
+
+	release, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+
+	t.Run("calls above the limit wait for a call to complete", func(t *testing.T) {
+		acquired := make(chan Release)
+		go func() {
+			r, _ := l.Acquire(context.Background())
+			acquired <- r
+		}()
+		select {
+		case <-acquired:
+			assert.Fail(t, "call above the limit wasn't blocked")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		release(time.Millisecond, false)
+		release = <-acquired
+		assert.NotNil(t, release)
+	})
+
+	t.Run("waiting calls are cancelled with their context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := l.Acquire(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		release(time.Millisecond, false)
+		_, err = l.Acquire(context.Background())
+		assert.NoError(t, err)
+	})
+
+	var nilLimiter *Limiter
+	release, err = nilLimiter.Acquire(context.Background())
+	assert.NoError(t, err)
+	release(time.Second, true)
+}
+
+// load keeps the limiter full of calls with the latency of their concurrency until the limit settles, completing them
+// in order
+func load(l *Limiter, latency func(inflight int) time.Duration, dropped func(inflight int) bool) {
+	type call struct {
+		release Release
+		latency time.Duration
+		dropped bool
+	}
+	var calls []call
+	for i := 0; i < 100000; i++ {
+		for len(calls) < l.Limit() {
+			release, _ := l.Acquire(context.Background())
+			calls = append(calls, call{release, latency(len(calls) + 1), dropped(len(calls) + 1)})
+		}
+		c := calls[0]
+		calls = calls[1:]
+		c.release(c.latency, c.dropped)
+	}
+}
+
+func TestAIMD(t *testing.T) {
+	never := func(int) bool { return false }
+
+	t.Run("limit grows while calls succeed", func(t *testing.T) {
+		l := newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyAIMD, MaxLimit: 100})
+		load(l, func(int) time.Duration { return time.Millisecond }, never)
+		assert.Equal(t, 100, l.Limit())
+	})
+
+	t.Run("limit backs off from slow calls", func(t *testing.T) {
+		l := newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyAIMD, LatencyThreshold: "100ms"})
+		// the app slows down above 40 concurrent calls
+		load(l, func(inflight int) time.Duration {
+			if inflight > 40 {
+				return time.Second
+			}
+			return 10 * time.Millisecond
+		}, never)
+		assert.InDelta(t, 40, l.Limit(), 5)
+	})
+
+	t.Run("limit backs off from dropped calls", func(t *testing.T) {
+		l := newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyAIMD, MinLimit: 2})
+		load(l, func(int) time.Duration { return time.Millisecond }, func(int) bool { return true })
+		assert.Equal(t, 2, l.Limit())
+	})
+}
+
+func TestGradient(t *testing.T) {
+	never := func(int) bool { return false }
+
+	t.Run("limit grows while the latency stays", func(t *testing.T) {
+		l := newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyGradient, MaxLimit: 200})
+		load(l, func(int) time.Duration { return 10 * time.Millisecond }, never)
+		assert.Equal(t, 200, l.Limit())
+	})
+
+	t.Run("limit settles where the latency grows", func(t *testing.T) {
+		l := newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyGradient})
+		// the app queues the calls above 50 concurrent calls, the limit settling with a tolerated queue
+		load(l, func(inflight int) time.Duration {
+			if inflight <= 50 {
+				return 10 * time.Millisecond
+			}
+			return time.Duration(inflight) * 10 * time.Millisecond / 50
+		}, never)
+		assert.InDelta(t, 85, l.Limit(), 10)
+	})
+
+	t.Run("limit halves on dropped calls", func(t *testing.T) {
+		l := newTestLimiter(t, config.AdaptiveConcurrencySpec{Algorithm: config.AdaptiveConcurrencyGradient, InitialLimit: 64})
+		release, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+		release(time.Millisecond, true)
+		assert.Equal(t, 32, l.Limit())
+	})
+}
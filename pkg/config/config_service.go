@@ -0,0 +1,203 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	modes "github.com/dapr/dapr/pkg/config/modes"
+	"github.com/dapr/dapr/pkg/fips"
+	"github.com/dapr/dapr/pkg/logger"
+	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const configServiceTimeout = time.Second * 10
+
+var log = logger.NewLogger("dapr.runtime.config")
+
+// ConfigServiceClient fetches the configuration and the components of a sidecar from a remote config service.
+// Over HTTP the configuration is read from GET <address>/configurations/<name> and the list of components from
+// GET <address>/components, with the id of the app as appId query parameter, as JSON or YAML. Over gRPC the config
+// service serves the GetConfiguration and GetComponents methods of the operator API.
+type ConfigServiceClient struct {
+	spec     modes.ConfigServiceConfig
+	appID    string
+	address  *url.URL
+	http     *http.Client
+	conn     *grpc.ClientConn
+	operator operatorv1pb.OperatorClient
+}
+
+// NewConfigServiceClient returns the client of the config service of the app
+func NewConfigServiceClient(spec modes.ConfigServiceConfig, appID string) (*ConfigServiceClient, error) {
+	address, err := url.Parse(spec.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config service address %s: %s", spec.Address, err)
+	}
+
+	c := &ConfigServiceClient{spec: spec, appID: appID, address: address}
+	switch address.Scheme {
+	case "http", "https":
+		c.http = &http.Client{Timeout: configServiceTimeout}
+	case "grpc", "grpcs":
+		opts := []grpc.DialOption{}
+		if address.Scheme == "grpcs" {
+			opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(fips.ConfigureTLS(&tls.Config{}))))
+		} else {
+			opts = append(opts, grpc.WithInsecure())
+		}
+		if spec.Token != "" {
+			opts = append(opts, grpc.WithPerRPCCredentials(bearerToken{token: spec.Token, secure: address.Scheme == "grpcs"}))
+		}
+		c.conn, err = grpc.Dial(address.Host, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to config service %s: %s", spec.Address, err)
+		}
+		c.operator = operatorv1pb.NewOperatorClient(c.conn)
+	default:
+		return nil, fmt.Errorf("invalid config service address %s: unsupported scheme %s", spec.Address, address.Scheme)
+	}
+	return c, nil
+}
+
+// Close closes the connection to the config service
+func (c *ConfigServiceClient) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// Configuration returns the configuration of the given name
+func (c *ConfigServiceClient) Configuration(name string) ([]byte, error) {
+	return c.fetch("configuration-"+filepath.Base(name), func(ctx context.Context) ([]byte, error) {
+		if c.operator == nil {
+			return c.get(ctx, "configurations/"+url.PathEscape(name))
+		}
+		resp, err := c.operator.GetConfiguration(ctx, &operatorv1pb.GetConfigurationRequest{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Configuration == nil {
+			return nil, fmt.Errorf("configuration %s not found", name)
+		}
+		return resp.Configuration.Value, nil
+	})
+}
+
+// Components returns the list of the components of the app
+func (c *ConfigServiceClient) Components() ([]byte, error) {
+	return c.fetch("components", func(ctx context.Context) ([]byte, error) {
+		if c.operator == nil {
+			return c.get(ctx, "components")
+		}
+		resp, err := c.operator.GetComponents(ctx, &empty.Empty{})
+		if err != nil {
+			return nil, err
+		}
+		list := make([]string, 0, len(resp.GetComponents()))
+		for _, component := range resp.GetComponents() {
+			list = append(list, string(component.Value))
+		}
+		return []byte("[" + strings.Join(list, ",") + "]"), nil
+	})
+}
+
+// fetch returns the resource from the config service, and caches it. The cached resource is returned when the config
+// service can't be reached.
+func (c *ConfigServiceClient) fetch(resource string, get func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), configServiceTimeout)
+	defer cancel()
+
+	b, err := get(ctx)
+	if c.spec.CacheDir == "" {
+		return b, err
+	}
+	cached := filepath.Join(c.spec.CacheDir, resource)
+	if err != nil {
+		b, cacheErr := ioutil.ReadFile(cached)
+		if cacheErr != nil {
+			return nil, err
+		}
+		log.Warnf("using the cached %s, the config service can't be reached: %s", resource, err)
+		return b, nil
+	}
+
+	if err := os.MkdirAll(c.spec.CacheDir, 0700); err != nil {
+		log.Warnf("failed to cache the %s of the config service: %s", resource, err)
+	} else if err := ioutil.WriteFile(cached, b, 0600); err != nil {
+		log.Warnf("failed to cache the %s of the config service: %s", resource, err)
+	}
+	return b, nil
+}
+
+// get returns the resource of the HTTP config service
+func (c *ConfigServiceClient) get(ctx context.Context, resource string) ([]byte, error) {
+	u := *c.address
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + resource
+	u.RawQuery = url.Values{"appId": []string{c.appID}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.spec.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.spec.Token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("config service returned status code %d for %s", resp.StatusCode, resource)
+	}
+	return b, nil
+}
+
+// bearerToken authenticates the gRPC calls to the config service
+type bearerToken struct {
+	token  string
+	secure bool
+}
+
+func (b bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearerToken) RequireTransportSecurity() bool {
+	return b.secure
+}
+
+// LoadRemoteConfiguration loads the configuration of the given name from the config service
+func LoadRemoteConfiguration(client *ConfigServiceClient, name string) (*Configuration, error) {
+	b, err := client.Configuration(name)
+	if err != nil {
+		return nil, err
+	}
+	var conf Configuration
+	if err := yaml.Unmarshal(b, &conf); err != nil {
+		return nil, fmt.Errorf("invalid configuration %s of the config service: %s", name, err)
+	}
+	return &conf, nil
+}
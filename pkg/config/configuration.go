@@ -15,6 +15,7 @@ import (
 
 	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	jsoniter "github.com/json-iterator/go"
 	yaml "gopkg.in/yaml.v2"
 )
 
@@ -28,9 +29,26 @@ type Configuration struct {
 }
 
 type ConfigurationSpec struct {
-	HTTPPipelineSpec PipelineSpec `json:"httpPipeline,omitempty" yaml:"httpPipeline,omitempty"`
-	TracingSpec      TracingSpec  `json:"tracing,omitempty" yaml:"tracing,omitempty"`
-	MTLSSpec         MTLSSpec     `json:"mtls,omitempty"`
+	HTTPPipelineSpec   PipelineSpec       `json:"httpPipeline,omitempty" yaml:"httpPipeline,omitempty"`
+	ActorPipelineSpec  PipelineSpec       `json:"actorPipeline,omitempty" yaml:"actorPipeline,omitempty"`
+	TracingSpec        TracingSpec        `json:"tracing,omitempty" yaml:"tracing,omitempty"`
+	MTLSSpec           MTLSSpec           `json:"mtls,omitempty"`
+	StartupSpec        StartupSpec        `json:"startup,omitempty" yaml:"startup,omitempty"`
+	ActorLifecycleSpec ActorLifecycleSpec `json:"actorLifecycle,omitempty" yaml:"actorLifecycle,omitempty"`
+	ActorTurnsSpec     ActorTurnsSpec     `json:"actorTurns,omitempty" yaml:"actorTurns,omitempty"`
+	ActorStateSpec     ActorStateSpec     `json:"actorState,omitempty" yaml:"actorState,omitempty"`
+	GRPCServerSpec     GRPCServerSpec     `json:"grpcServer,omitempty" yaml:"grpcServer,omitempty"`
+	GRPCClientSpec     GRPCClientSpec     `json:"grpcClient,omitempty" yaml:"grpcClient,omitempty"`
+	NameResolutionSpec NameResolutionSpec `json:"nameResolution,omitempty" yaml:"nameResolution,omitempty"`
+	PubSubSpec         PubSubSpec         `json:"pubsub,omitempty" yaml:"pubsub,omitempty"`
+	InvocationSpec     InvocationSpec     `json:"serviceInvocation,omitempty" yaml:"serviceInvocation,omitempty"`
+	DataResidencySpec  DataResidencySpec  `json:"dataResidency,omitempty" yaml:"dataResidency,omitempty"`
+	ActorResiliency    ActorResiliency    `json:"actorResiliency,omitempty" yaml:"actorResiliency,omitempty"`
+	Bulkheads          []BulkheadSpec     `json:"bulkheads,omitempty" yaml:"bulkheads,omitempty"`
+	JSONSpec           JSONSpec           `json:"json,omitempty" yaml:"json,omitempty"`
+	MemoryBudgetSpec   MemoryBudgetSpec   `json:"memoryBudget,omitempty" yaml:"memoryBudget,omitempty"`
+	EgressSpec         EgressSpec         `json:"egress,omitempty" yaml:"egress,omitempty"`
+	SecretsSpec        SecretsSpec        `json:"secrets,omitempty" yaml:"secrets,omitempty"`
 }
 
 type PipelineSpec struct {
@@ -54,6 +72,12 @@ type SelectorField struct {
 
 type TracingSpec struct {
 	SamplingRate string `json:"samplingRate" yaml:"samplingRate"`
+	// Propagators are the formats of the trace context headers that are extracted and injected, in order of precedence.
+	// The default is W3C trace context.
+	Propagators []string `json:"propagators,omitempty" yaml:"propagators,omitempty"`
+	// Disabled are the operations, e.g. GetState, or building blocks (state, secrets, bindings or pubsub) that aren't
+	// traced, to control the volume of the traces.
+	Disabled []string `json:"disabled,omitempty" yaml:"disabled,omitempty"`
 }
 
 type MTLSSpec struct {
@@ -62,6 +86,432 @@ type MTLSSpec struct {
 	AllowedClockSkew string `json:"allowedClockSkew"`
 }
 
+// GRPCServerSpec defines the limits of the public API and internal gRPC servers
+type GRPCServerSpec struct {
+	API      GRPCServerLimits `json:"api,omitempty" yaml:"api,omitempty"`
+	Internal GRPCServerLimits `json:"internal,omitempty" yaml:"internal,omitempty"`
+	// DrainGracePeriod is how long the internal server waits for in-flight calls on shutdown, after the sidecar
+	// deregistered and told its peers to go away. Defaults to 5s.
+	DrainGracePeriod string `json:"drainGracePeriod,omitempty" yaml:"drainGracePeriod,omitempty"`
+	// CallLocal shares the calls to the app from other sidecars fairly between them
+	CallLocal CallLocalSpec `json:"callLocal,omitempty" yaml:"callLocal,omitempty"`
+	// InvokePayload bounds the payloads of the invocations from other sidecars on the internal server
+	InvokePayload InvokePayloadSpec `json:"invokePayload,omitempty" yaml:"invokePayload,omitempty"`
+}
+
+// InvokePayloadSpec defines the limits of the Any payloads of the invocations from other sidecars, checked before
+// they're unmarshaled. Invocations beyond them fail with InvalidArgument.
+type InvokePayloadSpec struct {
+	// MaxDepth is the number of Any payloads nested in each other allowed. Defaults to 8.
+	MaxDepth int `json:"maxDepth,omitempty" yaml:"maxDepth,omitempty"`
+	// MaxSize is the size of the largest payload, e.g. 4Mi. Unlimited by default.
+	MaxSize string `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
+}
+
+// CallLocalSpec defines the flow control of the calls to the app from other sidecars on the internal server. Calls
+// beyond the concurrency limits wait in a queue per peer, and the queues take turns for the free slots, so that a
+// single peer can't monopolize the app channel. Zero means unlimited.
+type CallLocalSpec struct {
+	MaxConcurrency        int `json:"maxConcurrency,omitempty" yaml:"maxConcurrency,omitempty"`
+	MaxConcurrencyPerPeer int `json:"maxConcurrencyPerPeer,omitempty" yaml:"maxConcurrencyPerPeer,omitempty"`
+	// MaxQueuedPerPeer is the number of calls of a peer that can wait for their turn, before they are rejected
+	MaxQueuedPerPeer int `json:"maxQueuedPerPeer,omitempty" yaml:"maxQueuedPerPeer,omitempty"`
+	// AllowedCallers are the app IDs of the sidecars allowed to call the app with mTLS. Any sidecar of the trust
+	// domain may call it when empty.
+	AllowedCallers []string `json:"allowedCallers,omitempty" yaml:"allowedCallers,omitempty"`
+	// RequireTarget rejects the calls that don't name their target app. The sidecars of older versions don't name it,
+	// so it should be enabled once all the sidecars are upgraded.
+	RequireTarget bool `json:"requireTarget,omitempty" yaml:"requireTarget,omitempty"`
+}
+
+// GRPCClientSpec defines the connection timeouts of the gRPC clients calling other Dapr sidecars, for service
+// invocation and actors
+type GRPCClientSpec struct {
+	// DialTimeout is how long establishing a connection to a sidecar may take. Defaults to 5s.
+	DialTimeout string `json:"dialTimeout,omitempty" yaml:"dialTimeout,omitempty"`
+	// HandshakeTimeout is how long the TLS handshake with a sidecar may take. Defaults to 5s.
+	HandshakeTimeout string `json:"handshakeTimeout,omitempty" yaml:"handshakeTimeout,omitempty"`
+	// BlacklistDuration is how long connecting to a sidecar fails fast after a connection to it failed, instead of
+	// waiting for the dial timeout again. Defaults to 10s, 0s disables it.
+	BlacklistDuration string `json:"blacklistDuration,omitempty" yaml:"blacklistDuration,omitempty"`
+}
+
+// GRPCServerLimits defines the stream and connection limits of a gRPC server. Zero means unlimited.
+type GRPCServerLimits struct {
+	MaxConcurrentStreams  uint32 `json:"maxConcurrentStreams,omitempty" yaml:"maxConcurrentStreams,omitempty"`
+	MaxConnections        int    `json:"maxConnections,omitempty" yaml:"maxConnections,omitempty"`
+	MaxConnectionsPerPeer int    `json:"maxConnectionsPerPeer,omitempty" yaml:"maxConnectionsPerPeer,omitempty"`
+	// MaxMessageSize is the size of the largest message received, e.g. 16Mi. Defaults to the gRPC default of 4Mi.
+	MaxMessageSize string `json:"maxMessageSize,omitempty" yaml:"maxMessageSize,omitempty"`
+}
+
+// NameResolutionSpec configures how app ids are resolved to the addresses of their sidecars
+type NameResolutionSpec struct {
+	// Resolver overrides the resolver of the runtime mode, e.g. dns for self-hosted deployments without mDNS
+	Resolver string             `json:"resolver,omitempty" yaml:"resolver,omitempty"`
+	DNS      DNSResolverSpec    `json:"dns,omitempty" yaml:"dns,omitempty"`
+	Consul   ConsulResolverSpec `json:"consul,omitempty" yaml:"consul,omitempty"`
+}
+
+// DNSResolverSpec configures the DNS name resolver
+type DNSResolverSpec struct {
+	// Template is the DNS name of an app. {id} and {namespace} are replaced with the app id and namespace.
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
+	// SRV looks up SRV records for the port of the sidecar instead of using the local internal gRPC port
+	SRV bool `json:"srv,omitempty" yaml:"srv,omitempty"`
+	// Servers overrides the name servers of /etc/resolv.conf
+	Servers []string `json:"servers,omitempty" yaml:"servers,omitempty"`
+}
+
+// ConsulResolverSpec configures the Consul name resolver and the registration of the sidecar with the local Consul agent
+type ConsulResolverSpec struct {
+	// Address of the Consul agent. Defaults to CONSUL_HTTP_ADDR or 127.0.0.1:8500.
+	Address    string   `json:"address,omitempty" yaml:"address,omitempty"`
+	Datacenter string   `json:"datacenter,omitempty" yaml:"datacenter,omitempty"`
+	Tags       []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// CheckInterval is the interval of the TCP health check of the internal gRPC port
+	CheckInterval string `json:"checkInterval,omitempty" yaml:"checkInterval,omitempty"`
+	// DeregisterCriticalServiceAfter removes sidecars that fail their health check for this long, e.g. after a crash
+	DeregisterCriticalServiceAfter string `json:"deregisterCriticalServiceAfter,omitempty" yaml:"deregisterCriticalServiceAfter,omitempty"`
+	// SkipRegistration disables self registration when services are registered by other means
+	SkipRegistration bool `json:"skipRegistration,omitempty" yaml:"skipRegistration,omitempty"`
+}
+
+// PubSubSpec configures publishing to the pub/sub components
+type PubSubSpec struct {
+	FanOut      []FanOutSpec    `json:"fanOut,omitempty" yaml:"fanOut,omitempty"`
+	DualRead    DualReadSpec    `json:"dualRead,omitempty" yaml:"dualRead,omitempty"`
+	Transforms  []TransformSpec `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+	ExactlyOnce ExactlyOnceSpec `json:"exactlyOnce,omitempty" yaml:"exactlyOnce,omitempty"`
+	// MaxRetryAfter caps the delay the app can ask, with Retry-After, before a failed event is redelivered, e.g. 30s.
+	// Defaults to 1m. The delays asked by the app aren't honored when 0.
+	MaxRetryAfter string `json:"maxRetryAfter,omitempty" yaml:"maxRetryAfter,omitempty"`
+}
+
+// DualReadSpec subscribes the app to its topics on a second pub/sub component, e.g. while migrating between brokers.
+// Events received from both components are delivered once, deduplicated on their id.
+type DualReadSpec struct {
+	// PubSub is the name of the second pub/sub component. Dual-read is disabled when empty.
+	PubSub string `json:"pubsub,omitempty" yaml:"pubsub,omitempty"`
+	// Topics limits dual-read to these topics. All the subscribed topics are read from both components when empty.
+	Topics []string `json:"topics,omitempty" yaml:"topics,omitempty"`
+	// DeduplicationWindow is how long event ids are remembered, e.g. 10m
+	DeduplicationWindow string `json:"deduplicationWindow,omitempty" yaml:"deduplicationWindow,omitempty"`
+}
+
+// ExactlyOnceSpec drops the redeliveries of the events of the subscriptions of the app by at-least-once brokers.
+// The ids of the events delivered to the app are kept in a state store for the window, so that the deduplication
+// survives restarts and is shared by the instances of the app.
+type ExactlyOnceSpec struct {
+	// StateStore is the name of the state store of the delivered event ids. Deduplication is disabled when empty.
+	StateStore string `json:"stateStore,omitempty" yaml:"stateStore,omitempty"`
+	// Topics limits deduplication to these topics. All the subscribed topics are deduplicated when empty.
+	Topics []string `json:"topics,omitempty" yaml:"topics,omitempty"`
+	// Window is how long the ids of delivered events are kept, e.g. 1h. Defaults to 10m.
+	Window string `json:"window,omitempty" yaml:"window,omitempty"`
+}
+
+// FanOutSpec publishes the events of a topic to several pub/sub components and topics instead of the default pub/sub component
+type FanOutSpec struct {
+	Topic   string          `json:"topic" yaml:"topic"`
+	Targets []PublishTarget `json:"targets" yaml:"targets"`
+}
+
+// Transform stages
+const (
+	// TransformStagePublish transforms the events the app publishes, before they're sent to the pub/sub
+	TransformStagePublish = "publish"
+	// TransformStageDeliver transforms the events of the subscriptions of the app, before they're delivered to it
+	TransformStageDeliver = "deliver"
+)
+
+// TransformSpec rewrites the data of the CloudEvents of a topic with a Go template executed on the data.
+// The output is the new data, as JSON when it parses as JSON and as text otherwise.
+type TransformSpec struct {
+	Topic string `json:"topic" yaml:"topic"`
+	// Stage is publish or deliver
+	Stage    string `json:"stage" yaml:"stage"`
+	Template string `json:"template" yaml:"template"`
+}
+
+// PublishTarget is a pub/sub component and topic events are published to
+type PublishTarget struct {
+	// PubSub is the name of the pub/sub component. Defaults to the default pub/sub component.
+	PubSub string `json:"pubsub,omitempty" yaml:"pubsub,omitempty"`
+	// Topic defaults to the topic of the event
+	Topic string `json:"topic,omitempty" yaml:"topic,omitempty"`
+}
+
+// Data residency policies control what happens when an app may not use a component because of its residency
+const (
+	// ResidencyPolicyReject doesn't load the component
+	ResidencyPolicyReject = "reject"
+	// ResidencyPolicyWarn logs the violation and loads the component
+	ResidencyPolicyWarn = "warn"
+)
+
+// DataResidencySpec restricts the components an app uses to the ones whose data resides in its region
+type DataResidencySpec struct {
+	// Region of the app, e.g. eu-west. Components are not restricted when empty.
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+	// AllowedRegions are the other regions the app may use components of
+	AllowedRegions []string `json:"allowedRegions,omitempty" yaml:"allowedRegions,omitempty"`
+	// Policy is reject (default) or warn
+	Policy string `json:"policy,omitempty" yaml:"policy,omitempty"`
+}
+
+// InvocationSpec configures the service invocation of other apps
+type InvocationSpec struct {
+	PriorityClasses []PriorityClassSpec `json:"priorityClasses,omitempty" yaml:"priorityClasses,omitempty"`
+	HTTPMappings    []HTTPMappingSpec   `json:"httpMappings,omitempty" yaml:"httpMappings,omitempty"`
+	// AdaptiveConcurrency limits the concurrent invocations of the app with a limit adapted to its latency
+	AdaptiveConcurrency AdaptiveConcurrencySpec `json:"adaptiveConcurrency,omitempty" yaml:"adaptiveConcurrency,omitempty"`
+	// GRPCProxy forwards the calls of the app to gRPC services unknown to Dapr to the app named by their dapr-app-id
+	// metadata, so that the apps can call each other with their own generated clients
+	GRPCProxy bool `json:"grpcProxy,omitempty" yaml:"grpcProxy,omitempty"`
+}
+
+// Algorithms adapting the concurrency limit of the app
+const (
+	AdaptiveConcurrencyAIMD     = "aimd"
+	AdaptiveConcurrencyGradient = "gradient"
+)
+
+// AdaptiveConcurrencySpec adapts the limit of the concurrent calls to the app to its latency. Calls above the limit
+// wait for a call to complete.
+type AdaptiveConcurrencySpec struct {
+	// Algorithm is aimd, increasing the limit while calls succeed and decreasing it when they fail or are slow, or
+	// gradient, following the ratio of the latency without load to the current latency. Disabled when empty.
+	Algorithm string `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	// InitialLimit is the limit at startup, 10 by default
+	InitialLimit int `json:"initialLimit,omitempty" yaml:"initialLimit,omitempty"`
+	// MinLimit is 1 by default
+	MinLimit int `json:"minLimit,omitempty" yaml:"minLimit,omitempty"`
+	// MaxLimit is 1000 by default
+	MaxLimit int `json:"maxLimit,omitempty" yaml:"maxLimit,omitempty"`
+	// LatencyThreshold decreases the aimd limit when a call takes longer, e.g. 500ms. Only failed calls decrease it when
+	// empty.
+	LatencyThreshold string `json:"latencyThreshold,omitempty" yaml:"latencyThreshold,omitempty"`
+	// BackoffPercent is the percentage of the aimd limit kept when it's decreased, 90 by default
+	BackoffPercent int `json:"backoffPercent,omitempty" yaml:"backoffPercent,omitempty"`
+}
+
+// PriorityClassSpec limits the concurrent invocations of a priority class (high, normal or low).
+// Each priority class has its own connections, so its calls aren't queued behind the calls of other classes.
+type PriorityClassSpec struct {
+	Name string `json:"name" yaml:"name"`
+	// MaxConcurrency is the maximum number of concurrent invocations of the class. Unlimited when 0.
+	MaxConcurrency int `json:"maxConcurrency,omitempty" yaml:"maxConcurrency,omitempty"`
+}
+
+// HTTPMappingSpec maps the invocations of a method without an HTTP verb, e.g. gRPC InvokeService calls, to a request
+// of the HTTP app
+type HTTPMappingSpec struct {
+	// Method is the invoked method
+	Method string `json:"method" yaml:"method"`
+	// Verb is the HTTP verb of the request, e.g. GET
+	Verb string `json:"verb" yaml:"verb"`
+	// Path is the path template of the request, e.g. /orders/{id}. Parameters are fields of the JSON request message,
+	// with dots separating nested fields.
+	Path string `json:"path" yaml:"path"`
+	// Query maps query parameters to fields of the request message. Missing fields aren't sent.
+	Query map[string]string `json:"query,omitempty" yaml:"query,omitempty"`
+	// Body is * to send the whole request message, a field to send that field only, or empty to send no body
+	Body string `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// ActorLifecycleSpec configures publishing of actor lifecycle events
+type ActorLifecycleSpec struct {
+	// Topic to publish actor activated, deactivated and rebalanced events to. Events are not published when empty.
+	Topic string `json:"topic,omitempty" yaml:"topic,omitempty"`
+}
+
+// ActorTurnsSpec configures the monitoring of actor turns
+type ActorTurnsSpec struct {
+	// SlowTurnThreshold logs the actor turns that take longer than this, e.g. 500ms. Slow turns are not logged when empty.
+	SlowTurnThreshold string `json:"slowTurnThreshold,omitempty" yaml:"slowTurnThreshold,omitempty"`
+}
+
+// ActorStateSpec configures the state of the actors
+type ActorStateSpec struct {
+	// Cache keeps the state of the active actors in memory, written through to the state store, so that the turns of an
+	// actor don't read its state from the store again. The state of an actor is dropped on its deactivation.
+	Cache bool `json:"cache,omitempty" yaml:"cache,omitempty"`
+	// StaleReads let the state reads of read-heavy actor types return values read from the store recently, instead of
+	// reading the store on every call
+	StaleReads []ActorStaleReadSpec `json:"staleReads,omitempty" yaml:"staleReads,omitempty"`
+}
+
+// ActorStaleReadSpec is the stale-read budget of the state reads of an actor type
+type ActorStaleReadSpec struct {
+	ActorType string `json:"actorType" yaml:"actorType"`
+	// MaxStaleness is the age of the values read from the store that the reads still return, e.g. 500ms. Calls reading
+	// with strong consistency or a budget of their own override it.
+	MaxStaleness string `json:"maxStaleness" yaml:"maxStaleness"`
+}
+
+// ActorResiliency configures the timeouts, retries and circuit breakers of actor method invocations
+type ActorResiliency struct {
+	// Policies apply to the invocations of their actor type and method. The first matching policy applies.
+	Policies []ActorPolicySpec `json:"policies,omitempty" yaml:"policies,omitempty"`
+}
+
+// ActorPolicySpec is the resiliency policy of the methods of an actor type
+type ActorPolicySpec struct {
+	ActorType string `json:"actorType" yaml:"actorType"`
+	// Method is a glob pattern of the method names the policy applies to, e.g. get*. All the methods when empty.
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	// Timeout of each attempt, e.g. 5s. Attempts don't time out when empty.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// MaxRetries is the number of times a failed invocation is retried
+	MaxRetries    int    `json:"maxRetries,omitempty" yaml:"maxRetries,omitempty"`
+	RetryInterval string `json:"retryInterval,omitempty" yaml:"retryInterval,omitempty"`
+	// CircuitBreaker rejects the invocations after consecutive failures. It is disabled when nil.
+	CircuitBreaker *CircuitBreakerSpec `json:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty"`
+}
+
+// CircuitBreakerSpec opens a circuit after consecutive failures, rejecting calls until a trial call succeeds
+type CircuitBreakerSpec struct {
+	// ConsecutiveFailures opens the circuit
+	ConsecutiveFailures int `json:"consecutiveFailures" yaml:"consecutiveFailures"`
+	// OpenDuration is how long the circuit stays open before a trial call is let through, e.g. 30s
+	OpenDuration string `json:"openDuration" yaml:"openDuration"`
+}
+
+// Building blocks with a bulkhead
+const (
+	BulkheadState      = "state"
+	BulkheadPubSub     = "pubsub"
+	BulkheadBindings   = "bindings"
+	BulkheadInvocation = "invocation"
+)
+
+// BulkheadSpec isolates the API calls of a building block in a bounded worker pool, so that a backlog in one
+// building block, e.g. a slow state store, doesn't starve the others
+type BulkheadSpec struct {
+	// BuildingBlock is state, pubsub, bindings or invocation
+	BuildingBlock string `json:"buildingBlock" yaml:"buildingBlock"`
+	// MaxConcurrency is the number of workers of the pool
+	MaxConcurrency int `json:"maxConcurrency" yaml:"maxConcurrency"`
+	// MaxQueued is the number of calls waiting for a worker. Calls are rejected once the queue is full.
+	MaxQueued int `json:"maxQueued,omitempty" yaml:"maxQueued,omitempty"`
+	// QueueTimeout is how long calls wait for a worker before they're rejected, e.g. 1s. They wait until they're
+	// cancelled when empty.
+	QueueTimeout string `json:"queueTimeout,omitempty" yaml:"queueTimeout,omitempty"`
+}
+
+// Policies of the unknown fields of JSON request envelopes
+const (
+	UnknownFieldsIgnore = "ignore"
+	UnknownFieldsReject = "reject"
+)
+
+// JSONSpec configures the JSON serialization of the envelopes of the Dapr API and of CloudEvents
+type JSONSpec struct {
+	// PreserveInt64 keeps numbers as their original text instead of decoding them as float64, so that integers
+	// beyond 2^53, e.g. numeric IDs, aren't rounded
+	PreserveInt64 bool `json:"preserveInt64,omitempty" yaml:"preserveInt64,omitempty"`
+	// EscapeHTML escapes <, > and & in strings
+	EscapeHTML bool `json:"escapeHTML,omitempty" yaml:"escapeHTML,omitempty"`
+	// UnknownFields is the policy of the fields of request envelopes that Dapr doesn't know: ignore (default) or reject
+	UnknownFields string `json:"unknownFields,omitempty" yaml:"unknownFields,omitempty"`
+}
+
+// MemoryBudgetSpec protects the sidecar from running out of memory. Under pressure, it rejects the large payloads of
+// API calls and flushes its caches. When critical, it also pauses the delivery of the events of every topic to the app.
+type MemoryBudgetSpec struct {
+	// Limit is the memory budget of the sidecar, e.g. 512Mi, usually below the memory limit of its container. The
+	// budget is disabled when empty.
+	Limit string `json:"limit,omitempty" yaml:"limit,omitempty"`
+	// PressurePercent is the percentage of the limit the sidecar is under pressure from, 80 by default
+	PressurePercent int `json:"pressurePercent,omitempty" yaml:"pressurePercent,omitempty"`
+	// CriticalPercent is the percentage of the limit the sidecar is critical from, 95 by default
+	CriticalPercent int `json:"criticalPercent,omitempty" yaml:"criticalPercent,omitempty"`
+	// MaxPayloadSize is the size of the largest payload accepted under pressure, e.g. 64Ki, 1Mi by default
+	MaxPayloadSize string `json:"maxPayloadSize,omitempty" yaml:"maxPayloadSize,omitempty"`
+	// CheckInterval is how often the memory usage is checked, e.g. 500ms, 1s by default
+	CheckInterval string `json:"checkInterval,omitempty" yaml:"checkInterval,omitempty"`
+	// Topic to publish the changes of memory pressure to. They are not published when empty.
+	Topic string `json:"topic,omitempty" yaml:"topic,omitempty"`
+}
+
+// EgressSpec restricts the destinations the output bindings of the app may reach. They are not restricted when both
+// lists are empty. The restriction is best-effort: only the url, endpoint, host, address and brokers metadata of the
+// bindings and of their requests are checked, and host names allowed by their addresses are resolved apart from the
+// connections of the bindings. Network policies are needed to enforce the egress of the sidecar.
+type EgressSpec struct {
+	// AllowedHosts are the host names allowed, e.g. api.example.com, or *.example.com for its subdomains
+	AllowedHosts []string `json:"allowedHosts,omitempty" yaml:"allowedHosts,omitempty"`
+	// AllowedCIDRs are the IP ranges allowed, e.g. 10.0.0.0/8. Host names are allowed when all their addresses are.
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty" yaml:"allowedCIDRs,omitempty"`
+}
+
+// Access to the secrets of a secret store neither allowed nor denied by its scope
+const (
+	AllowAccess = "allow"
+	DenyAccess  = "deny"
+)
+
+// SecretsSpec restricts the secrets the app reads from its secret stores. The secrets of the stores without scope
+// aren't restricted.
+type SecretsSpec struct {
+	Scopes []SecretsScope `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// SecretsScope restricts the secrets the app reads from a secret store. Denied secrets win over allowed ones.
+type SecretsScope struct {
+	StoreName string `json:"storeName" yaml:"storeName"`
+	// DefaultAccess is allow, by default, or deny, for the secrets neither allowed nor denied
+	DefaultAccess string `json:"defaultAccess,omitempty" yaml:"defaultAccess,omitempty"`
+	// AllowedSecrets are the only secrets allowed when not empty
+	AllowedSecrets []string `json:"allowedSecrets,omitempty" yaml:"allowedSecrets,omitempty"`
+	DeniedSecrets  []string `json:"deniedSecrets,omitempty" yaml:"deniedSecrets,omitempty"`
+}
+
+// NewJSONAPI returns the JSON API configured by the spec
+func NewJSONAPI(spec JSONSpec) jsoniter.API {
+	if spec == (JSONSpec{}) {
+		return jsoniter.ConfigFastest
+	}
+	return jsoniter.Config{
+		EscapeHTML:                    spec.EscapeHTML,
+		UseNumber:                     spec.PreserveInt64,
+		DisallowUnknownFields:         spec.UnknownFields == UnknownFieldsReject,
+		MarshalFloatWith6Digits:       !spec.PreserveInt64,
+		ObjectFieldMustBeSimpleString: true,
+	}.Froze()
+}
+
+// Startup policies control how the runtime reacts when a subsystem fails to initialize
+const (
+	// StartupPolicyRequired fails the runtime startup
+	StartupPolicyRequired = "required"
+	// StartupPolicyBlock retries until the subsystem is initialized before continuing
+	StartupPolicyBlock = "block"
+	// StartupPolicyWarn logs the failure and continues without the subsystem
+	StartupPolicyWarn = "warn"
+	// StartupPolicyRetry continues without the subsystem and retries in the background
+	StartupPolicyRetry = "retry"
+)
+
+// StartupSpec defines the startup policy of the runtime subsystems
+type StartupSpec struct {
+	Placement     string                 `json:"placement,omitempty" yaml:"placement,omitempty"`
+	Operator      string                 `json:"operator,omitempty" yaml:"operator,omitempty"`
+	AppChannel    string                 `json:"appChannel,omitempty" yaml:"appChannel,omitempty"`
+	Components    []ComponentStartupSpec `json:"components,omitempty" yaml:"components,omitempty"`
+	RetryInterval string                 `json:"retryInterval,omitempty" yaml:"retryInterval,omitempty"`
+	// WaitForAppHealth defers subscribing to topics and reading input bindings until the health endpoint of the app
+	// passes, for at most this duration, e.g. 30s. They start right away when empty.
+	WaitForAppHealth string `json:"waitForAppHealth,omitempty" yaml:"waitForAppHealth,omitempty"`
+}
+
+// ComponentStartupSpec defines the startup policy of a single component
+type ComponentStartupSpec struct {
+	Name   string `json:"name" yaml:"name"`
+	Policy string `json:"policy" yaml:"policy"`
+}
+
 // LoadDefaultConfiguration returns the default config with tracing disabled
 func LoadDefaultConfiguration() *Configuration {
 	return &Configuration{
@@ -75,6 +525,12 @@ func LoadDefaultConfiguration() *Configuration {
 
 // LoadStandaloneConfiguration gets the path to a config file and loads it into a configuration
 func LoadStandaloneConfiguration(config string) (*Configuration, error) {
+	return LoadStandaloneProfileConfiguration(config, "")
+}
+
+// LoadStandaloneProfileConfiguration loads a config file with the overrides of a profile layered on it.
+// The overrides are in the profile file of the config file, e.g. config.prod.yaml for config.yaml, and are optional.
+func LoadStandaloneProfileConfiguration(config, profile string) (*Configuration, error) {
 	_, err := os.Stat(config)
 	if err != nil {
 		return nil, err
@@ -85,6 +541,18 @@ func LoadStandaloneConfiguration(config string) (*Configuration, error) {
 		return nil, err
 	}
 
+	if profile != "" {
+		overrides, err := ioutil.ReadFile(ProfileFile(config, profile))
+		switch {
+		case err == nil:
+			if b, err = mergeYAML(b, overrides); err != nil {
+				return nil, fmt.Errorf("error applying the %s profile: %s", profile, err)
+			}
+		case !os.IsNotExist(err):
+			return nil, err
+		}
+	}
+
 	var conf Configuration
 	err = yaml.Unmarshal(b, &conf)
 	if err != nil {
@@ -94,7 +562,8 @@ func LoadStandaloneConfiguration(config string) (*Configuration, error) {
 	return &conf, nil
 }
 
-// LoadKubernetesConfiguration gets configuration from the Kubernetes operator with a given name
+// LoadKubernetesConfiguration gets configuration from the Kubernetes operator with a given name, layered on the
+// cluster default configuration. An empty name gets the default configuration alone, or nil when there is none
 func LoadKubernetesConfiguration(config, namespace string, operatorClient operatorv1pb.OperatorClient) (*Configuration, error) {
 	resp, err := operatorClient.GetConfiguration(context.Background(), &operatorv1pb.GetConfigurationRequest{
 		Name:      config,
@@ -104,6 +573,9 @@ func LoadKubernetesConfiguration(config, namespace string, operatorClient operat
 		return nil, err
 	}
 	if resp.Configuration == nil {
+		if config == "" {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("configuration %s not found", config)
 	}
 	var conf Configuration
@@ -0,0 +1,100 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package config
+
+import (
+	"encoding/json"
+)
+
+// keyedSequences are the sequences of the spec whose entries are merged by key rather than replaced
+var keyedSequences = map[string]string{
+	"httpPipeline.handlers":  "name",
+	"actorPipeline.handlers": "name",
+	"secrets.scopes":         "storeName",
+	"bulkheads":              "buildingBlock",
+}
+
+// MergeConfigurationJSON layers the spec of an app configuration on the spec of the cluster default configuration,
+// both as JSON documents. The app wins: mappings are merged key by key and other values are replaced, except the
+// pipeline handlers, secret scopes and bulkheads whose entries are merged by key, keeping the order of the defaults
+// and appending the app entries without a default. The other fields, e.g. metadata, are the ones of the app.
+// Since the fields of the Configuration resource are serialized even when unset, null, empty, false and zero app
+// values don't override the defaults: an app can't switch off e.g. mTLS enabled by the default configuration.
+func MergeConfigurationJSON(defaults, overrides []byte) ([]byte, error) {
+	var d, o map[string]interface{}
+	if err := json.Unmarshal(defaults, &d); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(overrides, &o); err != nil {
+		return nil, err
+	}
+	defaultSpec, _ := d["spec"].(map[string]interface{})
+	overrideSpec, _ := o["spec"].(map[string]interface{})
+	o["spec"] = mergeJSONMappings(defaultSpec, overrideSpec, "")
+	return json.Marshal(o)
+}
+
+func mergeJSONMappings(base, overrides map[string]interface{}, path string) map[string]interface{} {
+	if base == nil {
+		base = map[string]interface{}{}
+	}
+	for k, v := range overrides {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		switch override := v.(type) {
+		case nil:
+			continue
+		case string:
+			if override == "" {
+				continue
+			}
+		case bool:
+			if !override {
+				continue
+			}
+		case float64:
+			if override == 0 {
+				continue
+			}
+		case map[string]interface{}:
+			if baseMapping, ok := base[k].(map[string]interface{}); ok {
+				base[k] = mergeJSONMappings(baseMapping, override, p)
+				continue
+			}
+		case []interface{}:
+			if key, ok := keyedSequences[p]; ok {
+				if baseSequence, ok := base[k].([]interface{}); ok {
+					base[k] = mergeKeyedSequences(baseSequence, override, key)
+					continue
+				}
+			}
+		}
+		base[k] = v
+	}
+	return base
+}
+
+func mergeKeyedSequences(base, overrides []interface{}, key string) []interface{} {
+	merged := append([]interface{}{}, base...)
+	index := map[interface{}]int{}
+	for i, e := range merged {
+		if m, ok := e.(map[string]interface{}); ok {
+			index[m[key]] = i
+		}
+	}
+	for _, e := range overrides {
+		if m, ok := e.(map[string]interface{}); ok {
+			if i, ok := index[m[key]]; ok {
+				merged[i] = e
+				continue
+			}
+		}
+		merged = append(merged, e)
+	}
+	return merged
+}
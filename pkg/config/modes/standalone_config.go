@@ -8,4 +8,21 @@ package config
 // StandaloneConfig is the configuration for standalone mode
 type StandaloneConfig struct {
 	ComponentsPath string
+	// Profile selects the overrides layered on the components and the configuration, e.g. prod. No overrides are
+	// applied when empty.
+	Profile string
+	// ConfigService is the remote config service the configuration and the components are fetched from instead of
+	// the local files, when its address is set
+	ConfigService ConfigServiceConfig
+}
+
+// ConfigServiceConfig is the configuration of the remote config service of a standalone sidecar
+type ConfigServiceConfig struct {
+	// Address is the URL of the config service, http(s)://host:port/path, or its gRPC address, grpc(s)://host:port
+	Address string
+	// Token is sent as bearer token to the config service
+	Token string
+	// CacheDir is the dir of the last configuration and components fetched, used when the config service can't be
+	// reached. Nothing is cached when empty.
+	CacheDir string
 }
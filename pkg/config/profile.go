@@ -0,0 +1,48 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ProfileFile returns the file of the overrides of a profile for a file, e.g. config.prod.yaml for config.yaml
+func ProfileFile(file, profile string) string {
+	ext := filepath.Ext(file)
+	return strings.TrimSuffix(file, ext) + "." + profile + ext
+}
+
+// mergeYAML layers the overrides document on the base document. Mappings are merged key by key, while other values,
+// including sequences, are replaced.
+func mergeYAML(base, overrides []byte) ([]byte, error) {
+	var b, o map[interface{}]interface{}
+	if err := yaml.Unmarshal(base, &b); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(overrides, &o); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(mergeMappings(b, o))
+}
+
+func mergeMappings(base, overrides map[interface{}]interface{}) map[interface{}]interface{} {
+	if base == nil {
+		base = map[interface{}]interface{}{}
+	}
+	for k, v := range overrides {
+		baseMapping, ok := base[k].(map[interface{}]interface{})
+		overrideMapping, isMapping := v.(map[interface{}]interface{})
+		if ok && isMapping {
+			base[k] = mergeMappings(baseMapping, overrideMapping)
+		} else {
+			base[k] = v
+		}
+	}
+	return base
+}
@@ -0,0 +1,253 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	httpMiddlewarePrefix  = "middleware.http."
+	actorMiddlewarePrefix = "middleware.actor."
+)
+
+// Validate returns the problems of the configuration that fail or degrade the sidecars using it
+func (c *Configuration) Validate() []string {
+	problems := []string{}
+	spec := c.Spec
+
+	if rate := spec.TracingSpec.SamplingRate; rate != "" {
+		if r, err := strconv.ParseFloat(rate, 64); err != nil || r < 0 || r > 1 {
+			problems = append(problems, fmt.Sprintf("tracing.samplingRate %s is not a number between 0 and 1", rate))
+		}
+	}
+
+	for i, h := range spec.HTTPPipelineSpec.Handlers {
+		if h.Name == "" {
+			problems = append(problems, fmt.Sprintf("httpPipeline.handlers[%d] has no name", i))
+		}
+		if !strings.HasPrefix(h.Type, httpMiddlewarePrefix) {
+			problems = append(problems, fmt.Sprintf("httpPipeline.handlers[%d].type %s is not an HTTP middleware", i, h.Type))
+		}
+	}
+	for i, h := range spec.ActorPipelineSpec.Handlers {
+		if h.Name == "" {
+			problems = append(problems, fmt.Sprintf("actorPipeline.handlers[%d] has no name", i))
+		}
+		if !strings.HasPrefix(h.Type, actorMiddlewarePrefix) {
+			problems = append(problems, fmt.Sprintf("actorPipeline.handlers[%d].type %s is not an actor middleware", i, h.Type))
+		}
+	}
+
+	problems = appendDurationProblem(problems, "mtls.workloadCertTTL", spec.MTLSSpec.WorkloadCertTTL)
+	problems = appendDurationProblem(problems, "mtls.allowedClockSkew", spec.MTLSSpec.AllowedClockSkew)
+	problems = appendDurationProblem(problems, "actorTurns.slowTurnThreshold", spec.ActorTurnsSpec.SlowTurnThreshold)
+	for i, r := range spec.ActorStateSpec.StaleReads {
+		if r.ActorType == "" {
+			problems = append(problems, fmt.Sprintf("actorState.staleReads[%d] has no actor type", i))
+		}
+		if d, err := time.ParseDuration(r.MaxStaleness); err != nil || d <= 0 {
+			problems = append(problems, fmt.Sprintf("actorState.staleReads[%d].maxStaleness %s is not a positive duration", i, r.MaxStaleness))
+		}
+	}
+	problems = appendDurationProblem(problems, "pubsub.dualRead.deduplicationWindow", spec.PubSubSpec.DualRead.DeduplicationWindow)
+	problems = appendDurationProblem(problems, "pubsub.exactlyOnce.window", spec.PubSubSpec.ExactlyOnce.Window)
+	problems = appendDurationProblem(problems, "pubsub.maxRetryAfter", spec.PubSubSpec.MaxRetryAfter)
+	problems = appendDurationProblem(problems, "startup.retryInterval", spec.StartupSpec.RetryInterval)
+	problems = appendDurationProblem(problems, "startup.waitForAppHealth", spec.StartupSpec.WaitForAppHealth)
+	problems = appendDurationProblem(problems, "grpcServer.drainGracePeriod", spec.GRPCServerSpec.DrainGracePeriod)
+	if c := spec.GRPCServerSpec.CallLocal; c.MaxConcurrency < 0 || c.MaxConcurrencyPerPeer < 0 || c.MaxQueuedPerPeer < 0 {
+		problems = append(problems, "grpcServer.callLocal limits are negative")
+	}
+	problems = appendQuantityProblem(problems, "grpcServer.api.maxMessageSize", spec.GRPCServerSpec.API.MaxMessageSize)
+	problems = appendQuantityProblem(problems, "grpcServer.internal.maxMessageSize", spec.GRPCServerSpec.Internal.MaxMessageSize)
+	if spec.GRPCServerSpec.InvokePayload.MaxDepth < 0 {
+		problems = append(problems, "grpcServer.invokePayload.maxDepth is negative")
+	}
+	problems = appendQuantityProblem(problems, "grpcServer.invokePayload.maxSize", spec.GRPCServerSpec.InvokePayload.MaxSize)
+	problems = appendDurationProblem(problems, "grpcClient.dialTimeout", spec.GRPCClientSpec.DialTimeout)
+	problems = appendDurationProblem(problems, "grpcClient.handshakeTimeout", spec.GRPCClientSpec.HandshakeTimeout)
+	problems = appendDurationProblem(problems, "grpcClient.blacklistDuration", spec.GRPCClientSpec.BlacklistDuration)
+
+	problems = appendStartupPolicyProblem(problems, "startup.placement", spec.StartupSpec.Placement)
+	problems = appendStartupPolicyProblem(problems, "startup.operator", spec.StartupSpec.Operator)
+	problems = appendStartupPolicyProblem(problems, "startup.appChannel", spec.StartupSpec.AppChannel)
+	for i, s := range spec.StartupSpec.Components {
+		if s.Name == "" {
+			problems = append(problems, fmt.Sprintf("startup.components[%d] has no name", i))
+		}
+		problems = appendStartupPolicyProblem(problems, fmt.Sprintf("startup.components[%d].policy", i), s.Policy)
+	}
+
+	if p := spec.DataResidencySpec.Policy; p != "" && p != ResidencyPolicyReject && p != ResidencyPolicyWarn {
+		problems = append(problems, fmt.Sprintf("dataResidency.policy %s is not %s or %s", p, ResidencyPolicyReject, ResidencyPolicyWarn))
+	}
+
+	for i, f := range spec.PubSubSpec.FanOut {
+		if f.Topic == "" || len(f.Targets) == 0 {
+			problems = append(problems, fmt.Sprintf("pubsub.fanOut[%d] needs a topic and targets", i))
+		}
+	}
+
+	for i, t := range spec.PubSubSpec.Transforms {
+		if t.Topic == "" || t.Template == "" {
+			problems = append(problems, fmt.Sprintf("pubsub.transforms[%d] needs a topic and a template", i))
+		}
+		if t.Stage != TransformStagePublish && t.Stage != TransformStageDeliver {
+			problems = append(problems, fmt.Sprintf("pubsub.transforms[%d].stage %s is not %s or %s", i, t.Stage, TransformStagePublish, TransformStageDeliver))
+		}
+	}
+
+	for i, p := range spec.InvocationSpec.PriorityClasses {
+		if p.MaxConcurrency < 0 {
+			problems = append(problems, fmt.Sprintf("serviceInvocation.priorityClasses[%d].maxConcurrency is negative", i))
+		}
+	}
+
+	if c := spec.InvocationSpec.AdaptiveConcurrency; c.Algorithm != "" {
+		field := "serviceInvocation.adaptiveConcurrency"
+		if c.Algorithm != AdaptiveConcurrencyAIMD && c.Algorithm != AdaptiveConcurrencyGradient {
+			problems = append(problems, fmt.Sprintf("%s.algorithm %s is not %s or %s", field, c.Algorithm, AdaptiveConcurrencyAIMD, AdaptiveConcurrencyGradient))
+		}
+		if c.InitialLimit < 0 || c.MinLimit < 0 || c.MaxLimit < 0 {
+			problems = append(problems, fmt.Sprintf("%s has a negative limit", field))
+		}
+		if c.MaxLimit > 0 && c.MinLimit > c.MaxLimit {
+			problems = append(problems, fmt.Sprintf("%s.minLimit is above its maxLimit", field))
+		}
+		if c.BackoffPercent < 0 || c.BackoffPercent >= 100 {
+			problems = append(problems, fmt.Sprintf("%s.backoffPercent %d is not a percentage below 100", field, c.BackoffPercent))
+		}
+		problems = appendDurationProblem(problems, field+".latencyThreshold", c.LatencyThreshold)
+	}
+
+	for i, m := range spec.InvocationSpec.HTTPMappings {
+		field := fmt.Sprintf("serviceInvocation.httpMappings[%d]", i)
+		if m.Method == "" || !strings.HasPrefix(m.Path, "/") {
+			problems = append(problems, fmt.Sprintf("%s needs a method and a path starting with /", field))
+		}
+		switch strings.ToUpper(m.Verb) {
+		case "GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE":
+		default:
+			problems = append(problems, fmt.Sprintf("%s.verb %s is not an HTTP verb", field, m.Verb))
+		}
+	}
+
+	for i, p := range spec.ActorResiliency.Policies {
+		field := fmt.Sprintf("actorResiliency.policies[%d]", i)
+		if p.ActorType == "" {
+			problems = append(problems, fmt.Sprintf("%s has no actorType", field))
+		}
+		if _, err := path.Match(p.Method, ""); err != nil {
+			problems = append(problems, fmt.Sprintf("%s.method %s is not a glob pattern", field, p.Method))
+		}
+		if p.MaxRetries < 0 {
+			problems = append(problems, fmt.Sprintf("%s.maxRetries is negative", field))
+		}
+		problems = appendDurationProblem(problems, field+".timeout", p.Timeout)
+		problems = appendDurationProblem(problems, field+".retryInterval", p.RetryInterval)
+		if cb := p.CircuitBreaker; cb != nil {
+			if cb.ConsecutiveFailures <= 0 {
+				problems = append(problems, fmt.Sprintf("%s.circuitBreaker.consecutiveFailures is not positive", field))
+			}
+			problems = appendDurationProblem(problems, field+".circuitBreaker.openDuration", cb.OpenDuration)
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, b := range spec.Bulkheads {
+		field := fmt.Sprintf("bulkheads[%d]", i)
+		switch b.BuildingBlock {
+		case BulkheadState, BulkheadPubSub, BulkheadBindings, BulkheadInvocation:
+		default:
+			problems = append(problems, fmt.Sprintf("%s.buildingBlock %s is not %s, %s, %s or %s", field, b.BuildingBlock, BulkheadState, BulkheadPubSub, BulkheadBindings, BulkheadInvocation))
+		}
+		if seen[b.BuildingBlock] {
+			problems = append(problems, fmt.Sprintf("%s.buildingBlock %s has several bulkheads", field, b.BuildingBlock))
+		}
+		seen[b.BuildingBlock] = true
+		if b.MaxConcurrency <= 0 {
+			problems = append(problems, fmt.Sprintf("%s.maxConcurrency is not positive", field))
+		}
+		if b.MaxQueued < 0 {
+			problems = append(problems, fmt.Sprintf("%s.maxQueued is negative", field))
+		}
+		problems = appendDurationProblem(problems, field+".queueTimeout", b.QueueTimeout)
+	}
+
+	if p := spec.JSONSpec.UnknownFields; p != "" && p != UnknownFieldsIgnore && p != UnknownFieldsReject {
+		problems = append(problems, fmt.Sprintf("json.unknownFields %s is not %s or %s", p, UnknownFieldsIgnore, UnknownFieldsReject))
+	}
+
+	m := spec.MemoryBudgetSpec
+	problems = appendQuantityProblem(problems, "memoryBudget.limit", m.Limit)
+	problems = appendQuantityProblem(problems, "memoryBudget.maxPayloadSize", m.MaxPayloadSize)
+	problems = appendDurationProblem(problems, "memoryBudget.checkInterval", m.CheckInterval)
+	if m.PressurePercent < 0 || m.PressurePercent > 100 {
+		problems = append(problems, fmt.Sprintf("memoryBudget.pressurePercent %d is not a percentage", m.PressurePercent))
+	}
+	if m.CriticalPercent < 0 || m.CriticalPercent > 100 {
+		problems = append(problems, fmt.Sprintf("memoryBudget.criticalPercent %d is not a percentage", m.CriticalPercent))
+	}
+	if m.PressurePercent > 0 && m.CriticalPercent > 0 && m.PressurePercent >= m.CriticalPercent {
+		problems = append(problems, "memoryBudget.pressurePercent is not below memoryBudget.criticalPercent")
+	}
+
+	for i, c := range spec.EgressSpec.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(c); err != nil {
+			problems = append(problems, fmt.Sprintf("egress.allowedCIDRs[%d] %s is not a cidr", i, c))
+		}
+	}
+	scopedStores := map[string]bool{}
+	for i, s := range spec.SecretsSpec.Scopes {
+		switch {
+		case s.StoreName == "":
+			problems = append(problems, fmt.Sprintf("secrets.scopes[%d] has no store name", i))
+		case scopedStores[s.StoreName]:
+			problems = append(problems, fmt.Sprintf("secrets.scopes[%d] store %s has several scopes", i, s.StoreName))
+		}
+		scopedStores[s.StoreName] = true
+		if s.DefaultAccess != "" && s.DefaultAccess != AllowAccess && s.DefaultAccess != DenyAccess {
+			problems = append(problems, fmt.Sprintf("secrets.scopes[%d] defaultAccess %s is not allow or deny", i, s.DefaultAccess))
+		}
+	}
+	return problems
+}
+
+func appendDurationProblem(problems []string, field, value string) []string {
+	if value == "" {
+		return problems
+	}
+	if _, err := time.ParseDuration(value); err != nil {
+		return append(problems, fmt.Sprintf("%s %s is not a duration", field, value))
+	}
+	return problems
+}
+
+func appendQuantityProblem(problems []string, field, value string) []string {
+	if value == "" {
+		return problems
+	}
+	if q, err := resource.ParseQuantity(value); err != nil || q.Sign() <= 0 {
+		return append(problems, fmt.Sprintf("%s %s is not a positive quantity", field, value))
+	}
+	return problems
+}
+
+func appendStartupPolicyProblem(problems []string, field, value string) []string {
+	switch value {
+	case "", StartupPolicyRequired, StartupPolicyBlock, StartupPolicyWarn, StartupPolicyRetry:
+		return problems
+	}
+	return append(problems, fmt.Sprintf("%s %s is not a startup policy", field, value))
+}
@@ -0,0 +1,63 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/google/uuid"
+)
+
+// CheckBindings runs the write and round trip checks against an output binding and, if given, the matching input binding.
+// Either binding may be nil when the component only supports one direction.
+func CheckBindings(name string, output bindings.OutputBinding, input bindings.InputBinding, timeout time.Duration, report *Report) {
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	payload := []byte(fmt.Sprintf("dapr-conformance-%s", uuid.New().String()))
+
+	var received chan []byte
+	if input != nil {
+		received = make(chan []byte, 1)
+		go input.Read(func(resp *bindings.ReadResponse) error {
+			if resp != nil && string(resp.Data) == string(payload) {
+				select {
+				case received <- resp.Data:
+				default:
+				}
+			}
+			return nil
+		})
+	}
+
+	report.run(name, "write", func() (Status, string) {
+		if output == nil {
+			return StatusSkipped, "component is not an output binding"
+		}
+		err := output.Write(&bindings.WriteRequest{
+			Data:     payload,
+			Metadata: map[string]string{},
+		})
+		if err != nil {
+			return StatusFailed, fmt.Sprintf("write failed: %s", err)
+		}
+		return StatusPassed, ""
+	})
+
+	report.run(name, "round-trip", func() (Status, string) {
+		if output == nil || input == nil {
+			return StatusSkipped, "component must be both an input and an output binding"
+		}
+		select {
+		case <-received:
+			return StatusPassed, ""
+		case <-time.After(timeout):
+			return StatusFailed, fmt.Sprintf("written data was not read back within %v", timeout)
+		}
+	})
+}
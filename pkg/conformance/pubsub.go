@@ -0,0 +1,159 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMessageCount = 10
+	defaultWaitTimeout  = time.Second * 10
+)
+
+// PubSubOptions controls the behavior of the pub/sub checks
+type PubSubOptions struct {
+	// MessageCount is the number of messages published in the delivery and ordering checks
+	MessageCount int
+	// Timeout is the maximum time to wait for messages to be delivered
+	Timeout time.Duration
+}
+
+// DefaultPubSubOptions returns the default options for the pub/sub checks
+func DefaultPubSubOptions() PubSubOptions {
+	return PubSubOptions{
+		MessageCount: defaultMessageCount,
+		Timeout:      defaultWaitTimeout,
+	}
+}
+
+// CheckPubSub runs the delivery, ordering and redelivery checks against a pub/sub component and records the results in report
+func CheckPubSub(name string, ps pubsub.PubSub, opts PubSubOptions, report *Report) {
+	if opts.MessageCount <= 0 {
+		opts.MessageCount = defaultMessageCount
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultWaitTimeout
+	}
+
+	prefix := fmt.Sprintf("dapr-conformance-%s", uuid.New().String())
+	var received []string
+
+	report.run(name, "delivery", func() (Status, string) {
+		var err error
+		received, err = publishAndReceive(ps, prefix+"-order", opts)
+		if err != nil {
+			return StatusFailed, err.Error()
+		}
+		return StatusPassed, ""
+	})
+
+	report.run(name, "order", func() (Status, string) {
+		if len(received) != opts.MessageCount {
+			return StatusSkipped, "not all messages were delivered"
+		}
+		for i, m := range received {
+			if m != fmt.Sprintf("message-%d", i) {
+				return StatusFailed, fmt.Sprintf("message %d delivered out of order: got %s", i, m)
+			}
+		}
+		return StatusPassed, ""
+	})
+
+	report.run(name, "redelivery", func() (Status, string) {
+		return checkRedelivery(ps, prefix+"-redelivery", opts.Timeout)
+	})
+}
+
+// publishAndReceive publishes a sequence of messages to topic and returns them in the order they were delivered
+func publishAndReceive(ps pubsub.PubSub, topic string, opts PubSubOptions) ([]string, error) {
+	var lock sync.Mutex
+	received := []string{}
+	done := make(chan struct{})
+
+	err := ps.Subscribe(pubsub.SubscribeRequest{Topic: topic}, func(msg *pubsub.NewMessage) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		received = append(received, string(msg.Data))
+		if len(received) == opts.MessageCount {
+			close(done)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("subscribe failed: %s", err)
+	}
+
+	for i := 0; i < opts.MessageCount; i++ {
+		err := ps.Publish(&pubsub.PublishRequest{
+			Topic: topic,
+			Data:  []byte(fmt.Sprintf("message-%d", i)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("publish failed: %s", err)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(opts.Timeout):
+		lock.Lock()
+		defer lock.Unlock()
+		return nil, fmt.Errorf("received %d of %d messages within %v", len(received), opts.MessageCount, opts.Timeout)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	return append([]string{}, received...), nil
+}
+
+// checkRedelivery rejects the first delivery of a message and expects the component to deliver it again
+func checkRedelivery(ps pubsub.PubSub, topic string, timeout time.Duration) (Status, string) {
+	var lock sync.Mutex
+	attempts := 0
+	done := make(chan struct{})
+
+	err := ps.Subscribe(pubsub.SubscribeRequest{Topic: topic}, func(msg *pubsub.NewMessage) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		attempts++
+		if attempts == 1 {
+			return errors.New("conformance: rejecting first delivery")
+		}
+		if attempts == 2 {
+			close(done)
+		}
+		return nil
+	})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("subscribe failed: %s", err)
+	}
+
+	err = ps.Publish(&pubsub.PublishRequest{
+		Topic: topic,
+		Data:  []byte("redeliver-me"),
+	})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("publish failed: %s", err)
+	}
+
+	select {
+	case <-done:
+		return StatusPassed, ""
+	case <-time.After(timeout):
+		lock.Lock()
+		defer lock.Unlock()
+		return StatusFailed, fmt.Sprintf("message was delivered %d times within %v after being rejected", attempts, timeout)
+	}
+}
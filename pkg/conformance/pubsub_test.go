@@ -0,0 +1,78 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePubSub delivers messages synchronously and redelivers them once if the handler fails
+type fakePubSub struct {
+	lock      sync.Mutex
+	handlers  map[string]func(msg *pubsub.NewMessage) error
+	redeliver bool
+}
+
+func (f *fakePubSub) Init(metadata pubsub.Metadata) error {
+	return nil
+}
+
+func (f *fakePubSub) Publish(req *pubsub.PublishRequest) error {
+	f.lock.Lock()
+	handler := f.handlers[req.Topic]
+	f.lock.Unlock()
+
+	if handler == nil {
+		return nil
+	}
+	msg := &pubsub.NewMessage{Topic: req.Topic, Data: req.Data}
+	if err := handler(msg); err != nil && f.redeliver {
+		handler(msg)
+	}
+	return nil
+}
+
+func (f *fakePubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.handlers[req.Topic] = handler
+	return nil
+}
+
+func TestCheckPubSub(t *testing.T) {
+	opts := PubSubOptions{MessageCount: 5, Timeout: time.Millisecond * 100}
+
+	t.Run("compliant pubsub passes", func(t *testing.T) {
+		ps := &fakePubSub{handlers: map[string]func(msg *pubsub.NewMessage) error{}, redeliver: true}
+		report := &Report{}
+
+		CheckPubSub("fake", ps, opts, report)
+
+		results := resultsByCheck(report)
+		assert.False(t, report.Failed())
+		assert.Equal(t, StatusPassed, results["delivery"].Status)
+		assert.Equal(t, StatusPassed, results["order"].Status)
+		assert.Equal(t, StatusPassed, results["redelivery"].Status)
+	})
+
+	t.Run("missing redelivery fails", func(t *testing.T) {
+		ps := &fakePubSub{handlers: map[string]func(msg *pubsub.NewMessage) error{}}
+		report := &Report{}
+
+		CheckPubSub("fake", ps, opts, report)
+
+		results := resultsByCheck(report)
+		assert.True(t, report.Failed())
+		assert.Equal(t, StatusPassed, results["delivery"].Status)
+		assert.Equal(t, StatusFailed, results["redelivery"].Status)
+	})
+}
@@ -0,0 +1,193 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Status is the outcome of a single conformance check
+type Status string
+
+const (
+	// StatusPassed means the component behaved as expected
+	StatusPassed Status = "passed"
+	// StatusFailed means a violation was detected
+	StatusFailed Status = "failed"
+	// StatusSkipped means the component does not support the checked feature
+	StatusSkipped Status = "skipped"
+)
+
+const (
+	// FormatText writes the report as human readable text
+	FormatText = "text"
+	// FormatJSON writes the report as JSON
+	FormatJSON = "json"
+	// FormatJUnit writes the report as JUnit XML
+	FormatJUnit = "junit"
+)
+
+// Result holds the outcome of a single check against a component
+type Result struct {
+	Component string        `json:"component"`
+	Check     string        `json:"check"`
+	Status    Status        `json:"status"`
+	Message   string        `json:"message,omitempty"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Report is a collection of check results
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Add appends a result to the report
+func (r *Report) Add(result Result) {
+	r.Results = append(r.Results, result)
+}
+
+// Failed returns true if any of the checks in the report failed
+func (r *Report) Failed() bool {
+	for _, res := range r.Results {
+		if res.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes the report to w in the given format
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatText, "":
+		r.WriteText(w)
+		return nil
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatJUnit:
+		return r.WriteJUnit(w)
+	default:
+		return fmt.Errorf("unknown report format %s", format)
+	}
+}
+
+// WriteJSON writes the report as JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the report as JUnit XML, with one test suite per component
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suites := junitTestSuites{}
+	index := map[string]int{}
+	durations := map[string]time.Duration{}
+
+	for _, res := range r.Results {
+		i, ok := index[res.Component]
+		if !ok {
+			i = len(suites.Suites)
+			index[res.Component] = i
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: res.Component})
+		}
+
+		suite := &suites.Suites[i]
+		tc := junitTestCase{
+			Name:      res.Check,
+			ClassName: res.Component,
+			Time:      fmt.Sprintf("%.3f", res.Duration.Seconds()),
+		}
+		switch res.Status {
+		case StatusFailed:
+			tc.Failure = &junitMessage{Message: res.Message}
+			suite.Failures++
+		case StatusSkipped:
+			tc.Skipped = &junitMessage{Message: res.Message}
+			suite.Skipped++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, tc)
+		durations[res.Component] += res.Duration
+		suite.Time = fmt.Sprintf("%.3f", durations[res.Component].Seconds())
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteText writes a human readable summary of the report
+func (r *Report) WriteText(w io.Writer) {
+	passed, failed, skipped := 0, 0, 0
+	for _, res := range r.Results {
+		switch res.Status {
+		case StatusPassed:
+			passed++
+		case StatusFailed:
+			failed++
+		case StatusSkipped:
+			skipped++
+		}
+
+		line := fmt.Sprintf("%-7s %s/%s (%v)", res.Status, res.Component, res.Check, res.Duration)
+		if res.Message != "" {
+			line = fmt.Sprintf("%s: %s", line, res.Message)
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "%d passed, %d failed, %d skipped\n", passed, failed, skipped)
+}
+
+// run executes a check and records its result in the report
+func (r *Report) run(component, check string, fn func() (Status, string)) {
+	start := time.Now()
+	status, msg := fn()
+	r.Add(Result{
+		Component: component,
+		Check:     check,
+		Status:    status,
+		Message:   msg,
+		Duration:  time.Since(start),
+	})
+}
@@ -0,0 +1,60 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testReport() *Report {
+	return &Report{
+		Results: []Result{
+			{Component: "statestore", Check: "crud", Status: StatusPassed},
+			{Component: "statestore", Check: "etag-race", Status: StatusFailed, Message: "two writers won"},
+			{Component: "statestore", Check: "transaction", Status: StatusSkipped, Message: "store is not transactional"},
+		},
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := testReport().Write(&buf, FormatJSON)
+		assert.NoError(t, err)
+
+		var r Report
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &r))
+		assert.Len(t, r.Results, 3)
+		assert.Equal(t, StatusFailed, r.Results[1].Status)
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := testReport().Write(&buf, FormatJUnit)
+		assert.NoError(t, err)
+
+		out := buf.String()
+		assert.True(t, strings.Contains(out, `<testsuite name="statestore" tests="3" failures="1" skipped="1"`))
+		assert.True(t, strings.Contains(out, `<failure message="two writers won"></failure>`))
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := testReport().Write(&buf, FormatText)
+		assert.NoError(t, err)
+		assert.True(t, strings.Contains(buf.String(), "1 passed, 1 failed, 1 skipped"))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := testReport().Write(&bytes.Buffer{}, "yaml")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,421 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/google/uuid"
+)
+
+const (
+	// TTLMetadataKey is the request metadata key used to ask a state store to expire a key
+	TTLMetadataKey = "ttlInSeconds"
+
+	defaultRaceWriters = 10
+	defaultSequence    = 20
+)
+
+// StateOptions controls the behavior of the state store checks
+type StateOptions struct {
+	// RaceWriters is the number of concurrent writers used in the etag race checks
+	RaceWriters int
+	// Sequence is the number of sequential writes used in the linearizability check
+	Sequence int
+	// TTL enables the time to live check. It is disabled by default since it waits for keys to expire
+	TTL bool
+}
+
+// DefaultStateOptions returns the default options for the state store checks
+func DefaultStateOptions() StateOptions {
+	return StateOptions{
+		RaceWriters: defaultRaceWriters,
+		Sequence:    defaultSequence,
+	}
+}
+
+type stateChecker struct {
+	name    string
+	store   state.Store
+	opts    StateOptions
+	prefix  string
+	written []string
+}
+
+// CheckStateStore runs the contention and consistency checks against a state store and records the results in report
+func CheckStateStore(name string, store state.Store, opts StateOptions, report *Report) {
+	if opts.RaceWriters <= 1 {
+		opts.RaceWriters = defaultRaceWriters
+	}
+	if opts.Sequence <= 0 {
+		opts.Sequence = defaultSequence
+	}
+
+	c := &stateChecker{
+		name:   name,
+		store:  store,
+		opts:   opts,
+		prefix: fmt.Sprintf("dapr-conformance-%s", uuid.New().String()),
+	}
+	defer c.cleanup()
+
+	report.run(name, "crud", c.checkCRUD)
+	report.run(name, "etag-race", c.checkETagRace)
+	report.run(name, "stale-etag", c.checkStaleETag)
+	report.run(name, "linearizability", c.checkLinearizability)
+	report.run(name, "concurrent-writes", c.checkConcurrentWrites)
+	report.run(name, "transaction", c.checkTransaction)
+	if opts.TTL {
+		report.run(name, "ttl", c.checkTTL)
+	}
+}
+
+func (c *stateChecker) key(suffix string) string {
+	k := fmt.Sprintf("%s-%s", c.prefix, suffix)
+	c.written = append(c.written, k)
+	return k
+}
+
+func (c *stateChecker) cleanup() {
+	for _, k := range c.written {
+		c.store.Delete(&state.DeleteRequest{Key: k})
+	}
+}
+
+func (c *stateChecker) get(key string) (*state.GetResponse, error) {
+	return c.store.Get(&state.GetRequest{
+		Key: key,
+		Options: state.GetStateOption{
+			Consistency: "strong",
+		},
+	})
+}
+
+func (c *stateChecker) checkCRUD() (Status, string) {
+	key := c.key("crud")
+
+	err := c.store.Set(&state.SetRequest{Key: key, Value: "v1"})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("set failed: %s", err)
+	}
+
+	resp, err := c.get(key)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	if !valueMatches(resp, "v1") {
+		return StatusFailed, fmt.Sprintf("expected value v1, got %q", respData(resp))
+	}
+
+	err = c.store.Delete(&state.DeleteRequest{Key: key})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("delete failed: %s", err)
+	}
+
+	resp, err = c.get(key)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get after delete failed: %s", err)
+	}
+	if resp != nil && len(resp.Data) > 0 {
+		return StatusFailed, fmt.Sprintf("key still present after delete: %q", resp.Data)
+	}
+	return StatusPassed, ""
+}
+
+// checkETagRace issues concurrent first-write updates using the same etag. Exactly one of them must win.
+func (c *stateChecker) checkETagRace() (Status, string) {
+	key := c.key("etag-race")
+
+	err := c.store.Set(&state.SetRequest{Key: key, Value: "initial"})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("set failed: %s", err)
+	}
+	resp, err := c.get(key)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	if resp == nil || resp.ETag == "" {
+		return StatusSkipped, "store does not return etags"
+	}
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	winners := []string{}
+
+	for i := 0; i < c.opts.RaceWriters; i++ {
+		wg.Add(1)
+		go func(value string) {
+			defer wg.Done()
+			err := c.store.Set(&state.SetRequest{
+				Key:   key,
+				Value: value,
+				ETag:  resp.ETag,
+				Options: state.SetStateOption{
+					Concurrency: state.FirstWrite,
+				},
+			})
+			if err == nil {
+				lock.Lock()
+				winners = append(winners, value)
+				lock.Unlock()
+			}
+		}(fmt.Sprintf("writer-%d", i))
+	}
+	wg.Wait()
+
+	if len(winners) != 1 {
+		return StatusFailed, fmt.Sprintf("expected exactly one successful write with etag %s, got %d", resp.ETag, len(winners))
+	}
+
+	final, err := c.get(key)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	if !valueMatches(final, winners[0]) {
+		return StatusFailed, fmt.Sprintf("winning write %s was not persisted, got %q", winners[0], respData(final))
+	}
+	return StatusPassed, ""
+}
+
+// checkStaleETag verifies that writes and deletes with an outdated etag are rejected
+func (c *stateChecker) checkStaleETag() (Status, string) {
+	key := c.key("stale-etag")
+
+	err := c.store.Set(&state.SetRequest{Key: key, Value: "v1"})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("set failed: %s", err)
+	}
+	first, err := c.get(key)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	if first == nil || first.ETag == "" {
+		return StatusSkipped, "store does not return etags"
+	}
+
+	err = c.store.Set(&state.SetRequest{Key: key, Value: "v2"})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("set failed: %s", err)
+	}
+
+	err = c.store.Set(&state.SetRequest{
+		Key:   key,
+		Value: "v3",
+		ETag:  first.ETag,
+		Options: state.SetStateOption{
+			Concurrency: state.FirstWrite,
+		},
+	})
+	if err == nil {
+		return StatusFailed, "set with stale etag succeeded"
+	}
+
+	err = c.store.Delete(&state.DeleteRequest{
+		Key:  key,
+		ETag: first.ETag,
+		Options: state.DeleteStateOption{
+			Concurrency: state.FirstWrite,
+		},
+	})
+	if err == nil {
+		return StatusFailed, "delete with stale etag succeeded"
+	}
+
+	resp, err := c.get(key)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	if !valueMatches(resp, "v2") {
+		return StatusFailed, fmt.Sprintf("expected value v2, got %q", respData(resp))
+	}
+	return StatusPassed, ""
+}
+
+// checkLinearizability verifies that every read observes the latest completed write
+func (c *stateChecker) checkLinearizability() (Status, string) {
+	key := c.key("linearizability")
+	lastETag := ""
+
+	for i := 0; i < c.opts.Sequence; i++ {
+		value := fmt.Sprintf("v%d", i)
+		err := c.store.Set(&state.SetRequest{Key: key, Value: value})
+		if err != nil {
+			return StatusFailed, fmt.Sprintf("set %s failed: %s", value, err)
+		}
+
+		resp, err := c.get(key)
+		if err != nil {
+			return StatusFailed, fmt.Sprintf("get failed: %s", err)
+		}
+		if !valueMatches(resp, value) {
+			return StatusFailed, fmt.Sprintf("stale read after write %d: expected %s, got %q", i, value, respData(resp))
+		}
+		if resp.ETag != "" && resp.ETag == lastETag {
+			return StatusFailed, fmt.Sprintf("etag %s was not changed by write %d", resp.ETag, i)
+		}
+		lastETag = resp.ETag
+	}
+	return StatusPassed, ""
+}
+
+// checkConcurrentWrites verifies that concurrent last-write updates converge to one of the written values
+func (c *stateChecker) checkConcurrentWrites() (Status, string) {
+	key := c.key("concurrent-writes")
+	values := map[string]bool{}
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var errs []error
+
+	for i := 0; i < c.opts.RaceWriters; i++ {
+		value := fmt.Sprintf("writer-%d", i)
+		values[value] = true
+
+		wg.Add(1)
+		go func(value string) {
+			defer wg.Done()
+			err := c.store.Set(&state.SetRequest{Key: key, Value: value})
+			if err != nil {
+				lock.Lock()
+				errs = append(errs, err)
+				lock.Unlock()
+			}
+		}(value)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return StatusFailed, fmt.Sprintf("%d concurrent writes failed: %s", len(errs), errs[0])
+	}
+
+	first, err := c.get(key)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	found := false
+	for v := range values {
+		if valueMatches(first, v) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return StatusFailed, fmt.Sprintf("value %q does not match any written value", respData(first))
+	}
+
+	for i := 0; i < c.opts.Sequence; i++ {
+		resp, err := c.get(key)
+		if err != nil {
+			return StatusFailed, fmt.Sprintf("get failed: %s", err)
+		}
+		if string(respData(resp)) != string(respData(first)) {
+			return StatusFailed, fmt.Sprintf("reads did not converge: got %q after %q", respData(resp), respData(first))
+		}
+	}
+	return StatusPassed, ""
+}
+
+func (c *stateChecker) checkTransaction() (Status, string) {
+	tx, ok := c.store.(state.TransactionalStore)
+	if !ok {
+		return StatusSkipped, "store is not transactional"
+	}
+
+	upsertKey := c.key("tx-upsert")
+	deleteKey := c.key("tx-delete")
+
+	err := c.store.Set(&state.SetRequest{Key: deleteKey, Value: "delete-me"})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("set failed: %s", err)
+	}
+
+	err = tx.Multi([]state.TransactionalRequest{
+		{
+			Operation: state.Upsert,
+			Request: state.SetRequest{
+				Key:   upsertKey,
+				Value: "tx-value",
+			},
+		},
+		{
+			Operation: state.Delete,
+			Request: state.DeleteRequest{
+				Key: deleteKey,
+			},
+		},
+	})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("transaction failed: %s", err)
+	}
+
+	resp, err := c.get(upsertKey)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	if !valueMatches(resp, "tx-value") {
+		return StatusFailed, fmt.Sprintf("transactional upsert not applied, got %q", respData(resp))
+	}
+
+	resp, err = c.get(deleteKey)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	if resp != nil && len(resp.Data) > 0 {
+		return StatusFailed, "transactional delete not applied"
+	}
+	return StatusPassed, ""
+}
+
+func (c *stateChecker) checkTTL() (Status, string) {
+	key := c.key("ttl")
+
+	err := c.store.Set(&state.SetRequest{
+		Key:   key,
+		Value: "expiring",
+		Metadata: map[string]string{
+			TTLMetadataKey: "1",
+		},
+	})
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("set failed: %s", err)
+	}
+
+	time.Sleep(time.Second * 3)
+
+	resp, err := c.get(key)
+	if err != nil {
+		return StatusFailed, fmt.Sprintf("get failed: %s", err)
+	}
+	if resp != nil && len(resp.Data) > 0 {
+		return StatusSkipped, "key did not expire, store does not support ttl"
+	}
+	return StatusPassed, ""
+}
+
+func respData(resp *state.GetResponse) []byte {
+	if resp == nil {
+		return nil
+	}
+	return resp.Data
+}
+
+// valueMatches compares stored data against an expected string, accounting for stores that JSON encode values
+func valueMatches(resp *state.GetResponse, expected string) bool {
+	data := respData(resp)
+	if string(data) == expected {
+		return true
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s == expected
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeItem struct {
+	value string
+	etag  int
+}
+
+type fakeStateStore struct {
+	lock       sync.Mutex
+	items      map[string]*fakeItem
+	ignoreETag bool
+}
+
+func newFakeStateStore() *fakeStateStore {
+	return &fakeStateStore{items: map[string]*fakeItem{}}
+}
+
+func (f *fakeStateStore) Init(metadata state.Metadata) error {
+	return nil
+}
+
+func (f *fakeStateStore) Delete(req *state.DeleteRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.checkETag(req.Key, req.ETag); err != nil {
+		return err
+	}
+	delete(f.items, req.Key)
+	return nil
+}
+
+func (f *fakeStateStore) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		if err := f.Delete(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	item, ok := f.items[req.Key]
+	if !ok {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{
+		Data: []byte(item.value),
+		ETag: fmt.Sprintf("%d", item.etag),
+	}, nil
+}
+
+func (f *fakeStateStore) Set(req *state.SetRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.checkETag(req.Key, req.ETag); err != nil {
+		return err
+	}
+	item, ok := f.items[req.Key]
+	if !ok {
+		item = &fakeItem{}
+		f.items[req.Key] = item
+	}
+	item.value = req.Value.(string)
+	item.etag++
+	return nil
+}
+
+func (f *fakeStateStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		if err := f.Set(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeStateStore) checkETag(key, etag string) error {
+	if etag == "" || f.ignoreETag {
+		return nil
+	}
+	item, ok := f.items[key]
+	if !ok || fmt.Sprintf("%d", item.etag) != etag {
+		return errors.New("etag mismatch")
+	}
+	return nil
+}
+
+func resultsByCheck(report *Report) map[string]Result {
+	results := map[string]Result{}
+	for _, r := range report.Results {
+		results[r.Check] = r
+	}
+	return results
+}
+
+func TestCheckStateStore(t *testing.T) {
+	t.Run("compliant store passes", func(t *testing.T) {
+		store := newFakeStateStore()
+		report := &Report{}
+
+		CheckStateStore("fake", store, DefaultStateOptions(), report)
+
+		results := resultsByCheck(report)
+		assert.False(t, report.Failed())
+		assert.Equal(t, StatusPassed, results["crud"].Status)
+		assert.Equal(t, StatusPassed, results["etag-race"].Status)
+		assert.Equal(t, StatusPassed, results["stale-etag"].Status)
+		assert.Equal(t, StatusPassed, results["linearizability"].Status)
+		assert.Equal(t, StatusPassed, results["concurrent-writes"].Status)
+		assert.Equal(t, StatusSkipped, results["transaction"].Status)
+		_, ok := results["ttl"]
+		assert.False(t, ok)
+	})
+
+	t.Run("store ignoring etags fails race checks", func(t *testing.T) {
+		store := newFakeStateStore()
+		store.ignoreETag = true
+		report := &Report{}
+
+		CheckStateStore("fake", store, DefaultStateOptions(), report)
+
+		results := resultsByCheck(report)
+		assert.True(t, report.Failed())
+		assert.Equal(t, StatusFailed, results["etag-race"].Status)
+		assert.Equal(t, StatusFailed, results["stale-etag"].Status)
+		assert.Equal(t, StatusPassed, results["crud"].Status)
+	})
+
+	t.Run("test keys are cleaned up", func(t *testing.T) {
+		store := newFakeStateStore()
+
+		CheckStateStore("fake", store, DefaultStateOptions(), &Report{})
+
+		assert.Len(t, store.items, 0)
+	})
+}
+
+func TestValueMatches(t *testing.T) {
+	assert.True(t, valueMatches(&state.GetResponse{Data: []byte("v1")}, "v1"))
+	assert.True(t, valueMatches(&state.GetResponse{Data: []byte(`"v1"`)}, "v1"))
+	assert.False(t, valueMatches(&state.GetResponse{Data: []byte("v2")}, "v1"))
+	assert.False(t, valueMatches(nil, "v1"))
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dapr/dapr/pkg/fips"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -16,6 +17,12 @@ func GetServerOptions(certChain *CertChain) ([]grpc.ServerOption, error) {
 		return opts, nil
 	}
 
+	if fips.Enabled() {
+		if err := fips.ValidateCertChain(certChain.RootCA, certChain.Cert, certChain.Key); err != nil {
+			return nil, err
+		}
+	}
+
 	cp := x509.NewCertPool()
 	cp.AppendCertsFromPEM(certChain.RootCA)
 
@@ -31,7 +38,7 @@ func GetServerOptions(certChain *CertChain) ([]grpc.ServerOption, error) {
 			ClientAuth:   tls.RequireAndVerifyClientCert,
 			Certificates: []tls.Certificate{cert},
 		}
-		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+		opts = append(opts, grpc.Creds(credentials.NewTLS(fips.ConfigureTLS(config))))
 	}
 	return opts, nil
 }
@@ -3,6 +3,8 @@ package credentials
 import (
 	"crypto/tls"
 	"crypto/x509"
+
+	"github.com/dapr/dapr/pkg/fips"
 )
 
 // TLSConfigFromCertAndKey return a tls.config object from valid cert/key pair in PEM format.
@@ -19,5 +21,5 @@ func TLSConfigFromCertAndKey(certPem, keyPem []byte, serverName string, rootCA *
 		Certificates:       []tls.Certificate{cert},
 	}
 
-	return config, nil
+	return fips.ConfigureTLS(config), nil
 }
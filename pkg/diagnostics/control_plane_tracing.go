@@ -0,0 +1,65 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	"go.opencensus.io/trace"
+)
+
+// Control plane services called by the runtime
+const (
+	ControlPlanePlacement = "placement"
+	ControlPlaneOperator  = "operator"
+	ControlPlaneSentry    = "sentry"
+)
+
+const controlPlaneServiceAttribute = "dapr.control_plane.service"
+
+// ControlPlaneCall tracks a single call from the runtime to a control plane service.
+// It records a client span and the call latency metric when ended.
+type ControlPlaneCall struct {
+	name      string
+	service   string
+	operation string
+	start     time.Time
+	span      *trace.Span
+}
+
+// StartControlPlaneCall starts a client span for a call to a control plane service and returns the context
+// carrying the span context, to be used for the outgoing call.
+func StartControlPlaneCall(ctx context.Context, service, operation string, spec config.TracingSpec) (context.Context, *ControlPlaneCall) {
+	name := fmt.Sprintf("%s/%s", service, operation)
+	ctx, span := startTracingSpanInternal(ctx, name, spec, trace.SpanKindClient)
+	span.AddAttributes(trace.StringAttribute(controlPlaneServiceAttribute, service))
+
+	ctx = AppendToOutgoingGRPCContext(ctx, span.SpanContext())
+	return ctx, &ControlPlaneCall{
+		name:      name,
+		service:   service,
+		operation: operation,
+		start:     time.Now(),
+		span:      span,
+	}
+}
+
+// Annotate adds a message to the call span, e.g. to record a failed attempt before a retry
+func (c *ControlPlaneCall) Annotate(format string, args ...interface{}) {
+	c.span.Annotatef(nil, format, args...)
+}
+
+// End completes the call span with the status derived from err and records the call latency
+func (c *ControlPlaneCall) End(err error) {
+	UpdateSpanPairStatusesFromError(c.span, err, c.name)
+	c.span.End()
+
+	elapsed := float64(time.Since(c.start) / time.Millisecond)
+	DefaultMonitoring.ControlPlaneCallCompleted(c.service, c.operation, err == nil, elapsed)
+}
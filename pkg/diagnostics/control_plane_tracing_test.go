@@ -0,0 +1,38 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartControlPlaneCall(t *testing.T) {
+	spec := config.TracingSpec{SamplingRate: "1"}
+
+	t.Run("span context is propagated to outgoing call", func(t *testing.T) {
+		ctx, call := StartControlPlaneCall(context.Background(), ControlPlaneOperator, "GetComponents", spec)
+		defer call.End(nil)
+
+		sc, ok := FromOutgoingGRPCContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, call.span.SpanContext().SpanID, sc.SpanID)
+		assert.Equal(t, "operator/GetComponents", call.name)
+	})
+
+	t.Run("end with error", func(t *testing.T) {
+		_, call := StartControlPlaneCall(context.Background(), ControlPlaneSentry, "SignCertificate", spec)
+		call.Annotate("attempt %d failed", 1)
+
+		assert.NotPanics(t, func() {
+			call.End(errors.New("unavailable"))
+		})
+	})
+}
@@ -11,9 +11,11 @@ import (
 
 	diag_utils "github.com/dapr/dapr/pkg/diagnostics/utils"
 	"github.com/golang/protobuf/proto"
+	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 )
@@ -148,18 +150,37 @@ func (g *grpcMetrics) ServerRequestReceived(ctx context.Context, method string,
 
 func (g *grpcMetrics) ServerRequestSent(ctx context.Context, method, status string, contentSize int64, start time.Time) {
 	if g.enabled {
-		elapsed := float64(time.Since(start) / time.Millisecond)
 		stats.RecordWithTags(
 			ctx,
 			diag_utils.WithTags(appIDKey, g.appID, KeyServerMethod, method),
 			g.serverSentBytes.M(contentSize))
-		stats.RecordWithTags(
-			ctx,
-			diag_utils.WithTags(appIDKey, g.appID, KeyServerMethod, method, KeyServerStatus, status),
-			g.serverLatency.M(elapsed))
+		g.serverCompleted(ctx, method, status, start)
 	}
 }
 
+// serverCompleted records the latency of a completed RPC, with the trace of the RPC as exemplar when it's sampled so
+// that the slow RPCs of a latency bucket lead to their traces
+func (g *grpcMetrics) serverCompleted(ctx context.Context, method, status string, start time.Time) {
+	elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+	stats.RecordWithOptions(
+		ctx,
+		stats.WithTags(diag_utils.WithTags(appIDKey, g.appID, KeyServerMethod, method, KeyServerStatus, status)...),
+		stats.WithMeasurements(g.serverLatency.M(elapsed)),
+		stats.WithAttachments(exemplarAttachments(ctx)))
+}
+
+// exemplarAttachments returns the attachments linking a measurement to the sampled trace of the context, if any
+func exemplarAttachments(ctx context.Context) metricdata.Attachments {
+	sc := FromContext(ctx)
+	if span := trace.FromContext(ctx); span != nil {
+		sc = span.SpanContext()
+	}
+	if !sc.IsSampled() {
+		return nil
+	}
+	return metricdata.Attachments{metricdata.AttachmentKeySpanContext: sc}
+}
+
 func (g *grpcMetrics) ClientRequestSent(ctx context.Context, method string, contentSize int64) time.Time {
 	if g.enabled {
 		stats.RecordWithTags(
@@ -202,6 +223,19 @@ func (g *grpcMetrics) UnaryServerInterceptor() func(ctx context.Context, req int
 	}
 }
 
+// StreamServerInterceptor is a gRPC server-side interceptor for streaming RPCs, recording their latency until the
+// stream ends.
+func (g *grpcMetrics) StreamServerInterceptor() func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		if g.enabled {
+			g.serverCompleted(ss.Context(), info.FullMethod, status.Code(err).String(), start)
+		}
+		return err
+	}
+}
+
 // UnaryClientInterceptor is a gRPC client-side interceptor for Unary RPCs.
 func (g *grpcMetrics) UnaryClientInterceptor() func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
@@ -0,0 +1,100 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// latencyData returns the server latency distribution of the method
+func latencyData(t *testing.T, method string) *view.DistributionData {
+	rows, err := view.RetrieveData("grpc.io/server/server_latency")
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key == KeyServerMethod && tag.Value == method {
+				return row.Data.(*view.DistributionData)
+			}
+		}
+	}
+	return nil
+}
+
+// exemplars returns the exemplars of the buckets of the distribution
+func exemplars(data *view.DistributionData) []*metricdata.Exemplar {
+	var e []*metricdata.Exemplar
+	for _, exemplar := range data.ExemplarsPerBucket {
+		if exemplar != nil {
+			e = append(e, exemplar)
+		}
+	}
+	return e
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestGRPCServerInterceptors(t *testing.T) {
+	testGRPC := newGRPCMetrics()
+	require.NoError(t, testGRPC.Init("fakeID"))
+
+	sampled := trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}, TraceOptions: 1}
+	unary := testGRPC.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &empty.Empty{}, nil
+	}
+
+	t.Run("latency of sampled calls has the trace as exemplar", func(t *testing.T) {
+		_, err := unary(NewContext(context.Background(), sampled), &empty.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/dapr.proto.internals.v1.ServiceInvocation/CallActor"}, handler)
+		assert.NoError(t, err)
+
+		data := latencyData(t, "/dapr.proto.internals.v1.ServiceInvocation/CallActor")
+		require.NotNil(t, data)
+		assert.Equal(t, int64(1), data.Count)
+		e := exemplars(data)
+		require.Len(t, e, 1)
+		assert.Equal(t, sampled, e[0].Attachments[metricdata.AttachmentKeySpanContext])
+	})
+
+	t.Run("latency of unsampled calls has no exemplar", func(t *testing.T) {
+		_, err := unary(NewContext(context.Background(), trace.SpanContext{TraceID: trace.TraceID{3}}), &empty.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/dapr.proto.runtime.v1.Dapr/GetState"}, handler)
+		assert.NoError(t, err)
+
+		data := latencyData(t, "/dapr.proto.runtime.v1.Dapr/GetState")
+		require.NotNil(t, data)
+		assert.Empty(t, exemplars(data))
+	})
+
+	t.Run("latency of streams is recorded when they end", func(t *testing.T) {
+		stream := testGRPC.StreamServerInterceptor()
+		err := stream(nil, &fakeServerStream{ctx: NewContext(context.Background(), sampled)}, &grpc.StreamServerInfo{FullMethod: "/dapr.proto.placement.v1.Placement/ReportDaprStatus"}, func(srv interface{}, ss grpc.ServerStream) error {
+			return status.Error(codes.Unavailable, "placement is leaving")
+		})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		data := latencyData(t, "/dapr.proto.placement.v1.Placement/ReportDaprStatus")
+		require.NotNil(t, data)
+		assert.Equal(t, int64(1), data.Count)
+		assert.Len(t, exemplars(data), 1)
+	})
+}
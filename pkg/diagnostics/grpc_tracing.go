@@ -48,7 +48,7 @@ func SetTracingSpanContextGRPCMiddlewareUnary(spec config.TracingSpec) grpc.Unar
 // StartTracingServerSpanFromGRPCContext creates a span on receiving an incoming gRPC method call from remote client
 func StartTracingServerSpanFromGRPCContext(ctx context.Context, method string, spec config.TracingSpec) (context.Context, *trace.Span) {
 	var span *trace.Span
-	ctx, span = startTracingSpanInternal(ctx, method, spec.SamplingRate, trace.SpanKindServer)
+	ctx, span = startTracingSpanInternal(ctx, method, spec, trace.SpanKindServer)
 	addAnnotationsToSpanFromGRPCMetadata(ctx, span)
 
 	return ctx, span
@@ -57,14 +57,19 @@ func StartTracingServerSpanFromGRPCContext(ctx context.Context, method string, s
 // StartTracingClientSpanFromGRPCContext creates a client span before invoking gRPC method call
 func StartTracingClientSpanFromGRPCContext(ctx context.Context, method string, spec config.TracingSpec) (context.Context, *trace.Span) {
 	var span *trace.Span
-	ctx, span = startTracingSpanInternal(ctx, method, spec.SamplingRate, trace.SpanKindClient)
+	ctx, span = startTracingSpanInternal(ctx, method, spec, trace.SpanKindClient)
 	addAnnotationsToSpanFromGRPCMetadata(ctx, span)
 
 	return ctx, span
 }
 
+// GetSpanContextFromGRPC returns the binary span context sent by sidecars or the one of the configured propagators
 func GetSpanContextFromGRPC(ctx context.Context, spec config.TracingSpec) trace.SpanContext {
 	spanContext, ok := FromGRPCContext(ctx)
+	if !ok {
+		md, _ := metadata.FromIncomingContext(ctx)
+		spanContext, ok = extractSpanContext(metadataGetter(md), spec)
+	}
 
 	if !ok {
 		spanContext = GetDefaultSpanContext(spec)
@@ -92,6 +97,17 @@ func AppendToOutgoingGRPCContext(ctx context.Context, spanContext trace.SpanCont
 	return metadata.AppendToOutgoingContext(ctx, grpcTraceContextKey, string(traceContextBinary))
 }
 
+// InjectToOutgoingGRPCContext appends the binary serialized SpanContext and the headers of the configured propagators
+// to the outgoing GRPC context
+func InjectToOutgoingGRPCContext(ctx context.Context, spanContext trace.SpanContext, spec config.TracingSpec) context.Context {
+	ctx = AppendToOutgoingGRPCContext(ctx, spanContext)
+	var kv []string
+	injectSpanContext(spanContext, func(name, value string) {
+		kv = append(kv, name, value)
+	}, spec)
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
 // FromOutgoingGRPCContext returns the SpanContext stored in a context, or empty if there isn't one.
 func FromOutgoingGRPCContext(ctx context.Context) (trace.SpanContext, bool) {
 	var sc trace.SpanContext
@@ -120,3 +136,12 @@ func addAnnotationsToSpanFromGRPCMetadata(ctx context.Context, span *trace.Span)
 		}
 	}
 }
+
+func metadataGetter(md metadata.MD) func(name string) string {
+	return func(name string) string {
+		if v := md.Get(name); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+}
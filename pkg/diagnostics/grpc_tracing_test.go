@@ -63,3 +63,26 @@ func TestWithGRPCWithNoSpanContext(t *testing.T) {
 		assert.Equal(t, 0, int(sc.TraceOptions), "Should not be sampled")
 	})
 }
+
+func TestDisabledTracing(t *testing.T) {
+	spec := config.TracingSpec{SamplingRate: "1", Disabled: []string{"state", "GetSecret"}}
+	sampled := trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}, TraceOptions: 1}
+	ctx := NewContext(context.Background(), sampled)
+
+	t.Run("operations of disabled building blocks aren't traced", func(t *testing.T) {
+		_, span := StartTracingClientSpanFromGRPCContext(ctx, "GetState: statestore", spec)
+		assert.False(t, span.SpanContext().IsSampled())
+	})
+
+	t.Run("disabled operations aren't traced", func(t *testing.T) {
+		_, span := StartTracingClientSpanFromGRPCContext(ctx, "getsecret: vault", spec)
+		assert.False(t, span.SpanContext().IsSampled())
+	})
+
+	t.Run("other operations are traced", func(t *testing.T) {
+		_, span := StartTracingClientSpanFromGRPCContext(ctx, "PublishEvent: orders", spec)
+		assert.True(t, span.SpanContext().IsSampled())
+		_, span = StartTracingServerSpanFromGRPCContext(ctx, "checkout", spec)
+		assert.True(t, span.SpanContext().IsSampled())
+	})
+}
@@ -8,7 +8,7 @@ package diagnostics
 import (
 	"context"
 	"encoding/hex"
-	"fmt"
+	"net/http"
 	"net/textproto"
 	"regexp"
 	"strconv"
@@ -37,7 +37,7 @@ var trimOWSRegExp = regexp.MustCompile(trimOWSRegexFmt)
 func SetTracingSpanContextFromHTTPContext(next fasthttp.RequestHandler, spec config.TracingSpec) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
 		sc := GetSpanContextFromRequestContext(ctx, spec)
-		SpanContextToRequest(sc, &ctx.Request)
+		SpanContextToRequest(sc, &ctx.Request, spec)
 		next(ctx)
 	}
 }
@@ -45,7 +45,7 @@ func SetTracingSpanContextFromHTTPContext(next fasthttp.RequestHandler, spec con
 // StartTracingClientSpanFromHTTPContext creates a client span before invoking http method call
 func StartTracingClientSpanFromHTTPContext(ctx context.Context, req *fasthttp.Request, method string, spec config.TracingSpec) (context.Context, *trace.Span) {
 	var span *trace.Span
-	ctx, span = startTracingSpanInternal(ctx, method, spec.SamplingRate, trace.SpanKindClient)
+	ctx, span = startTracingSpanInternal(ctx, method, spec, trace.SpanKindClient)
 
 	addAnnotationsToSpan(req, span)
 
@@ -53,7 +53,7 @@ func StartTracingClientSpanFromHTTPContext(ctx context.Context, req *fasthttp.Re
 }
 
 func GetSpanContextFromRequestContext(ctx *fasthttp.RequestCtx, spec config.TracingSpec) trace.SpanContext {
-	spanContext, ok := SpanContextFromRequest(&ctx.Request)
+	spanContext, ok := extractSpanContext(requestHeaderGetter(&ctx.Request), spec)
 
 	if !ok {
 		spanContext = GetDefaultSpanContext(spec)
@@ -62,10 +62,24 @@ func GetSpanContextFromRequestContext(ctx *fasthttp.RequestCtx, spec config.Trac
 	return spanContext
 }
 
-// SpanContextFromRequest extracts a span context from incoming requests.
+// SpanContextFromRequest extracts a W3C trace context from incoming requests.
 func SpanContextFromRequest(req *fasthttp.Request) (sc trace.SpanContext, ok bool) {
-	h, ok := getRequestHeader(req, traceparentHeader)
-	if !ok {
+	return w3cPropagator{}.Extract(requestHeaderGetter(req))
+}
+
+// SpanContextToRequest modifies the given request to include the trace context headers of the configured propagators.
+func SpanContextToRequest(sc trace.SpanContext, req *fasthttp.Request, spec config.TracingSpec) {
+	injectSpanContext(sc, req.Header.Set, spec)
+}
+
+// SpanContextToHTTPHeader modifies the given net/http header to include the trace context headers of the configured
+// propagators.
+func SpanContextToHTTPHeader(sc trace.SpanContext, header http.Header, spec config.TracingSpec) {
+	injectSpanContext(sc, header.Set, spec)
+}
+
+func parseTraceparent(h string) (sc trace.SpanContext, ok bool) {
+	if h == "" {
 		return trace.SpanContext{}, false
 	}
 	sections := strings.Split(h, "-")
@@ -118,21 +132,9 @@ func SpanContextFromRequest(req *fasthttp.Request) (sc trace.SpanContext, ok boo
 		return trace.SpanContext{}, false
 	}
 
-	sc.Tracestate = tracestateFromRequest(req)
 	return sc, true
 }
 
-// SpanContextToRequest modifies the given request to include traceparent and tracestate headers.
-func SpanContextToRequest(sc trace.SpanContext, req *fasthttp.Request) {
-	h := fmt.Sprintf("%x-%x-%x-%x",
-		[]byte{supportedVersion},
-		sc.TraceID[:],
-		sc.SpanID[:],
-		[]byte{byte(sc.TraceOptions)})
-	req.Header.Set(traceparentHeader, h)
-	tracestateToRequest(sc, req)
-}
-
 func addAnnotationsToSpan(req *fasthttp.Request, span *trace.Span) {
 	req.Header.VisitAll(func(key []byte, value []byte) {
 		headerKey := string(key)
@@ -151,17 +153,13 @@ func UpdateSpanStatus(span *trace.Span, resp *fasthttp.Response) {
 	})
 }
 
-func getRequestHeader(req *fasthttp.Request, name string) (string, bool) {
-	s := string(req.Header.Peek(textproto.CanonicalMIMEHeaderKey(name)))
-	if s == "" {
-		return "", false
+func requestHeaderGetter(req *fasthttp.Request) func(name string) string {
+	return func(name string) string {
+		return string(req.Header.Peek(textproto.CanonicalMIMEHeaderKey(name)))
 	}
-
-	return s, true
 }
 
-func tracestateFromRequest(req *fasthttp.Request) *tracestate.Tracestate {
-	h, _ := getRequestHeader(req, tracestateHeader)
+func parseTracestate(h string) *tracestate.Tracestate {
 	if h == "" {
 		return nil
 	}
@@ -193,16 +191,18 @@ func tracestateFromRequest(req *fasthttp.Request) *tracestate.Tracestate {
 	return ts
 }
 
-func tracestateToRequest(sc trace.SpanContext, req *fasthttp.Request) {
-	var pairs = make([]string, 0, len(sc.Tracestate.Entries()))
-	if sc.Tracestate != nil {
-		for _, entry := range sc.Tracestate.Entries() {
-			pairs = append(pairs, strings.Join([]string{entry.Key, entry.Value}, "="))
-		}
-		h := strings.Join(pairs, ",")
+func formatTracestate(sc trace.SpanContext) string {
+	if sc.Tracestate == nil {
+		return ""
+	}
 
-		if h != "" && len(h) <= maxTracestateLen {
-			req.Header.Set(tracestateHeader, h)
-		}
+	var pairs = make([]string, 0, len(sc.Tracestate.Entries()))
+	for _, entry := range sc.Tracestate.Entries() {
+		pairs = append(pairs, strings.Join([]string{entry.Key, entry.Value}, "="))
+	}
+	h := strings.Join(pairs, ",")
+	if len(h) > maxTracestateLen {
+		return ""
 	}
+	return h
 }
@@ -103,7 +103,7 @@ func TestSpanContextToRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("SpanContextToRequest", func(t *testing.T) {
 			req := &fasthttp.Request{}
-			SpanContextToRequest(tt.sc, req)
+			SpanContextToRequest(tt.sc, req, config.TracingSpec{})
 
 			got, _ := SpanContextFromRequest(req)
 
@@ -154,6 +154,6 @@ func getTestHTTPRequest() *fasthttp.Request {
 		TraceOptions: 0x0,
 	}
 
-	SpanContextToRequest(sc, req)
+	SpanContextToRequest(sc, req, config.TracingSpec{})
 	return req
 }
@@ -0,0 +1,115 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package diagnostics
+
+import (
+	"sort"
+	"sync"
+)
+
+// Directions of the service invocations, from the point of view of the sidecar
+const (
+	InvocationOutbound = "outbound"
+	InvocationInbound  = "inbound"
+)
+
+const (
+	// maxPayloadPeers bounds the number of peers summed, the payloads of the other peers are summed as otherPeers
+	maxPayloadPeers = 1000
+	otherPeers      = "*"
+	unknownPeer     = "unknown"
+)
+
+// DefaultInvocationPayloads sums the payloads of the service invocations of the sidecar
+var DefaultInvocationPayloads = NewInvocationPayloads()
+
+// PeerPayloads are the payloads of the service invocations with a peer app in a direction
+type PeerPayloads struct {
+	AppID            string `json:"appId"`
+	Direction        string `json:"direction"`
+	Calls            int64  `json:"calls"`
+	RequestBytes     int64  `json:"requestBytes"`
+	ResponseBytes    int64  `json:"responseBytes"`
+	MaxRequestBytes  int64  `json:"maxRequestBytes"`
+	MaxResponseBytes int64  `json:"maxResponseBytes"`
+}
+
+// TotalBytes is the size of the requests and responses
+func (p PeerPayloads) TotalBytes() int64 {
+	return p.RequestBytes + p.ResponseBytes
+}
+
+type payloadPeer struct {
+	appID     string
+	direction string
+}
+
+// InvocationPayloads sums the sizes of the payloads of the service invocations by peer app, to report the peers
+// responsible for most of the bytes going through the sidecar
+type InvocationPayloads struct {
+	lock  sync.Mutex
+	peers map[payloadPeer]*PeerPayloads
+}
+
+// NewInvocationPayloads returns empty sums of invocation payloads
+func NewInvocationPayloads() *InvocationPayloads {
+	return &InvocationPayloads{peers: map[payloadPeer]*PeerPayloads{}}
+}
+
+// Record adds the sizes of the request and response of an invocation with the peer app
+func (i *InvocationPayloads) Record(direction, appID string, request, response int64) {
+	if appID == "" {
+		appID = unknownPeer
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	key := payloadPeer{appID: appID, direction: direction}
+	p, ok := i.peers[key]
+	if !ok {
+		if len(i.peers) >= maxPayloadPeers {
+			key.appID = otherPeers
+			p = i.peers[key]
+		}
+		if p == nil {
+			p = &PeerPayloads{AppID: key.appID, Direction: direction}
+			i.peers[key] = p
+		}
+	}
+	p.Calls++
+	p.RequestBytes += request
+	p.ResponseBytes += response
+	if request > p.MaxRequestBytes {
+		p.MaxRequestBytes = request
+	}
+	if response > p.MaxResponseBytes {
+		p.MaxResponseBytes = response
+	}
+}
+
+// Top returns the n peers with the most bytes, heaviest first, or all the peers when n isn't positive
+func (i *InvocationPayloads) Top(n int) []PeerPayloads {
+	i.lock.Lock()
+	result := make([]PeerPayloads, 0, len(i.peers))
+	for _, p := range i.peers {
+		result = append(result, *p)
+	}
+	i.lock.Unlock()
+
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].TotalBytes() != result[b].TotalBytes() {
+			return result[a].TotalBytes() > result[b].TotalBytes()
+		}
+		if result[a].AppID != result[b].AppID {
+			return result[a].AppID < result[b].AppID
+		}
+		return result[a].Direction < result[b].Direction
+	})
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
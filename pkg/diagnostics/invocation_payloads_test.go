@@ -0,0 +1,44 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package diagnostics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvocationPayloads(t *testing.T) {
+	t.Run("peers are reported heaviest first", func(t *testing.T) {
+		p := NewInvocationPayloads()
+		p.Record(InvocationOutbound, "light", 10, 10)
+		p.Record(InvocationOutbound, "heavy", 1000, 100)
+		p.Record(InvocationOutbound, "heavy", 500, 2000)
+		p.Record(InvocationInbound, "heavy", 100, 0)
+		p.Record(InvocationInbound, "", 50, 50)
+
+		top := p.Top(3)
+		assert.Equal(t, []PeerPayloads{
+			{AppID: "heavy", Direction: InvocationOutbound, Calls: 2, RequestBytes: 1500, ResponseBytes: 2100, MaxRequestBytes: 1000, MaxResponseBytes: 2000},
+			{AppID: "heavy", Direction: InvocationInbound, Calls: 1, RequestBytes: 100, MaxRequestBytes: 100},
+			{AppID: unknownPeer, Direction: InvocationInbound, Calls: 1, RequestBytes: 50, ResponseBytes: 50, MaxRequestBytes: 50, MaxResponseBytes: 50},
+		}, top)
+		assert.Len(t, p.Top(0), 4)
+	})
+
+	t.Run("peers above the limit are summed together", func(t *testing.T) {
+		p := NewInvocationPayloads()
+		for i := 0; i < maxPayloadPeers+2; i++ {
+			p.Record(InvocationOutbound, fmt.Sprintf("app%d", i), 1, 0)
+		}
+
+		top := p.Top(1)
+		assert.Equal(t, otherPeers, top[0].AppID)
+		assert.Equal(t, int64(2), top[0].Calls)
+		assert.Len(t, p.Top(0), maxPayloadPeers+1)
+	})
+}
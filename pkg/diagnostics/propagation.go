@@ -0,0 +1,243 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package diagnostics
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dapr/dapr/pkg/config"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// W3CPropagator propagates the span context in the W3C traceparent and tracestate headers
+	W3CPropagator = "w3c"
+	// B3Propagator propagates the span context in the single b3 header
+	B3Propagator = "b3"
+	// B3MultiPropagator propagates the span context in the X-B3-* headers
+	B3MultiPropagator = "b3multi"
+	// JaegerPropagator propagates the span context in the uber-trace-id header
+	JaegerPropagator = "jaeger"
+
+	b3Header        = "b3"
+	b3TraceIDHeader = "x-b3-traceid"
+	b3SpanIDHeader  = "x-b3-spanid"
+	b3SampledHeader = "x-b3-sampled"
+	b3FlagsHeader   = "x-b3-flags"
+	jaegerHeader    = "uber-trace-id"
+)
+
+// Propagator extracts a span context from the headers of an incoming request and injects it in an outgoing request.
+// The same propagators serve HTTP headers and gRPC metadata.
+type Propagator interface {
+	Extract(getHeader func(name string) string) (trace.SpanContext, bool)
+	Inject(sc trace.SpanContext, setHeader func(name, value string))
+}
+
+var (
+	propagatorsLock sync.RWMutex
+	propagators     = map[string]Propagator{
+		W3CPropagator:     w3cPropagator{},
+		B3Propagator:      b3Propagator{},
+		B3MultiPropagator: b3MultiPropagator{},
+		JaegerPropagator:  jaegerPropagator{},
+	}
+)
+
+// RegisterPropagator makes a custom propagator available to the tracing configuration under name
+func RegisterPropagator(name string, p Propagator) {
+	propagatorsLock.Lock()
+	defer propagatorsLock.Unlock()
+	propagators[strings.ToLower(name)] = p
+}
+
+// propagatorsForSpec returns the propagators of the tracing configuration, W3C trace context if none is configured.
+// Unknown propagators are ignored.
+func propagatorsForSpec(spec config.TracingSpec) []Propagator {
+	if len(spec.Propagators) == 0 {
+		return []Propagator{w3cPropagator{}}
+	}
+
+	propagatorsLock.RLock()
+	defer propagatorsLock.RUnlock()
+	ps := make([]Propagator, 0, len(spec.Propagators))
+	for _, name := range spec.Propagators {
+		if p, ok := propagators[strings.ToLower(name)]; ok {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// extractSpanContext returns the span context of the first configured propagator that finds one in the headers
+func extractSpanContext(getHeader func(name string) string, spec config.TracingSpec) (trace.SpanContext, bool) {
+	for _, p := range propagatorsForSpec(spec) {
+		if sc, ok := p.Extract(getHeader); ok {
+			return sc, true
+		}
+	}
+	return trace.SpanContext{}, false
+}
+
+// injectSpanContext sets the headers of all the configured propagators
+func injectSpanContext(sc trace.SpanContext, setHeader func(name, value string), spec config.TracingSpec) {
+	for _, p := range propagatorsForSpec(spec) {
+		p.Inject(sc, setHeader)
+	}
+}
+
+// parseTraceID parses a 64 or 128 bit hex trace ID, 64 bit IDs being padded with zeros
+func parseTraceID(s string) ([16]byte, bool) {
+	var tid [16]byte
+	if len(s) == 0 || len(s) > 32 {
+		return tid, false
+	}
+	b, err := hex.DecodeString(strings.Repeat("0", 32-len(s)) + s)
+	if err != nil {
+		return tid, false
+	}
+	copy(tid[:], b)
+	return tid, tid != [16]byte{}
+}
+
+// parseSpanID parses a 64 bit hex span ID
+func parseSpanID(s string) ([8]byte, bool) {
+	var sid [8]byte
+	if len(s) == 0 || len(s) > 16 {
+		return sid, false
+	}
+	b, err := hex.DecodeString(strings.Repeat("0", 16-len(s)) + s)
+	if err != nil {
+		return sid, false
+	}
+	copy(sid[:], b)
+	return sid, sid != [8]byte{}
+}
+
+func traceOptions(sampled bool) trace.TraceOptions {
+	if sampled {
+		return 1
+	}
+	return 0
+}
+
+type w3cPropagator struct{}
+
+func (w3cPropagator) Extract(getHeader func(name string) string) (trace.SpanContext, bool) {
+	sc, ok := parseTraceparent(getHeader(traceparentHeader))
+	if !ok {
+		return trace.SpanContext{}, false
+	}
+	sc.Tracestate = parseTracestate(getHeader(tracestateHeader))
+	return sc, true
+}
+
+func (w3cPropagator) Inject(sc trace.SpanContext, setHeader func(name, value string)) {
+	setHeader(traceparentHeader, fmt.Sprintf("%x-%x-%x-%x",
+		[]byte{supportedVersion},
+		sc.TraceID[:],
+		sc.SpanID[:],
+		[]byte{byte(sc.TraceOptions)}))
+	if h := formatTracestate(sc); h != "" {
+		setHeader(tracestateHeader, h)
+	}
+}
+
+// b3Propagator handles the single header format: {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}
+type b3Propagator struct{}
+
+func (b3Propagator) Extract(getHeader func(name string) string) (trace.SpanContext, bool) {
+	sections := strings.Split(getHeader(b3Header), "-")
+	if len(sections) < 2 {
+		// a sampling decision alone doesn't identify a span
+		return trace.SpanContext{}, false
+	}
+
+	var sc trace.SpanContext
+	var ok bool
+	if sc.TraceID, ok = parseTraceID(sections[0]); !ok {
+		return trace.SpanContext{}, false
+	}
+	if sc.SpanID, ok = parseSpanID(sections[1]); !ok {
+		return trace.SpanContext{}, false
+	}
+	if len(sections) > 2 {
+		sc.TraceOptions = traceOptions(sections[2] == "1" || sections[2] == "d")
+	}
+	return sc, true
+}
+
+func (b3Propagator) Inject(sc trace.SpanContext, setHeader func(name, value string)) {
+	sampled := "0"
+	if sc.IsSampled() {
+		sampled = "1"
+	}
+	setHeader(b3Header, fmt.Sprintf("%x-%x-%s", sc.TraceID[:], sc.SpanID[:], sampled))
+}
+
+type b3MultiPropagator struct{}
+
+func (b3MultiPropagator) Extract(getHeader func(name string) string) (trace.SpanContext, bool) {
+	var sc trace.SpanContext
+	var ok bool
+	if sc.TraceID, ok = parseTraceID(getHeader(b3TraceIDHeader)); !ok {
+		return trace.SpanContext{}, false
+	}
+	if sc.SpanID, ok = parseSpanID(getHeader(b3SpanIDHeader)); !ok {
+		return trace.SpanContext{}, false
+	}
+	sampled := getHeader(b3SampledHeader)
+	sc.TraceOptions = traceOptions(sampled == "1" || sampled == "true" || getHeader(b3FlagsHeader) == "1")
+	return sc, true
+}
+
+func (b3MultiPropagator) Inject(sc trace.SpanContext, setHeader func(name, value string)) {
+	sampled := "0"
+	if sc.IsSampled() {
+		sampled = "1"
+	}
+	setHeader(b3TraceIDHeader, fmt.Sprintf("%x", sc.TraceID[:]))
+	setHeader(b3SpanIDHeader, fmt.Sprintf("%x", sc.SpanID[:]))
+	setHeader(b3SampledHeader, sampled)
+}
+
+// jaegerPropagator handles the header format: {trace-id}:{span-id}:{parent-span-id}:{flags}
+type jaegerPropagator struct{}
+
+func (jaegerPropagator) Extract(getHeader func(name string) string) (trace.SpanContext, bool) {
+	h, err := url.QueryUnescape(getHeader(jaegerHeader))
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	sections := strings.Split(h, ":")
+	if len(sections) != 4 {
+		return trace.SpanContext{}, false
+	}
+
+	var sc trace.SpanContext
+	var ok bool
+	if sc.TraceID, ok = parseTraceID(sections[0]); !ok {
+		return trace.SpanContext{}, false
+	}
+	if sc.SpanID, ok = parseSpanID(sections[1]); !ok {
+		return trace.SpanContext{}, false
+	}
+	flags, err := strconv.ParseUint(sections[3], 16, 8)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	sc.TraceOptions = traceOptions(flags&1 == 1)
+	return sc, true
+}
+
+func (jaegerPropagator) Inject(sc trace.SpanContext, setHeader func(name, value string)) {
+	setHeader(jaegerHeader, fmt.Sprintf("%x:%x:0:%x", sc.TraceID[:], sc.SpanID[:], byte(sc.TraceOptions)))
+}
@@ -0,0 +1,90 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestPropagators(t *testing.T) {
+	sc := trace.SpanContext{
+		TraceID:      trace.TraceID{75, 249, 47, 53, 119, 179, 77, 166, 163, 206, 146, 157, 14, 14, 71, 54},
+		SpanID:       trace.SpanID{0, 240, 103, 170, 11, 169, 2, 183},
+		TraceOptions: 1,
+	}
+
+	for _, name := range []string{W3CPropagator, B3Propagator, B3MultiPropagator, JaegerPropagator} {
+		t.Run(name+" round trip", func(t *testing.T) {
+			spec := config.TracingSpec{Propagators: []string{name}}
+			req := &fasthttp.Request{}
+			SpanContextToRequest(sc, req, spec)
+
+			got, ok := extractSpanContext(requestHeaderGetter(req), spec)
+			assert.True(t, ok)
+			assert.Equal(t, sc, got)
+		})
+	}
+
+	t.Run("b3 with 64 bit trace ID", func(t *testing.T) {
+		got, ok := b3Propagator{}.Extract(func(name string) string {
+			return map[string]string{b3Header: "a3ce929d0e0e4736-00f067aa0ba902b7-0"}[name]
+		})
+		assert.True(t, ok)
+		assert.Equal(t, trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 163, 206, 146, 157, 14, 14, 71, 54}, got.TraceID)
+		assert.False(t, got.IsSampled())
+	})
+
+	t.Run("b3 sampling decision only", func(t *testing.T) {
+		_, ok := b3Propagator{}.Extract(func(name string) string {
+			return map[string]string{b3Header: "1"}[name]
+		})
+		assert.False(t, ok)
+	})
+
+	t.Run("url encoded jaeger header", func(t *testing.T) {
+		got, ok := jaegerPropagator{}.Extract(func(name string) string {
+			return map[string]string{jaegerHeader: "4bf92f3577b34da6a3ce929d0e0e4736%3A00f067aa0ba902b7%3A0%3A1"}[name]
+		})
+		assert.True(t, ok)
+		assert.Equal(t, sc, got)
+	})
+
+	t.Run("first configured propagator with a context wins", func(t *testing.T) {
+		spec := config.TracingSpec{Propagators: []string{"unknown", B3Propagator, W3CPropagator}}
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.Set(b3Header, "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1")
+		reqCtx.Request.Header.Set(traceparentHeader, "00-00000000000000000000000000000001-0000000000000001-01")
+
+		got := GetSpanContextFromRequestContext(reqCtx, spec)
+		assert.Equal(t, sc, got)
+	})
+
+	t.Run("custom propagator", func(t *testing.T) {
+		RegisterPropagator("Custom", jaegerPropagator{})
+		req := &fasthttp.Request{}
+		SpanContextToRequest(sc, req, config.TracingSpec{Propagators: []string{"custom"}})
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1", string(req.Header.Peek(jaegerHeader)))
+	})
+
+	t.Run("gRPC metadata", func(t *testing.T) {
+		spec := config.TracingSpec{Propagators: []string{B3MultiPropagator}}
+		ctx := InjectToOutgoingGRPCContext(context.Background(), sc, spec)
+		md, _ := metadata.FromOutgoingContext(ctx)
+		assert.Equal(t, []string{"1"}, md.Get(b3SampledHeader))
+
+		// the binary context of the sidecars isn't sent by apps
+		delete(md, grpcTraceContextKey)
+		got := GetSpanContextFromGRPC(metadata.NewIncomingContext(context.Background(), md), spec)
+		assert.Equal(t, sc, got)
+	})
+}
@@ -2,6 +2,8 @@ package diagnostics
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	diag_utils "github.com/dapr/dapr/pkg/diagnostics/utils"
 	"go.opencensus.io/stats"
@@ -15,8 +17,22 @@ var (
 	failReasonKey = tag.MustNewKey("reason")
 	operationKey  = tag.MustNewKey("operation")
 	actorTypeKey  = tag.MustNewKey("actor_type")
+	serviceKey    = tag.MustNewKey("service")
+	successKey    = tag.MustNewKey("success")
+	compressorKey = tag.MustNewKey("compressor")
+	serverKey     = tag.MustNewKey("server")
+	winnerKey     = tag.MustNewKey("winner")
+	policyKey     = tag.MustNewKey("policy")
+	apiKey        = tag.MustNewKey("api")
+	bulkheadKey   = tag.MustNewKey("bulkhead")
+	topicKey      = tag.MustNewKey("topic")
+	peerAppIDKey  = tag.MustNewKey("peer_app_id")
+	directionKey  = tag.MustNewKey("direction")
 )
 
+// compressionRatioDistribution holds buckets of compressed size divided by uncompressed size
+var compressionRatioDistribution = view.Distribution(0.05, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1, 1.1)
+
 // serviceMetrics holds dapr runtime metric monitoring methods
 type serviceMetrics struct {
 	// component metrics
@@ -29,6 +45,7 @@ type serviceMetrics struct {
 	mtlsInitFailed                *stats.Int64Measure
 	mtlsWorkloadCertRotated       *stats.Int64Measure
 	mtlsWorkloadCertRotatedFailed *stats.Int64Measure
+	mtlsHandshakeFailed           *stats.Int64Measure
 
 	// Actor metrics
 	actorStatusReportTotal       *stats.Int64Measure
@@ -39,6 +56,66 @@ type serviceMetrics struct {
 	actorActivatedFailedTotal    *stats.Int64Measure
 	actorDeactivationTotal       *stats.Int64Measure
 	actorDeactivationFailedTotal *stats.Int64Measure
+	actorActiveCount             *stats.Int64Measure
+	actorTurnLatency             *stats.Float64Measure
+	actorLockWaitTime            *stats.Float64Measure
+
+	// Control plane metrics
+	controlPlaneCallLatency *stats.Float64Measure
+
+	// Compression metrics
+	compressionRatio      *stats.Float64Measure
+	compressionSavedBytes *stats.Int64Measure
+
+	// gRPC server connection metrics
+	grpcServerConnections         *stats.Int64Measure
+	grpcServerConnectionsRejected *stats.Int64Measure
+	grpcServerCallsQueued         *stats.Int64Measure
+	grpcServerCallsRejected       *stats.Int64Measure
+
+	// Request hedging metrics
+	requestHedged *stats.Int64Measure
+
+	// Publish spool metrics
+	pubsubSpoolDepth   *stats.Int64Measure
+	pubsubSpoolBytes   *stats.Int64Measure
+	pubsubSpoolDropped *stats.Int64Measure
+	pubsubDuplicates   *stats.Int64Measure
+
+	// State mirroring metrics
+	stateMirrored            *stats.Int64Measure
+	stateMirrorDivergentKeys *stats.Int64Measure
+
+	// State hedged read metrics
+	stateReadHedged      *stats.Int64Measure
+	stateHedgedReadStale *stats.Int64Measure
+
+	// API deprecation metrics
+	deprecatedAPICalls *stats.Int64Measure
+
+	// Bulkhead metrics
+	bulkheadActive   *stats.Int64Measure
+	bulkheadQueued   *stats.Int64Measure
+	bulkheadRejected *stats.Int64Measure
+
+	// Adaptive concurrency metrics
+	appConcurrencyLimit    *stats.Int64Measure
+	appConcurrencyInflight *stats.Int64Measure
+
+	// Memory budget metrics
+	memoryBudgetUsed     *stats.Int64Measure
+	memoryBudgetLevel    *stats.Int64Measure
+	memoryBudgetRejected *stats.Int64Measure
+
+	// Subscription metrics
+	subscriptionConnected   *stats.Int64Measure
+	subscriptionDelivered   *stats.Int64Measure
+	subscriptionLastMessage *stats.Int64Measure
+	subscriptionLag         *stats.Int64Measure
+
+	// Service invocation payload metrics
+	invocationRequestSize  *stats.Int64Measure
+	invocationResponseSize *stats.Int64Measure
 
 	appID   string
 	ctx     context.Context
@@ -79,6 +156,10 @@ func newServiceMetrics() *serviceMetrics {
 			"runtime/mtls/workload_cert_rotated_fail_total",
 			"The number of the failed workload certificate rotations.",
 			stats.UnitDimensionless),
+		mtlsHandshakeFailed: stats.Int64(
+			"runtime/mtls/handshake_fail_total",
+			"The number of failed mTLS handshakes of the peers of a gRPC server.",
+			stats.UnitDimensionless),
 
 		// Actor
 		actorStatusReportTotal: stats.Int64(
@@ -113,6 +194,168 @@ func newServiceMetrics() *serviceMetrics {
 			"runtime/actor/deactivated_failed_total",
 			"The number of the failed actor deactivation.",
 			stats.UnitDimensionless),
+		actorActiveCount: stats.Int64(
+			"runtime/actor/active_count",
+			"The number of active actors.",
+			stats.UnitDimensionless),
+		actorTurnLatency: stats.Float64(
+			"runtime/actor/turn_latency",
+			"The latency of the actor method invocations by the app.",
+			stats.UnitMilliseconds),
+		actorLockWaitTime: stats.Float64(
+			"runtime/actor/lock_wait_time",
+			"The time actor invocations waited for the turn of the actor.",
+			stats.UnitMilliseconds),
+
+		// Control plane
+		controlPlaneCallLatency: stats.Float64(
+			"runtime/control_plane/call_latency",
+			"The latency of the calls to the placement, operator and sentry services.",
+			stats.UnitMilliseconds),
+
+		// Compression
+		compressionRatio: stats.Float64(
+			"runtime/grpc/compression_ratio",
+			"The compressed size divided by the uncompressed size of messages sent to other Dapr sidecars.",
+			stats.UnitDimensionless),
+		compressionSavedBytes: stats.Int64(
+			"runtime/grpc/compression_saved_bytes",
+			"The number of bytes saved by compressing messages sent to other Dapr sidecars.",
+			stats.UnitBytes),
+
+		// gRPC server connections
+		grpcServerConnections: stats.Int64(
+			"runtime/grpc/server_connections",
+			"The number of open connections to the Dapr gRPC servers.",
+			stats.UnitDimensionless),
+		grpcServerConnectionsRejected: stats.Int64(
+			"runtime/grpc/server_connections_rejected_total",
+			"The number of connections to the Dapr gRPC servers rejected by connection limits.",
+			stats.UnitDimensionless),
+		grpcServerCallsQueued: stats.Int64(
+			"runtime/grpc/server_calls_queued",
+			"The number of calls to the app from other Dapr sidecars waiting for their turn.",
+			stats.UnitDimensionless),
+		grpcServerCallsRejected: stats.Int64(
+			"runtime/grpc/server_calls_rejected_total",
+			"The number of calls to the app from other Dapr sidecars rejected by the queue limit of their peer.",
+			stats.UnitDimensionless),
+
+		// Request hedging
+		requestHedged: stats.Int64(
+			"runtime/invocation/hedged_total",
+			"The number of service and actor invocations duplicated after the hedging delay.",
+			stats.UnitDimensionless),
+
+		// Publish spool
+		pubsubSpoolDepth: stats.Int64(
+			"runtime/pubsub/spool_depth",
+			"The number of events spooled to disk while the pub/sub broker is unreachable.",
+			stats.UnitDimensionless),
+		pubsubSpoolBytes: stats.Int64(
+			"runtime/pubsub/spool_bytes",
+			"The size of the events spooled to disk while the pub/sub broker is unreachable.",
+			stats.UnitBytes),
+		pubsubSpoolDropped: stats.Int64(
+			"runtime/pubsub/spool_dropped_total",
+			"The number of events dropped because the publish spool was full.",
+			stats.UnitDimensionless),
+		pubsubDuplicates: stats.Int64(
+			"runtime/pubsub/duplicates_total",
+			"The number of redelivered events not delivered to the app because they were delivered in the deduplication window.",
+			stats.UnitDimensionless),
+
+		// State mirroring
+		stateMirrored: stats.Int64(
+			"runtime/state/mirror_total",
+			"The number of state writes mirrored to a secondary state store.",
+			stats.UnitDimensionless),
+		stateMirrorDivergentKeys: stats.Int64(
+			"runtime/state/mirror_divergent_keys",
+			"The number of keys whose last write wasn't mirrored to the secondary state store.",
+			stats.UnitDimensionless),
+
+		// State hedged reads
+		stateReadHedged: stats.Int64(
+			"runtime/state/hedged_read_total",
+			"The number of state reads sent to the read replica after the hedging delay.",
+			stats.UnitDimensionless),
+		stateHedgedReadStale: stats.Int64(
+			"runtime/state/hedged_read_stale_total",
+			"The number of state reads answered by the read replica with another ETag than the state store.",
+			stats.UnitDimensionless),
+
+		// API deprecation
+		deprecatedAPICalls: stats.Int64(
+			"runtime/api/deprecated_calls_total",
+			"The number of calls to deprecated Dapr API endpoints.",
+			stats.UnitDimensionless),
+
+		// Bulkheads
+		bulkheadActive: stats.Int64(
+			"runtime/bulkhead/active",
+			"The number of busy workers of the bulkhead of a building block.",
+			stats.UnitDimensionless),
+		bulkheadQueued: stats.Int64(
+			"runtime/bulkhead/queued",
+			"The number of calls waiting for a worker of the bulkhead of a building block.",
+			stats.UnitDimensionless),
+		bulkheadRejected: stats.Int64(
+			"runtime/bulkhead/rejected_total",
+			"The number of calls rejected by the bulkhead of a building block.",
+			stats.UnitDimensionless),
+
+		// Adaptive concurrency
+		appConcurrencyLimit: stats.Int64(
+			"runtime/app_channel/concurrency_limit",
+			"The adaptive limit of the concurrent calls to the app.",
+			stats.UnitDimensionless),
+		appConcurrencyInflight: stats.Int64(
+			"runtime/app_channel/inflight",
+			"The number of calls to the app under the adaptive concurrency limit.",
+			stats.UnitDimensionless),
+
+		// Memory budget
+		memoryBudgetUsed: stats.Int64(
+			"runtime/memory_budget/used_bytes",
+			"The memory used by the sidecar, checked against its memory budget.",
+			stats.UnitBytes),
+		memoryBudgetLevel: stats.Int64(
+			"runtime/memory_budget/level",
+			"The memory pressure of the sidecar: 0 when normal, 1 under pressure, 2 when critical.",
+			stats.UnitDimensionless),
+		memoryBudgetRejected: stats.Int64(
+			"runtime/memory_budget/rejected_total",
+			"The number of API calls rejected for the size of their payload under memory pressure.",
+			stats.UnitDimensionless),
+
+		// Subscriptions
+		subscriptionConnected: stats.Int64(
+			"runtime/pubsub/subscription_connected",
+			"Whether the app is subscribed to a topic of a pub/sub: 1 when subscribed, 0 when subscribing failed.",
+			stats.UnitDimensionless),
+		subscriptionDelivered: stats.Int64(
+			"runtime/pubsub/subscription_delivered_total",
+			"The number of events of a subscription delivered to the app, or whose delivery failed.",
+			stats.UnitDimensionless),
+		subscriptionLastMessage: stats.Int64(
+			"runtime/pubsub/subscription_last_message_time",
+			"The Unix time of the last event of a subscription received from the pub/sub.",
+			stats.UnitSeconds),
+		subscriptionLag: stats.Int64(
+			"runtime/pubsub/subscription_lag",
+			"The approximate number of events of a subscription waiting in the pub/sub, when the pub/sub reports it.",
+			stats.UnitDimensionless),
+
+		// Service invocation payloads
+		invocationRequestSize: stats.Int64(
+			"runtime/invocation/request_size",
+			"The size of the data of the service invocation requests, by peer app and direction.",
+			stats.UnitBytes),
+		invocationResponseSize: stats.Int64(
+			"runtime/invocation/response_size",
+			"The size of the data of the service invocation responses, by peer app and direction.",
+			stats.UnitBytes),
 
 		// TODO: use the correct context for each request
 		ctx:     context.Background(),
@@ -133,6 +376,7 @@ func (s *serviceMetrics) Init(appID string) error {
 		diag_utils.NewMeasureView(s.mtlsInitFailed, []tag.Key{appIDKey, failReasonKey}, view.Count()),
 		diag_utils.NewMeasureView(s.mtlsWorkloadCertRotated, []tag.Key{appIDKey}, view.Count()),
 		diag_utils.NewMeasureView(s.mtlsWorkloadCertRotatedFailed, []tag.Key{appIDKey, failReasonKey}, view.Count()),
+		diag_utils.NewMeasureView(s.mtlsHandshakeFailed, []tag.Key{appIDKey, serverKey, failReasonKey}, view.Count()),
 
 		diag_utils.NewMeasureView(s.actorStatusReportTotal, []tag.Key{appIDKey, actorTypeKey, operationKey}, view.Count()),
 		diag_utils.NewMeasureView(s.actorStatusReportFailedTotal, []tag.Key{appIDKey, actorTypeKey, operationKey, failReasonKey}, view.Count()),
@@ -142,6 +386,52 @@ func (s *serviceMetrics) Init(appID string) error {
 		diag_utils.NewMeasureView(s.actorActivatedFailedTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
 		diag_utils.NewMeasureView(s.actorDeactivationTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
 		diag_utils.NewMeasureView(s.actorDeactivationFailedTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
+		diag_utils.NewMeasureView(s.actorActiveCount, []tag.Key{appIDKey, actorTypeKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.actorTurnLatency, []tag.Key{appIDKey, actorTypeKey}, defaultLatencyDistribution),
+		diag_utils.NewMeasureView(s.actorLockWaitTime, []tag.Key{appIDKey, actorTypeKey}, defaultLatencyDistribution),
+
+		diag_utils.NewMeasureView(s.controlPlaneCallLatency, []tag.Key{appIDKey, serviceKey, operationKey, successKey}, defaultLatencyDistribution),
+
+		diag_utils.NewMeasureView(s.compressionRatio, []tag.Key{appIDKey, compressorKey}, compressionRatioDistribution),
+		diag_utils.NewMeasureView(s.compressionSavedBytes, []tag.Key{appIDKey, compressorKey}, view.Sum()),
+
+		diag_utils.NewMeasureView(s.grpcServerConnections, []tag.Key{appIDKey, serverKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.grpcServerConnectionsRejected, []tag.Key{appIDKey, serverKey, failReasonKey}, view.Count()),
+		diag_utils.NewMeasureView(s.grpcServerCallsQueued, []tag.Key{appIDKey, serverKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.grpcServerCallsRejected, []tag.Key{appIDKey, serverKey}, view.Count()),
+		diag_utils.NewMeasureView(s.requestHedged, []tag.Key{appIDKey, operationKey, winnerKey}, view.Count()),
+
+		diag_utils.NewMeasureView(s.pubsubSpoolDepth, []tag.Key{appIDKey, componentKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.pubsubSpoolBytes, []tag.Key{appIDKey, componentKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.pubsubSpoolDropped, []tag.Key{appIDKey, componentKey, policyKey}, view.Count()),
+		diag_utils.NewMeasureView(s.pubsubDuplicates, []tag.Key{appIDKey, componentKey, topicKey}, view.Count()),
+
+		diag_utils.NewMeasureView(s.stateMirrored, []tag.Key{appIDKey, componentKey, successKey}, view.Count()),
+		diag_utils.NewMeasureView(s.stateMirrorDivergentKeys, []tag.Key{appIDKey, componentKey}, view.LastValue()),
+
+		diag_utils.NewMeasureView(s.stateReadHedged, []tag.Key{appIDKey, componentKey, winnerKey}, view.Count()),
+		diag_utils.NewMeasureView(s.stateHedgedReadStale, []tag.Key{appIDKey, componentKey}, view.Count()),
+
+		diag_utils.NewMeasureView(s.deprecatedAPICalls, []tag.Key{appIDKey, apiKey}, view.Count()),
+
+		diag_utils.NewMeasureView(s.bulkheadActive, []tag.Key{appIDKey, bulkheadKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.bulkheadQueued, []tag.Key{appIDKey, bulkheadKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.bulkheadRejected, []tag.Key{appIDKey, bulkheadKey, failReasonKey}, view.Count()),
+
+		diag_utils.NewMeasureView(s.appConcurrencyLimit, []tag.Key{appIDKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.appConcurrencyInflight, []tag.Key{appIDKey}, view.LastValue()),
+
+		diag_utils.NewMeasureView(s.memoryBudgetUsed, []tag.Key{appIDKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.memoryBudgetLevel, []tag.Key{appIDKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.memoryBudgetRejected, []tag.Key{appIDKey, serverKey}, view.Count()),
+
+		diag_utils.NewMeasureView(s.subscriptionConnected, []tag.Key{appIDKey, componentKey, topicKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.subscriptionDelivered, []tag.Key{appIDKey, componentKey, topicKey, successKey}, view.Count()),
+		diag_utils.NewMeasureView(s.subscriptionLastMessage, []tag.Key{appIDKey, componentKey, topicKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.subscriptionLag, []tag.Key{appIDKey, componentKey, topicKey}, view.LastValue()),
+
+		diag_utils.NewMeasureView(s.invocationRequestSize, []tag.Key{appIDKey, peerAppIDKey, directionKey}, defaultSizeDistribution),
+		diag_utils.NewMeasureView(s.invocationResponseSize, []tag.Key{appIDKey, peerAppIDKey, directionKey}, defaultSizeDistribution),
 	)
 }
 
@@ -204,6 +494,15 @@ func (s *serviceMetrics) MTLSWorkLoadCertRotationFailed(reason string) {
 	}
 }
 
+// MTLSHandshakeFailed records metric when the mTLS handshake of a peer of a gRPC server failed
+func (s *serviceMetrics) MTLSHandshakeFailed(server, reason string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx, diag_utils.WithTags(appIDKey, s.appID, serverKey, server, failReasonKey, reason),
+			s.mtlsHandshakeFailed.M(1))
+	}
+}
+
 // ActorStatusReported records metrics when status is reported to placement service.
 func (s *serviceMetrics) ActorStatusReported(operation string) {
 	if s.enabled {
@@ -280,3 +579,286 @@ func (s *serviceMetrics) ActorDeactivationFailed(actorType, reason string) {
 			s.actorDeactivationFailedTotal.M(1))
 	}
 }
+
+// ActorActiveCount records the number of active actors of an actor type.
+func (s *serviceMetrics) ActorActiveCount(actorType string, count int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, actorTypeKey, actorType),
+			s.actorActiveCount.M(count))
+	}
+}
+
+// ActorTurnCompleted records the time an actor invocation waited for the turn of the actor and the latency of the turn.
+func (s *serviceMetrics) ActorTurnCompleted(actorType string, lockWait, elapsed float64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, actorTypeKey, actorType),
+			s.actorLockWaitTime.M(lockWait),
+			s.actorTurnLatency.M(elapsed))
+	}
+}
+
+// ControlPlaneCallCompleted records the latency of a call to a control plane service.
+func (s *serviceMetrics) ControlPlaneCallCompleted(service, operation string, success bool, elapsed float64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, serviceKey, service, operationKey, operation, successKey, strconv.FormatBool(success)),
+			s.controlPlaneCallLatency.M(elapsed))
+	}
+}
+
+// MessageCompressed records the compression ratio and saved bytes of a compressed message.
+func (s *serviceMetrics) MessageCompressed(compressor string, uncompressed, compressed int64) {
+	if s.enabled && uncompressed > 0 {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, compressorKey, compressor),
+			s.compressionRatio.M(float64(compressed)/float64(uncompressed)),
+			s.compressionSavedBytes.M(uncompressed-compressed))
+	}
+}
+
+// GRPCServerConnections records the number of open connections to a gRPC server.
+func (s *serviceMetrics) GRPCServerConnections(server string, connections int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, serverKey, server),
+			s.grpcServerConnections.M(connections))
+	}
+}
+
+// GRPCServerConnectionRejected records a connection to a gRPC server rejected by a connection limit.
+func (s *serviceMetrics) GRPCServerConnectionRejected(server, reason string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, serverKey, server, failReasonKey, reason),
+			s.grpcServerConnectionsRejected.M(1))
+	}
+}
+
+// GRPCServerCallsQueued records the number of calls to the app waiting for their turn.
+func (s *serviceMetrics) GRPCServerCallsQueued(server string, queued int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, serverKey, server),
+			s.grpcServerCallsQueued.M(queued))
+	}
+}
+
+// GRPCServerCallRejected records a call to the app rejected by the queue limit of its peer.
+func (s *serviceMetrics) GRPCServerCallRejected(server string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, serverKey, server),
+			s.grpcServerCallsRejected.M(1))
+	}
+}
+
+// RequestHedged records an invocation that was duplicated after the hedging delay and which of the two calls answered first.
+func (s *serviceMetrics) RequestHedged(operation string, hedgeWon bool) {
+	if s.enabled {
+		winner := "original"
+		if hedgeWon {
+			winner = "hedge"
+		}
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, operationKey, operation, winnerKey, winner),
+			s.requestHedged.M(1))
+	}
+}
+
+// PubSubSpoolChanged records the number and size of the events in the publish spool of a pub/sub component.
+func (s *serviceMetrics) PubSubSpoolChanged(component string, events, bytes int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component),
+			s.pubsubSpoolDepth.M(events),
+			s.pubsubSpoolBytes.M(bytes))
+	}
+}
+
+// PubSubSpoolDropped records an event dropped by the eviction policy of a full publish spool.
+func (s *serviceMetrics) PubSubSpoolDropped(component, policy string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component, policyKey, policy),
+			s.pubsubSpoolDropped.M(1))
+	}
+}
+
+// PubSubDuplicateDropped records a redelivered event of a subscription not delivered to the app.
+func (s *serviceMetrics) PubSubDuplicateDropped(component, topic string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component, topicKey, topic),
+			s.pubsubDuplicates.M(1))
+	}
+}
+
+// StateMirrored records a state write mirrored to the secondary store of a state store, or dropped or failed.
+func (s *serviceMetrics) StateMirrored(component string, success bool) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component, successKey, strconv.FormatBool(success)),
+			s.stateMirrored.M(1))
+	}
+}
+
+// StateMirrorDivergence records the number of keys of a state store that differ in its secondary store.
+func (s *serviceMetrics) StateMirrorDivergence(component string, keys int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component),
+			s.stateMirrorDivergentKeys.M(keys))
+	}
+}
+
+// StateReadHedged records a state read sent to the read replica of a state store and which of the two answered first.
+func (s *serviceMetrics) StateReadHedged(component string, replicaWon bool) {
+	if s.enabled {
+		winner := "primary"
+		if replicaWon {
+			winner = "replica"
+		}
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component, winnerKey, winner),
+			s.stateReadHedged.M(1))
+	}
+}
+
+// StateHedgedReadStale records a read answered by the read replica of a state store with a stale ETag.
+func (s *serviceMetrics) StateHedgedReadStale(component string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component),
+			s.stateHedgedReadStale.M(1))
+	}
+}
+
+// DeprecatedAPICalled records a call to a deprecated Dapr API endpoint.
+func (s *serviceMetrics) DeprecatedAPICalled(api string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, apiKey, api),
+			s.deprecatedAPICalls.M(1))
+	}
+}
+
+// BulkheadChanged records the number of busy workers and of waiting calls of the bulkhead of a building block.
+func (s *serviceMetrics) BulkheadChanged(bulkhead string, active, queued int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, bulkheadKey, bulkhead),
+			s.bulkheadActive.M(active),
+			s.bulkheadQueued.M(queued))
+	}
+}
+
+// BulkheadRejected records a call rejected by the bulkhead of a building block.
+func (s *serviceMetrics) BulkheadRejected(bulkhead, reason string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, bulkheadKey, bulkhead, failReasonKey, reason),
+			s.bulkheadRejected.M(1))
+	}
+}
+
+// AppConcurrencyChanged records the adaptive concurrency limit of the app and the number of calls under it.
+func (s *serviceMetrics) AppConcurrencyChanged(limit, inflight int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID),
+			s.appConcurrencyLimit.M(limit),
+			s.appConcurrencyInflight.M(inflight))
+	}
+}
+
+// MemoryBudgetChecked records the memory used by the sidecar and its memory pressure level.
+func (s *serviceMetrics) MemoryBudgetChecked(used, level int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID),
+			s.memoryBudgetUsed.M(used),
+			s.memoryBudgetLevel.M(level))
+	}
+}
+
+// MemoryBudgetRejected records an API call of the HTTP or gRPC server rejected under memory pressure.
+func (s *serviceMetrics) MemoryBudgetRejected(server string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, serverKey, server),
+			s.memoryBudgetRejected.M(1))
+	}
+}
+
+// SubscriptionConnected records whether the app is subscribed to a topic of a pub/sub.
+func (s *serviceMetrics) SubscriptionConnected(component, topic string, connected bool) {
+	if s.enabled {
+		var v int64
+		if connected {
+			v = 1
+		}
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component, topicKey, topic),
+			s.subscriptionConnected.M(v))
+	}
+}
+
+// SubscriptionDelivered records an event of a subscription delivered to the app, or whose delivery failed.
+func (s *serviceMetrics) SubscriptionDelivered(component, topic string, success bool, received time.Time) {
+	if s.enabled {
+		tags := diag_utils.WithTags(appIDKey, s.appID, componentKey, component, topicKey, topic)
+		stats.RecordWithTags(s.ctx, tags, s.subscriptionLastMessage.M(received.Unix()))
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component, topicKey, topic, successKey, strconv.FormatBool(success)),
+			s.subscriptionDelivered.M(1))
+	}
+}
+
+// SubscriptionLag records the approximate number of events of a subscription waiting in the pub/sub.
+func (s *serviceMetrics) SubscriptionLag(component, topic string, lag int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component, topicKey, topic),
+			s.subscriptionLag.M(lag))
+	}
+}
+
+// ServiceInvocationPayload records the sizes of the request and response data of a service invocation with a peer app,
+// also summed by DefaultInvocationPayloads when metrics are disabled.
+func (s *serviceMetrics) ServiceInvocationPayload(direction, peerAppID string, request, response int64) {
+	DefaultInvocationPayloads.Record(direction, peerAppID, request, response)
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, peerAppIDKey, peerAppID, directionKey, direction),
+			s.invocationRequestSize.M(request),
+			s.invocationResponseSize.M(response))
+	}
+}
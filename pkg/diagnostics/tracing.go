@@ -28,6 +28,14 @@ const (
 	daprHeaderPrefix = "dapr-"
 )
 
+// tracingBuildingBlocks are the operations of the building blocks whose tracing can be disabled as a whole
+var tracingBuildingBlocks = map[string][]string{
+	"state":    {"GetState", "GetBulkState", "SaveState", "DeleteState", "DeleteBulkState", "ExecuteStateTransaction", "QueryStateAlpha1"},
+	"secrets":  {"GetSecret", "GetBulkSecret"},
+	"bindings": {"OutputBindingMessage", "InvokeBinding"},
+	"pubsub":   {"PublishEvent", "SubscribeTopicEvents"},
+}
+
 // NewContext returns a new context with the given SpanContext attached.
 func NewContext(ctx context.Context, spanContext trace.SpanContext) context.Context {
 	return context.WithValue(ctx, DaprTraceContextKey{}, spanContext)
@@ -39,14 +47,17 @@ func FromContext(ctx context.Context) trace.SpanContext {
 	return sc
 }
 
-func startTracingSpanInternal(ctx context.Context, uri, samplingRate string, spanKind int) (context.Context, *trace.Span) {
+func startTracingSpanInternal(ctx context.Context, uri string, spec config.TracingSpec, spanKind int) (context.Context, *trace.Span) {
 	var span *trace.Span
 	name := createSpanName(uri)
 
-	rate := diag_utils.GetTraceSamplingRate(samplingRate)
+	rate := diag_utils.GetTraceSamplingRate(spec.SamplingRate)
 
 	// TODO : Continue using ProbabilitySampler till Go SDK starts supporting RateLimiting sampler
 	probSamplerOption := trace.WithSampler(trace.ProbabilitySampler(rate))
+	if isTracingDisabled(name, spec) {
+		probSamplerOption = trace.WithSampler(trace.NeverSample())
+	}
 	kindOption := trace.WithSpanKind(spanKind)
 
 	sc := FromContext(ctx)
@@ -61,6 +72,26 @@ func startTracingSpanInternal(ctx context.Context, uri, samplingRate string, spa
 	return ctx, span
 }
 
+// isTracingDisabled returns whether the spec disables the tracing of the operation of the span, named as
+// <operation>: <resource>
+func isTracingDisabled(name string, spec config.TracingSpec) bool {
+	operation := name
+	if i := strings.Index(name, ":"); i > 0 {
+		operation = name[:i]
+	}
+	for _, disabled := range spec.Disabled {
+		if strings.EqualFold(disabled, operation) {
+			return true
+		}
+		for _, o := range tracingBuildingBlocks[strings.ToLower(disabled)] {
+			if o == operation {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetDefaultSpanContext returns default span context when not provided by the client
 func GetDefaultSpanContext(spec config.TracingSpec) trace.SpanContext {
 	spanContext := trace.SpanContext{}
@@ -0,0 +1,128 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package discovery
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/dapr/components-contrib/servicediscovery"
+	"github.com/dapr/dapr/pkg/config"
+	consul "github.com/hashicorp/consul/api"
+)
+
+const (
+	defaultConsulCheckInterval                  = "10s"
+	defaultConsulDeregisterCriticalServiceAfter = "1m"
+)
+
+// ConsulResolver resolves app ids to the healthy instances registered in Consul under the app id.
+// The sidecar registers itself with the local Consul agent unless registration is skipped.
+type ConsulResolver struct {
+	client     *consul.Client
+	spec       config.ConsulResolverSpec
+	serviceID  string
+	registered bool
+}
+
+// NewConsulResolver returns a Consul resolver. Init configures it from the name resolution configuration.
+func NewConsulResolver() *ConsulResolver {
+	return &ConsulResolver{}
+}
+
+// Init creates the Consul client and validates the health check settings
+func (c *ConsulResolver) Init(spec config.ConsulResolverSpec) error {
+	if spec.CheckInterval == "" {
+		spec.CheckInterval = defaultConsulCheckInterval
+	}
+	if spec.DeregisterCriticalServiceAfter == "" {
+		spec.DeregisterCriticalServiceAfter = defaultConsulDeregisterCriticalServiceAfter
+	}
+	for _, d := range []string{spec.CheckInterval, spec.DeregisterCriticalServiceAfter} {
+		if _, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("invalid consul duration %s: %s", d, err)
+		}
+	}
+
+	conf := consul.DefaultConfig()
+	if spec.Address != "" {
+		conf.Address = spec.Address
+	}
+	if spec.Datacenter != "" {
+		conf.Datacenter = spec.Datacenter
+	}
+	client, err := consul.NewClient(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create consul client: %s", err)
+	}
+
+	c.client = client
+	c.spec = spec
+	return nil
+}
+
+// ResolveID resolves an app id to the address of a random healthy sidecar registered for it
+func (c *ConsulResolver) ResolveID(req servicediscovery.ResolveRequest) (string, error) {
+	entries, _, err := c.client.Health().Service(req.ID, "", true, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to query consul for %s: %s", req.ID, err)
+	}
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no healthy instances of %s registered in consul", req.ID)
+	}
+
+	entry := entries[rand.Intn(len(entries))]
+	host := entry.Service.Address
+	if host == "" {
+		host = entry.Node.Address
+	}
+	return net.JoinHostPort(host, strconv.Itoa(entry.Service.Port)), nil
+}
+
+// Register registers the sidecar under the app id with a TCP health check of its internal gRPC address
+func (c *ConsulResolver) Register(id, host string, port int) error {
+	if c.spec.SkipRegistration {
+		return nil
+	}
+
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	serviceID := fmt.Sprintf("%s-%s", id, address)
+	err := c.client.Agent().ServiceRegister(&consul.AgentServiceRegistration{
+		ID:      serviceID,
+		Name:    id,
+		Tags:    c.spec.Tags,
+		Address: host,
+		Port:    port,
+		Check: &consul.AgentServiceCheck{
+			Name:                           fmt.Sprintf("dapr sidecar %s", address),
+			TCP:                            address,
+			Interval:                       c.spec.CheckInterval,
+			DeregisterCriticalServiceAfter: c.spec.DeregisterCriticalServiceAfter,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register %s with consul: %s", id, err)
+	}
+
+	c.serviceID = serviceID
+	c.registered = true
+	return nil
+}
+
+// Deregister removes the registration of the sidecar from the local Consul agent
+func (c *ConsulResolver) Deregister() error {
+	if !c.registered {
+		return nil
+	}
+	if err := c.client.Agent().ServiceDeregister(c.serviceID); err != nil {
+		return fmt.Errorf("failed to deregister %s from consul: %s", c.serviceID, err)
+	}
+	c.registered = false
+	return nil
+}
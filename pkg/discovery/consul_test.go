@@ -0,0 +1,96 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dapr/components-contrib/servicediscovery"
+	"github.com/dapr/dapr/pkg/config"
+	consul "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConsulAgent keeps the services registered through the agent API and serves them as healthy
+type fakeConsulAgent struct {
+	lock     sync.Mutex
+	services map[string]consul.AgentServiceRegistration
+}
+
+func (f *fakeConsulAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	switch {
+	case r.URL.Path == "/v1/agent/service/register":
+		var s consul.AgentServiceRegistration
+		json.NewDecoder(r.Body).Decode(&s)
+		f.services[s.ID] = s
+	case strings.HasPrefix(r.URL.Path, "/v1/agent/service/deregister/"):
+		delete(f.services, strings.TrimPrefix(r.URL.Path, "/v1/agent/service/deregister/"))
+	case strings.HasPrefix(r.URL.Path, "/v1/health/service/"):
+		name := strings.TrimPrefix(r.URL.Path, "/v1/health/service/")
+		entries := []*consul.ServiceEntry{}
+		for _, s := range f.services {
+			if s.Name == name {
+				entries = append(entries, &consul.ServiceEntry{
+					Node:    &consul.Node{Address: "10.0.0.1"},
+					Service: &consul.AgentService{ID: s.ID, Service: s.Name, Address: s.Address, Port: s.Port},
+				})
+			}
+		}
+		json.NewEncoder(w).Encode(entries)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestConsulResolver(t *testing.T) {
+	agent := &fakeConsulAgent{services: map[string]consul.AgentServiceRegistration{}}
+	server := httptest.NewServer(agent)
+	defer server.Close()
+
+	r := NewConsulResolver()
+	err := r.Init(config.ConsulResolverSpec{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+		Tags:    []string{"dapr"},
+	})
+	assert.NoError(t, err)
+
+	t.Run("registers with a tcp health check", func(t *testing.T) {
+		assert.NoError(t, r.Register("app1", "fd00::5", 50002))
+
+		s, ok := agent.services["app1-[fd00::5]:50002"]
+		assert.True(t, ok)
+		assert.Equal(t, "app1", s.Name)
+		assert.Equal(t, []string{"dapr"}, s.Tags)
+		assert.Equal(t, "[fd00::5]:50002", s.Check.TCP)
+		assert.Equal(t, defaultConsulCheckInterval, s.Check.Interval)
+	})
+
+	t.Run("resolves registered instances", func(t *testing.T) {
+		address, err := r.ResolveID(servicediscovery.ResolveRequest{ID: "app1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "[fd00::5]:50002", address)
+
+		_, err = r.ResolveID(servicediscovery.ResolveRequest{ID: "app2"})
+		assert.Error(t, err)
+	})
+
+	t.Run("deregisters on shutdown", func(t *testing.T) {
+		assert.NoError(t, r.Deregister())
+		assert.Empty(t, agent.services)
+	})
+
+	t.Run("invalid check interval", func(t *testing.T) {
+		assert.Error(t, NewConsulResolver().Init(config.ConsulResolverSpec{CheckInterval: "often"}))
+	})
+}
@@ -0,0 +1,278 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package discovery
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/servicediscovery"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/miekg/dns"
+)
+
+const (
+	defaultDNSTemplate = "{id}"
+	resolvConfPath     = "/etc/resolv.conf"
+	dnsQueryTimeout    = time.Second * 2
+)
+
+// DNSResolver resolves app ids with A, AAAA or SRV lookups of a DNS name built from a template.
+// Answers are cached for the TTL of their records.
+type DNSResolver struct {
+	template string
+	srv      bool
+	servers  []string
+	search   *dns.ClientConfig
+	exchange func(m *dns.Msg, server string) (*dns.Msg, error)
+
+	lock  sync.RWMutex
+	cache map[string]dnsCacheEntry
+}
+
+type dnsCacheEntry struct {
+	host    string
+	port    int
+	expires time.Time
+}
+
+// NewDNSResolver returns a DNS resolver using the name servers of /etc/resolv.conf.
+// Init configures it from the name resolution configuration.
+func NewDNSResolver() *DNSResolver {
+	client := &dns.Client{Timeout: dnsQueryTimeout}
+	return &DNSResolver{
+		template: defaultDNSTemplate,
+		exchange: func(m *dns.Msg, server string) (*dns.Msg, error) {
+			r, _, err := client.Exchange(m, server)
+			return r, err
+		},
+		cache: map[string]dnsCacheEntry{},
+	}
+}
+
+// Init configures the name template, SRV lookups and name servers of the resolver
+func (d *DNSResolver) Init(spec config.DNSResolverSpec) error {
+	if spec.Template != "" {
+		d.template = spec.Template
+	}
+	d.srv = spec.SRV
+
+	if len(spec.Servers) > 0 {
+		d.servers = nil
+		for _, s := range spec.Servers {
+			if _, _, err := net.SplitHostPort(s); err != nil {
+				s = net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(s, "["), "]"), "53")
+			}
+			d.servers = append(d.servers, s)
+		}
+		return nil
+	}
+
+	conf, err := dns.ClientConfigFromFile(resolvConfPath)
+	if err != nil {
+		return fmt.Errorf("failed to read name servers from %s: %s", resolvConfPath, err)
+	}
+	if len(conf.Servers) == 0 {
+		return fmt.Errorf("no name servers in %s", resolvConfPath)
+	}
+	d.search = conf
+	d.servers = nil
+	for _, s := range conf.Servers {
+		d.servers = append(d.servers, net.JoinHostPort(s, conf.Port))
+	}
+	return nil
+}
+
+// ResolveID resolves an app id to the address of its Dapr sidecar.
+// Without SRV lookups the sidecar is expected on the internal gRPC port of the request.
+func (d *DNSResolver) ResolveID(req servicediscovery.ResolveRequest) (string, error) {
+	name := strings.NewReplacer("{id}", req.ID, "{namespace}", req.Namespace).Replace(d.template)
+
+	entry, ok := d.cached(name)
+	if !ok {
+		var err error
+		entry, err = d.lookup(name)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	port := entry.port
+	if port == 0 {
+		port = req.Port
+	}
+	return net.JoinHostPort(entry.host, strconv.Itoa(port)), nil
+}
+
+// Flush empties the cache of the resolver
+func (d *DNSResolver) Flush() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.cache = map[string]dnsCacheEntry{}
+}
+
+func (d *DNSResolver) cached(name string) (dnsCacheEntry, bool) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	entry, ok := d.cache[name]
+	if !ok || time.Now().After(entry.expires) {
+		return dnsCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (d *DNSResolver) lookup(name string) (dnsCacheEntry, error) {
+	var entry dnsCacheEntry
+	var ttl uint32
+	var err error
+	if d.srv {
+		entry.host, entry.port, ttl, err = d.lookupSRV(name)
+	} else {
+		entry.host, ttl, err = d.lookupHost(name, nil)
+	}
+	if err != nil {
+		return entry, err
+	}
+
+	if ttl > 0 {
+		entry.expires = time.Now().Add(time.Duration(ttl) * time.Second)
+		d.lock.Lock()
+		d.cache[name] = entry
+		d.lock.Unlock()
+	}
+	return entry, nil
+}
+
+// lookupSRV picks a target among the SRV records with the lowest priority, weighted by their weight
+func (d *DNSResolver) lookupSRV(name string) (string, int, uint32, error) {
+	r, err := d.query(name, dns.TypeSRV)
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	var candidates []*dns.SRV
+	for _, rr := range r.Answer {
+		srv, ok := rr.(*dns.SRV)
+		if !ok {
+			continue
+		}
+		if len(candidates) == 0 || srv.Priority < candidates[0].Priority {
+			candidates = []*dns.SRV{srv}
+		} else if srv.Priority == candidates[0].Priority {
+			candidates = append(candidates, srv)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", 0, 0, fmt.Errorf("no SRV records found for %s", name)
+	}
+
+	target := pickSRV(candidates)
+	host, ttl, err := d.lookupHost(target.Target, r.Extra)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if target.Hdr.Ttl < ttl {
+		ttl = target.Hdr.Ttl
+	}
+	return host, int(target.Port), ttl, nil
+}
+
+func pickSRV(candidates []*dns.SRV) *dns.SRV {
+	total := 0
+	for _, c := range candidates {
+		total += int(c.Weight)
+	}
+	if total == 0 {
+		return candidates[rand.Intn(len(candidates))]
+	}
+
+	n := rand.Intn(total)
+	for _, c := range candidates {
+		if n < int(c.Weight) {
+			return c
+		}
+		n -= int(c.Weight)
+	}
+	return candidates[len(candidates)-1]
+}
+
+// lookupHost returns the first IPv4 address of name, or its first IPv6 address if it has none.
+// Address records from extra, e.g. the additional section of a SRV answer, are used before querying.
+func (d *DNSResolver) lookupHost(name string, extra []dns.RR) (string, uint32, error) {
+	if ip := net.ParseIP(strings.TrimSuffix(name, ".")); ip != nil {
+		return ip.String(), 0, nil
+	}
+
+	if host, ttl, ok := addressFromRecords(name, extra); ok {
+		return host, ttl, nil
+	}
+
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		r, err := d.query(name, qtype)
+		if err != nil {
+			continue
+		}
+		if host, ttl, ok := addressFromRecords("", r.Answer); ok {
+			return host, ttl, nil
+		}
+	}
+	return "", 0, fmt.Errorf("no address records found for %s", name)
+}
+
+// addressFromRecords returns the first A record, or the first AAAA record, for name. An empty name matches any record.
+func addressFromRecords(name string, records []dns.RR) (string, uint32, bool) {
+	var v6 *dns.AAAA
+	for _, rr := range records {
+		if name != "" && !strings.EqualFold(rr.Header().Name, dns.Fqdn(name)) {
+			continue
+		}
+		switch record := rr.(type) {
+		case *dns.A:
+			return record.A.String(), record.Hdr.Ttl, true
+		case *dns.AAAA:
+			if v6 == nil {
+				v6 = record
+			}
+		}
+	}
+	if v6 != nil {
+		return v6.AAAA.String(), v6.Hdr.Ttl, true
+	}
+	return "", 0, false
+}
+
+// query sends the question to the name servers in order, trying the search domains of /etc/resolv.conf for relative names
+func (d *DNSResolver) query(name string, qtype uint16) (*dns.Msg, error) {
+	names := []string{dns.Fqdn(name)}
+	if d.search != nil {
+		names = d.search.NameList(name)
+	}
+
+	err := fmt.Errorf("no name servers configured")
+	for _, n := range names {
+		m := new(dns.Msg)
+		m.SetQuestion(n, qtype)
+		for _, server := range d.servers {
+			r, exchangeErr := d.exchange(m, server)
+			if exchangeErr != nil {
+				err = exchangeErr
+				continue
+			}
+			if r.Rcode != dns.RcodeSuccess || len(r.Answer) == 0 {
+				err = fmt.Errorf("%s lookup of %s returned %s", dns.TypeToString[qtype], n, dns.RcodeToString[r.Rcode])
+				break
+			}
+			return r, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,110 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package discovery
+
+import (
+	"net"
+	"testing"
+
+	"github.com/dapr/components-contrib/servicediscovery"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDNS answers queries from a fixed set of records and counts the queries it receives
+type fakeDNS struct {
+	records []dns.RR
+	queries int
+}
+
+func (f *fakeDNS) exchange(m *dns.Msg, server string) (*dns.Msg, error) {
+	f.queries++
+	r := new(dns.Msg)
+	r.SetReply(m)
+	q := m.Question[0]
+	for _, rr := range f.records {
+		if rr.Header().Name == q.Name && rr.Header().Rrtype == q.Qtype {
+			r.Answer = append(r.Answer, rr)
+		}
+	}
+	if len(r.Answer) == 0 {
+		r.Rcode = dns.RcodeNameError
+	}
+	return r, nil
+}
+
+func newTestDNSResolver(t *testing.T, spec config.DNSResolverSpec, records ...string) (*DNSResolver, *fakeDNS) {
+	fake := &fakeDNS{}
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		assert.NoError(t, err)
+		fake.records = append(fake.records, rr)
+	}
+
+	spec.Servers = []string{"127.0.0.1"}
+	r := NewDNSResolver()
+	assert.NoError(t, r.Init(spec))
+	r.exchange = fake.exchange
+	return r, fake
+}
+
+func TestDNSResolver(t *testing.T) {
+	t.Run("resolves A records on the internal grpc port", func(t *testing.T) {
+		r, _ := newTestDNSResolver(t, config.DNSResolverSpec{Template: "{id}.{namespace}.dapr.internal"},
+			"app1.default.dapr.internal. 60 IN A 10.0.0.5")
+
+		address, err := r.ResolveID(servicediscovery.ResolveRequest{ID: "app1", Namespace: "default", Port: 50002})
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.5:50002", address)
+	})
+
+	t.Run("falls back to AAAA records", func(t *testing.T) {
+		r, _ := newTestDNSResolver(t, config.DNSResolverSpec{},
+			"app1. 60 IN AAAA fd00::5")
+
+		address, err := r.ResolveID(servicediscovery.ResolveRequest{ID: "app1", Port: 50002})
+		assert.NoError(t, err)
+		assert.Equal(t, net.JoinHostPort("fd00::5", "50002"), address)
+	})
+
+	t.Run("resolves SRV records with the lowest priority", func(t *testing.T) {
+		r, _ := newTestDNSResolver(t, config.DNSResolverSpec{Template: "_dapr._tcp.{id}.example.com", SRV: true},
+			"_dapr._tcp.app1.example.com. 60 IN SRV 10 0 30001 node1.example.com.",
+			"_dapr._tcp.app1.example.com. 60 IN SRV 20 0 30002 node2.example.com.",
+			"node1.example.com. 60 IN A 192.168.1.1",
+			"node2.example.com. 60 IN A 192.168.1.2")
+
+		address, err := r.ResolveID(servicediscovery.ResolveRequest{ID: "app1", Port: 50002})
+		assert.NoError(t, err)
+		assert.Equal(t, "192.168.1.1:30001", address)
+	})
+
+	t.Run("caches answers for their ttl", func(t *testing.T) {
+		r, fake := newTestDNSResolver(t, config.DNSResolverSpec{},
+			"cached. 60 IN A 10.0.0.6",
+			"uncached. 0 IN A 10.0.0.7")
+
+		for i := 0; i < 3; i++ {
+			_, err := r.ResolveID(servicediscovery.ResolveRequest{ID: "cached", Port: 50002})
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 1, fake.queries)
+
+		for i := 0; i < 3; i++ {
+			_, err := r.ResolveID(servicediscovery.ResolveRequest{ID: "uncached", Port: 50002})
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 4, fake.queries)
+	})
+
+	t.Run("unknown app", func(t *testing.T) {
+		r, _ := newTestDNSResolver(t, config.DNSResolverSpec{})
+
+		_, err := r.ResolveID(servicediscovery.ResolveRequest{ID: "missing", Port: 50002})
+		assert.Error(t, err)
+	})
+}
@@ -8,23 +8,40 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/dapr/components-contrib/servicediscovery"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/grandcat/zeroconf"
 )
 
 var log = logger.NewLogger("dapr.runtime.discovery")
 
-// RegisterMDNS uses mdns to publish an entry of the service to a local network
-func RegisterMDNS(id string, port int) error {
+// RegisterMDNS uses mdns to publish an entry of the service to a local network.
+// The entry announces the given ips, or the addresses of all interfaces when none are given.
+func RegisterMDNS(id string, port int, ips []string) error {
+	host, _ := os.Hostname()
+	info := []string{id}
+	register := func() (*zeroconf.Server, error) {
+		return zeroconf.Register(host, id, "local.", port, info, nil)
+	}
+	if len(ips) > 0 {
+		addresses, err := resolveIPs(ips)
+		if err != nil {
+			return err
+		}
+		register = func() (*zeroconf.Server, error) {
+			return zeroconf.RegisterProxy(host, id, "local.", port, host, addresses, info, nil)
+		}
+	}
+
 	go func() {
-		host, _ := os.Hostname()
-		info := []string{id}
-		server, err := zeroconf.Register(host, id, "local.", port, info, nil)
+		server, err := register()
 		if err != nil {
 			log.Errorf("error from zeroconf register: %s", err)
 			return
@@ -38,38 +55,117 @@ func RegisterMDNS(id string, port int) error {
 	return nil
 }
 
+// resolveIPs resolves host names to the IP addresses mdns entries announce
+func resolveIPs(hosts []string) ([]string, error) {
+	ips := []string{}
+	for _, h := range hosts {
+		if net.ParseIP(h) != nil {
+			ips = append(ips, h)
+			continue
+		}
+		resolved, err := net.LookupHost(h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %s", h, err)
+		}
+		ips = append(ips, resolved...)
+	}
+	return ips, nil
+}
+
 // LookupPortMDNS uses mdns to find the port of a given service entry on a local network
 func LookupPortMDNS(id string) (int, error) {
+	entry, err := lookupEntryMDNS(id, func(*zeroconf.ServiceEntry) bool { return true })
+	if err != nil {
+		return -1, err
+	}
+	return entry.Port, nil
+}
+
+// NewMDNSResolver returns a resolver that resolves app ids to the addresses published with mdns. Unlike the mdns
+// resolver of components-contrib, which resolves them to localhost, it resolves them to the IPv4 or IPv6 addresses of
+// the hosts of the sidecars.
+func NewMDNSResolver() servicediscovery.Resolver {
+	return &mdnsResolver{}
+}
+
+type mdnsResolver struct{}
+
+// ResolveID resolves an app id to the address and internal gRPC port of its Dapr sidecar.
+// IPv4 addresses are preferred, IPv6 addresses are used for hosts without one.
+func (m *mdnsResolver) ResolveID(req servicediscovery.ResolveRequest) (string, error) {
+	return LookupAddressMDNS(req.ID)
+}
+
+// LookupAddressMDNS uses mdns to find the address of a given service entry on a local network.
+// The entries without an address are skipped.
+func LookupAddressMDNS(id string) (string, error) {
+	entry, err := lookupEntryMDNS(id, func(entry *zeroconf.ServiceEntry) bool {
+		_, ok := entryAddress(entry)
+		return ok
+	})
+	if err != nil {
+		return "", err
+	}
+	address, _ := entryAddress(entry)
+	return address, nil
+}
+
+// lookupEntryMDNS uses mdns to find the first usable entry of a given service on a local network, for a second at most
+func lookupEntryMDNS(id string, usable func(*zeroconf.ServiceEntry) bool) (*zeroconf.ServiceEntry, error) {
 	resolver, err := zeroconf.NewResolver(nil)
 	if err != nil {
-		log.Fatalf("Failed to initialize resolver: %v", err.Error())
+		return nil, fmt.Errorf("failed to initialize resolver: %s", err)
 	}
 
-	port := -1
 	entries := make(chan *zeroconf.ServiceEntry)
+	found := make(chan *zeroconf.ServiceEntry, 1)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 
-	go func(results <-chan *zeroconf.ServiceEntry) {
-		for entry := range results {
-			for _, text := range entry.Text {
-				if text == id {
-					port = entry.Port
-					cancel()
-					return
-				}
+	// the results are read until the browsing closes them, so that it's never blocked on them
+	go func() {
+		matched := false
+		for entry := range entries {
+			if !matched && announces(entry, id) && usable(entry) {
+				found <- entry
+				matched = true
 			}
 		}
-	}(entries)
+	}()
 
 	err = resolver.Browse(ctx, id, "local.", entries)
 	if err != nil {
-		return -1, fmt.Errorf("failed to browse: %s", err.Error())
+		return nil, fmt.Errorf("failed to browse: %s", err.Error())
+	}
+
+	select {
+	case entry := <-found:
+		return entry, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("couldn't find service: %s", id)
+	}
+}
+
+// announces returns whether the entry announces the id
+func announces(entry *zeroconf.ServiceEntry, id string) bool {
+	for _, text := range entry.Text {
+		if text == id {
+			return true
+		}
 	}
+	return false
+}
 
-	<-ctx.Done()
-	if port == -1 {
-		return port, fmt.Errorf("couldn't find service: %s", id)
+// entryAddress returns the address of the sidecar of the entry, with its IPv4 address if it has one and its IPv6
+// address otherwise. It returns false for the entries without an address.
+func entryAddress(entry *zeroconf.ServiceEntry) (string, bool) {
+	var host string
+	if len(entry.AddrIPv4) > 0 {
+		host = entry.AddrIPv4[0].String()
+	} else if len(entry.AddrIPv6) > 0 {
+		host = entry.AddrIPv6[0].String()
+	} else {
+		return "", false
 	}
-	return port, nil
+	return net.JoinHostPort(host, strconv.Itoa(entry.Port)), true
 }
@@ -0,0 +1,53 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package discovery
+
+import (
+	"net"
+	"testing"
+
+	"github.com/grandcat/zeroconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryAddress(t *testing.T) {
+	newEntry := func(ipv4, ipv6 []net.IP) *zeroconf.ServiceEntry {
+		entry := zeroconf.NewServiceEntry("host", "orders", "local.")
+		entry.Port = 50002
+		entry.AddrIPv4, entry.AddrIPv6 = ipv4, ipv6
+		return entry
+	}
+
+	t.Run("ipv4", func(t *testing.T) {
+		address, ok := entryAddress(newEntry([]net.IP{net.ParseIP("10.0.0.5")}, nil))
+		assert.True(t, ok)
+		assert.Equal(t, "10.0.0.5:50002", address)
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		address, ok := entryAddress(newEntry(nil, []net.IP{net.ParseIP("fd00::5")}))
+		assert.True(t, ok)
+		assert.Equal(t, "[fd00::5]:50002", address)
+	})
+
+	t.Run("ipv4 preferred", func(t *testing.T) {
+		address, ok := entryAddress(newEntry([]net.IP{net.ParseIP("10.0.0.5")}, []net.IP{net.ParseIP("fd00::5")}))
+		assert.True(t, ok)
+		assert.Equal(t, "10.0.0.5:50002", address)
+	})
+
+	t.Run("no address", func(t *testing.T) {
+		_, ok := entryAddress(newEntry(nil, nil))
+		assert.False(t, ok)
+	})
+}
+
+func TestAnnounces(t *testing.T) {
+	entry := zeroconf.NewServiceEntry("host", "orders", "local.")
+	entry.Text = []string{"orders"}
+	assert.True(t, announces(entry, "orders"))
+	assert.False(t, announces(entry, "payments"))
+}
@@ -0,0 +1,12 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+//go:build !fips
+// +build !fips
+
+package fips
+
+// buildEnabled is whether the mode is enabled by default in this build
+const buildEnabled = false
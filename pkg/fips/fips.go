@@ -0,0 +1,167 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package fips restricts the crypto of the Dapr services to FIPS-approved primitives. The mode is enabled with the
+// --enable-fips flag, or by default in binaries built with the fips build tag.
+package fips
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// minRSAKeySize is the smallest approved RSA modulus, in bits
+const minRSAKeySize = 2048
+
+// enabled defaults to the build mode, and is overridden by the flag
+var enabled = buildEnabled
+
+// CipherSuites are the approved TLS 1.2 cipher suites, the AES-GCM suites with ECDHE key exchange
+var CipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// CurvePreferences are the approved curves of the TLS key exchange
+var CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+
+var approvedSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.SHA256WithRSA:    true,
+	x509.SHA384WithRSA:    true,
+	x509.SHA512WithRSA:    true,
+	x509.SHA256WithRSAPSS: true,
+	x509.SHA384WithRSAPSS: true,
+	x509.SHA512WithRSAPSS: true,
+	x509.ECDSAWithSHA256:  true,
+	x509.ECDSAWithSHA384:  true,
+	x509.ECDSAWithSHA512:  true,
+}
+
+// Error is returned for the use of a primitive that isn't FIPS-approved while the mode is enabled
+type Error struct {
+	// Primitive is the rejected algorithm, key or certificate
+	Primitive string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("fips mode: %s is not FIPS-approved", e.Primitive)
+}
+
+// AttachCmdFlags attaches the flag enabling the mode
+func AttachCmdFlags(boolVar func(p *bool, name string, value bool, usage string)) {
+	boolVar(
+		&enabled,
+		"enable-fips",
+		enabled,
+		"Restricts TLS and the other crypto to FIPS-approved primitives. Certificates and keys that aren't approved fail the startup")
+}
+
+// Enabled returns whether the mode is enabled
+func Enabled() bool {
+	return enabled
+}
+
+// ConfigureTLS restricts the TLS config to TLS 1.2 with the approved cipher suites and curves when the mode is
+// enabled. TLS 1.3 is disabled as its cipher suites can't be restricted.
+func ConfigureTLS(config *tls.Config) *tls.Config {
+	if !enabled {
+		return config
+	}
+	config.MinVersion = tls.VersionTLS12
+	config.MaxVersion = tls.VersionTLS12
+	config.CipherSuites = CipherSuites
+	config.CurvePreferences = CurvePreferences
+	config.PreferServerCipherSuites = true
+	return config
+}
+
+// ValidatePublicKey checks that the key is an RSA key of at least 2048 bits or an ECDSA key on a NIST curve
+func ValidatePublicKey(key interface{}) error {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeySize {
+			return &Error{Primitive: fmt.Sprintf("%d bits RSA key", k.N.BitLen())}
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		return validateCurve(k.Curve)
+	}
+	return &Error{Primitive: fmt.Sprintf("%T key", key)}
+}
+
+// ValidatePrivateKey checks the public key of the private key
+func ValidatePrivateKey(key interface{}) error {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return ValidatePublicKey(&k.PublicKey)
+	case *ecdsa.PrivateKey:
+		return ValidatePublicKey(&k.PublicKey)
+	}
+	return &Error{Primitive: fmt.Sprintf("%T key", key)}
+}
+
+func validateCurve(curve elliptic.Curve) error {
+	switch curve {
+	case elliptic.P256(), elliptic.P384(), elliptic.P521():
+		return nil
+	}
+	return &Error{Primitive: fmt.Sprintf("curve %s", curve.Params().Name)}
+}
+
+// ValidateCertificate checks the signature algorithm and the public key of the certificate
+func ValidateCertificate(cert *x509.Certificate) error {
+	if !approvedSignatureAlgorithms[cert.SignatureAlgorithm] {
+		return &Error{Primitive: fmt.Sprintf("signature algorithm %s of certificate %s", cert.SignatureAlgorithm, cert.Subject)}
+	}
+	if err := ValidatePublicKey(cert.PublicKey); err != nil {
+		return fmt.Errorf("certificate %s: %w", cert.Subject, err)
+	}
+	return nil
+}
+
+// ValidateCertificatesPEM checks the PEM encoded certificates
+func ValidateCertificatesPEM(certsPEM []byte) error {
+	found := false
+	for block, rest := pem.Decode(certsPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		if err := ValidateCertificate(cert); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return errors.New("no PEM encoded certificate found")
+	}
+	return nil
+}
+
+// ValidateCertChain checks the PEM encoded trust anchors, and the certificate chain with its private key
+func ValidateCertChain(rootCA, certChain, key []byte) error {
+	if err := ValidateCertificatesPEM(rootCA); err != nil {
+		return fmt.Errorf("invalid trust anchors: %w", err)
+	}
+	if err := ValidateCertificatesPEM(certChain); err != nil {
+		return fmt.Errorf("invalid cert chain: %w", err)
+	}
+	pair, err := tls.X509KeyPair(certChain, key)
+	if err != nil {
+		return err
+	}
+	return ValidatePrivateKey(pair.PrivateKey)
+}
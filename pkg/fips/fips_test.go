@@ -0,0 +1,97 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package fips
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func selfSignedCertPEM(t *testing.T, pub, priv interface{}) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestConfigureTLS(t *testing.T) {
+	defer func(e bool) { enabled = e }(enabled)
+
+	t.Run("disabled", func(t *testing.T) {
+		enabled = false
+		config := ConfigureTLS(&tls.Config{})
+		assert.Nil(t, config.CipherSuites)
+		assert.Zero(t, config.MaxVersion)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		enabled = true
+		config := ConfigureTLS(&tls.Config{ServerName: "test"})
+		assert.Equal(t, "test", config.ServerName)
+		assert.Equal(t, CipherSuites, config.CipherSuites)
+		assert.Equal(t, CurvePreferences, config.CurvePreferences)
+		assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+		assert.Equal(t, uint16(tls.VersionTLS12), config.MaxVersion)
+	})
+}
+
+func TestValidatePrivateKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	assert.NoError(t, ValidatePrivateKey(ecKey))
+
+	p224Key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+	assert.Error(t, ValidatePrivateKey(p224Key))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	var fipsErr *Error
+	assert.True(t, errors.As(ValidatePrivateKey(rsaKey), &fipsErr))
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	assert.Error(t, ValidatePrivateKey(edKey))
+}
+
+func TestValidateCertificatesPEM(t *testing.T) {
+	t.Run("approved certificate", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		assert.NoError(t, ValidateCertificatesPEM(selfSignedCertPEM(t, &key.PublicKey, key)))
+	})
+
+	t.Run("ed25519 certificate", func(t *testing.T) {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		err = ValidateCertificatesPEM(selfSignedCertPEM(t, pub, priv))
+		var fipsErr *Error
+		assert.True(t, errors.As(err, &fipsErr))
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		assert.Error(t, ValidateCertificatesPEM([]byte("not a certificate")))
+	})
+}
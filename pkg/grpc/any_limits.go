@@ -0,0 +1,53 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// anyLimitsInterceptor rejects the invocations from other sidecars whose Any payloads exceed the limits, before the
+// payloads are unmarshaled
+func anyLimitsInterceptor(limits invokev1.AnyLimits) grpc_go.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc_go.UnaryServerInfo, handler grpc_go.UnaryHandler) (interface{}, error) {
+		if r, ok := req.(*internalv1pb.InternalInvokeRequest); ok {
+			if err := limits.Check(r.GetMessage()); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid invocation request: %s", err)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// anyLimitsStreamInterceptor rejects the streamed invocations from other sidecars whose Any payloads exceed the limits
+func anyLimitsStreamInterceptor(limits invokev1.AnyLimits) grpc_go.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc_go.ServerStream, info *grpc_go.StreamServerInfo, handler grpc_go.StreamHandler) error {
+		return handler(srv, &anyLimitsStream{ServerStream: stream, limits: limits})
+	}
+}
+
+type anyLimitsStream struct {
+	grpc_go.ServerStream
+	limits invokev1.AnyLimits
+}
+
+func (s *anyLimitsStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if r, ok := m.(*internalv1pb.InternalInvokeRequestStream); ok {
+		if err := s.limits.Check(r.GetRequest().GetMessage()); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid invocation request: %s", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream receives a single message
+type fakeServerStream struct {
+	grpc_go.ServerStream
+	msg *internalv1pb.InternalInvokeRequestStream
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	*m.(*internalv1pb.InternalInvokeRequestStream) = *s.msg
+	return nil
+}
+
+func TestAnyLimitsInterceptor(t *testing.T) {
+	// the data of the request is nested in the request, and the data of the deep request in another Any
+	data := &any.Any{TypeUrl: "type.googleapis.com/app.Message"}
+	request, err := ptypes.MarshalAny(&commonv1pb.InvokeRequest{Method: "method", Data: data})
+	require.NoError(t, err)
+	nested, err := ptypes.MarshalAny(data)
+	require.NoError(t, err)
+	deepRequest, err := ptypes.MarshalAny(&commonv1pb.InvokeRequest{Method: "method", Data: nested})
+	require.NoError(t, err)
+	limits := invokev1.AnyLimits{MaxDepth: 2}
+
+	t.Run("unary", func(t *testing.T) {
+		interceptor := anyLimitsInterceptor(limits)
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		}
+		info := &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.internals.v1.ServiceInvocation/CallLocal"}
+
+		_, err := interceptor(context.Background(), &internalv1pb.InternalInvokeRequest{Message: request}, info, handler)
+		assert.NoError(t, err)
+		_, err = interceptor(context.Background(), &internalv1pb.InternalInvokeRequest{Message: deepRequest}, info, handler)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		interceptor := anyLimitsStreamInterceptor(limits)
+		recv := func(m *any.Any) error {
+			stream := &fakeServerStream{msg: &internalv1pb.InternalInvokeRequestStream{Request: &internalv1pb.InternalInvokeRequest{Message: m}}}
+			return interceptor(nil, stream, &grpc_go.StreamServerInfo{}, func(srv interface{}, stream grpc_go.ServerStream) error {
+				return stream.RecvMsg(&internalv1pb.InternalInvokeRequestStream{})
+			})
+		}
+
+		assert.NoError(t, recv(request))
+		assert.Equal(t, codes.InvalidArgument, status.Code(recv(deepRequest)))
+	})
+}
@@ -9,6 +9,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
@@ -17,22 +20,34 @@ import (
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/actors"
 	"github.com/dapr/dapr/pkg/channel"
+	"github.com/dapr/dapr/pkg/components"
+	"github.com/dapr/dapr/pkg/components/configuration"
+	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
+	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/leadership"
+	"github.com/dapr/dapr/pkg/lock"
 	"github.com/dapr/dapr/pkg/messaging"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/dapr/dapr/pkg/sentry/identity"
+	"github.com/dapr/dapr/pkg/sequencer"
 	"github.com/golang/protobuf/ptypes/any"
 	durpb "github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 	"go.opencensus.io/trace"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -42,6 +57,12 @@ const (
 	maxSeconds    = int64(10000 * 365.25 * 24 * 60 * 60)
 	minSeconds    = -maxSeconds
 	daprSeparator = "||"
+	// defaultBulkStateParallelism is the number of keys of a bulk get read at once by default
+	defaultBulkStateParallelism = 10
+	// maxBulkStateParallelism is the most keys of a bulk get read at once, whatever the requested parallelism
+	maxBulkStateParallelism = 100
+	// defaultDeletePrefixPageSize is the number of keys deleted at once by a prefix deletion by default
+	defaultDeletePrefixPageSize = 1000
 )
 
 // API is the gRPC interface for the Dapr gRPC API. It implements both the internal and external proto definitions.
@@ -49,15 +70,38 @@ type API interface {
 	// DaprInternal Service methods
 	CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error)
 	CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error)
+	CallLocalStream(stream internalv1pb.DaprInternal_CallLocalStreamServer) error
 
 	// Dapr Service methods
 	PublishEvent(ctx context.Context, in *daprv1pb.PublishEventEnvelope) (*empty.Empty, error)
+	SubscribeTopicEvents(stream daprv1pb.Dapr_SubscribeTopicEventsServer) error
 	InvokeService(ctx context.Context, in *daprv1pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error)
+	InvokeServiceStream(stream daprv1pb.Dapr_InvokeServiceStreamServer) error
 	InvokeBinding(ctx context.Context, in *daprv1pb.InvokeBindingEnvelope) (*empty.Empty, error)
 	GetState(ctx context.Context, in *daprv1pb.GetStateEnvelope) (*daprv1pb.GetStateResponseEnvelope, error)
+	GetBulkState(ctx context.Context, in *daprv1pb.GetBulkStateEnvelope) (*daprv1pb.GetBulkStateResponseEnvelope, error)
 	GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (*daprv1pb.GetSecretResponseEnvelope, error)
+	GetBulkSecret(ctx context.Context, in *daprv1pb.GetBulkSecretEnvelope) (*daprv1pb.GetBulkSecretResponseEnvelope, error)
 	SaveState(ctx context.Context, in *daprv1pb.SaveStateEnvelope) (*empty.Empty, error)
 	DeleteState(ctx context.Context, in *daprv1pb.DeleteStateEnvelope) (*empty.Empty, error)
+	DeleteBulkState(ctx context.Context, in *daprv1pb.DeleteBulkStateEnvelope) (*empty.Empty, error)
+	ExecuteStateTransaction(ctx context.Context, in *daprv1pb.ExecuteStateTransactionEnvelope) (*empty.Empty, error)
+	QueryStateAlpha1(ctx context.Context, in *daprv1pb.QueryStateEnvelope) (*daprv1pb.QueryStateResponseEnvelope, error)
+	DeleteStateByPrefixAlpha1(in *daprv1pb.DeleteStateByPrefixEnvelope, stream daprv1pb.Dapr_DeleteStateByPrefixAlpha1Server) error
+	SubscribeState(in *daprv1pb.SubscribeStateEnvelope, stream daprv1pb.Dapr_SubscribeStateServer) error
+	GetNextID(ctx context.Context, in *daprv1pb.GetNextIDEnvelope) (*daprv1pb.GetNextIDResponseEnvelope, error)
+	GenerateID(ctx context.Context, in *daprv1pb.GenerateIDEnvelope) (*daprv1pb.GenerateIDResponseEnvelope, error)
+	Campaign(in *daprv1pb.CampaignEnvelope, stream daprv1pb.Dapr_CampaignServer) error
+	Resign(ctx context.Context, in *daprv1pb.ResignEnvelope) (*empty.Empty, error)
+	Observe(in *daprv1pb.ObserveEnvelope, stream daprv1pb.Dapr_ObserveServer) error
+	TryLock(ctx context.Context, in *daprv1pb.TryLockEnvelope) (*daprv1pb.TryLockResponseEnvelope, error)
+	Unlock(ctx context.Context, in *daprv1pb.UnlockEnvelope) (*daprv1pb.UnlockResponseEnvelope, error)
+	GetConfiguration(ctx context.Context, in *daprv1pb.GetConfigurationEnvelope) (*daprv1pb.GetConfigurationResponseEnvelope, error)
+	SubscribeConfiguration(in *daprv1pb.SubscribeConfigurationEnvelope, stream daprv1pb.Dapr_SubscribeConfigurationServer) error
+	GetComponentCapabilities(ctx context.Context, in *empty.Empty) (*daprv1pb.GetComponentCapabilitiesResponseEnvelope, error)
+	GetMetadata(ctx context.Context, in *empty.Empty) (*daprv1pb.GetMetadataResponseEnvelope, error)
+	SetMetadata(ctx context.Context, in *daprv1pb.SetMetadataEnvelope) (*empty.Empty, error)
+	Shutdown(ctx context.Context, in *empty.Empty) (*empty.Empty, error)
 }
 
 type api struct {
@@ -65,22 +109,39 @@ type api struct {
 	directMessaging       messaging.DirectMessaging
 	appChannel            channel.AppChannel
 	stateStores           map[string]state.Store
+	stateWatchers         map[string]state_loader.Watcher
 	secretStores          map[string]secretstores.SecretStore
+	configurationStores   map[string]configuration.Store
+	componentsLock        *sync.RWMutex
 	publishFn             func(req *pubsub.PublishRequest) error
+	subscribeFn           func(topics []string) (*pubsub_loader.Stream, error)
+	json                  jsoniter.API
 	id                    string
 	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error
+	capabilitiesFn        func() []components.Capabilities
+	shutdownFn            func()
 	tracingSpec           config.TracingSpec
+	electors              sync.Map
+	extendedMetadata      *sync.Map
 }
 
 // NewAPI returns a new gRPC API
 func NewAPI(
 	appID string, appChannel channel.AppChannel,
 	stateStores map[string]state.Store,
+	stateWatchers map[string]state_loader.Watcher,
 	secretStores map[string]secretstores.SecretStore,
+	configurationStores map[string]configuration.Store,
+	componentsLock *sync.RWMutex,
+	extendedMetadata *sync.Map,
 	publishFn func(req *pubsub.PublishRequest) error,
+	subscribeFn func(topics []string) (*pubsub_loader.Stream, error),
 	directMessaging messaging.DirectMessaging,
 	actor actors.Actors,
 	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error,
+	capabilitiesFn func() []components.Capabilities,
+	shutdownFn func(),
+	jsonSpec config.JSONSpec,
 	tracingSpec config.TracingSpec) API {
 	return &api{
 		directMessaging:       directMessaging,
@@ -88,13 +149,71 @@ func NewAPI(
 		id:                    appID,
 		appChannel:            appChannel,
 		publishFn:             publishFn,
+		subscribeFn:           subscribeFn,
+		json:                  config.NewJSONAPI(jsonSpec),
 		stateStores:           stateStores,
+		stateWatchers:         stateWatchers,
 		secretStores:          secretStores,
+		configurationStores:   configurationStores,
+		componentsLock:        componentsLock,
+		extendedMetadata:      extendedMetadata,
 		sendToOutputBindingFn: sendToOutputBindingFn,
+		capabilitiesFn:        capabilitiesFn,
+		shutdownFn:            shutdownFn,
 		tracingSpec:           tracingSpec,
 	}
 }
 
+// rLockComponents locks the component maps for reading and returns the func unlocking them. The components lock
+// guards the components registered by the runtime while the API serves, e.g. the components initialized in the
+// background.
+func (a *api) rLockComponents() func() {
+	if a.componentsLock == nil {
+		return func() {}
+	}
+	a.componentsLock.RLock()
+	return a.componentsLock.RUnlock
+}
+
+func (a *api) stateStore(name string) (state.Store, bool) {
+	defer a.rLockComponents()()
+	store, ok := a.stateStores[name]
+	return store, ok
+}
+
+func (a *api) hasStateStores() bool {
+	defer a.rLockComponents()()
+	return len(a.stateStores) > 0
+}
+
+func (a *api) stateWatcher(name string) (state_loader.Watcher, bool) {
+	defer a.rLockComponents()()
+	watcher, ok := a.stateWatchers[name]
+	return watcher, ok
+}
+
+func (a *api) secretStore(name string) (secretstores.SecretStore, bool) {
+	defer a.rLockComponents()()
+	store, ok := a.secretStores[name]
+	return store, ok
+}
+
+func (a *api) hasSecretStores() bool {
+	defer a.rLockComponents()()
+	return len(a.secretStores) > 0
+}
+
+func (a *api) configurationStore(name string) (configuration.Store, bool) {
+	defer a.rLockComponents()()
+	store, ok := a.configurationStores[name]
+	return store, ok
+}
+
+func (a *api) hasConfigurationStores() bool {
+	defer a.rLockComponents()()
+	return len(a.configurationStores) > 0
+}
+
 // CallLocal is used for internal dapr to dapr calls. It is invoked by another Dapr instance with a request to the local app.
 func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error) {
 	if a.appChannel == nil {
@@ -106,18 +225,99 @@ func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 		return nil, status.Errorf(codes.InvalidArgument, "parsing InternalInvokeRequest error: %s", err.Error())
 	}
 
+	callerAppID := ""
+	if caller := callerIdentity(ctx); caller != nil {
+		callerAppID = caller.ID
+		req.WithCallerIdentity(caller.ID, caller.Namespace, caller.TrustDomain)
+	} else {
+		req.WithCallerIdentity("", "", "")
+	}
+
 	ctx, span := diag.StartTracingServerSpanFromGRPCContext(ctx, req.Message().Method, a.tracingSpec)
 	defer span.End()
 	ctx = diag.NewContext(ctx, span.SpanContext())
 
-	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	resp, err := a.appChannel.InvokeMethod(channel.WithOperation(ctx, channel.OperationInvocation), req)
 	diag.UpdateSpanPairStatusesFromError(span, err, req.Message().Method)
+	requestSize := int64(len(req.Message().GetData().GetValue()))
 	if err != nil {
+		diag.DefaultMonitoring.ServiceInvocationPayload(diag.InvocationInbound, callerAppID, requestSize, 0)
 		return nil, err
 	}
+	diag.DefaultMonitoring.ServiceInvocationPayload(diag.InvocationInbound, callerAppID, requestSize, int64(len(resp.Message().GetData().GetValue())))
 	return resp.Proto(), err
 }
 
+// CallLocalStream is used for internal dapr to dapr streamed calls. It streams the request of another Dapr instance to
+// the local app, and the response of the app back.
+func (a *api) CallLocalStream(stream internalv1pb.DaprInternal_CallLocalStreamServer) error {
+	if a.appChannel == nil {
+		return status.Error(codes.Internal, "app channel is not initialized")
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if first.GetRequest() == nil {
+		return status.Error(codes.InvalidArgument, "the streamed invocation request has no header")
+	}
+	req, err := invokev1.InternalInvokeRequest(first.GetRequest())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "parsing InternalInvokeRequest error: %s", err.Error())
+	}
+
+	ctx := stream.Context()
+	if caller := callerIdentity(ctx); caller != nil {
+		req.WithCallerIdentity(caller.ID, caller.Namespace, caller.TrustDomain)
+	} else {
+		req.WithCallerIdentity("", "", "")
+	}
+
+	ctx, span := diag.StartTracingServerSpanFromGRPCContext(ctx, req.Message().Method, a.tracingSpec)
+	defer span.End()
+	ctx = diag.NewContext(ctx, span.SpanContext())
+
+	body := invokev1.NewChunkReader(func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return msg.GetChunk(), nil
+	})
+	resp, respBody, err := channel.InvokeMethodStream(channel.WithOperation(ctx, channel.OperationInvocation), a.appChannel, req, body)
+	diag.UpdateSpanPairStatusesFromError(span, err, req.Message().Method)
+	if err != nil {
+		return err
+	}
+	defer respBody.Close()
+
+	if err := stream.Send(&internalv1pb.InternalInvokeResponseStream{Response: resp.Proto()}); err != nil {
+		return err
+	}
+	return invokev1.SendChunks(respBody, func(chunk []byte) error {
+		return stream.Send(&internalv1pb.InternalInvokeResponseStream{Chunk: chunk})
+	})
+}
+
+// callerIdentity returns the identity in the mTLS certificate of the calling sidecar, or nil without mTLS
+func callerIdentity(ctx context.Context) *identity.Bundle {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	caller, err := identity.FromCertificate(tlsInfo.State.VerifiedChains[0][0])
+	if err != nil {
+		log.Debugf("unknown caller identity: %s", err)
+		return nil
+	}
+	return caller
+}
+
 // CallActor invokes a virtual actor
 func (a *api) CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error) {
 	req, err := invokev1.InternalInvokeRequest(in)
@@ -152,8 +352,8 @@ func (a *api) PublishEvent(ctx context.Context, in *daprv1pb.PublishEventEnvelop
 	sc := diag.FromContext(ctx)
 	corID := sc.TraceID.String()
 
-	envelope := pubsub.NewCloudEventsEnvelope(uuid.New().String(), a.id, pubsub.DefaultCloudEventType, corID, body)
-	b, err := jsoniter.ConfigFastest.Marshal(envelope)
+	envelope := pubsub_loader.NewCloudEventsEnvelope(a.json, uuid.New().String(), a.id, pubsub.DefaultCloudEventType, corID, body)
+	b, err := a.json.Marshal(envelope)
 	if err != nil {
 		return &empty.Empty{}, fmt.Errorf("ERR_PUBSUB_CLOUD_EVENTS_SER: %s", err)
 	}
@@ -175,6 +375,73 @@ func (a *api) PublishEvent(ctx context.Context, in *daprv1pb.PublishEventEnvelop
 	return &empty.Empty{}, nil
 }
 
+// SubscribeTopicEvents streams the events of the topics of the first message to the app, and redelivers the events
+// the app doesn't ack with SUCCESS or DROP, so that the app subscribes without running a callback server
+func (a *api) SubscribeTopicEvents(stream daprv1pb.Dapr_SubscribeTopicEventsServer) error {
+	if a.subscribeFn == nil {
+		return status.Error(codes.FailedPrecondition, "ERR_PUBSUB_NOT_FOUND")
+	}
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	topics := req.GetInitialRequest().GetTopics()
+	if len(topics) == 0 {
+		return status.Error(codes.InvalidArgument, "ERR_PUBSUB_SUBSCRIBE: the first message must name the topics")
+	}
+	subscription, err := a.subscribeFn(topics)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "ERR_PUBSUB_SUBSCRIBE: %s", err)
+	}
+	defer subscription.Close()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			ack := req.GetEventAck()
+			if ack == nil {
+				recvErr <- status.Error(codes.InvalidArgument, "ERR_PUBSUB_SUBSCRIBE: the messages after the first one must be acks")
+				return
+			}
+			if !subscription.Ack(ack.Id, topicEventAckError(ack.Status)) {
+				log.Debugf("ack of unknown event %s", ack.Id)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case event := <-subscription.Events():
+			if err := stream.Send(&daprv1pb.TopicEventEnvelope{Id: event.ID, Topic: event.Topic, Data: event.Data}); err != nil {
+				return err
+			}
+		case err := <-recvErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+// topicEventAckError returns the error of the delivery of an event acked with the status, nil unless it's redelivered
+func topicEventAckError(s daprv1pb.TopicEventAckEnvelope_Status) error {
+	switch s {
+	case daprv1pb.TopicEventAckEnvelope_RETRY:
+		return errors.New("the app asked for the redelivery of the event")
+	case daprv1pb.TopicEventAckEnvelope_DROP:
+		log.Warn("the app dropped an event")
+	}
+	return nil
+}
+
 func (a *api) InvokeService(ctx context.Context, in *daprv1pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error) {
 	req := invokev1.FromInvokeRequestMessage(in.GetMessage())
 
@@ -205,6 +472,57 @@ func (a *api) InvokeService(ctx context.Context, in *daprv1pb.InvokeServiceReque
 	return resp.Message(), respError
 }
 
+// InvokeServiceStream invokes a service streaming the request and response data in chunks, to proxy payloads too
+// large to buffer
+func (a *api) InvokeServiceStream(stream daprv1pb.Dapr_InvokeServiceStreamServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	in := first.GetRequest()
+	if in == nil {
+		return status.Error(codes.InvalidArgument, "the streamed invocation request has no header")
+	}
+
+	ctx := stream.Context()
+	req := invokev1.FromInvokeRequestMessage(in.GetMessage())
+	if incomingMD, ok := metadata.FromIncomingContext(ctx); ok {
+		req.WithMetadata(incomingMD)
+	}
+
+	body := invokev1.NewChunkReader(func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return msg.GetChunk(), nil
+	})
+	resp, respBody, err := a.directMessaging.InvokeStream(ctx, in.Id, req, body)
+	if err != nil {
+		return err
+	}
+	defer respBody.Close()
+
+	stream.SendHeader(invokev1.InternalMetadataToGrpcMetadata(resp.Headers(), true))
+	if err := stream.Send(&daprv1pb.InvokeServiceStreamResponse{Response: resp.Message()}); err != nil {
+		return err
+	}
+	err = invokev1.SendChunks(respBody, func(chunk []byte) error {
+		return stream.Send(&daprv1pb.InvokeServiceStreamResponse{Chunk: chunk})
+	})
+	if err != nil {
+		return err
+	}
+
+	// the status comes after the data, which was already streamed, so HTTP errors have no detail
+	if resp.IsHTTPResponse() {
+		return invokev1.ErrorFromHTTPResponseCode(int(resp.Status().Code), "")
+	}
+	// ignore trailer if appchannel uses HTTP
+	stream.SetTrailer(invokev1.InternalMetadataToGrpcMetadata(resp.Trailers(), false))
+	return invokev1.ErrorFromInternalStatus(resp.Status())
+}
+
 func (a *api) InvokeBinding(ctx context.Context, in *daprv1pb.InvokeBindingEnvelope) (*empty.Empty, error) {
 	req := &bindings.WriteRequest{
 		Metadata: in.Metadata,
@@ -225,13 +543,14 @@ func (a *api) InvokeBinding(ctx context.Context, in *daprv1pb.InvokeBindingEnvel
 }
 
 func (a *api) GetState(ctx context.Context, in *daprv1pb.GetStateEnvelope) (*daprv1pb.GetStateResponseEnvelope, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if !a.hasStateStores() {
 		return nil, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
 	}
 
 	storeName := in.StoreName
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
 		return nil, errors.New("ERR_STATE_STORE_NOT_FOUND")
 	}
 
@@ -247,7 +566,7 @@ func (a *api) GetState(ctx context.Context, in *daprv1pb.GetStateEnvelope) (*dap
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	getResponse, err := a.stateStores[storeName].Get(&req)
+	getResponse, err := store.Get(&req)
 	if err != nil {
 		return nil, fmt.Errorf("ERR_STATE_GET: %s", err)
 	}
@@ -260,44 +579,124 @@ func (a *api) GetState(ctx context.Context, in *daprv1pb.GetStateEnvelope) (*dap
 	return response, nil
 }
 
+// GetBulkState gets the values of several keys, reading parallelism keys at once, up to maxBulkStateParallelism.
+// The errors reading a key are returned in its item.
+func (a *api) GetBulkState(ctx context.Context, in *daprv1pb.GetBulkStateEnvelope) (*daprv1pb.GetBulkStateResponseEnvelope, error) {
+	if !a.hasStateStores() {
+		return nil, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	store, _ := a.stateStore(in.StoreName)
+	if store == nil {
+		return nil, errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("GetBulkState: %s", in.StoreName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	parallelism := int(in.Parallelism)
+	if parallelism <= 0 {
+		parallelism = defaultBulkStateParallelism
+	}
+	if parallelism > maxBulkStateParallelism {
+		parallelism = maxBulkStateParallelism
+	}
+	items := make([]*daprv1pb.BulkStateItem, len(in.Keys))
+	limiter := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, key := range in.Keys {
+		wg.Add(1)
+		limiter <- struct{}{}
+		go func(i int, key string) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+
+			item := &daprv1pb.BulkStateItem{Key: key}
+			items[i] = item
+			resp, err := store.Get(&state.GetRequest{Key: a.getModifiedStateKey(key)})
+			if err != nil {
+				item.Error = fmt.Sprintf("ERR_STATE_GET: %s", err)
+				return
+			}
+			if resp != nil {
+				item.Etag = resp.ETag
+				item.Data = &any.Any{Value: resp.Data}
+			}
+		}(i, key)
+	}
+	wg.Wait()
+
+	return &daprv1pb.GetBulkStateResponseEnvelope{Items: items}, nil
+}
+
+// SubscribeState streams the changes of the watched keys of a state store until the client cancels the stream
+func (a *api) SubscribeState(in *daprv1pb.SubscribeStateEnvelope, stream daprv1pb.Dapr_SubscribeStateServer) error {
+	if !a.hasStateStores() {
+		return errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+	watcher, ok := a.stateWatcher(in.StoreName)
+	if !ok {
+		return errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+	if len(in.Keys) == 0 && len(in.KeyPrefixes) == 0 {
+		return status.Error(codes.InvalidArgument, "ERR_STATE_WATCH: no keys or key prefixes to watch")
+	}
+
+	keys := make([]string, len(in.Keys))
+	for i, k := range in.Keys {
+		keys[i] = a.getModifiedStateKey(k)
+	}
+	prefixes := make([]string, len(in.KeyPrefixes))
+	for i, p := range in.KeyPrefixes {
+		prefixes[i] = a.getModifiedStateKey(p)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sendErr error
+	err := watcher.Watch(ctx, keys, prefixes, func(c state_loader.StateChange) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&daprv1pb.StateChangeEnvelope{
+			Key:     a.getOriginalStateKey(c.Key),
+			Data:    &any.Any{Value: c.Value},
+			Etag:    c.ETag,
+			Deleted: c.Deleted,
+		})
+		if sendErr != nil {
+			cancel()
+		}
+	})
+	if err == state_loader.ErrWatchPrefixNotSupported {
+		return status.Errorf(codes.FailedPrecondition, "ERR_STATE_WATCH: %s", err)
+	}
+	if err != nil {
+		return fmt.Errorf("ERR_STATE_WATCH: %s", err)
+	}
+	return sendErr
+}
+
 func (a *api) SaveState(ctx context.Context, in *daprv1pb.SaveStateEnvelope) (*empty.Empty, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if !a.hasStateStores() {
 		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
 	}
 
 	storeName := in.StoreName
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
 		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_FOUND")
 	}
 
 	reqs := []state.SetRequest{}
 	for _, s := range in.Requests {
-		req := state.SetRequest{
-			Key:      a.getModifiedStateKey(s.Key),
-			Metadata: s.Metadata,
-			Value:    s.Value.Value,
-			ETag:     s.Etag,
-		}
-		if s.Options != nil {
-			req.Options = state.SetStateOption{
-				Consistency: s.Options.Consistency,
-				Concurrency: s.Options.Concurrency,
-			}
-			if s.Options.RetryPolicy != nil {
-				req.Options.RetryPolicy = state.RetryPolicy{
-					Threshold: int(s.Options.RetryPolicy.Threshold),
-					Pattern:   s.Options.RetryPolicy.Pattern,
-				}
-				if s.Options.RetryPolicy.Interval != nil {
-					dur, err := duration(s.Options.RetryPolicy.Interval)
-					if err == nil {
-						req.Options.RetryPolicy.Interval = dur
-					}
-				}
-			}
-		}
-		reqs = append(reqs, req)
+		reqs = append(reqs, a.stateSetRequest(s))
 	}
 
 	var span *trace.Span
@@ -305,21 +704,92 @@ func (a *api) SaveState(ctx context.Context, in *daprv1pb.SaveStateEnvelope) (*e
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	err := a.stateStores[storeName].BulkSet(reqs)
+	err := store.BulkSet(reqs)
+	if validationErr, ok := err.(*state_loader.SchemaValidationError); ok {
+		return &empty.Empty{}, schemaValidationStatus(validationErr)
+	}
 	if err != nil {
 		return &empty.Empty{}, fmt.Errorf("ERR_STATE_SAVE: %s", err)
 	}
 	return &empty.Empty{}, nil
 }
 
+// stateSetRequest converts a state request of the API to the set request of a state store
+func (a *api) stateSetRequest(s *daprv1pb.StateRequest) state.SetRequest {
+	req := state.SetRequest{
+		Key:      a.getModifiedStateKey(s.Key),
+		Metadata: s.Metadata,
+		ETag:     s.Etag,
+	}
+	if s.Value != nil {
+		req.Value = s.Value.Value
+	}
+	if s.Options != nil {
+		req.Options = state.SetStateOption{
+			Consistency: s.Options.Consistency,
+			Concurrency: s.Options.Concurrency,
+		}
+		req.Options.RetryPolicy = retryPolicy(s.Options.RetryPolicy)
+	}
+	return req
+}
+
+// stateDeleteRequest converts a state request of the API to the delete request of a state store
+func (a *api) stateDeleteRequest(s *daprv1pb.StateRequest) state.DeleteRequest {
+	req := state.DeleteRequest{
+		Key:      a.getModifiedStateKey(s.Key),
+		Metadata: s.Metadata,
+		ETag:     s.Etag,
+	}
+	if s.Options != nil {
+		req.Options = state.DeleteStateOption{
+			Consistency: s.Options.Consistency,
+			Concurrency: s.Options.Concurrency,
+		}
+		req.Options.RetryPolicy = retryPolicy(s.Options.RetryPolicy)
+	}
+	return req
+}
+
+func retryPolicy(policy *daprv1pb.RetryPolicy) state.RetryPolicy {
+	if policy == nil {
+		return state.RetryPolicy{}
+	}
+	retryPolicy := state.RetryPolicy{
+		Threshold: int(policy.Threshold),
+		Pattern:   policy.Pattern,
+	}
+	if policy.Interval != nil {
+		dur, err := duration(policy.Interval)
+		if err == nil {
+			retryPolicy.Interval = dur
+		}
+	}
+	return retryPolicy
+}
+
+// schemaValidationStatus returns an invalid argument status with a field violation per schema error
+func schemaValidationStatus(err *state_loader.SchemaValidationError) error {
+	s := status.New(codes.InvalidArgument, fmt.Sprintf("ERR_STATE_SCHEMA_VALIDATION: value of key %s does not match its JSON schema", err.Key))
+	violations := []*epb.BadRequest_FieldViolation{}
+	for _, e := range err.Errors {
+		violations = append(violations, &epb.BadRequest_FieldViolation{Field: err.Key, Description: e})
+	}
+	if withDetails, detailsErr := s.WithDetails(&epb.BadRequest{FieldViolations: violations}); detailsErr == nil {
+		s = withDetails
+	}
+	return s.Err()
+}
+
 func (a *api) DeleteState(ctx context.Context, in *daprv1pb.DeleteStateEnvelope) (*empty.Empty, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if !a.hasStateStores() {
 		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
 	}
 
 	storeName := in.StoreName
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
 		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_FOUND")
 	}
 
@@ -353,13 +823,205 @@ func (a *api) DeleteState(ctx context.Context, in *daprv1pb.DeleteStateEnvelope)
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	err := a.stateStores[storeName].Delete(&req)
+	err := store.Delete(&req)
 	if err != nil {
 		return &empty.Empty{}, fmt.Errorf("ERR_STATE_DELETE: failed deleting state with key %s: %s", in.Key, err)
 	}
 	return &empty.Empty{}, nil
 }
 
+// DeleteBulkState deletes the keys in one operation on stores with the bulk delete feature, and one by one otherwise
+func (a *api) DeleteBulkState(ctx context.Context, in *daprv1pb.DeleteBulkStateEnvelope) (*empty.Empty, error) {
+	if !a.hasStateStores() {
+		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+
+	reqs := []state.DeleteRequest{}
+	for _, s := range in.Requests {
+		reqs = append(reqs, a.stateDeleteRequest(s))
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("DeleteBulkState: %s", storeName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	if a.hasFeature(storeName, components.FeatureBulkDelete) {
+		if err := store.BulkDelete(reqs); err != nil {
+			return &empty.Empty{}, fmt.Errorf("ERR_STATE_BULK_DELETE: %s", err)
+		}
+		return &empty.Empty{}, nil
+	}
+	for i := range reqs {
+		if err := store.Delete(&reqs[i]); err != nil {
+			return &empty.Empty{}, fmt.Errorf("ERR_STATE_DELETE: failed deleting state with key %s: %s", in.Requests[i].Key, err)
+		}
+	}
+	return &empty.Empty{}, nil
+}
+
+// DeleteStateByPrefixAlpha1 deletes the keys of the app starting with the prefix a page at a time, on stores that opted
+// in, and streams the progress after each page. The cursor of the last progress resumes an interrupted deletion.
+func (a *api) DeleteStateByPrefixAlpha1(in *daprv1pb.DeleteStateByPrefixEnvelope, stream daprv1pb.Dapr_DeleteStateByPrefixAlpha1Server) error {
+	if !a.hasStateStores() {
+		return errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, _ := a.stateStore(storeName)
+	if store == nil {
+		return errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+	if !a.hasFeature(storeName, components.FeatureDeleteByPrefix) {
+		return status.Errorf(codes.Unimplemented, "ERR_STATE_STORE_NOT_SUPPORTED: state store %s doesn't delete keys by prefix", storeName)
+	}
+	if in.Prefix == "" || in.PageSize < 0 {
+		return status.Error(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: deleting keys by prefix requires a prefix and a non-negative page size")
+	}
+	pageSize := int(in.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultDeletePrefixPageSize
+	}
+
+	progress := &daprv1pb.DeleteStateByPrefixProgressEnvelope{Cursor: in.Cursor}
+	for {
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+		n, next, err := state_loader.DeletePrefixPage(store, a.id, in.Prefix, progress.Cursor, pageSize)
+		if err != nil {
+			return fmt.Errorf("ERR_STATE_DELETE_PREFIX: failed to delete keys with prefix %s at cursor %q after %d deleted keys: %s", in.Prefix, progress.Cursor, progress.Deleted, err)
+		}
+		progress.Deleted += int64(n)
+		progress.Cursor = next
+		if err := stream.Send(progress); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+	}
+}
+
+// hasFeature returns whether the component, called by its name or an alias, declares or was detected with the feature
+func (a *api) hasFeature(name, feature string) bool {
+	if a.capabilitiesFn == nil {
+		return false
+	}
+	return components.HasFeature(a.capabilitiesFn(), name, feature)
+}
+
+// ExecuteStateTransaction runs the upserts and deletes atomically on a transactional state store
+func (a *api) ExecuteStateTransaction(ctx context.Context, in *daprv1pb.ExecuteStateTransactionEnvelope) (*empty.Empty, error) {
+	if !a.hasStateStores() {
+		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+
+	transactionalStore, ok := store.(state.TransactionalStore)
+	if !ok {
+		return &empty.Empty{}, status.Errorf(codes.Unimplemented, "ERR_STATE_STORE_NOT_SUPPORTED: state store %s doesn't support transactions", storeName)
+	}
+
+	reqs := []state.TransactionalRequest{}
+	for _, op := range in.Operations {
+		if op.Request == nil {
+			return &empty.Empty{}, status.Error(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: missing request of a transaction operation")
+		}
+		var req interface{}
+		switch state.OperationType(op.OperationType) {
+		case state.Upsert:
+			req = a.stateSetRequest(op.Request)
+		case state.Delete:
+			req = a.stateDeleteRequest(op.Request)
+		default:
+			return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: operation type %s not supported", op.OperationType)
+		}
+		reqs = append(reqs, state.TransactionalRequest{
+			Operation: state.OperationType(op.OperationType),
+			Request:   req,
+		})
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("ExecuteStateTransaction: %s", storeName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	err := transactionalStore.Multi(reqs)
+	if validationErr, ok := err.(*state_loader.SchemaValidationError); ok {
+		return &empty.Empty{}, schemaValidationStatus(validationErr)
+	}
+	if err != nil {
+		return &empty.Empty{}, fmt.Errorf("ERR_STATE_TRANSACTION: %s", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// QueryStateAlpha1 returns a page of the keys of the app whose values match the JSON query, on a state store with
+// the query feature
+func (a *api) QueryStateAlpha1(ctx context.Context, in *daprv1pb.QueryStateEnvelope) (*daprv1pb.QueryStateResponseEnvelope, error) {
+	if !a.hasStateStores() {
+		return nil, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		return nil, errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+
+	querier, ok := state_loader.Unwrap(store).(state_loader.Querier)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "ERR_STATE_STORE_NOT_SUPPORTED: state store %s doesn't support queries", storeName)
+	}
+
+	query, err := state_loader.ParseQuery([]byte(in.Query))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: %s", err)
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("QueryStateAlpha1: %s", storeName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	prefix := a.getModifiedStateKey("")
+	resp, err := querier.Query(&state_loader.QueryRequest{
+		Query:     *query,
+		KeyPrefix: prefix,
+		Metadata:  in.Metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ERR_STATE_QUERY: %s", err)
+	}
+
+	results := make([]*daprv1pb.QueryStateItem, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		results = append(results, &daprv1pb.QueryStateItem{
+			Key:  strings.TrimPrefix(r.Key, prefix),
+			Data: &any.Any{Value: r.Data},
+			Etag: r.ETag,
+		})
+	}
+	return &daprv1pb.QueryStateResponseEnvelope{Results: results, Token: resp.Token}, nil
+}
+
 func (a *api) getModifiedStateKey(key string) string {
 	if a.id != "" {
 		return fmt.Sprintf("%s%s%s", a.id, daprSeparator, key)
@@ -367,14 +1029,327 @@ func (a *api) getModifiedStateKey(key string) string {
 	return key
 }
 
+func (a *api) GetNextID(ctx context.Context, in *daprv1pb.GetNextIDEnvelope) (*daprv1pb.GetNextIDResponseEnvelope, error) {
+	store, ok := a.stateStore(in.StoreName)
+	if !ok || store == nil {
+		return nil, errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+	if _, native := state_loader.Unwrap(store).(sequencer.Incrementer); !native && !a.hasFeature(in.StoreName, components.FeatureInsert) {
+		return nil, status.Errorf(codes.FailedPrecondition, "ERR_SEQUENCE_NOT_SUPPORTED: state store %s can't create sequences only if they don't exist", in.StoreName)
+	}
+
+	id, err := sequencer.NextID(store, a.getModifiedStateKey(in.Key))
+	if err != nil {
+		return nil, fmt.Errorf("ERR_SEQUENCE_NEXT: %s", err)
+	}
+	return &daprv1pb.GetNextIDResponseEnvelope{Id: id}, nil
+}
+
+func (a *api) GenerateID(ctx context.Context, in *daprv1pb.GenerateIDEnvelope) (*daprv1pb.GenerateIDResponseEnvelope, error) {
+	id, err := sequencer.GenerateID(in.Kind)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_ID_KIND_NOT_SUPPORTED: %s", err)
+	}
+	return &daprv1pb.GenerateIDResponseEnvelope{Id: id}, nil
+}
+
+// GetComponentCapabilities returns the features of the loaded components
+func (a *api) GetComponentCapabilities(ctx context.Context, in *empty.Empty) (*daprv1pb.GetComponentCapabilitiesResponseEnvelope, error) {
+	return &daprv1pb.GetComponentCapabilitiesResponseEnvelope{Components: a.componentCapabilities()}, nil
+}
+
+func (a *api) componentCapabilities() []*daprv1pb.ComponentCapabilities {
+	if a.capabilitiesFn == nil {
+		return nil
+	}
+	var result []*daprv1pb.ComponentCapabilities
+	for _, c := range a.capabilitiesFn() {
+		result = append(result, &daprv1pb.ComponentCapabilities{
+			Name:     c.Name,
+			Type:     c.Type,
+			Features: c.Features,
+		})
+	}
+	return result
+}
+
+// GetMetadata returns the app id, the loaded components, the active actors and the labels set by the app
+func (a *api) GetMetadata(ctx context.Context, in *empty.Empty) (*daprv1pb.GetMetadataResponseEnvelope, error) {
+	resp := &daprv1pb.GetMetadataResponseEnvelope{
+		Id:                   a.id,
+		RegisteredComponents: a.componentCapabilities(),
+		ExtendedMetadata:     map[string]string{},
+	}
+	if a.actor != nil {
+		for _, c := range a.actor.GetActiveActorsCount(ctx) {
+			resp.ActiveActorsCount = append(resp.ActiveActorsCount, &daprv1pb.ActiveActorsCount{
+				Type:  c.Type,
+				Count: int32(c.Count),
+			})
+		}
+	}
+	a.extendedMetadata.Range(func(key, value interface{}) bool {
+		resp.ExtendedMetadata[key.(string)] = value.(string)
+		return true
+	})
+	return resp, nil
+}
+
+// SetMetadata sets a label of the sidecar, returned by GetMetadata
+func (a *api) SetMetadata(ctx context.Context, in *daprv1pb.SetMetadataEnvelope) (*empty.Empty, error) {
+	if in.Key == "" {
+		return &empty.Empty{}, status.Error(codes.InvalidArgument, "ERR_METADATA_MALFORMED_REQUEST: key is required")
+	}
+	a.extendedMetadata.Store(in.Key, in.Value)
+	return &empty.Empty{}, nil
+}
+
+// Shutdown asks for the graceful shutdown of the sidecar, done after the call returns
+func (a *api) Shutdown(ctx context.Context, in *empty.Empty) (*empty.Empty, error) {
+	if a.shutdownFn == nil {
+		return &empty.Empty{}, status.Error(codes.Unimplemented, "ERR_SHUTDOWN: shutdown is not supported")
+	}
+	a.shutdownFn()
+	return &empty.Empty{}, nil
+}
+
+func (a *api) Campaign(in *daprv1pb.CampaignEnvelope, stream daprv1pb.Dapr_CampaignServer) error {
+	if in.Candidate == "" {
+		return status.Error(codes.InvalidArgument, "ERR_LEADERSHIP_MALFORMED_REQUEST: candidate is required")
+	}
+	elector, err := a.getElector(in.StoreName, in.Election)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sendErr error
+	err = elector.Campaign(ctx, a.getElectionKey(in.Election), in.Candidate, time.Duration(in.TtlInSeconds)*time.Second, func() {
+		sendErr = stream.Send(&daprv1pb.LeaderEnvelope{Election: in.Election, Leader: in.Candidate})
+		if sendErr != nil {
+			cancel()
+		}
+	})
+	if err == leadership.ErrLeadershipLost {
+		return status.Errorf(codes.Aborted, "ERR_LEADERSHIP_LOST: %s", err)
+	}
+	if err != nil {
+		return fmt.Errorf("ERR_LEADERSHIP_CAMPAIGN: %s", err)
+	}
+	return sendErr
+}
+
+func (a *api) Resign(ctx context.Context, in *daprv1pb.ResignEnvelope) (*empty.Empty, error) {
+	if in.Candidate == "" {
+		return &empty.Empty{}, status.Error(codes.InvalidArgument, "ERR_LEADERSHIP_MALFORMED_REQUEST: candidate is required")
+	}
+	elector, err := a.getElector(in.StoreName, in.Election)
+	if err != nil {
+		return &empty.Empty{}, err
+	}
+
+	err = elector.Resign(a.getElectionKey(in.Election), in.Candidate)
+	if err == leadership.ErrNotCampaigning {
+		return &empty.Empty{}, status.Errorf(codes.FailedPrecondition, "ERR_LEADERSHIP_RESIGN: %s", err)
+	}
+	return &empty.Empty{}, err
+}
+
+func (a *api) Observe(in *daprv1pb.ObserveEnvelope, stream daprv1pb.Dapr_ObserveServer) error {
+	elector, err := a.getElector(in.StoreName, in.Election)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sendErr error
+	err = elector.Observe(ctx, a.getElectionKey(in.Election), 0, func(leader string) {
+		sendErr = stream.Send(&daprv1pb.LeaderEnvelope{Election: in.Election, Leader: leader})
+		if sendErr != nil {
+			cancel()
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("ERR_LEADERSHIP_OBSERVE: %s", err)
+	}
+	return sendErr
+}
+
+// getElector returns the elector of the state store, shared by all the elections in the store
+func (a *api) getElector(storeName, election string) (*leadership.Elector, error) {
+	if !a.hasStateStores() {
+		return nil, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		return nil, errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+	if election == "" {
+		return nil, status.Error(codes.InvalidArgument, "ERR_LEADERSHIP_MALFORMED_REQUEST: election is required")
+	}
+	if !a.hasFeature(storeName, components.FeatureInsert) {
+		return nil, status.Errorf(codes.FailedPrecondition, "ERR_LEADERSHIP_NOT_SUPPORTED: state store %s can't create leases only if they don't exist", storeName)
+	}
+
+	e, _ := a.electors.LoadOrStore(storeName, leadership.NewElector(store))
+	return e.(*leadership.Elector), nil
+}
+
+// getElectionKey returns the key of the lease of an election, scoped to the app so that its replicas compete
+func (a *api) getElectionKey(election string) string {
+	return a.getModifiedStateKey(fmt.Sprintf("leadership%s%s", daprSeparator, election))
+}
+
+// TryLock acquires the lock of a resource with a lease in a state store, without waiting for it to be released
+func (a *api) TryLock(ctx context.Context, in *daprv1pb.TryLockEnvelope) (*daprv1pb.TryLockResponseEnvelope, error) {
+	if in.LockOwner == "" {
+		return nil, status.Error(codes.InvalidArgument, "ERR_LOCK_MALFORMED_REQUEST: lock owner is required")
+	}
+	if in.ExpiryInSeconds <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "ERR_LOCK_MALFORMED_REQUEST: expiry in seconds must be positive")
+	}
+	store, err := a.getLockStore(in.StoreName, in.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, token, err := lock.TryLock(store, a.getLockKey(in.ResourceId), in.LockOwner, time.Duration(in.ExpiryInSeconds)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("ERR_LOCK_TRY_LOCK: %s", err)
+	}
+	return &daprv1pb.TryLockResponseEnvelope{Success: ok, FencingToken: token}, nil
+}
+
+// Unlock releases the lock of a resource held by the owner
+func (a *api) Unlock(ctx context.Context, in *daprv1pb.UnlockEnvelope) (*daprv1pb.UnlockResponseEnvelope, error) {
+	if in.LockOwner == "" {
+		return nil, status.Error(codes.InvalidArgument, "ERR_LOCK_MALFORMED_REQUEST: lock owner is required")
+	}
+	store, err := a.getLockStore(in.StoreName, in.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := lock.Unlock(store, a.getLockKey(in.ResourceId), in.LockOwner)
+	if err != nil {
+		return nil, fmt.Errorf("ERR_LOCK_UNLOCK: %s", err)
+	}
+	resp := &daprv1pb.UnlockResponseEnvelope{}
+	switch s {
+	case lock.LockDoesNotExist:
+		resp.Status = daprv1pb.UnlockResponseEnvelope_LOCK_DOES_NOT_EXIST
+	case lock.LockBelongsToOthers:
+		resp.Status = daprv1pb.UnlockResponseEnvelope_LOCK_BELONGS_TO_OTHERS
+	}
+	return resp, nil
+}
+
+func (a *api) getLockStore(storeName, resourceID string) (state.Store, error) {
+	if !a.hasStateStores() {
+		return nil, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		return nil, errors.New("ERR_STATE_STORE_NOT_FOUND")
+	}
+	if resourceID == "" {
+		return nil, status.Error(codes.InvalidArgument, "ERR_LOCK_MALFORMED_REQUEST: resource id is required")
+	}
+	if _, native := state_loader.Unwrap(store).(lock.Locker); !native && !a.hasFeature(storeName, components.FeatureInsert) {
+		return nil, status.Errorf(codes.FailedPrecondition, "ERR_LOCK_NOT_SUPPORTED: state store %s can't create locks only if they don't exist", storeName)
+	}
+	return store, nil
+}
+
+func (a *api) getLockKey(resourceID string) string {
+	return a.getModifiedStateKey(fmt.Sprintf("lock%s%s", daprSeparator, resourceID))
+}
+
+// GetConfiguration returns keys of a configuration store, or all its keys when there are no keys
+func (a *api) GetConfiguration(ctx context.Context, in *daprv1pb.GetConfigurationEnvelope) (*daprv1pb.GetConfigurationResponseEnvelope, error) {
+	store, err := a.getConfigurationStore(in.StoreName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := store.Get(ctx, &configuration.GetRequest{Keys: in.Keys, Metadata: in.Metadata})
+	if err != nil {
+		return nil, fmt.Errorf("ERR_CONFIGURATION_GET: %s", err)
+	}
+	return &daprv1pb.GetConfigurationResponseEnvelope{Items: configurationItems(resp.Items)}, nil
+}
+
+// SubscribeConfiguration streams the changes of keys of a configuration store until the stream ends
+func (a *api) SubscribeConfiguration(in *daprv1pb.SubscribeConfigurationEnvelope, stream daprv1pb.Dapr_SubscribeConfigurationServer) error {
+	store, err := a.getConfigurationStore(in.StoreName)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sendErr error
+	err = store.Subscribe(ctx, &configuration.SubscribeRequest{Keys: in.Keys, Metadata: in.Metadata}, func(e *configuration.UpdateEvent) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&daprv1pb.SubscribeConfigurationResponseEnvelope{Items: configurationItems(e.Items)})
+		if sendErr != nil {
+			cancel()
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("ERR_CONFIGURATION_SUBSCRIBE: %s", err)
+	}
+	return sendErr
+}
+
+func (a *api) getConfigurationStore(storeName string) (configuration.Store, error) {
+	if !a.hasConfigurationStores() {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_CONFIGURATION_STORE_NOT_CONFIGURED")
+	}
+	store, ok := a.configurationStore(storeName)
+	if !ok || store == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_CONFIGURATION_STORE_NOT_FOUND: %s", storeName)
+	}
+	return store, nil
+}
+
+func configurationItems(items []*configuration.Item) []*daprv1pb.ConfigurationItem {
+	result := make([]*daprv1pb.ConfigurationItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, &daprv1pb.ConfigurationItem{
+			Key:      item.Key,
+			Value:    item.Value,
+			Version:  item.Version,
+			Metadata: item.Metadata,
+		})
+	}
+	return result
+}
+
+// getOriginalStateKey removes the app id prefix added by getModifiedStateKey
+func (a *api) getOriginalStateKey(key string) string {
+	if a.id != "" {
+		return strings.TrimPrefix(key, a.id+daprSeparator)
+	}
+	return key
+}
+
 func (a *api) GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (*daprv1pb.GetSecretResponseEnvelope, error) {
-	if a.secretStores == nil || len(a.secretStores) == 0 {
+	if !a.hasSecretStores() {
 		return nil, errors.New("ERR_SECRET_STORE_NOT_CONFIGURED")
 	}
 
 	secretStoreName := in.StoreName
 
-	if a.secretStores[secretStoreName] == nil {
+	secretStore, ok := a.secretStore(secretStoreName)
+	if !ok || secretStore == nil {
 		return nil, errors.New("ERR_SECRET_STORE_NOT_FOUND")
 	}
 
@@ -388,10 +1363,10 @@ func (a *api) GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (*d
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	getResponse, err := a.secretStores[secretStoreName].GetSecret(req)
+	getResponse, err := secretStore.GetSecret(req)
 
 	if err != nil {
-		return nil, fmt.Errorf("ERR_SECRET_GET: %s", err)
+		return nil, secretError(err)
 	}
 
 	response := &daprv1pb.GetSecretResponseEnvelope{}
@@ -401,6 +1376,46 @@ func (a *api) GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (*d
 	return response, nil
 }
 
+func (a *api) GetBulkSecret(ctx context.Context, in *daprv1pb.GetBulkSecretEnvelope) (*daprv1pb.GetBulkSecretResponseEnvelope, error) {
+	if !a.hasSecretStores() {
+		return nil, errors.New("ERR_SECRET_STORE_NOT_CONFIGURED")
+	}
+
+	secretStoreName := in.StoreName
+
+	secretStore, ok := a.secretStore(secretStoreName)
+	if !ok || secretStore == nil {
+		return nil, errors.New("ERR_SECRET_STORE_NOT_FOUND")
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("GetBulkSecret: %s", secretStoreName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	data, err := secretstores_loader.BulkGet(secretStore, in.Keys, in.Metadata)
+	if err == secretstores_loader.ErrBulkGetNotSupported {
+		return nil, status.Errorf(codes.Unimplemented, "ERR_SECRET_STORE_NOT_SUPPORTED: secret store %s doesn't read all its secrets, name the secrets to read", secretStoreName)
+	}
+	if err != nil {
+		return nil, secretError(err)
+	}
+
+	response := &daprv1pb.GetBulkSecretResponseEnvelope{Data: make(map[string]*daprv1pb.SecretResponse, len(data))}
+	for name, secret := range data {
+		response.Data[name] = &daprv1pb.SecretResponse{Secrets: secret}
+	}
+	return response, nil
+}
+
+// secretError returns the error of reading secrets, denied by the secrets scope of the app or failed
+func secretError(err error) error {
+	if notAllowed, ok := err.(*secretstores_loader.ErrSecretNotAllowed); ok {
+		return status.Errorf(codes.PermissionDenied, "ERR_PERMISSION_DENIED: %s", notAllowed)
+	}
+	return fmt.Errorf("ERR_SECRET_GET: %s", err)
+}
+
 func duration(p *durpb.Duration) (time.Duration, error) {
 	if err := validateDuration(p); err != nil {
 		return 0, err
@@ -7,14 +7,32 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
+	"net/url"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/dapr/components-contrib/exporters"
 	"github.com/dapr/components-contrib/exporters/stringexporter"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/components-contrib/state"
 	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/components"
+	"github.com/dapr/dapr/pkg/components/configuration"
+	configuration_inmemory "github.com/dapr/dapr/pkg/components/configuration/inmemory"
+	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
+	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	state_inmemory "github.com/dapr/dapr/pkg/components/state/inmemory"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/logger"
@@ -31,10 +49,13 @@ import (
 	"github.com/phayes/freeport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.opencensus.io/trace"
 	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
 	grpc_go "google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -49,6 +70,10 @@ func (m *mockGRPCAPI) CallLocal(ctx context.Context, in *internalv1pb.InternalIn
 	return resp.Proto(), nil
 }
 
+func (m *mockGRPCAPI) CallLocalStream(stream internalv1pb.DaprInternal_CallLocalStreamServer) error {
+	return nil
+}
+
 func (m *mockGRPCAPI) CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error) {
 	var resp = invokev1.NewInvokeMethodResponse(0, "", nil)
 	resp.WithRawData(ExtractSpanContext(ctx), "text/plains")
@@ -63,6 +88,10 @@ func (m *mockGRPCAPI) InvokeService(ctx context.Context, in *daprv1pb.InvokeServ
 	return &commonv1pb.InvokeResponse{}, nil
 }
 
+func (m *mockGRPCAPI) InvokeServiceStream(stream daprv1pb.Dapr_InvokeServiceStreamServer) error {
+	return nil
+}
+
 func (m *mockGRPCAPI) InvokeBinding(ctx context.Context, in *daprv1pb.InvokeBindingEnvelope) (*empty.Empty, error) {
 	return &empty.Empty{}, nil
 }
@@ -71,6 +100,10 @@ func (m *mockGRPCAPI) GetState(ctx context.Context, in *daprv1pb.GetStateEnvelop
 	return &daprv1pb.GetStateResponseEnvelope{}, nil
 }
 
+func (m *mockGRPCAPI) GetBulkState(ctx context.Context, in *daprv1pb.GetBulkStateEnvelope) (*daprv1pb.GetBulkStateResponseEnvelope, error) {
+	return &daprv1pb.GetBulkStateResponseEnvelope{}, nil
+}
+
 func (m *mockGRPCAPI) SaveState(ctx context.Context, in *daprv1pb.SaveStateEnvelope) (*empty.Empty, error) {
 	return &empty.Empty{}, nil
 }
@@ -79,10 +112,90 @@ func (m *mockGRPCAPI) DeleteState(ctx context.Context, in *daprv1pb.DeleteStateE
 	return &empty.Empty{}, nil
 }
 
+func (m *mockGRPCAPI) DeleteBulkState(ctx context.Context, in *daprv1pb.DeleteBulkStateEnvelope) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *mockGRPCAPI) ExecuteStateTransaction(ctx context.Context, in *daprv1pb.ExecuteStateTransactionEnvelope) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *mockGRPCAPI) DeleteStateByPrefixAlpha1(in *daprv1pb.DeleteStateByPrefixEnvelope, stream daprv1pb.Dapr_DeleteStateByPrefixAlpha1Server) error {
+	return nil
+}
+
+func (m *mockGRPCAPI) QueryStateAlpha1(ctx context.Context, in *daprv1pb.QueryStateEnvelope) (*daprv1pb.QueryStateResponseEnvelope, error) {
+	return &daprv1pb.QueryStateResponseEnvelope{}, nil
+}
+
+func (m *mockGRPCAPI) SubscribeState(in *daprv1pb.SubscribeStateEnvelope, stream daprv1pb.Dapr_SubscribeStateServer) error {
+	return nil
+}
+
+func (m *mockGRPCAPI) GetNextID(ctx context.Context, in *daprv1pb.GetNextIDEnvelope) (*daprv1pb.GetNextIDResponseEnvelope, error) {
+	return &daprv1pb.GetNextIDResponseEnvelope{}, nil
+}
+
+func (m *mockGRPCAPI) GenerateID(ctx context.Context, in *daprv1pb.GenerateIDEnvelope) (*daprv1pb.GenerateIDResponseEnvelope, error) {
+	return &daprv1pb.GenerateIDResponseEnvelope{}, nil
+}
+
+func (m *mockGRPCAPI) Campaign(in *daprv1pb.CampaignEnvelope, stream daprv1pb.Dapr_CampaignServer) error {
+	return nil
+}
+
+func (m *mockGRPCAPI) Resign(ctx context.Context, in *daprv1pb.ResignEnvelope) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *mockGRPCAPI) Observe(in *daprv1pb.ObserveEnvelope, stream daprv1pb.Dapr_ObserveServer) error {
+	return nil
+}
+
+func (m *mockGRPCAPI) TryLock(ctx context.Context, in *daprv1pb.TryLockEnvelope) (*daprv1pb.TryLockResponseEnvelope, error) {
+	return &daprv1pb.TryLockResponseEnvelope{}, nil
+}
+
+func (m *mockGRPCAPI) Unlock(ctx context.Context, in *daprv1pb.UnlockEnvelope) (*daprv1pb.UnlockResponseEnvelope, error) {
+	return &daprv1pb.UnlockResponseEnvelope{}, nil
+}
+
+func (m *mockGRPCAPI) GetConfiguration(ctx context.Context, in *daprv1pb.GetConfigurationEnvelope) (*daprv1pb.GetConfigurationResponseEnvelope, error) {
+	return &daprv1pb.GetConfigurationResponseEnvelope{}, nil
+}
+
+func (m *mockGRPCAPI) SubscribeConfiguration(in *daprv1pb.SubscribeConfigurationEnvelope, stream daprv1pb.Dapr_SubscribeConfigurationServer) error {
+	return nil
+}
+
+func (m *mockGRPCAPI) GetComponentCapabilities(ctx context.Context, in *empty.Empty) (*daprv1pb.GetComponentCapabilitiesResponseEnvelope, error) {
+	return &daprv1pb.GetComponentCapabilitiesResponseEnvelope{}, nil
+}
+
+func (m *mockGRPCAPI) GetMetadata(ctx context.Context, in *empty.Empty) (*daprv1pb.GetMetadataResponseEnvelope, error) {
+	return &daprv1pb.GetMetadataResponseEnvelope{}, nil
+}
+
+func (m *mockGRPCAPI) SetMetadata(ctx context.Context, in *daprv1pb.SetMetadataEnvelope) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *mockGRPCAPI) SubscribeTopicEvents(stream daprv1pb.Dapr_SubscribeTopicEventsServer) error {
+	return nil
+}
+
+func (m *mockGRPCAPI) Shutdown(ctx context.Context, in *empty.Empty) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
 func (m *mockGRPCAPI) GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (*daprv1pb.GetSecretResponseEnvelope, error) {
 	return &daprv1pb.GetSecretResponseEnvelope{}, nil
 }
 
+func (m *mockGRPCAPI) GetBulkSecret(ctx context.Context, in *daprv1pb.GetBulkSecretEnvelope) (*daprv1pb.GetBulkSecretResponseEnvelope, error) {
+	return &daprv1pb.GetBulkSecretResponseEnvelope{}, nil
+}
+
 func ExtractSpanContext(ctx context.Context) []byte {
 	sc, _ := ctx.Value(diag.DaprTraceContextKey{}).(trace.SpanContext)
 	return []byte(SerializeSpanContext(sc))
@@ -225,6 +338,25 @@ func TestCallRemoteAppWithTracing(t *testing.T) {
 	assert.NotEmpty(t, resp.GetMessage(), "failed to generate trace context with app call")
 }
 
+func TestCallerIdentity(t *testing.T) {
+	t.Run("mTLS peer", func(t *testing.T) {
+		cert := &x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/apps/frontend"}}}
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+		})
+
+		caller := callerIdentity(ctx)
+		assert.Equal(t, "frontend", caller.ID)
+		assert.Equal(t, "apps", caller.Namespace)
+		assert.Equal(t, "cluster.local", caller.TrustDomain)
+	})
+
+	t.Run("no mTLS", func(t *testing.T) {
+		assert.Nil(t, callerIdentity(peer.NewContext(context.Background(), &peer.Peer{})))
+		assert.Nil(t, callerIdentity(context.Background()))
+	})
+}
+
 func TestCallLocal(t *testing.T) {
 	t.Run("appchannel is not ready", func(t *testing.T) {
 		port, _ := freeport.GetFreePort()
@@ -289,6 +421,99 @@ func TestCallLocal(t *testing.T) {
 	})
 }
 
+// streamingAppChannel streams the request body of the invocations back
+type streamingAppChannel struct {
+	channelt.MockAppChannel
+}
+
+func (s *streamingAppChannel) InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	resp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	resp.WithRawData(nil, req.Message().GetContentType())
+	return resp, ioutil.NopCloser(body), nil
+}
+
+func TestCallLocalStream(t *testing.T) {
+	t.Run("streams the request to the app and the response back", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		fakeAPI := &api{
+			id:         "fakeAPI",
+			appChannel: &streamingAppChannel{},
+		}
+		server := startInternalServer(port, fakeAPI)
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := internalv1pb.NewDaprInternalClient(clientConn)
+		stream, err := client.CallLocalStream(context.Background())
+		require.NoError(t, err)
+		request := invokev1.NewInvokeMethodRequest("method").WithRawData(nil, "text/plain").Proto()
+		require.NoError(t, stream.Send(&internalv1pb.InternalInvokeRequestStream{Request: request}))
+		require.NoError(t, stream.Send(&internalv1pb.InternalInvokeRequestStream{Chunk: []byte("hello ")}))
+		require.NoError(t, stream.Send(&internalv1pb.InternalInvokeRequestStream{Chunk: []byte("world")}))
+		require.NoError(t, stream.CloseSend())
+
+		first, err := stream.Recv()
+		require.NoError(t, err)
+		resp, err := invokev1.InternalInvokeResponse(first.GetResponse())
+		require.NoError(t, err)
+		contentType, _ := resp.RawData()
+		assert.Equal(t, "text/plain", contentType)
+
+		data := []byte{}
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			data = append(data, msg.GetChunk()...)
+		}
+		assert.Equal(t, "hello world", string(data))
+	})
+
+	t.Run("app channel doesn't stream", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		fakeAPI := &api{
+			id:         "fakeAPI",
+			appChannel: new(channelt.MockAppChannel),
+		}
+		server := startInternalServer(port, fakeAPI)
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := internalv1pb.NewDaprInternalClient(clientConn)
+		stream, err := client.CallLocalStream(context.Background())
+		require.NoError(t, err)
+		request := invokev1.NewInvokeMethodRequest("method").Proto()
+		require.NoError(t, stream.Send(&internalv1pb.InternalInvokeRequestStream{Request: request}))
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("request without header", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		fakeAPI := &api{
+			id:         "fakeAPI",
+			appChannel: &streamingAppChannel{},
+		}
+		server := startInternalServer(port, fakeAPI)
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := internalv1pb.NewDaprInternalClient(clientConn)
+		stream, err := client.CallLocalStream(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&internalv1pb.InternalInvokeRequestStream{Chunk: []byte("data")}))
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
 func mustMarshalAny(msg proto.Message) *any.Any {
 	any, err := ptypes.MarshalAny(msg)
 	if err != nil {
@@ -405,6 +630,87 @@ func TestInvokeService(t *testing.T) {
 	})
 }
 
+func TestInvokeServiceStream(t *testing.T) {
+	mockDirectMessaging := new(daprt.MockDirectMessaging)
+	fakeAPI := &api{
+		id:              "fakeAPI",
+		directMessaging: mockDirectMessaging,
+	}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, fakeAPI)
+	defer server.Stop()
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	invoke := func(t *testing.T, chunks ...string) (string, error) {
+		stream, err := client.InvokeServiceStream(context.Background())
+		require.NoError(t, err)
+		req := &daprv1pb.InvokeServiceRequest{
+			Id:      "fakeAppID",
+			Message: &commonv1pb.InvokeRequest{Method: "fakeMethod", ContentType: "text/plain"},
+		}
+		require.NoError(t, stream.Send(&daprv1pb.InvokeServiceStreamRequest{Request: req}))
+		for _, c := range chunks {
+			require.NoError(t, stream.Send(&daprv1pb.InvokeServiceStreamRequest{Chunk: []byte(c)}))
+		}
+		require.NoError(t, stream.CloseSend())
+
+		data := []byte{}
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				return string(data), nil
+			}
+			if err != nil {
+				return string(data), err
+			}
+			data = append(data, msg.GetChunk()...)
+		}
+	}
+	echo := func(ctx context.Context, id string, req *invokev1.InvokeMethodRequest, body io.Reader) io.ReadCloser {
+		return ioutil.NopCloser(body)
+	}
+
+	t.Run("streams the request and the response", func(t *testing.T) {
+		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil).WithRawData(nil, "text/plain")
+		mockDirectMessaging.On("InvokeStream",
+			mock.Anything,
+			"fakeAppID",
+			mock.AnythingOfType("*v1.InvokeMethodRequest"),
+			mock.Anything).Return(fakeResp, echo, nil).Once()
+
+		data, err := invoke(t, "hello ", "world")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", data)
+	})
+
+	t.Run("handle http response code", func(t *testing.T) {
+		fakeResp := invokev1.NewInvokeMethodResponse(404, "NotFound", nil).WithRawData(nil, "text/plain")
+		mockDirectMessaging.On("InvokeStream",
+			mock.Anything,
+			"fakeAppID",
+			mock.AnythingOfType("*v1.InvokeMethodRequest"),
+			mock.Anything).Return(fakeResp, echo, nil).Once()
+
+		data, err := invoke(t, "not found")
+		assert.Equal(t, "not found", data)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invocation error", func(t *testing.T) {
+		mockDirectMessaging.On("InvokeStream",
+			mock.Anything,
+			"fakeAppID",
+			mock.AnythingOfType("*v1.InvokeMethodRequest"),
+			mock.Anything).Return(nil, nil, status.Error(codes.Unimplemented, "no streaming")).Once()
+
+		_, err := invoke(t)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	mockDirectMessaging.AssertExpectations(t)
+}
+
 func TestSaveState(t *testing.T) {
 	port, _ := freeport.GetFreePort()
 
@@ -442,6 +748,90 @@ func TestGetState(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// bulkStateStore returns the keys as values, failing the keys of the failed key, and records the concurrent gets
+type bulkStateStore struct {
+	state.Store
+	failed        string
+	lock          sync.Mutex
+	active        int
+	maxActive     int
+	requestedKeys []string
+}
+
+func (b *bulkStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	b.lock.Lock()
+	b.active++
+	if b.active > b.maxActive {
+		b.maxActive = b.active
+	}
+	b.requestedKeys = append(b.requestedKeys, req.Key)
+	b.lock.Unlock()
+
+	time.Sleep(time.Millisecond * 10)
+
+	b.lock.Lock()
+	b.active--
+	b.lock.Unlock()
+	if req.Key == b.failed {
+		return nil, errors.New("unavailable")
+	}
+	return &state.GetResponse{Data: []byte(req.Key), ETag: "1"}, nil
+}
+
+func TestGetBulkState(t *testing.T) {
+	store := &bulkStateStore{failed: "fakeAPI||key3"}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		id:          "fakeAPI",
+		stateStores: map[string]state.Store{"store": store},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("keys are read with the given parallelism", func(t *testing.T) {
+		keys := []string{"key1", "key2", "key3", "key4", "key5", "key6"}
+		resp, err := client.GetBulkState(context.Background(), &daprv1pb.GetBulkStateEnvelope{StoreName: "store", Keys: keys, Parallelism: 2})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, len(keys))
+		for i, item := range resp.Items {
+			assert.Equal(t, keys[i], item.Key)
+			if item.Key == "key3" {
+				assert.Equal(t, "ERR_STATE_GET: unavailable", item.Error)
+				assert.Nil(t, item.Data)
+				continue
+			}
+			assert.Empty(t, item.Error)
+			assert.Equal(t, []byte("fakeAPI||"+keys[i]), item.Data.Value)
+			assert.Equal(t, "1", item.Etag)
+		}
+		assert.Len(t, store.requestedKeys, len(keys))
+		assert.True(t, store.maxActive <= 2)
+	})
+
+	t.Run("parallelism is clamped", func(t *testing.T) {
+		store.lock.Lock()
+		store.maxActive = 0
+		store.lock.Unlock()
+
+		keys := make([]string, 2*maxBulkStateParallelism)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("key%d", i)
+		}
+		resp, err := client.GetBulkState(context.Background(), &daprv1pb.GetBulkStateEnvelope{StoreName: "store", Keys: keys, Parallelism: 1 << 30})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, len(keys))
+		assert.True(t, store.maxActive <= maxBulkStateParallelism)
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		_, err := client.GetBulkState(context.Background(), &daprv1pb.GetBulkStateEnvelope{StoreName: "other", Keys: []string{"key1"}})
+		assert.Error(t, err)
+	})
+}
+
 func TestDeleteState(t *testing.T) {
 	port, _ := freeport.GetFreePort()
 
@@ -456,6 +846,573 @@ func TestDeleteState(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// deletingStateStore records its deletes and bulk deletes, failing the deletes of the failed key
+type deletingStateStore struct {
+	state.Store
+	failed      string
+	deleted     []string
+	bulkDeletes int
+}
+
+func (d *deletingStateStore) Delete(req *state.DeleteRequest) error {
+	if req.Key == d.failed {
+		return errors.New("unavailable")
+	}
+	d.deleted = append(d.deleted, req.Key)
+	return nil
+}
+
+func (d *deletingStateStore) BulkDelete(reqs []state.DeleteRequest) error {
+	d.bulkDeletes++
+	for _, r := range reqs {
+		d.deleted = append(d.deleted, r.Key)
+	}
+	return nil
+}
+
+func TestDeleteBulkState(t *testing.T) {
+	bulkStore := &deletingStateStore{}
+	sequentialStore := &deletingStateStore{failed: "fakeAPI||key2"}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		id: "fakeAPI",
+		stateStores: map[string]state.Store{
+			"bulk":       bulkStore,
+			"sequential": sequentialStore,
+		},
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{
+				{Name: "bulk", Type: "state.zookeeper", Features: []string{components.FeatureBulkDelete}},
+				{Name: "sequential", Type: "state.redis", Features: []string{components.FeatureETag}},
+			}
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("keys are deleted in one operation", func(t *testing.T) {
+		_, err := client.DeleteBulkState(context.Background(), &daprv1pb.DeleteBulkStateEnvelope{
+			StoreName: "bulk",
+			Requests:  []*daprv1pb.StateRequest{{Key: "key1", Etag: "1"}, {Key: "key2"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, bulkStore.bulkDeletes)
+		assert.Equal(t, []string{"fakeAPI||key1", "fakeAPI||key2"}, bulkStore.deleted)
+	})
+
+	t.Run("keys are deleted one by one until a delete fails", func(t *testing.T) {
+		_, err := client.DeleteBulkState(context.Background(), &daprv1pb.DeleteBulkStateEnvelope{
+			StoreName: "sequential",
+			Requests:  []*daprv1pb.StateRequest{{Key: "key1"}, {Key: "key2"}, {Key: "key3"}},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "key2")
+		assert.Equal(t, 0, sequentialStore.bulkDeletes)
+		assert.Equal(t, []string{"fakeAPI||key1"}, sequentialStore.deleted)
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		_, err := client.DeleteBulkState(context.Background(), &daprv1pb.DeleteBulkStateEnvelope{StoreName: "other"})
+		assert.Error(t, err)
+	})
+}
+
+// transactionalStateStore records the operations of its transactions
+type transactionalStateStore struct {
+	state.Store
+	reqs []state.TransactionalRequest
+}
+
+func (s *transactionalStateStore) Multi(reqs []state.TransactionalRequest) error {
+	s.reqs = reqs
+	return nil
+}
+
+func TestExecuteStateTransaction(t *testing.T) {
+	store := &transactionalStateStore{}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		id: "fakeAPI",
+		stateStores: map[string]state.Store{
+			"transactional":     store,
+			"non-transactional": &bulkStateStore{},
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("operations are executed in one transaction", func(t *testing.T) {
+		_, err := client.ExecuteStateTransaction(context.Background(), &daprv1pb.ExecuteStateTransactionEnvelope{
+			StoreName: "transactional",
+			Operations: []*daprv1pb.TransactionalStateOperation{
+				{OperationType: "upsert", Request: &daprv1pb.StateRequest{Key: "key1", Value: &any.Any{Value: []byte("value1")}, Etag: "1"}},
+				{OperationType: "delete", Request: &daprv1pb.StateRequest{Key: "key2", Etag: "2"}},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "fakeAPI||key1", Value: []byte("value1"), ETag: "1"}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "fakeAPI||key2", ETag: "2"}},
+		}, store.reqs)
+	})
+
+	t.Run("store without transactions", func(t *testing.T) {
+		_, err := client.ExecuteStateTransaction(context.Background(), &daprv1pb.ExecuteStateTransactionEnvelope{
+			StoreName:  "non-transactional",
+			Operations: []*daprv1pb.TransactionalStateOperation{{OperationType: "delete", Request: &daprv1pb.StateRequest{Key: "key1"}}},
+		})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("unknown operation type", func(t *testing.T) {
+		_, err := client.ExecuteStateTransaction(context.Background(), &daprv1pb.ExecuteStateTransactionEnvelope{
+			StoreName:  "transactional",
+			Operations: []*daprv1pb.TransactionalStateOperation{{OperationType: "get", Request: &daprv1pb.StateRequest{Key: "key1"}}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestQueryStateAlpha1(t *testing.T) {
+	store := state_inmemory.NewStateStore()
+	assert.NoError(t, store.BulkSet([]state.SetRequest{
+		{Key: "fakeAPI||order1", Value: []byte(`{"status": "shipped", "total": 20}`)},
+		{Key: "fakeAPI||order2", Value: []byte(`{"status": "pending", "total": 5}`)},
+		{Key: "fakeAPI||order3", Value: []byte(`{"status": "shipped", "total": 10}`)},
+		{Key: "otherApp||order4", Value: []byte(`{"status": "shipped", "total": 1}`)},
+	}))
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		id: "fakeAPI",
+		stateStores: map[string]state.Store{
+			"queryable": store,
+			"other":     &bulkStateStore{},
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("pages of the keys of the app", func(t *testing.T) {
+		query := `{"filter": {"EQ": {"status": "shipped"}}, "sort": [{"key": "total"}], "page": {"limit": 1}}`
+		resp, err := client.QueryStateAlpha1(context.Background(), &daprv1pb.QueryStateEnvelope{StoreName: "queryable", Query: query})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Results, 1)
+		assert.Equal(t, "order3", resp.Results[0].Key)
+		assert.Equal(t, []byte(`{"status": "shipped", "total": 10}`), resp.Results[0].Data.Value)
+		assert.NotEmpty(t, resp.Results[0].Etag)
+
+		query = fmt.Sprintf(`{"filter": {"EQ": {"status": "shipped"}}, "sort": [{"key": "total"}], "page": {"limit": 1, "token": "%s"}}`, resp.Token)
+		resp, err = client.QueryStateAlpha1(context.Background(), &daprv1pb.QueryStateEnvelope{StoreName: "queryable", Query: query})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Results, 1)
+		assert.Equal(t, "order1", resp.Results[0].Key)
+		assert.Empty(t, resp.Token)
+	})
+
+	t.Run("malformed query", func(t *testing.T) {
+		_, err := client.QueryStateAlpha1(context.Background(), &daprv1pb.QueryStateEnvelope{StoreName: "queryable", Query: `{"filter": {"LIKE": {"status": "s%"}}}`})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("store without queries", func(t *testing.T) {
+		_, err := client.QueryStateAlpha1(context.Background(), &daprv1pb.QueryStateEnvelope{StoreName: "other", Query: `{}`})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+type fakeWatcher struct {
+	keys []string
+}
+
+func (f *fakeWatcher) Watch(ctx context.Context, keys, prefixes []string, handler func(state_loader.StateChange)) error {
+	if len(prefixes) > 0 {
+		return state_loader.ErrWatchPrefixNotSupported
+	}
+	f.keys = keys
+	for _, k := range keys {
+		handler(state_loader.StateChange{Key: k, Value: []byte("value"), ETag: "1"})
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestSubscribeState(t *testing.T) {
+	watcher := &fakeWatcher{}
+	fakeAPI := &api{
+		id:            "fakeAPI",
+		stateStores:   map[string]state.Store{"store": nil},
+		stateWatchers: map[string]state_loader.Watcher{"store": watcher},
+	}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, fakeAPI)
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("changes of keys are streamed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := client.SubscribeState(ctx, &daprv1pb.SubscribeStateEnvelope{StoreName: "store", Keys: []string{"key1"}})
+		assert.NoError(t, err)
+		change, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, "key1", change.Key)
+		assert.Equal(t, []byte("value"), change.Data.Value)
+		assert.Equal(t, "1", change.Etag)
+		assert.Equal(t, []string{"fakeAPI||key1"}, watcher.keys)
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		stream, err := client.SubscribeState(context.Background(), &daprv1pb.SubscribeStateEnvelope{StoreName: "other", Keys: []string{"key1"}})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported prefixes", func(t *testing.T) {
+		stream, err := client.SubscribeState(context.Background(), &daprv1pb.SubscribeStateEnvelope{StoreName: "store", KeyPrefixes: []string{"order-"}})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeleteStateByPrefixAlpha1(t *testing.T) {
+	store := state_inmemory.NewStateStore()
+	assert.NoError(t, store.BulkSet([]state.SetRequest{
+		{Key: "fakeAPI||tenant1||a", Value: []byte("1")},
+		{Key: "fakeAPI||tenant1||b", Value: []byte("2")},
+		{Key: "fakeAPI||tenant1||c", Value: []byte("3")},
+		{Key: "fakeAPI||tenant2||a", Value: []byte("4")},
+	}))
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		id:          "fakeAPI",
+		stateStores: map[string]state.Store{"store": store, "other": store},
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{{Name: "store", Features: []string{components.FeatureDeleteByPrefix}}}
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("progress is streamed after each page", func(t *testing.T) {
+		stream, err := client.DeleteStateByPrefixAlpha1(context.Background(), &daprv1pb.DeleteStateByPrefixEnvelope{StoreName: "store", Prefix: "tenant1||", PageSize: 2})
+		assert.NoError(t, err)
+		progress, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), progress.Deleted)
+		assert.Equal(t, "fakeAPI||tenant1||b", progress.Cursor)
+		progress, err = stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), progress.Deleted)
+		assert.Empty(t, progress.Cursor)
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+
+		resp, err := store.Get(&state.GetRequest{Key: "fakeAPI||tenant2||a"})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("4"), resp.Data)
+	})
+
+	t.Run("store that didn't opt in", func(t *testing.T) {
+		stream, err := client.DeleteStateByPrefixAlpha1(context.Background(), &daprv1pb.DeleteStateByPrefixEnvelope{StoreName: "other", Prefix: "tenant2||"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("missing prefix", func(t *testing.T) {
+		stream, err := client.DeleteStateByPrefixAlpha1(context.Background(), &daprv1pb.DeleteStateByPrefixEnvelope{StoreName: "store"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGenerateID(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	resp, err := client.GenerateID(context.Background(), &daprv1pb.GenerateIDEnvelope{Kind: "ulid"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Id, 26)
+
+	_, err = client.GenerateID(context.Background(), &daprv1pb.GenerateIDEnvelope{Kind: "snowflake"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetComponentCapabilities(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{
+				{Name: "statestore", Type: "state.redis", Features: []string{components.FeatureETag}},
+			}
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	resp, err := client.GetComponentCapabilities(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Components, 1)
+	assert.Equal(t, "statestore", resp.Components[0].Name)
+	assert.Equal(t, "state.redis", resp.Components[0].Type)
+	assert.Equal(t, []string{"etag"}, resp.Components[0].Features)
+}
+
+func TestMetadata(t *testing.T) {
+	mockActors := new(daprt.MockActors)
+	mockActors.On("GetActiveActorsCount")
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		id:               "app",
+		actor:            mockActors,
+		extendedMetadata: &sync.Map{},
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{
+				{Name: "statestore", Type: "state.redis", Features: []string{components.FeatureETag}},
+			}
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	_, err := client.SetMetadata(context.Background(), &daprv1pb.SetMetadataEnvelope{Key: "team", Value: "payments"})
+	assert.NoError(t, err)
+	_, err = client.SetMetadata(context.Background(), &daprv1pb.SetMetadataEnvelope{Value: "payments"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := client.GetMetadata(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "app", resp.Id)
+	assert.Equal(t, map[string]string{"team": "payments"}, resp.ExtendedMetadata)
+	require.Len(t, resp.RegisteredComponents, 1)
+	assert.Equal(t, "statestore", resp.RegisteredComponents[0].Name)
+	require.Len(t, resp.ActiveActorsCount, 2)
+	assert.Equal(t, "abcd", resp.ActiveActorsCount[0].Type)
+	assert.Equal(t, int32(10), resp.ActiveActorsCount[0].Count)
+}
+
+// fakeStateStore is a state store that is never called
+type fakeStateStore struct {
+	state.Store
+}
+
+func TestLeadership(t *testing.T) {
+	fakeAPI := &api{
+		id:          "fakeAPI",
+		stateStores: map[string]state.Store{"store": &fakeStateStore{}, "noinsert": &fakeStateStore{}},
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{
+				{Name: "store", Features: []string{components.FeatureETag, components.FeatureInsert}},
+				{Name: "noinsert", Features: []string{components.FeatureETag}},
+			}
+		},
+	}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, fakeAPI)
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("campaign in unknown store", func(t *testing.T) {
+		stream, err := client.Campaign(context.Background(), &daprv1pb.CampaignEnvelope{StoreName: "other", Election: "workers", Candidate: "a"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Error(t, err)
+	})
+
+	t.Run("campaign in store without the insert feature", func(t *testing.T) {
+		stream, err := client.Campaign(context.Background(), &daprv1pb.CampaignEnvelope{StoreName: "noinsert", Election: "workers", Candidate: "a"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("campaign without candidate", func(t *testing.T) {
+		stream, err := client.Campaign(context.Background(), &daprv1pb.CampaignEnvelope{StoreName: "store", Election: "workers"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("resign without campaign", func(t *testing.T) {
+		_, err := client.Resign(context.Background(), &daprv1pb.ResignEnvelope{StoreName: "store", Election: "workers", Candidate: "a"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+// failingStateStore is a state store that fails all the calls
+type failingStateStore struct {
+	state.Store
+}
+
+func (f *failingStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestLock(t *testing.T) {
+	fakeAPI := &api{
+		id: "fakeAPI",
+		stateStores: map[string]state.Store{
+			"store":    state_inmemory.NewStateStore(),
+			"noinsert": state_inmemory.NewStateStore(),
+			"failing":  &failingStateStore{},
+		},
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{
+				{Name: "store", Features: []string{components.FeatureInsert}},
+				{Name: "noinsert", Features: []string{components.FeatureETag}},
+				{Name: "failing", Features: []string{components.FeatureInsert}},
+			}
+		},
+	}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, fakeAPI)
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("lock is held until it's released", func(t *testing.T) {
+		resp, err := client.TryLock(context.Background(), &daprv1pb.TryLockEnvelope{StoreName: "store", ResourceId: "job", LockOwner: "a", ExpiryInSeconds: 60})
+		assert.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.Equal(t, int64(1), resp.FencingToken)
+
+		resp, err = client.TryLock(context.Background(), &daprv1pb.TryLockEnvelope{StoreName: "store", ResourceId: "job", LockOwner: "b", ExpiryInSeconds: 60})
+		assert.NoError(t, err)
+		assert.False(t, resp.Success)
+
+		unlock, err := client.Unlock(context.Background(), &daprv1pb.UnlockEnvelope{StoreName: "store", ResourceId: "job", LockOwner: "b"})
+		assert.NoError(t, err)
+		assert.Equal(t, daprv1pb.UnlockResponseEnvelope_LOCK_BELONGS_TO_OTHERS, unlock.Status)
+
+		unlock, err = client.Unlock(context.Background(), &daprv1pb.UnlockEnvelope{StoreName: "store", ResourceId: "job", LockOwner: "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, daprv1pb.UnlockResponseEnvelope_SUCCESS, unlock.Status)
+
+		resp, err = client.TryLock(context.Background(), &daprv1pb.TryLockEnvelope{StoreName: "store", ResourceId: "job", LockOwner: "b", ExpiryInSeconds: 60})
+		assert.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.Equal(t, int64(2), resp.FencingToken)
+	})
+
+	t.Run("unlock without lock", func(t *testing.T) {
+		unlock, err := client.Unlock(context.Background(), &daprv1pb.UnlockEnvelope{StoreName: "store", ResourceId: "other", LockOwner: "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, daprv1pb.UnlockResponseEnvelope_LOCK_DOES_NOT_EXIST, unlock.Status)
+	})
+
+	t.Run("lock in unknown store", func(t *testing.T) {
+		_, err := client.TryLock(context.Background(), &daprv1pb.TryLockEnvelope{StoreName: "other", ResourceId: "job", LockOwner: "a", ExpiryInSeconds: 60})
+		assert.Error(t, err)
+	})
+
+	t.Run("lock in store without the insert feature", func(t *testing.T) {
+		_, err := client.TryLock(context.Background(), &daprv1pb.TryLockEnvelope{StoreName: "noinsert", ResourceId: "job", LockOwner: "a", ExpiryInSeconds: 60})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("store errors are returned", func(t *testing.T) {
+		_, err := client.TryLock(context.Background(), &daprv1pb.TryLockEnvelope{StoreName: "failing", ResourceId: "job", LockOwner: "a", ExpiryInSeconds: 60})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ERR_LOCK_TRY_LOCK")
+	})
+
+	t.Run("lock without expiry", func(t *testing.T) {
+		_, err := client.TryLock(context.Background(), &daprv1pb.TryLockEnvelope{StoreName: "store", ResourceId: "job", LockOwner: "a"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestConfiguration(t *testing.T) {
+	store := configuration_inmemory.NewStore()
+	assert.NoError(t, store.Init(configuration.Metadata{Properties: map[string]string{"flag": "on", "limit": "10"}}))
+	fakeAPI := &api{
+		id:                  "fakeAPI",
+		configurationStores: map[string]configuration.Store{"config": store},
+	}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, fakeAPI)
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("get keys", func(t *testing.T) {
+		resp, err := client.GetConfiguration(context.Background(), &daprv1pb.GetConfigurationEnvelope{StoreName: "config", Keys: []string{"flag"}})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, 1)
+		assert.Equal(t, "on", resp.Items[0].Value)
+	})
+
+	t.Run("get from unknown store", func(t *testing.T) {
+		_, err := client.GetConfiguration(context.Background(), &daprv1pb.GetConfigurationEnvelope{StoreName: "other"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("subscribe to changes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := client.SubscribeConfiguration(ctx, &daprv1pb.SubscribeConfigurationEnvelope{StoreName: "config", Keys: []string{"flag"}})
+		assert.NoError(t, err)
+
+		// the subscription starts asynchronously, so the change is made until it's received
+		received := make(chan *daprv1pb.SubscribeConfigurationResponseEnvelope)
+		go func() {
+			resp, err := stream.Recv()
+			if err == nil {
+				received <- resp
+			}
+		}()
+		value := 0
+		for {
+			value++
+			assert.NoError(t, store.Init(configuration.Metadata{Properties: map[string]string{"flag": strconv.Itoa(value), "limit": "10"}}))
+			select {
+			case resp := <-received:
+				assert.Len(t, resp.Items, 1)
+				assert.Equal(t, "flag", resp.Items[0].Key)
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
+}
+
 func TestPublishTopic(t *testing.T) {
 	port, _ := freeport.GetFreePort()
 
@@ -483,3 +1440,164 @@ func TestInvokeBinding(t *testing.T) {
 	_, err := client.InvokeBinding(context.Background(), &daprv1pb.InvokeBindingEnvelope{})
 	assert.Nil(t, err)
 }
+
+// bulkSecretStore reads all its secrets at once
+type bulkSecretStore struct {
+	secrets map[string]map[string]string
+}
+
+func (b *bulkSecretStore) Init(metadata secretstores.Metadata) error {
+	return nil
+}
+
+func (b *bulkSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	return secretstores.GetSecretResponse{Data: b.secrets[req.Name]}, nil
+}
+
+func (b *bulkSecretStore) BulkGetSecret(req secretstores_loader.BulkGetSecretRequest) (secretstores_loader.BulkGetSecretResponse, error) {
+	return secretstores_loader.BulkGetSecretResponse{Data: b.secrets}, nil
+}
+
+// singleSecretStore reads its secrets one by one
+type singleSecretStore struct {
+	secrets map[string]map[string]string
+}
+
+func (s *singleSecretStore) Init(metadata secretstores.Metadata) error {
+	return nil
+}
+
+func (s *singleSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	return secretstores.GetSecretResponse{Data: s.secrets[req.Name]}, nil
+}
+
+func TestGetBulkSecret(t *testing.T) {
+	secrets := map[string]map[string]string{
+		"db":    {"password": "1"},
+		"admin": {"password": "2"},
+	}
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		secretStores: map[string]secretstores.SecretStore{
+			"bulk":   secretstores_loader.WithScope(&bulkSecretStore{secrets: secrets}, config.SecretsScope{StoreName: "bulk", DeniedSecrets: []string{"admin"}}),
+			"single": &singleSecretStore{secrets: secrets},
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("all the secrets allowed to the app", func(t *testing.T) {
+		resp, err := client.GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{StoreName: "bulk"})
+		require.NoError(t, err)
+		assert.Len(t, resp.Data, 1)
+		assert.Equal(t, "1", resp.Data["db"].GetSecrets()["password"])
+	})
+
+	t.Run("named secrets", func(t *testing.T) {
+		resp, err := client.GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{StoreName: "single", Keys: []string{"db", "admin"}})
+		require.NoError(t, err)
+		assert.Len(t, resp.Data, 2)
+		assert.Equal(t, "2", resp.Data["admin"].GetSecrets()["password"])
+	})
+
+	t.Run("denied secret", func(t *testing.T) {
+		_, err := client.GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{StoreName: "bulk", Keys: []string{"db", "admin"}})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("all the secrets of a store reading them one by one", func(t *testing.T) {
+		_, err := client.GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{StoreName: "single"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		_, err := client.GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{StoreName: "unknown"})
+		assert.Error(t, err)
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	shutdown := make(chan struct{})
+	server := startDaprAPIServer(port, &api{shutdownFn: func() { close(shutdown) }})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	_, err := client.Shutdown(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	select {
+	case <-shutdown:
+	default:
+		t.Fatal("shutdown wasn't requested")
+	}
+}
+
+func TestSubscribeTopicEvents(t *testing.T) {
+	streams := pubsub_loader.NewStreams()
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		subscribeFn: func(topics []string) (*pubsub_loader.Stream, error) {
+			return streams.Open(topics), nil
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("events are acked by the app", func(t *testing.T) {
+		stream, err := client.SubscribeTopicEvents(context.Background())
+		require.NoError(t, err)
+		defer stream.CloseSend()
+		require.NoError(t, stream.Send(&daprv1pb.SubscribeTopicEventsEnvelope{
+			SubscribeTopicEventsType: &daprv1pb.SubscribeTopicEventsEnvelope_InitialRequest{
+				InitialRequest: &daprv1pb.SubscribeTopicEventsInitialEnvelope{Topics: []string{"orders"}},
+			},
+		}))
+
+		for _, ack := range []daprv1pb.TopicEventAckEnvelope_Status{daprv1pb.TopicEventAckEnvelope_SUCCESS, daprv1pb.TopicEventAckEnvelope_RETRY} {
+			delivered := make(chan error, 1)
+			go func() {
+				// the stream may not be open yet when the first event is published
+				for {
+					err := streams.Deliver(context.Background(), &pubsub.NewMessage{Topic: "orders", Data: []byte("event")})
+					if err != pubsub_loader.ErrNoStream {
+						delivered <- err
+						return
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}()
+
+			event, err := stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, "orders", event.Topic)
+			assert.Equal(t, []byte("event"), event.Data)
+			require.NoError(t, stream.Send(&daprv1pb.SubscribeTopicEventsEnvelope{
+				SubscribeTopicEventsType: &daprv1pb.SubscribeTopicEventsEnvelope_EventAck{
+					EventAck: &daprv1pb.TopicEventAckEnvelope{Id: event.Id, Status: ack},
+				},
+			}))
+			if ack == daprv1pb.TopicEventAckEnvelope_SUCCESS {
+				assert.NoError(t, <-delivered)
+			} else {
+				assert.Error(t, <-delivered)
+			}
+		}
+	})
+
+	t.Run("first message without topics", func(t *testing.T) {
+		stream, err := client.SubscribeTopicEvents(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&daprv1pb.SubscribeTopicEventsEnvelope{}))
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,56 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/bulkhead"
+	"github.com/dapr/dapr/pkg/config"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bulkheadBuildingBlocks are the building blocks of the bulkheads of the unary methods, by method name
+var bulkheadBuildingBlocks = map[string]string{
+	"GetState":                config.BulkheadState,
+	"GetBulkState":            config.BulkheadState,
+	"SaveState":               config.BulkheadState,
+	"DeleteState":             config.BulkheadState,
+	"DeleteBulkState":         config.BulkheadState,
+	"ExecuteStateTransaction": config.BulkheadState,
+	"QueryStateAlpha1":        config.BulkheadState,
+	"GetNextID":               config.BulkheadState,
+	"GenerateID":              config.BulkheadState,
+	"TryLock":                 config.BulkheadState,
+	"Unlock":                  config.BulkheadState,
+	"PublishEvent":            config.BulkheadPubSub,
+	"InvokeBinding":           config.BulkheadBindings,
+	"InvokeService":           config.BulkheadInvocation,
+	"CallLocal":               config.BulkheadInvocation,
+}
+
+// bulkheadInterceptor runs the calls of the building blocks on a worker of their bulkhead, rejecting them when it's full
+func bulkheadInterceptor(bulkheads bulkhead.Bulkheads) grpc_go.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc_go.UnaryServerInfo, handler grpc_go.UnaryHandler) (interface{}, error) {
+		method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+		block := bulkheadBuildingBlocks[method]
+		b := bulkheads.For(block)
+		if b == nil {
+			return handler(ctx, req)
+		}
+		if err := b.Acquire(ctx); err != nil {
+			if err == bulkhead.ErrFull {
+				return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent %s calls: %s", block, err)
+			}
+			return nil, status.FromContextError(err).Err()
+		}
+		defer b.Release()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,47 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/bulkhead"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBulkheadInterceptor(t *testing.T) {
+	b := bulkhead.New(config.BulkheadState, 1, 0, 0)
+	interceptor := bulkheadInterceptor(bulkhead.Bulkheads{config.BulkheadState: b})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	call := func(method string) (interface{}, error) {
+		return interceptor(context.Background(), nil, &grpc_go.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	resp, err := call("/dapr.proto.dapr.v1.Dapr/GetState")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	assert.NoError(t, b.Acquire(context.Background()))
+	_, err = call("/dapr.proto.dapr.v1.Dapr/SaveState")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// building blocks without a bulkhead aren't limited
+	_, err = call("/dapr.proto.dapr.v1.Dapr/PublishEvent")
+	assert.NoError(t, err)
+	_, err = call("/dapr.proto.dapr.v1.Dapr/GetSecret")
+	assert.NoError(t, err)
+
+	b.Release()
+	_, err = call("/dapr.proto.dapr.v1.Dapr/DeleteState")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,121 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/config"
+	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// callLocalAuth authorizes the invocations of the app from other sidecars: the requests naming their target must target
+// the app id of the sidecar and, with mTLS, the callers must present the identity of an allowed sidecar of the trust domain issued by
+// Sentry
+type callLocalAuth struct {
+	appID string
+	// trustDomain is the trust domain of the sidecar, empty without mTLS
+	trustDomain string
+	// allowedCallers are the app ids of the callers allowed with mTLS, all of them when empty
+	allowedCallers []string
+	// requireTarget rejects the requests that don't name their target, like the ones of older sidecars
+	requireTarget bool
+}
+
+func newCallLocalAuth(appID, trustDomain string, spec config.CallLocalSpec) callLocalAuth {
+	return callLocalAuth{
+		appID:          appID,
+		trustDomain:    trustDomain,
+		allowedCallers: spec.AllowedCallers,
+		requireTarget:  spec.RequireTarget,
+	}
+}
+
+func (a callLocalAuth) checkCaller(ctx context.Context) error {
+	if a.trustDomain == "" {
+		return nil
+	}
+	caller := callerIdentity(ctx)
+	if caller == nil {
+		return status.Error(codes.PermissionDenied, "the caller has no sidecar identity")
+	}
+	if caller.TrustDomain != a.trustDomain {
+		return status.Errorf(codes.PermissionDenied, "the caller %s is not in the trust domain %s", caller.SPIFFEID(), a.trustDomain)
+	}
+	if len(a.allowedCallers) == 0 {
+		return nil
+	}
+	for _, id := range a.allowedCallers {
+		if caller.ID == id {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "the caller %s is not allowed to call app %s", caller.SPIFFEID(), a.appID)
+}
+
+func (a callLocalAuth) checkTarget(req *internalv1pb.InternalInvokeRequest) error {
+	target := req.GetTargetAppId()
+	if target == "" {
+		if a.requireTarget {
+			return status.Errorf(codes.PermissionDenied, "the request for app %s doesn't name its target app", a.appID)
+		}
+		log.Debugf("accepting a request for app %s that doesn't name its target app", a.appID)
+		return nil
+	}
+	if target != a.appID {
+		return status.Errorf(codes.PermissionDenied, "the request for app %s was routed to app %s", target, a.appID)
+	}
+	return nil
+}
+
+func callLocalAuthInterceptor(auth callLocalAuth) grpc_go.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc_go.UnaryServerInfo, handler grpc_go.UnaryHandler) (interface{}, error) {
+		if !strings.HasSuffix(info.FullMethod, "/CallLocal") {
+			return handler(ctx, req)
+		}
+		if err := auth.checkCaller(ctx); err != nil {
+			return nil, err
+		}
+		if r, ok := req.(*internalv1pb.InternalInvokeRequest); ok {
+			if err := auth.checkTarget(r); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+func callLocalAuthStreamInterceptor(auth callLocalAuth) grpc_go.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc_go.ServerStream, info *grpc_go.StreamServerInfo, handler grpc_go.StreamHandler) error {
+		if !strings.HasSuffix(info.FullMethod, "/CallLocalStream") {
+			return handler(srv, stream)
+		}
+		if err := auth.checkCaller(stream.Context()); err != nil {
+			return err
+		}
+		return handler(srv, &callLocalAuthStream{ServerStream: stream, auth: auth})
+	}
+}
+
+// callLocalAuthStream checks the target of the request, set in the first message of the stream
+type callLocalAuthStream struct {
+	grpc_go.ServerStream
+	auth callLocalAuth
+}
+
+func (s *callLocalAuthStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if r, ok := m.(*internalv1pb.InternalInvokeRequestStream); ok && r.GetRequest() != nil {
+		return s.auth.checkTarget(r.GetRequest())
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// sidecarContext returns the context of a call from a sidecar with the SPIFFE ID
+func sidecarContext(spiffeID *url.URL) context.Context {
+	cert := &x509.Certificate{}
+	if spiffeID != nil {
+		cert.URIs = []*url.URL{spiffeID}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func TestCallLocalAuthInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	callLocal := &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.daprinternal.v1.DaprInternal/CallLocal"}
+	callActor := &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.daprinternal.v1.DaprInternal/CallActor"}
+
+	t.Run("target app id", func(t *testing.T) {
+		interceptor := callLocalAuthInterceptor(callLocalAuth{appID: "orders"})
+		call := func(info *grpc_go.UnaryServerInfo, target string) error {
+			_, err := interceptor(context.Background(), &internalv1pb.InternalInvokeRequest{TargetAppId: target}, info, handler)
+			return err
+		}
+
+		assert.NoError(t, call(callLocal, "orders"))
+		assert.NoError(t, call(callLocal, ""))
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(callLocal, "payments")))
+		assert.NoError(t, call(callActor, "payments"))
+	})
+
+	t.Run("missing target app id rejected when required", func(t *testing.T) {
+		interceptor := callLocalAuthInterceptor(callLocalAuth{appID: "orders", requireTarget: true})
+		_, err := interceptor(context.Background(), &internalv1pb.InternalInvokeRequest{}, callLocal, handler)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = interceptor(context.Background(), &internalv1pb.InternalInvokeRequest{TargetAppId: "orders"}, callLocal, handler)
+		assert.NoError(t, err)
+	})
+
+	t.Run("sidecars of older and newer versions during an upgrade", func(t *testing.T) {
+		// older sidecars don't name the target app, newer ones do
+		interceptor := callLocalAuthInterceptor(newCallLocalAuth("orders", "cluster.local", config.CallLocalSpec{}))
+		ctx := sidecarContext(&url.URL{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/apps/frontend"})
+		_, err := interceptor(ctx, &internalv1pb.InternalInvokeRequest{}, callLocal, handler)
+		assert.NoError(t, err)
+		_, err = interceptor(ctx, &internalv1pb.InternalInvokeRequest{TargetAppId: "orders"}, callLocal, handler)
+		assert.NoError(t, err)
+
+		// once all the sidecars are upgraded
+		interceptor = callLocalAuthInterceptor(newCallLocalAuth("orders", "cluster.local", config.CallLocalSpec{RequireTarget: true}))
+		_, err = interceptor(ctx, &internalv1pb.InternalInvokeRequest{}, callLocal, handler)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("caller identity with mTLS", func(t *testing.T) {
+		interceptor := callLocalAuthInterceptor(callLocalAuth{appID: "orders", trustDomain: "cluster.local"})
+		call := func(ctx context.Context) error {
+			_, err := interceptor(ctx, &internalv1pb.InternalInvokeRequest{TargetAppId: "orders"}, callLocal, handler)
+			return err
+		}
+
+		assert.NoError(t, call(sidecarContext(&url.URL{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/apps/frontend"})))
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(sidecarContext(&url.URL{Scheme: "spiffe", Host: "other.domain", Path: "/ns/apps/frontend"}))))
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(sidecarContext(nil))))
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(context.Background())))
+	})
+
+	t.Run("allowed callers with mTLS", func(t *testing.T) {
+		interceptor := callLocalAuthInterceptor(callLocalAuth{appID: "orders", trustDomain: "cluster.local", allowedCallers: []string{"frontend"}})
+		call := func(ctx context.Context) error {
+			_, err := interceptor(ctx, &internalv1pb.InternalInvokeRequest{TargetAppId: "orders"}, callLocal, handler)
+			return err
+		}
+
+		assert.NoError(t, call(sidecarContext(&url.URL{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/apps/frontend"})))
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(sidecarContext(&url.URL{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/apps/payments"}))))
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(sidecarContext(&url.URL{Scheme: "spiffe", Host: "other.domain", Path: "/ns/apps/frontend"}))))
+	})
+}
+
+func TestCallLocalAuthStreamInterceptor(t *testing.T) {
+	interceptor := callLocalAuthStreamInterceptor(callLocalAuth{appID: "orders"})
+	info := &grpc_go.StreamServerInfo{FullMethod: "/dapr.proto.daprinternal.v1.DaprInternal/CallLocalStream"}
+	recv := func(target string) error {
+		stream := &fakeServerStream{msg: &internalv1pb.InternalInvokeRequestStream{Request: &internalv1pb.InternalInvokeRequest{TargetAppId: target}}}
+		return interceptor(nil, stream, info, func(srv interface{}, stream grpc_go.ServerStream) error {
+			return stream.RecvMsg(&internalv1pb.InternalInvokeRequestStream{})
+		})
+	}
+
+	assert.NoError(t, recv("orders"))
+	assert.NoError(t, recv(""))
+	assert.Equal(t, codes.PermissionDenied, status.Code(recv("payments")))
+}
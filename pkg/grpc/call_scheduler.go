@@ -0,0 +1,217 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// errPeerQueueFull is returned for the calls of a peer whose queue is full
+var errPeerQueueFull = errors.New("too many queued calls of the peer")
+
+// callScheduler shares the concurrent calls to the app fairly between the peers of a server. Calls beyond the limits
+// wait in a queue per peer, and free slots go to the queued peers with the fewest active calls, so that a peer sending
+// many calls only delays its own.
+type callScheduler struct {
+	server         string
+	maxConcurrency int
+	maxPerPeer     int
+	maxQueued      int
+
+	lock   sync.Mutex
+	active int
+	queued int
+	peers  map[string]*peerCalls
+	// turns are the peers with queued calls, in the order of their turns
+	turns []string
+}
+
+// peerCalls are the active and queued calls of a peer. The channel of a queued call is closed when it gets a slot.
+type peerCalls struct {
+	active  int
+	waiting []chan struct{}
+}
+
+func newCallScheduler(server string, spec config.CallLocalSpec) *callScheduler {
+	return &callScheduler{
+		server:         server,
+		maxConcurrency: spec.MaxConcurrency,
+		maxPerPeer:     spec.MaxConcurrencyPerPeer,
+		maxQueued:      spec.MaxQueuedPerPeer,
+		peers:          map[string]*peerCalls{},
+	}
+}
+
+// acquire waits for the turn of a call of the peer. It returns errPeerQueueFull if the call is rejected, or the error
+// of the context if it's done first. Calls that acquired a slot must release it.
+func (s *callScheduler) acquire(ctx context.Context, peer string) error {
+	s.lock.Lock()
+	p := s.peers[peer]
+	if p == nil {
+		p = &peerCalls{}
+		s.peers[peer] = p
+	}
+	if s.maxQueued > 0 && len(p.waiting) >= s.maxQueued {
+		s.lock.Unlock()
+		diag.DefaultMonitoring.GRPCServerCallRejected(s.server)
+		return errPeerQueueFull
+	}
+
+	turn := make(chan struct{})
+	p.waiting = append(p.waiting, turn)
+	s.queued++
+	if len(p.waiting) == 1 {
+		s.turns = append(s.turns, peer)
+	}
+	s.dispatch()
+	s.lock.Unlock()
+
+	select {
+	case <-turn:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	select {
+	case <-turn:
+		// the call got its slot while it was cancelled
+		s.release(peer)
+	default:
+		s.dequeue(peer, turn)
+	}
+	return ctx.Err()
+}
+
+// release releases the slot of a call of the peer and gives it to the next call. The lock must be held.
+func (s *callScheduler) release(peer string) {
+	s.active--
+	if p := s.peers[peer]; p != nil {
+		p.active--
+		s.forget(peer, p)
+	}
+	s.dispatch()
+}
+
+// done releases the slot of a call of the peer
+func (s *callScheduler) done(peer string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.release(peer)
+}
+
+// dispatch gives the free slots to the queued calls, one at a time to the peer with the fewest active calls, the
+// peers taking turns on ties. Peers at their limit wait for their calls to complete. The lock must be held.
+func (s *callScheduler) dispatch() {
+	for s.maxConcurrency <= 0 || s.active < s.maxConcurrency {
+		next := -1
+		for i, peer := range s.turns {
+			p := s.peers[peer]
+			if s.maxPerPeer > 0 && p.active >= s.maxPerPeer {
+				continue
+			}
+			if next < 0 || p.active < s.peers[s.turns[next]].active {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		peer := s.turns[next]
+		p := s.peers[peer]
+		close(p.waiting[0])
+		p.waiting = p.waiting[1:]
+		p.active++
+		s.active++
+		s.queued--
+
+		// the peer goes to the end of the turns if it has more queued calls
+		s.turns = append(s.turns[:next], s.turns[next+1:]...)
+		if len(p.waiting) > 0 {
+			s.turns = append(s.turns, peer)
+		}
+	}
+	diag.DefaultMonitoring.GRPCServerCallsQueued(s.server, int64(s.queued))
+}
+
+// dequeue removes a queued call of the peer. The lock must be held.
+func (s *callScheduler) dequeue(peer string, turn chan struct{}) {
+	p := s.peers[peer]
+	for i, w := range p.waiting {
+		if w == turn {
+			p.waiting = append(p.waiting[:i], p.waiting[i+1:]...)
+			s.queued--
+			break
+		}
+	}
+	if len(p.waiting) == 0 {
+		for i, t := range s.turns {
+			if t == peer {
+				s.turns = append(s.turns[:i], s.turns[i+1:]...)
+				break
+			}
+		}
+	}
+	s.forget(peer, p)
+	diag.DefaultMonitoring.GRPCServerCallsQueued(s.server, int64(s.queued))
+}
+
+// forget removes a peer without calls. The lock must be held.
+func (s *callScheduler) forget(peer string, p *peerCalls) {
+	if p.active <= 0 && len(p.waiting) == 0 {
+		delete(s.peers, peer)
+	}
+}
+
+// callScheduleInterceptor schedules the CallLocal calls of the peers of the internal server
+func callScheduleInterceptor(scheduler *callScheduler) grpc_go.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc_go.UnaryServerInfo, handler grpc_go.UnaryHandler) (interface{}, error) {
+		if !strings.HasSuffix(info.FullMethod, "/CallLocal") {
+			return handler(ctx, req)
+		}
+		peer := callPeer(ctx)
+		if err := scheduleCall(ctx, scheduler, peer); err != nil {
+			return nil, err
+		}
+		defer scheduler.done(peer)
+		return handler(ctx, req)
+	}
+}
+
+// scheduleCall waits for the turn of a call of the peer, and returns the status of the call when it can't wait
+func scheduleCall(ctx context.Context, scheduler *callScheduler, peer string) error {
+	err := scheduler.acquire(ctx, peer)
+	if err == nil {
+		return nil
+	}
+	if err == errPeerQueueFull {
+		return status.Errorf(codes.ResourceExhausted, "too many concurrent calls from %s: %s", peer, err)
+	}
+	return status.FromContextError(err).Err()
+}
+
+// callPeer returns the peer of a call: the identity of the calling sidecar with mTLS, its host otherwise
+func callPeer(ctx context.Context) string {
+	if caller := callerIdentity(ctx); caller != nil {
+		return caller.Namespace + "/" + caller.ID
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return peerHost(p.Addr)
+	}
+	return ""
+}
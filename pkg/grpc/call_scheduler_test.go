@@ -0,0 +1,150 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// queueCall starts a call of the peer and returns the channel of its result once it got a slot
+func queueCall(t *testing.T, s *callScheduler, ctx context.Context, peer string) <-chan error {
+	acquired := make(chan error, 1)
+	s.lock.Lock()
+	queued := s.queued
+	s.lock.Unlock()
+	go func() {
+		acquired <- s.acquire(ctx, peer)
+	}()
+	// wait for the call to be queued or to get a slot
+	assert.Eventually(t, func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.queued != queued || len(acquired) > 0
+	}, time.Second, 10*time.Millisecond)
+	return acquired
+}
+
+func assertWaiting(t *testing.T, calls ...<-chan error) {
+	for _, c := range calls {
+		select {
+		case <-c:
+			assert.Fail(t, "call got a slot out of turn")
+		default:
+		}
+	}
+}
+
+func TestCallScheduler(t *testing.T) {
+	t.Run("a flooding peer doesn't delay the calls of other peers", func(t *testing.T) {
+		s := newCallScheduler(internalServer, config.CallLocalSpec{MaxConcurrency: 2})
+		assert.NoError(t, s.acquire(context.Background(), "hostile"))
+		assert.NoError(t, s.acquire(context.Background(), "hostile"))
+
+		flood := []<-chan error{}
+		for i := 0; i < 20; i++ {
+			flood = append(flood, queueCall(t, s, context.Background(), "hostile"))
+		}
+		other := queueCall(t, s, context.Background(), "app")
+		assertWaiting(t, append(flood, other)...)
+
+		// the first free slot goes to the other peer, though the flood was queued before
+		s.done("hostile")
+		assert.NoError(t, <-other)
+		assertWaiting(t, flood...)
+
+		// then to the flood, in order
+		s.done("app")
+		assert.NoError(t, <-flood[0])
+		assertWaiting(t, flood[1:]...)
+	})
+
+	t.Run("peers at their limit don't take the free slots", func(t *testing.T) {
+		s := newCallScheduler(internalServer, config.CallLocalSpec{MaxConcurrency: 4, MaxConcurrencyPerPeer: 1})
+		assert.NoError(t, s.acquire(context.Background(), "hostile"))
+		flood := queueCall(t, s, context.Background(), "hostile")
+		assertWaiting(t, flood)
+
+		assert.NoError(t, <-queueCall(t, s, context.Background(), "app"))
+
+		s.done("hostile")
+		assert.NoError(t, <-flood)
+	})
+
+	t.Run("calls beyond the queue limit of the peer are rejected", func(t *testing.T) {
+		s := newCallScheduler(internalServer, config.CallLocalSpec{MaxConcurrency: 1, MaxQueuedPerPeer: 1})
+		assert.NoError(t, s.acquire(context.Background(), "hostile"))
+		queued := queueCall(t, s, context.Background(), "hostile")
+		assert.Equal(t, errPeerQueueFull, s.acquire(context.Background(), "hostile"))
+
+		// other peers have their own queue
+		other := queueCall(t, s, context.Background(), "app")
+		assertWaiting(t, queued, other)
+	})
+
+	t.Run("cancelled calls leave the queue", func(t *testing.T) {
+		s := newCallScheduler(internalServer, config.CallLocalSpec{MaxConcurrency: 1})
+		assert.NoError(t, s.acquire(context.Background(), "app"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelled := queueCall(t, s, ctx, "hostile")
+		cancel()
+		assert.Equal(t, context.Canceled, <-cancelled)
+
+		s.done("app")
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		assert.Zero(t, s.active)
+		assert.Zero(t, s.queued)
+		assert.Empty(t, s.turns)
+		assert.Empty(t, s.peers)
+	})
+}
+
+func TestCallScheduleInterceptor(t *testing.T) {
+	s := newCallScheduler(internalServer, config.CallLocalSpec{MaxConcurrency: 1, MaxQueuedPerPeer: 1})
+	interceptor := callScheduleInterceptor(s)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	callLocal := &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.internals.v1.ServiceInvocation/CallLocal"}
+
+	block := make(chan struct{})
+	started := make(chan struct{}, 2)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		started <- struct{}{}
+		<-block
+		return nil, nil
+	}
+	for i := 0; i < 2; i++ {
+		go interceptor(ctx, nil, callLocal, handler)
+	}
+	<-started
+	assert.Eventually(t, func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.queued == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// the peer is identified by its host, whatever its port
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5001}})
+	_, err := interceptor(ctx, nil, callLocal, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// other methods aren't scheduled
+	_, err = interceptor(ctx, nil, &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.internals.v1.ServiceInvocation/CallActor"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	assert.NoError(t, err)
+
+	close(block)
+	<-started
+}
@@ -0,0 +1,175 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// CompressionNone disables compression of internal invocation calls
+	CompressionNone = "none"
+	// CompressionGzip compresses internal invocation calls with gzip
+	CompressionGzip = gzip.Name
+	// CompressionZstd compresses internal invocation calls with zstd
+	CompressionZstd = "zstd"
+)
+
+// returned by grpc servers that do not have the requested compressor registered
+const unsupportedCompressorMessage = "Decompressor is not installed"
+
+func init() {
+	encoding.RegisterCompressor(&measuredCompressor{Compressor: encoding.GetCompressor(gzip.Name)})
+	encoding.RegisterCompressor(&measuredCompressor{Compressor: newZstdCompressor()})
+}
+
+// ValidateCompression returns an error if the given compressor is unknown
+func ValidateCompression(name string) error {
+	switch name {
+	case "", CompressionNone, CompressionGzip, CompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("unknown compression %s, must be one of %s, %s or %s", name, CompressionNone, CompressionGzip, CompressionZstd)
+	}
+}
+
+// compressionInterceptor compresses calls to target with the configured compressor.
+// Targets that reject the compressor, e.g. sidecars of an older version, are remembered and called uncompressed from then on.
+func (g *Manager) compressionInterceptor(target string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name := g.compression
+		if name == "" || name == CompressionNone {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if _, unsupported := g.uncompressedTargets.Load(target); unsupported {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.UseCompressor(name))...)
+		if isUnsupportedCompressor(err) {
+			log.Infof("%s does not support %s compression, falling back to uncompressed calls", target, name)
+			g.uncompressedTargets.Store(target, true)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return err
+	}
+}
+
+func isUnsupportedCompressor(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.Unimplemented && strings.Contains(s.Message(), unsupportedCompressorMessage)
+}
+
+// measuredCompressor records the compression ratio of compressed messages
+type measuredCompressor struct {
+	encoding.Compressor
+}
+
+func (m *measuredCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	out := &countingWriter{w: w}
+	wc, err := m.Compressor.Compress(out)
+	if err != nil {
+		return nil, err
+	}
+	return &measuredWriter{name: m.Name(), wc: wc, out: out}, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type measuredWriter struct {
+	name string
+	wc   io.WriteCloser
+	out  *countingWriter
+	n    int64
+}
+
+func (m *measuredWriter) Write(p []byte) (int, error) {
+	n, err := m.wc.Write(p)
+	m.n += int64(n)
+	return n, err
+}
+
+func (m *measuredWriter) Close() error {
+	err := m.wc.Close()
+	if err == nil {
+		diag.DefaultMonitoring.MessageCompressed(m.name, m.n, m.out.n)
+	}
+	return err
+}
+
+// zstdCompressor implements the grpc compressor interface with shared zstd encoders and decoders
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCompressor() *zstdCompressor {
+	// creating an encoder and decoder without a stream only fails on invalid options
+	encoder, _ := zstd.NewWriter(nil)
+	decoder, _ := zstd.NewReader(nil)
+	return &zstdCompressor{
+		encoder: encoder,
+		decoder: decoder,
+	}
+}
+
+func (z *zstdCompressor) Name() string {
+	return CompressionZstd
+}
+
+func (z *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &zstdWriter{encoder: z.encoder, w: w}, nil
+}
+
+func (z *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	compressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	b, err := z.decoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
+
+// zstdWriter buffers a message and compresses it as a single frame on close
+type zstdWriter struct {
+	encoder *zstd.Encoder
+	w       io.Writer
+	buf     bytes.Buffer
+}
+
+func (z *zstdWriter) Write(p []byte) (int, error) {
+	return z.buf.Write(p)
+}
+
+func (z *zstdWriter) Close() error {
+	_, err := z.w.Write(z.encoder.EncodeAll(z.buf.Bytes(), nil))
+	return err
+}
@@ -0,0 +1,102 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateCompression(t *testing.T) {
+	for _, name := range []string{"", CompressionNone, CompressionGzip, CompressionZstd} {
+		assert.NoError(t, ValidateCompression(name))
+	}
+	assert.Error(t, ValidateCompression("brotli"))
+}
+
+func TestCompressorsRoundTrip(t *testing.T) {
+	payload := []byte(strings.Repeat("dapr compression ", 100))
+
+	for _, name := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(name, func(t *testing.T) {
+			c := encoding.GetCompressor(name)
+			assert.NotNil(t, c)
+
+			var buf bytes.Buffer
+			w, err := c.Compress(&buf)
+			assert.NoError(t, err)
+			_, err = w.Write(payload)
+			assert.NoError(t, err)
+			assert.NoError(t, w.Close())
+			assert.True(t, buf.Len() < len(payload))
+
+			r, err := c.Decompress(&buf)
+			assert.NoError(t, err)
+			b, err := ioutil.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, payload, b)
+		})
+	}
+}
+
+func TestCompressionInterceptor(t *testing.T) {
+	t.Run("compression disabled", func(t *testing.T) {
+		m := NewGRPCManager(modes.StandaloneMode)
+		calls := []int{}
+		err := m.compressionInterceptor("target")(context.Background(), "method", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls = append(calls, len(opts))
+				return nil
+			})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0}, calls)
+	})
+
+	t.Run("falls back for targets without the compressor", func(t *testing.T) {
+		m := NewGRPCManager(modes.StandaloneMode)
+		assert.NoError(t, m.SetCompression(CompressionZstd))
+
+		calls := []int{}
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls = append(calls, len(opts))
+			if len(opts) > 0 {
+				return status.Errorf(codes.Unimplemented, "grpc: Decompressor is not installed for grpc-encoding \"zstd\"")
+			}
+			return nil
+		}
+
+		err := m.compressionInterceptor("target")(context.Background(), "method", nil, nil, nil, invoker)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 0}, calls)
+
+		// the target is remembered and called uncompressed
+		calls = []int{}
+		err = m.compressionInterceptor("target")(context.Background(), "method", nil, nil, nil, invoker)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0}, calls)
+	})
+
+	t.Run("other errors are returned", func(t *testing.T) {
+		m := NewGRPCManager(modes.StandaloneMode)
+		assert.NoError(t, m.SetCompression(CompressionGzip))
+
+		err := m.compressionInterceptor("target")(context.Background(), "method", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return status.Error(codes.Unavailable, "unavailable")
+			})
+		assert.Error(t, err)
+	})
+}
@@ -5,11 +5,35 @@
 
 package grpc
 
+import (
+	"github.com/dapr/dapr/pkg/bulkhead"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/memorybudget"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
 // ServerConfig is the config object for a grpc server
 type ServerConfig struct {
 	AppID       string
 	HostAddress string
 	Port        int
+	Limits      config.GRPCServerLimits
+	// ListenAddresses are the addresses to bind to. The server listens on all interfaces when empty.
+	ListenAddresses []string
+	// Pipe is the Windows named pipe the server also listens on, e.g. \\.\pipe\dapr-grpc
+	Pipe string
+	// EnableChannelz registers the channelz service on the internal server, to diagnose its connections
+	EnableChannelz bool
+	// Bulkheads isolate the calls of the building blocks, shared with the HTTP server
+	Bulkheads bulkhead.Bulkheads
+	// CallLocal shares the calls to the app fairly between the peers of the internal server
+	CallLocal config.CallLocalSpec
+	// MemoryBudget rejects the calls with a large request under memory pressure, shared with the HTTP server
+	MemoryBudget *memorybudget.Budget
+	// AnyLimits bound the Any payloads of the invocations received by the internal server
+	AnyLimits invokev1.AnyLimits
+	// Proxy forwards the calls to the services unknown to the server, e.g. the app's own services, when set
+	Proxy ProxyDirector
 }
 
 // NewServerConfig returns a new grpc server config
@@ -6,25 +6,40 @@
 package grpc
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"sync"
+	"time"
 
 	"github.com/dapr/dapr/pkg/channel"
 	grpc_channel "github.com/dapr/dapr/pkg/channel/grpc"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
+	"github.com/dapr/dapr/pkg/logger"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	"github.com/dapr/dapr/pkg/modes"
+	"github.com/dapr/dapr/pkg/namedpipe"
 	"github.com/dapr/dapr/pkg/runtime/security"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	// needed to load balance requests for target services with multiple endpoints, ie. multiple instances
 	grpcServiceConfig = `{"loadBalancingPolicy":"round_robin"}`
+
+	defaultDialTimeout       = 5 * time.Second
+	defaultHandshakeTimeout  = 5 * time.Second
+	defaultBlacklistDuration = 10 * time.Second
 )
 
+var log = logger.NewLogger("dapr.runtime.grpc")
+
 // Manager is a wrapper around gRPC connection pooling
 type Manager struct {
 	AppClient      *grpc.ClientConn
@@ -32,14 +47,28 @@ type Manager struct {
 	connectionPool map[string]*grpc.ClientConn
 	auth           security.Authenticator
 	mode           modes.DaprMode
+	// compression is the compressor used for calls to other Dapr sidecars
+	compression         string
+	uncompressedTargets *sync.Map
+	// timeouts of the connections to other Dapr sidecars
+	dialTimeout       time.Duration
+	handshakeTimeout  time.Duration
+	blacklistDuration time.Duration
+	// blacklist holds the time until which connecting to a sidecar fails fast, by address
+	blacklist map[string]time.Time
 }
 
 // NewGRPCManager returns a new grpc manager
 func NewGRPCManager(mode modes.DaprMode) *Manager {
 	return &Manager{
-		lock:           &sync.Mutex{},
-		connectionPool: map[string]*grpc.ClientConn{},
-		mode:           mode,
+		lock:                &sync.Mutex{},
+		connectionPool:      map[string]*grpc.ClientConn{},
+		mode:                mode,
+		uncompressedTargets: &sync.Map{},
+		dialTimeout:         defaultDialTimeout,
+		handshakeTimeout:    defaultHandshakeTimeout,
+		blacklistDuration:   defaultBlacklistDuration,
+		blacklist:           map[string]time.Time{},
 	}
 }
 
@@ -48,35 +77,112 @@ func (g *Manager) SetAuthenticator(auth security.Authenticator) {
 	g.auth = auth
 }
 
+// SetCompression sets the compressor used for calls to other Dapr sidecars
+func (g *Manager) SetCompression(name string) error {
+	if err := ValidateCompression(name); err != nil {
+		return err
+	}
+	g.compression = name
+	return nil
+}
+
+// SetClientTimeouts sets the connection timeouts of the calls to other Dapr sidecars. Empty durations keep the defaults.
+func (g *Manager) SetClientTimeouts(spec config.GRPCClientSpec) error {
+	for _, t := range []struct {
+		name  string
+		value string
+		field *time.Duration
+	}{
+		{"dial timeout", spec.DialTimeout, &g.dialTimeout},
+		{"handshake timeout", spec.HandshakeTimeout, &g.handshakeTimeout},
+		{"blacklist duration", spec.BlacklistDuration, &g.blacklistDuration},
+	} {
+		if t.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(t.value)
+		if err != nil {
+			return fmt.Errorf("invalid gRPC client %s: %s", t.name, err)
+		}
+		*t.field = d
+	}
+	return nil
+}
+
 // CreateLocalChannel creates a new gRPC AppChannel
-func (g *Manager) CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error) {
-	conn, err := g.GetGRPCConnection(fmt.Sprintf("127.0.0.1:%v", port), "", true, false)
+func (g *Manager) CreateLocalChannel(port, maxConcurrency int, timeouts channel.Timeouts, spec config.TracingSpec) (channel.AppChannel, error) {
+	address := fmt.Sprintf("127.0.0.1:%v", port)
+	conn, err := g.getGRPCConnection(address, address, "", true, false, false)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing connection to app grpc on port %v: %s", port, err)
 	}
 
 	g.AppClient = conn
-	ch := grpc_channel.CreateLocalChannel(port, maxConcurrency, conn, spec)
+	ch := grpc_channel.CreateLocalChannel(port, maxConcurrency, conn, timeouts, spec)
+	return ch, nil
+}
+
+// CreatePipeChannel creates a new gRPC AppChannel to an app listening on a Windows named pipe
+func (g *Manager) CreatePipeChannel(pipe string, maxConcurrency int, timeouts channel.Timeouts, spec config.TracingSpec) (channel.AppChannel, error) {
+	conn, err := grpc.DialContext(context.Background(), "passthrough:///"+channel.DefaultChannelAddress,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryClientInterceptor()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return namedpipe.Dial(ctx, pipe)
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("error establishing connection to app grpc on pipe %s: %s", pipe, err)
+	}
+
+	g.AppClient = conn
+	ch := grpc_channel.CreatePipeChannel(pipe, maxConcurrency, conn, timeouts, spec)
 	return ch, nil
 }
 
 // GetGRPCConnection returns a new grpc connection for a given address and inits one if doesn't exist
 func (g *Manager) GetGRPCConnection(address, id string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error) {
-	if val, ok := g.connectionPool[address]; ok && !recreateIfExists {
+	return g.getGRPCConnection(address, address, id, skipTLS, recreateIfExists, true)
+}
+
+// GetGRPCConnectionWithPriority returns the grpc connection of a priority class for a given address and inits one if doesn't exist.
+// The high and low priority classes have their own connections, so that their calls aren't queued behind the streams of other classes.
+func (g *Manager) GetGRPCConnectionWithPriority(address, id, priority string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error) {
+	key := address
+	if priority != "" && priority != invokev1.PriorityNormal {
+		key = address + "#" + priority
+	}
+	return g.getGRPCConnection(key, address, id, skipTLS, recreateIfExists, true)
+}
+
+// getGRPCConnection returns the connection of the key to an address. The connections to other sidecars are remote: they
+// are compressed, time out, and fail fast while their address is blacklisted after a failed connection.
+func (g *Manager) getGRPCConnection(key, address, id string, skipTLS, recreateIfExists, remote bool) (*grpc.ClientConn, error) {
+	if val, ok := g.connectionPool[key]; ok && !recreateIfExists {
 		return val, nil
 	}
 
 	g.lock.Lock()
-	if val, ok := g.connectionPool[address]; ok && !recreateIfExists {
+	if val, ok := g.connectionPool[key]; ok && !recreateIfExists {
 		g.lock.Unlock()
 		return val, nil
 	}
 
+	if remote {
+		if until, ok := g.blacklist[address]; ok && time.Now().Before(until) {
+			g.lock.Unlock()
+			return nil, status.Errorf(codes.Unavailable, "connecting to %s failed recently, retrying after %s", address, until.Format(time.RFC3339))
+		}
+	}
+
 	opts := []grpc.DialOption{
-		grpc.WithBlock(),
-		grpc.WithUnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryClientInterceptor()),
 		grpc.WithDefaultServiceConfig(grpcServiceConfig),
 	}
+	if remote {
+		// the app may start after the sidecar, so only the connections to other sidecars wait to be established
+		opts = append(opts, grpc.WithBlock(), grpc.WithChainUnaryInterceptor(g.compressionInterceptor(address), diag.DefaultGRPCMonitoring.UnaryClientInterceptor()))
+	} else {
+		opts = append(opts, grpc.WithUnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryClientInterceptor()))
+	}
 
 	if !skipTLS && g.auth != nil {
 		signedCert := g.auth.GetCurrentSignedCert()
@@ -85,25 +191,59 @@ func (g *Manager) GetGRPCConnection(address, id string, skipTLS, recreateIfExist
 			return nil, fmt.Errorf("error generating x509 Key Pair: %s", err)
 		}
 
-		ta := credentials.NewTLS(&tls.Config{
+		ta := credentials.NewTLS(fips.ConfigureTLS(&tls.Config{
 			ServerName:   id,
 			Certificates: []tls.Certificate{cert},
 			RootCAs:      signedCert.TrustChain,
-		})
+		}))
+		if remote && g.handshakeTimeout > 0 {
+			ta = &handshakeTimeoutCredentials{TransportCredentials: ta, timeout: g.handshakeTimeout}
+		}
 		opts = append(opts, grpc.WithTransportCredentials(ta))
 	} else {
 		opts = append(opts, grpc.WithInsecure())
 	}
 
+	ctx := context.Background()
+	if remote && g.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.dialTimeout)
+		defer cancel()
+	}
+
 	dialPrefix := GetDialAddressPrefix(g.mode)
-	conn, err := grpc.Dial(dialPrefix+address, opts...)
+	conn, err := grpc.DialContext(ctx, dialPrefix+address, opts...)
 	if err != nil {
+		if remote && g.blacklistDuration > 0 {
+			g.blacklist[address] = time.Now().Add(g.blacklistDuration)
+			log.Warnf("connecting to %s failed, failing fast for %s: %s", address, g.blacklistDuration, err)
+		}
 		g.lock.Unlock()
+		if err == context.DeadlineExceeded {
+			return nil, status.Errorf(codes.Unavailable, "connecting to %s timed out after %s", address, g.dialTimeout)
+		}
 		return nil, err
 	}
 
-	g.connectionPool[address] = conn
+	delete(g.blacklist, address)
+	g.connectionPool[key] = conn
 	g.lock.Unlock()
 
 	return conn, nil
 }
+
+// handshakeTimeoutCredentials bounds the client TLS handshake, which otherwise waits for the dial timeout
+type handshakeTimeoutCredentials struct {
+	credentials.TransportCredentials
+	timeout time.Duration
+}
+
+func (c *handshakeTimeoutCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.TransportCredentials.ClientHandshake(ctx, authority, conn)
+}
+
+func (c *handshakeTimeoutCredentials) Clone() credentials.TransportCredentials {
+	return &handshakeTimeoutCredentials{TransportCredentials: c.TransportCredentials.Clone(), timeout: c.timeout}
+}
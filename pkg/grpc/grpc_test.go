@@ -0,0 +1,69 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// blockingCredentials are credentials whose client handshake never completes
+type blockingCredentials struct {
+	credentials.TransportCredentials
+}
+
+func (blockingCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	<-ctx.Done()
+	return nil, nil, ctx.Err()
+}
+
+func TestSetClientTimeouts(t *testing.T) {
+	m := NewGRPCManager(modes.StandaloneMode)
+	assert.NoError(t, m.SetClientTimeouts(config.GRPCClientSpec{DialTimeout: "1s", BlacklistDuration: "0s"}))
+	assert.Equal(t, time.Second, m.dialTimeout)
+	assert.Equal(t, defaultHandshakeTimeout, m.handshakeTimeout)
+	assert.Zero(t, m.blacklistDuration)
+
+	assert.Error(t, m.SetClientTimeouts(config.GRPCClientSpec{HandshakeTimeout: "soon"}))
+}
+
+func TestGetGRPCConnectionTimeout(t *testing.T) {
+	// an address nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	m := NewGRPCManager(modes.StandaloneMode)
+	assert.NoError(t, m.SetClientTimeouts(config.GRPCClientSpec{DialTimeout: "50ms", BlacklistDuration: "1m"}))
+
+	start := time.Now()
+	_, err = m.GetGRPCConnection(address, "app", true, false)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	t.Run("blacklisted address fails fast", func(t *testing.T) {
+		start := time.Now()
+		_, err := m.GetGRPCConnectionWithPriority(address, "app", "high", true, true)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.True(t, time.Since(start) < 50*time.Millisecond)
+	})
+}
+
+func TestHandshakeTimeoutCredentials(t *testing.T) {
+	c := &handshakeTimeoutCredentials{TransportCredentials: blockingCredentials{}, timeout: 10 * time.Millisecond}
+	_, _, err := c.ClientHandshake(context.Background(), "app", nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,122 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"net"
+	"sync"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+// Reasons for rejecting a connection
+const (
+	maxConnectionsReached        = "max_connections"
+	maxConnectionsPerPeerReached = "max_connections_per_peer"
+)
+
+// connectionLimiter tracks the connections of a server against its total and per peer connection limits
+type connectionLimiter struct {
+	server                string
+	maxConnections        int
+	maxConnectionsPerPeer int
+
+	lock   sync.Mutex
+	active int
+	peers  map[string]int
+}
+
+func newConnectionLimiter(server string, maxConnections, maxConnectionsPerPeer int) *connectionLimiter {
+	return &connectionLimiter{
+		server:                server,
+		maxConnections:        maxConnections,
+		maxConnectionsPerPeer: maxConnectionsPerPeer,
+		peers:                 map[string]int{},
+	}
+}
+
+// acquire reserves a connection slot for the peer and returns the reason if the connection exceeds a limit
+func (c *connectionLimiter) acquire(peer string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.maxConnections > 0 && c.active >= c.maxConnections {
+		return maxConnectionsReached
+	}
+	if c.maxConnectionsPerPeer > 0 && c.peers[peer] >= c.maxConnectionsPerPeer {
+		return maxConnectionsPerPeerReached
+	}
+	c.active++
+	c.peers[peer]++
+	diag.DefaultMonitoring.GRPCServerConnections(c.server, int64(c.active))
+	return ""
+}
+
+func (c *connectionLimiter) release(peer string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.active--
+	if c.peers[peer]--; c.peers[peer] <= 0 {
+		delete(c.peers, peer)
+	}
+	diag.DefaultMonitoring.GRPCServerConnections(c.server, int64(c.active))
+}
+
+// limitListener closes accepted connections that exceed the limits of its connection limiter.
+// A limiter can be shared by the listeners of a server bound to multiple addresses.
+type limitListener struct {
+	net.Listener
+	limiter *connectionLimiter
+}
+
+func newLimitListener(l net.Listener, limiter *connectionLimiter) net.Listener {
+	return &limitListener{
+		Listener: l,
+		limiter:  limiter,
+	}
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		peer := peerHost(conn.RemoteAddr())
+		if reason := l.limiter.acquire(peer); reason != "" {
+			diag.DefaultMonitoring.GRPCServerConnectionRejected(l.limiter.server, reason)
+			conn.Close()
+			continue
+		}
+		return &limitConn{Conn: conn, release: func() { l.limiter.release(peer) }}, nil
+	}
+}
+
+func peerHost(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+// limitConn releases its connection slot once closed
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
@@ -0,0 +1,75 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func acceptAsync(l net.Listener) chan net.Conn {
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+	return accepted
+}
+
+func TestLimitListener(t *testing.T) {
+	t.Run("connections over the per peer limit are closed", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		l := newLimitListener(lis, newConnectionLimiter(internalServer, 0, 1))
+		defer l.Close()
+
+		accepted := acceptAsync(l)
+		first, err := net.Dial("tcp", lis.Addr().String())
+		assert.NoError(t, err)
+		defer first.Close()
+		serverConn := <-accepted
+
+		accepted = acceptAsync(l)
+		second, err := net.Dial("tcp", lis.Addr().String())
+		assert.NoError(t, err)
+		defer second.Close()
+
+		// the second connection is closed by the listener
+		second.SetReadDeadline(time.Now().Add(time.Second))
+		_, err = second.Read(make([]byte, 1))
+		assert.Error(t, err)
+
+		// closing the first connection frees the slot for the peer
+		serverConn.Close()
+		third, err := net.Dial("tcp", lis.Addr().String())
+		assert.NoError(t, err)
+		defer third.Close()
+
+		select {
+		case conn := <-accepted:
+			conn.Close()
+		case <-time.After(time.Second):
+			assert.Fail(t, "connection was not accepted after a slot was released")
+		}
+	})
+
+	t.Run("slots are tracked per peer and in total", func(t *testing.T) {
+		l := newConnectionLimiter(apiServer, 2, 1)
+
+		assert.Equal(t, "", l.acquire("10.0.0.1"))
+		assert.Equal(t, maxConnectionsPerPeerReached, l.acquire("10.0.0.1"))
+		assert.Equal(t, "", l.acquire("fd00::2"))
+		assert.Equal(t, maxConnectionsReached, l.acquire("10.0.0.3"))
+
+		l.release("10.0.0.1")
+		assert.Equal(t, "", l.acquire("10.0.0.3"))
+	})
+}
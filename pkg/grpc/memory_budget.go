@@ -0,0 +1,30 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/memorybudget"
+	"github.com/golang/protobuf/proto"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryBudgetInterceptor rejects the calls with a large request while the sidecar is under memory pressure
+func memoryBudgetInterceptor(kind string, b *memorybudget.Budget) grpc_go.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc_go.UnaryServerInfo, handler grpc_go.UnaryHandler) (interface{}, error) {
+		if m, ok := req.(proto.Message); ok {
+			if size := proto.Size(m); !b.AllowPayload(size) {
+				diag.DefaultMonitoring.MemoryBudgetRejected(kind)
+				return nil, status.Errorf(codes.ResourceExhausted, "request of %d bytes rejected while the sidecar is under memory pressure", size)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
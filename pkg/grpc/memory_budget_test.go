@@ -0,0 +1,41 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/memorybudget"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMemoryBudgetInterceptor(t *testing.T) {
+	// the sidecar uses more than its budget of 1Ki
+	b, err := memorybudget.New(config.MemoryBudgetSpec{Limit: "1Ki", MaxPayloadSize: "16"})
+	require.NoError(t, err)
+	interceptor := memoryBudgetInterceptor(apiServer, b)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	call := func(req interface{}) error {
+		_, err := interceptor(context.Background(), req, &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.dapr.v1.Dapr/PublishEvent"}, handler)
+		return err
+	}
+	large := &daprv1pb.PublishEventEnvelope{Topic: "a topic longer than the max payload size"}
+
+	assert.NoError(t, call(large))
+
+	b.Check()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(large)))
+	assert.NoError(t, call(&daprv1pb.PublishEventEnvelope{Topic: "orders"}))
+}
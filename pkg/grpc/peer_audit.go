@@ -0,0 +1,128 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/sentry/identity"
+	"google.golang.org/grpc/credentials"
+)
+
+// Reasons of failed peer handshakes
+const (
+	handshakeNoCertificate      = "no_certificate"
+	handshakeUnknownAuthority   = "unknown_authority"
+	handshakeCertificateExpiry  = "certificate_expired"
+	handshakeInvalidCertificate = "invalid_certificate"
+	handshakeTimeout            = "timeout"
+	handshakeConnectionClosed   = "connection_closed"
+	handshakeProtocolError      = "protocol_error"
+)
+
+// auditCredentials are server TLS credentials recording the failed handshakes of the peers, with their address,
+// SNI and identity when known, so that certificate rollout problems aren't only seen as Unavailable by the callers
+type auditCredentials struct {
+	credentials.TransportCredentials
+	config      *tls.Config
+	server      string
+	trustDomain string
+	logger      logger.Logger
+}
+
+func newAuditCredentials(config *tls.Config, server, trustDomain string, serverLogger logger.Logger) credentials.TransportCredentials {
+	return &auditCredentials{
+		TransportCredentials: credentials.NewTLS(config),
+		config:               config,
+		server:               server,
+		trustDomain:          trustDomain,
+		logger:               serverLogger,
+	}
+}
+
+func (c *auditCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	var sni string
+	config := c.config.Clone()
+	getCertificate := config.GetCertificate
+	config.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		sni = hello.ServerName
+		return getCertificate(hello)
+	}
+
+	secureConn, authInfo, err := credentials.NewTLS(config).ServerHandshake(conn)
+	if err != nil {
+		reason, peerCert := handshakeFailure(err)
+		diag.DefaultMonitoring.MTLSHandshakeFailed(c.server, reason)
+
+		fields := map[string]interface{}{
+			"peer":        conn.RemoteAddr().String(),
+			"sni":         sni,
+			"reason":      reason,
+			"trustDomain": c.trustDomain,
+		}
+		if peerCert != nil {
+			if id, idErr := identity.FromCertificate(peerCert); idErr == nil {
+				fields["peerIdentity"] = id.SPIFFEID().String()
+			} else {
+				fields["peerSubject"] = peerCert.Subject.String()
+			}
+		}
+		c.logger.WithLogType(logger.LogTypeAudit).WithFields(fields).Warnf("mTLS handshake with peer failed: %s", err)
+	}
+	return secureConn, authInfo, err
+}
+
+func (c *auditCredentials) Clone() credentials.TransportCredentials {
+	return newAuditCredentials(c.config.Clone(), c.server, c.trustDomain, c.logger)
+}
+
+// handshakeFailure returns the reason of a failed handshake, and the certificate of the peer when it was rejected
+func handshakeFailure(err error) (string, *x509.Certificate) {
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &unknownAuthority):
+		return handshakeUnknownAuthority, unknownAuthority.Cert
+	case errors.As(err, &invalid):
+		if invalid.Reason == x509.Expired {
+			return handshakeCertificateExpiry, invalid.Cert
+		}
+		return handshakeInvalidCertificate, invalid.Cert
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return handshakeTimeout, nil
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return handshakeConnectionClosed, nil
+	case strings.Contains(err.Error(), "didn't provide a certificate"):
+		return handshakeNoCertificate, nil
+	}
+	return handshakeProtocolError, nil
+}
+
+// certificateTrustDomain returns the trust domain of the SPIFFE ID of a PEM workload certificate, empty when unknown
+func certificateTrustDomain(certPem []byte) string {
+	block, _ := pem.Decode(certPem)
+	if block == nil {
+		return ""
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ""
+	}
+	id, err := identity.FromCertificate(cert)
+	if err != nil {
+		return ""
+	}
+	return id.TrustDomain
+}
@@ -0,0 +1,123 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testCertificate returns a self-signed PEM certificate and its TLS certificate, with a SPIFFE ID
+func testCertificate(t *testing.T, spiffeID string) ([]byte, tls.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	uri, err := url.Parse(spiffeID)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "app"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{uri},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	cert, err := tls.X509KeyPair(certPem, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+	assert.NoError(t, err)
+	return certPem, cert
+}
+
+func TestAuditCredentials(t *testing.T) {
+	serverPem, serverCert := testCertificate(t, "spiffe://cluster.local/ns/default/server")
+	_, otherCert := testCertificate(t, "spiffe://other.domain/ns/default/client")
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(serverPem)
+
+	ta := newAuditCredentials(&tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &serverCert, nil
+		},
+	}, internalServer, certificateTrustDomain(serverPem), internalServerLogger)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	handshake := func(clientCerts []tls.Certificate) error {
+		go func() {
+			client, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{ServerName: "server", Certificates: clientCerts, InsecureSkipVerify: true})
+			if err == nil {
+				// read the alert of the server, if any
+				client.Read(make([]byte, 1))
+				client.Close()
+			}
+		}()
+		serverConn, err := listener.Accept()
+		assert.NoError(t, err)
+		defer serverConn.Close()
+		_, _, err = ta.ServerHandshake(serverConn)
+		return err
+	}
+
+	t.Run("peer without a certificate", func(t *testing.T) {
+		err := handshake(nil)
+		assert.Error(t, err)
+		reason, _ := handshakeFailure(err)
+		assert.Equal(t, handshakeNoCertificate, reason)
+	})
+
+	t.Run("peer of an unknown authority", func(t *testing.T) {
+		err := handshake([]tls.Certificate{otherCert})
+		assert.Error(t, err)
+		reason, peerCert := handshakeFailure(err)
+		assert.Equal(t, handshakeUnknownAuthority, reason)
+		assert.Equal(t, "other.domain", peerCert.URIs[0].Host)
+	})
+
+	t.Run("trusted peer", func(t *testing.T) {
+		assert.NoError(t, handshake([]tls.Certificate{serverCert}))
+	})
+}
+
+func TestHandshakeFailure(t *testing.T) {
+	reason, _ := handshakeFailure(io.EOF)
+	assert.Equal(t, handshakeConnectionClosed, reason)
+	reason, _ = handshakeFailure(x509.CertificateInvalidError{Reason: x509.Expired})
+	assert.Equal(t, handshakeCertificateExpiry, reason)
+	reason, _ = handshakeFailure(errors.New("tls: first record does not look like a TLS handshake"))
+	assert.Equal(t, handshakeProtocolError, reason)
+}
+
+func TestCertificateTrustDomain(t *testing.T) {
+	certPem, _ := testCertificate(t, "spiffe://cluster.local/ns/default/app")
+	assert.Equal(t, "cluster.local", certificateTrustDomain(certPem))
+	assert.Empty(t, certificateTrustDomain([]byte("not a certificate")))
+}
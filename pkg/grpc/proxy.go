@@ -0,0 +1,237 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"io"
+
+	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/messaging"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ProxyAppIDHeader is the metadata of the calls of the app to its own gRPC services naming the app to proxy them to
+const ProxyAppIDHeader = "dapr-app-id"
+
+// ProxyDirector returns the connection to forward a call to an unknown service to
+type ProxyDirector func(ctx context.Context, fullMethod string) (*grpc_go.ClientConn, error)
+
+// NewAPIProxyDirector returns the director of the API server, forwarding the calls to the app named by their
+// dapr-app-id metadata: to the local app through its gRPC connection, nil when it doesn't use gRPC, and to the remote
+// apps through their sidecar.
+func NewAPIProxyDirector(appID string, appConn *grpc_go.ClientConn, directMessaging messaging.DirectMessaging) ProxyDirector {
+	local := NewInternalProxyDirector(appConn)
+	return func(ctx context.Context, fullMethod string) (*grpc_go.ClientConn, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ids := md.Get(ProxyAppIDHeader)
+		if len(ids) == 0 {
+			return nil, status.Errorf(codes.Unimplemented, "unknown method %s: set the %s metadata to proxy it", fullMethod, ProxyAppIDHeader)
+		}
+		if ids[0] == appID {
+			return local(ctx, fullMethod)
+		}
+		return directMessaging.RemoteConnection(ids[0])
+	}
+}
+
+// NewInternalProxyDirector returns the director of the internal server, forwarding the calls proxied by the other
+// sidecars to the local app through its gRPC connection, nil when it doesn't use gRPC
+func NewInternalProxyDirector(appConn *grpc_go.ClientConn) ProxyDirector {
+	return func(ctx context.Context, fullMethod string) (*grpc_go.ClientConn, error) {
+		if appConn == nil {
+			return nil, status.Errorf(codes.Unimplemented, "unknown method %s: the app doesn't use gRPC", fullMethod)
+		}
+		return appConn, nil
+	}
+}
+
+// internalProxyHandler authorizes the calls proxied by the other sidecars like their invocations: the callers must be
+// sidecars of the trust domain, the calls must name the local app, and they wait for their turn with the invocations.
+// The caller identity metadata is replaced with the verified identity of the calling sidecar.
+func internalProxyHandler(handler grpc_go.StreamHandler, auth callLocalAuth, scheduler *callScheduler) grpc_go.StreamHandler {
+	return func(srv interface{}, serverStream grpc_go.ServerStream) error {
+		ctx := serverStream.Context()
+		if err := auth.checkCaller(ctx); err != nil {
+			return err
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		ids := md.Get(ProxyAppIDHeader)
+		if len(ids) == 0 {
+			return status.Errorf(codes.PermissionDenied, "the proxied call has no %s metadata", ProxyAppIDHeader)
+		}
+		if ids[0] != auth.appID {
+			return status.Errorf(codes.PermissionDenied, "the proxied call for app %s was routed to app %s", ids[0], auth.appID)
+		}
+		delete(md, invokev1.CallerAppIDHeader)
+		delete(md, invokev1.CallerNamespaceHeader)
+		delete(md, invokev1.CallerTrustDomainHeader)
+		if caller := callerIdentity(ctx); caller != nil {
+			md.Set(invokev1.CallerAppIDHeader, caller.ID)
+			md.Set(invokev1.CallerNamespaceHeader, caller.Namespace)
+			md.Set(invokev1.CallerTrustDomainHeader, caller.TrustDomain)
+		}
+
+		if scheduler != nil {
+			peer := callPeer(ctx)
+			if err := scheduleCall(ctx, scheduler, peer); err != nil {
+				return err
+			}
+			defer scheduler.done(peer)
+		}
+		return handler(srv, &proxiedServerStream{ServerStream: serverStream, ctx: metadata.NewIncomingContext(ctx, md)})
+	}
+}
+
+// proxiedServerStream is a server stream with the metadata of the call replaced
+type proxiedServerStream struct {
+	grpc_go.ServerStream
+	ctx context.Context
+}
+
+func (s *proxiedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// frame is the raw message of a proxied call
+type frame struct {
+	payload []byte
+}
+
+// proxyCodec passes the frames of the proxied calls through, and encodes the other messages with protobuf
+type proxyCodec struct {
+	parent encoding.Codec
+}
+
+func newProxyCodec() *proxyCodec {
+	return &proxyCodec{parent: encoding.GetCodec(proto.Name)}
+}
+
+func (c *proxyCodec) Marshal(v interface{}) ([]byte, error) {
+	if f, ok := v.(*frame); ok {
+		return f.payload, nil
+	}
+	return c.parent.Marshal(v)
+}
+
+func (c *proxyCodec) Unmarshal(data []byte, v interface{}) error {
+	if f, ok := v.(*frame); ok {
+		f.payload = data
+		return nil
+	}
+	return c.parent.Unmarshal(data, v)
+}
+
+func (c *proxyCodec) Name() string {
+	return c.parent.Name()
+}
+
+// String implements the deprecated grpc.Codec taken by grpc.CustomCodec
+func (c *proxyCodec) String() string {
+	return c.parent.Name()
+}
+
+// proxyHandler forwards the calls to unknown services, unary or streaming, to the connection returned by the director.
+// The messages and the metadata are passed through, so that the app keeps its own generated clients and servers.
+func proxyHandler(director ProxyDirector, codec *proxyCodec, tracingSpec config.TracingSpec) grpc_go.StreamHandler {
+	return func(srv interface{}, serverStream grpc_go.ServerStream) error {
+		fullMethod, ok := grpc_go.MethodFromServerStream(serverStream)
+		if !ok {
+			return status.Error(codes.Internal, "the proxied call has no method")
+		}
+		ctx := serverStream.Context()
+		conn, err := director(ctx, fullMethod)
+		if err != nil {
+			return err
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		delete(md, ":authority")
+		ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(ctx, md))
+		defer cancel()
+		ctx, span := diag.StartTracingClientSpanFromGRPCContext(ctx, fullMethod, tracingSpec)
+		ctx = diag.AppendToOutgoingGRPCContext(ctx, span.SpanContext())
+
+		clientStream, err := grpc_go.NewClientStream(ctx, &grpc_go.StreamDesc{ServerStreams: true, ClientStreams: true}, conn, fullMethod, grpc_go.CallCustomCodec(codec))
+		if err != nil {
+			diag.UpdateSpanPairStatusesFromError(span, err, fullMethod)
+			span.End()
+			return err
+		}
+
+		err = forwardProxiedCall(serverStream, clientStream)
+		diag.UpdateSpanPairStatusesFromError(span, err, fullMethod)
+		span.End()
+		return err
+	}
+}
+
+// forwardProxiedCall forwards the messages of the caller to the callee, and the headers, messages and trailers of the
+// callee back, until the callee ends the call
+func forwardProxiedCall(serverStream grpc_go.ServerStream, clientStream grpc_go.ClientStream) error {
+	sendErr := make(chan error, 1)
+	go func() {
+		for {
+			f := &frame{}
+			if err := serverStream.RecvMsg(f); err != nil {
+				if err == io.EOF {
+					err = clientStream.CloseSend()
+				}
+				sendErr <- err
+				return
+			}
+			if err := clientStream.SendMsg(f); err != nil {
+				// the callee ended the call, with the status returned by RecvMsg below
+				sendErr <- nil
+				return
+			}
+		}
+	}()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		var err error
+		// a failed call has no header, its status is returned by RecvMsg
+		if header, headerErr := clientStream.Header(); headerErr == nil {
+			err = serverStream.SendHeader(header)
+		}
+		for err == nil {
+			f := &frame{}
+			if err = clientStream.RecvMsg(f); err == nil {
+				err = serverStream.SendMsg(f)
+			}
+		}
+		recvErr <- err
+	}()
+
+	for {
+		select {
+		case err := <-sendErr:
+			if err != nil {
+				// the caller canceled the call or its stream broke
+				return status.Errorf(codes.Canceled, "error receiving the proxied call: %s", err)
+			}
+			// keep receiving until the callee ends the call
+			sendErr = nil
+		case err := <-recvErr:
+			serverStream.SetTrailer(clientStream.Trailer())
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serveOnLocalPort serves on a random local port and returns a connection to it
+func serveOnLocalPort(t *testing.T, server *grpc_go.Server) *grpc_go.ClientConn {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+
+	conn, err := grpc_go.Dial(lis.Addr().String(), grpc_go.WithInsecure())
+	require.NoError(t, err)
+	return conn
+}
+
+func startTestProxy(t *testing.T, director ProxyDirector) (*grpc_go.Server, *grpc_go.ClientConn) {
+	codec := newProxyCodec()
+	server := grpc_go.NewServer(grpc_go.CustomCodec(codec), grpc_go.UnknownServiceHandler(proxyHandler(director, codec, config.TracingSpec{})))
+	return server, serveOnLocalPort(t, server)
+}
+
+func TestProxy(t *testing.T) {
+	// the health service stands for a service of the app unknown to Dapr
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("app", grpc_health_v1.HealthCheckResponse_SERVING)
+	app := grpc_go.NewServer()
+	grpc_health_v1.RegisterHealthServer(app, healthServer)
+	appConn := serveOnLocalPort(t, app)
+	defer app.Stop()
+	defer appConn.Close()
+
+	t.Run("unary call", func(t *testing.T) {
+		proxy, conn := startTestProxy(t, NewInternalProxyDirector(appConn))
+		defer proxy.Stop()
+		defer conn.Close()
+
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+	})
+
+	t.Run("errors of the callee", func(t *testing.T) {
+		proxy, conn := startTestProxy(t, NewInternalProxyDirector(appConn))
+		defer proxy.Stop()
+		defer conn.Close()
+
+		_, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("streaming call", func(t *testing.T) {
+		proxy, conn := startTestProxy(t, NewInternalProxyDirector(appConn))
+		defer proxy.Stop()
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := grpc_health_v1.NewHealthClient(conn).Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		require.NoError(t, err)
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+		healthServer.SetServingStatus("app", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		defer healthServer.SetServingStatus("app", grpc_health_v1.HealthCheckResponse_SERVING)
+		resp, err = stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+	})
+
+	t.Run("app without gRPC", func(t *testing.T) {
+		proxy, conn := startTestProxy(t, NewInternalProxyDirector(nil))
+		defer proxy.Stop()
+		defer conn.Close()
+
+		_, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("calls to the local app", func(t *testing.T) {
+		mockDirectMessaging := new(daprt.MockDirectMessaging)
+		proxy, conn := startTestProxy(t, NewAPIProxyDirector("app", appConn, mockDirectMessaging))
+		defer proxy.Stop()
+		defer conn.Close()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), ProxyAppIDHeader, "app")
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+		mockDirectMessaging.AssertNotCalled(t, "RemoteConnection", "app")
+	})
+
+	t.Run("calls to a remote app", func(t *testing.T) {
+		mockDirectMessaging := new(daprt.MockDirectMessaging)
+		mockDirectMessaging.On("RemoteConnection", "remote").Return(appConn, nil)
+		mockDirectMessaging.On("RemoteConnection", "missing").Return(nil, errors.New("not found"))
+		proxy, conn := startTestProxy(t, NewAPIProxyDirector("app", nil, mockDirectMessaging))
+		defer proxy.Stop()
+		defer conn.Close()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), ProxyAppIDHeader, "remote")
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+		ctx = metadata.AppendToOutgoingContext(context.Background(), ProxyAppIDHeader, "missing")
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		assert.Error(t, err)
+	})
+
+	t.Run("calls without app id", func(t *testing.T) {
+		proxy, conn := startTestProxy(t, NewAPIProxyDirector("app", appConn, new(daprt.MockDirectMessaging)))
+		defer proxy.Stop()
+		defer conn.Close()
+
+		_, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestInternalProxy(t *testing.T) {
+	// the app records the metadata of the calls it receives
+	var received metadata.MD
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("app", grpc_health_v1.HealthCheckResponse_SERVING)
+	app := grpc_go.NewServer(grpc_go.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc_go.UnaryServerInfo, handler grpc_go.UnaryHandler) (interface{}, error) {
+		received, _ = metadata.FromIncomingContext(ctx)
+		return handler(ctx, req)
+	}))
+	grpc_health_v1.RegisterHealthServer(app, healthServer)
+	appConn := serveOnLocalPort(t, app)
+	defer app.Stop()
+	defer appConn.Close()
+
+	startInternalProxy := func(auth callLocalAuth) (*grpc_go.Server, *grpc_go.ClientConn) {
+		codec := newProxyCodec()
+		handler := internalProxyHandler(proxyHandler(NewInternalProxyDirector(appConn), codec, config.TracingSpec{}), auth, nil)
+		server := grpc_go.NewServer(grpc_go.CustomCodec(codec), grpc_go.UnknownServiceHandler(handler))
+		return server, serveOnLocalPort(t, server)
+	}
+
+	t.Run("calls to the local app without the caller identity sent by the caller", func(t *testing.T) {
+		proxy, conn := startInternalProxy(callLocalAuth{appID: "app"})
+		defer proxy.Stop()
+		defer conn.Close()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), ProxyAppIDHeader, "app", invokev1.CallerAppIDHeader, "admin")
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+		assert.Empty(t, received.Get(invokev1.CallerAppIDHeader))
+	})
+
+	t.Run("calls to another app", func(t *testing.T) {
+		proxy, conn := startInternalProxy(callLocalAuth{appID: "app"})
+		defer proxy.Stop()
+		defer conn.Close()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), ProxyAppIDHeader, "other")
+		_, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("callers without a sidecar identity of the trust domain", func(t *testing.T) {
+		proxy, conn := startInternalProxy(callLocalAuth{appID: "app", trustDomain: "public"})
+		defer proxy.Stop()
+		defer conn.Close()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), ProxyAppIDHeader, "app")
+		_, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "app"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
@@ -9,20 +9,24 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/namedpipe"
 	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
 	auth "github.com/dapr/dapr/pkg/runtime/security"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	grpc_go "google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	channelz "google.golang.org/grpc/channelz/service"
 	"google.golang.org/grpc/keepalive"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -36,6 +40,7 @@ const (
 // Server is an interface for the dapr gRPC server
 type Server interface {
 	StartNonBlocking() error
+	Drain(gracePeriod time.Duration)
 }
 
 type server struct {
@@ -43,13 +48,15 @@ type server struct {
 	config             ServerConfig
 	tracingSpec        config.TracingSpec
 	authenticator      auth.Authenticator
-	listener           net.Listener
+	listeners          []net.Listener
 	srv                *grpc_go.Server
 	renewMutex         *sync.Mutex
 	signedCert         *auth.SignedCertificate
 	tlsCert            tls.Certificate
 	signedCertDuration time.Duration
+	trustDomain        string
 	kind               string
+	scheduler          *callScheduler
 	logger             logger.Logger
 	maxConnectionAge   *time.Duration
 }
@@ -89,11 +96,45 @@ func getDefaultMaxAgeDuration() *time.Duration {
 
 // StartNonBlocking starts a new server in a goroutine
 func (s *server) StartNonBlocking() error {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%v", s.config.Port))
-	if err != nil {
-		return err
+	var limiter *connectionLimiter
+	if s.config.Limits.MaxConnections > 0 || s.config.Limits.MaxConnectionsPerPeer > 0 {
+		limiter = newConnectionLimiter(s.kind, s.config.Limits.MaxConnections, s.config.Limits.MaxConnectionsPerPeer)
+	}
+
+	listenAddresses := s.config.ListenAddresses
+	if len(listenAddresses) == 0 {
+		// listen on all interfaces, dual-stack where available
+		listenAddresses = []string{""}
 	}
-	s.listener = lis
+
+	listeners := []net.Listener{}
+	for _, address := range listenAddresses {
+		lis, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(s.config.Port)))
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return err
+		}
+		if limiter != nil {
+			lis = newLimitListener(lis, limiter)
+		}
+		listeners = append(listeners, lis)
+	}
+	if s.config.Pipe != "" {
+		lis, err := namedpipe.Listen(s.config.Pipe)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return err
+		}
+		if limiter != nil {
+			lis = newLimitListener(lis, limiter)
+		}
+		listeners = append(listeners, lis)
+	}
+	s.listeners = listeners
 
 	server, err := s.getGRPCServer()
 	if err != nil {
@@ -103,15 +144,43 @@ func (s *server) StartNonBlocking() error {
 
 	if s.kind == internalServer {
 		internalv1pb.RegisterDaprInternalServer(server, s.api)
+		if s.config.EnableChannelz {
+			channelz.RegisterChannelzServiceToServer(server)
+		}
 	} else if s.kind == apiServer {
 		daprv1pb.RegisterDaprServer(server, s.api)
 	}
+	for _, lis := range listeners {
+		go func(lis net.Listener) {
+			// a server drained before it started serving returns ErrServerStopped
+			if err := server.Serve(lis); err != nil && err != grpc_go.ErrServerStopped {
+				s.logger.Fatalf("gRPC serve error: %v", err)
+			}
+		}(lis)
+	}
+	return nil
+}
+
+// Drain stops accepting connections and sends GOAWAY to the peers, so that they send new calls elsewhere. In-flight
+// calls have the grace period to complete before the remaining connections are closed.
+func (s *server) Drain(gracePeriod time.Duration) {
+	if s.srv == nil {
+		return
+	}
+
+	drained := make(chan struct{})
 	go func() {
-		if err := server.Serve(lis); err != nil {
-			s.logger.Fatalf("gRPC serve error: %v", err)
-		}
+		s.srv.GracefulStop()
+		close(drained)
 	}()
-	return nil
+
+	select {
+	case <-drained:
+		s.logger.Info("gRPC server drained")
+	case <-time.After(gracePeriod):
+		s.logger.Warnf("gRPC server not drained after %s, closing the remaining connections", gracePeriod)
+		s.srv.Stop()
+	}
 }
 
 func (s *server) generateWorkloadCert() error {
@@ -137,30 +206,74 @@ func (s *server) getMiddlewareOptions() []grpc_go.ServerOption {
 	opts := []grpc_go.ServerOption{}
 
 	s.logger.Infof("enabled monitoring middleware.")
-	unaryChains := grpc_middleware.ChainUnaryServer(
+	unaryInterceptors := []grpc_go.UnaryServerInterceptor{
 		diag.SetTracingSpanContextGRPCMiddlewareUnary(s.tracingSpec),
 		diag.DefaultGRPCMonitoring.UnaryServerInterceptor(),
-	)
+	}
+	if s.kind == internalServer {
+		// the calls of other sidecars are authorized and their payloads checked before they wait for their turn
+		auth := newCallLocalAuth(s.config.AppID, s.trustDomain, s.config.CallLocal)
+		unaryInterceptors = append(unaryInterceptors, callLocalAuthInterceptor(auth), anyLimitsInterceptor(s.config.AnyLimits))
+	}
+	if c := s.config.CallLocal; s.kind == internalServer && (c.MaxConcurrency > 0 || c.MaxConcurrencyPerPeer > 0) {
+		s.scheduler = newCallScheduler(s.kind, c)
+		unaryInterceptors = append(unaryInterceptors, callScheduleInterceptor(s.scheduler))
+	}
+	if s.config.MemoryBudget != nil {
+		unaryInterceptors = append(unaryInterceptors, memoryBudgetInterceptor(s.kind, s.config.MemoryBudget))
+	}
+	if len(s.config.Bulkheads) > 0 {
+		unaryInterceptors = append(unaryInterceptors, bulkheadInterceptor(s.config.Bulkheads))
+	}
+	streamInterceptors := []grpc_go.StreamServerInterceptor{
+		diag.SetTracingSpanContextGRPCMiddlewareStream(s.tracingSpec),
+		diag.DefaultGRPCMonitoring.StreamServerInterceptor(),
+	}
+	if s.kind == internalServer {
+		auth := newCallLocalAuth(s.config.AppID, s.trustDomain, s.config.CallLocal)
+		streamInterceptors = append(streamInterceptors, callLocalAuthStreamInterceptor(auth), anyLimitsStreamInterceptor(s.config.AnyLimits))
+	}
+	unaryChains := grpc_middleware.ChainUnaryServer(unaryInterceptors...)
 	opts = append(
 		opts,
-		grpc_go.StreamInterceptor(diag.SetTracingSpanContextGRPCMiddlewareStream(s.tracingSpec)),
+		grpc_go.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 		grpc_go.UnaryInterceptor(unaryChains))
 
 	return opts
 }
 
 func (s *server) getGRPCServer() (*grpc_go.Server, error) {
+	if s.authenticator != nil {
+		if err := s.generateWorkloadCert(); err != nil {
+			return nil, err
+		}
+		s.trustDomain = certificateTrustDomain(s.signedCert.WorkloadCert)
+	}
+
 	opts := s.getMiddlewareOptions()
 	if s.maxConnectionAge != nil {
 		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{MaxConnectionAge: *s.maxConnectionAge}))
 	}
-
-	if s.authenticator != nil {
-		err := s.generateWorkloadCert()
+	if s.config.Limits.MaxMessageSize != "" {
+		size, err := resource.ParseQuantity(s.config.Limits.MaxMessageSize)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("invalid max message size: %s", err)
+		}
+		opts = append(opts, grpc_go.MaxRecvMsgSize(int(size.Value())))
+	}
+	if s.config.Limits.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc_go.MaxConcurrentStreams(s.config.Limits.MaxConcurrentStreams))
+	}
+	if s.config.Proxy != nil {
+		codec := newProxyCodec()
+		handler := proxyHandler(s.config.Proxy, codec, s.tracingSpec)
+		if s.kind == internalServer {
+			handler = internalProxyHandler(handler, newCallLocalAuth(s.config.AppID, s.trustDomain, s.config.CallLocal), s.scheduler)
 		}
+		opts = append(opts, grpc_go.CustomCodec(codec), grpc_go.UnknownServiceHandler(handler))
+	}
 
+	if s.authenticator != nil {
 		tlsConfig := tls.Config{
 			ClientCAs:  s.signedCert.TrustChain,
 			ClientAuth: tls.RequireAndVerifyClientCert,
@@ -168,7 +281,7 @@ func (s *server) getGRPCServer() (*grpc_go.Server, error) {
 				return &s.tlsCert, nil
 			},
 		}
-		ta := credentials.NewTLS(&tlsConfig)
+		ta := newAuditCredentials(fips.ConfigureTLS(&tlsConfig), s.kind, s.trustDomain, s.logger)
 
 		opts = append(opts, grpc_go.Creds(ta))
 		go s.startWorkloadCertRotation()
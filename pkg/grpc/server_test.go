@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"net"
 	"sync"
 	"testing"
 	"time"
@@ -60,3 +61,84 @@ func TestGetMiddlewareOptions(t *testing.T) {
 		assert.Equal(t, 2, len(serverOption))
 	})
 }
+
+func TestListenAddresses(t *testing.T) {
+	port, err := GetFreePort()
+	assert.NoError(t, err)
+
+	addresses := []string{"127.0.0.1"}
+	if l, err := net.Listen("tcp", "[::1]:0"); err == nil {
+		l.Close()
+		addresses = append(addresses, "::1")
+	}
+
+	s := NewAPIServer(&api{}, ServerConfig{
+		Port:            port,
+		ListenAddresses: addresses,
+	}, config.TracingSpec{}).(*server)
+	assert.NoError(t, s.StartNonBlocking())
+	defer s.srv.Stop()
+
+	assert.Equal(t, len(addresses), len(s.listeners))
+	for _, l := range s.listeners {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		assert.NoError(t, err)
+		conn.Close()
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Run("server that didn't start", func(t *testing.T) {
+		s := NewInternalServer(&api{}, ServerConfig{}, config.TracingSpec{}, nil)
+		s.Drain(time.Second)
+	})
+
+	t.Run("drained server stops listening", func(t *testing.T) {
+		port, err := GetFreePort()
+		assert.NoError(t, err)
+
+		s := NewAPIServer(&api{}, ServerConfig{
+			Port:            port,
+			ListenAddresses: []string{"127.0.0.1"},
+		}, config.TracingSpec{}).(*server)
+		assert.NoError(t, s.StartNonBlocking())
+
+		start := time.Now()
+		s.Drain(time.Second * 5)
+		assert.True(t, time.Since(start) < time.Second*5)
+
+		_, err = net.Dial("tcp", s.listeners[0].Addr().String())
+		assert.Error(t, err)
+	})
+}
+
+func TestInternalServerChannelz(t *testing.T) {
+	start := func(t *testing.T, enabled bool) *server {
+		port, err := GetFreePort()
+		assert.NoError(t, err)
+
+		s := NewInternalServer(&api{}, ServerConfig{
+			Port:            port,
+			ListenAddresses: []string{"127.0.0.1"},
+			EnableChannelz:  enabled,
+		}, config.TracingSpec{}, nil).(*server)
+		assert.NoError(t, s.StartNonBlocking())
+		return s
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s := start(t, false)
+		defer s.srv.Stop()
+
+		_, ok := s.srv.GetServiceInfo()["grpc.channelz.v1.Channelz"]
+		assert.False(t, ok)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		s := start(t, true)
+		defer s.srv.Stop()
+
+		_, ok := s.srv.GetServiceInfo()["grpc.channelz.v1.Channelz"]
+		assert.True(t, ok)
+	})
+}
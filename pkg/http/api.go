@@ -6,7 +6,9 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -20,10 +22,16 @@ import (
 	"github.com/dapr/dapr/pkg/actors"
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/channel/http"
+	"github.com/dapr/dapr/pkg/components"
+	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
+	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/messaging"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/dapr/pkg/saga"
+	"github.com/dapr/dapr/pkg/sequencer"
 	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/valyala/fasthttp"
@@ -35,6 +43,7 @@ import (
 // API returns a list of HTTP endpoints for Dapr
 type API interface {
 	APIEndpoints() []Endpoint
+	ProfilingEndpoints() []Endpoint
 	MarkStatusAsReady()
 }
 
@@ -44,20 +53,71 @@ type api struct {
 	appChannel            channel.AppChannel
 	stateStores           map[string]state.Store
 	secretStores          map[string]secretstores.SecretStore
+	componentsLock        *sync.RWMutex
 	json                  jsoniter.API
 	actor                 actors.Actors
 	publishFn             func(req *pubsub.PublishRequest) error
 	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error
+	sagas                 *saga.Coordinator
+	pauser                *pubsub_loader.Pauser
+	subscriptions         *pubsub_loader.Subscriptions
+	replayFn              func(req pubsub_loader.SeekRequest, hintWindow time.Duration) (string, error)
 	id                    string
-	extendedMetadata      sync.Map
+	extendedMetadata      *sync.Map
 	readyStatus           bool
+	configDumpFn          func() interface{}
+	capabilitiesFn        func() []components.Capabilities
 	tracingSpec           config.TracingSpec
 }
 
+type nextIDResponse struct {
+	ID int64 `json:"id"`
+}
+
+type generatedIDResponse struct {
+	ID string `json:"id"`
+}
+
+type startSagaResponse struct {
+	ID string `json:"id"`
+}
+
+type stateExportResponse struct {
+	Exported int `json:"exported"`
+	// Cursor and Page resume the export, which is complete when the cursor is empty
+	Cursor string `json:"cursor"`
+	Page   int    `json:"page"`
+}
+
+type stateDeletePrefixResponse struct {
+	Deleted int `json:"deleted"`
+	// Cursor resumes the deletion, which is complete when the cursor is empty
+	Cursor string `json:"cursor"`
+}
+
+type stateImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+type pausedTopicsResponse struct {
+	Paused []string `json:"paused"`
+}
+
+type profileResponse struct {
+	Type      string    `json:"type"`
+	Size      int       `json:"size"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type replayResponse struct {
+	ReplayID string `json:"replayId"`
+}
+
 type metadata struct {
-	ID                string                      `json:"id"`
-	ActiveActorsCount []actors.ActiveActorsCount  `json:"actors"`
-	Extended          map[interface{}]interface{} `json:"extended"`
+	ID                string                             `json:"id"`
+	ActiveActorsCount []actors.ActiveActorsCount         `json:"actors"`
+	Extended          map[interface{}]interface{}        `json:"extended"`
+	Subscriptions     []pubsub_loader.SubscriptionStatus `json:"subscriptions"`
 }
 
 const (
@@ -73,25 +133,44 @@ const (
 	secretNameParam      = "key"
 	nameParam            = "name"
 	consistencyParam     = "consistency"
+	maxStalenessParam    = "maxStaleness"
 	retryIntervalParam   = "retryInterval"
 	retryPatternParam    = "retryPattern"
 	retryThresholdParam  = "retryThreshold"
 	concurrencyParam     = "concurrency"
+	idKindParam          = "kind"
+	messageIDParam       = "messageId"
+	limitParam           = "limit"
+	continuationParam    = "continuationToken"
+	versionParam         = "version"
+	topParam             = "top"
 	daprSeparator        = "||"
+
+	defaultStateExportPageSize       = 1000
+	defaultStateDeletePrefixPageSize = 1000
+	defaultInvocationPayloadsTop     = 10
 )
 
 // NewAPI returns a new API
-func NewAPI(appID string, appChannel channel.AppChannel, directMessaging messaging.DirectMessaging, stateStores map[string]state.Store, secretStores map[string]secretstores.SecretStore, publishFn func(*pubsub.PublishRequest) error, actor actors.Actors, sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error, tracingSpec config.TracingSpec) API {
+func NewAPI(appID string, appChannel channel.AppChannel, directMessaging messaging.DirectMessaging, stateStores map[string]state.Store, secretStores map[string]secretstores.SecretStore, componentsLock *sync.RWMutex, extendedMetadata *sync.Map, publishFn func(*pubsub.PublishRequest) error, actor actors.Actors, sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error, sagas *saga.Coordinator, pauser *pubsub_loader.Pauser, subscriptions *pubsub_loader.Subscriptions, replayFn func(req pubsub_loader.SeekRequest, hintWindow time.Duration) (string, error), configDumpFn func() interface{}, capabilitiesFn func() []components.Capabilities, jsonSpec config.JSONSpec, tracingSpec config.TracingSpec) API {
 	api := &api{
 		appChannel:            appChannel,
 		directMessaging:       directMessaging,
 		stateStores:           stateStores,
 		secretStores:          secretStores,
-		json:                  jsoniter.ConfigFastest,
+		componentsLock:        componentsLock,
+		extendedMetadata:      extendedMetadata,
+		json:                  config.NewJSONAPI(jsonSpec),
 		actor:                 actor,
 		publishFn:             publishFn,
 		sendToOutputBindingFn: sendToOutputBindingFn,
+		sagas:                 sagas,
+		pauser:                pauser,
+		subscriptions:         subscriptions,
+		replayFn:              replayFn,
 		id:                    appID,
+		configDumpFn:          configDumpFn,
+		capabilitiesFn:        capabilitiesFn,
 		tracingSpec:           tracingSpec,
 	}
 	api.endpoints = append(api.endpoints, api.constructStateEndpoints()...)
@@ -102,15 +181,63 @@ func NewAPI(appID string, appChannel channel.AppChannel, directMessaging messagi
 	api.endpoints = append(api.endpoints, api.constructMetadataEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructBindingsEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructHealthzEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructAdminEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructSequencerEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructSagaEndpoints()...)
 
 	return api
 }
 
+// rLockComponents locks the component maps for reading and returns the func unlocking them. The components lock
+// guards the components registered by the runtime while the API serves, e.g. the components initialized in the
+// background.
+func (a *api) rLockComponents() func() {
+	if a.componentsLock == nil {
+		return func() {}
+	}
+	a.componentsLock.RLock()
+	return a.componentsLock.RUnlock
+}
+
+func (a *api) stateStore(name string) (state.Store, bool) {
+	defer a.rLockComponents()()
+	store, ok := a.stateStores[name]
+	return store, ok
+}
+
+func (a *api) hasStateStores() bool {
+	defer a.rLockComponents()()
+	return len(a.stateStores) > 0
+}
+
+func (a *api) secretStore(name string) (secretstores.SecretStore, bool) {
+	defer a.rLockComponents()()
+	store, ok := a.secretStores[name]
+	return store, ok
+}
+
+func (a *api) hasSecretStores() bool {
+	defer a.rLockComponents()()
+	return len(a.secretStores) > 0
+}
+
 // APIEndpoints returns the list of registered endpoints
 func (a *api) APIEndpoints() []Endpoint {
 	return a.endpoints
 }
 
+// ProfilingEndpoints returns the endpoints served by the profiling server next to the pprof endpoints
+func (a *api) ProfilingEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "admin/profiles",
+			Version: apiVersionV1,
+			Handler: a.onPostProfile,
+		},
+	}
+}
+
 // MarkStatusAsReady marks the ready status of dapr
 func (a *api) MarkStatusAsReady() {
 	a.readyStatus = true
@@ -130,12 +257,36 @@ func (a *api) constructStateEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onPostState,
 		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "state/{storeName}/delete",
+			Version: apiVersionV1,
+			Handler: a.onBulkDeleteState,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "state/{storeName}/delete/prefix",
+			Version: apiVersionV1,
+			Handler: a.onDeleteStatePrefix,
+		},
 		{
 			Methods: []string{fhttp.MethodDelete},
 			Route:   "state/{storeName}/{key}",
 			Version: apiVersionV1,
 			Handler: a.onDeleteState,
 		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "state/{storeName}/{key}/versions",
+			Version: apiVersionV1,
+			Handler: a.onGetStateVersions,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "state/{storeName}/{key}/restore",
+			Version: apiVersionV1,
+			Handler: a.onPostStateRestore,
+		},
 	}
 }
 
@@ -180,6 +331,12 @@ func (a *api) constructDirectMessagingEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onDirectMessage,
 		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "invocations/{messageId}",
+			Version: apiVersionV1,
+			Handler: a.onGetInvocationStatus,
+		},
 	}
 }
 
@@ -245,6 +402,42 @@ func (a *api) constructActorEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onGetActorReminder,
 		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "actors/{actorType}/{actorId}/reminders",
+			Version: apiVersionV1,
+			Handler: a.onListActorReminders,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "actors/{actorType}/{actorId}/timers",
+			Version: apiVersionV1,
+			Handler: a.onListActorTimers,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "actortypes/{actorType}/reminders",
+			Version: apiVersionV1,
+			Handler: a.onListActorReminders,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "actortypes",
+			Version: apiVersionV1,
+			Handler: a.onGetActorTypes,
+		},
+		{
+			Methods: []string{fhttp.MethodPut},
+			Route:   "actortypes/{actorType}",
+			Version: apiVersionV1,
+			Handler: a.onRegisterActorType,
+		},
+		{
+			Methods: []string{fhttp.MethodDelete},
+			Route:   "actortypes/{actorType}",
+			Version: apiVersionV1,
+			Handler: a.onUnregisterActorType,
+		},
 	}
 }
 
@@ -262,6 +455,18 @@ func (a *api) constructMetadataEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onPutMetadata,
 		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "capabilities",
+			Version: apiVersionV1,
+			Handler: a.onGetCapabilities,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "openapi.json",
+			Version: apiVersionV1,
+			Handler: a.onGetOpenAPI,
+		},
 	}
 }
 
@@ -276,6 +481,105 @@ func (a *api) constructHealthzEndpoints() []Endpoint {
 	}
 }
 
+func (a *api) constructAdminEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "admin/config",
+			Version: apiVersionV1,
+			Handler: a.onGetConfigDump,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "admin/invocation/payloads",
+			Version: apiVersionV1,
+			Handler: a.onGetInvocationPayloads,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "admin/state/{storeName}/queue",
+			Version: apiVersionV1,
+			Handler: a.onGetStateQueue,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "admin/state/{storeName}/mirror",
+			Version: apiVersionV1,
+			Handler: a.onGetStateMirror,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "admin/state/{storeName}/export",
+			Version: apiVersionV1,
+			Handler: a.onPostStateExport,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "admin/state/{storeName}/import",
+			Version: apiVersionV1,
+			Handler: a.onPostStateImport,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "admin/pubsub/topics",
+			Version: apiVersionV1,
+			Handler: a.onGetPausedTopics,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "admin/pubsub/topics/{topic}/pause",
+			Version: apiVersionV1,
+			Handler: a.onPauseTopic,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "admin/pubsub/topics/{topic}/resume",
+			Version: apiVersionV1,
+			Handler: a.onResumeTopic,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "admin/pubsub/topics/{topic}/replay",
+			Version: apiVersionV1,
+			Handler: a.onReplayTopic,
+		},
+	}
+}
+
+func (a *api) constructSequencerEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "sequences/{storeName}/{key}",
+			Version: apiVersionV1,
+			Handler: a.onNextID,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "ids/{kind}",
+			Version: apiVersionV1,
+			Handler: a.onGenerateID,
+		},
+	}
+}
+
+func (a *api) constructSagaEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "sagas",
+			Version: apiVersionV1,
+			Handler: a.onStartSaga,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "sagas/{id}",
+			Version: apiVersionV1,
+			Handler: a.onGetSaga,
+		},
+	}
+}
+
 func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 	name := reqCtx.UserValue(nameParam).(string)
 	body := reqCtx.PostBody()
@@ -300,7 +604,7 @@ func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	ctx := diag.NewContext((context.Context)(reqCtx), sc)
 	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
-	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request, a.tracingSpec)
 	defer span.End()
 
 	err = a.sendToOutputBindingFn(name, &bindings.WriteRequest{
@@ -317,7 +621,7 @@ func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 }
 
 func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if !a.hasStateStores() {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_CONFIGURED", "")
 		respondWithError(reqCtx, 400, msg)
 		return
@@ -325,7 +629,8 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 
 	storeName := reqCtx.UserValue(storeNameParam).(string)
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
 		respondWithError(reqCtx, 401, msg)
 		return
@@ -336,7 +641,7 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	ctx := diag.NewContext((context.Context)(reqCtx), sc)
 	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
-	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request, a.tracingSpec)
 	defer span.End()
 
 	key := reqCtx.UserValue(stateKeyParam).(string)
@@ -347,8 +652,11 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 			Consistency: consistency,
 		},
 	}
+	if version := string(reqCtx.QueryArgs().Peek(versionParam)); version != "" {
+		req.Metadata = map[string]string{state_loader.VersionMetadataKey: version}
+	}
 
-	resp, err := a.stateStores[storeName].Get(&req)
+	resp, err := store.Get(&req)
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_GET", err.Error())
 		respondWithError(reqCtx, 500, msg)
@@ -362,7 +670,7 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 }
 
 func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if !a.hasStateStores() {
 		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
 		respondWithError(reqCtx, 400, msg)
 		return
@@ -370,7 +678,8 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 
 	storeName := reqCtx.UserValue(storeNameParam).(string)
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
 		respondWithError(reqCtx, 401, msg)
 		return
@@ -413,10 +722,10 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	ctx := diag.NewContext((context.Context)(reqCtx), sc)
 	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
-	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request, a.tracingSpec)
 	defer span.End()
 
-	err := a.stateStores[storeName].Delete(&req)
+	err := store.Delete(&req)
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_DELETE", fmt.Sprintf("failed deleting state with key %s: %s", key, err))
 		respondWithError(reqCtx, 500, msg)
@@ -425,8 +734,175 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 	respondEmpty(reqCtx, 200)
 }
 
+// onBulkDeleteState deletes the keys in one operation on stores with the bulk delete feature, and one by one otherwise,
+// stopping at the first failure
+func (a *api) onBulkDeleteState(reqCtx *fasthttp.RequestCtx) {
+	if !a.hasStateStores() {
+		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	reqs := []state.DeleteRequest{}
+	err := a.json.Unmarshal(reqCtx.PostBody(), &reqs)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	keys := make([]string, len(reqs))
+	for i, r := range reqs {
+		keys[i] = r.Key
+		reqs[i].Key = a.getModifiedStateKey(r.Key)
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("DeleteBulkState: %s", storeName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request, a.tracingSpec)
+	defer span.End()
+
+	if a.capabilitiesFn != nil && components.HasFeature(a.capabilitiesFn(), storeName, components.FeatureBulkDelete) {
+		if err := store.BulkDelete(reqs); err != nil {
+			msg := NewErrorResponse("ERR_STATE_BULK_DELETE", err.Error())
+			respondWithError(reqCtx, 500, msg)
+			return
+		}
+		respondEmpty(reqCtx, 200)
+		return
+	}
+	for i := range reqs {
+		if err := store.Delete(&reqs[i]); err != nil {
+			msg := NewErrorResponse("ERR_STATE_DELETE", fmt.Sprintf("failed deleting state with key %s: %s", keys[i], err))
+			respondWithError(reqCtx, 500, msg)
+			return
+		}
+	}
+	respondEmpty(reqCtx, 200)
+}
+
+// onDeleteStatePrefix deletes the keys of the app starting with the prefix a page at a time, on stores that opted in.
+// The response reports the deleted keys and the cursor resuming the deletion, so that large prefixes are deleted by
+// several requests limited in pages.
+func (a *api) onDeleteStatePrefix(reqCtx *fasthttp.RequestCtx) {
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	if a.capabilitiesFn == nil || !components.HasFeature(a.capabilitiesFn(), storeName, components.FeatureDeleteByPrefix) {
+		msg := NewErrorResponse("ERR_STATE_DELETE_PREFIX_NOT_SUPPORTED", fmt.Sprintf("state store %s doesn't delete keys by prefix", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	var req StateDeletePrefixRequest
+	err := a.json.Unmarshal(reqCtx.PostBody(), &req)
+	if err != nil || req.Prefix == "" || req.PageSize < 0 || req.MaxPages < 0 {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "deleting keys by prefix requires a prefix and non-negative page options")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	if req.PageSize == 0 {
+		req.PageSize = defaultStateDeletePrefixPageSize
+	}
+
+	resp := stateDeletePrefixResponse{Cursor: req.Cursor}
+	for pages := 0; req.MaxPages == 0 || pages < req.MaxPages; pages++ {
+		n, next, err := state_loader.DeletePrefixPage(store, a.id, req.Prefix, resp.Cursor, req.PageSize)
+		if err != nil {
+			// the cursor of the failed page resumes the deletion
+			msg := NewErrorResponse("ERR_STATE_DELETE_PREFIX", fmt.Sprintf("failed to delete keys with prefix %s at cursor %q after %d deleted keys: %s", req.Prefix, resp.Cursor, resp.Deleted, err))
+			respondWithError(reqCtx, 500, msg)
+			return
+		}
+
+		resp.Deleted += n
+		resp.Cursor = next
+		log.Debugf("deleted %d keys with prefix %s of state store %s", resp.Deleted, req.Prefix, storeName)
+		if next == "" {
+			break
+		}
+	}
+
+	b, _ := a.json.Marshal(resp)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// versionedStateStore returns the versioning of the state store of the request, responding with an error when the
+// store is missing or doesn't keep versions
+func (a *api) versionedStateStore(reqCtx *fasthttp.RequestCtx) (state_loader.VersionedStore, bool) {
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return nil, false
+	}
+
+	versioned, ok := state_loader.Versioning(store)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_VERSIONING_NOT_ENABLED", fmt.Sprintf("versioning is not enabled for state store %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return nil, false
+	}
+	return versioned, true
+}
+
+func (a *api) onGetStateVersions(reqCtx *fasthttp.RequestCtx) {
+	versioned, ok := a.versionedStateStore(reqCtx)
+	if !ok {
+		return
+	}
+
+	key := reqCtx.UserValue(stateKeyParam).(string)
+	versions, err := versioned.Versions(a.getModifiedStateKey(key))
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_VERSIONS", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(versions)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onPostStateRestore(reqCtx *fasthttp.RequestCtx) {
+	versioned, ok := a.versionedStateStore(reqCtx)
+	if !ok {
+		return
+	}
+
+	key := reqCtx.UserValue(stateKeyParam).(string)
+	err := versioned.Restore(a.getModifiedStateKey(key))
+	if err == state_loader.ErrKeyNotDeleted {
+		msg := NewErrorResponse("ERR_STATE_NOT_DELETED", fmt.Sprintf("key %s is not deleted", key))
+		respondWithError(reqCtx, 409, msg)
+		return
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_RESTORE", fmt.Sprintf("failed restoring state with key %s: %s", key, err))
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	respondEmpty(reqCtx, 200)
+}
+
 func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
-	if a.secretStores == nil || len(a.secretStores) == 0 {
+	if !a.hasSecretStores() {
 		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_CONFIGURED", "")
 		respondWithError(reqCtx, 400, msg)
 		return
@@ -434,7 +910,8 @@ func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
 
 	secretStoreName := reqCtx.UserValue(secretStoreNameParam).(string)
 
-	if a.secretStores[secretStoreName] == nil {
+	secretStore, ok := a.secretStore(secretStoreName)
+	if !ok || secretStore == nil {
 		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_FOUND", fmt.Sprintf("secret store name: %s", secretStoreName))
 		respondWithError(reqCtx, 401, msg)
 		return
@@ -461,10 +938,15 @@ func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	ctx := diag.NewContext((context.Context)(reqCtx), sc)
 	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
-	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request, a.tracingSpec)
 	defer span.End()
 
-	resp, err := a.secretStores[secretStoreName].GetSecret(req)
+	resp, err := secretStore.GetSecret(req)
+	if _, ok := err.(*secretstores_loader.ErrSecretNotAllowed); ok {
+		msg := NewErrorResponse("ERR_PERMISSION_DENIED", err.Error())
+		respondWithError(reqCtx, 403, msg)
+		return
+	}
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_GET", err.Error())
 		respondWithError(reqCtx, 500, msg)
@@ -481,7 +963,7 @@ func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
 }
 
 func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if !a.hasStateStores() {
 		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
 		respondWithError(reqCtx, 400, msg)
 		return
@@ -489,7 +971,8 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 
 	storeName := reqCtx.UserValue(storeNameParam).(string)
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
 		respondWithError(reqCtx, 401, msg)
 		return
@@ -512,10 +995,16 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	ctx := diag.NewContext((context.Context)(reqCtx), sc)
 	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
-	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request, a.tracingSpec)
 	defer span.End()
 
-	err = a.stateStores[storeName].BulkSet(reqs)
+	err = store.BulkSet(reqs)
+	if validationErr, ok := err.(*state_loader.SchemaValidationError); ok {
+		msg := NewErrorResponse("ERR_STATE_SCHEMA_VALIDATION", fmt.Sprintf("value of key %s does not match its JSON schema", validationErr.Key))
+		msg.Details = validationErr.Errors
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_SAVE", err.Error())
 		respondWithError(reqCtx, 500, msg)
@@ -578,6 +1067,25 @@ func (a *api) onDirectMessage(reqCtx *fasthttp.RequestCtx) {
 	respond(reqCtx, statusCode, body)
 }
 
+// onGetInvocationStatus returns the delivery status of a fire-and-forget invocation
+func (a *api) onGetInvocationStatus(reqCtx *fasthttp.RequestCtx) {
+	messageID := reqCtx.UserValue(messageIDParam).(string)
+	status, ok := a.directMessaging.DeliveryStatus(messageID)
+	if !ok {
+		msg := NewErrorResponse("ERR_INVOCATION_NOT_FOUND", fmt.Sprintf("invocation %s not found", messageID))
+		respondWithError(reqCtx, fhttp.StatusNotFound, msg)
+		return
+	}
+
+	b, err := a.json.Marshal(status)
+	if err != nil {
+		msg := NewErrorResponse("ERR_INVOCATION_STATUS_GET", err.Error())
+		respondWithError(reqCtx, fhttp.StatusInternalServerError, msg)
+		return
+	}
+	respondWithJSON(reqCtx, fhttp.StatusOK, b)
+}
+
 func (a *api) onCreateActorReminder(reqCtx *fasthttp.RequestCtx) {
 	if a.actor == nil {
 		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
@@ -757,6 +1265,124 @@ func (a *api) onGetActorReminder(reqCtx *fasthttp.RequestCtx) {
 	}
 }
 
+// onListActorReminders lists the reminders of an actor, or pages through the reminders of an actor type
+func (a *api) onListActorReminders(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	actorID, _ := reqCtx.UserValue(actorIDParam).(string)
+	limit := 0
+	if l := string(reqCtx.QueryArgs().Peek(limitParam)); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("invalid limit: %s", l))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+	}
+
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+
+	resp, err := a.actor.ListReminders(ctx, &actors.ListRemindersRequest{
+		ActorType:         actorType,
+		ActorID:           actorID,
+		ContinuationToken: string(reqCtx.QueryArgs().Peek(continuationParam)),
+		Limit:             limit,
+	})
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_REMINDER_LIST", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(resp)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onListActorTimers(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	actorID := reqCtx.UserValue(actorIDParam).(string)
+
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+
+	timers, err := a.actor.ListTimers(ctx, &actors.ListTimersRequest{
+		ActorType: actorType,
+		ActorID:   actorID,
+	})
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_TIMER_LIST", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(timers)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onGetActorTypes(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+
+	b, _ := a.json.Marshal(a.actor.GetHostedActorTypes(ctx))
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onRegisterActorType(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+
+	err := a.actor.RegisterActorType(ctx, actorType)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_REGISTER", err.Error())
+		respondWithError(reqCtx, 400, msg)
+	} else {
+		respondEmpty(reqCtx, 200)
+	}
+}
+
+func (a *api) onUnregisterActorType(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+
+	err := a.actor.UnregisterActorType(ctx, actorType)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_UNREGISTER", err.Error())
+		respondWithError(reqCtx, 400, msg)
+	} else {
+		respondEmpty(reqCtx, 200)
+	}
+}
+
 func (a *api) onDeleteActorTimer(reqCtx *fasthttp.RequestCtx) {
 	if a.actor == nil {
 		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
@@ -898,9 +1524,19 @@ func (a *api) onGetActorState(reqCtx *fasthttp.RequestCtx) {
 	key := reqCtx.UserValue(stateKeyParam).(string)
 
 	req := actors.GetStateRequest{
-		ActorType: actorType,
-		ActorID:   actorID,
-		Key:       key,
+		ActorType:   actorType,
+		ActorID:     actorID,
+		Key:         key,
+		Consistency: string(reqCtx.QueryArgs().Peek(consistencyParam)),
+	}
+	if maxStaleness := string(reqCtx.QueryArgs().Peek(maxStalenessParam)); maxStaleness != "" {
+		d, err := time.ParseDuration(maxStaleness)
+		if err != nil || d < 0 {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("invalid max staleness %s", maxStaleness))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+		req.MaxStaleness = d
 	}
 
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
@@ -971,6 +1607,7 @@ func (a *api) onGetMetadata(reqCtx *fasthttp.RequestCtx) {
 		ID:                a.id,
 		ActiveActorsCount: a.actor.GetActiveActorsCount(ctx),
 		Extended:          temp,
+		Subscriptions:     a.subscriptions.Status(),
 	}
 
 	mtdBytes, err := a.json.Marshal(mtd)
@@ -982,6 +1619,436 @@ func (a *api) onGetMetadata(reqCtx *fasthttp.RequestCtx) {
 	}
 }
 
+// onGetCapabilities returns the features of the loaded components
+func (a *api) onGetCapabilities(reqCtx *fasthttp.RequestCtx) {
+	capabilities := []components.Capabilities{}
+	if a.capabilitiesFn != nil {
+		capabilities = a.capabilitiesFn()
+	}
+
+	b, err := a.json.Marshal(capabilities)
+	if err != nil {
+		msg := NewErrorResponse("ERR_CAPABILITIES_GET", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// onGetOpenAPI returns the OpenAPI document of the endpoints of the enabled building blocks
+func (a *api) onGetOpenAPI(reqCtx *fasthttp.RequestCtx) {
+	endpoints := []Endpoint{}
+	for _, e := range a.endpoints {
+		if a.buildingBlockEnabled(buildingBlock(e.Route)) {
+			endpoints = append(endpoints, e)
+		}
+	}
+
+	// encoding/json sorts the paths, so that the document is stable
+	b, err := json.Marshal(newOpenAPIDocument(endpoints))
+	if err != nil {
+		msg := NewErrorResponse("ERR_OPENAPI_GET", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// onGetConfigDump returns the sanitized effective configuration of the sidecar as a downloadable JSON file
+func (a *api) onGetConfigDump(reqCtx *fasthttp.RequestCtx) {
+	if a.configDumpFn == nil {
+		msg := NewErrorResponse("ERR_CONFIG_DUMP", "config dump is not available")
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+
+	b, err := a.json.Marshal(a.configDumpFn())
+	if err != nil {
+		msg := NewErrorResponse("ERR_CONFIG_DUMP", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	reqCtx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"dapr-config-%s.json\"", a.id))
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// onGetInvocationPayloads reports the peer apps with the most bytes of service invocation payloads, heaviest first
+func (a *api) onGetInvocationPayloads(reqCtx *fasthttp.RequestCtx) {
+	top := defaultInvocationPayloadsTop
+	if t := string(reqCtx.QueryArgs().Peek(topParam)); t != "" {
+		var err error
+		if top, err = strconv.Atoi(t); err != nil || top <= 0 {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("invalid top: %s", t))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+	}
+
+	b, err := a.json.Marshal(diag.DefaultInvocationPayloads.Top(top))
+	if err != nil {
+		msg := NewErrorResponse("ERR_INVOCATION_PAYLOADS", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onGetStateQueue(reqCtx *fasthttp.RequestCtx) {
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	writeBehind, ok := state_loader.WriteBehind(store)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_QUEUE_NOT_ENABLED", fmt.Sprintf("write-behind is not enabled for state store %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	status, err := writeBehind.WriteBehindStatus()
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_QUEUE", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(status)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onGetStateMirror(reqCtx *fasthttp.RequestCtx) {
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	mirror, ok := state_loader.Mirror(store)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_MIRROR_NOT_ENABLED", fmt.Sprintf("mirroring is not enabled for state store %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	report, err := mirror.MirrorReport()
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_MIRROR", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(report)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onGetPausedTopics(reqCtx *fasthttp.RequestCtx) {
+	a.respondWithPausedTopics(reqCtx)
+}
+
+// onPauseTopic pauses the delivery of the events of a topic to the app, which stay in the broker until it's resumed
+func (a *api) onPauseTopic(reqCtx *fasthttp.RequestCtx) {
+	if a.pauser == nil {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", "no pub sub is configured")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	topic := reqCtx.UserValue(topicParam).(string)
+	if a.pauser.Pause(topic) {
+		log.Infof("paused the delivery of topic %s", topic)
+	}
+	a.respondWithPausedTopics(reqCtx)
+}
+
+func (a *api) onResumeTopic(reqCtx *fasthttp.RequestCtx) {
+	if a.pauser == nil {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", "no pub sub is configured")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	topic := reqCtx.UserValue(topicParam).(string)
+	if a.pauser.Resume(topic) {
+		log.Infof("resumed the delivery of topic %s", topic)
+	}
+	a.respondWithPausedTopics(reqCtx)
+}
+
+// onReplayTopic rewinds the subscription of the app to a topic to an offset or a timestamp
+func (a *api) onReplayTopic(reqCtx *fasthttp.RequestCtx) {
+	if a.replayFn == nil {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", "no pub sub is configured")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	var req ReplayRequest
+	if err := a.json.Unmarshal(reqCtx.PostBody(), &req); err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	if req.Offset == "" && req.Timestamp.IsZero() {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "offset or timestamp is required")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	hintWindow := pubsub_loader.DefaultDeduplicationWindow
+	if req.HintWindow != "" {
+		d, err := time.ParseDuration(req.HintWindow)
+		if err != nil {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("invalid hint window %s", req.HintWindow))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+		hintWindow = d
+	}
+
+	id, err := a.replayFn(pubsub_loader.SeekRequest{
+		Topic:     reqCtx.UserValue(topicParam).(string),
+		Offset:    req.Offset,
+		Timestamp: req.Timestamp,
+	}, hintWindow)
+	if err == pubsub_loader.ErrSeekNotSupported {
+		msg := NewErrorResponse("ERR_PUBSUB_REPLAY_NOT_SUPPORTED", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_PUBSUB_REPLAY", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(replayResponse{ReplayID: id})
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) respondWithPausedTopics(reqCtx *fasthttp.RequestCtx) {
+	resp := pausedTopicsResponse{Paused: []string{}}
+	if a.pauser != nil {
+		resp.Paused = a.pauser.Paused()
+	}
+	b, _ := a.json.Marshal(resp)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onPostStateExport(reqCtx *fasthttp.RequestCtx) {
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	var req StateExportRequest
+	err := a.json.Unmarshal(reqCtx.PostBody(), &req)
+	if err != nil || req.Binding == "" || req.PageSize < 0 || req.MaxPages < 0 || req.Page < 0 {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "export requires an output binding and non-negative page options")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	if req.PageSize == 0 {
+		req.PageSize = defaultStateExportPageSize
+	}
+
+	resp := stateExportResponse{Cursor: req.Cursor, Page: req.Page}
+	for pages := 0; req.MaxPages == 0 || pages < req.MaxPages; pages++ {
+		data, n, next, err := state_loader.ExportPage(store, a.id, resp.Cursor, req.PageSize)
+		if err == state_loader.ErrListKeysNotSupported {
+			msg := NewErrorResponse("ERR_STATE_EXPORT_NOT_SUPPORTED", fmt.Sprintf("state store %s doesn't list its keys", storeName))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+		if err == nil && len(data) > 0 {
+			metadata := map[string]string{}
+			for k, v := range req.Metadata {
+				metadata[k] = strings.ReplaceAll(v, "{page}", strconv.Itoa(resp.Page))
+			}
+			err = a.sendToOutputBindingFn(req.Binding, &bindings.WriteRequest{Data: data, Metadata: metadata})
+		}
+		if err != nil {
+			// the cursor and page of the failed page resume the export
+			msg := NewErrorResponse("ERR_STATE_EXPORT", fmt.Sprintf("failed to export page %d at cursor %q after %d exported keys: %s", resp.Page, resp.Cursor, resp.Exported, err))
+			respondWithError(reqCtx, 500, msg)
+			return
+		}
+
+		resp.Exported += n
+		resp.Cursor = next
+		if len(data) > 0 {
+			resp.Page++
+		}
+		if next == "" {
+			break
+		}
+	}
+
+	b, _ := a.json.Marshal(resp)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// onPostProfile captures a profile of the sidecar and writes it to an output binding, for clusters where the profiling
+// port can't be reached to pull it
+func (a *api) onPostProfile(reqCtx *fasthttp.RequestCtx) {
+	var req ProfileRequest
+	err := a.json.Unmarshal(reqCtx.PostBody(), &req)
+	if err != nil || req.Binding == "" || (req.Type != cpuProfile && req.Type != heapProfile) {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "profile requires an output binding and a cpu or heap type")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	duration := defaultCPUProfileDuration
+	if req.Duration != "" {
+		duration, err = time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 || duration > maxCPUProfileDuration {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("invalid duration %s, the maximum is %s", req.Duration, maxCPUProfileDuration))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+	}
+
+	timestamp := time.Now().UTC()
+	data, err := captureProfile(req.Type, duration)
+	if err == errProfileInProgress {
+		msg := NewErrorResponse("ERR_PROFILE_IN_PROGRESS", err.Error())
+		respondWithError(reqCtx, 409, msg)
+		return
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_PROFILE", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+
+	replacer := strings.NewReplacer("{appId}", a.id, "{type}", req.Type, "{timestamp}", timestamp.Format("20060102T150405Z"))
+	metadata := map[string]string{}
+	for k, v := range req.Metadata {
+		metadata[k] = replacer.Replace(v)
+	}
+	err = a.sendToOutputBindingFn(req.Binding, &bindings.WriteRequest{Data: data, Metadata: metadata})
+	if err != nil {
+		msg := NewErrorResponse("ERR_PROFILE_EXPORT", fmt.Sprintf("failed to write the %s profile to binding %s: %s", req.Type, req.Binding, err))
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	log.Infof("wrote a %s profile of %d bytes to binding %s", req.Type, len(data), req.Binding)
+
+	b, _ := a.json.Marshal(profileResponse{Type: req.Type, Size: len(data), Timestamp: timestamp})
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onPostStateImport(reqCtx *fasthttp.RequestCtx) {
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	n, err := state_loader.Import(store, a.id, bytes.NewReader(reqCtx.PostBody()))
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_IMPORT", fmt.Sprintf("%s, %d imported keys", err, n))
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(stateImportResponse{Imported: n})
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onNextID(reqCtx *fasthttp.RequestCtx) {
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+	store, ok := a.stateStore(storeName)
+	if !ok || store == nil {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	if _, native := state_loader.Unwrap(store).(sequencer.Incrementer); !native &&
+		(a.capabilitiesFn == nil || !components.HasFeature(a.capabilitiesFn(), storeName, components.FeatureInsert)) {
+		msg := NewErrorResponse("ERR_SEQUENCE_NOT_SUPPORTED", fmt.Sprintf("state store %s can't create sequences only if they don't exist", storeName))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	key := reqCtx.UserValue(stateKeyParam).(string)
+	id, err := sequencer.NextID(store, a.getModifiedStateKey(key))
+	if err != nil {
+		msg := NewErrorResponse("ERR_SEQUENCE_NEXT", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(nextIDResponse{ID: id})
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onGenerateID(reqCtx *fasthttp.RequestCtx) {
+	kind := reqCtx.UserValue(idKindParam).(string)
+	id, err := sequencer.GenerateID(kind)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ID_KIND_NOT_SUPPORTED", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	b, _ := a.json.Marshal(generatedIDResponse{ID: id})
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onStartSaga(reqCtx *fasthttp.RequestCtx) {
+	if a.sagas == nil {
+		msg := NewErrorResponse("ERR_SAGAS_NOT_CONFIGURED", "sagas require an actor state store")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	var s saga.Saga
+	if err := a.json.Unmarshal(reqCtx.PostBody(), &s); err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	err := a.sagas.Start(&s)
+	if err == saga.ErrSagaExists {
+		msg := NewErrorResponse("ERR_SAGA_EXISTS", fmt.Sprintf("saga id: %s", s.ID))
+		respondWithError(reqCtx, 409, msg)
+		return
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_SAGA_START", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, _ := a.json.Marshal(startSagaResponse{ID: s.ID})
+	respondWithJSON(reqCtx, 202, b)
+}
+
+func (a *api) onGetSaga(reqCtx *fasthttp.RequestCtx) {
+	if a.sagas == nil {
+		msg := NewErrorResponse("ERR_SAGAS_NOT_CONFIGURED", "sagas require an actor state store")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	id := reqCtx.UserValue(idParam).(string)
+	s, err := a.sagas.Get(id)
+	if err != nil {
+		msg := NewErrorResponse("ERR_SAGA_GET", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	if s == nil {
+		respondEmpty(reqCtx, 204)
+		return
+	}
+	b, _ := a.json.Marshal(s)
+	respondWithJSON(reqCtx, 200, b)
+}
+
 func (a *api) onPutMetadata(reqCtx *fasthttp.RequestCtx) {
 	key := fmt.Sprintf("%v", reqCtx.UserValue("key"))
 	body := reqCtx.PostBody()
@@ -1002,7 +2069,7 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 	// TODO : Remove passing corID in NewCloudEventsEnvelope through arguments as it can be passed through context
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	corID := sc.TraceID.String()
-	envelope := pubsub.NewCloudEventsEnvelope(uuid.New().String(), a.id, pubsub.DefaultCloudEventType, corID, body)
+	envelope := pubsub_loader.NewCloudEventsEnvelope(a.json, uuid.New().String(), a.id, pubsub.DefaultCloudEventType, corID, body)
 
 	b, err := a.json.Marshal(envelope)
 	if err != nil {
@@ -1020,12 +2087,17 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 	spanName := fmt.Sprintf("PublishEvent: %s", topic)
 	ctx := diag.NewContext((context.Context)(reqCtx), sc)
 	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
-	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request, a.tracingSpec)
 	defer span.End()
 
 	err = a.publishFn(&req)
 	if err != nil {
 		msg := NewErrorResponse("ERR_PUBSUB_PUBLISH_MESSAGE", err.Error())
+		if fanOutErr, ok := err.(*pubsub_loader.FanOutError); ok {
+			for _, s := range fanOutErr.Statuses {
+				msg.Details = append(msg.Details, s.String())
+			}
+		}
 		respondWithError(reqCtx, 500, msg)
 	} else {
 		respondEmpty(reqCtx, 200)
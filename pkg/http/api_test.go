@@ -16,28 +16,42 @@ import (
 	"io/ioutil"
 	"net"
 	gohttp "net/http"
+	"os"
+	"runtime/pprof"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/components-contrib/exporters"
 	"github.com/dapr/components-contrib/exporters/stringexporter"
 	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/actors"
+	"github.com/dapr/dapr/pkg/components"
 	http_middleware_loader "github.com/dapr/dapr/pkg/components/middleware/http"
+	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	state_inmemory "github.com/dapr/dapr/pkg/components/state/inmemory"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/messaging"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	v1 "github.com/dapr/dapr/pkg/messaging/v1"
 	http_middleware "github.com/dapr/dapr/pkg/middleware/http"
+	"github.com/dapr/dapr/pkg/saga"
 	daprt "github.com/dapr/dapr/pkg/testing"
 	routing "github.com/fasthttp/router"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/fasthttputil"
 )
@@ -232,6 +246,30 @@ func TestV1DirectMessagingEndpoints(t *testing.T) {
 		assert.Equal(t, 200, resp.StatusCode)
 	})
 
+	t.Run("Get fire-and-forget invocation status - 200 OK", func(t *testing.T) {
+		mockDirectMessaging.On("DeliveryStatus", "fakeMessageID").Return(messaging.DeliveryStatus{
+			MessageID: "fakeMessageID",
+			Status:    messaging.DeliveryDelivered,
+			Attempts:  1,
+		}, true).Once()
+
+		resp := fakeServer.DoRequest("GET", "v1.0/invocations/fakeMessageID", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		var status messaging.DeliveryStatus
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &status))
+		assert.Equal(t, messaging.DeliveryDelivered, status.Status)
+	})
+
+	t.Run("Get unknown invocation status - 404 ERR_INVOCATION_NOT_FOUND", func(t *testing.T) {
+		mockDirectMessaging.On("DeliveryStatus", "unknown").Return(messaging.DeliveryStatus{}, false).Once()
+
+		resp := fakeServer.DoRequest("GET", "v1.0/invocations/unknown", nil, nil)
+
+		assert.Equal(t, 404, resp.StatusCode)
+		assert.Equal(t, "ERR_INVOCATION_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
 	fakeServer.Shutdown()
 }
 
@@ -528,6 +566,34 @@ func TestV1ActorEndpoints(t *testing.T) {
 		mockActors.AssertNumberOfCalls(t, "GetState", 1)
 	})
 
+	t.Run("Get actor state with a stale-read budget - 200 OK", func(t *testing.T) {
+		apiPath := "v1.0/actors/fakeActorType/fakeActorID/state/key1?consistency=eventual&maxStaleness=500ms"
+		mockActors := new(daprt.MockActors)
+		mockActors.On("GetState", &actors.GetStateRequest{
+			ActorID:      "fakeActorID",
+			ActorType:    "fakeActorType",
+			Key:          "key1",
+			Consistency:  "eventual",
+			MaxStaleness: 500 * time.Millisecond,
+		}).Return(&actors.StateResponse{
+			Data: fakeData,
+		}, nil)
+
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		mockActors.AssertNumberOfCalls(t, "GetState", 1)
+
+		resp = fakeServer.DoRequest("GET", "v1.0/actors/fakeActorType/fakeActorID/state/key1?maxStaleness=soon", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+		mockActors.AssertNumberOfCalls(t, "GetState", 1)
+	})
+
 	t.Run("Delete actor state - 200 OK", func(t *testing.T) {
 		apiPath := "v1.0/actors/fakeActorType/fakeActorID/state/key1"
 		mockActors := new(daprt.MockActors)
@@ -599,14 +665,109 @@ func TestV1ActorEndpoints(t *testing.T) {
 	fakeServer.Shutdown()
 }
 
-func TestV1MetadataEndpoint(t *testing.T) {
+func TestV1ActorTypesEndpoints(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
+	mockActors := new(daprt.MockActors)
+	testAPI := &api{
+		actor: mockActors,
+		json:  jsoniter.ConfigFastest,
+	}
+
+	fakeServer.StartServer(testAPI.constructActorEndpoints())
+
+	t.Run("Get actor types - 200 OK", func(t *testing.T) {
+		mockActors.On("GetHostedActorTypes").Return([]string{"cat", "dog"}).Once()
+		resp := fakeServer.DoRequest("GET", "v1.0/actortypes", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `["cat","dog"]`, string(resp.RawBody))
+	})
+
+	t.Run("Register actor type - 200 OK", func(t *testing.T) {
+		mockActors.On("RegisterActorType", "cat").Return(nil).Once()
+		resp := fakeServer.DoRequest("PUT", "v1.0/actortypes/cat", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		mockActors.AssertCalled(t, "RegisterActorType", "cat")
+	})
 
+	t.Run("Unregister unknown actor type - 400", func(t *testing.T) {
+		mockActors.On("UnregisterActorType", "bird").Return(errors.New("actor type bird is not registered")).Once()
+		resp := fakeServer.DoRequest("DELETE", "v1.0/actortypes/bird", nil, nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_ACTOR_TYPE_UNREGISTER", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Actor runtime is not initialized - 400", func(t *testing.T) {
+		testAPI.actor = nil
+		resp := fakeServer.DoRequest("PUT", "v1.0/actortypes/cat", nil, nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_ACTOR_RUNTIME_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1ActorSchedulesEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	mockActors := new(daprt.MockActors)
 	testAPI := &api{
-		actor: nil,
+		actor: mockActors,
 		json:  jsoniter.ConfigFastest,
 	}
 
+	fakeServer.StartServer(testAPI.constructActorEndpoints())
+
+	t.Run("List reminders of an actor - 200 OK", func(t *testing.T) {
+		req := &actors.ListRemindersRequest{ActorType: "cat", ActorID: "1"}
+		mockActors.On("ListReminders", req).Return(&actors.ListRemindersResponse{
+			Reminders: []actors.ActorReminder{{Reminder: actors.Reminder{Name: "feed"}, NextFireTime: "2020-06-01T00:00:00Z"}},
+		}, nil).Once()
+		resp := fakeServer.DoRequest("GET", "v1.0/actors/cat/1/reminders", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, string(resp.RawBody), `"nextFireTime":"2020-06-01T00:00:00Z"`)
+	})
+
+	t.Run("Page through reminders of an actor type - 200 OK", func(t *testing.T) {
+		req := &actors.ListRemindersRequest{ActorType: "cat", ContinuationToken: "10", Limit: 10}
+		mockActors.On("ListReminders", req).Return(&actors.ListRemindersResponse{ContinuationToken: "20"}, nil).Once()
+		resp := fakeServer.DoRequest("GET", "v1.0/actortypes/cat/reminders?limit=10&continuationToken=10", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, string(resp.RawBody), `"continuationToken":"20"`)
+	})
+
+	t.Run("Invalid limit - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/actortypes/cat/reminders?limit=0", nil, nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("List timers of an actor - 200 OK", func(t *testing.T) {
+		req := &actors.ListTimersRequest{ActorType: "cat", ActorID: "1"}
+		mockActors.On("ListTimers", req).Return([]actors.ActorTimer{{Name: "purr", Period: "1s"}}, nil).Once()
+		resp := fakeServer.DoRequest("GET", "v1.0/actors/cat/1/timers", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, string(resp.RawBody), `"name":"purr"`)
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1MetadataEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	testAPI := &api{
+		actor:            nil,
+		json:             jsoniter.ConfigFastest,
+		extendedMetadata: &sync.Map{},
+	}
+
 	fakeServer.StartServer(testAPI.constructMetadataEndpoints())
 
 	expectedBody := map[string]interface{}{
@@ -635,6 +796,657 @@ func TestV1MetadataEndpoint(t *testing.T) {
 	fakeServer.Shutdown()
 }
 
+func TestV1ConfigDumpEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	testAPI := &api{
+		id:   "xyz",
+		json: jsoniter.ConfigFastest,
+		configDumpFn: func() interface{} {
+			return struct {
+				Mode string `json:"mode"`
+			}{Mode: "standalone"}
+		},
+	}
+
+	fakeServer.StartServer(testAPI.constructAdminEndpoints())
+
+	t.Run("Config dump - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/config", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"mode":"standalone"}`, string(resp.RawBody))
+	})
+
+	t.Run("Config dump not available - 500", func(t *testing.T) {
+		testAPI.configDumpFn = nil
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/config", nil, nil)
+
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_CONFIG_DUMP", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1InvocationPayloadsEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructAdminEndpoints())
+
+	diag.DefaultInvocationPayloads.Record(diag.InvocationOutbound, "heavy", 1<<40, 0)
+
+	t.Run("Heaviest peers - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/invocation/payloads?top=1", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		var peers []diag.PeerPayloads
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &peers))
+		assert.Len(t, peers, 1)
+		assert.Equal(t, "heavy", peers[0].AppID)
+		assert.Equal(t, diag.InvocationOutbound, peers[0].Direction)
+	})
+
+	t.Run("Invalid top - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/invocation/payloads?top=zero", nil, nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1CapabilitiesEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{
+				{Name: "statestore", Type: "state.redis", Features: []string{components.FeatureETag, components.FeatureTransactional}},
+			}
+		},
+	}
+
+	fakeServer.StartServer(testAPI.constructMetadataEndpoints())
+
+	t.Run("Get capabilities - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/capabilities", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `[{"name":"statestore","type":"state.redis","features":["etag","transactional"]}]`, string(resp.RawBody))
+	})
+
+	t.Run("No capabilities - 200 OK", func(t *testing.T) {
+		testAPI.capabilitiesFn = nil
+		resp := fakeServer.DoRequest("GET", "v1.0/capabilities", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `[]`, string(resp.RawBody))
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1OpenAPIEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	testAPI := NewAPI("xyz", nil, nil, map[string]state.Store{"store": fakeStateStore{}}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, config.JSONSpec{}, config.TracingSpec{}).(*api)
+	fakeServer.StartServer(testAPI.constructMetadataEndpoints())
+
+	t.Run("Get OpenAPI document - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/openapi.json", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var doc openAPIDocument
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &doc))
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+		assert.Contains(t, doc.Paths, "/v1.0/state/{storeName}/{key}")
+		assert.Contains(t, doc.Paths["/v1.0/state/{storeName}/{key}"], "get")
+		assert.Contains(t, doc.Paths["/v1.0/state/{storeName}/{key}"], "delete")
+		assert.Contains(t, doc.Paths, "/v1.0/openapi.json")
+		// disabled building blocks aren't documented
+		assert.NotContains(t, doc.Paths, "/v1.0/actors/{actorType}/{actorId}/state")
+		assert.NotContains(t, doc.Paths, "/v1.0/secrets/{secretStoreName}/{key}")
+		assert.NotContains(t, doc.Paths, "/v1.0/publish/{topic}")
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1PubSubPauseEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	testAPI := &api{
+		json:   jsoniter.ConfigFastest,
+		pauser: pubsub_loader.NewPauser(),
+	}
+	fakeServer.StartServer(testAPI.constructAdminEndpoints())
+
+	t.Run("Pause topic - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/pubsub/topics/orders/pause", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"paused":["orders"]}`, string(resp.RawBody))
+
+		resp = fakeServer.DoRequest("GET", "v1.0/admin/pubsub/topics", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"paused":["orders"]}`, string(resp.RawBody))
+	})
+
+	t.Run("Resume topic - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/pubsub/topics/orders/resume", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"paused":[]}`, string(resp.RawBody))
+	})
+
+	t.Run("No pub sub - 400", func(t *testing.T) {
+		testAPI.pauser = nil
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/pubsub/topics/orders/pause", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_PUBSUB_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1PubSubReplayEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	var seek pubsub_loader.SeekRequest
+	var window time.Duration
+	var replayErr error
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+		replayFn: func(req pubsub_loader.SeekRequest, hintWindow time.Duration) (string, error) {
+			seek = req
+			window = hintWindow
+			return "replay", replayErr
+		},
+	}
+	fakeServer.StartServer(testAPI.constructAdminEndpoints())
+	apiPath := "v1.0/admin/pubsub/topics/orders/replay"
+
+	t.Run("Replay from offset - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"offset": "42"}`), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"replayId":"replay"}`, string(resp.RawBody))
+		assert.Equal(t, pubsub_loader.SeekRequest{Topic: "orders", Offset: "42"}, seek)
+		assert.Equal(t, pubsub_loader.DefaultDeduplicationWindow, window)
+	})
+
+	t.Run("Replay from timestamp - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"timestamp": "2020-06-01T10:00:00Z", "hintWindow": "1h"}`), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC), seek.Timestamp.UTC())
+		assert.Equal(t, time.Hour, window)
+	})
+
+	t.Run("No position - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{}`), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Seek not supported - 400", func(t *testing.T) {
+		replayErr = pubsub_loader.ErrSeekNotSupported
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"offset": "42"}`), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_PUBSUB_REPLAY_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1StateQueueEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	dir, err := ioutil.TempDir("", "statequeue")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	queued, err := state_loader.WithWriteBehind(fakeStateStore{}, map[string]string{state_loader.WriteBehindMetadataKey: "true"}, dir)
+	assert.NoError(t, err)
+
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+		stateStores: map[string]state.Store{
+			"queued": queued,
+			"store":  fakeStateStore{},
+		},
+	}
+
+	fakeServer.StartServer(testAPI.constructAdminEndpoints())
+
+	t.Run("Queue status - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/state/queued/queue", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"pending":0}`, string(resp.RawBody))
+	})
+
+	t.Run("Write-behind not enabled - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/state/store/queue", nil, nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_QUEUE_NOT_ENABLED", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("State store not found - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/state/missing/queue", nil, nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_STORE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1StateMirrorEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	stateStores := map[string]state.Store{
+		"backup": fakeStateStore{},
+		"store":  fakeStateStore{},
+	}
+	mirrored, err := state_loader.WithMirroring(fakeStateStore{}, map[string]string{state_loader.MirrorMetadataKey: "backup"}, "mirrored", func(name string) (state.Store, bool) {
+		s, ok := stateStores[name]
+		return s, ok
+	})
+	assert.NoError(t, err)
+	stateStores["mirrored"] = mirrored
+
+	testAPI := &api{
+		json:        jsoniter.ConfigFastest,
+		stateStores: stateStores,
+	}
+
+	fakeServer.StartServer(testAPI.constructAdminEndpoints())
+
+	t.Run("Mirror report - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/state/mirrored/mirror", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		var report state_loader.MirrorReport
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &report))
+		assert.Equal(t, "backup", report.Secondary)
+		assert.Empty(t, report.DivergentKeys)
+	})
+
+	t.Run("Mirroring not enabled - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/admin/state/store/mirror", nil, nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_MIRROR_NOT_ENABLED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1StateVersionEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	versioned, err := state_loader.WithVersioning(state_inmemory.NewStateStore(), map[string]string{
+		state_loader.KeyVersionsMetadataKey: "1",
+		state_loader.SoftDeleteMetadataKey:  "true",
+	})
+	assert.NoError(t, err)
+	testAPI := &api{
+		id:   "fakeAPI",
+		json: jsoniter.ConfigFastest,
+		stateStores: map[string]state.Store{
+			"versioned": versioned,
+			"store":     fakeStateStore{},
+		},
+	}
+
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+
+	for _, v := range []string{`"v1"`, `"v2"`} {
+		resp := fakeServer.DoRequest("POST", "v1.0/state/versioned", []byte(`[{"key": "order", "value": `+v+`}]`), nil)
+		assert.Equal(t, 201, resp.StatusCode)
+	}
+
+	t.Run("Get version - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/state/versioned/order?version=1", nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `"v1"`, string(resp.RawBody))
+	})
+
+	t.Run("Restore soft deleted key - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/state/versioned/order/restore", nil, nil)
+		assert.Equal(t, 409, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_NOT_DELETED", resp.ErrorBody["errorCode"])
+
+		resp = fakeServer.DoRequest("DELETE", "v1.0/state/versioned/order", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		resp = fakeServer.DoRequest("GET", "v1.0/state/versioned/order/versions", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		var versions state_loader.KeyVersions
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &versions))
+		assert.Equal(t, state_loader.KeyVersions{Latest: 2, Versions: []int{1, 2}, Deleted: true}, versions)
+
+		resp = fakeServer.DoRequest("POST", "v1.0/state/versioned/order/restore", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		resp = fakeServer.DoRequest("GET", "v1.0/state/versioned/order", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `"v2"`, string(resp.RawBody))
+	})
+
+	t.Run("Versioning not enabled - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/state/store/order/versions", nil, nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_VERSIONING_NOT_ENABLED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+// exportStore lists the keys of a sequence store in order, its cursor being the index of the next key
+type exportStore struct {
+	*sequenceStore
+}
+
+func (s exportStore) ListKeys(prefix, cursor string, count int) ([]string, string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := []string{}
+	for k := range s.values {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	start, _ := strconv.Atoi(cursor)
+	end := start + count
+	if end >= len(keys) {
+		return keys[start:], "", nil
+	}
+	return keys[start:end], strconv.Itoa(end), nil
+}
+
+func (s exportStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		if err := s.Set(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestV1StateExportImportEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	store := exportStore{&sequenceStore{values: map[string][]byte{
+		"app||a": []byte("1"),
+		"app||b": []byte("2"),
+		"app||c": []byte("3"),
+	}}}
+	written := []*bindings.WriteRequest{}
+	failing := false
+	testAPI := &api{
+		id:          "app",
+		json:        jsoniter.ConfigFastest,
+		stateStores: map[string]state.Store{"store": store, "plain": fakeStateStore{}},
+		sendToOutputBindingFn: func(name string, req *bindings.WriteRequest) error {
+			if failing {
+				return errors.New("binding is unreachable")
+			}
+			written = append(written, req)
+			return nil
+		},
+	}
+
+	fakeServer.StartServer(testAPI.constructAdminEndpoints())
+
+	t.Run("Export resumes from the cursor - 200 OK", func(t *testing.T) {
+		body := []byte(`{"binding":"backup","metadata":{"key":"state-{page}.ndjson"},"pageSize":2,"maxPages":1}`)
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/state/store/export", body, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.JSONEq(t, `{"exported":2,"cursor":"2","page":1}`, string(resp.RawBody))
+
+		body = []byte(`{"binding":"backup","metadata":{"key":"state-{page}.ndjson"},"pageSize":2,"cursor":"2","page":1}`)
+		resp = fakeServer.DoRequest("POST", "v1.0/admin/state/store/export", body, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.JSONEq(t, `{"exported":1,"cursor":"","page":2}`, string(resp.RawBody))
+		assert.Len(t, written, 2)
+		assert.Equal(t, "state-0.ndjson", written[0].Metadata["key"])
+		assert.Equal(t, "state-1.ndjson", written[1].Metadata["key"])
+	})
+
+	t.Run("Import - 200 OK", func(t *testing.T) {
+		body := append(append([]byte{}, written[0].Data...), written[1].Data...)
+		store.values = map[string][]byte{}
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/state/store/import", body, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.JSONEq(t, `{"imported":3}`, string(resp.RawBody))
+		assert.Equal(t, []byte("3"), store.values["app||c"])
+	})
+
+	t.Run("Failed export returns its cursor - 500", func(t *testing.T) {
+		failing = true
+		defer func() { failing = false }()
+		body := []byte(`{"binding":"backup","pageSize":2,"cursor":"2","page":1}`)
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/state/store/export", body, nil)
+
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_EXPORT", resp.ErrorBody["errorCode"])
+		assert.Contains(t, resp.ErrorBody["message"], `cursor "2"`)
+	})
+
+	t.Run("Store without key listing - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/state/plain/export", []byte(`{"binding":"backup"}`), nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_EXPORT_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Missing binding - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/state/store/export", []byte(`{}`), nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1ProfileEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+
+	written := map[string]*bindings.WriteRequest{}
+	testAPI := &api{
+		id:   "app",
+		json: jsoniter.ConfigFastest,
+		sendToOutputBindingFn: func(name string, req *bindings.WriteRequest) error {
+			if name != "profiles" {
+				return errors.New("binding not found")
+			}
+			written[req.Metadata["key"]] = req
+			return nil
+		},
+	}
+
+	fakeServer.StartServer(testAPI.ProfilingEndpoints())
+
+	t.Run("Heap snapshot - 200 OK", func(t *testing.T) {
+		body := []byte(`{"type":"heap","binding":"profiles","metadata":{"key":"{appId}/{type}.pprof"}}`)
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/profiles", body, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		require.Contains(t, written, "app/heap.pprof")
+		assert.NotEmpty(t, written["app/heap.pprof"].Data)
+	})
+
+	t.Run("Timed CPU profile - 200 OK", func(t *testing.T) {
+		body := []byte(`{"type":"cpu","duration":"100ms","binding":"profiles","metadata":{"key":"{type}-{timestamp}.pprof"}}`)
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/profiles", body, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, string(resp.RawBody), `"type":"cpu"`)
+		assert.Len(t, written, 2)
+	})
+
+	t.Run("CPU profile in progress - 409", func(t *testing.T) {
+		require.NoError(t, pprof.StartCPUProfile(ioutil.Discard))
+		defer pprof.StopCPUProfile()
+		body := []byte(`{"type":"cpu","duration":"100ms","binding":"profiles"}`)
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/profiles", body, nil)
+
+		assert.Equal(t, 409, resp.StatusCode)
+		assert.Equal(t, "ERR_PROFILE_IN_PROGRESS", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Failed export - 500", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/admin/profiles", []byte(`{"type":"heap","binding":"other"}`), nil)
+
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_PROFILE_EXPORT", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Invalid requests - 400", func(t *testing.T) {
+		for _, body := range []string{`{"type":"heap"}`, `{"type":"block","binding":"profiles"}`, `{"type":"cpu","duration":"1h","binding":"profiles"}`} {
+			resp := fakeServer.DoRequest("POST", "v1.0/admin/profiles", []byte(body), nil)
+
+			assert.Equal(t, 400, resp.StatusCode, body)
+			assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"], body)
+		}
+	})
+
+	fakeServer.Shutdown()
+}
+
+// sequenceStore keeps the values set in memory
+type sequenceStore struct {
+	fakeStateStore
+	lock   sync.Mutex
+	values map[string][]byte
+}
+
+func (s *sequenceStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return &state.GetResponse{Data: s.values[req.Key]}, nil
+}
+
+func (s *sequenceStore) Set(req *state.SetRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.values[req.Key] = req.Value.([]byte)
+	return nil
+}
+
+func TestV1SequencerEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	store := &sequenceStore{values: map[string][]byte{}}
+	testAPI := &api{
+		id:          "fakeAPI",
+		json:        jsoniter.ConfigFastest,
+		stateStores: map[string]state.Store{"store": store, "cache": store},
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{
+				{Name: "store", Features: []string{components.FeatureInsert}},
+				{Name: "cache", Features: []string{}},
+			}
+		},
+	}
+
+	fakeServer.StartServer(testAPI.constructSequencerEndpoints())
+
+	t.Run("Next id - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/sequences/store/orders", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"id":1}`, string(resp.RawBody))
+
+		resp = fakeServer.DoRequest("POST", "v1.0/sequences/store/orders", nil, nil)
+		assert.Equal(t, `{"id":2}`, string(resp.RawBody))
+		assert.Equal(t, "2", string(store.values["fakeAPI||orders"]))
+	})
+
+	t.Run("State store not found - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/sequences/missing/orders", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_STORE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("State store without the insert feature - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/sequences/cache/orders", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_SEQUENCE_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Generate ulid - 200 OK", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/ids/ulid", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Len(t, resp.JSONBody.(map[string]interface{})["id"], 26)
+	})
+
+	t.Run("Unsupported id kind - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/ids/snowflake", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_ID_KIND_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1SagaEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	invoked := make(chan string, 2)
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+		sagas: saga.NewCoordinator(&sequenceStore{values: map[string][]byte{}}, "fakeAPI", func(method string, data []byte) error {
+			invoked <- method
+			return nil
+		}),
+	}
+
+	fakeServer.StartServer(testAPI.constructSagaEndpoints())
+
+	t.Run("Start saga - 202 Accepted", func(t *testing.T) {
+		body := []byte(`{"id":"order-1","steps":[{"name":"reserve","action":"reserve","compensation":"release"},{"name":"ship","action":"ship"}]}`)
+		resp := fakeServer.DoRequest("POST", "v1.0/sagas", body, nil)
+		assert.Equal(t, 202, resp.StatusCode)
+		assert.Equal(t, `{"id":"order-1"}`, string(resp.RawBody))
+		assert.Equal(t, "reserve", <-invoked)
+		assert.Equal(t, "ship", <-invoked)
+
+		assert.Eventually(t, func() bool {
+			resp := fakeServer.DoRequest("GET", "v1.0/sagas/order-1", nil, nil)
+			return resp.StatusCode == 200 && resp.JSONBody.(map[string]interface{})["status"] == saga.StatusCompleted
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Start existing saga - 409", func(t *testing.T) {
+		body := []byte(`{"id":"order-1","steps":[{"name":"ship","action":"ship"}]}`)
+		resp := fakeServer.DoRequest("POST", "v1.0/sagas", body, nil)
+		assert.Equal(t, 409, resp.StatusCode)
+		assert.Equal(t, "ERR_SAGA_EXISTS", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Get missing saga - 204", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/sagas/order-2", nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+	})
+
+	t.Run("Sagas not configured - 400", func(t *testing.T) {
+		testAPI.sagas = nil
+		resp := fakeServer.DoRequest("GET", "v1.0/sagas/order-1", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_SAGAS_NOT_CONFIGURED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
 func createExporters(meta exporters.Metadata) {
 	exporter := stringexporter.NewStringExporter(logger.NewLogger("fakeLogger"))
 	exporter.Init("fakeID", "fakeAddress", meta)
@@ -1011,7 +1823,7 @@ func buildHTTPPineline(spec config.PipelineSpec) http_middleware.Pipeline {
 	}))
 	var handlers []http_middleware.Middleware
 	for i := 0; i < len(spec.Handlers); i++ {
-		handler, err := registry.Create(spec.Handlers[i].Type, middleware.Metadata{})
+		handler, err := registry.Create(spec.Handlers[i].Type, "", middleware.Metadata{})
 		if err != nil {
 			return http_middleware.Pipeline{}
 		}
@@ -1295,6 +2107,86 @@ func (f *fakeHTTPServer) DoRequest(method, path string, body []byte, params map[
 	return response
 }
 
+func TestV1StateDeleteEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	store := state_inmemory.NewStateStore()
+	features := []string{components.FeatureBulkDelete, components.FeatureDeleteByPrefix}
+	testAPI := &api{
+		id:          "app",
+		json:        jsoniter.ConfigFastest,
+		stateStores: map[string]state.Store{"store": store, "plain": fakeStateStore{}},
+		capabilitiesFn: func() []components.Capabilities {
+			return []components.Capabilities{{Name: "store", Type: "state.in-memory", Features: features}}
+		},
+	}
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+
+	save := func(keys ...string) {
+		for _, k := range keys {
+			assert.NoError(t, store.Set(&state.SetRequest{Key: k, Value: []byte("v")}))
+		}
+	}
+	exists := func(key string) bool {
+		resp, err := store.Get(&state.GetRequest{Key: key})
+		assert.NoError(t, err)
+		return resp.Data != nil
+	}
+
+	t.Run("Bulk delete - 200 OK", func(t *testing.T) {
+		save("app||a", "app||b", "app||c")
+		body := []byte(`[{"key":"a"},{"key":"b"}]`)
+		resp := fakeServer.DoRequest("POST", "v1.0/state/store/delete", body, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.False(t, exists("app||a"))
+		assert.False(t, exists("app||b"))
+		assert.True(t, exists("app||c"))
+	})
+
+	t.Run("Bulk delete with a stale etag - 500", func(t *testing.T) {
+		body := []byte(`[{"key":"c","etag":"stale"}]`)
+		resp := fakeServer.DoRequest("POST", "v1.0/state/store/delete", body, nil)
+
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_BULK_DELETE", resp.ErrorBody["errorCode"])
+		assert.True(t, exists("app||c"))
+	})
+
+	t.Run("Prefix delete resumes from the cursor - 200 OK", func(t *testing.T) {
+		save("app||tenant1||a", "app||tenant1||b", "app||tenant1||c", "app||tenant2||a", "other||tenant1||a")
+		body := []byte(`{"prefix":"tenant1||","pageSize":2,"maxPages":1}`)
+		resp := fakeServer.DoRequest("POST", "v1.0/state/store/delete/prefix", body, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.JSONEq(t, `{"deleted":2,"cursor":"app||tenant1||b"}`, string(resp.RawBody))
+
+		body = []byte(`{"prefix":"tenant1||","pageSize":2,"cursor":"app||tenant1||b"}`)
+		resp = fakeServer.DoRequest("POST", "v1.0/state/store/delete/prefix", body, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.JSONEq(t, `{"deleted":1,"cursor":""}`, string(resp.RawBody))
+		assert.False(t, exists("app||tenant1||c"))
+		assert.True(t, exists("app||tenant2||a"))
+		assert.True(t, exists("other||tenant1||a"))
+	})
+
+	t.Run("Prefix delete without prefix - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/state/store/delete/prefix", []byte(`{}`), nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Prefix delete on a store that didn't opt in - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/state/plain/delete/prefix", []byte(`{"prefix":"tenant1||"}`), nil)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_DELETE_PREFIX_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
 func TestV1StateEndpoints(t *testing.T) {
 	etag := "`~!@#$%^&*()_+-={}[]|\\:\";'<>?,./'"
 	fakeServer := newFakeHTTPServer()
@@ -1571,3 +2463,41 @@ func TestV1HealthzEndpoint(t *testing.T) {
 
 	fakeServer.Shutdown()
 }
+
+func TestV1JSONSpec(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	var published []byte
+	var written []byte
+	testAPI := &api{
+		publishFn: func(req *pubsub.PublishRequest) error {
+			published = req.Data
+			return nil
+		},
+		sendToOutputBindingFn: func(name string, req *bindings.WriteRequest) error {
+			written = req.Data
+			return nil
+		},
+		json: config.NewJSONAPI(config.JSONSpec{PreserveInt64: true, UnknownFields: config.UnknownFieldsReject}),
+	}
+	fakeServer.StartServer(append(testAPI.constructPubSubEndpoints(), testAPI.constructBindingsEndpoints()...))
+
+	t.Run("int64 IDs of published events are preserved", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/publish/orders", apiVersionV1), []byte(`[9007199254740993]`), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, string(published), `"data":[9007199254740993]`)
+	})
+
+	t.Run("int64 IDs of output binding data are preserved", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/bindings/queue", apiVersionV1), []byte(`{"data":[9007199254740993]}`), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `[9007199254740993]`, string(written))
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/bindings/queue", apiVersionV1), []byte(`{"data":"x","operaton":"create"}`), nil)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_INVOKE_OUTPUT_BINDING", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
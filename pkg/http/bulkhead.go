@@ -0,0 +1,42 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"fmt"
+
+	"github.com/dapr/dapr/pkg/bulkhead"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/valyala/fasthttp"
+)
+
+// bulkheadBuildingBlock returns the building block of the bulkhead of a route, empty when it has none
+func bulkheadBuildingBlock(route string) string {
+	switch block := buildingBlock(route); block {
+	case "state":
+		return config.BulkheadState
+	case "pubsub":
+		return config.BulkheadPubSub
+	case "bindings":
+		return config.BulkheadBindings
+	case "invoke":
+		return config.BulkheadInvocation
+	}
+	return ""
+}
+
+// withBulkhead runs the requests of the handler on a worker of the bulkhead, rejecting them when it's full
+func withBulkhead(b *bulkhead.Bulkhead, buildingBlock string, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if err := b.Acquire(ctx); err != nil {
+			msg := NewErrorResponse("ERR_BULKHEAD_FULL", fmt.Sprintf("too many concurrent %s requests: %s", buildingBlock, err))
+			respondWithError(ctx, fasthttp.StatusTooManyRequests, msg)
+			return
+		}
+		defer b.Release()
+		next(ctx)
+	}
+}
@@ -0,0 +1,54 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"testing"
+
+	"github.com/dapr/dapr/pkg/bulkhead"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func TestBulkheadBuildingBlock(t *testing.T) {
+	assert.Equal(t, config.BulkheadState, bulkheadBuildingBlock("state/{storeName}/{key}"))
+	assert.Equal(t, config.BulkheadPubSub, bulkheadBuildingBlock("publish/{topic}"))
+	assert.Equal(t, config.BulkheadBindings, bulkheadBuildingBlock("bindings/{name}"))
+	assert.Equal(t, config.BulkheadInvocation, bulkheadBuildingBlock("invoke/{id}/method/{method:*}"))
+	assert.Equal(t, "", bulkheadBuildingBlock("healthz"))
+}
+
+func TestWithBulkhead(t *testing.T) {
+	b := bulkhead.New(config.BulkheadState, 1, 0, 0)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	h := withBulkhead(b, config.BulkheadState, func(ctx *fasthttp.RequestCtx) {
+		if release != nil {
+			close(started)
+			<-release
+		}
+	})
+
+	busy := make(chan struct{})
+	go func() {
+		h(&fasthttp.RequestCtx{})
+		close(busy)
+	}()
+	<-started
+
+	ctx := &fasthttp.RequestCtx{}
+	h(ctx)
+	assert.Equal(t, fasthttp.StatusTooManyRequests, ctx.Response.StatusCode())
+	assert.Contains(t, string(ctx.Response.Body()), "ERR_BULKHEAD_FULL")
+
+	close(release)
+	<-busy
+	release = nil
+	ctx = &fasthttp.RequestCtx{}
+	h(ctx)
+	assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+}
@@ -5,6 +5,11 @@
 
 package http
 
+import (
+	"github.com/dapr/dapr/pkg/bulkhead"
+	"github.com/dapr/dapr/pkg/memorybudget"
+)
+
 // ServerConfig holds config values for an HTTP server
 type ServerConfig struct {
 	AllowedOrigins  string
@@ -13,6 +18,14 @@ type ServerConfig struct {
 	Port            int
 	ProfilePort     int
 	EnableProfiling bool
+	// ListenAddresses are the addresses to bind to. The server listens on all interfaces when empty.
+	ListenAddresses []string
+	// Pipe is the Windows named pipe the API is also served on, e.g. \\.\pipe\dapr-http
+	Pipe string
+	// Bulkheads isolate the requests of the building blocks, shared with the gRPC API server
+	Bulkheads bulkhead.Bulkheads
+	// MemoryBudget rejects the requests with a large body under memory pressure, shared with the gRPC servers
+	MemoryBudget *memorybudget.Budget
 }
 
 // NewServerConfig returns a new HTTP server config
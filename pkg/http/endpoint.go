@@ -13,4 +13,14 @@ type Endpoint struct {
 	Route   string
 	Version string
 	Handler fasthttp.RequestHandler
+	// Deprecation is set when the endpoint is slated for removal
+	Deprecation *Deprecation
+}
+
+// Deprecation describes the removal of a deprecated endpoint
+type Deprecation struct {
+	// RemovedIn is the Dapr version the endpoint is removed in
+	RemovedIn string
+	// Replacement is the endpoint to use instead, if any
+	Replacement string
 }
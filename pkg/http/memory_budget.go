@@ -0,0 +1,29 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"fmt"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/memorybudget"
+	"github.com/valyala/fasthttp"
+)
+
+const httpServer = "httpServer"
+
+// withMemoryBudget rejects the requests with a large body while the sidecar is under memory pressure
+func withMemoryBudget(b *memorybudget.Budget, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if size := len(ctx.PostBody()); !b.AllowPayload(size) {
+			diag.DefaultMonitoring.MemoryBudgetRejected(httpServer)
+			msg := NewErrorResponse("ERR_MEMORY_PRESSURE", fmt.Sprintf("payload of %d bytes rejected while the sidecar is under memory pressure", size))
+			respondWithError(ctx, fasthttp.StatusServiceUnavailable, msg)
+			return
+		}
+		next(ctx)
+	}
+}
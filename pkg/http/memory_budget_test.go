@@ -0,0 +1,39 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/memorybudget"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestWithMemoryBudget(t *testing.T) {
+	// the sidecar uses more than its budget of 1Ki
+	b, err := memorybudget.New(config.MemoryBudgetSpec{Limit: "1Ki", MaxPayloadSize: "4"})
+	require.NoError(t, err)
+	h := withMemoryBudget(b, func(ctx *fasthttp.RequestCtx) {})
+
+	request := func(body string) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+		ctx.Request.SetBodyString(body)
+		h(ctx)
+		return ctx
+	}
+
+	assert.Equal(t, fasthttp.StatusOK, request("large").Response.StatusCode())
+
+	b.Check()
+	ctx := request("large")
+	assert.Equal(t, fasthttp.StatusServiceUnavailable, ctx.Response.StatusCode())
+	assert.Contains(t, string(ctx.Response.Body()), "ERR_MEMORY_PRESSURE")
+	assert.Equal(t, fasthttp.StatusOK, request("tiny").Response.StatusCode())
+}
@@ -0,0 +1,180 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/version"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+	openAPITitle   = "Dapr sidecar HTTP API"
+)
+
+type openAPIDocument struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    openAPIInfo                            `json:"info"`
+	Tags    []openAPITag                           `json:"tags"`
+	Paths   map[string]map[string]openAPIOperation `json:"paths"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPITag struct {
+	Name string `json:"name"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Tags        []string                   `json:"tags"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name     string        `json:"name"`
+	In       string        `json:"in"`
+	Required bool          `json:"required"`
+	Schema   openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Type string `json:"type"`
+}
+
+type openAPIResponse struct {
+	Description string `json:"description"`
+}
+
+// newOpenAPIDocument returns the OpenAPI document of the endpoints, tagged with their building block
+func newOpenAPIDocument(endpoints []Endpoint) openAPIDocument {
+	doc := openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:   openAPITitle,
+			Version: version.Version(),
+		},
+		Tags:  []openAPITag{},
+		Paths: map[string]map[string]openAPIOperation{},
+	}
+
+	tagged := map[string]bool{}
+	for _, e := range endpoints {
+		path, params := openAPIPath(e.Route)
+		path = fmt.Sprintf("/%s/%s", e.Version, path)
+		tag := buildingBlock(e.Route)
+		if !tagged[tag] {
+			tagged[tag] = true
+			doc.Tags = append(doc.Tags, openAPITag{Name: tag})
+		}
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = map[string]openAPIOperation{}
+			doc.Paths[path] = item
+		}
+		for _, m := range e.Methods {
+			item[strings.ToLower(m)] = openAPIOperation{
+				OperationID: operationID(m, e.Version, e.Route),
+				Tags:        []string{tag},
+				Parameters:  params,
+				Responses: map[string]openAPIResponse{
+					"default": {Description: "Dapr API response"},
+				},
+				Deprecated: e.Deprecation != nil,
+			}
+		}
+	}
+	return doc
+}
+
+// openAPIPath returns the OpenAPI path template of a route and its path parameters.
+// Catch-all parameters such as {method:*} become plain parameters.
+func openAPIPath(route string) (string, []openAPIParameter) {
+	segments := strings.Split(route, "/")
+	params := []openAPIParameter{}
+	for i, s := range segments {
+		if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.Trim(s, "{}"), ":*")
+		segments[i] = "{" + name + "}"
+		params = append(params, openAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   openAPISchema{Type: "string"},
+		})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID returns a unique operation ID of a route method, e.g. getStateStoreNameKey
+func operationID(method, version, route string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	path, _ := openAPIPath(route)
+	for _, s := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '.' || r == '-'
+	}) {
+		b.WriteString(strings.ToUpper(s[:1]) + s[1:])
+	}
+	if version != apiVersionV1 {
+		b.WriteString(strings.ToUpper(strings.NewReplacer(".", "", "-", "").Replace(version)))
+	}
+	return b.String()
+}
+
+// buildingBlock returns the building block of a route
+func buildingBlock(route string) string {
+	switch {
+	case strings.HasPrefix(route, "state/"), strings.HasPrefix(route, "admin/state/"), strings.HasPrefix(route, "sequences/"):
+		return "state"
+	case strings.HasPrefix(route, "secrets/"):
+		return "secrets"
+	case strings.HasPrefix(route, "publish/"), strings.HasPrefix(route, "admin/pubsub/"):
+		return "pubsub"
+	case strings.HasPrefix(route, "bindings/"):
+		return "bindings"
+	case strings.HasPrefix(route, "invoke/"), strings.HasPrefix(route, "invocations/"), strings.HasPrefix(route, "admin/invocation/"):
+		return "invoke"
+	case strings.HasPrefix(route, "actors/"), route == "actortypes", strings.HasPrefix(route, "actortypes/"):
+		return "actors"
+	case route == "sagas", strings.HasPrefix(route, "sagas/"):
+		return "sagas"
+	default:
+		return strings.SplitN(route, "/", 2)[0]
+	}
+}
+
+// buildingBlockEnabled returns whether the building block is enabled in the sidecar
+func (a *api) buildingBlockEnabled(block string) bool {
+	switch block {
+	case "state":
+		return a.hasStateStores()
+	case "secrets":
+		return a.hasSecretStores()
+	case "pubsub":
+		return a.publishFn != nil
+	case "bindings":
+		return a.sendToOutputBindingFn != nil
+	case "invoke":
+		return a.directMessaging != nil
+	case "actors":
+		return a.actor != nil
+	case "sagas":
+		return a.sagas != nil
+	default:
+		return true
+	}
+}
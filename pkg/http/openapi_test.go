@@ -0,0 +1,47 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIPath(t *testing.T) {
+	path, params := openAPIPath("invoke/{id}/method/{method:*}")
+	assert.Equal(t, "invoke/{id}/method/{method}", path)
+	assert.Len(t, params, 2)
+	assert.Equal(t, "id", params[0].Name)
+	assert.Equal(t, "method", params[1].Name)
+	assert.Equal(t, "path", params[1].In)
+	assert.True(t, params[1].Required)
+
+	path, params = openAPIPath("healthz")
+	assert.Equal(t, "healthz", path)
+	assert.Empty(t, params)
+}
+
+func TestOperationID(t *testing.T) {
+	assert.Equal(t, "getStateStoreNameKey", operationID("GET", apiVersionV1, "state/{storeName}/{key}"))
+	assert.Equal(t, "postPublishTopic", operationID("POST", apiVersionV1, "publish/{topic:*}"))
+	assert.Equal(t, "getOpenapiJson", operationID("GET", apiVersionV1, "openapi.json"))
+	assert.Equal(t, "getHealthzV2", operationID("GET", "v2", "healthz"))
+}
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	doc := newOpenAPIDocument([]Endpoint{
+		{Methods: []string{"GET", "DELETE"}, Route: "state/{storeName}/{key}", Version: apiVersionV1},
+		{Methods: []string{"POST"}, Route: "actors/{actorType}/{actorId}/method/{method}", Version: apiVersionV1},
+	})
+
+	assert.Len(t, doc.Paths, 2)
+	item := doc.Paths["/v1.0/state/{storeName}/{key}"]
+	assert.Len(t, item, 2)
+	assert.Equal(t, []string{"state"}, item["get"].Tags)
+	assert.Equal(t, "deleteStateStoreNameKey", item["delete"].OperationID)
+	assert.Equal(t, []openAPITag{{Name: "state"}, {Name: "actors"}}, doc.Tags)
+}
@@ -0,0 +1,47 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"runtime/pprof"
+	"time"
+)
+
+const (
+	cpuProfile  = "cpu"
+	heapProfile = "heap"
+
+	defaultCPUProfileDuration = time.Second * 30
+	maxCPUProfileDuration     = time.Minute * 5
+)
+
+// errProfileInProgress is returned when a CPU profile is requested while another one is running
+var errProfileInProgress = errors.New("a CPU profile is already in progress")
+
+// captureProfile returns a CPU profile of the sidecar over the duration, or a snapshot of its heap after a garbage
+// collection, in the pprof format
+func captureProfile(profileType string, duration time.Duration) ([]byte, error) {
+	var buf bytes.Buffer
+	switch profileType {
+	case cpuProfile:
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return nil, errProfileInProgress
+		}
+		time.Sleep(duration)
+		pprof.StopCPUProfile()
+	case heapProfile:
+		runtime.GC()
+		if err := pprof.Lookup("heap").WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unknown profile type " + profileType)
+	}
+	return buf.Bytes(), nil
+}
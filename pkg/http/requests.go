@@ -5,8 +5,51 @@
 
 package http
 
+import "time"
+
 // OutputBindingRequest is the request object to invoke an output binding
 type OutputBindingRequest struct {
 	Metadata map[string]string `json:"metadata"`
 	Data     interface{}       `json:"data"`
 }
+
+// StateExportRequest is the request object to export the state of the app to an output binding.
+// Each page of keys is written to the binding as newline-delimited JSON, with {page} in the metadata values replaced by
+// the page number, e.g. to write each page to its own object. An interrupted export resumes from its cursor and page.
+type StateExportRequest struct {
+	Binding  string            `json:"binding"`
+	Metadata map[string]string `json:"metadata"`
+	Cursor   string            `json:"cursor"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"pageSize"`
+	// MaxPages limits the number of pages written by the request, all the pages being written if it's 0
+	MaxPages int `json:"maxPages"`
+}
+
+// StateDeletePrefixRequest is the request object to delete the keys of the app starting with a prefix, e.g. the keys of
+// a tenant. An interrupted deletion resumes from its cursor.
+type StateDeletePrefixRequest struct {
+	Prefix   string `json:"prefix"`
+	Cursor   string `json:"cursor"`
+	PageSize int    `json:"pageSize"`
+	// MaxPages limits the number of pages deleted by the request, all the pages being deleted if it's 0
+	MaxPages int `json:"maxPages"`
+}
+
+// ProfileRequest is the request object to capture a CPU profile or a heap snapshot of the sidecar and write it to an
+// output binding. {appId}, {type} and {timestamp} in the metadata values are replaced by the app ID, the profile type
+// and the UTC start time of the capture, e.g. to name the object of each profile.
+type ProfileRequest struct {
+	Type     string            `json:"type"`
+	Duration string            `json:"duration"`
+	Binding  string            `json:"binding"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// ReplayRequest is the request object to replay a topic from an offset or, when the offset is empty, from a timestamp.
+// The events delivered during the hint window carry the id of the replay.
+type ReplayRequest struct {
+	Offset     string    `json:"offset"`
+	Timestamp  time.Time `json:"timestamp"`
+	HintWindow string    `json:"hintWindow"`
+}
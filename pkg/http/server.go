@@ -7,6 +7,8 @@ package http
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	cors "github.com/AdhityaRamadhanus/fasthttpcors"
@@ -15,6 +17,7 @@ import (
 
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	http_middleware "github.com/dapr/dapr/pkg/middleware/http"
+	"github.com/dapr/dapr/pkg/namedpipe"
 	routing "github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/pprofhandler"
@@ -49,24 +52,64 @@ func (s *server) StartNonBlocking() {
 	handler :=
 		s.useProxy(
 			s.useCors(
-				s.useComponents(
-					s.useRouter())))
+				s.useAPIVersion(
+					s.useComponents(
+						s.useRouter()))))
 
+	if s.config.MemoryBudget != nil {
+		handler = withMemoryBudget(s.config.MemoryBudget, handler)
+	}
 	handler = s.useMetrics(handler)
 	handler = s.useTracing(handler)
 
-	go func() {
-		log.Fatal(fasthttp.ListenAndServe(fmt.Sprintf(":%v", s.config.Port), handler))
-	}()
+	s.serve(s.config.Port, handler)
+	if s.config.Pipe != "" {
+		s.servePipe(s.config.Pipe, handler)
+	}
 
 	if s.config.EnableProfiling {
-		go func() {
-			log.Infof("starting profiling server on port %v", s.config.ProfilePort)
-			log.Fatal(fasthttp.ListenAndServe(fmt.Sprintf(":%v", s.config.ProfilePort), pprofhandler.PprofHandler))
-		}()
+		log.Infof("starting profiling server on port %v", s.config.ProfilePort)
+		s.serve(s.config.ProfilePort, s.useProfiling())
 	}
 }
 
+// useProfiling serves the profiling endpoints of the API, and the pprof endpoints on every other path
+func (s *server) useProfiling() fasthttp.RequestHandler {
+	router := s.getRouter(s.api.ProfilingEndpoints())
+	router.NotFound = pprofhandler.PprofHandler
+	return router.Handler
+}
+
+// serve listens on the port of every configured listen address and serves the handler on each of them
+func (s *server) serve(port int, handler fasthttp.RequestHandler) {
+	listenAddresses := s.config.ListenAddresses
+	if len(listenAddresses) == 0 {
+		listenAddresses = []string{""}
+	}
+
+	for _, address := range listenAddresses {
+		go func(address string) {
+			l, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Fatal(fasthttp.Serve(l, handler))
+		}(address)
+	}
+}
+
+// servePipe serves the handler on a Windows named pipe
+func (s *server) servePipe(pipe string, handler fasthttp.RequestHandler) {
+	l, err := namedpipe.Listen(pipe)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Infof("http server is listening on pipe %s", pipe)
+	go func() {
+		log.Fatal(fasthttp.Serve(l, handler))
+	}()
+}
+
 func (s *server) useTracing(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 	log.Infof("enabled tracing http middleware")
 	return diag.SetTracingSpanContextFromHTTPContext(next, s.tracingSpec)
@@ -131,8 +174,14 @@ func (s *server) getRouter(endpoints []Endpoint) *routing.Router {
 
 	for _, e := range endpoints {
 		path := fmt.Sprintf("/%s/%s", e.Version, e.Route)
+		block := bulkheadBuildingBlock(e.Route)
+		b := s.config.Bulkheads.For(block)
 		for _, m := range e.Methods {
-			router.Handle(m, path, e.Handler)
+			handler := withAPIVersion(e, m)
+			if b != nil {
+				handler = withBulkhead(b, block, handler)
+			}
+			router.Handle(m, path, handler)
 		}
 	}
 	return router
@@ -0,0 +1,98 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// APIVersionHeader is the header of the API version of a response, and of the API version requested by a caller.
+	// Requests to paths without a version are routed to the requested version.
+	APIVersionHeader = "dapr-api-version"
+
+	deprecationHeader = "Deprecation"
+	warningHeader     = "Warning"
+)
+
+// useAPIVersion routes the requests to the API version requested in their header
+func (s *server) useAPIVersion(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	versions := map[string]bool{}
+	for _, e := range s.api.APIEndpoints() {
+		versions[e.Version] = true
+	}
+	return apiVersionHandler(next, versions)
+}
+
+func apiVersionHandler(next fasthttp.RequestHandler, versions map[string]bool) fasthttp.RequestHandler {
+	supported := make([]string, 0, len(versions))
+	for v := range versions {
+		supported = append(supported, v)
+	}
+	sort.Strings(supported)
+
+	return func(ctx *fasthttp.RequestCtx) {
+		requested := string(ctx.Request.Header.Peek(APIVersionHeader))
+		if requested == "" {
+			next(ctx)
+			return
+		}
+		if !versions[requested] {
+			msg := NewErrorResponse("ERR_API_VERSION_NOT_SUPPORTED",
+				fmt.Sprintf("api version %s is not supported, supported versions: %s", requested, strings.Join(supported, ", ")))
+			respondWithError(ctx, 400, msg)
+			return
+		}
+
+		path := string(ctx.Path())
+		version := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+		if !versions[version] {
+			ctx.Request.URI().SetPath("/" + requested + path)
+		} else if version != requested {
+			msg := NewErrorResponse("ERR_API_VERSION_MISMATCH",
+				fmt.Sprintf("api version %s was requested for a %s path", requested, version))
+			respondWithError(ctx, 400, msg)
+			return
+		}
+		next(ctx)
+	}
+}
+
+// withAPIVersion stamps the responses of the endpoint with its API version, and warns the callers of a deprecated endpoint
+func withAPIVersion(e Endpoint, method string) fasthttp.RequestHandler {
+	if e.Deprecation == nil {
+		return func(ctx *fasthttp.RequestCtx) {
+			ctx.Response.Header.Set(APIVersionHeader, e.Version)
+			e.Handler(ctx)
+		}
+	}
+
+	api := fmt.Sprintf("%s /%s/%s", method, e.Version, e.Route)
+	warning := fmt.Sprintf("%s is deprecated and will be removed in Dapr %s", api, e.Deprecation.RemovedIn)
+	if e.Deprecation.Replacement != "" {
+		warning = fmt.Sprintf("%s, use %s instead", warning, e.Deprecation.Replacement)
+	}
+	// the warning is logged once per endpoint, callers are counted by the metric
+	var once sync.Once
+	return func(ctx *fasthttp.RequestCtx) {
+		once.Do(func() {
+			log.WithLogType(logger.LogTypeRequest).Warn(warning)
+		})
+		diag.DefaultMonitoring.DeprecatedAPICalled(api)
+
+		ctx.Response.Header.Set(APIVersionHeader, e.Version)
+		ctx.Response.Header.Set(deprecationHeader, "true")
+		ctx.Response.Header.Set(warningHeader, fmt.Sprintf("299 - %q", warning))
+		e.Handler(ctx)
+	}
+}
@@ -0,0 +1,91 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAPIVersionHandler(t *testing.T) {
+	var routed string
+	h := apiVersionHandler(func(ctx *fasthttp.RequestCtx) {
+		routed = string(ctx.Path())
+	}, map[string]bool{apiVersionV1: true, "v2.0": true})
+
+	request := func(path, version string) *fasthttp.RequestCtx {
+		routed = ""
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI(path)
+		if version != "" {
+			ctx.Request.Header.Set(APIVersionHeader, version)
+		}
+		h(ctx)
+		return ctx
+	}
+
+	t.Run("no requested version", func(t *testing.T) {
+		request("/v1.0/healthz", "")
+		assert.Equal(t, "/v1.0/healthz", routed)
+	})
+
+	t.Run("unversioned path is routed to the requested version", func(t *testing.T) {
+		request("/state/store/key", "v2.0")
+		assert.Equal(t, "/v2.0/state/store/key", routed)
+	})
+
+	t.Run("versioned path of the requested version", func(t *testing.T) {
+		request("/v1.0/healthz", apiVersionV1)
+		assert.Equal(t, "/v1.0/healthz", routed)
+	})
+
+	t.Run("versioned path of another version", func(t *testing.T) {
+		ctx := request("/v1.0/healthz", "v2.0")
+		assert.Equal(t, "", routed)
+		assert.Equal(t, 400, ctx.Response.StatusCode())
+		assert.Contains(t, string(ctx.Response.Body()), "ERR_API_VERSION_MISMATCH")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		ctx := request("/healthz", "v3.0")
+		assert.Equal(t, "", routed)
+		assert.Equal(t, 400, ctx.Response.StatusCode())
+		assert.Contains(t, string(ctx.Response.Body()), "v1.0, v2.0")
+	})
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	called := 0
+	handler := func(ctx *fasthttp.RequestCtx) {
+		called++
+	}
+
+	t.Run("stamps the api version", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+		withAPIVersion(Endpoint{Route: "healthz", Version: apiVersionV1, Handler: handler}, fasthttp.MethodGet)(ctx)
+
+		assert.Equal(t, 1, called)
+		assert.Equal(t, apiVersionV1, string(ctx.Response.Header.Peek(APIVersionHeader)))
+		assert.Empty(t, ctx.Response.Header.Peek(deprecationHeader))
+	})
+
+	t.Run("warns of deprecated endpoints", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+		withAPIVersion(Endpoint{
+			Route:       "old",
+			Version:     apiVersionV1,
+			Handler:     handler,
+			Deprecation: &Deprecation{RemovedIn: "1.0", Replacement: "GET /v1.0/new"},
+		}, fasthttp.MethodGet)(ctx)
+
+		assert.Equal(t, 2, called)
+		assert.Equal(t, apiVersionV1, string(ctx.Response.Header.Peek(APIVersionHeader)))
+		assert.Equal(t, "true", string(ctx.Response.Header.Peek(deprecationHeader)))
+		assert.Equal(t, `299 - "GET /v1.0/old is deprecated and will be removed in Dapr 1.0, use GET /v1.0/new instead"`, string(ctx.Response.Header.Peek(warningHeader)))
+	})
+}
@@ -0,0 +1,179 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package injector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/dapr/dapr/pkg/sentry/certs"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	dryRunNamespaceParam = "namespace"
+	dryRunFormatParam    = "format"
+
+	dryRunFormatPod   = "pod"
+	dryRunFormatPatch = "patch"
+	dryRunFormatDiff  = "diff"
+
+	redactedValue = "<redacted>"
+)
+
+// handleDryRun returns the pod of the body as the webhook would mutate it in the namespace of the query, without
+// admission. The format query parameter selects the response: the mutated pod (default), the JSON patch or a diff.
+func (i *injector) handleDryRun(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed, expect POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := r.URL.Query().Get(dryRunFormatParam)
+	if format == "" {
+		format = dryRunFormatPod
+	}
+	if format != dryRunFormatPod && format != dryRunFormatPatch && format != dryRunFormatDiff {
+		http.Error(w, fmt.Sprintf("invalid format %s, expect pod, patch or diff", format), http.StatusBadRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
+		http.Error(w, "empty body", http.StatusBadRequest)
+		return
+	}
+	var pod corev1.Pod
+	if err := json.Unmarshal(body, &pod); err != nil {
+		http.Error(w, fmt.Sprintf("invalid pod: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	namespace := r.URL.Query().Get(dryRunNamespaceParam)
+	if namespace == "" {
+		namespace = pod.Namespace
+	}
+	ar := &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Namespace: namespace,
+			Name:      pod.Name,
+			Operation: v1beta1.Create,
+			Object:    runtime.RawExtension{Raw: body},
+		},
+	}
+	patchOps, err := i.getPodPatchOperations(ar, i.config.Namespace, i.config.SidecarImage, i.kubeClient, i.daprClient)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to mutate pod: %s", err), http.StatusUnprocessableEntity)
+		return
+	}
+	if patchOps == nil {
+		patchOps = []PatchOperation{}
+	}
+	redactPatchOperations(patchOps)
+
+	resp, err := dryRunResponse(body, patchOps, format)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to mutate pod: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	if format == dryRunFormatDiff {
+		w.Header().Set("Content-Type", "text/plain")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	if _, err := w.Write(resp); err != nil {
+		log.Errorf("can't write dry run response: %v", err)
+	}
+}
+
+func dryRunResponse(pod []byte, patchOps []PatchOperation, format string) ([]byte, error) {
+	patchBytes, err := json.Marshal(patchOps)
+	if err != nil {
+		return nil, err
+	}
+	if format == dryRunFormatPatch {
+		return patchBytes, nil
+	}
+
+	mutated := pod
+	if len(patchOps) > 0 {
+		patch, err := jsonpatch.DecodePatch(patchBytes)
+		if err != nil {
+			return nil, err
+		}
+		mutated, err = patch.Apply(pod)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mutatedIndented, err := indentJSON(mutated)
+	if err != nil {
+		return nil, err
+	}
+	if format == dryRunFormatPod {
+		return mutatedIndented, nil
+	}
+
+	podIndented, err := indentJSON(pod)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(podIndented)),
+		B:        difflib.SplitLines(string(mutatedIndented)),
+		FromFile: "original",
+		ToFile:   "mutated",
+		Context:  3,
+	})
+	return []byte(diff), err
+}
+
+// indentJSON formats the JSON with sorted keys, so that diffs only show the mutations
+func indentJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// redactPatchOperations hides the private key of the sidecar certificate from dry run responses
+func redactPatchOperations(patchOps []PatchOperation) {
+	for _, op := range patchOps {
+		switch v := op.Value.(type) {
+		case *corev1.Container:
+			redactContainer(v)
+		case []corev1.Container:
+			for i := range v {
+				redactContainer(&v[i])
+			}
+		}
+	}
+}
+
+func redactContainer(c *corev1.Container) {
+	for i, env := range c.Env {
+		if env.Name == certs.CertKeyEnvVar {
+			c.Env[i].Value = redactedValue
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package injector
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	configurationv1alpha1 "github.com/dapr/dapr/pkg/apis/configuration/v1alpha1"
+	"github.com/dapr/dapr/pkg/client/clientset/versioned/fake"
+	"github.com/dapr/dapr/pkg/sentry/certs"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestHandleDryRun(t *testing.T) {
+	// mTLS is disabled, so the injector doesn't read the sidecar certificates from Kubernetes
+	daprClient := fake.NewSimpleClientset()
+	daprClient.PrependReactor("list", "configurations", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &configurationv1alpha1.ConfigurationList{
+			Items: []configurationv1alpha1.Configuration{{ObjectMeta: metav1.ObjectMeta{Name: defaultConfig}}},
+		}, nil
+	})
+	i := &injector{
+		config:     Config{SidecarImage: "daprio/daprd", Namespace: "dapr-system"},
+		daprClient: daprClient,
+	}
+
+	pod, _ := json.Marshal(corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Annotations: map[string]string{daprEnabledKey: "true", appIDKey: "app"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "app"}}},
+	})
+
+	dryRun := func(query string, body []byte) *http.Response {
+		r := httptest.NewRequest(http.MethodPost, "/dryrun"+query, bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		i.handleDryRun(w, r)
+		return w.Result()
+	}
+
+	t.Run("mutated pod", func(t *testing.T) {
+		resp := dryRun("?namespace=apps", pod)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var mutated corev1.Pod
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&mutated))
+		assert.Len(t, mutated.Spec.Containers, 2)
+		assert.Equal(t, sidecarContainerName, mutated.Spec.Containers[1].Name)
+		assert.Contains(t, mutated.Spec.Containers[1].Env, corev1.EnvVar{Name: "NAMESPACE", Value: "apps"})
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		resp := dryRun("?format=patch", pod)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var patchOps []PatchOperation
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&patchOps))
+		assert.Len(t, patchOps, 1)
+		assert.Equal(t, "/spec/containers/-", patchOps[0].Path)
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		resp := dryRun("?format=diff", pod)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		diff, _ := ioutil.ReadAll(resp.Body)
+		assert.Contains(t, string(diff), "--- original")
+		assert.Contains(t, string(diff), `+        "name": "daprd",`)
+	})
+
+	t.Run("pod without dapr is unchanged", func(t *testing.T) {
+		plain, _ := json.Marshal(corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}})
+		resp := dryRun("?format=patch", plain)
+		body, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(t, "[]", string(body))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, dryRun("?format=yaml", pod).StatusCode)
+		assert.Equal(t, http.StatusBadRequest, dryRun("", []byte("{")).StatusCode)
+	})
+}
+
+func TestRedactPatchOperations(t *testing.T) {
+	c := &corev1.Container{Env: []corev1.EnvVar{
+		{Name: certs.CertChainEnvVar, Value: "chain"},
+		{Name: certs.CertKeyEnvVar, Value: "key"},
+	}}
+	redactPatchOperations([]PatchOperation{{Value: c}})
+	assert.Equal(t, "chain", c.Env[0].Value)
+	assert.Equal(t, redactedValue, c.Env[1].Value)
+}
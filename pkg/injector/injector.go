@@ -88,6 +88,7 @@ func NewInjector(config Config, daprClient scheme.Interface, kubeClient *kuberne
 	}
 
 	mux.HandleFunc("/mutate", i.handleRequest)
+	mux.HandleFunc("/dryrun", i.handleDryRun)
 	return i
 }
 
@@ -26,6 +26,7 @@ import (
 
 const (
 	sidecarContainerName              = "daprd"
+	initContainerName                 = "daprd-init"
 	daprEnabledKey                    = "dapr.io/enabled"
 	daprPortKey                       = "dapr.io/port"
 	daprConfigKey                     = "dapr.io/config"
@@ -44,6 +45,13 @@ const (
 	daprReadinessProbeTimeoutKey      = "dapr.io/sidecar-readiness-probe-timeout-seconds"
 	daprReadinessProbePeriodKey       = "dapr.io/sidecar-readiness-probe-period-seconds"
 	daprReadinessProbeThresholdKey    = "dapr.io/sidecar-readiness-probe-threshold"
+	daprAppContainerKey               = "dapr.io/app-container"
+	daprAppInvocationTimeoutKey       = "dapr.io/app-invocation-timeout"
+	daprAppPubSubTimeoutKey           = "dapr.io/app-pubsub-timeout"
+	daprAppBindingTimeoutKey          = "dapr.io/app-binding-timeout"
+	daprAppActorTimeoutKey            = "dapr.io/app-actor-timeout"
+	daprInternalGRPCChannelzKey       = "dapr.io/internal-grpc-channelz"
+	daprInitContainerKey              = "dapr.io/sidecar-init-container"
 	sidecarHTTPPort                   = 3500
 	sidecarAPIGRPCPort                = 50001
 	sidecarInternalGRPCPort           = 50002
@@ -67,6 +75,7 @@ const (
 	apiVersionV1                      = "v1.0"
 	defaultMtlsEnabled                = true
 	trueString                        = "true"
+	jobKind                           = "Job"
 )
 
 func (i *injector) getPodPatchOperations(ar *v1beta1.AdmissionReview,
@@ -116,14 +125,43 @@ func (i *injector) getPodPatchOperations(ar *v1beta1.AdmissionReview,
 	if err != nil {
 		return nil, err
 	}
+	if isJobPod(pod) {
+		// a Job pod completes only when all its containers exited
+		addJobMode(sidecarContainer, getAppContainer(pod))
+	}
+
+	var initContainer *corev1.Container
+	if initContainerEnabled(pod.Annotations) {
+		initContainer = getInitContainer(image, apiSrvAddress, placementAddress)
+		addSidecarWait(sidecarContainer)
+	}
 
+	return getContainerPatchOperations(pod, sidecarContainer, initContainer), nil
+}
+
+// getContainerPatchOperations adds the sidecar after the containers of the pod. With an init container, the init
+// container is added after the init containers of the pod and the sidecar before the containers of the pod, so that
+// the post start hook of the sidecar holds them until it's ready.
+func getContainerPatchOperations(pod corev1.Pod, sidecarContainer, initContainer *corev1.Container) []PatchOperation {
 	patchOps := []PatchOperation{}
+	if initContainer != nil {
+		if len(pod.Spec.InitContainers) == 0 {
+			patchOps = append(patchOps, PatchOperation{Op: "add", Path: "/spec/initContainers", Value: []corev1.Container{*initContainer}})
+		} else {
+			patchOps = append(patchOps, PatchOperation{Op: "add", Path: "/spec/initContainers/-", Value: initContainer})
+		}
+	}
+
 	var path string
 	var value interface{}
-	if len(pod.Spec.Containers) == 0 {
+	switch {
+	case len(pod.Spec.Containers) == 0:
 		path = "/spec/containers"
 		value = []corev1.Container{*sidecarContainer}
-	} else {
+	case initContainer != nil:
+		path = "/spec/containers/0"
+		value = sidecarContainer
+	default:
 		path = "/spec/containers/-"
 		value = sidecarContainer
 	}
@@ -137,7 +175,7 @@ func (i *injector) getPodPatchOperations(ar *v1beta1.AdmissionReview,
 		},
 	)
 
-	return patchOps, nil
+	return patchOps
 }
 
 func getTrustAnchorsAndCertChain(kubeClient *kubernetes.Clientset, namespace string) (string, string, string) {
@@ -221,6 +259,10 @@ func profilingEnabled(annotations map[string]string) bool {
 	return getBoolAnnotationOrDefault(annotations, daprProfilingKey, false)
 }
 
+func internalGRPCChannelzEnabled(annotations map[string]string) bool {
+	return getBoolAnnotationOrDefault(annotations, daprInternalGRPCChannelzKey, false)
+}
+
 func getBoolAnnotationOrDefault(annotations map[string]string, key string, defaultValue bool) bool {
 	enabled, ok := annotations[key]
 	if !ok {
@@ -319,6 +361,85 @@ func getResourceRequirements(annotations map[string]string) (*v1.ResourceRequire
 	return nil, nil
 }
 
+// isJobPod returns true if the pod was created by a Job, including the Jobs of CronJobs
+func isJobPod(pod corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == jobKind {
+			return true
+		}
+	}
+	return false
+}
+
+// getAppContainer returns the container of the annotation, or the first container of the pod
+func getAppContainer(pod corev1.Pod) string {
+	if name := getStringAnnotation(pod.Annotations, daprAppContainerKey); name != "" {
+		return name
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name != sidecarContainerName {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+// addJobMode makes the sidecar exit when the app container terminates
+func addJobMode(c *corev1.Container, appContainer string) {
+	if appContainer == "" {
+		return
+	}
+	c.Args = append(c.Args, "--app-container", appContainer)
+}
+
+// appTimeoutFlags are the sidecar flags of the app callback timeout annotations
+var appTimeoutFlags = []struct {
+	annotation string
+	flag       string
+}{
+	{daprAppInvocationTimeoutKey, "--app-invocation-timeout"},
+	{daprAppPubSubTimeoutKey, "--app-pubsub-timeout"},
+	{daprAppBindingTimeoutKey, "--app-binding-timeout"},
+	{daprAppActorTimeoutKey, "--app-actor-timeout"},
+}
+
+// addAppTimeouts passes the app callback timeouts of the annotations to the sidecar
+func addAppTimeouts(c *corev1.Container, annotations map[string]string) {
+	for _, t := range appTimeoutFlags {
+		if timeout := getStringAnnotation(annotations, t.annotation); timeout != "" {
+			c.Args = append(c.Args, t.flag, timeout)
+		}
+	}
+}
+
+func initContainerEnabled(annotations map[string]string) bool {
+	return getBoolAnnotationOrDefault(annotations, daprInitContainerKey, false)
+}
+
+// getInitContainer returns the init container waiting for the control plane addresses the sidecar connects to
+func getInitContainer(daprSidecarImage string, addresses ...string) *corev1.Container {
+	return &corev1.Container{
+		Name:            initContainerName,
+		Image:           daprSidecarImage,
+		ImagePullPolicy: corev1.PullAlways,
+		Command:         []string{"/daprd"},
+		Args:            []string{"--wait-for-addresses", strings.Join(addresses, ",")},
+	}
+}
+
+// addSidecarWait holds the start of the containers after the sidecar until the sidecar is ready. The sidecar is told
+// the app starts after it, so it doesn't wait for the app itself.
+func addSidecarWait(c *corev1.Container) {
+	c.Args = append(c.Args, "--app-starts-after-sidecar")
+	c.Lifecycle = &corev1.Lifecycle{
+		PostStart: &corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/daprd", "--wait-for-sidecar", "--dapr-http-port", fmt.Sprintf("%v", sidecarHTTPPort)},
+			},
+		},
+	}
+}
+
 func isResourceDaprEnabled(annotations map[string]string) bool {
 	return getBoolAnnotationOrDefault(annotations, daprEnabledKey, false)
 }
@@ -379,6 +500,14 @@ func getSidecarContainer(annotations map[string]string, id, daprSidecarImage, na
 				Name:  "NAMESPACE",
 				Value: namespace,
 			},
+			{
+				Name: runtime.PodNameEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.name",
+					},
+				},
+			},
 		},
 		Args: []string{
 			"--mode", "kubernetes",
@@ -436,6 +565,12 @@ func getSidecarContainer(annotations map[string]string, id, daprSidecarImage, na
 		c.Args = append(c.Args, "--enable-profiling")
 	}
 
+	if internalGRPCChannelzEnabled(annotations) {
+		c.Args = append(c.Args, "--enable-internal-grpc-channelz")
+	}
+
+	addAppTimeouts(c, annotations)
+
 	if mtlsEnabled && trustAnchors != "" {
 		c.Args = append(c.Args, "--enable-mtls")
 		c.Env = append(c.Env, corev1.EnvVar{
@@ -8,7 +8,10 @@ package injector
 import (
 	"testing"
 
+	"github.com/dapr/dapr/pkg/runtime"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestLogAsJSONEnabled(t *testing.T) {
@@ -62,4 +65,90 @@ func TestGetSideCarContainer(t *testing.T) {
 	}
 
 	assert.EqualValues(t, expectedArgs, container.Args)
+	assert.Equal(t, runtime.PodNameEnvVar, container.Env[2].Name)
+	assert.Equal(t, "metadata.name", container.Env[2].ValueFrom.FieldRef.FieldPath)
+}
+
+func TestJobMode(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "Job", Name: "backup"}},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "backup"}, {Name: "logger"}},
+		},
+	}
+
+	t.Run("job pods", func(t *testing.T) {
+		assert.True(t, isJobPod(pod))
+		assert.False(t, isJobPod(corev1.Pod{}))
+	})
+
+	t.Run("app container", func(t *testing.T) {
+		assert.Equal(t, "backup", getAppContainer(pod))
+
+		annotated := pod
+		annotated.Annotations = map[string]string{daprAppContainerKey: "logger"}
+		assert.Equal(t, "logger", getAppContainer(annotated))
+	})
+
+	t.Run("sidecar exits with the app container", func(t *testing.T) {
+		c := &corev1.Container{}
+		addJobMode(c, "backup")
+		assert.Equal(t, []string{"--app-container", "backup"}, c.Args)
+	})
+}
+
+func TestAddAppTimeouts(t *testing.T) {
+	c := &corev1.Container{}
+	addAppTimeouts(c, map[string]string{
+		daprAppActorTimeoutKey:  "5m",
+		daprAppPubSubTimeoutKey: "30s",
+	})
+	assert.Equal(t, []string{"--app-pubsub-timeout", "30s", "--app-actor-timeout", "5m"}, c.Args)
+}
+
+func TestInitContainerMode(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrations"}},
+			Containers:     []corev1.Container{{Name: "app"}},
+		},
+	}
+	sidecar := &corev1.Container{Name: sidecarContainerName}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.False(t, initContainerEnabled(map[string]string{}))
+		assert.True(t, initContainerEnabled(map[string]string{daprInitContainerKey: "true"}))
+
+		ops := getContainerPatchOperations(pod, sidecar, nil)
+		assert.Len(t, ops, 1)
+		assert.Equal(t, "/spec/containers/-", ops[0].Path)
+	})
+
+	t.Run("init container waits for the control plane", func(t *testing.T) {
+		c := getInitContainer("daprio/dapr", "dapr-api:80", "dapr-placement:80")
+		assert.Equal(t, initContainerName, c.Name)
+		assert.Equal(t, []string{"--wait-for-addresses", "dapr-api:80,dapr-placement:80"}, c.Args)
+	})
+
+	t.Run("sidecar holds the app until it's ready", func(t *testing.T) {
+		c := &corev1.Container{}
+		addSidecarWait(c)
+		assert.Equal(t, []string{"/daprd", "--wait-for-sidecar", "--dapr-http-port", "3500"}, c.Lifecycle.PostStart.Exec.Command)
+		assert.Equal(t, []string{"--app-starts-after-sidecar"}, c.Args)
+	})
+
+	t.Run("sidecar is added before the app", func(t *testing.T) {
+		ops := getContainerPatchOperations(pod, sidecar, &corev1.Container{Name: initContainerName})
+		assert.Len(t, ops, 2)
+		assert.Equal(t, "/spec/initContainers/-", ops[0].Path)
+		assert.Equal(t, "/spec/containers/0", ops[1].Path)
+	})
+
+	t.Run("first init container", func(t *testing.T) {
+		ops := getContainerPatchOperations(corev1.Pod{}, sidecar, &corev1.Container{Name: initContainerName})
+		assert.Equal(t, "/spec/initContainers", ops[0].Path)
+		assert.Equal(t, "/spec/containers", ops[1].Path)
+	})
 }
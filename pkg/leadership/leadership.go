@@ -0,0 +1,250 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package leadership
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+// DefaultTTL is the time a leader holds its lease without renewing it
+const DefaultTTL = 15 * time.Second
+
+var log = logger.NewLogger("dapr.runtime.leadership")
+
+var (
+	// ErrLeadershipLost is returned by Campaign when the leader failed to renew its lease before it expired, or another
+	// candidate took over the lease
+	ErrLeadershipLost = errors.New("leadership lost")
+	// ErrNotCampaigning is returned by Resign when the candidate isn't campaigning in the election
+	ErrNotCampaigning = errors.New("candidate is not campaigning")
+)
+
+// lease is the value of the key of an election in the state store
+type lease struct {
+	Leader    string `json:"leader"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+// Elector elects leaders with leases on keys of a state store.
+// Leases are created only if they don't exist, and renewed with compare-and-swap on the ETag of the key,
+// so the store must have the insert feature.
+type Elector struct {
+	store     state.Store
+	lock      sync.Mutex
+	campaigns map[string]context.CancelFunc
+}
+
+// NewElector returns an elector backed by the store, bypassing its wrappers such as the write-behind queue
+func NewElector(store state.Store) *Elector {
+	return &Elector{
+		store:     state_loader.Unwrap(store),
+		campaigns: map[string]context.CancelFunc{},
+	}
+}
+
+// Campaign blocks until the candidate is elected leader of the election, calls elected, and keeps renewing the lease
+// until ctx is done or the candidate resigns. The lease is released when the campaign ends.
+// A leader that fails to renew its lease before it expires gets ErrLeadershipLost, whatever the reason of the failure.
+func (e *Elector) Campaign(ctx context.Context, election, candidate string, ttl time.Duration, elected func()) error {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	id := campaignID(election, candidate)
+	e.lock.Lock()
+	if _, ok := e.campaigns[id]; ok {
+		e.lock.Unlock()
+		return fmt.Errorf("candidate %s is already campaigning in election %s", candidate, election)
+	}
+	e.campaigns[id] = cancel
+	e.lock.Unlock()
+	defer func() {
+		e.lock.Lock()
+		delete(e.campaigns, id)
+		e.lock.Unlock()
+	}()
+
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+	// expiry fires when the lease of the leader expires without being renewed
+	expiry := time.NewTimer(ttl)
+	expiry.Stop()
+	defer expiry.Stop()
+
+	leader := false
+	for {
+		acquired, expiresAt, err := e.tryAcquire(election, candidate, ttl)
+		switch {
+		case err != nil:
+			log.Debugf("failed to acquire lease of election %s: %s", election, err)
+		case acquired:
+			expiry.Stop()
+			expiry.Reset(time.Until(expiresAt))
+			if !leader {
+				leader = true
+				elected()
+			}
+		case leader:
+			return ErrLeadershipLost
+		}
+
+		select {
+		case <-ctx.Done():
+			if leader {
+				e.release(election, candidate)
+			}
+			return nil
+		case <-expiry.C:
+			if leader {
+				return ErrLeadershipLost
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// Resign ends the campaign of the candidate in the election, releasing the lease if the candidate is the leader
+func (e *Elector) Resign(election, candidate string) error {
+	e.lock.Lock()
+	cancel, ok := e.campaigns[campaignID(election, candidate)]
+	e.lock.Unlock()
+	if !ok {
+		return ErrNotCampaigning
+	}
+	cancel()
+	return nil
+}
+
+// Leader returns the current leader of the election, or an empty string when the election has no leader
+func (e *Elector) Leader(election string) (string, error) {
+	l, _, err := e.get(election)
+	if err != nil || l == nil || l.expired() {
+		return "", err
+	}
+	return l.Leader, nil
+}
+
+// Observe calls handler with the leader of the election, and with each new leader, until ctx is done.
+// The leader is empty while the election has no leader.
+func (e *Elector) Observe(ctx context.Context, election string, interval time.Duration, handler func(leader string)) error {
+	if interval <= 0 {
+		interval = DefaultTTL / 3
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	first := true
+	var last string
+	for {
+		leader, err := e.Leader(election)
+		if err != nil {
+			log.Debugf("failed to get leader of election %s: %s", election, err)
+		} else if first || leader != last {
+			first = false
+			last = leader
+			handler(leader)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryAcquire acquires or renews the lease of the candidate, and returns when it expires. It returns false if another
+// candidate holds the lease or updated it first.
+func (e *Elector) tryAcquire(election, candidate string, ttl time.Duration) (bool, time.Time, error) {
+	l, etag, err := e.get(election)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if l != nil && l.Leader != candidate && !l.expired() {
+		return false, time.Time{}, nil
+	}
+
+	// the lease expires for the candidate before it's written, so that it never outlives the lease in the store
+	expiresAt := time.Now().Add(ttl)
+	value, err := json.Marshal(&lease{Leader: candidate, ExpiresAt: expiresAt.UnixNano()})
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	err = state_loader.CompareAndSet(e.store, &state.SetRequest{
+		Key:   election,
+		Value: value,
+		ETag:  etag,
+		Options: state.SetStateOption{
+			Consistency: state.Strong,
+		},
+	})
+	if err == state_loader.ErrETagMismatch {
+		return false, time.Time{}, nil
+	}
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	return true, expiresAt, nil
+}
+
+func (e *Elector) release(election, candidate string) {
+	l, etag, err := e.get(election)
+	if err != nil || l == nil || l.Leader != candidate {
+		return
+	}
+	err = e.store.Delete(&state.DeleteRequest{
+		Key:  election,
+		ETag: etag,
+		Options: state.DeleteStateOption{
+			Concurrency: state.FirstWrite,
+			Consistency: state.Strong,
+		},
+	})
+	if err != nil {
+		log.Warnf("failed to release lease of election %s: %s", election, err)
+	}
+}
+
+func (e *Elector) get(election string) (*lease, string, error) {
+	resp, err := e.store.Get(&state.GetRequest{Key: election, Options: state.GetStateOption{Consistency: state.Strong}})
+	if err != nil {
+		return nil, "", err
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		var etag string
+		if resp != nil {
+			etag = resp.ETag
+		}
+		return nil, etag, nil
+	}
+
+	var l lease
+	if err := json.Unmarshal(resp.Data, &l); err != nil {
+		return nil, "", fmt.Errorf("value of key %s is not a lease: %s", election, err)
+	}
+	return &l, resp.ETag, nil
+}
+
+func (l *lease) expired() bool {
+	return time.Now().UnixNano() >= l.ExpiresAt
+}
+
+func campaignID(election, candidate string) string {
+	return election + "||" + candidate
+}
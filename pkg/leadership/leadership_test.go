@@ -0,0 +1,133 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package leadership
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaign(t *testing.T) {
+	ttl := 30 * time.Millisecond
+
+	t.Run("one candidate is elected until it resigns", func(t *testing.T) {
+		e := NewElector(daprt.NewFaultyStateStore())
+		elected := make(chan string, 2)
+		done := make(chan error, 2)
+		for _, c := range []string{"a", "b"} {
+			candidate := c
+			go func() {
+				done <- e.Campaign(context.Background(), "workers", candidate, ttl, func() { elected <- candidate })
+			}()
+		}
+
+		first := <-elected
+		select {
+		case c := <-elected:
+			t.Fatalf("%s elected while %s is leader", c, first)
+		case <-time.After(3 * ttl):
+		}
+		leader, err := e.Leader("workers")
+		assert.NoError(t, err)
+		assert.Equal(t, first, leader)
+
+		assert.NoError(t, e.Resign("workers", first))
+		assert.NoError(t, <-done)
+
+		second := <-elected
+		assert.NotEqual(t, first, second)
+		assert.NoError(t, e.Resign("workers", second))
+		assert.NoError(t, <-done)
+
+		leader, err = e.Leader("workers")
+		assert.NoError(t, err)
+		assert.Empty(t, leader)
+	})
+
+	t.Run("resign without campaign", func(t *testing.T) {
+		e := NewElector(daprt.NewFaultyStateStore())
+		assert.Equal(t, ErrNotCampaigning, e.Resign("workers", "a"))
+	})
+
+	t.Run("candidates racing on an empty store elect one leader", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		held, release := store.HoldGets(2)
+		elected := make(chan string, 2)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		for _, c := range []string{"a", "b"} {
+			candidate := c
+			go NewElector(store).Campaign(ctx, "workers", candidate, time.Minute, func() { elected <- candidate })
+		}
+		// both candidates read the missing lease before any of them creates it
+		<-held
+		<-held
+		release()
+
+		<-elected
+		select {
+		case c := <-elected:
+			t.Fatalf("%s elected with another leader", c)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("leader unable to renew its lease steps down when it expires", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		e := NewElector(store)
+		elected := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- e.Campaign(context.Background(), "workers", "a", ttl, func() { close(elected) })
+		}()
+		<-elected
+
+		store.Fail(errors.New("connection refused"))
+		select {
+		case err := <-done:
+			assert.Equal(t, ErrLeadershipLost, err)
+		case <-time.After(10 * ttl):
+			t.Fatal("leader didn't step down")
+		}
+	})
+
+	t.Run("expired lease is taken over", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "workers", Value: []byte(`{"leader":"crashed","expiresAt":1}`)}))
+		e := NewElector(store)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		elected := make(chan struct{})
+		go e.Campaign(ctx, "workers", "a", ttl, func() { close(elected) })
+		<-elected
+		cancel()
+	})
+}
+
+func TestObserve(t *testing.T) {
+	ttl := 30 * time.Millisecond
+	e := NewElector(daprt.NewFaultyStateStore())
+
+	leaders := make(chan string, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Observe(ctx, "workers", ttl/3, func(leader string) { leaders <- leader })
+	assert.Equal(t, "", <-leaders)
+
+	elected := make(chan struct{})
+	go e.Campaign(context.Background(), "workers", "a", ttl, func() { close(elected) })
+	<-elected
+	assert.Equal(t, "a", <-leaders)
+
+	assert.NoError(t, e.Resign("workers", "a"))
+	assert.Equal(t, "", <-leaders)
+}
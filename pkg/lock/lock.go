@@ -0,0 +1,154 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package lock
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+)
+
+// UnlockStatus is the outcome of an unlock
+type UnlockStatus int
+
+const (
+	// Unlocked is returned when the owner released the lock
+	Unlocked UnlockStatus = iota
+	// LockDoesNotExist is returned when the lock isn't held, or its lease expired
+	LockDoesNotExist
+	// LockBelongsToOthers is returned when another owner holds the lock
+	LockBelongsToOthers
+)
+
+// Locker is implemented by state stores with native locks
+type Locker interface {
+	// TryLock acquires the lock of resource for owner until ttl elapses, and returns whether it was acquired with the
+	// fencing token of the lease
+	TryLock(resource, owner string, ttl time.Duration) (bool, int64, error)
+	// Unlock releases the lock of resource held by owner
+	Unlock(resource, owner string) (UnlockStatus, error)
+}
+
+// lease is the value of the key of a lock in the state store. The key is kept once the lock is released, so that
+// the fencing tokens of the resource keep increasing.
+type lease struct {
+	Owner     string `json:"owner"`
+	ExpiresAt int64  `json:"expiresAt"`
+	Token     int64  `json:"token"`
+}
+
+// TryLock acquires the lock of key in the store for owner until ttl elapses, without waiting for it to be released.
+// It returns whether the lock was acquired, and the fencing token of the lease, which increases each time the lock
+// changes hands so that the resource can reject the writes of previous owners. The owner holding the lock renews its
+// lease and keeps its token.
+// Stores without native locks create the lease only if it doesn't exist and update it with compare-and-swap on the
+// ETag of the key, so the store must have the insert feature. Failing writes are returned as errors, only another
+// owner updating the lease first makes the lock not acquired.
+func TryLock(store state.Store, key, owner string, ttl time.Duration) (bool, int64, error) {
+	store = state_loader.Unwrap(store)
+	if locker, ok := store.(Locker); ok {
+		return locker.TryLock(key, owner, ttl)
+	}
+
+	l, etag, err := get(store, key)
+	if err != nil {
+		return false, 0, err
+	}
+	next := lease{Owner: owner, ExpiresAt: time.Now().Add(ttl).UnixNano(), Token: 1}
+	if l != nil {
+		switch {
+		case l.held() && l.Owner != owner:
+			return false, 0, nil
+		case l.held():
+			next.Token = l.Token
+		default:
+			next.Token = l.Token + 1
+		}
+	}
+
+	err = set(store, key, etag, &next)
+	if err == state_loader.ErrETagMismatch {
+		// another owner updated the lease first
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	return true, next.Token, nil
+}
+
+// Unlock releases the lock of key in the store held by owner
+func Unlock(store state.Store, key, owner string) (UnlockStatus, error) {
+	store = state_loader.Unwrap(store)
+	if locker, ok := store.(Locker); ok {
+		return locker.Unlock(key, owner)
+	}
+
+	for {
+		l, etag, err := get(store, key)
+		if err != nil {
+			return 0, err
+		}
+		if l == nil || !l.held() {
+			return LockDoesNotExist, nil
+		}
+		if l.Owner != owner {
+			return LockBelongsToOthers, nil
+		}
+
+		err = set(store, key, etag, &lease{Token: l.Token})
+		if err == state_loader.ErrETagMismatch {
+			// the lease was renewed or taken over meanwhile, check it again
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to release lock %s: %s", key, err)
+		}
+		return Unlocked, nil
+	}
+}
+
+func get(store state.Store, key string) (*lease, string, error) {
+	resp, err := store.Get(&state.GetRequest{Key: key, Options: state.GetStateOption{Consistency: state.Strong}})
+	if err != nil {
+		return nil, "", err
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		var etag string
+		if resp != nil {
+			etag = resp.ETag
+		}
+		return nil, etag, nil
+	}
+
+	var l lease
+	if err := json.Unmarshal(resp.Data, &l); err != nil {
+		return nil, "", fmt.Errorf("value of key %s is not a lock: %s", key, err)
+	}
+	return &l, resp.ETag, nil
+}
+
+func set(store state.Store, key, etag string, l *lease) error {
+	value, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return state_loader.CompareAndSet(store, &state.SetRequest{
+		Key:   key,
+		Value: value,
+		ETag:  etag,
+		Options: state.SetStateOption{
+			Consistency: state.Strong,
+		},
+	})
+}
+
+func (l *lease) held() bool {
+	return l.Owner != "" && time.Now().UnixNano() < l.ExpiresAt
+}
@@ -0,0 +1,148 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package lock
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+type nativeStore struct {
+	state.Store
+	owner string
+}
+
+func (n *nativeStore) TryLock(resource, owner string, ttl time.Duration) (bool, int64, error) {
+	if n.owner != "" {
+		return false, 0, nil
+	}
+	n.owner = owner
+	return true, 42, nil
+}
+
+func (n *nativeStore) Unlock(resource, owner string) (UnlockStatus, error) {
+	n.owner = ""
+	return Unlocked, nil
+}
+
+func TestTryLock(t *testing.T) {
+	t.Run("lock is held until it's released", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+
+		ok, token, err := TryLock(store, "job", "a", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, int64(1), token)
+
+		ok, _, err = TryLock(store, "job", "b", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		status, err := Unlock(store, "job", "a")
+		assert.NoError(t, err)
+		assert.Equal(t, Unlocked, status)
+
+		ok, token, err = TryLock(store, "job", "b", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, int64(2), token)
+	})
+
+	t.Run("owner renews its lease and keeps its token", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+
+		_, first, _ := TryLock(store, "job", "a", time.Minute)
+		ok, token, err := TryLock(store, "job", "a", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, first, token)
+	})
+
+	t.Run("expired lease is acquired with a new token", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+
+		_, first, _ := TryLock(store, "job", "a", time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+		ok, token, err := TryLock(store, "job", "b", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Greater(t, token, first)
+
+		status, err := Unlock(store, "job", "a")
+		assert.NoError(t, err)
+		assert.Equal(t, LockBelongsToOthers, status)
+	})
+
+	t.Run("concurrent first acquirers get the lock once", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		held, release := store.HoldGets(2)
+
+		results := make(chan bool, 2)
+		for _, o := range []string{"a", "b"} {
+			owner := o
+			go func() {
+				ok, token, err := TryLock(store, "job", owner, time.Minute)
+				assert.NoError(t, err)
+				if ok {
+					assert.Equal(t, int64(1), token)
+				}
+				results <- ok
+			}()
+		}
+		// both owners read the missing lease before any of them creates it
+		<-held
+		<-held
+		release()
+
+		first, second := <-results, <-results
+		assert.True(t, first != second, "exactly one owner acquires the lock")
+	})
+
+	t.Run("store errors aren't contention", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		store.Fail(errors.New("connection refused"))
+
+		ok, _, err := TryLock(store, "job", "a", time.Minute)
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("native locks", func(t *testing.T) {
+		store := &nativeStore{}
+
+		ok, token, err := TryLock(store, "job", "a", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, int64(42), token)
+		assert.Equal(t, "a", store.owner)
+	})
+}
+
+func TestUnlock(t *testing.T) {
+	store := daprt.NewFaultyStateStore()
+
+	status, err := Unlock(store, "job", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, LockDoesNotExist, status)
+
+	TryLock(store, "job", "a", time.Minute)
+	status, err = Unlock(store, "job", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, LockBelongsToOthers, status)
+
+	status, err = Unlock(store, "job", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, Unlocked, status)
+
+	status, err = Unlock(store, "job", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, LockDoesNotExist, status)
+}
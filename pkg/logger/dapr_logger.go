@@ -92,6 +92,14 @@ func (l *daprLogger) WithLogType(logType string) Logger {
 	}
 }
 
+// WithFields adds the fields to the log
+func (l *daprLogger) WithFields(fields map[string]interface{}) Logger {
+	return &daprLogger{
+		name:   l.name,
+		logger: l.logger.WithFields(fields),
+	}
+}
+
 // Info logs a message at level Info.
 func (l *daprLogger) Info(args ...interface{}) {
 	l.logger.Log(logrus.InfoLevel, args...)
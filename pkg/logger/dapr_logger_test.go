@@ -177,6 +177,23 @@ func TestWithTypeFields(t *testing.T) {
 	assert.Equalf(t, LogTypeLog, o[logFieldType], "testLogger must be %s type", LogTypeLog)
 }
 
+func TestWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	testLogger := getTestLogger(&buf)
+	testLogger.EnableJSONOutput(true)
+	testLogger.SetOutputLevel(InfoLevel)
+
+	testLogger.WithLogType(LogTypeAudit).WithFields(map[string]interface{}{"peer": "10.0.0.1:5000"}).Info("handshake failed")
+
+	b, _ := buf.ReadBytes('\n')
+	var o map[string]interface{}
+	json.Unmarshal(b, &o)
+
+	assert.Equal(t, LogTypeAudit, o[logFieldType])
+	assert.Equal(t, "10.0.0.1:5000", o["peer"])
+	assert.Equal(t, "handshake failed", o[logFieldMessage])
+}
+
 func TestToLogrusLevel(t *testing.T) {
 	t.Run("Dapr DebugLevel to Logrus.DebugLevel", func(t *testing.T) {
 		assert.Equal(t, logrus.DebugLevel, toLogrusLevel(DebugLevel))
@@ -15,6 +15,8 @@ const (
 	LogTypeLog = "log"
 	// LogTypeRequest is Request log type
 	LogTypeRequest = "request"
+	// LogTypeAudit is Audit log type, for security events
+	LogTypeAudit = "audit"
 
 	// Field names that defines Dapr log schema
 	logFieldTimeStamp = "time"
@@ -63,6 +65,8 @@ type Logger interface {
 
 	// WithLogType specify the log_type field in log. Default value is LogTypeLog
 	WithLogType(logType string) Logger
+	// WithFields adds the fields to the log
+	WithFields(fields map[string]interface{}) Logger
 
 	// Info logs a message at level Info.
 	Info(args ...interface{})
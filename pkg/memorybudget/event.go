@@ -0,0 +1,63 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package memorybudget
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/google/uuid"
+)
+
+// CloudEventType is the cloud event type of published memory pressure events
+const CloudEventType = "com.dapr.sidecar.memory"
+
+// Event is published when the memory pressure of the sidecar changes
+type Event struct {
+	Level         string    `json:"level"`
+	PreviousLevel string    `json:"previousLevel"`
+	UsedBytes     uint64    `json:"usedBytes"`
+	LimitBytes    uint64    `json:"limitBytes"`
+	AppID         string    `json:"appId"`
+	Time          time.Time `json:"time"`
+}
+
+// PublishEvents publishes the changes of memory pressure of the budget to the topic.
+// Events are published asynchronously so that checking the budget never waits on the pub/sub component.
+func PublishEvents(b *Budget, topic, appID string, publishFn func(req *pubsub.PublishRequest) error) {
+	b.OnLevelChange(func(from, to Level, used uint64) {
+		e := Event{
+			Level:         to.String(),
+			PreviousLevel: from.String(),
+			UsedBytes:     used,
+			LimitBytes:    b.Limit(),
+			AppID:         appID,
+			Time:          time.Now().UTC(),
+		}
+		go func() {
+			data, err := json.Marshal(e)
+			if err != nil {
+				log.Warnf("failed to serialize memory pressure event: %s", err)
+				return
+			}
+			envelope := pubsub.NewCloudEventsEnvelope(uuid.New().String(), appID, CloudEventType, "", data)
+			payload, err := json.Marshal(envelope)
+			if err != nil {
+				log.Warnf("failed to serialize memory pressure event: %s", err)
+				return
+			}
+
+			err = publishFn(&pubsub.PublishRequest{
+				Topic: topic,
+				Data:  payload,
+			})
+			if err != nil {
+				log.Warnf("failed to publish memory pressure event to topic %s: %s", topic, err)
+			}
+		}()
+	})
+}
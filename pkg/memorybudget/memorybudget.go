@@ -0,0 +1,240 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package memorybudget protects the sidecar from being OOM-killed: it checks the memory used by the sidecar against a
+// budget, and notifies the subsystems that can shed memory when the pressure changes.
+package memorybudget
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/logger"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var log = logger.NewLogger("dapr.runtime.memorybudget")
+
+// Level is the memory pressure of the sidecar
+type Level int
+
+// Memory pressure levels
+const (
+	LevelNormal Level = iota
+	// LevelPressure rejects the large payloads of API calls and flushes the caches
+	LevelPressure
+	// LevelCritical also pauses the delivery of events to the app
+	LevelCritical
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelPressure:
+		return "pressure"
+	case LevelCritical:
+		return "critical"
+	}
+	return "normal"
+}
+
+const (
+	defaultPressurePercent = 80
+	defaultCriticalPercent = 95
+	defaultMaxPayloadSize  = 1024 * 1024
+	defaultCheckInterval   = time.Second
+
+	// recoveryPercent of the limit below the threshold of a level leaves it, so that the level doesn't flap
+	recoveryPercent = 5
+)
+
+// Budget is the memory budget of the sidecar. A nil budget is unlimited.
+type Budget struct {
+	limit          uint64
+	pressure       uint64
+	critical       uint64
+	recovery       uint64
+	maxPayloadSize int
+	interval       time.Duration
+	// usage returns the memory used by the sidecar
+	usage func() uint64
+
+	lock      sync.Mutex
+	level     Level
+	shrinkers []func()
+	listeners []func(from, to Level, used uint64)
+}
+
+// New returns the memory budget of the spec, nil if it has no limit
+func New(spec config.MemoryBudgetSpec) (*Budget, error) {
+	if spec.Limit == "" {
+		return nil, nil
+	}
+	limit, err := parseSize(spec.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory budget limit: %s", err)
+	}
+	maxPayloadSize := int64(defaultMaxPayloadSize)
+	if spec.MaxPayloadSize != "" {
+		if maxPayloadSize, err = parseSize(spec.MaxPayloadSize); err != nil {
+			return nil, fmt.Errorf("invalid memory budget max payload size: %s", err)
+		}
+	}
+	interval := defaultCheckInterval
+	if spec.CheckInterval != "" {
+		if interval, err = time.ParseDuration(spec.CheckInterval); err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid memory budget check interval %s", spec.CheckInterval)
+		}
+	}
+	pressurePercent, criticalPercent := spec.PressurePercent, spec.CriticalPercent
+	if pressurePercent == 0 {
+		pressurePercent = defaultPressurePercent
+	}
+	if criticalPercent == 0 {
+		criticalPercent = defaultCriticalPercent
+	}
+	if pressurePercent >= criticalPercent || criticalPercent > 100 {
+		return nil, fmt.Errorf("memory budget pressure percent %d is not below critical percent %d", pressurePercent, criticalPercent)
+	}
+
+	return &Budget{
+		limit:          uint64(limit),
+		pressure:       uint64(limit) * uint64(pressurePercent) / 100,
+		critical:       uint64(limit) * uint64(criticalPercent) / 100,
+		recovery:       uint64(limit) * recoveryPercent / 100,
+		maxPayloadSize: int(maxPayloadSize),
+		interval:       interval,
+		usage:          usage,
+	}, nil
+}
+
+// Limit returns the memory budget in bytes
+func (b *Budget) Limit() uint64 {
+	return b.limit
+}
+
+// OnShrink registers a function freeing memory, e.g. flushing a cache, called when the sidecar comes under pressure
+func (b *Budget) OnShrink(shrink func()) {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.shrinkers = append(b.shrinkers, shrink)
+}
+
+// OnLevelChange registers a function called with the previous and the new level when the pressure changes
+func (b *Budget) OnLevelChange(listener func(from, to Level, used uint64)) {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.listeners = append(b.listeners, listener)
+}
+
+// Level returns the current memory pressure
+func (b *Budget) Level() Level {
+	if b == nil {
+		return LevelNormal
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.level
+}
+
+// AllowPayload returns whether an API call with a payload of size bytes is accepted. Payloads larger than the max
+// payload size are rejected under pressure.
+func (b *Budget) AllowPayload(size int) bool {
+	return b == nil || size <= b.maxPayloadSize || b.Level() == LevelNormal
+}
+
+// Run checks the memory usage until stopCh is closed
+func (b *Budget) Run(stopCh <-chan struct{}) {
+	if b == nil {
+		return
+	}
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.Check()
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// Check updates the level from the memory usage, and notifies its change
+func (b *Budget) Check() {
+	used := b.usage()
+
+	b.lock.Lock()
+	from := b.level
+	to := b.levelOf(used)
+	b.level = to
+	shrinkers := b.shrinkers
+	listeners := b.listeners
+	b.lock.Unlock()
+
+	diag.DefaultMonitoring.MemoryBudgetChecked(int64(used), int64(to))
+	if to == from {
+		return
+	}
+
+	if to > from {
+		log.Warnf("memory usage of %d bytes of the %d bytes budget, entering %s level", used, b.limit, to)
+	} else {
+		log.Infof("memory usage of %d bytes of the %d bytes budget, back to %s level", used, b.limit, to)
+	}
+	if from == LevelNormal {
+		for _, shrink := range shrinkers {
+			shrink()
+		}
+		debug.FreeOSMemory()
+	}
+	for _, listener := range listeners {
+		listener(from, to, used)
+	}
+}
+
+// levelOf returns the level of the memory usage. Levels are left once the usage is a recovery margin below their
+// threshold. The lock must be held.
+func (b *Budget) levelOf(used uint64) Level {
+	switch {
+	case used >= b.critical:
+		return LevelCritical
+	case b.level == LevelCritical && used+b.recovery >= b.critical:
+		return LevelCritical
+	case used >= b.pressure:
+		return LevelPressure
+	case b.level >= LevelPressure && used+b.recovery >= b.pressure:
+		return LevelPressure
+	}
+	return LevelNormal
+}
+
+// usage returns the memory obtained from the OS by the sidecar and not returned yet, close to its resident memory
+func usage() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.Sys - m.HeapReleased
+}
+
+func parseSize(value string) (int64, error) {
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+	if q.Sign() <= 0 {
+		return 0, fmt.Errorf("%s is not positive", value)
+	}
+	return q.Value(), nil
+}
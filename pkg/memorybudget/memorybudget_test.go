@@ -0,0 +1,135 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package memorybudget
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBudget(t *testing.T, used *uint64) *Budget {
+	b, err := New(config.MemoryBudgetSpec{Limit: "1000", MaxPayloadSize: "10"})
+	require.NoError(t, err)
+	b.usage = func() uint64 {
+		return *used
+	}
+	return b
+}
+
+func TestNew(t *testing.T) {
+	b, err := New(config.MemoryBudgetSpec{})
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	b, err = New(config.MemoryBudgetSpec{Limit: "512Mi"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(512*1024*1024), b.Limit())
+	assert.Equal(t, defaultMaxPayloadSize, b.maxPayloadSize)
+
+	for _, spec := range []config.MemoryBudgetSpec{
+		{Limit: "lots"},
+		{Limit: "-1Mi"},
+		{Limit: "1Mi", MaxPayloadSize: "0"},
+		{Limit: "1Mi", CheckInterval: "often"},
+		{Limit: "1Mi", PressurePercent: 90, CriticalPercent: 90},
+	} {
+		_, err := New(spec)
+		assert.Error(t, err, "%+v", spec)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	used := uint64(0)
+	b := newTestBudget(t, &used)
+	shrunk := 0
+	b.OnShrink(func() {
+		shrunk++
+	})
+	changes := [][2]Level{}
+	b.OnLevelChange(func(from, to Level, _ uint64) {
+		changes = append(changes, [2]Level{from, to})
+	})
+
+	check := func(u uint64) Level {
+		used = u
+		b.Check()
+		return b.Level()
+	}
+
+	assert.Equal(t, LevelNormal, check(500))
+	assert.True(t, b.AllowPayload(100))
+
+	t.Run("large payloads are rejected under pressure", func(t *testing.T) {
+		assert.Equal(t, LevelPressure, check(800))
+		assert.Equal(t, 1, shrunk)
+		assert.True(t, b.AllowPayload(10))
+		assert.False(t, b.AllowPayload(11))
+	})
+
+	t.Run("levels are left below a recovery margin", func(t *testing.T) {
+		assert.Equal(t, LevelCritical, check(950))
+		assert.Equal(t, LevelCritical, check(910))
+		assert.Equal(t, LevelPressure, check(890))
+		assert.Equal(t, LevelPressure, check(760))
+		assert.Equal(t, LevelNormal, check(740))
+		assert.True(t, b.AllowPayload(100))
+	})
+
+	t.Run("caches are shrunk when coming under pressure", func(t *testing.T) {
+		assert.Equal(t, LevelCritical, check(990))
+		assert.Equal(t, 2, shrunk)
+	})
+
+	assert.Equal(t, [][2]Level{
+		{LevelNormal, LevelPressure},
+		{LevelPressure, LevelCritical},
+		{LevelCritical, LevelPressure},
+		{LevelPressure, LevelNormal},
+		{LevelNormal, LevelCritical},
+	}, changes)
+}
+
+func TestNilBudget(t *testing.T) {
+	var b *Budget
+	b.OnShrink(func() {})
+	b.OnLevelChange(func(from, to Level, used uint64) {})
+	assert.Equal(t, LevelNormal, b.Level())
+	assert.True(t, b.AllowPayload(1<<30))
+	b.Run(nil)
+}
+
+func TestPublishEvents(t *testing.T) {
+	used := uint64(0)
+	b := newTestBudget(t, &used)
+	published := make(chan *pubsub.PublishRequest, 1)
+	PublishEvents(b, "sidecars", "app", func(req *pubsub.PublishRequest) error {
+		published <- req
+		return nil
+	})
+
+	used = 960
+	b.Check()
+	req := <-published
+	assert.Equal(t, "sidecars", req.Topic)
+
+	var envelope pubsub.CloudEventsEnvelope
+	require.NoError(t, json.Unmarshal(req.Data, &envelope))
+	assert.Equal(t, CloudEventType, envelope.Type)
+	data, err := json.Marshal(envelope.Data)
+	require.NoError(t, err)
+	var e Event
+	require.NoError(t, json.Unmarshal(data, &e))
+	assert.Equal(t, "critical", e.Level)
+	assert.Equal(t, "normal", e.PreviousLevel)
+	assert.Equal(t, uint64(960), e.UsedBytes)
+	assert.Equal(t, uint64(1000), e.LimitBytes)
+	assert.Equal(t, "app", e.AppID)
+}
@@ -9,11 +9,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/dapr/components-contrib/servicediscovery"
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/modes"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc"
@@ -26,15 +29,22 @@ import (
 
 const (
 	invokeRemoteRetryCount = 3
+	// defaultDeliveryBackoff is the delay before the first retry of a fire-and-forget invocation
+	defaultDeliveryBackoff = time.Second
 )
 
+var log = logger.NewLogger("dapr.runtime.messaging")
+
 // messageClientConnection is the function type to connect to the other
-// applications to send the message using service invocation.
-type messageClientConnection func(address, id string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error)
+// applications to send the message of a priority class using service invocation.
+type messageClientConnection func(address, id, priority string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error)
 
 // DirectMessaging is the API interface for invoking a remote app
 type DirectMessaging interface {
 	Invoke(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error)
+	InvokeStream(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error)
+	DeliveryStatus(messageID string) (DeliveryStatus, bool)
+	RemoteConnection(targetAppID string) (*grpc.ClientConn, error)
 }
 
 type directMessaging struct {
@@ -45,6 +55,9 @@ type directMessaging struct {
 	grpcPort            int
 	namespace           string
 	resolver            servicediscovery.Resolver
+	workerPools         map[string]workerPool
+	deliveries          *deliveryTracker
+	deliveryBackoff     time.Duration
 	tracingSpec         config.TracingSpec
 }
 
@@ -55,6 +68,7 @@ func NewDirectMessaging(
 	appChannel channel.AppChannel,
 	clientConnFn messageClientConnection,
 	resolver servicediscovery.Resolver,
+	priorityClasses []config.PriorityClassSpec,
 	tracingSpec config.TracingSpec) DirectMessaging {
 	return &directMessaging{
 		appChannel:          appChannel,
@@ -64,16 +78,56 @@ func NewDirectMessaging(
 		grpcPort:            port,
 		namespace:           namespace,
 		resolver:            resolver,
+		workerPools:         newWorkerPools(priorityClasses),
+		deliveries:          newDeliveryTracker(),
+		deliveryBackoff:     defaultDeliveryBackoff,
 		tracingSpec:         tracingSpec,
 	}
 }
 
-// Invoke takes a message requests and invokes an app, either local or remote
+// Invoke takes a message requests and invokes an app, either local or remote.
+// Fire-and-forget invocations are acknowledged with their message ID and delivered in the background.
 func (d *directMessaging) Invoke(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	// retries are told apart from new requests by the callee with the attempt metadata, which callers can't set
+	req = req.WithAttempt(1, time.Now())
+	if req.FireAndForget() {
+		return d.invokeAsync(ctx, targetAppID, req)
+	}
+	return d.invoke(ctx, targetAppID, req)
+}
+
+func (d *directMessaging) invoke(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	resp, err := d.invokeTarget(ctx, targetAppID, req)
+	var responseSize int
+	if resp != nil {
+		responseSize = len(resp.Message().GetData().GetValue())
+	}
+	diag.DefaultMonitoring.ServiceInvocationPayload(diag.InvocationOutbound, targetAppID, int64(len(req.Message().GetData().GetValue())), int64(responseSize))
+	return resp, err
+}
+
+func (d *directMessaging) invokeTarget(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
 	if targetAppID == d.appID {
 		return d.invokeLocal(ctx, req)
 	}
-	return d.invokeWithRetry(ctx, invokeRemoteRetryCount, targetAppID, d.invokeRemote, req)
+
+	// each priority class waits for its own workers, so high priority calls aren't starved by bulk traffic
+	pool := d.workerPools[req.Priority()]
+	if err := pool.acquire(ctx); err != nil {
+		return nil, status.Errorf(codes.ResourceExhausted, "no worker available for %s priority invocation: %s", req.Priority(), err)
+	}
+	defer pool.release()
+
+	invoke := func(ctx context.Context) (*invokev1.InvokeMethodResponse, error) {
+		return d.invokeWithRetry(ctx, invokeRemoteRetryCount, targetAppID, d.invokeRemote, req)
+	}
+	// the hedged call resolves the target again, reaching another replica when the resolver balances between them
+	if delay, ok := req.HedgeDelay(); ok {
+		return invokev1.Hedge(ctx, delay, invoke, func(won bool) {
+			diag.DefaultMonitoring.RequestHedged("service_invocation", won)
+		})
+	}
+	return invoke(ctx)
 }
 
 // invokeWithRetry will call a remote endpoint for the specified number of retries and will only retry in the case of transient failures
@@ -85,8 +139,12 @@ func (d *directMessaging) invokeWithRetry(
 	targetID string,
 	fn func(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error),
 	req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	attempt, originalTime, ok := req.Attempt()
+	if !ok {
+		attempt, originalTime = 1, time.Now()
+	}
 	for i := 0; i < numRetries; i++ {
-		resp, err := fn(ctx, targetID, req)
+		resp, err := fn(ctx, targetID, req.WithAttempt(attempt+i, originalTime))
 		if err == nil {
 			return resp, nil
 		}
@@ -97,7 +155,7 @@ func (d *directMessaging) invokeWithRetry(
 			if addErr != nil {
 				return nil, addErr
 			}
-			_, connErr := d.connectionCreatorFn(address, targetID, false, true)
+			_, connErr := d.connectionCreatorFn(address, targetID, req.Priority(), false, true)
 			if connErr != nil {
 				return nil, connErr
 			}
@@ -113,7 +171,7 @@ func (d *directMessaging) invokeLocal(ctx context.Context, req *invokev1.InvokeM
 		return nil, errors.New("cannot invoke local endpoint: app channel not initialized")
 	}
 
-	return d.appChannel.InvokeMethod(ctx, req)
+	return d.appChannel.InvokeMethod(channel.WithOperation(ctx, channel.OperationInvocation), req)
 }
 
 func (d *directMessaging) invokeRemote(ctx context.Context, targetID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
@@ -122,7 +180,7 @@ func (d *directMessaging) invokeRemote(ctx context.Context, targetID string, req
 		return nil, err
 	}
 
-	conn, err := d.connectionCreatorFn(address, targetID, false, false)
+	conn, err := d.connectionCreatorFn(address, targetID, req.Priority(), false, false)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +195,7 @@ func (d *directMessaging) invokeRemote(ctx context.Context, targetID string, req
 
 	ctx = diag.AppendToOutgoingGRPCContext(ctx, span.SpanContext())
 	clientV1 := internalv1pb.NewDaprInternalClient(conn)
-	resp, err := clientV1.CallLocal(ctx, req.Proto())
+	resp, err := clientV1.CallLocal(ctx, req.WithTargetAppID(targetID).Proto())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,33 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvokeRecordsPayloads(t *testing.T) {
+	d := &directMessaging{appChannel: &flakyAppChannel{}, appID: "payloads"}
+	req := invokev1.NewInvokeMethodRequest("method").WithRawData([]byte("0123456789"), "text/plain")
+
+	_, err := d.Invoke(context.Background(), "payloads", req)
+	assert.NoError(t, err)
+
+	for _, p := range diag.DefaultInvocationPayloads.Top(0) {
+		if p.AppID == "payloads" {
+			assert.Equal(t, diag.InvocationOutbound, p.Direction)
+			assert.Equal(t, int64(1), p.Calls)
+			assert.Equal(t, int64(10), p.RequestBytes)
+			return
+		}
+	}
+	assert.Fail(t, "the invocation payloads aren't recorded")
+}
@@ -0,0 +1,172 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/google/uuid"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DeliveryPending is the status of a fire-and-forget invocation that is being delivered
+	DeliveryPending = "pending"
+	// DeliveryDelivered is the status of a fire-and-forget invocation the target app accepted
+	DeliveryDelivered = "delivered"
+	// DeliveryFailed is the status of a fire-and-forget invocation that failed after all its attempts
+	DeliveryFailed = "failed"
+
+	fireAndForgetMaxAttempts    = 5
+	fireAndForgetAttemptTimeout = time.Minute
+	// maxTrackedDeliveries bounds the statuses kept for lookup. The oldest completed deliveries are forgotten first.
+	maxTrackedDeliveries = 1000
+)
+
+// DeliveryStatus is the status of a fire-and-forget invocation
+type DeliveryStatus struct {
+	MessageID   string    `json:"messageId"`
+	TargetAppID string    `json:"targetAppId"`
+	Method      string    `json:"method"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type fireAndForgetResponse struct {
+	MessageID string `json:"messageId"`
+}
+
+// deliveryTracker keeps the statuses of the recent fire-and-forget invocations
+type deliveryTracker struct {
+	lock     sync.Mutex
+	statuses map[string]*DeliveryStatus
+	order    []string
+}
+
+func newDeliveryTracker() *deliveryTracker {
+	return &deliveryTracker{
+		statuses: map[string]*DeliveryStatus{},
+	}
+}
+
+func (t *deliveryTracker) add(s *DeliveryStatus) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.order) >= maxTrackedDeliveries {
+		for i, id := range t.order {
+			if t.statuses[id].Status != DeliveryPending {
+				delete(t.statuses, id)
+				t.order = append(t.order[:i], t.order[i+1:]...)
+				break
+			}
+		}
+	}
+	t.statuses[s.MessageID] = s
+	t.order = append(t.order, s.MessageID)
+}
+
+func (t *deliveryTracker) update(messageID string, fn func(s *DeliveryStatus)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if s, ok := t.statuses[messageID]; ok {
+		fn(s)
+		s.UpdatedAt = time.Now().UTC()
+	}
+}
+
+func (t *deliveryTracker) get(messageID string) (DeliveryStatus, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	s, ok := t.statuses[messageID]
+	if !ok {
+		return DeliveryStatus{}, false
+	}
+	return *s, true
+}
+
+// DeliveryStatus returns the status of a fire-and-forget invocation of this sidecar
+func (d *directMessaging) DeliveryStatus(messageID string) (DeliveryStatus, bool) {
+	return d.deliveries.get(messageID)
+}
+
+// invokeAsync acknowledges the invocation with its message ID and delivers it in the background
+func (d *directMessaging) invokeAsync(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	messageID := uuid.New().String()
+	body, err := json.Marshal(fireAndForgetResponse{MessageID: messageID})
+	if err != nil {
+		return nil, err
+	}
+
+	d.deliveries.add(&DeliveryStatus{
+		MessageID:   messageID,
+		TargetAppID: targetAppID,
+		Method:      req.Message().GetMethod(),
+		Status:      DeliveryPending,
+		UpdatedAt:   time.Now().UTC(),
+	})
+	// the delivery outlives the request, only its span is kept
+	go d.deliver(trace.NewContext(context.Background(), trace.FromContext(ctx)), messageID, targetAppID, req)
+
+	resp := invokev1.NewInvokeMethodResponse(http.StatusAccepted, http.StatusText(http.StatusAccepted), nil)
+	resp.WithRawData(body, invokev1.JSONContentType)
+	resp.WithHeaders(metadata.Pairs(invokev1.MessageIDHeader, messageID))
+	return resp, nil
+}
+
+// deliver invokes the target until it accepts the invocation, backing off exponentially between attempts
+func (d *directMessaging) deliver(ctx context.Context, messageID, targetAppID string, req *invokev1.InvokeMethodRequest) {
+	backoff := d.deliveryBackoff
+	_, acceptedAt, ok := req.Attempt()
+	if !ok {
+		acceptedAt = time.Now()
+	}
+	var lastErr error
+	for attempt := 1; attempt <= fireAndForgetMaxAttempts; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, fireAndForgetAttemptTimeout)
+		resp, err := d.invoke(attemptCtx, targetAppID, req.WithAttempt(attempt, acceptedAt))
+		cancel()
+		if err == nil && !resp.IsSuccess() {
+			err = fmt.Errorf("target responded with status %v", resp.Status().GetCode())
+		}
+
+		d.deliveries.update(messageID, func(s *DeliveryStatus) {
+			s.Attempts = attempt
+			if err == nil {
+				s.Status = DeliveryDelivered
+				s.Error = ""
+			} else {
+				s.Error = err.Error()
+			}
+		})
+		if err == nil {
+			return
+		}
+
+		lastErr = err
+		log.Debugf("fire-and-forget invocation %s of %s failed on attempt %v: %s", messageID, targetAppID, attempt, err)
+		if attempt < fireAndForgetMaxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	log.Warnf("fire-and-forget invocation %s of %s failed after %v attempts: %s", messageID, targetAppID, fireAndForgetMaxAttempts, lastErr)
+	d.deliveries.update(messageID, func(s *DeliveryStatus) {
+		s.Status = DeliveryFailed
+	})
+}
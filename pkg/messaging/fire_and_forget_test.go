@@ -0,0 +1,90 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyAppChannel fails the first invocations it receives
+type flakyAppChannel struct {
+	failures int32
+	calls    int32
+	attempts []int
+}
+
+func (f *flakyAppChannel) GetBaseAddress() string {
+	return ""
+}
+
+func (f *flakyAppChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	attempt, _, _ := req.Attempt()
+	f.attempts = append(f.attempts, attempt)
+	if atomic.AddInt32(&f.calls, 1) <= f.failures {
+		return invokev1.NewInvokeMethodResponse(503, "Service Unavailable", nil), nil
+	}
+	return invokev1.NewInvokeMethodResponse(200, "OK", nil), nil
+}
+
+func TestInvokeFireAndForget(t *testing.T) {
+	newMessaging := func(failures int32) *directMessaging {
+		return &directMessaging{
+			appChannel:      &flakyAppChannel{failures: failures},
+			appID:           "app",
+			deliveries:      newDeliveryTracker(),
+			deliveryBackoff: time.Millisecond,
+		}
+	}
+	waitForDelivery := func(d *directMessaging, messageID string) DeliveryStatus {
+		var s DeliveryStatus
+		assert.Eventually(t, func() bool {
+			s, _ = d.DeliveryStatus(messageID)
+			return s.Status != DeliveryPending
+		}, time.Second, 10*time.Millisecond)
+		return s
+	}
+	req := invokev1.NewInvokeMethodRequest("method").WithMetadata(map[string][]string{invokev1.FireAndForgetHeader: {"true"}})
+
+	t.Run("acknowledges with the message ID and retries until delivered", func(t *testing.T) {
+		d := newMessaging(2)
+		resp, err := d.Invoke(context.Background(), "app", req)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(202), resp.Status().GetCode())
+
+		messageID := resp.Headers()[invokev1.MessageIDHeader].GetValues()[0].GetStringValue()
+		_, body := resp.RawData()
+		assert.JSONEq(t, `{"messageId":"`+messageID+`"}`, string(body))
+
+		s := waitForDelivery(d, messageID)
+		assert.Equal(t, DeliveryDelivered, s.Status)
+		assert.Equal(t, 3, s.Attempts)
+		assert.Equal(t, []int{1, 2, 3}, d.appChannel.(*flakyAppChannel).attempts)
+		assert.Equal(t, "method", s.Method)
+		assert.Empty(t, s.Error)
+	})
+
+	t.Run("fails after the last attempt", func(t *testing.T) {
+		d := newMessaging(fireAndForgetMaxAttempts)
+		resp, err := d.Invoke(context.Background(), "app", req)
+		assert.NoError(t, err)
+
+		s := waitForDelivery(d, resp.Headers()[invokev1.MessageIDHeader].GetValues()[0].GetStringValue())
+		assert.Equal(t, DeliveryFailed, s.Status)
+		assert.Equal(t, fireAndForgetMaxAttempts, s.Attempts)
+		assert.NotEmpty(t, s.Error)
+	})
+
+	t.Run("unknown message ID", func(t *testing.T) {
+		_, ok := newMessaging(0).DeliveryStatus("unknown")
+		assert.False(t, ok)
+	})
+}
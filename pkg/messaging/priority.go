@@ -0,0 +1,49 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"context"
+
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+// workerPool limits the concurrent invocations of a priority class. A nil pool is unlimited.
+type workerPool chan struct{}
+
+func (p workerPool) acquire(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	select {
+	case p <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p workerPool) release() {
+	if p != nil {
+		<-p
+	}
+}
+
+// newWorkerPools returns the worker pools of the priority classes with a concurrency limit
+func newWorkerPools(classes []config.PriorityClassSpec) map[string]workerPool {
+	pools := map[string]workerPool{}
+	for _, c := range classes {
+		if !invokev1.IsPriority(c.Name) {
+			log.Warnf("ignoring unknown invocation priority class %s", c.Name)
+			continue
+		}
+		if c.MaxConcurrency > 0 {
+			pools[c.Name] = make(workerPool, c.MaxConcurrency)
+		}
+	}
+	return pools
+}
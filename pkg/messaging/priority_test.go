@@ -0,0 +1,42 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPools(t *testing.T) {
+	pools := newWorkerPools([]config.PriorityClassSpec{
+		{Name: invokev1.PriorityLow, MaxConcurrency: 1},
+		{Name: invokev1.PriorityNormal},
+		{Name: "urgent", MaxConcurrency: 1},
+	})
+	assert.Len(t, pools, 1)
+
+	t.Run("full pool doesn't block other classes", func(t *testing.T) {
+		low := pools[invokev1.PriorityLow]
+		assert.NoError(t, low.acquire(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		assert.Error(t, low.acquire(ctx))
+
+		high := pools[invokev1.PriorityHigh]
+		assert.NoError(t, high.acquire(context.Background()))
+		high.release()
+
+		low.release()
+		assert.NoError(t, low.acquire(context.Background()))
+		low.release()
+	})
+}
@@ -0,0 +1,20 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"google.golang.org/grpc"
+)
+
+// RemoteConnection returns the connection to the sidecar of a remote app, to proxy the gRPC calls of the app to it
+func (d *directMessaging) RemoteConnection(targetAppID string) (*grpc.ClientConn, error) {
+	address, err := d.getAddressFromMessageRequest(targetAppID)
+	if err != nil {
+		return nil, err
+	}
+	return d.connectionCreatorFn(address, targetAppID, invokev1.PriorityNormal, false, false)
+}
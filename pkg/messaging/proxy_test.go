@@ -0,0 +1,33 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"testing"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestRemoteConnection(t *testing.T) {
+	var gotAddress, gotID, gotPriority string
+	d := &directMessaging{
+		appID:    "app",
+		resolver: staticResolver("10.0.0.1:50002"),
+		connectionCreatorFn: func(address, id, priority string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error) {
+			gotAddress, gotID, gotPriority = address, id, priority
+			return &grpc.ClientConn{}, nil
+		},
+	}
+
+	conn, err := d.RemoteConnection("remote")
+	assert.NoError(t, err)
+	assert.NotNil(t, conn)
+	assert.Equal(t, "10.0.0.1:50002", gotAddress)
+	assert.Equal(t, "remote", gotID)
+	assert.Equal(t, invokev1.PriorityNormal, gotPriority)
+}
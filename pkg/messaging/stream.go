@@ -0,0 +1,126 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/dapr/dapr/pkg/channel"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InvokeStream invokes an app, either local or remote, streaming the request body and the response body, which must be
+// closed. Streamed invocations aren't retried nor hedged, as their body can't be replayed.
+func (d *directMessaging) InvokeStream(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	if req.FireAndForget() {
+		return nil, nil, status.Error(codes.InvalidArgument, "streamed invocations can't be fire-and-forget")
+	}
+	req = req.WithAttempt(1, time.Now())
+
+	if targetAppID == d.appID {
+		if d.appChannel == nil {
+			return nil, nil, errors.New("cannot invoke local endpoint: app channel not initialized")
+		}
+		return channel.InvokeMethodStream(channel.WithOperation(ctx, channel.OperationInvocation), d.appChannel, req, body)
+	}
+
+	// the worker is held until the response body is closed
+	pool := d.workerPools[req.Priority()]
+	if err := pool.acquire(ctx); err != nil {
+		return nil, nil, status.Errorf(codes.ResourceExhausted, "no worker available for %s priority invocation: %s", req.Priority(), err)
+	}
+	resp, respBody, err := d.invokeRemoteStream(ctx, targetAppID, req, body)
+	if err != nil {
+		pool.release()
+		return nil, nil, err
+	}
+	return resp, channel.WithCloseHook(respBody, pool.release), nil
+}
+
+func (d *directMessaging) invokeRemoteStream(ctx context.Context, targetID string, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	address, err := d.getAddressFromMessageRequest(targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := d.connectionCreatorFn(address, targetID, req.Priority(), false, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the call lasts as long as the transfer, so it has no timeout but is canceled when the response body is closed
+	ctx, cancel := context.WithCancel(ctx)
+	ctx, span := diag.StartTracingClientSpanFromGRPCContext(ctx, req.Message().Method, d.tracingSpec)
+	end := func(err error) {
+		diag.UpdateSpanPairStatusesFromError(span, err, req.Message().Method)
+		span.End()
+		cancel()
+	}
+
+	ctx = diag.AppendToOutgoingGRPCContext(ctx, span.SpanContext())
+	stream, err := internalv1pb.NewDaprInternalClient(conn).CallLocalStream(ctx)
+	if err != nil {
+		end(err)
+		return nil, nil, err
+	}
+	if err = stream.Send(&internalv1pb.InternalInvokeRequestStream{Request: req.WithTargetAppID(targetID).Proto()}); err != nil {
+		end(err)
+		return nil, nil, err
+	}
+
+	go func() {
+		err := invokev1.SendChunks(body, func(chunk []byte) error {
+			return stream.Send(&internalv1pb.InternalInvokeRequestStream{Chunk: chunk})
+		})
+		switch {
+		case err == io.EOF:
+			// the callee responded without reading the whole request
+		case err != nil:
+			// the call is canceled for the callee not to take the truncated body for the whole one
+			log.Debugf("error streaming the request to %s: %s", targetID, err)
+			cancel()
+		default:
+			stream.CloseSend()
+		}
+	}()
+
+	first, err := stream.Recv()
+	if err == nil && first.GetResponse() == nil {
+		err = status.Error(codes.Internal, "the streamed invocation response has no header")
+	}
+	if err != nil {
+		end(err)
+		return nil, nil, err
+	}
+	resp, err := invokev1.InternalInvokeResponse(first.GetResponse())
+	if err != nil {
+		end(err)
+		return nil, nil, err
+	}
+
+	var recvErr error
+	respBody := invokev1.NewChunkReader(func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF {
+				recvErr = err
+			}
+			return nil, err
+		}
+		return msg.GetChunk(), nil
+	})
+	return resp, channel.WithCloseHook(ioutil.NopCloser(respBody), func() {
+		end(recvErr)
+	}), nil
+}
@@ -0,0 +1,134 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/dapr/components-contrib/servicediscovery"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// echoAppChannel streams the request body back
+type echoAppChannel struct {
+	flakyAppChannel
+}
+
+func (e *echoAppChannel) InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest, body io.Reader) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	return invokev1.NewInvokeMethodResponse(200, "OK", nil), ioutil.NopCloser(body), nil
+}
+
+// echoInternalServer streams the request data of CallLocalStream back, like the sidecar of an echoing app
+type echoInternalServer struct {
+	internalv1pb.UnimplementedDaprInternalServer
+}
+
+func (e *echoInternalServer) CallLocalStream(stream internalv1pb.DaprInternal_CallLocalStreamServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	req, err := invokev1.InternalInvokeRequest(first.GetRequest())
+	if err != nil {
+		return err
+	}
+	resp := invokev1.NewInvokeMethodResponse(200, "OK", nil).WithRawData(nil, req.Message().GetContentType())
+	if err := stream.Send(&internalv1pb.InternalInvokeResponseStream{Response: resp.Proto()}); err != nil {
+		return err
+	}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&internalv1pb.InternalInvokeResponseStream{Chunk: msg.GetChunk()}); err != nil {
+			return err
+		}
+	}
+}
+
+type staticResolver string
+
+func (s staticResolver) ResolveID(req servicediscovery.ResolveRequest) (string, error) {
+	return string(s), nil
+}
+
+func TestInvokeStream(t *testing.T) {
+	data := strings.Repeat("x", 3*invokev1.StreamChunkSize+1)
+	req := invokev1.NewInvokeMethodRequest("method").WithRawData(nil, "application/octet-stream")
+
+	t.Run("local app", func(t *testing.T) {
+		d := &directMessaging{appChannel: &echoAppChannel{}, appID: "app"}
+		_, body, err := d.InvokeStream(context.Background(), "app", req, strings.NewReader(data))
+		require.NoError(t, err)
+		defer body.Close()
+
+		got, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, data, string(got))
+	})
+
+	t.Run("local app without streaming", func(t *testing.T) {
+		d := &directMessaging{appChannel: &flakyAppChannel{}, appID: "app"}
+		_, _, err := d.InvokeStream(context.Background(), "app", req, strings.NewReader(data))
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("remote app", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		server := grpc.NewServer()
+		internalv1pb.RegisterDaprInternalServer(server, &echoInternalServer{})
+		go server.Serve(lis)
+		defer server.Stop()
+
+		conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		pool := make(workerPool, 1)
+		d := &directMessaging{
+			appID:    "app",
+			resolver: staticResolver(lis.Addr().String()),
+			connectionCreatorFn: func(address, id, priority string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error) {
+				return conn, nil
+			},
+			workerPools: map[string]workerPool{req.Priority(): pool},
+		}
+		resp, body, err := d.InvokeStream(context.Background(), "remote", req, strings.NewReader(data))
+		require.NoError(t, err)
+		contentType, _ := resp.RawData()
+		assert.Equal(t, "application/octet-stream", contentType)
+		assert.Len(t, pool, 1, "the worker is held until the body is closed")
+
+		got, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, data, string(got))
+		body.Close()
+		assert.Len(t, pool, 0)
+	})
+
+	t.Run("fire-and-forget", func(t *testing.T) {
+		d := &directMessaging{appChannel: &echoAppChannel{}, appID: "app"}
+		req := invokev1.NewInvokeMethodRequest("method").WithMetadata(map[string][]string{invokev1.FireAndForgetHeader: {"true"}})
+		_, _, err := d.InvokeStream(context.Background(), "app", req, strings.NewReader(data))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
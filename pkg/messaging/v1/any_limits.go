@@ -0,0 +1,139 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+// DefaultMaxAnyDepth is the number of Any payloads nested in each other allowed by default. The data of an invocation
+// request is an Any nested in the Any of the request.
+const DefaultMaxAnyDepth = 8
+
+const (
+	// field numbers of Any and InvokeRequest
+	anyTypeURLField     = 1
+	anyValueField       = 2
+	invokeRequestData   = 2
+	wireVarint          = 0
+	wireFixed64         = 1
+	wireLengthDelimited = 2
+	wireFixed32         = 5
+)
+
+var (
+	anyMessageName           = proto.MessageName(&any.Any{})
+	invokeRequestMessageName = proto.MessageName(&commonv1pb.InvokeRequest{})
+	errMalformedPayload      = errors.New("malformed payload")
+)
+
+// AnyLimits bound the Any payloads of the invocation requests and the Any payloads nested in them
+type AnyLimits struct {
+	// MaxDepth is the number of Any payloads nested in each other allowed, DefaultMaxAnyDepth when zero
+	MaxDepth int
+	// MaxSize is the size of the largest payload in bytes, unlimited when zero
+	MaxSize int
+}
+
+// Check returns an error when the payload, or a payload nested in it, exceeds the limits. The payloads are walked on
+// the wire, so that malicious payloads are rejected before they're unmarshaled.
+func (l AnyLimits) Check(payload *any.Any) error {
+	if payload == nil {
+		return nil
+	}
+	maxDepth := l.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultMaxAnyDepth
+	}
+	return l.check(payload.GetTypeUrl(), payload.GetValue(), 1, maxDepth)
+}
+
+func (l AnyLimits) check(typeURL string, value []byte, depth, maxDepth int) error {
+	if depth > maxDepth {
+		return fmt.Errorf("payload nests more than %d Any payloads", maxDepth)
+	}
+	if l.MaxSize > 0 && len(value) > l.MaxSize {
+		return fmt.Errorf("payload of %d bytes is larger than %d bytes", len(value), l.MaxSize)
+	}
+
+	var nested []byte
+	switch typeURL[strings.LastIndex(typeURL, "/")+1:] {
+	case anyMessageName:
+		nested = value
+	case invokeRequestMessageName:
+		// the occurrences of a message field are merged, like the concatenation of their bytes
+		data, err := lengthDelimitedField(value, invokeRequestData, true)
+		if err != nil || data == nil {
+			return err
+		}
+		nested = data
+	default:
+		// the payloads of the apps are opaque
+		return nil
+	}
+
+	nestedTypeURL, err := lengthDelimitedField(nested, anyTypeURLField, false)
+	if err != nil {
+		return err
+	}
+	nestedValue, err := lengthDelimitedField(nested, anyValueField, false)
+	if err != nil {
+		return err
+	}
+	return l.check(string(nestedTypeURL), nestedValue, depth+1, maxDepth)
+}
+
+// lengthDelimitedField returns the value of a length-delimited field of the wire bytes of a message, nil when the field
+// isn't set: the last occurrence of the field, or their concatenation when merged
+func lengthDelimitedField(b []byte, field uint64, merged bool) ([]byte, error) {
+	var result []byte
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errMalformedPayload
+		}
+		b = b[n:]
+
+		var size uint64
+		switch key & 7 {
+		case wireVarint:
+			if _, n = binary.Uvarint(b); n <= 0 {
+				return nil, errMalformedPayload
+			}
+			size = uint64(n)
+		case wireFixed64:
+			size = 8
+		case wireFixed32:
+			size = 4
+		case wireLengthDelimited:
+			if size, n = binary.Uvarint(b); n <= 0 {
+				return nil, errMalformedPayload
+			}
+			b = b[n:]
+		default:
+			return nil, errMalformedPayload
+		}
+		if size > uint64(len(b)) {
+			return nil, errMalformedPayload
+		}
+		if key>>3 == field && key&7 == wireLengthDelimited {
+			if merged {
+				result = append(result, b[:size]...)
+			} else {
+				result = b[:size]
+			}
+		}
+		b = b[size:]
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"testing"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nestedAny returns a payload of n Any payloads nested in each other
+func nestedAny(t *testing.T, n int) *any.Any {
+	payload, err := ptypes.MarshalAny(&wrappers.StringValue{Value: "payload"})
+	require.NoError(t, err)
+	for i := 1; i < n; i++ {
+		payload, err = ptypes.MarshalAny(payload)
+		require.NoError(t, err)
+	}
+	return payload
+}
+
+func invokeRequestAny(t *testing.T, data *any.Any) *any.Any {
+	payload, err := ptypes.MarshalAny(&commonv1pb.InvokeRequest{Method: "method", Data: data})
+	require.NoError(t, err)
+	return payload
+}
+
+func TestAnyLimits(t *testing.T) {
+	t.Run("invocation request within the defaults", func(t *testing.T) {
+		assert.NoError(t, AnyLimits{}.Check(invokeRequestAny(t, nestedAny(t, 1))))
+		assert.NoError(t, AnyLimits{}.Check(invokeRequestAny(t, nil)))
+		assert.NoError(t, AnyLimits{}.Check(nil))
+	})
+
+	t.Run("nested too deep", func(t *testing.T) {
+		limits := AnyLimits{MaxDepth: 4}
+		assert.NoError(t, limits.Check(invokeRequestAny(t, nestedAny(t, 3))))
+		assert.Error(t, limits.Check(invokeRequestAny(t, nestedAny(t, 4))))
+		assert.Error(t, AnyLimits{}.Check(nestedAny(t, DefaultMaxAnyDepth+1)))
+	})
+
+	t.Run("payload too large", func(t *testing.T) {
+		data, err := ptypes.MarshalAny(&wrappers.BytesValue{Value: make([]byte, 1024)})
+		require.NoError(t, err)
+		assert.NoError(t, AnyLimits{MaxSize: 2048}.Check(invokeRequestAny(t, data)))
+		assert.Error(t, AnyLimits{MaxSize: 512}.Check(invokeRequestAny(t, data)))
+	})
+
+	t.Run("data split across occurrences", func(t *testing.T) {
+		// the type url and the value of the data are merged from two occurrences of the field
+		typeURL, err := proto.Marshal(&any.Any{TypeUrl: "type.googleapis.com/" + anyMessageName})
+		require.NoError(t, err)
+		value, err := proto.Marshal(&any.Any{Value: mustMarshal(t, nestedAny(t, 8))})
+		require.NoError(t, err)
+		b := proto.NewBuffer(nil)
+		for _, occurrence := range [][]byte{typeURL, value} {
+			require.NoError(t, b.EncodeVarint(invokeRequestData<<3|wireLengthDelimited))
+			require.NoError(t, b.EncodeRawBytes(occurrence))
+		}
+		payload := &any.Any{TypeUrl: "type.googleapis.com/" + invokeRequestMessageName, Value: b.Bytes()}
+
+		var req commonv1pb.InvokeRequest
+		require.NoError(t, ptypes.UnmarshalAny(payload, &req))
+		assert.Equal(t, "type.googleapis.com/"+anyMessageName, req.GetData().GetTypeUrl())
+		assert.Error(t, AnyLimits{MaxDepth: 4}.Check(payload))
+	})
+
+	t.Run("malformed payload", func(t *testing.T) {
+		payload := &any.Any{TypeUrl: "type.googleapis.com/" + invokeRequestMessageName, Value: []byte{0x12, 0xff}}
+		assert.Error(t, AnyLimits{}.Check(payload))
+	})
+
+	t.Run("payloads of the apps are opaque", func(t *testing.T) {
+		payload := &any.Any{TypeUrl: "type.googleapis.com/app.Message", Value: []byte{0x12, 0xff}}
+		assert.NoError(t, AnyLimits{}.Check(payload))
+	})
+}
+
+func mustMarshal(t *testing.T, m proto.Message) []byte {
+	b, err := proto.Marshal(m)
+	require.NoError(t, err)
+	return b
+}
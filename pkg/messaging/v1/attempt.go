@@ -0,0 +1,71 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+const (
+	// AttemptHeader is the header of the attempt number of an invocation, starting at 1. Retries have a greater number.
+	AttemptHeader = "dapr-attempt"
+	// OriginalTimestampHeader is the header of the time of the first attempt of an invocation, in RFC 3339 format
+	OriginalTimestampHeader = "dapr-original-timestamp"
+)
+
+// Attempt returns the attempt number of the request and the time of its first attempt, if the request carries them
+func (imr *InvokeMethodRequest) Attempt() (int, time.Time, bool) {
+	var attempt, timestamp string
+	for k, v := range imr.Metadata() {
+		if len(v.GetValues()) == 0 {
+			continue
+		}
+		switch strings.ToLower(k) {
+		case AttemptHeader:
+			attempt = v.Values[0].GetStringValue()
+		case OriginalTimestampHeader:
+			timestamp = v.Values[0].GetStringValue()
+		}
+	}
+
+	n, err := strconv.Atoi(attempt)
+	if err != nil || n < 1 {
+		return 0, time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return n, t, true
+}
+
+// WithAttempt returns a copy of the request carrying the attempt number and the time of the first attempt, replacing
+// the ones sent by the caller. The copy shares the message of the request, so that concurrent attempts don't race.
+func (imr *InvokeMethodRequest) WithAttempt(attempt int, originalTime time.Time) *InvokeMethodRequest {
+	r := *imr.r
+	r.Metadata = DaprInternalMetadata{}
+	for k, v := range imr.r.Metadata {
+		switch strings.ToLower(k) {
+		case AttemptHeader, OriginalTimestampHeader:
+			continue
+		}
+		r.Metadata[k] = v
+	}
+
+	for k, v := range map[string]string{
+		AttemptHeader:           strconv.Itoa(attempt),
+		OriginalTimestampHeader: originalTime.UTC().Format(time.RFC3339Nano),
+	} {
+		r.Metadata[k] = &structpb.ListValue{
+			Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: v}}},
+		}
+	}
+	return &InvokeMethodRequest{r: &r, m: imr.m}
+}
@@ -0,0 +1,49 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttempt(t *testing.T) {
+	t.Run("request without attempt metadata", func(t *testing.T) {
+		_, _, ok := NewInvokeMethodRequest("method").Attempt()
+		assert.False(t, ok)
+	})
+
+	t.Run("attempt metadata replaces the one sent by the caller", func(t *testing.T) {
+		original := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+		req := NewInvokeMethodRequest("method").WithMetadata(map[string][]string{
+			"Dapr-Attempt": {"7"},
+			"x-custom":     {"value"},
+		})
+
+		retry := req.WithAttempt(2, original)
+		attempt, timestamp, ok := retry.Attempt()
+		assert.True(t, ok)
+		assert.Equal(t, 2, attempt)
+		assert.True(t, original.Equal(timestamp))
+		assert.Len(t, retry.Metadata(), 3)
+		assert.Equal(t, "value", retry.Metadata()["x-custom"].GetValues()[0].GetStringValue())
+
+		// the original request is left untouched
+		assert.Equal(t, "7", req.Metadata()["Dapr-Attempt"].GetValues()[0].GetStringValue())
+		assert.Same(t, req.Message(), retry.Message())
+	})
+
+	t.Run("invalid attempt metadata", func(t *testing.T) {
+		req := NewInvokeMethodRequest("method").WithMetadata(map[string][]string{
+			AttemptHeader:           {"first"},
+			OriginalTimestampHeader: {"2020-06-01T10:00:00Z"},
+		})
+		_, _, ok := req.Attempt()
+		assert.False(t, ok)
+	})
+}
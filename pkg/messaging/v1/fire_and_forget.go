@@ -0,0 +1,39 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// FireAndForgetHeader marks an invocation that is acknowledged by the calling sidecar and delivered asynchronously
+	FireAndForgetHeader = "dapr-fire-and-forget"
+	// MessageIDHeader is the response header of the ID of a fire-and-forget invocation
+	MessageIDHeader = "dapr-message-id"
+)
+
+// FireAndForget returns true if the caller doesn't wait for the response of the invocation
+func (imr *InvokeMethodRequest) FireAndForget() bool {
+	for k, v := range imr.Metadata() {
+		if len(v.GetValues()) == 0 || strings.ToLower(k) != FireAndForgetHeader {
+			continue
+		}
+		enabled, _ := strconv.ParseBool(v.Values[0].GetStringValue())
+		return enabled
+	}
+	return false
+}
+
+// IsSuccess returns true if the response has a successful HTTP or gRPC status
+func (imr *InvokeMethodResponse) IsSuccess() bool {
+	code := imr.Status().GetCode()
+	if imr.IsHTTPResponse() {
+		return code >= 200 && code < 300
+	}
+	return code == 0
+}
@@ -0,0 +1,35 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFireAndForget(t *testing.T) {
+	t.Run("default waits for the response", func(t *testing.T) {
+		assert.False(t, NewInvokeMethodRequest("method").FireAndForget())
+	})
+
+	t.Run("header enables fire-and-forget", func(t *testing.T) {
+		req := NewInvokeMethodRequest("method").WithMetadata(map[string][]string{"Dapr-Fire-And-Forget": {"true"}})
+		assert.True(t, req.FireAndForget())
+	})
+
+	t.Run("invalid header value waits for the response", func(t *testing.T) {
+		req := NewInvokeMethodRequest("method").WithMetadata(map[string][]string{FireAndForgetHeader: {"yes please"}})
+		assert.False(t, req.FireAndForget())
+	})
+}
+
+func TestIsSuccess(t *testing.T) {
+	assert.True(t, NewInvokeMethodResponse(202, "Accepted", nil).IsSuccess())
+	assert.False(t, NewInvokeMethodResponse(500, "Internal Server Error", nil).IsSuccess())
+	assert.True(t, NewInvokeMethodResponse(0, "OK", nil).IsSuccess())
+	assert.False(t, NewInvokeMethodResponse(14, "Unavailable", nil).IsSuccess())
+}
@@ -0,0 +1,93 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HedgeHeader marks an invocation of a read-only method as safe to send more than once
+	HedgeHeader = "dapr-hedge"
+	// HedgeDelayHeader overrides the latency after which a hedged invocation is duplicated
+	HedgeDelayHeader = "dapr-hedge-delay"
+	// DefaultHedgeDelay is the latency after which a hedged invocation is duplicated
+	DefaultHedgeDelay = time.Millisecond * 100
+)
+
+// HedgeDelay returns the latency after which the request may be duplicated, if the caller marked it as safe to hedge
+func (imr *InvokeMethodRequest) HedgeDelay() (time.Duration, bool) {
+	var hedge, delay string
+	for k, v := range imr.Metadata() {
+		if len(v.GetValues()) == 0 {
+			continue
+		}
+		switch strings.ToLower(k) {
+		case HedgeHeader:
+			hedge = v.Values[0].GetStringValue()
+		case HedgeDelayHeader:
+			delay = v.Values[0].GetStringValue()
+		}
+	}
+
+	if enabled, _ := strconv.ParseBool(hedge); !enabled {
+		return 0, false
+	}
+	if d, err := time.ParseDuration(delay); err == nil && d > 0 {
+		return d, true
+	}
+	return DefaultHedgeDelay, true
+}
+
+type hedgeResult struct {
+	resp  *InvokeMethodResponse
+	err   error
+	hedge bool
+}
+
+// Hedge calls fn and calls it a second time if it hasn't returned after delay. The first successful response is
+// returned and the other call is cancelled. A failure of the first call before the delay is returned without hedging.
+// onHedged is called with whether the response of the second call was used, if a second call was made.
+func Hedge(
+	ctx context.Context,
+	delay time.Duration,
+	fn func(ctx context.Context) (*InvokeMethodResponse, error),
+	onHedged func(won bool)) (*InvokeMethodResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	call := func(hedge bool) {
+		resp, err := fn(ctx)
+		results <- hedgeResult{resp: resp, err: err, hedge: hedge}
+	}
+	go call(false)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	hedged := false
+	pending := 1
+	for {
+		select {
+		case <-timer.C:
+			hedged = true
+			pending++
+			go call(true)
+		case r := <-results:
+			pending--
+			if r.err == nil || pending == 0 {
+				if hedged && onHedged != nil {
+					onHedged(r.hedge)
+				}
+				return r.resp, r.err
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedgeDelay(t *testing.T) {
+	t.Run("not marked", func(t *testing.T) {
+		_, ok := NewInvokeMethodRequest("method").HedgeDelay()
+		assert.False(t, ok)
+	})
+
+	t.Run("default delay", func(t *testing.T) {
+		req := NewInvokeMethodRequest("method").WithMetadata(map[string][]string{"Dapr-Hedge": {"true"}})
+		delay, ok := req.HedgeDelay()
+		assert.True(t, ok)
+		assert.Equal(t, DefaultHedgeDelay, delay)
+	})
+
+	t.Run("custom delay", func(t *testing.T) {
+		req := NewInvokeMethodRequest("method").WithMetadata(map[string][]string{
+			HedgeHeader:      {"true"},
+			HedgeDelayHeader: {"20ms"},
+		})
+		delay, ok := req.HedgeDelay()
+		assert.True(t, ok)
+		assert.Equal(t, time.Millisecond*20, delay)
+	})
+}
+
+func TestHedge(t *testing.T) {
+	resp := NewInvokeMethodResponse(200, "OK", nil)
+
+	t.Run("fast response is not hedged", func(t *testing.T) {
+		var calls int32
+		hedged := false
+		r, err := Hedge(context.Background(), time.Second, func(ctx context.Context) (*InvokeMethodResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			return resp, nil
+		}, func(won bool) { hedged = true })
+
+		assert.NoError(t, err)
+		assert.Equal(t, resp, r)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.False(t, hedged)
+	})
+
+	t.Run("slow response is hedged and cancelled", func(t *testing.T) {
+		var calls int32
+		cancelled := make(chan struct{})
+		var hedgeWon bool
+		r, err := Hedge(context.Background(), time.Millisecond*10, func(ctx context.Context) (*InvokeMethodResponse, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				<-ctx.Done()
+				close(cancelled)
+				return nil, ctx.Err()
+			}
+			return resp, nil
+		}, func(won bool) { hedgeWon = won })
+
+		assert.NoError(t, err)
+		assert.Equal(t, resp, r)
+		assert.True(t, hedgeWon)
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			assert.Fail(t, "slow call was not cancelled")
+		}
+	})
+
+	t.Run("failure before the delay is returned", func(t *testing.T) {
+		var calls int32
+		_, err := Hedge(context.Background(), time.Second, func(ctx context.Context) (*InvokeMethodResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, errors.New("failed")
+		}, nil)
+
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
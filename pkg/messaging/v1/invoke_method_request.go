@@ -14,11 +14,23 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 )
 
 const (
 	// DefaultAPIVersion is the default Dapr API version
 	DefaultAPIVersion = internalv1pb.APIVersion_V1
+
+	// CallerAppIDHeader is the header of the app ID of the caller of an invocation. Like the other caller headers,
+	// it's taken from the mTLS certificate of the calling sidecar. In Kubernetes, Sentry signs the app ID of a sidecar
+	// only if a pod of its service account has the app ID, so the app ID is verified down to the service account.
+	// In self-hosted mode Sentry doesn't validate the sidecars, and the app ID and the namespace are the ones claimed
+	// by the calling sidecar.
+	CallerAppIDHeader = "dapr-caller-app-id"
+	// CallerNamespaceHeader is the header of the namespace of the caller of an invocation
+	CallerNamespaceHeader = "dapr-caller-namespace"
+	// CallerTrustDomainHeader is the header of the trust domain of the caller of an invocation
+	CallerTrustDomainHeader = "dapr-caller-trust-domain"
 )
 
 // InvokeMethodRequest holds InternalInvokeRequest protobuf message
@@ -70,6 +82,12 @@ func (imr *InvokeMethodRequest) WithActor(actorType, actorID string) *InvokeMeth
 	return imr
 }
 
+// WithTargetAppID sets the app id the callee was resolved from
+func (imr *InvokeMethodRequest) WithTargetAppID(appID string) *InvokeMethodRequest {
+	imr.r.TargetAppId = appID
+	return imr
+}
+
 // WithMetadata sets metadata
 func (imr *InvokeMethodRequest) WithMetadata(md map[string][]string) *InvokeMethodRequest {
 	imr.r.Metadata = GrpcMetadataToInternalMetadata(md)
@@ -86,6 +104,34 @@ func (imr *InvokeMethodRequest) WithRawData(data []byte, contentType string) *In
 	return imr
 }
 
+// WithCallerIdentity sets the caller identity headers, replacing the ones sent by the caller.
+// The headers are removed when the identity of the caller is unknown, i.e. an empty app ID.
+func (imr *InvokeMethodRequest) WithCallerIdentity(appID, namespace, trustDomain string) *InvokeMethodRequest {
+	if imr.r.Metadata == nil {
+		imr.r.Metadata = DaprInternalMetadata{}
+	}
+	for k := range imr.r.Metadata {
+		switch strings.ToLower(k) {
+		case CallerAppIDHeader, CallerNamespaceHeader, CallerTrustDomainHeader:
+			delete(imr.r.Metadata, k)
+		}
+	}
+	if appID == "" {
+		return imr
+	}
+
+	for k, v := range map[string]string{
+		CallerAppIDHeader:       appID,
+		CallerNamespaceHeader:   namespace,
+		CallerTrustDomainHeader: trustDomain,
+	} {
+		imr.r.Metadata[k] = &structpb.ListValue{
+			Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: v}}},
+		}
+	}
+	return imr
+}
+
 // WithHTTPExtension sets new HTTP extension with verb and querystring
 func (imr *InvokeMethodRequest) WithHTTPExtension(verb string, querystring string) *InvokeMethodRequest {
 	httpMethod, ok := commonv1pb.HTTPExtension_Verb_value[strings.ToUpper(verb)]
@@ -147,6 +193,11 @@ func (imr *InvokeMethodRequest) Proto() *internalv1pb.InternalInvokeRequest {
 	return p
 }
 
+// TargetAppID returns the app id the callee was resolved from, empty when the caller didn't set it
+func (imr *InvokeMethodRequest) TargetAppID() string {
+	return imr.r.GetTargetAppId()
+}
+
 // Actor returns actor type and id
 func (imr *InvokeMethodRequest) Actor() *internalv1pb.Actor {
 	return imr.r.GetActor()
@@ -64,6 +64,32 @@ func TestMetadata(t *testing.T) {
 	assert.Equal(t, "val4", mdata["test2"].GetValues()[1].GetStringValue())
 }
 
+func TestCallerIdentity(t *testing.T) {
+	t.Run("verified identity replaces the headers of the caller", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method")
+		req.WithMetadata(map[string][]string{
+			"Dapr-Caller-App-Id": {"admin"},
+			"test1":              {"val1"},
+		})
+		req.WithCallerIdentity("frontend", "apps", "cluster.local")
+		mdata := req.Metadata()
+
+		assert.Len(t, mdata, 4)
+		assert.Equal(t, "frontend", mdata[CallerAppIDHeader].GetValues()[0].GetStringValue())
+		assert.Equal(t, "apps", mdata[CallerNamespaceHeader].GetValues()[0].GetStringValue())
+		assert.Equal(t, "cluster.local", mdata[CallerTrustDomainHeader].GetValues()[0].GetStringValue())
+		assert.Equal(t, "val1", mdata["test1"].GetValues()[0].GetStringValue())
+	})
+
+	t.Run("unknown identity removes the headers of the caller", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method")
+		req.WithMetadata(map[string][]string{CallerAppIDHeader: {"admin"}})
+		req.WithCallerIdentity("", "", "")
+
+		assert.Len(t, req.Metadata(), 0)
+	})
+}
+
 func TestData(t *testing.T) {
 	t.Run("contenttype is set", func(t *testing.T) {
 		resp := NewInvokeMethodRequest("test_method")
@@ -104,6 +130,14 @@ func TestActor(t *testing.T) {
 	assert.Equal(t, "1", req.Actor().GetActorId())
 }
 
+func TestTargetAppID(t *testing.T) {
+	req := NewInvokeMethodRequest("test_method")
+	assert.Empty(t, req.TargetAppID())
+	req.WithTargetAppID("orders")
+	assert.Equal(t, "orders", req.TargetAppID())
+	assert.Equal(t, "orders", req.Proto().GetTargetAppId())
+}
+
 func TestProto(t *testing.T) {
 	m := commonv1pb.InvokeRequest{
 		Method:      "invoketest",
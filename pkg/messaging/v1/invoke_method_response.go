@@ -6,6 +6,8 @@
 package v1
 
 import (
+	"net/http"
+
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
 	"github.com/golang/protobuf/proto"
@@ -87,6 +89,22 @@ func (imr *InvokeMethodResponse) WithFastHTTPHeaders(header *fasthttp.ResponseHe
 	return imr
 }
 
+// WithHTTPHeaders populates net/http response header to gRPC header metadata
+func (imr *InvokeMethodResponse) WithHTTPHeaders(header http.Header) *InvokeMethodResponse {
+	var md = DaprInternalMetadata{}
+	for key, values := range header {
+		lv := &structpb.ListValue{}
+		for _, v := range values {
+			lv.Values = append(lv.Values, &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v}})
+		}
+		md[key] = lv
+	}
+	if len(md) > 0 {
+		imr.r.Headers = md
+	}
+	return imr
+}
+
 // WithTrailers sets Trailer in internal InvokeMethodResponse
 func (imr *InvokeMethodResponse) WithTrailers(trailer metadata.MD) *InvokeMethodResponse {
 	imr.r.Trailers = GrpcMetadataToInternalMetadata(trailer)
@@ -99,6 +99,19 @@ func TestResponseHeader(t *testing.T) {
 		assert.Equal(t, "Value2", mheader["Header2"].GetValues()[0].GetStringValue())
 		assert.Equal(t, "Value3", mheader["Header3"].GetValues()[0].GetStringValue())
 	})
+
+	t.Run("net/http headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Add("Header1", "Value1")
+		header.Add("Header1", "Value2")
+
+		re := NewInvokeMethodResponse(0, "OK", nil)
+		re.WithHTTPHeaders(header)
+		mheader := re.Headers()
+
+		assert.Equal(t, "Value1", mheader["Header1"].GetValues()[0].GetStringValue())
+		assert.Equal(t, "Value2", mheader["Header1"].GetValues()[1].GetStringValue())
+	})
 }
 
 func TestResponseTrailer(t *testing.T) {
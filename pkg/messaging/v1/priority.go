@@ -0,0 +1,38 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import "strings"
+
+const (
+	// PriorityHeader sets the priority class of an invocation
+	PriorityHeader = "dapr-priority"
+
+	// PriorityHigh is the priority class of health checks and control operations
+	PriorityHigh = "high"
+	// PriorityNormal is the default priority class
+	PriorityNormal = "normal"
+	// PriorityLow is the priority class of bulk traffic
+	PriorityLow = "low"
+)
+
+// IsPriority returns true if p is a known priority class
+func IsPriority(p string) bool {
+	return p == PriorityHigh || p == PriorityNormal || p == PriorityLow
+}
+
+// Priority returns the priority class of the request. Requests without a known priority class are normal.
+func (imr *InvokeMethodRequest) Priority() string {
+	for k, v := range imr.Metadata() {
+		if len(v.GetValues()) == 0 || strings.ToLower(k) != PriorityHeader {
+			continue
+		}
+		if p := strings.ToLower(v.Values[0].GetStringValue()); IsPriority(p) {
+			return p
+		}
+	}
+	return PriorityNormal
+}
@@ -0,0 +1,28 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriority(t *testing.T) {
+	t.Run("default is normal", func(t *testing.T) {
+		assert.Equal(t, PriorityNormal, NewInvokeMethodRequest("method").Priority())
+	})
+
+	t.Run("header sets the priority class", func(t *testing.T) {
+		req := NewInvokeMethodRequest("method").WithMetadata(map[string][]string{"Dapr-Priority": {"High"}})
+		assert.Equal(t, PriorityHigh, req.Priority())
+	})
+
+	t.Run("unknown priority class is normal", func(t *testing.T) {
+		req := NewInvokeMethodRequest("method").WithMetadata(map[string][]string{PriorityHeader: {"urgent"}})
+		assert.Equal(t, PriorityNormal, req.Priority())
+	})
+}
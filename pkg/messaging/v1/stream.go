@@ -0,0 +1,59 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"io"
+)
+
+// StreamChunkSize is the size of the data chunks of streamed invocations
+const StreamChunkSize = 64 * 1024
+
+// chunkReader reads the data of a stream of chunks
+type chunkReader struct {
+	recv  func() ([]byte, error)
+	chunk []byte
+	err   error
+}
+
+// NewChunkReader returns a reader over the chunks returned by recv, until recv returns an error. io.EOF ends the data.
+func NewChunkReader(recv func() ([]byte, error)) io.Reader {
+	return &chunkReader{recv: recv}
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.chunk, r.err = r.recv()
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+// SendChunks sends the data read from r in chunks of StreamChunkSize bytes at most
+func SendChunks(r io.Reader, send func(chunk []byte) error) error {
+	buf := make([]byte, StreamChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			// the chunk is copied as send may hold it after returning, e.g. in a gRPC send buffer
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			if sendErr := send(chunk); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
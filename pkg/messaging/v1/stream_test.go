@@ -0,0 +1,62 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendChunks(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 2*StreamChunkSize+10)
+
+	chunks := [][]byte{}
+	err := SendChunks(bytes.NewReader(data), func(chunk []byte) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, chunks, 3)
+	assert.Len(t, chunks[2], 10)
+	assert.Equal(t, data, bytes.Join(chunks, nil))
+
+	t.Run("send error", func(t *testing.T) {
+		err := SendChunks(bytes.NewReader(data), func(chunk []byte) error {
+			return errors.New("closed")
+		})
+		assert.EqualError(t, err, "closed")
+	})
+}
+
+func TestChunkReader(t *testing.T) {
+	chunks := [][]byte{[]byte("hello "), {}, []byte("world")}
+	r := NewChunkReader(func() ([]byte, error) {
+		if len(chunks) == 0 {
+			return nil, io.EOF
+		}
+		chunk := chunks[0]
+		chunks = chunks[1:]
+		return chunk, nil
+	})
+
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+
+	t.Run("receive error", func(t *testing.T) {
+		r := NewChunkReader(func() ([]byte, error) {
+			return nil, errors.New("reset")
+		})
+		_, err := ioutil.ReadAll(r)
+		assert.EqualError(t, err, "reset")
+	})
+}
@@ -214,7 +214,7 @@ func HTTPStatusFromCode(code codes.Code) int {
 // See: https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md
 func CodeFromHTTPStatus(httpStatusCode int) codes.Code {
 	switch httpStatusCode {
-	case http.StatusOK:
+	case http.StatusOK, http.StatusAccepted:
 		return codes.OK
 	case http.StatusRequestTimeout:
 		return codes.Canceled
@@ -3,7 +3,9 @@ package metrics
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 
 	ocprom "contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/dapr/dapr/pkg/logger"
@@ -91,21 +93,27 @@ func (m *promMetricsExporter) startMetricServer() error {
 		return nil
 	}
 
-	addr := fmt.Sprintf(":%d", m.options.MetricsPort())
-
 	if m.ocExporter == nil {
 		return errors.New("exporter was not initiailized")
 	}
 
-	m.exporter.logger.Infof("metrics server started on %s%s", addr, defaultMetricsPath)
-	go func() {
-		mux := http.NewServeMux()
-		mux.Handle(defaultMetricsPath, m.ocExporter)
+	listenAddresses := m.options.ListenAddresses
+	if len(listenAddresses) == 0 {
+		listenAddresses = []string{""}
+	}
 
-		if err := http.ListenAndServe(addr, mux); err != nil {
-			m.exporter.logger.Fatalf("failed to start metrics server: %v", err)
-		}
-	}()
+	mux := http.NewServeMux()
+	mux.Handle(defaultMetricsPath, m.ocExporter)
+
+	for _, address := range listenAddresses {
+		addr := net.JoinHostPort(address, strconv.FormatUint(m.options.MetricsPort(), 10))
+		m.exporter.logger.Infof("metrics server started on %s%s", addr, defaultMetricsPath)
+		go func() {
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				m.exporter.logger.Fatalf("failed to start metrics server: %v", err)
+			}
+		}()
+	}
 
 	return nil
 }
@@ -18,6 +18,8 @@ const (
 type Options struct {
 	// OutputLevel is the level of logging
 	MetricsEnabled bool
+	// ListenAddresses are the addresses the metrics server binds to. It listens on all interfaces when empty.
+	ListenAddresses []string
 
 	metricsPort string
 }
@@ -0,0 +1,32 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actor
+
+import (
+	"context"
+
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+// Handler dispatches an actor method call to the actor
+type Handler func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error)
+
+// Middleware runs before and after the dispatch of the actor method calls, e.g. to authorize, measure or validate them.
+// The actor and the method of a call are in req.Actor() and req.Message().Method.
+type Middleware func(h Handler) Handler
+
+// Pipeline defines the middleware pipeline the actor method calls are dispatched through
+type Pipeline struct {
+	Handlers []Middleware
+}
+
+// Apply returns the handler running the middleware of the pipeline in order before the handler
+func (p Pipeline) Apply(handler Handler) Handler {
+	for i := len(p.Handlers) - 1; i >= 0; i-- {
+		handler = p.Handlers[i](handler)
+	}
+	return handler
+}
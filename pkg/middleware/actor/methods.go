@@ -0,0 +1,49 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actor
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dapr/components-contrib/middleware"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AllowedMethodsKey is the metadata key of the comma-separated methods the methods middleware lets through, as
+// <actor type>.<method>, with * for any actor type or method, e.g. Cart.*,*.GetStatus
+const AllowedMethodsKey = "allowedMethods"
+
+// NewMethodsMiddleware returns the middleware denying the calls to the actor methods that aren't allowed
+func NewMethodsMiddleware(metadata middleware.Metadata) (Middleware, error) {
+	var allowed [][2]string
+	for _, m := range strings.Split(metadata.Properties[AllowedMethodsKey], ",") {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		i := strings.Index(m, ".")
+		if i <= 0 || i == len(m)-1 {
+			return nil, errors.New("allowed actor method " + m + " is not <actor type>.<method>")
+		}
+		allowed = append(allowed, [2]string{m[:i], m[i+1:]})
+	}
+
+	return func(h Handler) Handler {
+		return func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			actorType, method := req.Actor().GetActorType(), req.Message().GetMethod()
+			for _, a := range allowed {
+				if (a[0] == "*" || a[0] == actorType) && (a[1] == "*" || a[1] == method) {
+					return h(ctx, req)
+				}
+			}
+			return nil, status.Errorf(codes.PermissionDenied, "method %s of actor type %s is not allowed", method, actorType)
+		}
+	}, nil
+}
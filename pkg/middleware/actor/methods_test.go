@@ -0,0 +1,70 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dapr/components-contrib/middleware"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPipeline(t *testing.T) {
+	var calls []string
+	hook := func(name string) Middleware {
+		return func(h Handler) Handler {
+			return func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+				calls = append(calls, "pre "+name)
+				defer func() { calls = append(calls, "post "+name) }()
+				return h(ctx, req)
+			}
+		}
+	}
+	handler := Pipeline{Handlers: []Middleware{hook("auth"), hook("metrics")}}.Apply(func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+		calls = append(calls, "dispatch")
+		return invokev1.NewInvokeMethodResponse(200, "OK", nil), nil
+	})
+
+	_, err := handler(context.Background(), invokev1.NewInvokeMethodRequest("AddItem").WithActor("Cart", "1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pre auth", "pre metrics", "dispatch", "post metrics", "post auth"}, calls)
+}
+
+func TestMethodsMiddleware(t *testing.T) {
+	newHandler := func(allowed string) (Handler, error) {
+		m, err := NewMethodsMiddleware(middleware.Metadata{Properties: map[string]string{AllowedMethodsKey: allowed}})
+		if err != nil {
+			return nil, err
+		}
+		return m(func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			return invokev1.NewInvokeMethodResponse(200, "OK", nil), nil
+		}), nil
+	}
+	call := func(h Handler, actorType, method string) error {
+		_, err := h(context.Background(), invokev1.NewInvokeMethodRequest(method).WithActor(actorType, "1"))
+		return err
+	}
+
+	h, err := newHandler("Cart.AddItem, Order.*, *.GetStatus")
+	require.NoError(t, err)
+	assert.NoError(t, call(h, "Cart", "AddItem"))
+	assert.NoError(t, call(h, "Order", "Cancel"))
+	assert.NoError(t, call(h, "Payment", "GetStatus"))
+	err = call(h, "Cart", "Clear")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	h, err = newHandler("")
+	require.NoError(t, err)
+	assert.Error(t, call(h, "Cart", "AddItem"), "no method is allowed by default")
+
+	_, err = newHandler("Cart")
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package namedpipe listens on and dials Windows named pipes, e.g. \\.\pipe\dapr-api, as an alternative to localhost
+// TCP for the API of the sidecar and its channel to the app.
+package namedpipe
+
+import (
+	"errors"
+	"strings"
+)
+
+const pipePrefix = `\\.\pipe\`
+
+// ErrNotSupported is returned on platforms without named pipes
+var ErrNotSupported = errors.New("named pipes are only supported on Windows")
+
+// IsPipe returns whether the address is the path of a local named pipe
+func IsPipe(address string) bool {
+	return strings.HasPrefix(address, pipePrefix)
+}
+
+// Addr is the address of a named pipe
+type Addr string
+
+// Network returns the network of named pipes
+func (a Addr) Network() string {
+	return "pipe"
+}
+
+func (a Addr) String() string {
+	return string(a)
+}
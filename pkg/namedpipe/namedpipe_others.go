@@ -0,0 +1,24 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+//go:build !windows
+// +build !windows
+
+package namedpipe
+
+import (
+	"context"
+	"net"
+)
+
+// Listen listens on the named pipe
+func Listen(path string) (net.Listener, error) {
+	return nil, ErrNotSupported
+}
+
+// Dial connects to the named pipe
+func Dial(ctx context.Context, path string) (net.Conn, error) {
+	return nil, ErrNotSupported
+}
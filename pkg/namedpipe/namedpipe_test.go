@@ -0,0 +1,25 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package namedpipe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPipe(t *testing.T) {
+	assert.True(t, IsPipe(`\\.\pipe\dapr-api`))
+	assert.False(t, IsPipe(`\\server\pipe\dapr-api`))
+	assert.False(t, IsPipe("localhost:3500"))
+	assert.False(t, IsPipe(""))
+}
+
+func TestAddr(t *testing.T) {
+	addr := Addr(`\\.\pipe\dapr-api`)
+	assert.Equal(t, "pipe", addr.Network())
+	assert.Equal(t, `\\.\pipe\dapr-api`, addr.String())
+}
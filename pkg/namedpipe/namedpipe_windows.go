@@ -0,0 +1,343 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+//go:build windows
+// +build windows
+
+package namedpipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	pipeAccessDuplex        = 0x3
+	pipeRejectRemoteClients = 0x8
+	pipeUnlimitedInstances  = 255
+	pipeBufferSize          = 65536
+	// securitySQOSPresent with the anonymous level keeps the server of a dialed pipe from impersonating the sidecar
+	securitySQOSPresent = 0x00100000
+	// dialRetryInterval is the interval between the attempts to connect to a pipe whose instances are all busy
+	dialRetryInterval = time.Millisecond * 10
+)
+
+var (
+	kernel32                = windows.NewLazySystemDLL("kernel32.dll")
+	procCreateNamedPipeW    = kernel32.NewProc("CreateNamedPipeW")
+	procConnectNamedPipe    = kernel32.NewProc("ConnectNamedPipe")
+	procDisconnectNamedPipe = kernel32.NewProc("DisconnectNamedPipe")
+
+	errClosed = errors.New("use of closed network connection")
+)
+
+// timeoutError is returned by the operations of connections past their deadline
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// createNamedPipe creates an instance of the pipe for the next client, in byte mode for overlapped I/O
+func createNamedPipe(path *uint16, first bool) (windows.Handle, error) {
+	mode := uint32(pipeAccessDuplex | windows.FILE_FLAG_OVERLAPPED)
+	if first {
+		mode |= windows.FILE_FLAG_FIRST_PIPE_INSTANCE
+	}
+	h, _, err := procCreateNamedPipeW.Call(uintptr(unsafe.Pointer(path)), uintptr(mode), pipeRejectRemoteClients,
+		pipeUnlimitedInstances, pipeBufferSize, pipeBufferSize, 0, 0)
+	if windows.Handle(h) == windows.InvalidHandle {
+		return windows.InvalidHandle, err
+	}
+	return windows.Handle(h), nil
+}
+
+func connectNamedPipe(h windows.Handle, o *windows.Overlapped) error {
+	r, _, err := procConnectNamedPipe.Call(uintptr(h), uintptr(unsafe.Pointer(o)))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+// wait waits for the overlapped operation on the handle to complete. The operation is cancelled after the timeout,
+// unless negative, or once the cancel event is set.
+func wait(h windows.Handle, o *windows.Overlapped, timeout time.Duration, cancel windows.Handle) (uint32, error) {
+	ms := uint32(windows.INFINITE)
+	if timeout >= 0 {
+		ms = uint32(timeout / time.Millisecond)
+	}
+
+	var n uint32
+	event, err := windows.WaitForMultipleObjects([]windows.Handle{o.HEvent, cancel}, false, ms)
+	if err != nil {
+		return 0, err
+	}
+	if event == windows.WAIT_OBJECT_0 {
+		err := windows.GetOverlappedResult(h, o, &n, false)
+		return n, err
+	}
+
+	windows.CancelIoEx(h, o)
+	if err := windows.GetOverlappedResult(h, o, &n, true); err != windows.ERROR_OPERATION_ABORTED {
+		// the operation completed before it was cancelled
+		return n, err
+	}
+	if event == windows.WAIT_OBJECT_0+1 {
+		return n, errClosed
+	}
+	return n, timeoutError{}
+}
+
+// untilDeadline returns the time left before the deadline, or -1 without deadline
+func untilDeadline(deadline time.Time) time.Duration {
+	if deadline.IsZero() {
+		return -1
+	}
+	if d := time.Until(deadline); d > 0 {
+		return d
+	}
+	return 0
+}
+
+type listener struct {
+	path *uint16
+	addr Addr
+	// lock serializes the accepts
+	lock sync.Mutex
+	// next is the instance of the pipe waiting for the next client
+	next      windows.Handle
+	closed    windows.Handle
+	closeOnce sync.Once
+	isClosed  bool
+}
+
+// Listen listens on the named pipe. Only local clients can connect to it.
+func Listen(path string) (net.Listener, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	h, err := createNamedPipe(p, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on pipe %s: %s", path, err)
+	}
+	closed, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		windows.CloseHandle(h)
+		return nil, err
+	}
+	return &listener{path: p, addr: Addr(path), next: h, closed: closed}, nil
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.isClosed {
+		return nil, errClosed
+	}
+
+	h := l.next
+	l.next = windows.InvalidHandle
+	if h == windows.InvalidHandle {
+		var err error
+		if h, err = createNamedPipe(l.path, false); err != nil {
+			return nil, err
+		}
+	}
+
+	event, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		windows.CloseHandle(h)
+		return nil, err
+	}
+	defer windows.CloseHandle(event)
+	o := &windows.Overlapped{HEvent: event}
+	switch err = connectNamedPipe(h, o); err {
+	case windows.ERROR_IO_PENDING:
+		_, err = wait(h, o, -1, l.closed)
+	case windows.ERROR_PIPE_CONNECTED:
+		err = nil
+	}
+	if err != nil {
+		windows.CloseHandle(h)
+		return nil, err
+	}
+	return newConn(h, l.addr, true)
+}
+
+func (l *listener) Close() error {
+	l.closeOnce.Do(func() {
+		windows.SetEvent(l.closed)
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		l.isClosed = true
+		if l.next != windows.InvalidHandle {
+			windows.CloseHandle(l.next)
+		}
+		windows.CloseHandle(l.closed)
+	})
+	return nil
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.addr
+}
+
+type conn struct {
+	handle windows.Handle
+	addr   Addr
+	server bool
+	// closed is set when the connection is closed, cancelling its pending operations
+	closed        windows.Handle
+	closeOnce     sync.Once
+	pending       sync.WaitGroup
+	lock          sync.Mutex
+	isClosed      bool
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+func newConn(h windows.Handle, addr Addr, server bool) (*conn, error) {
+	closed, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		windows.CloseHandle(h)
+		return nil, err
+	}
+	return &conn{handle: h, addr: addr, server: server, closed: closed}, nil
+}
+
+// do runs the overlapped operation until it completes, the deadline passes or the connection is closed
+func (c *conn) do(op func(o *windows.Overlapped) error, deadline func() time.Time) (int, error) {
+	c.lock.Lock()
+	if c.isClosed {
+		c.lock.Unlock()
+		return 0, errClosed
+	}
+	c.pending.Add(1)
+	timeout := untilDeadline(deadline())
+	c.lock.Unlock()
+	defer c.pending.Done()
+
+	if timeout == 0 {
+		return 0, timeoutError{}
+	}
+	event, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer windows.CloseHandle(event)
+	o := &windows.Overlapped{HEvent: event}
+	if err := op(o); err != nil && err != windows.ERROR_IO_PENDING {
+		return 0, err
+	}
+	n, err := wait(c.handle, o, timeout, c.closed)
+	return int(n), err
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	n, err := c.do(func(o *windows.Overlapped) error {
+		return windows.ReadFile(c.handle, b, nil, o)
+	}, func() time.Time { return c.readDeadline })
+	if err == windows.ERROR_BROKEN_PIPE || err == windows.ERROR_PIPE_NOT_CONNECTED {
+		return n, io.EOF
+	}
+	return n, err
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	written := 0
+	for written < len(b) {
+		n, err := c.do(func(o *windows.Overlapped) error {
+			return windows.WriteFile(c.handle, b[written:], nil, o)
+		}, func() time.Time { return c.writeDeadline })
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func (c *conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.lock.Lock()
+		c.isClosed = true
+		c.lock.Unlock()
+
+		windows.SetEvent(c.closed)
+		c.pending.Wait()
+		if c.server {
+			procDisconnectNamedPipe.Call(uintptr(c.handle))
+		}
+		windows.CloseHandle(c.handle)
+		windows.CloseHandle(c.closed)
+	})
+	return nil
+}
+
+func (c *conn) LocalAddr() net.Addr {
+	return c.addr
+}
+
+func (c *conn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.readDeadline = t
+	c.writeDeadline = t
+	return nil
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.readDeadline = t
+	return nil
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.writeDeadline = t
+	return nil
+}
+
+// Dial connects to the named pipe, waiting for an instance of the pipe while they're all busy
+func Dial(ctx context.Context, path string) (net.Conn, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		h, err := windows.CreateFile(p, windows.GENERIC_READ|windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING,
+			windows.FILE_FLAG_OVERLAPPED|securitySQOSPresent, 0)
+		if err == nil {
+			return newConn(h, Addr(path), false)
+		}
+		if err != windows.ERROR_PIPE_BUSY {
+			return nil, fmt.Errorf("failed to dial pipe %s: %s", path, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(dialRetryInterval):
+		}
+	}
+}
@@ -10,23 +10,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sort"
+	"sync"
 
 	v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 	scheme "github.com/dapr/dapr/pkg/client/clientset/versioned"
+	global_config "github.com/dapr/dapr/pkg/config"
 	dapr_credentials "github.com/dapr/dapr/pkg/credentials"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/logger"
 	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 )
 
 const serverPort = 6500
 
 var log = logger.NewLogger("dapr.operator.api")
 
-//Server runs the Dapr API server for components and configurations
+// Server runs the Dapr API server for components and configurations
 type Server interface {
 	Run(certChain *dapr_credentials.CertChain)
 	OnComponentUpdated(component *v1alpha1.Component)
@@ -34,14 +43,26 @@ type Server interface {
 
 type apiServer struct {
 	Client     scheme.Interface
+	kubeClient kubernetes.Interface
 	updateChan chan (*v1alpha1.Component)
+	statusLock sync.Mutex
+	// sidecarHTTPPort is the port of the HTTP API of the sidecars topics are paused on
+	sidecarHTTPPort int
+	// defaultConfig is the name of the cluster default configuration in defaultConfigNamespace, if any
+	defaultConfig          string
+	defaultConfigNamespace string
 }
 
-// NewAPIServer returns a new API server
-func NewAPIServer(client scheme.Interface) Server {
+// NewAPIServer returns a new API server. kubeClient is used to drop the component statuses of deleted pods.
+// The configurations of the apps are layered on the defaultConfig configuration of namespace, when not empty.
+func NewAPIServer(client scheme.Interface, kubeClient kubernetes.Interface, namespace, defaultConfig string) Server {
 	return &apiServer{
-		Client:     client,
-		updateChan: make(chan *v1alpha1.Component, 1),
+		Client:                 client,
+		kubeClient:             kubeClient,
+		updateChan:             make(chan *v1alpha1.Component, 1),
+		sidecarHTTPPort:        sidecarHTTPPort,
+		defaultConfig:          defaultConfig,
+		defaultConfigNamespace: namespace,
 	}
 }
 
@@ -56,6 +77,9 @@ func (a *apiServer) Run(certChain *dapr_credentials.CertChain) {
 	if err != nil {
 		log.Fatal("error creating gRPC options: %s", err)
 	}
+	opts = append(opts,
+		grpc.UnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(diag.DefaultGRPCMonitoring.StreamServerInterceptor()))
 	s := grpc.NewServer(opts...)
 	operatorv1pb.RegisterOperatorServer(s, a)
 
@@ -69,8 +93,24 @@ func (a *apiServer) OnComponentUpdated(component *v1alpha1.Component) {
 	a.updateChan <- component
 }
 
-// GetConfiguration returns a Dapr configuration
+// GetConfiguration returns a Dapr configuration layered on the cluster default configuration.
+// An empty name returns the default configuration alone, or no configuration when there is none.
 func (a *apiServer) GetConfiguration(ctx context.Context, in *operatorv1pb.GetConfigurationRequest) (*operatorv1pb.GetConfigurationResponse, error) {
+	defaults, err := a.getDefaultConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	if in.Name == "" {
+		if defaults == nil {
+			return &operatorv1pb.GetConfigurationResponse{}, nil
+		}
+		return &operatorv1pb.GetConfigurationResponse{
+			Configuration: &any.Any{
+				Value: defaults,
+			},
+		}, nil
+	}
+
 	config, err := a.Client.ConfigurationV1alpha1().Configurations(in.Namespace).Get(in.Name, meta_v1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error getting configuration: %s", err)
@@ -79,6 +119,12 @@ func (a *apiServer) GetConfiguration(ctx context.Context, in *operatorv1pb.GetCo
 	if err != nil {
 		return nil, fmt.Errorf("error marshalling configuration: %s", err)
 	}
+	if defaults != nil {
+		b, err = global_config.MergeConfigurationJSON(defaults, b)
+		if err != nil {
+			return nil, fmt.Errorf("error merging configuration with the default configuration: %s", err)
+		}
+	}
 	return &operatorv1pb.GetConfigurationResponse{
 		Configuration: &any.Any{
 			Value: b,
@@ -86,6 +132,25 @@ func (a *apiServer) GetConfiguration(ctx context.Context, in *operatorv1pb.GetCo
 	}, nil
 }
 
+// getDefaultConfiguration returns the JSON of the cluster default configuration, or nil when there is none
+func (a *apiServer) getDefaultConfiguration() ([]byte, error) {
+	if a.defaultConfig == "" {
+		return nil, nil
+	}
+	config, err := a.Client.ConfigurationV1alpha1().Configurations(a.defaultConfigNamespace).Get(a.defaultConfig, meta_v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting default configuration: %s", err)
+	}
+	b, err := json.Marshal(&config)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling default configuration: %s", err)
+	}
+	return b, nil
+}
+
 // GetComponents returns a list of Dapr components
 func (a *apiServer) GetComponents(ctx context.Context, in *empty.Empty) (*operatorv1pb.GetComponentResponse, error) {
 	components, err := a.Client.ComponentsV1alpha1().Components(meta_v1.NamespaceAll).List(meta_v1.ListOptions{})
@@ -133,3 +198,64 @@ func (a *apiServer) ComponentUpdate(in *empty.Empty, srv operatorv1pb.Operator_C
 	}
 	return nil
 }
+
+// ReportComponentStatus records whether a sidecar loaded a component in the status of the component
+func (a *apiServer) ReportComponentStatus(ctx context.Context, in *operatorv1pb.ReportComponentStatusRequest) (*empty.Empty, error) {
+	if in.Name == "" || in.Pod == "" {
+		return nil, status.Error(codes.InvalidArgument, "component name and pod are required")
+	}
+
+	// sidecars report concurrently on rollouts, serializing the updates saves most of the conflicts
+	a.statusLock.Lock()
+	defer a.statusLock.Unlock()
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		c, err := a.Client.ComponentsV1alpha1().Components(in.Namespace).Get(in.Name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		c.Status = a.reportedStatus(in.Namespace, c.Status, in)
+		_, err = a.Client.ComponentsV1alpha1().Components(in.Namespace).UpdateStatus(c)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating status of component %s: %s", in.Name, err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// reportedStatus returns the status with the report of the sidecar, without the sidecars of deleted pods
+func (a *apiServer) reportedStatus(namespace string, current v1alpha1.ComponentStatus, in *operatorv1pb.ReportComponentStatusRequest) v1alpha1.ComponentStatus {
+	sidecars := []v1alpha1.SidecarStatus{{
+		Pod:    in.Pod,
+		AppID:  in.AppId,
+		Loaded: in.Loaded,
+		Error:  in.Error,
+	}}
+	for _, s := range current.Sidecars {
+		if s.Pod != in.Pod && a.podExists(namespace, s.Pod) {
+			sidecars = append(sidecars, s)
+		}
+	}
+	sort.Slice(sidecars, func(i, j int) bool {
+		return sidecars[i].Pod < sidecars[j].Pod
+	})
+
+	result := v1alpha1.ComponentStatus{Sidecars: sidecars}
+	for _, s := range sidecars {
+		if s.Loaded {
+			result.Ready++
+		} else {
+			result.Failed++
+		}
+	}
+	return result
+}
+
+func (a *apiServer) podExists(namespace, name string) bool {
+	if a.kubeClient == nil {
+		return true
+	}
+	_, err := a.kubeClient.CoreV1().Pods(namespace).Get(name, meta_v1.GetOptions{})
+	return !errors.IsNotFound(err)
+}
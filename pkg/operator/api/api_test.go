@@ -0,0 +1,192 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	config_v1alpha1 "github.com/dapr/dapr/pkg/apis/configuration/v1alpha1"
+	dapr_fake "github.com/dapr/dapr/pkg/client/clientset/versioned/fake"
+	dapr_config "github.com/dapr/dapr/pkg/config"
+	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8s_testing "k8s.io/client-go/testing"
+)
+
+func TestReportComponentStatus(t *testing.T) {
+	component := &v1alpha1.Component{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "statestore", Namespace: "apps"},
+		Status: v1alpha1.ComponentStatus{
+			Ready: 2,
+			Sidecars: []v1alpha1.SidecarStatus{
+				{Pod: "deleted", AppID: "app", Loaded: true},
+				{Pod: "frontend", AppID: "frontend", Loaded: true},
+			},
+		},
+	}
+	// the fake clientset registers components in another group than the scheme, so its tracker can't be used
+	daprClient := dapr_fake.NewSimpleClientset()
+	daprClient.PrependReactor("get", "components", func(action k8s_testing.Action) (bool, runtime.Object, error) {
+		if action.(k8s_testing.GetAction).GetName() != component.Name {
+			return true, nil, errors.NewNotFound(schema.GroupResource{Resource: "components"}, action.(k8s_testing.GetAction).GetName())
+		}
+		return true, component.DeepCopy(), nil
+	})
+	daprClient.PrependReactor("update", "components", func(action k8s_testing.Action) (bool, runtime.Object, error) {
+		assert.Equal(t, "status", action.GetSubresource())
+		component = action.(k8s_testing.UpdateAction).GetObject().(*v1alpha1.Component)
+		return true, component, nil
+	})
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "frontend", Namespace: "apps"}},
+		&corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "backend", Namespace: "apps"}},
+	)
+	s := NewAPIServer(daprClient, kubeClient, "", "").(*apiServer)
+
+	t.Run("reports are aggregated in the status", func(t *testing.T) {
+		_, err := s.ReportComponentStatus(context.Background(), &operatorv1pb.ReportComponentStatusRequest{
+			Name:      "statestore",
+			Namespace: "apps",
+			Pod:       "backend",
+			AppId:     "backend",
+			Error:     "connection refused",
+		})
+		assert.NoError(t, err)
+
+		c := component
+		assert.Equal(t, 1, c.Status.Ready)
+		assert.Equal(t, 1, c.Status.Failed)
+		assert.Equal(t, []v1alpha1.SidecarStatus{
+			{Pod: "backend", AppID: "backend", Error: "connection refused"},
+			{Pod: "frontend", AppID: "frontend", Loaded: true},
+		}, c.Status.Sidecars)
+	})
+
+	t.Run("reports replace the previous report of the pod", func(t *testing.T) {
+		_, err := s.ReportComponentStatus(context.Background(), &operatorv1pb.ReportComponentStatusRequest{
+			Name:      "statestore",
+			Namespace: "apps",
+			Pod:       "backend",
+			AppId:     "backend",
+			Loaded:    true,
+		})
+		assert.NoError(t, err)
+
+		c := component
+		assert.Equal(t, 2, c.Status.Ready)
+		assert.Equal(t, 0, c.Status.Failed)
+		assert.Len(t, c.Status.Sidecars, 2)
+	})
+
+	t.Run("unknown component", func(t *testing.T) {
+		_, err := s.ReportComponentStatus(context.Background(), &operatorv1pb.ReportComponentStatusRequest{
+			Name:      "pubsub",
+			Namespace: "apps",
+			Pod:       "backend",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("missing pod", func(t *testing.T) {
+		_, err := s.ReportComponentStatus(context.Background(), &operatorv1pb.ReportComponentStatusRequest{
+			Name: "statestore",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetConfiguration(t *testing.T) {
+	configurations := map[string]*config_v1alpha1.Configuration{
+		"dapr-system/defaults": {
+			ObjectMeta: meta_v1.ObjectMeta{Name: "defaults", Namespace: "dapr-system"},
+			Spec: config_v1alpha1.ConfigurationSpec{
+				TracingSpec: config_v1alpha1.TracingSpec{SamplingRate: "1"},
+				MTLSSpec:    config_v1alpha1.MTLSSpec{Enabled: true, WorkloadCertTTL: "24h"},
+				HTTPPipelineSpec: config_v1alpha1.PipelineSpec{Handlers: []config_v1alpha1.HandlerSpec{
+					{Name: "oauth", Type: "middleware.http.oauth2"},
+					{Name: "ratelimit", Type: "middleware.http.ratelimit"},
+				}},
+			},
+		},
+		"apps/frontend": {
+			ObjectMeta: meta_v1.ObjectMeta{Name: "frontend", Namespace: "apps"},
+			Spec: config_v1alpha1.ConfigurationSpec{
+				MTLSSpec: config_v1alpha1.MTLSSpec{WorkloadCertTTL: "1h"},
+				HTTPPipelineSpec: config_v1alpha1.PipelineSpec{Handlers: []config_v1alpha1.HandlerSpec{
+					{Name: "uppercase", Type: "middleware.http.uppercase"},
+					{Name: "ratelimit", Type: "middleware.http.ratelimit2"},
+				}},
+			},
+		},
+	}
+	daprClient := dapr_fake.NewSimpleClientset()
+	daprClient.PrependReactor("get", "configurations", func(action k8s_testing.Action) (bool, runtime.Object, error) {
+		name := action.(k8s_testing.GetAction).GetName()
+		c, ok := configurations[action.GetNamespace()+"/"+name]
+		if !ok {
+			return true, nil, errors.NewNotFound(schema.GroupResource{Resource: "configurations"}, name)
+		}
+		return true, c.DeepCopy(), nil
+	})
+
+	getConfiguration := func(t *testing.T, s *apiServer, name string) *dapr_config.Configuration {
+		resp, err := s.GetConfiguration(context.Background(), &operatorv1pb.GetConfigurationRequest{Name: name, Namespace: "apps"})
+		assert.NoError(t, err)
+		if resp.Configuration == nil {
+			return nil
+		}
+		var c dapr_config.Configuration
+		assert.NoError(t, json.Unmarshal(resp.Configuration.Value, &c))
+		return &c
+	}
+
+	t.Run("app configuration is layered on the defaults", func(t *testing.T) {
+		s := NewAPIServer(daprClient, nil, "dapr-system", "defaults").(*apiServer)
+		c := getConfiguration(t, s, "frontend")
+
+		assert.Equal(t, "1", c.Spec.TracingSpec.SamplingRate)
+		assert.True(t, c.Spec.MTLSSpec.Enabled)
+		assert.Equal(t, "1h", c.Spec.MTLSSpec.WorkloadCertTTL)
+		assert.Equal(t, []dapr_config.HandlerSpec{
+			{Name: "oauth", Type: "middleware.http.oauth2"},
+			{Name: "ratelimit", Type: "middleware.http.ratelimit2"},
+			{Name: "uppercase", Type: "middleware.http.uppercase"},
+		}, c.Spec.HTTPPipelineSpec.Handlers)
+	})
+
+	t.Run("empty name returns the defaults", func(t *testing.T) {
+		s := NewAPIServer(daprClient, nil, "dapr-system", "defaults").(*apiServer)
+		c := getConfiguration(t, s, "")
+
+		assert.Equal(t, "1", c.Spec.TracingSpec.SamplingRate)
+		assert.Len(t, c.Spec.HTTPPipelineSpec.Handlers, 2)
+	})
+
+	t.Run("missing defaults", func(t *testing.T) {
+		s := NewAPIServer(daprClient, nil, "dapr-system", "missing").(*apiServer)
+		c := getConfiguration(t, s, "frontend")
+
+		assert.Equal(t, "", c.Spec.TracingSpec.SamplingRate)
+		assert.False(t, c.Spec.MTLSSpec.Enabled)
+		assert.Nil(t, getConfiguration(t, s, ""))
+	})
+
+	t.Run("no defaults", func(t *testing.T) {
+		s := NewAPIServer(daprClient, nil, "", "").(*apiServer)
+		c := getConfiguration(t, s, "frontend")
+
+		assert.Len(t, c.Spec.HTTPPipelineSpec.Handlers, 2)
+	})
+}
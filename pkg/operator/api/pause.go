@@ -0,0 +1,107 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	daprEnabledAnnotationKey = "dapr.io/enabled"
+	appIDAnnotationKey       = "dapr.io/id"
+	sidecarHTTPPort          = 3500
+	sidecarCallTimeout       = time.Second * 5
+)
+
+// SetTopicPaused pauses or resumes the delivery of a topic on the sidecars of a namespace, or of an app of the namespace
+func (a *apiServer) SetTopicPaused(ctx context.Context, in *operatorv1pb.SetTopicPausedRequest) (*operatorv1pb.SetTopicPausedResponse, error) {
+	if in.Topic == "" {
+		return nil, status.Error(codes.InvalidArgument, "topic is required")
+	}
+	if a.kubeClient == nil {
+		return nil, status.Error(codes.Unavailable, "pods can't be listed")
+	}
+
+	pods, err := a.kubeClient.CoreV1().Pods(in.Namespace).List(meta_v1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %s", err)
+	}
+
+	action := "resume"
+	if in.Paused {
+		action = "pause"
+	}
+
+	resp := &operatorv1pb.SetTopicPausedResponse{}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isSidecarPod(pod, in.AppId) {
+			continue
+		}
+		wg.Add(1)
+		go func(pod *corev1.Pod) {
+			defer wg.Done()
+			err := a.callSidecar(ctx, pod.Status.PodIP, fmt.Sprintf("/v1.0/admin/pubsub/topics/%s/%s", in.Topic, action))
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				resp.Errors = append(resp.Errors, fmt.Sprintf("%s: %s", pod.Name, err))
+				return
+			}
+			resp.Pods = append(resp.Pods, pod.Name)
+		}(pod)
+	}
+	wg.Wait()
+
+	sort.Strings(resp.Pods)
+	sort.Strings(resp.Errors)
+	log.Infof("%sd topic %s on %d sidecars in namespace %s, %d failed", action, in.Topic, len(resp.Pods), in.Namespace, len(resp.Errors))
+	return resp, nil
+}
+
+// isSidecarPod returns whether the pod is running a sidecar of the app, or any sidecar when appID is empty
+func isSidecarPod(pod *corev1.Pod, appID string) bool {
+	if pod.Annotations[daprEnabledAnnotationKey] != "true" || pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		return false
+	}
+	return appID == "" || pod.Annotations[appIDAnnotationKey] == appID
+}
+
+func (a *apiServer) callSidecar(ctx context.Context, podIP, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, sidecarCallTimeout)
+	defer cancel()
+
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(podIP, strconv.Itoa(a.sidecarHTTPPort)), path)
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("sidecar returned status %d", res.StatusCode)
+	}
+	return nil
+}
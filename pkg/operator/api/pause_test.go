@@ -0,0 +1,98 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetTopicPaused(t *testing.T) {
+	var lock sync.Mutex
+	paths := []string{}
+	sidecar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+	}))
+	defer sidecar.Close()
+	host, port, _ := net.SplitHostPort(sidecar.Listener.Addr().String())
+
+	pod := func(name, appID string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:        name,
+				Namespace:   "apps",
+				Annotations: map[string]string{daprEnabledAnnotationKey: "true", appIDAnnotationKey: appID},
+			},
+			Status: corev1.PodStatus{Phase: phase, PodIP: host},
+		}
+	}
+	kubeClient := fake.NewSimpleClientset(
+		pod("frontend-1", "frontend", corev1.PodRunning),
+		pod("frontend-2", "frontend", corev1.PodPending),
+		pod("backend-1", "backend", corev1.PodRunning),
+		&corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "redis", Namespace: "apps"}},
+	)
+	s := NewAPIServer(nil, kubeClient, "", "").(*apiServer)
+	s.sidecarHTTPPort, _ = strconv.Atoi(port)
+
+	t.Run("pause on the running sidecars of an app", func(t *testing.T) {
+		paths = []string{}
+		resp, err := s.SetTopicPaused(context.Background(), &operatorv1pb.SetTopicPausedRequest{
+			Namespace: "apps",
+			AppId:     "frontend",
+			Topic:     "orders",
+			Paused:    true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"frontend-1"}, resp.Pods)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, []string{"POST /v1.0/admin/pubsub/topics/orders/pause"}, paths)
+	})
+
+	t.Run("resume on all the sidecars of a namespace", func(t *testing.T) {
+		paths = []string{}
+		resp, err := s.SetTopicPaused(context.Background(), &operatorv1pb.SetTopicPausedRequest{
+			Namespace: "apps",
+			Topic:     "orders",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"backend-1", "frontend-1"}, resp.Pods)
+		assert.Equal(t, []string{"POST /v1.0/admin/pubsub/topics/orders/resume", "POST /v1.0/admin/pubsub/topics/orders/resume"}, paths)
+	})
+
+	t.Run("failing sidecars are reported", func(t *testing.T) {
+		s.sidecarHTTPPort = 1
+		defer func() { s.sidecarHTTPPort, _ = strconv.Atoi(port) }()
+
+		resp, err := s.SetTopicPaused(context.Background(), &operatorv1pb.SetTopicPausedRequest{
+			Namespace: "apps",
+			AppId:     "backend",
+			Topic:     "orders",
+			Paused:    true,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Pods)
+		assert.Len(t, resp.Errors, 1)
+	})
+
+	t.Run("topic is required", func(t *testing.T) {
+		_, err := s.SetTopicPaused(context.Background(), &operatorv1pb.SetTopicPausedRequest{Namespace: "apps"})
+		assert.Error(t, err)
+	})
+}
@@ -12,6 +12,10 @@ import (
 type Config struct {
 	MTLSEnabled bool
 	Credentials credentials.TLSCredentials
+	// Namespace is the namespace of the operator
+	Namespace string
+	// DefaultConfig is the name of the configuration of Namespace the app configurations are layered on
+	DefaultConfig string
 }
 
 // LoadConfiguration loads the Kubernetes configuration and returns an Operator Config
@@ -23,5 +27,6 @@ func LoadConfiguration(name string, client scheme.Interface) (*Config, error) {
 	}
 	return &Config{
 		MTLSEnabled: conf.Spec.MTLSSpec.Enabled,
+		Namespace:   namespace,
 	}, nil
 }
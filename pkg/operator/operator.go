@@ -7,6 +7,7 @@ package operator
 
 import (
 	"context"
+	"reflect"
 
 	v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 	scheme "github.com/dapr/dapr/pkg/client/clientset/versioned"
@@ -74,8 +75,11 @@ func NewOperator(kubeAPI *k8s.API, config *Config) Operator {
 
 	o.componentsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: o.syncComponent,
-		UpdateFunc: func(_, newObj interface{}) {
-			o.syncComponent(newObj)
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// status updates come from the sidecars themselves, sending them back would reload the component
+			if !isStatusUpdate(oldObj, newObj) {
+				o.syncComponent(newObj)
+			}
 		},
 	})
 
@@ -89,6 +93,23 @@ func (o *operator) syncComponent(obj interface{}) {
 	}
 }
 
+// isStatusUpdate returns true when only the status of the component changed
+func isStatusUpdate(oldObj, newObj interface{}) bool {
+	oldComponent, ok := oldObj.(*v1alpha1.Component)
+	if !ok {
+		return false
+	}
+	newComponent, ok := newObj.(*v1alpha1.Component)
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(oldComponent.Spec, newComponent.Spec) &&
+		oldComponent.Auth == newComponent.Auth &&
+		reflect.DeepEqual(oldComponent.Scopes, newComponent.Scopes) &&
+		oldComponent.Residency == newComponent.Residency &&
+		!reflect.DeepEqual(oldComponent.Status, newComponent.Status)
+}
+
 func (o *operator) syncDeployment(obj interface{}) {
 	o.daprHandler.ObjectCreated(obj)
 }
@@ -116,7 +137,7 @@ func (o *operator) Run(ctx context.Context) {
 		cancel()
 	}()
 
-	o.apiServer = api.NewAPIServer(o.daprClient)
+	o.apiServer = api.NewAPIServer(o.daprClient, o.kubeClient, o.config.Namespace, o.config.DefaultConfig)
 
 	var certChain *credentials.CertChain
 	if o.config.MTLSEnabled {
@@ -0,0 +1,80 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package validation
+
+// componentTypes are the component types registered in daprd, and the metadata fields each of them requires
+var componentTypes = map[string][]string{
+	"secretstores.kubernetes":        nil,
+	"secretstores.azure.keyvault":    nil,
+	"secretstores.hashicorp.vault":   nil,
+	"secretstores.aws.secretmanager": nil,
+	"secretstores.gcp.secretmanager": nil,
+
+	"configuration.in-memory": nil,
+
+	"state.redis":              {"redisHost"},
+	"state.consul":             nil,
+	"state.azure.cosmosdb":     {"url", "masterKey", "database", "collection"},
+	"state.azure.tablestorage": nil,
+	"state.etcd":               {"endpoints"},
+	"state.cassandra":          {"hosts"},
+	"state.memcached":          {"hosts"},
+	"state.mongodb":            {"host"},
+	"state.zookeeper":          {"servers"},
+	"state.gcp.firestore":      nil,
+	"state.sqlserver":          {"connectionString"},
+	"state.hazelcast":          nil,
+	"state.cloudstate.crdt":    nil,
+	"state.couchbase":          nil,
+	"state.aerospike":          nil,
+
+	"pubsub.redis":            {"redisHost"},
+	"pubsub.nats":             {"natsURL"},
+	"pubsub.azure.eventhubs":  nil,
+	"pubsub.azure.servicebus": {"connectionString"},
+	"pubsub.rabbitmq":         {"host"},
+	"pubsub.hazelcast":        nil,
+	"pubsub.gcp.pubsub":       nil,
+	"pubsub.kafka":            {"brokers"},
+
+	"exporters.zipkin": nil,
+	"exporters.string": nil,
+	"exporters.native": nil,
+
+	"bindings.aws.sqs":                nil,
+	"bindings.aws.sns":                nil,
+	"bindings.aws.kinesis":            nil,
+	"bindings.aws.dynamodb":           nil,
+	"bindings.aws.s3":                 nil,
+	"bindings.azure.eventhubs":        nil,
+	"bindings.azure.cosmosdb":         nil,
+	"bindings.azure.servicebusqueues": nil,
+	"bindings.azure.storagequeues":    nil,
+	"bindings.azure.eventgrid":        nil,
+	"bindings.azure.blobstorage":      nil,
+	"bindings.azure.signalr":          nil,
+	"bindings.gcp.pubsub":             nil,
+	"bindings.gcp.bucket":             nil,
+	"bindings.kubernetes":             nil,
+	"bindings.twitter":                nil,
+	"bindings.http":                   {"url"},
+	"bindings.kafka":                  {"brokers"},
+	"bindings.mqtt":                   {"url", "topic"},
+	"bindings.rabbitmq":               nil,
+	"bindings.redis":                  {"redisHost"},
+	"bindings.twilio.sms":             nil,
+	"bindings.twilio.sendgrid":        nil,
+
+	"middleware.http.uppercase": nil,
+	"middleware.http.oauth2":    nil,
+	"middleware.http.ratelimit": nil,
+	"middleware.http.bearer":    nil,
+
+	"servicediscovery.mdns":       nil,
+	"servicediscovery.dns":        nil,
+	"servicediscovery.consul":     nil,
+	"servicediscovery.kubernetes": nil,
+}
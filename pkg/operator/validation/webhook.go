@@ -0,0 +1,204 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package validation
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/fips"
+	"github.com/dapr/dapr/pkg/logger"
+	"k8s.io/api/admission/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	componentKind     = "Component"
+	configurationKind = "Configuration"
+	kubernetesStore   = "kubernetes"
+)
+
+var log = logger.NewLogger("dapr.operator.validation")
+
+// Webhook is a validating admission webhook rejecting Components and Configurations that would fail sidecars
+type Webhook struct {
+	kubeClient kubernetes.Interface
+	server     *http.Server
+}
+
+// NewWebhook returns a webhook listening on the port. Secret references of components are resolved with kubeClient.
+func NewWebhook(kubeClient kubernetes.Interface, port int) *Webhook {
+	mux := http.NewServeMux()
+	w := &Webhook{
+		kubeClient: kubeClient,
+		server: &http.Server{
+			Addr:      fmt.Sprintf(":%d", port),
+			Handler:   mux,
+			TLSConfig: fips.ConfigureTLS(&tls.Config{}),
+		},
+	}
+	mux.HandleFunc("/validate", w.handleValidate)
+	return w
+}
+
+// Run serves the webhook with TLS until ctx is done
+func (w *Webhook) Run(ctx context.Context, certFile, keyFile string) {
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		w.server.Shutdown(shutdownCtx) // nolint: errcheck
+	}()
+
+	log.Infof("validating webhook is listening on %s", w.server.Addr)
+	if err := w.server.ListenAndServeTLS(certFile, keyFile); err != http.ErrServerClosed {
+		log.Errorf("validating webhook error: %s", err)
+	}
+}
+
+func (w *Webhook) handleValidate(rw http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
+		http.Error(rw, "empty body", http.StatusBadRequest)
+		return
+	}
+
+	var ar v1beta1.AdmissionReview
+	if err := json.Unmarshal(body, &ar); err != nil || ar.Request == nil {
+		http.Error(rw, fmt.Sprintf("invalid admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	response := &v1beta1.AdmissionResponse{UID: ar.Request.UID, Allowed: true}
+	if problems := w.validate(ar.Request); len(problems) > 0 {
+		log.Infof("rejecting %s %s/%s: %s", ar.Request.Kind.Kind, ar.Request.Namespace, ar.Request.Name, strings.Join(problems, "; "))
+		response.Allowed = false
+		response.Result = &meta_v1.Status{
+			Status:  meta_v1.StatusFailure,
+			Reason:  meta_v1.StatusReasonInvalid,
+			Message: fmt.Sprintf("invalid %s: %s", ar.Request.Kind.Kind, strings.Join(problems, "; ")),
+			Code:    http.StatusUnprocessableEntity,
+		}
+	}
+
+	resp, err := json.Marshal(v1beta1.AdmissionReview{TypeMeta: ar.TypeMeta, Response: response})
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("could not encode response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	if _, err := rw.Write(resp); err != nil {
+		log.Errorf("can't write response: %v", err)
+	}
+}
+
+// validate returns the problems of the object of the request
+func (w *Webhook) validate(req *v1beta1.AdmissionRequest) []string {
+	if req.Operation == v1beta1.Delete {
+		return nil
+	}
+
+	switch req.Kind.Kind {
+	case componentKind:
+		return w.validateComponent(req.Object.Raw, req.Namespace)
+	case configurationKind:
+		return validateConfiguration(req.Object.Raw)
+	}
+	return nil
+}
+
+func (w *Webhook) validateComponent(raw []byte, namespace string) []string {
+	var c components_v1alpha1.Component
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return []string{err.Error()}
+	}
+
+	problems := []string{}
+	required, ok := componentTypes[c.Spec.Type]
+	if !ok {
+		problems = append(problems, fmt.Sprintf("component type %q is not supported", c.Spec.Type))
+	}
+
+	items := map[string]components_v1alpha1.MetadataItem{}
+	for i, m := range c.Spec.Metadata {
+		if m.Name == "" {
+			problems = append(problems, fmt.Sprintf("metadata[%d] has no name", i))
+			continue
+		}
+		if _, ok := items[m.Name]; ok {
+			problems = append(problems, fmt.Sprintf("metadata %s is duplicated", m.Name))
+		}
+		items[m.Name] = m
+
+		if m.SecretKeyRef.Name != "" {
+			if err := w.resolveSecretKeyRef(c.Auth.SecretStore, namespace, m.SecretKeyRef); err != nil {
+				problems = append(problems, fmt.Sprintf("secretKeyRef of metadata %s: %s", m.Name, err))
+			}
+		}
+	}
+
+	for _, name := range required {
+		m, ok := items[name]
+		if !ok || (m.Value == "" && m.SecretKeyRef.Name == "") {
+			problems = append(problems, fmt.Sprintf("metadata %s is required by %s", name, c.Spec.Type))
+		}
+	}
+	return problems
+}
+
+// resolveSecretKeyRef checks the secret of the reference exists when the component reads its secrets from Kubernetes.
+// Secrets of other secret stores can't be resolved by the operator.
+func (w *Webhook) resolveSecretKeyRef(secretStore, namespace string, ref components_v1alpha1.SecretKeyRef) error {
+	if (secretStore != "" && secretStore != kubernetesStore) || w.kubeClient == nil {
+		return nil
+	}
+
+	secret, err := w.kubeClient.CoreV1().Secrets(namespace).Get(ref.Name, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("secret %s not found: %s", ref.Name, err)
+	}
+	key := ref.Key
+	if key == "" {
+		key = ref.Name
+	}
+	if _, ok := secret.Data[key]; !ok {
+		if _, ok := secret.StringData[key]; !ok {
+			return fmt.Errorf("secret %s has no key %s", ref.Name, key)
+		}
+	}
+	return nil
+}
+
+func validateConfiguration(raw []byte) []string {
+	var obj struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return []string{err.Error()}
+	}
+
+	var c config.Configuration
+	if len(obj.Spec) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(obj.Spec))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&c.Spec); err != nil {
+			return []string{fmt.Sprintf("spec: %s", err)}
+		}
+	}
+	return c.Validate()
+}
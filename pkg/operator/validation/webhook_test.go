@@ -0,0 +1,110 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func review(t *testing.T, w *Webhook, kind, object string) *v1beta1.AdmissionResponse {
+	body, _ := json.Marshal(v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			UID:       "1",
+			Kind:      meta_v1.GroupVersionKind{Kind: kind},
+			Namespace: "apps",
+			Operation: v1beta1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(object)},
+		},
+	})
+	r := httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body))
+	rw := httptest.NewRecorder()
+	w.handleValidate(rw, r)
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	var ar v1beta1.AdmissionReview
+	assert.NoError(t, json.Unmarshal(rw.Body.Bytes(), &ar))
+	assert.Equal(t, "1", string(ar.Response.UID))
+	return ar.Response
+}
+
+func TestValidateComponent(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "redis", Namespace: "apps"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	})
+	w := NewWebhook(kubeClient, 0)
+
+	t.Run("valid component", func(t *testing.T) {
+		resp := review(t, w, componentKind, `{"spec":{"type":"state.redis","metadata":[
+			{"name":"redisHost","value":"redis:6379"},
+			{"name":"redisPassword","secretKeyRef":{"name":"redis","key":"password"}}]}}`)
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		resp := review(t, w, componentKind, `{"spec":{"type":"state.reddis"}}`)
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, `component type "state.reddis" is not supported`)
+	})
+
+	t.Run("missing required metadata", func(t *testing.T) {
+		resp := review(t, w, componentKind, `{"spec":{"type":"state.redis","metadata":[{"name":"redisHost","value":""}]}}`)
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "metadata redisHost is required by state.redis")
+	})
+
+	t.Run("unresolvable secret references", func(t *testing.T) {
+		resp := review(t, w, componentKind, `{"spec":{"type":"state.redis","metadata":[
+			{"name":"redisHost","secretKeyRef":{"name":"missing"}},
+			{"name":"redisPassword","secretKeyRef":{"name":"redis","key":"pass"}}]}}`)
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "secret missing not found")
+		assert.Contains(t, resp.Result.Message, "secret redis has no key pass")
+	})
+
+	t.Run("secret references of other secret stores are not resolved", func(t *testing.T) {
+		resp := review(t, w, componentKind, `{"spec":{"type":"state.redis","metadata":[
+			{"name":"redisHost","secretKeyRef":{"name":"missing"}}]},"auth":{"secretStore":"vault"}}`)
+		assert.True(t, resp.Allowed)
+	})
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	w := NewWebhook(nil, 0)
+
+	t.Run("valid configuration", func(t *testing.T) {
+		resp := review(t, w, configurationKind, `{"spec":{"tracing":{"samplingRate":"0.5"},"mtls":{"enabled":true,"workloadCertTTL":"24h"},
+			"httpPipeline":{"handlers":[{"name":"oauth","type":"middleware.http.oauth2"}]}}}`)
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		resp := review(t, w, configurationKind, `{"spec":{"tracng":{"samplingRate":"1"}}}`)
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "tracng")
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		resp := review(t, w, configurationKind, `{"spec":{"tracing":{"samplingRate":"2"},"actorTurns":{"slowTurnThreshold":"500"},
+			"startup":{"placement":"sometimes"},"httpPipeline":{"handlers":[{"name":"oauth","type":"oauth2"}]}}}`)
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "tracing.samplingRate 2")
+		assert.Contains(t, resp.Result.Message, "actorTurns.slowTurnThreshold 500 is not a duration")
+		assert.Contains(t, resp.Result.Message, "startup.placement sometimes is not a startup policy")
+		assert.Contains(t, resp.Result.Message, "httpPipeline.handlers[0].type oauth2 is not an HTTP middleware")
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 
 	dapr_credentials "github.com/dapr/dapr/pkg/credentials"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/placement/monitoring"
 	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
@@ -56,6 +57,7 @@ func (p *Service) ReportDaprStatus(srv placementv1pb.PlacementService_ReportDapr
 	md, _ := metadata.FromIncomingContext(srv.Context())
 	v := md.Get("id")
 	if len(v) == 0 {
+		p.hostsLock.Unlock()
 		return errors.New("id header not found in metadata")
 	}
 
@@ -79,12 +81,13 @@ func (p *Service) ReportDaprStatus(srv placementv1pb.PlacementService_ReportDapr
 
 		req, err := srv.Recv()
 		if err != nil {
+			// the stream is closed once the host disconnected, receiving again would fail right away
 			p.hostsLock.Lock()
 			p.RemoveHost(srv)
 			p.ProcessRemovedHost(id)
 			log.Infof("host removed: %s", id)
 			p.hostsLock.Unlock()
-			continue
+			return err
 		}
 
 		p.ProcessHost(req)
@@ -236,6 +239,9 @@ func (p *Service) Run(port string, certChain *dapr_credentials.CertChain) {
 	if err != nil {
 		log.Fatalf("error creating gRPC options: %s", err)
 	}
+	opts = append(opts,
+		grpc.UnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(diag.DefaultGRPCMonitoring.StreamServerInterceptor()))
 	s := grpc.NewServer(opts...)
 	placementv1pb.RegisterPlacementServiceServer(s, p)
 
@@ -28,6 +28,62 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type UnlockResponseEnvelope_Status int32
+
+const (
+	UnlockResponseEnvelope_SUCCESS                UnlockResponseEnvelope_Status = 0
+	UnlockResponseEnvelope_LOCK_DOES_NOT_EXIST    UnlockResponseEnvelope_Status = 1
+	UnlockResponseEnvelope_LOCK_BELONGS_TO_OTHERS UnlockResponseEnvelope_Status = 2
+)
+
+var UnlockResponseEnvelope_Status_name = map[int32]string{
+	0: "SUCCESS",
+	1: "LOCK_DOES_NOT_EXIST",
+	2: "LOCK_BELONGS_TO_OTHERS",
+}
+
+var UnlockResponseEnvelope_Status_value = map[string]int32{
+	"SUCCESS":                0,
+	"LOCK_DOES_NOT_EXIST":    1,
+	"LOCK_BELONGS_TO_OTHERS": 2,
+}
+
+func (x UnlockResponseEnvelope_Status) String() string {
+	return proto.EnumName(UnlockResponseEnvelope_Status_name, int32(x))
+}
+
+func (UnlockResponseEnvelope_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{31, 0}
+}
+
+type TopicEventAckEnvelope_Status int32
+
+const (
+	TopicEventAckEnvelope_SUCCESS TopicEventAckEnvelope_Status = 0
+	TopicEventAckEnvelope_RETRY   TopicEventAckEnvelope_Status = 1
+	TopicEventAckEnvelope_DROP    TopicEventAckEnvelope_Status = 2
+)
+
+var TopicEventAckEnvelope_Status_name = map[int32]string{
+	0: "SUCCESS",
+	1: "RETRY",
+	2: "DROP",
+}
+
+var TopicEventAckEnvelope_Status_value = map[string]int32{
+	"SUCCESS": 0,
+	"RETRY":   1,
+	"DROP":    2,
+}
+
+func (x TopicEventAckEnvelope_Status) String() string {
+	return proto.EnumName(TopicEventAckEnvelope_Status_name, int32(x))
+}
+
+func (TopicEventAckEnvelope_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{51, 0}
+}
+
 // InvokeServiceRequest represents the request message for Service invocation.
 type InvokeServiceRequest struct {
 	// id specifies callee's app id.
@@ -82,6 +138,110 @@ func (m *InvokeServiceRequest) GetMessage() *v1.InvokeRequest {
 	return nil
 }
 
+// InvokeServiceStreamRequest is a message of a streamed service invocation, to
+// send data too large to buffer. The first message holds the request without
+// data, the following ones hold the chunks of the data.
+type InvokeServiceStreamRequest struct {
+	// request is the invocation request, set in the first message only.
+	Request *InvokeServiceRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// chunk is the next chunk of the request data.
+	Chunk                []byte   `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvokeServiceStreamRequest) Reset()         { *m = InvokeServiceStreamRequest{} }
+func (m *InvokeServiceStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*InvokeServiceStreamRequest) ProtoMessage()    {}
+func (*InvokeServiceStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{1}
+}
+
+func (m *InvokeServiceStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeServiceStreamRequest.Unmarshal(m, b)
+}
+func (m *InvokeServiceStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeServiceStreamRequest.Marshal(b, m, deterministic)
+}
+func (m *InvokeServiceStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeServiceStreamRequest.Merge(m, src)
+}
+func (m *InvokeServiceStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_InvokeServiceStreamRequest.Size(m)
+}
+func (m *InvokeServiceStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeServiceStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeServiceStreamRequest proto.InternalMessageInfo
+
+func (m *InvokeServiceStreamRequest) GetRequest() *InvokeServiceRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *InvokeServiceStreamRequest) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+// InvokeServiceStreamResponse is a message of a streamed service invocation
+// response. The first message holds the response without data, the following
+// ones hold the chunks of the data.
+type InvokeServiceStreamResponse struct {
+	// response is the invocation response, set in the first message only.
+	Response *v1.InvokeResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// chunk is the next chunk of the response data.
+	Chunk                []byte   `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvokeServiceStreamResponse) Reset()         { *m = InvokeServiceStreamResponse{} }
+func (m *InvokeServiceStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*InvokeServiceStreamResponse) ProtoMessage()    {}
+func (*InvokeServiceStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{2}
+}
+
+func (m *InvokeServiceStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeServiceStreamResponse.Unmarshal(m, b)
+}
+func (m *InvokeServiceStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeServiceStreamResponse.Marshal(b, m, deterministic)
+}
+func (m *InvokeServiceStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeServiceStreamResponse.Merge(m, src)
+}
+func (m *InvokeServiceStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_InvokeServiceStreamResponse.Size(m)
+}
+func (m *InvokeServiceStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeServiceStreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeServiceStreamResponse proto.InternalMessageInfo
+
+func (m *InvokeServiceStreamResponse) GetResponse() *v1.InvokeResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *InvokeServiceStreamResponse) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
 type DeleteStateEnvelope struct {
 	StoreName            string        `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
 	Key                  string        `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
@@ -96,7 +256,7 @@ func (m *DeleteStateEnvelope) Reset()         { *m = DeleteStateEnvelope{} }
 func (m *DeleteStateEnvelope) String() string { return proto.CompactTextString(m) }
 func (*DeleteStateEnvelope) ProtoMessage()    {}
 func (*DeleteStateEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{1}
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{3}
 }
 
 func (m *DeleteStateEnvelope) XXX_Unmarshal(b []byte) error {
@@ -157,7 +317,7 @@ func (m *SaveStateEnvelope) Reset()         { *m = SaveStateEnvelope{} }
 func (m *SaveStateEnvelope) String() string { return proto.CompactTextString(m) }
 func (*SaveStateEnvelope) ProtoMessage()    {}
 func (*SaveStateEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{2}
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{4}
 }
 
 func (m *SaveStateEnvelope) XXX_Unmarshal(b []byte) error {
@@ -205,7 +365,7 @@ func (m *GetStateEnvelope) Reset()         { *m = GetStateEnvelope{} }
 func (m *GetStateEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetStateEnvelope) ProtoMessage()    {}
 func (*GetStateEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{3}
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{5}
 }
 
 func (m *GetStateEnvelope) XXX_Unmarshal(b []byte) error {
@@ -259,7 +419,7 @@ func (m *GetStateResponseEnvelope) Reset()         { *m = GetStateResponseEnvelo
 func (m *GetStateResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetStateResponseEnvelope) ProtoMessage()    {}
 func (*GetStateResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{4}
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{6}
 }
 
 func (m *GetStateResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -294,792 +454,4042 @@ func (m *GetStateResponseEnvelope) GetEtag() string {
 	return ""
 }
 
-type GetSecretEnvelope struct {
-	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
-	Key                  string            `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+// GetBulkStateEnvelope gets the values of several keys of a state store in one call.
+type GetBulkStateEnvelope struct {
+	StoreName string   `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys      []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	// parallelism is the number of keys read at once. Defaults to 10.
+	Parallelism          int32    `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetSecretEnvelope) Reset()         { *m = GetSecretEnvelope{} }
-func (m *GetSecretEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetSecretEnvelope) ProtoMessage()    {}
-func (*GetSecretEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{5}
+func (m *GetBulkStateEnvelope) Reset()         { *m = GetBulkStateEnvelope{} }
+func (m *GetBulkStateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBulkStateEnvelope) ProtoMessage()    {}
+func (*GetBulkStateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{7}
 }
 
-func (m *GetSecretEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetSecretEnvelope.Unmarshal(m, b)
+func (m *GetBulkStateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBulkStateEnvelope.Unmarshal(m, b)
 }
-func (m *GetSecretEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetSecretEnvelope.Marshal(b, m, deterministic)
+func (m *GetBulkStateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBulkStateEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetSecretEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetSecretEnvelope.Merge(m, src)
+func (m *GetBulkStateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBulkStateEnvelope.Merge(m, src)
 }
-func (m *GetSecretEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetSecretEnvelope.Size(m)
+func (m *GetBulkStateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBulkStateEnvelope.Size(m)
 }
-func (m *GetSecretEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetSecretEnvelope.DiscardUnknown(m)
+func (m *GetBulkStateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBulkStateEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetSecretEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetBulkStateEnvelope proto.InternalMessageInfo
 
-func (m *GetSecretEnvelope) GetStoreName() string {
+func (m *GetBulkStateEnvelope) GetStoreName() string {
 	if m != nil {
 		return m.StoreName
 	}
 	return ""
 }
 
-func (m *GetSecretEnvelope) GetKey() string {
+func (m *GetBulkStateEnvelope) GetKeys() []string {
 	if m != nil {
-		return m.Key
+		return m.Keys
 	}
-	return ""
+	return nil
 }
 
-func (m *GetSecretEnvelope) GetMetadata() map[string]string {
+func (m *GetBulkStateEnvelope) GetParallelism() int32 {
 	if m != nil {
-		return m.Metadata
+		return m.Parallelism
 	}
-	return nil
+	return 0
 }
 
-type GetSecretResponseEnvelope struct {
-	Data                 map[string]string `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type GetBulkStateResponseEnvelope struct {
+	// items are in the order of the keys.
+	Items                []*BulkStateItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *GetSecretResponseEnvelope) Reset()         { *m = GetSecretResponseEnvelope{} }
-func (m *GetSecretResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetSecretResponseEnvelope) ProtoMessage()    {}
-func (*GetSecretResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{6}
+func (m *GetBulkStateResponseEnvelope) Reset()         { *m = GetBulkStateResponseEnvelope{} }
+func (m *GetBulkStateResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBulkStateResponseEnvelope) ProtoMessage()    {}
+func (*GetBulkStateResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{8}
 }
 
-func (m *GetSecretResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetSecretResponseEnvelope.Unmarshal(m, b)
+func (m *GetBulkStateResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBulkStateResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetSecretResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetSecretResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetBulkStateResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBulkStateResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetSecretResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetSecretResponseEnvelope.Merge(m, src)
+func (m *GetBulkStateResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBulkStateResponseEnvelope.Merge(m, src)
 }
-func (m *GetSecretResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetSecretResponseEnvelope.Size(m)
+func (m *GetBulkStateResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBulkStateResponseEnvelope.Size(m)
 }
-func (m *GetSecretResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetSecretResponseEnvelope.DiscardUnknown(m)
+func (m *GetBulkStateResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBulkStateResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetSecretResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetBulkStateResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetSecretResponseEnvelope) GetData() map[string]string {
+func (m *GetBulkStateResponseEnvelope) GetItems() []*BulkStateItem {
 	if m != nil {
-		return m.Data
+		return m.Items
 	}
 	return nil
 }
 
-type InvokeBindingEnvelope struct {
-	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Data                 *any.Any          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+// BulkStateItem is the value of a key, or the error reading it.
+type BulkStateItem struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data                 *any.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *InvokeBindingEnvelope) Reset()         { *m = InvokeBindingEnvelope{} }
-func (m *InvokeBindingEnvelope) String() string { return proto.CompactTextString(m) }
-func (*InvokeBindingEnvelope) ProtoMessage()    {}
-func (*InvokeBindingEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{7}
+func (m *BulkStateItem) Reset()         { *m = BulkStateItem{} }
+func (m *BulkStateItem) String() string { return proto.CompactTextString(m) }
+func (*BulkStateItem) ProtoMessage()    {}
+func (*BulkStateItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{9}
 }
 
-func (m *InvokeBindingEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_InvokeBindingEnvelope.Unmarshal(m, b)
+func (m *BulkStateItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkStateItem.Unmarshal(m, b)
 }
-func (m *InvokeBindingEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_InvokeBindingEnvelope.Marshal(b, m, deterministic)
+func (m *BulkStateItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkStateItem.Marshal(b, m, deterministic)
 }
-func (m *InvokeBindingEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_InvokeBindingEnvelope.Merge(m, src)
+func (m *BulkStateItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkStateItem.Merge(m, src)
 }
-func (m *InvokeBindingEnvelope) XXX_Size() int {
-	return xxx_messageInfo_InvokeBindingEnvelope.Size(m)
+func (m *BulkStateItem) XXX_Size() int {
+	return xxx_messageInfo_BulkStateItem.Size(m)
 }
-func (m *InvokeBindingEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_InvokeBindingEnvelope.DiscardUnknown(m)
+func (m *BulkStateItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkStateItem.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_InvokeBindingEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_BulkStateItem proto.InternalMessageInfo
 
-func (m *InvokeBindingEnvelope) GetName() string {
+func (m *BulkStateItem) GetKey() string {
 	if m != nil {
-		return m.Name
+		return m.Key
 	}
 	return ""
 }
 
-func (m *InvokeBindingEnvelope) GetData() *any.Any {
+func (m *BulkStateItem) GetData() *any.Any {
 	if m != nil {
 		return m.Data
 	}
 	return nil
 }
 
-func (m *InvokeBindingEnvelope) GetMetadata() map[string]string {
+func (m *BulkStateItem) GetEtag() string {
 	if m != nil {
-		return m.Metadata
+		return m.Etag
 	}
-	return nil
+	return ""
 }
 
-type PublishEventEnvelope struct {
-	Topic                string   `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
-	Data                 *any.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *BulkStateItem) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
 }
 
-func (m *PublishEventEnvelope) Reset()         { *m = PublishEventEnvelope{} }
-func (m *PublishEventEnvelope) String() string { return proto.CompactTextString(m) }
-func (*PublishEventEnvelope) ProtoMessage()    {}
-func (*PublishEventEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{8}
+// DeleteBulkStateEnvelope deletes several keys of a state store in one call.
+// The keys are deleted one by one on stores without the bulkDelete feature, stopping at the first failure.
+type DeleteBulkStateEnvelope struct {
+	StoreName            string          `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Requests             []*StateRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *PublishEventEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PublishEventEnvelope.Unmarshal(m, b)
+func (m *DeleteBulkStateEnvelope) Reset()         { *m = DeleteBulkStateEnvelope{} }
+func (m *DeleteBulkStateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DeleteBulkStateEnvelope) ProtoMessage()    {}
+func (*DeleteBulkStateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{10}
 }
-func (m *PublishEventEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PublishEventEnvelope.Marshal(b, m, deterministic)
+
+func (m *DeleteBulkStateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBulkStateEnvelope.Unmarshal(m, b)
 }
-func (m *PublishEventEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PublishEventEnvelope.Merge(m, src)
+func (m *DeleteBulkStateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBulkStateEnvelope.Marshal(b, m, deterministic)
 }
-func (m *PublishEventEnvelope) XXX_Size() int {
-	return xxx_messageInfo_PublishEventEnvelope.Size(m)
+func (m *DeleteBulkStateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBulkStateEnvelope.Merge(m, src)
 }
-func (m *PublishEventEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_PublishEventEnvelope.DiscardUnknown(m)
+func (m *DeleteBulkStateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DeleteBulkStateEnvelope.Size(m)
+}
+func (m *DeleteBulkStateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBulkStateEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PublishEventEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_DeleteBulkStateEnvelope proto.InternalMessageInfo
 
-func (m *PublishEventEnvelope) GetTopic() string {
+func (m *DeleteBulkStateEnvelope) GetStoreName() string {
 	if m != nil {
-		return m.Topic
+		return m.StoreName
 	}
 	return ""
 }
 
-func (m *PublishEventEnvelope) GetData() *any.Any {
+func (m *DeleteBulkStateEnvelope) GetRequests() []*StateRequest {
 	if m != nil {
-		return m.Data
+		return m.Requests
 	}
 	return nil
 }
 
-type State struct {
-	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                *any.Any          `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Etag                 string            `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
-	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Options              *StateOptions     `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+// ExecuteStateTransactionEnvelope runs the operations atomically on a transactional state store.
+type ExecuteStateTransactionEnvelope struct {
+	StoreName            string                         `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Operations           []*TransactionalStateOperation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
 }
 
-func (m *State) Reset()         { *m = State{} }
-func (m *State) String() string { return proto.CompactTextString(m) }
-func (*State) ProtoMessage()    {}
-func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{9}
+func (m *ExecuteStateTransactionEnvelope) Reset()         { *m = ExecuteStateTransactionEnvelope{} }
+func (m *ExecuteStateTransactionEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ExecuteStateTransactionEnvelope) ProtoMessage()    {}
+func (*ExecuteStateTransactionEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{11}
 }
 
-func (m *State) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_State.Unmarshal(m, b)
+func (m *ExecuteStateTransactionEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteStateTransactionEnvelope.Unmarshal(m, b)
 }
-func (m *State) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_State.Marshal(b, m, deterministic)
+func (m *ExecuteStateTransactionEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteStateTransactionEnvelope.Marshal(b, m, deterministic)
 }
-func (m *State) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_State.Merge(m, src)
+func (m *ExecuteStateTransactionEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteStateTransactionEnvelope.Merge(m, src)
 }
-func (m *State) XXX_Size() int {
-	return xxx_messageInfo_State.Size(m)
+func (m *ExecuteStateTransactionEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ExecuteStateTransactionEnvelope.Size(m)
 }
-func (m *State) XXX_DiscardUnknown() {
-	xxx_messageInfo_State.DiscardUnknown(m)
+func (m *ExecuteStateTransactionEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteStateTransactionEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_State proto.InternalMessageInfo
+var xxx_messageInfo_ExecuteStateTransactionEnvelope proto.InternalMessageInfo
 
-func (m *State) GetKey() string {
+func (m *ExecuteStateTransactionEnvelope) GetStoreName() string {
 	if m != nil {
-		return m.Key
+		return m.StoreName
 	}
 	return ""
 }
 
-func (m *State) GetValue() *any.Any {
+func (m *ExecuteStateTransactionEnvelope) GetOperations() []*TransactionalStateOperation {
 	if m != nil {
-		return m.Value
+		return m.Operations
 	}
 	return nil
 }
 
-func (m *State) GetEtag() string {
-	if m != nil {
-		return m.Etag
-	}
-	return ""
+// TransactionalStateOperation is an operation on a key in a state transaction.
+type TransactionalStateOperation struct {
+	// operation_type is upsert or delete.
+	OperationType        string        `protobuf:"bytes,1,opt,name=operation_type,json=operationType,proto3" json:"operation_type,omitempty"`
+	Request              *StateRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *State) GetMetadata() map[string]string {
+func (m *TransactionalStateOperation) Reset()         { *m = TransactionalStateOperation{} }
+func (m *TransactionalStateOperation) String() string { return proto.CompactTextString(m) }
+func (*TransactionalStateOperation) ProtoMessage()    {}
+func (*TransactionalStateOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{12}
+}
+
+func (m *TransactionalStateOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionalStateOperation.Unmarshal(m, b)
+}
+func (m *TransactionalStateOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionalStateOperation.Marshal(b, m, deterministic)
+}
+func (m *TransactionalStateOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionalStateOperation.Merge(m, src)
+}
+func (m *TransactionalStateOperation) XXX_Size() int {
+	return xxx_messageInfo_TransactionalStateOperation.Size(m)
+}
+func (m *TransactionalStateOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionalStateOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionalStateOperation proto.InternalMessageInfo
+
+func (m *TransactionalStateOperation) GetOperationType() string {
 	if m != nil {
-		return m.Metadata
+		return m.OperationType
 	}
-	return nil
+	return ""
 }
 
-func (m *State) GetOptions() *StateOptions {
+func (m *TransactionalStateOperation) GetRequest() *StateRequest {
 	if m != nil {
-		return m.Options
+		return m.Request
 	}
 	return nil
 }
 
-type StateOptions struct {
-	Concurrency          string       `protobuf:"bytes,1,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
-	Consistency          string       `protobuf:"bytes,2,opt,name=consistency,proto3" json:"consistency,omitempty"`
-	RetryPolicy          *RetryPolicy `protobuf:"bytes,3,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+// DeleteStateByPrefixEnvelope deletes the keys starting with a prefix a page at a time, on state stores with the
+// deleteByPrefix feature.
+type DeleteStateByPrefixEnvelope struct {
+	StoreName string `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Prefix    string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// cursor resumes an interrupted deletion.
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// page_size is the number of keys deleted at once. Defaults to 1000.
+	PageSize             int32    `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StateOptions) Reset()         { *m = StateOptions{} }
-func (m *StateOptions) String() string { return proto.CompactTextString(m) }
-func (*StateOptions) ProtoMessage()    {}
-func (*StateOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{10}
+func (m *DeleteStateByPrefixEnvelope) Reset()         { *m = DeleteStateByPrefixEnvelope{} }
+func (m *DeleteStateByPrefixEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DeleteStateByPrefixEnvelope) ProtoMessage()    {}
+func (*DeleteStateByPrefixEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{13}
 }
 
-func (m *StateOptions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StateOptions.Unmarshal(m, b)
+func (m *DeleteStateByPrefixEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteStateByPrefixEnvelope.Unmarshal(m, b)
 }
-func (m *StateOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StateOptions.Marshal(b, m, deterministic)
+func (m *DeleteStateByPrefixEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteStateByPrefixEnvelope.Marshal(b, m, deterministic)
 }
-func (m *StateOptions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StateOptions.Merge(m, src)
+func (m *DeleteStateByPrefixEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteStateByPrefixEnvelope.Merge(m, src)
 }
-func (m *StateOptions) XXX_Size() int {
-	return xxx_messageInfo_StateOptions.Size(m)
+func (m *DeleteStateByPrefixEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DeleteStateByPrefixEnvelope.Size(m)
 }
-func (m *StateOptions) XXX_DiscardUnknown() {
-	xxx_messageInfo_StateOptions.DiscardUnknown(m)
+func (m *DeleteStateByPrefixEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteStateByPrefixEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StateOptions proto.InternalMessageInfo
+var xxx_messageInfo_DeleteStateByPrefixEnvelope proto.InternalMessageInfo
 
-func (m *StateOptions) GetConcurrency() string {
+func (m *DeleteStateByPrefixEnvelope) GetStoreName() string {
 	if m != nil {
-		return m.Concurrency
+		return m.StoreName
 	}
 	return ""
 }
 
-func (m *StateOptions) GetConsistency() string {
+func (m *DeleteStateByPrefixEnvelope) GetPrefix() string {
 	if m != nil {
-		return m.Consistency
+		return m.Prefix
 	}
 	return ""
 }
 
-func (m *StateOptions) GetRetryPolicy() *RetryPolicy {
+func (m *DeleteStateByPrefixEnvelope) GetCursor() string {
 	if m != nil {
-		return m.RetryPolicy
+		return m.Cursor
 	}
-	return nil
+	return ""
 }
 
-type RetryPolicy struct {
-	Threshold            int32              `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	Pattern              string             `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
-	Interval             *duration.Duration `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+func (m *DeleteStateByPrefixEnvelope) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
 }
 
-func (m *RetryPolicy) Reset()         { *m = RetryPolicy{} }
-func (m *RetryPolicy) String() string { return proto.CompactTextString(m) }
-func (*RetryPolicy) ProtoMessage()    {}
-func (*RetryPolicy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{11}
+// DeleteStateByPrefixProgressEnvelope is sent after each deleted page.
+type DeleteStateByPrefixProgressEnvelope struct {
+	// deleted is the number of keys deleted by the call so far.
+	Deleted int64 `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// cursor resumes the deletion, which is complete when the cursor is empty.
+	Cursor               string   `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RetryPolicy) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RetryPolicy.Unmarshal(m, b)
+func (m *DeleteStateByPrefixProgressEnvelope) Reset()         { *m = DeleteStateByPrefixProgressEnvelope{} }
+func (m *DeleteStateByPrefixProgressEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DeleteStateByPrefixProgressEnvelope) ProtoMessage()    {}
+func (*DeleteStateByPrefixProgressEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{14}
 }
-func (m *RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RetryPolicy.Marshal(b, m, deterministic)
+
+func (m *DeleteStateByPrefixProgressEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteStateByPrefixProgressEnvelope.Unmarshal(m, b)
 }
-func (m *RetryPolicy) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RetryPolicy.Merge(m, src)
+func (m *DeleteStateByPrefixProgressEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteStateByPrefixProgressEnvelope.Marshal(b, m, deterministic)
 }
-func (m *RetryPolicy) XXX_Size() int {
-	return xxx_messageInfo_RetryPolicy.Size(m)
+func (m *DeleteStateByPrefixProgressEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteStateByPrefixProgressEnvelope.Merge(m, src)
 }
-func (m *RetryPolicy) XXX_DiscardUnknown() {
-	xxx_messageInfo_RetryPolicy.DiscardUnknown(m)
+func (m *DeleteStateByPrefixProgressEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DeleteStateByPrefixProgressEnvelope.Size(m)
+}
+func (m *DeleteStateByPrefixProgressEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteStateByPrefixProgressEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RetryPolicy proto.InternalMessageInfo
+var xxx_messageInfo_DeleteStateByPrefixProgressEnvelope proto.InternalMessageInfo
 
-func (m *RetryPolicy) GetThreshold() int32 {
+func (m *DeleteStateByPrefixProgressEnvelope) GetDeleted() int64 {
 	if m != nil {
-		return m.Threshold
+		return m.Deleted
 	}
 	return 0
 }
 
-func (m *RetryPolicy) GetPattern() string {
+func (m *DeleteStateByPrefixProgressEnvelope) GetCursor() string {
 	if m != nil {
-		return m.Pattern
+		return m.Cursor
 	}
 	return ""
 }
 
-func (m *RetryPolicy) GetInterval() *duration.Duration {
+// QueryStateEnvelope queries the JSON values of a state store with the query feature.
+type QueryStateEnvelope struct {
+	StoreName string `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	// query is the JSON query, with a filter, a sort and a page, e.g.
+	// {"filter": {"EQ": {"city": "Seattle"}}, "sort": [{"key": "name", "order": "DESC"}], "page": {"limit": 10}}
+	Query                string            `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *QueryStateEnvelope) Reset()         { *m = QueryStateEnvelope{} }
+func (m *QueryStateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*QueryStateEnvelope) ProtoMessage()    {}
+func (*QueryStateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{15}
+}
+
+func (m *QueryStateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateEnvelope.Unmarshal(m, b)
+}
+func (m *QueryStateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateEnvelope.Marshal(b, m, deterministic)
+}
+func (m *QueryStateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateEnvelope.Merge(m, src)
+}
+func (m *QueryStateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_QueryStateEnvelope.Size(m)
+}
+func (m *QueryStateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateEnvelope proto.InternalMessageInfo
+
+func (m *QueryStateEnvelope) GetStoreName() string {
 	if m != nil {
-		return m.Interval
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *QueryStateEnvelope) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *QueryStateEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
 	}
 	return nil
 }
 
-type StateRequest struct {
-	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                *any.Any          `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Etag                 string            `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
-	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Options              *StateOptions     `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type QueryStateResponseEnvelope struct {
+	Results []*QueryStateItem `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// token is the page token of the next results, empty on the last page.
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StateRequest) Reset()         { *m = StateRequest{} }
-func (m *StateRequest) String() string { return proto.CompactTextString(m) }
-func (*StateRequest) ProtoMessage()    {}
-func (*StateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0f3c232bd8a4c7dd, []int{12}
+func (m *QueryStateResponseEnvelope) Reset()         { *m = QueryStateResponseEnvelope{} }
+func (m *QueryStateResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*QueryStateResponseEnvelope) ProtoMessage()    {}
+func (*QueryStateResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{16}
 }
 
-func (m *StateRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StateRequest.Unmarshal(m, b)
+func (m *QueryStateResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateResponseEnvelope.Unmarshal(m, b)
 }
-func (m *StateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StateRequest.Marshal(b, m, deterministic)
+func (m *QueryStateResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *StateRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StateRequest.Merge(m, src)
+func (m *QueryStateResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateResponseEnvelope.Merge(m, src)
 }
-func (m *StateRequest) XXX_Size() int {
-	return xxx_messageInfo_StateRequest.Size(m)
+func (m *QueryStateResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_QueryStateResponseEnvelope.Size(m)
 }
-func (m *StateRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StateRequest.DiscardUnknown(m)
+func (m *QueryStateResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StateRequest proto.InternalMessageInfo
+var xxx_messageInfo_QueryStateResponseEnvelope proto.InternalMessageInfo
 
-func (m *StateRequest) GetKey() string {
+func (m *QueryStateResponseEnvelope) GetResults() []*QueryStateItem {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *QueryStateResponseEnvelope) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// QueryStateItem is a key matching a query and its value.
+type QueryStateItem struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data                 *any.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryStateItem) Reset()         { *m = QueryStateItem{} }
+func (m *QueryStateItem) String() string { return proto.CompactTextString(m) }
+func (*QueryStateItem) ProtoMessage()    {}
+func (*QueryStateItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{17}
+}
+
+func (m *QueryStateItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateItem.Unmarshal(m, b)
+}
+func (m *QueryStateItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateItem.Marshal(b, m, deterministic)
+}
+func (m *QueryStateItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateItem.Merge(m, src)
+}
+func (m *QueryStateItem) XXX_Size() int {
+	return xxx_messageInfo_QueryStateItem.Size(m)
+}
+func (m *QueryStateItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateItem proto.InternalMessageInfo
+
+func (m *QueryStateItem) GetKey() string {
 	if m != nil {
 		return m.Key
 	}
 	return ""
 }
 
-func (m *StateRequest) GetValue() *any.Any {
+func (m *QueryStateItem) GetData() *any.Any {
 	if m != nil {
-		return m.Value
+		return m.Data
 	}
 	return nil
 }
 
-func (m *StateRequest) GetEtag() string {
+func (m *QueryStateItem) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+// SubscribeStateEnvelope subscribes to the changes of keys of a state store.
+type SubscribeStateEnvelope struct {
+	StoreName string `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	// keys to watch.
+	Keys []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	// key_prefixes watches the keys starting with these prefixes.
+	// Only state stores that notify changes support watching prefixes.
+	KeyPrefixes          []string `protobuf:"bytes,3,rep,name=key_prefixes,json=keyPrefixes,proto3" json:"key_prefixes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeStateEnvelope) Reset()         { *m = SubscribeStateEnvelope{} }
+func (m *SubscribeStateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SubscribeStateEnvelope) ProtoMessage()    {}
+func (*SubscribeStateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{18}
+}
+
+func (m *SubscribeStateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeStateEnvelope.Unmarshal(m, b)
+}
+func (m *SubscribeStateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeStateEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SubscribeStateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeStateEnvelope.Merge(m, src)
+}
+func (m *SubscribeStateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SubscribeStateEnvelope.Size(m)
+}
+func (m *SubscribeStateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeStateEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeStateEnvelope proto.InternalMessageInfo
+
+func (m *SubscribeStateEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *SubscribeStateEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *SubscribeStateEnvelope) GetKeyPrefixes() []string {
+	if m != nil {
+		return m.KeyPrefixes
+	}
+	return nil
+}
+
+// StateChangeEnvelope is a change of the value of a key.
+type StateChangeEnvelope struct {
+	Key  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data *any.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Etag string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// deleted is true when the key was deleted.
+	Deleted              bool     `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateChangeEnvelope) Reset()         { *m = StateChangeEnvelope{} }
+func (m *StateChangeEnvelope) String() string { return proto.CompactTextString(m) }
+func (*StateChangeEnvelope) ProtoMessage()    {}
+func (*StateChangeEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{19}
+}
+
+func (m *StateChangeEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateChangeEnvelope.Unmarshal(m, b)
+}
+func (m *StateChangeEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateChangeEnvelope.Marshal(b, m, deterministic)
+}
+func (m *StateChangeEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateChangeEnvelope.Merge(m, src)
+}
+func (m *StateChangeEnvelope) XXX_Size() int {
+	return xxx_messageInfo_StateChangeEnvelope.Size(m)
+}
+func (m *StateChangeEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateChangeEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateChangeEnvelope proto.InternalMessageInfo
+
+func (m *StateChangeEnvelope) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateChangeEnvelope) GetData() *any.Any {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *StateChangeEnvelope) GetEtag() string {
 	if m != nil {
 		return m.Etag
 	}
 	return ""
 }
 
-func (m *StateRequest) GetMetadata() map[string]string {
-	if m != nil {
-		return m.Metadata
+func (m *StateChangeEnvelope) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+// GetNextIDEnvelope gets the next id of the sequence of a key in a state store.
+// The ids of a key strictly increase, starting at 1. The state store must have native counters or the insert feature.
+type GetNextIDEnvelope struct {
+	StoreName            string   `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNextIDEnvelope) Reset()         { *m = GetNextIDEnvelope{} }
+func (m *GetNextIDEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetNextIDEnvelope) ProtoMessage()    {}
+func (*GetNextIDEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{20}
+}
+
+func (m *GetNextIDEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNextIDEnvelope.Unmarshal(m, b)
+}
+func (m *GetNextIDEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNextIDEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetNextIDEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNextIDEnvelope.Merge(m, src)
+}
+func (m *GetNextIDEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetNextIDEnvelope.Size(m)
+}
+func (m *GetNextIDEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNextIDEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNextIDEnvelope proto.InternalMessageInfo
+
+func (m *GetNextIDEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *GetNextIDEnvelope) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type GetNextIDResponseEnvelope struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNextIDResponseEnvelope) Reset()         { *m = GetNextIDResponseEnvelope{} }
+func (m *GetNextIDResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetNextIDResponseEnvelope) ProtoMessage()    {}
+func (*GetNextIDResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{21}
+}
+
+func (m *GetNextIDResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNextIDResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetNextIDResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNextIDResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetNextIDResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNextIDResponseEnvelope.Merge(m, src)
+}
+func (m *GetNextIDResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetNextIDResponseEnvelope.Size(m)
+}
+func (m *GetNextIDResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNextIDResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNextIDResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetNextIDResponseEnvelope) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// GenerateIDEnvelope generates a unique id.
+type GenerateIDEnvelope struct {
+	// kind of the id: uuid or ulid.
+	Kind                 string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateIDEnvelope) Reset()         { *m = GenerateIDEnvelope{} }
+func (m *GenerateIDEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GenerateIDEnvelope) ProtoMessage()    {}
+func (*GenerateIDEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{22}
+}
+
+func (m *GenerateIDEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenerateIDEnvelope.Unmarshal(m, b)
+}
+func (m *GenerateIDEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenerateIDEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GenerateIDEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateIDEnvelope.Merge(m, src)
+}
+func (m *GenerateIDEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GenerateIDEnvelope.Size(m)
+}
+func (m *GenerateIDEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateIDEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateIDEnvelope proto.InternalMessageInfo
+
+func (m *GenerateIDEnvelope) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+type GenerateIDResponseEnvelope struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateIDResponseEnvelope) Reset()         { *m = GenerateIDResponseEnvelope{} }
+func (m *GenerateIDResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GenerateIDResponseEnvelope) ProtoMessage()    {}
+func (*GenerateIDResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{23}
+}
+
+func (m *GenerateIDResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenerateIDResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GenerateIDResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenerateIDResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GenerateIDResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateIDResponseEnvelope.Merge(m, src)
+}
+func (m *GenerateIDResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GenerateIDResponseEnvelope.Size(m)
+}
+func (m *GenerateIDResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateIDResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateIDResponseEnvelope proto.InternalMessageInfo
+
+func (m *GenerateIDResponseEnvelope) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// CampaignEnvelope campaigns for the leadership of an election with a lease in a state store.
+// The stream sends the candidate once it's elected, and the lease is released when the stream ends.
+// The state store must have the insert feature.
+type CampaignEnvelope struct {
+	StoreName string `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Election  string `protobuf:"bytes,2,opt,name=election,proto3" json:"election,omitempty"`
+	Candidate string `protobuf:"bytes,3,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	// ttl_in_seconds is the time the leader holds its lease without renewing it.
+	TtlInSeconds         int64    `protobuf:"varint,4,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CampaignEnvelope) Reset()         { *m = CampaignEnvelope{} }
+func (m *CampaignEnvelope) String() string { return proto.CompactTextString(m) }
+func (*CampaignEnvelope) ProtoMessage()    {}
+func (*CampaignEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{24}
+}
+
+func (m *CampaignEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CampaignEnvelope.Unmarshal(m, b)
+}
+func (m *CampaignEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CampaignEnvelope.Marshal(b, m, deterministic)
+}
+func (m *CampaignEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CampaignEnvelope.Merge(m, src)
+}
+func (m *CampaignEnvelope) XXX_Size() int {
+	return xxx_messageInfo_CampaignEnvelope.Size(m)
+}
+func (m *CampaignEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_CampaignEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CampaignEnvelope proto.InternalMessageInfo
+
+func (m *CampaignEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *CampaignEnvelope) GetElection() string {
+	if m != nil {
+		return m.Election
+	}
+	return ""
+}
+
+func (m *CampaignEnvelope) GetCandidate() string {
+	if m != nil {
+		return m.Candidate
+	}
+	return ""
+}
+
+func (m *CampaignEnvelope) GetTtlInSeconds() int64 {
+	if m != nil {
+		return m.TtlInSeconds
+	}
+	return 0
+}
+
+// ResignEnvelope ends the campaign of a candidate in an election.
+type ResignEnvelope struct {
+	StoreName            string   `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Election             string   `protobuf:"bytes,2,opt,name=election,proto3" json:"election,omitempty"`
+	Candidate            string   `protobuf:"bytes,3,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResignEnvelope) Reset()         { *m = ResignEnvelope{} }
+func (m *ResignEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ResignEnvelope) ProtoMessage()    {}
+func (*ResignEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{25}
+}
+
+func (m *ResignEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResignEnvelope.Unmarshal(m, b)
+}
+func (m *ResignEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResignEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ResignEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResignEnvelope.Merge(m, src)
+}
+func (m *ResignEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ResignEnvelope.Size(m)
+}
+func (m *ResignEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResignEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResignEnvelope proto.InternalMessageInfo
+
+func (m *ResignEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *ResignEnvelope) GetElection() string {
+	if m != nil {
+		return m.Election
+	}
+	return ""
+}
+
+func (m *ResignEnvelope) GetCandidate() string {
+	if m != nil {
+		return m.Candidate
+	}
+	return ""
+}
+
+// ObserveEnvelope observes the leader of an election.
+type ObserveEnvelope struct {
+	StoreName            string   `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Election             string   `protobuf:"bytes,2,opt,name=election,proto3" json:"election,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ObserveEnvelope) Reset()         { *m = ObserveEnvelope{} }
+func (m *ObserveEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ObserveEnvelope) ProtoMessage()    {}
+func (*ObserveEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{26}
+}
+
+func (m *ObserveEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObserveEnvelope.Unmarshal(m, b)
+}
+func (m *ObserveEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObserveEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ObserveEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObserveEnvelope.Merge(m, src)
+}
+func (m *ObserveEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ObserveEnvelope.Size(m)
+}
+func (m *ObserveEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObserveEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObserveEnvelope proto.InternalMessageInfo
+
+func (m *ObserveEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *ObserveEnvelope) GetElection() string {
+	if m != nil {
+		return m.Election
+	}
+	return ""
+}
+
+// LeaderEnvelope is the leader of an election. The leader is empty while the election has no leader.
+type LeaderEnvelope struct {
+	Election             string   `protobuf:"bytes,1,opt,name=election,proto3" json:"election,omitempty"`
+	Leader               string   `protobuf:"bytes,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaderEnvelope) Reset()         { *m = LeaderEnvelope{} }
+func (m *LeaderEnvelope) String() string { return proto.CompactTextString(m) }
+func (*LeaderEnvelope) ProtoMessage()    {}
+func (*LeaderEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{27}
+}
+
+func (m *LeaderEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderEnvelope.Unmarshal(m, b)
+}
+func (m *LeaderEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderEnvelope.Marshal(b, m, deterministic)
+}
+func (m *LeaderEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderEnvelope.Merge(m, src)
+}
+func (m *LeaderEnvelope) XXX_Size() int {
+	return xxx_messageInfo_LeaderEnvelope.Size(m)
+}
+func (m *LeaderEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderEnvelope proto.InternalMessageInfo
+
+func (m *LeaderEnvelope) GetElection() string {
+	if m != nil {
+		return m.Election
+	}
+	return ""
+}
+
+func (m *LeaderEnvelope) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+// TryLockEnvelope acquires the lock of a resource with a lease in a state store, without waiting for it to be released.
+// The state store must have native locks or the insert feature.
+type TryLockEnvelope struct {
+	StoreName  string `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	ResourceId string `protobuf:"bytes,2,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	LockOwner  string `protobuf:"bytes,3,opt,name=lock_owner,json=lockOwner,proto3" json:"lock_owner,omitempty"`
+	// expiry_in_seconds is the time the owner holds the lock without renewing it.
+	ExpiryInSeconds      int32    `protobuf:"varint,4,opt,name=expiry_in_seconds,json=expiryInSeconds,proto3" json:"expiry_in_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TryLockEnvelope) Reset()         { *m = TryLockEnvelope{} }
+func (m *TryLockEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TryLockEnvelope) ProtoMessage()    {}
+func (*TryLockEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{28}
+}
+
+func (m *TryLockEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TryLockEnvelope.Unmarshal(m, b)
+}
+func (m *TryLockEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TryLockEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TryLockEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TryLockEnvelope.Merge(m, src)
+}
+func (m *TryLockEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TryLockEnvelope.Size(m)
+}
+func (m *TryLockEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TryLockEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TryLockEnvelope proto.InternalMessageInfo
+
+func (m *TryLockEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *TryLockEnvelope) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *TryLockEnvelope) GetLockOwner() string {
+	if m != nil {
+		return m.LockOwner
+	}
+	return ""
+}
+
+func (m *TryLockEnvelope) GetExpiryInSeconds() int32 {
+	if m != nil {
+		return m.ExpiryInSeconds
+	}
+	return 0
+}
+
+// TryLockResponseEnvelope returns whether the lock was acquired, with the fencing token of the lease.
+// The token increases each time the lock changes hands, so that resources can reject the writes of previous owners.
+type TryLockResponseEnvelope struct {
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	FencingToken         int64    `protobuf:"varint,2,opt,name=fencing_token,json=fencingToken,proto3" json:"fencing_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TryLockResponseEnvelope) Reset()         { *m = TryLockResponseEnvelope{} }
+func (m *TryLockResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TryLockResponseEnvelope) ProtoMessage()    {}
+func (*TryLockResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{29}
+}
+
+func (m *TryLockResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TryLockResponseEnvelope.Unmarshal(m, b)
+}
+func (m *TryLockResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TryLockResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TryLockResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TryLockResponseEnvelope.Merge(m, src)
+}
+func (m *TryLockResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TryLockResponseEnvelope.Size(m)
+}
+func (m *TryLockResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TryLockResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TryLockResponseEnvelope proto.InternalMessageInfo
+
+func (m *TryLockResponseEnvelope) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *TryLockResponseEnvelope) GetFencingToken() int64 {
+	if m != nil {
+		return m.FencingToken
+	}
+	return 0
+}
+
+// UnlockEnvelope releases the lock of a resource held by the owner.
+type UnlockEnvelope struct {
+	StoreName            string   `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	ResourceId           string   `protobuf:"bytes,2,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	LockOwner            string   `protobuf:"bytes,3,opt,name=lock_owner,json=lockOwner,proto3" json:"lock_owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockEnvelope) Reset()         { *m = UnlockEnvelope{} }
+func (m *UnlockEnvelope) String() string { return proto.CompactTextString(m) }
+func (*UnlockEnvelope) ProtoMessage()    {}
+func (*UnlockEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{30}
+}
+
+func (m *UnlockEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockEnvelope.Unmarshal(m, b)
+}
+func (m *UnlockEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockEnvelope.Marshal(b, m, deterministic)
+}
+func (m *UnlockEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockEnvelope.Merge(m, src)
+}
+func (m *UnlockEnvelope) XXX_Size() int {
+	return xxx_messageInfo_UnlockEnvelope.Size(m)
+}
+func (m *UnlockEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockEnvelope proto.InternalMessageInfo
+
+func (m *UnlockEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *UnlockEnvelope) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *UnlockEnvelope) GetLockOwner() string {
+	if m != nil {
+		return m.LockOwner
+	}
+	return ""
+}
+
+type UnlockResponseEnvelope struct {
+	Status               UnlockResponseEnvelope_Status `protobuf:"varint,1,opt,name=status,proto3,enum=dapr.proto.dapr.v1.UnlockResponseEnvelope_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *UnlockResponseEnvelope) Reset()         { *m = UnlockResponseEnvelope{} }
+func (m *UnlockResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*UnlockResponseEnvelope) ProtoMessage()    {}
+func (*UnlockResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{31}
+}
+
+func (m *UnlockResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockResponseEnvelope.Unmarshal(m, b)
+}
+func (m *UnlockResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *UnlockResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockResponseEnvelope.Merge(m, src)
+}
+func (m *UnlockResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_UnlockResponseEnvelope.Size(m)
+}
+func (m *UnlockResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockResponseEnvelope proto.InternalMessageInfo
+
+func (m *UnlockResponseEnvelope) GetStatus() UnlockResponseEnvelope_Status {
+	if m != nil {
+		return m.Status
+	}
+	return UnlockResponseEnvelope_SUCCESS
+}
+
+// GetConfigurationEnvelope gets keys of a configuration store, or all its keys when there are no keys.
+type GetConfigurationEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys                 []string          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetConfigurationEnvelope) Reset()         { *m = GetConfigurationEnvelope{} }
+func (m *GetConfigurationEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigurationEnvelope) ProtoMessage()    {}
+func (*GetConfigurationEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{32}
+}
+
+func (m *GetConfigurationEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigurationEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigurationEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigurationEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigurationEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigurationEnvelope.Merge(m, src)
+}
+func (m *GetConfigurationEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigurationEnvelope.Size(m)
+}
+func (m *GetConfigurationEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigurationEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigurationEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigurationEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *GetConfigurationEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetConfigurationEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type GetConfigurationResponseEnvelope struct {
+	Items                []*ConfigurationItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetConfigurationResponseEnvelope) Reset()         { *m = GetConfigurationResponseEnvelope{} }
+func (m *GetConfigurationResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigurationResponseEnvelope) ProtoMessage()    {}
+func (*GetConfigurationResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{33}
+}
+
+func (m *GetConfigurationResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigurationResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigurationResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigurationResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigurationResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigurationResponseEnvelope.Merge(m, src)
+}
+func (m *GetConfigurationResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigurationResponseEnvelope.Size(m)
+}
+func (m *GetConfigurationResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigurationResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigurationResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigurationResponseEnvelope) GetItems() []*ConfigurationItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// ConfigurationItem is the value of a configuration key. The version changes each time the value changes,
+// and is empty for deleted keys.
+type ConfigurationItem struct {
+	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string            `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Version              string            `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConfigurationItem) Reset()         { *m = ConfigurationItem{} }
+func (m *ConfigurationItem) String() string { return proto.CompactTextString(m) }
+func (*ConfigurationItem) ProtoMessage()    {}
+func (*ConfigurationItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{34}
+}
+
+func (m *ConfigurationItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigurationItem.Unmarshal(m, b)
+}
+func (m *ConfigurationItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigurationItem.Marshal(b, m, deterministic)
+}
+func (m *ConfigurationItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigurationItem.Merge(m, src)
+}
+func (m *ConfigurationItem) XXX_Size() int {
+	return xxx_messageInfo_ConfigurationItem.Size(m)
+}
+func (m *ConfigurationItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigurationItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigurationItem proto.InternalMessageInfo
+
+func (m *ConfigurationItem) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ConfigurationItem) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *ConfigurationItem) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ConfigurationItem) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// SubscribeConfigurationEnvelope subscribes to the changes of keys of a configuration store, or of all its keys when
+// there are no keys. The stream sends the changes until it ends.
+type SubscribeConfigurationEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys                 []string          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SubscribeConfigurationEnvelope) Reset()         { *m = SubscribeConfigurationEnvelope{} }
+func (m *SubscribeConfigurationEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SubscribeConfigurationEnvelope) ProtoMessage()    {}
+func (*SubscribeConfigurationEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{35}
+}
+
+func (m *SubscribeConfigurationEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeConfigurationEnvelope.Unmarshal(m, b)
+}
+func (m *SubscribeConfigurationEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeConfigurationEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SubscribeConfigurationEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeConfigurationEnvelope.Merge(m, src)
+}
+func (m *SubscribeConfigurationEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SubscribeConfigurationEnvelope.Size(m)
+}
+func (m *SubscribeConfigurationEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeConfigurationEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeConfigurationEnvelope proto.InternalMessageInfo
+
+func (m *SubscribeConfigurationEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *SubscribeConfigurationEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *SubscribeConfigurationEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type SubscribeConfigurationResponseEnvelope struct {
+	Items                []*ConfigurationItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SubscribeConfigurationResponseEnvelope) Reset() {
+	*m = SubscribeConfigurationResponseEnvelope{}
+}
+func (m *SubscribeConfigurationResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SubscribeConfigurationResponseEnvelope) ProtoMessage()    {}
+func (*SubscribeConfigurationResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{36}
+}
+
+func (m *SubscribeConfigurationResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeConfigurationResponseEnvelope.Unmarshal(m, b)
+}
+func (m *SubscribeConfigurationResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeConfigurationResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SubscribeConfigurationResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeConfigurationResponseEnvelope.Merge(m, src)
+}
+func (m *SubscribeConfigurationResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SubscribeConfigurationResponseEnvelope.Size(m)
+}
+func (m *SubscribeConfigurationResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeConfigurationResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeConfigurationResponseEnvelope proto.InternalMessageInfo
+
+func (m *SubscribeConfigurationResponseEnvelope) GetItems() []*ConfigurationItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// GetComponentCapabilitiesResponseEnvelope lists the features of the loaded components.
+type GetComponentCapabilitiesResponseEnvelope struct {
+	Components           []*ComponentCapabilities `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetComponentCapabilitiesResponseEnvelope) Reset() {
+	*m = GetComponentCapabilitiesResponseEnvelope{}
+}
+func (m *GetComponentCapabilitiesResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetComponentCapabilitiesResponseEnvelope) ProtoMessage()    {}
+func (*GetComponentCapabilitiesResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{37}
+}
+
+func (m *GetComponentCapabilitiesResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetComponentCapabilitiesResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetComponentCapabilitiesResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetComponentCapabilitiesResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetComponentCapabilitiesResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetComponentCapabilitiesResponseEnvelope.Merge(m, src)
+}
+func (m *GetComponentCapabilitiesResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetComponentCapabilitiesResponseEnvelope.Size(m)
+}
+func (m *GetComponentCapabilitiesResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetComponentCapabilitiesResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetComponentCapabilitiesResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetComponentCapabilitiesResponseEnvelope) GetComponents() []*ComponentCapabilities {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+// ComponentCapabilities are the features of a component: transactional, etag, insert, query, ttl, streaming,
+// bulkDelete or deleteByPrefix.
+type ComponentCapabilities struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Features             []string `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ComponentCapabilities) Reset()         { *m = ComponentCapabilities{} }
+func (m *ComponentCapabilities) String() string { return proto.CompactTextString(m) }
+func (*ComponentCapabilities) ProtoMessage()    {}
+func (*ComponentCapabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{38}
+}
+
+func (m *ComponentCapabilities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComponentCapabilities.Unmarshal(m, b)
+}
+func (m *ComponentCapabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComponentCapabilities.Marshal(b, m, deterministic)
+}
+func (m *ComponentCapabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComponentCapabilities.Merge(m, src)
+}
+func (m *ComponentCapabilities) XXX_Size() int {
+	return xxx_messageInfo_ComponentCapabilities.Size(m)
+}
+func (m *ComponentCapabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComponentCapabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComponentCapabilities proto.InternalMessageInfo
+
+func (m *ComponentCapabilities) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ComponentCapabilities) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ComponentCapabilities) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+// GetMetadataResponseEnvelope describes the sidecar: the app id, the loaded components, the active actors and the
+// labels set by the app.
+type GetMetadataResponseEnvelope struct {
+	Id                   string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActiveActorsCount    []*ActiveActorsCount     `protobuf:"bytes,2,rep,name=active_actors_count,json=activeActorsCount,proto3" json:"active_actors_count,omitempty"`
+	RegisteredComponents []*ComponentCapabilities `protobuf:"bytes,3,rep,name=registered_components,json=registeredComponents,proto3" json:"registered_components,omitempty"`
+	ExtendedMetadata     map[string]string        `protobuf:"bytes,4,rep,name=extended_metadata,json=extendedMetadata,proto3" json:"extended_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetMetadataResponseEnvelope) Reset()         { *m = GetMetadataResponseEnvelope{} }
+func (m *GetMetadataResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetMetadataResponseEnvelope) ProtoMessage()    {}
+func (*GetMetadataResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{39}
+}
+
+func (m *GetMetadataResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMetadataResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetMetadataResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMetadataResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetMetadataResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMetadataResponseEnvelope.Merge(m, src)
+}
+func (m *GetMetadataResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetMetadataResponseEnvelope.Size(m)
+}
+func (m *GetMetadataResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMetadataResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMetadataResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetMetadataResponseEnvelope) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetMetadataResponseEnvelope) GetActiveActorsCount() []*ActiveActorsCount {
+	if m != nil {
+		return m.ActiveActorsCount
+	}
+	return nil
+}
+
+func (m *GetMetadataResponseEnvelope) GetRegisteredComponents() []*ComponentCapabilities {
+	if m != nil {
+		return m.RegisteredComponents
+	}
+	return nil
+}
+
+func (m *GetMetadataResponseEnvelope) GetExtendedMetadata() map[string]string {
+	if m != nil {
+		return m.ExtendedMetadata
+	}
+	return nil
+}
+
+type ActiveActorsCount struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActiveActorsCount) Reset()         { *m = ActiveActorsCount{} }
+func (m *ActiveActorsCount) String() string { return proto.CompactTextString(m) }
+func (*ActiveActorsCount) ProtoMessage()    {}
+func (*ActiveActorsCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{40}
+}
+
+func (m *ActiveActorsCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActiveActorsCount.Unmarshal(m, b)
+}
+func (m *ActiveActorsCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActiveActorsCount.Marshal(b, m, deterministic)
+}
+func (m *ActiveActorsCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActiveActorsCount.Merge(m, src)
+}
+func (m *ActiveActorsCount) XXX_Size() int {
+	return xxx_messageInfo_ActiveActorsCount.Size(m)
+}
+func (m *ActiveActorsCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActiveActorsCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActiveActorsCount proto.InternalMessageInfo
+
+func (m *ActiveActorsCount) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ActiveActorsCount) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// SetMetadataEnvelope sets a label of the sidecar, returned by GetMetadata.
+type SetMetadataEnvelope struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetMetadataEnvelope) Reset()         { *m = SetMetadataEnvelope{} }
+func (m *SetMetadataEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SetMetadataEnvelope) ProtoMessage()    {}
+func (*SetMetadataEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{41}
+}
+
+func (m *SetMetadataEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMetadataEnvelope.Unmarshal(m, b)
+}
+func (m *SetMetadataEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMetadataEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SetMetadataEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMetadataEnvelope.Merge(m, src)
+}
+func (m *SetMetadataEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SetMetadataEnvelope.Size(m)
+}
+func (m *SetMetadataEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMetadataEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMetadataEnvelope proto.InternalMessageInfo
+
+func (m *SetMetadataEnvelope) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetMetadataEnvelope) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type GetSecretEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Key                  string            `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetSecretEnvelope) Reset()         { *m = GetSecretEnvelope{} }
+func (m *GetSecretEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetSecretEnvelope) ProtoMessage()    {}
+func (*GetSecretEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{42}
+}
+
+func (m *GetSecretEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSecretEnvelope.Unmarshal(m, b)
+}
+func (m *GetSecretEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSecretEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetSecretEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSecretEnvelope.Merge(m, src)
+}
+func (m *GetSecretEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetSecretEnvelope.Size(m)
+}
+func (m *GetSecretEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSecretEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSecretEnvelope proto.InternalMessageInfo
+
+func (m *GetSecretEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *GetSecretEnvelope) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetSecretEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type GetSecretResponseEnvelope struct {
+	Data                 map[string]string `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetSecretResponseEnvelope) Reset()         { *m = GetSecretResponseEnvelope{} }
+func (m *GetSecretResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetSecretResponseEnvelope) ProtoMessage()    {}
+func (*GetSecretResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{43}
+}
+
+func (m *GetSecretResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSecretResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetSecretResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSecretResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetSecretResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSecretResponseEnvelope.Merge(m, src)
+}
+func (m *GetSecretResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetSecretResponseEnvelope.Size(m)
+}
+func (m *GetSecretResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSecretResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSecretResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetSecretResponseEnvelope) GetData() map[string]string {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// GetBulkSecretEnvelope reads several secrets of a secret store at once. All
+// the secrets the app is allowed to read are returned when keys is empty,
+// which requires a secret store that reads all its secrets.
+type GetBulkSecretEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys                 []string          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBulkSecretEnvelope) Reset()         { *m = GetBulkSecretEnvelope{} }
+func (m *GetBulkSecretEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBulkSecretEnvelope) ProtoMessage()    {}
+func (*GetBulkSecretEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{44}
+}
+
+func (m *GetBulkSecretEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBulkSecretEnvelope.Unmarshal(m, b)
+}
+func (m *GetBulkSecretEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBulkSecretEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetBulkSecretEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBulkSecretEnvelope.Merge(m, src)
+}
+func (m *GetBulkSecretEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBulkSecretEnvelope.Size(m)
+}
+func (m *GetBulkSecretEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBulkSecretEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBulkSecretEnvelope proto.InternalMessageInfo
+
+func (m *GetBulkSecretEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *GetBulkSecretEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetBulkSecretEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// SecretResponse holds the key/value pairs of a secret.
+type SecretResponse struct {
+	Secrets              map[string]string `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SecretResponse) Reset()         { *m = SecretResponse{} }
+func (m *SecretResponse) String() string { return proto.CompactTextString(m) }
+func (*SecretResponse) ProtoMessage()    {}
+func (*SecretResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{45}
+}
+
+func (m *SecretResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecretResponse.Unmarshal(m, b)
+}
+func (m *SecretResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecretResponse.Marshal(b, m, deterministic)
+}
+func (m *SecretResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecretResponse.Merge(m, src)
+}
+func (m *SecretResponse) XXX_Size() int {
+	return xxx_messageInfo_SecretResponse.Size(m)
+}
+func (m *SecretResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecretResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecretResponse proto.InternalMessageInfo
+
+func (m *SecretResponse) GetSecrets() map[string]string {
+	if m != nil {
+		return m.Secrets
+	}
+	return nil
+}
+
+// GetBulkSecretResponseEnvelope holds the secrets by name.
+type GetBulkSecretResponseEnvelope struct {
+	Data                 map[string]*SecretResponse `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *GetBulkSecretResponseEnvelope) Reset()         { *m = GetBulkSecretResponseEnvelope{} }
+func (m *GetBulkSecretResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBulkSecretResponseEnvelope) ProtoMessage()    {}
+func (*GetBulkSecretResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{46}
+}
+
+func (m *GetBulkSecretResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBulkSecretResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetBulkSecretResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBulkSecretResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetBulkSecretResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBulkSecretResponseEnvelope.Merge(m, src)
+}
+func (m *GetBulkSecretResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBulkSecretResponseEnvelope.Size(m)
+}
+func (m *GetBulkSecretResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBulkSecretResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBulkSecretResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetBulkSecretResponseEnvelope) GetData() map[string]*SecretResponse {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type InvokeBindingEnvelope struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data                 *any.Any          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *InvokeBindingEnvelope) Reset()         { *m = InvokeBindingEnvelope{} }
+func (m *InvokeBindingEnvelope) String() string { return proto.CompactTextString(m) }
+func (*InvokeBindingEnvelope) ProtoMessage()    {}
+func (*InvokeBindingEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{47}
+}
+
+func (m *InvokeBindingEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeBindingEnvelope.Unmarshal(m, b)
+}
+func (m *InvokeBindingEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeBindingEnvelope.Marshal(b, m, deterministic)
+}
+func (m *InvokeBindingEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeBindingEnvelope.Merge(m, src)
+}
+func (m *InvokeBindingEnvelope) XXX_Size() int {
+	return xxx_messageInfo_InvokeBindingEnvelope.Size(m)
+}
+func (m *InvokeBindingEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeBindingEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeBindingEnvelope proto.InternalMessageInfo
+
+func (m *InvokeBindingEnvelope) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *InvokeBindingEnvelope) GetData() *any.Any {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *InvokeBindingEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type PublishEventEnvelope struct {
+	Topic                string   `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Data                 *any.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishEventEnvelope) Reset()         { *m = PublishEventEnvelope{} }
+func (m *PublishEventEnvelope) String() string { return proto.CompactTextString(m) }
+func (*PublishEventEnvelope) ProtoMessage()    {}
+func (*PublishEventEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{48}
+}
+
+func (m *PublishEventEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishEventEnvelope.Unmarshal(m, b)
+}
+func (m *PublishEventEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishEventEnvelope.Marshal(b, m, deterministic)
+}
+func (m *PublishEventEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishEventEnvelope.Merge(m, src)
+}
+func (m *PublishEventEnvelope) XXX_Size() int {
+	return xxx_messageInfo_PublishEventEnvelope.Size(m)
+}
+func (m *PublishEventEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishEventEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishEventEnvelope proto.InternalMessageInfo
+
+func (m *PublishEventEnvelope) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *PublishEventEnvelope) GetData() *any.Any {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// SubscribeTopicEventsEnvelope is a message of the app on a stream subscribed to topics: the topics in the first
+// message, then the acks of the events received.
+type SubscribeTopicEventsEnvelope struct {
+	// Types that are valid to be assigned to SubscribeTopicEventsType:
+	//	*SubscribeTopicEventsEnvelope_InitialRequest
+	//	*SubscribeTopicEventsEnvelope_EventAck
+	SubscribeTopicEventsType isSubscribeTopicEventsEnvelope_SubscribeTopicEventsType `protobuf_oneof:"subscribe_topic_events_type"`
+	XXX_NoUnkeyedLiteral     struct{}                                                `json:"-"`
+	XXX_unrecognized         []byte                                                  `json:"-"`
+	XXX_sizecache            int32                                                   `json:"-"`
+}
+
+func (m *SubscribeTopicEventsEnvelope) Reset()         { *m = SubscribeTopicEventsEnvelope{} }
+func (m *SubscribeTopicEventsEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SubscribeTopicEventsEnvelope) ProtoMessage()    {}
+func (*SubscribeTopicEventsEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{49}
+}
+
+func (m *SubscribeTopicEventsEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeTopicEventsEnvelope.Unmarshal(m, b)
+}
+func (m *SubscribeTopicEventsEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeTopicEventsEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SubscribeTopicEventsEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeTopicEventsEnvelope.Merge(m, src)
+}
+func (m *SubscribeTopicEventsEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SubscribeTopicEventsEnvelope.Size(m)
+}
+func (m *SubscribeTopicEventsEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeTopicEventsEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeTopicEventsEnvelope proto.InternalMessageInfo
+
+type isSubscribeTopicEventsEnvelope_SubscribeTopicEventsType interface {
+	isSubscribeTopicEventsEnvelope_SubscribeTopicEventsType()
+}
+
+type SubscribeTopicEventsEnvelope_InitialRequest struct {
+	InitialRequest *SubscribeTopicEventsInitialEnvelope `protobuf:"bytes,1,opt,name=initial_request,json=initialRequest,proto3,oneof"`
+}
+
+type SubscribeTopicEventsEnvelope_EventAck struct {
+	EventAck *TopicEventAckEnvelope `protobuf:"bytes,2,opt,name=event_ack,json=eventAck,proto3,oneof"`
+}
+
+func (*SubscribeTopicEventsEnvelope_InitialRequest) isSubscribeTopicEventsEnvelope_SubscribeTopicEventsType() {
+}
+
+func (*SubscribeTopicEventsEnvelope_EventAck) isSubscribeTopicEventsEnvelope_SubscribeTopicEventsType() {
+}
+
+func (m *SubscribeTopicEventsEnvelope) GetSubscribeTopicEventsType() isSubscribeTopicEventsEnvelope_SubscribeTopicEventsType {
+	if m != nil {
+		return m.SubscribeTopicEventsType
+	}
+	return nil
+}
+
+func (m *SubscribeTopicEventsEnvelope) GetInitialRequest() *SubscribeTopicEventsInitialEnvelope {
+	if x, ok := m.GetSubscribeTopicEventsType().(*SubscribeTopicEventsEnvelope_InitialRequest); ok {
+		return x.InitialRequest
+	}
+	return nil
+}
+
+func (m *SubscribeTopicEventsEnvelope) GetEventAck() *TopicEventAckEnvelope {
+	if x, ok := m.GetSubscribeTopicEventsType().(*SubscribeTopicEventsEnvelope_EventAck); ok {
+		return x.EventAck
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SubscribeTopicEventsEnvelope) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SubscribeTopicEventsEnvelope_InitialRequest)(nil),
+		(*SubscribeTopicEventsEnvelope_EventAck)(nil),
+	}
+}
+
+type SubscribeTopicEventsInitialEnvelope struct {
+	Topics               []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeTopicEventsInitialEnvelope) Reset()         { *m = SubscribeTopicEventsInitialEnvelope{} }
+func (m *SubscribeTopicEventsInitialEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SubscribeTopicEventsInitialEnvelope) ProtoMessage()    {}
+func (*SubscribeTopicEventsInitialEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{50}
+}
+
+func (m *SubscribeTopicEventsInitialEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeTopicEventsInitialEnvelope.Unmarshal(m, b)
+}
+func (m *SubscribeTopicEventsInitialEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeTopicEventsInitialEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SubscribeTopicEventsInitialEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeTopicEventsInitialEnvelope.Merge(m, src)
+}
+func (m *SubscribeTopicEventsInitialEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SubscribeTopicEventsInitialEnvelope.Size(m)
+}
+func (m *SubscribeTopicEventsInitialEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeTopicEventsInitialEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeTopicEventsInitialEnvelope proto.InternalMessageInfo
+
+func (m *SubscribeTopicEventsInitialEnvelope) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+// TopicEventAckEnvelope acks an event: RETRY redelivers it, DROP discards it.
+type TopicEventAckEnvelope struct {
+	Id                   string                       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status               TopicEventAckEnvelope_Status `protobuf:"varint,2,opt,name=status,proto3,enum=dapr.proto.dapr.v1.TopicEventAckEnvelope_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *TopicEventAckEnvelope) Reset()         { *m = TopicEventAckEnvelope{} }
+func (m *TopicEventAckEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TopicEventAckEnvelope) ProtoMessage()    {}
+func (*TopicEventAckEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{51}
+}
+
+func (m *TopicEventAckEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopicEventAckEnvelope.Unmarshal(m, b)
+}
+func (m *TopicEventAckEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopicEventAckEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TopicEventAckEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopicEventAckEnvelope.Merge(m, src)
+}
+func (m *TopicEventAckEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TopicEventAckEnvelope.Size(m)
+}
+func (m *TopicEventAckEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopicEventAckEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopicEventAckEnvelope proto.InternalMessageInfo
+
+func (m *TopicEventAckEnvelope) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TopicEventAckEnvelope) GetStatus() TopicEventAckEnvelope_Status {
+	if m != nil {
+		return m.Status
+	}
+	return TopicEventAckEnvelope_SUCCESS
+}
+
+// TopicEventEnvelope is an event of a topic sent on the stream, data holds the cloud event. The event is redelivered
+// if the stream closes before it's acked.
+type TopicEventEnvelope struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic                string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TopicEventEnvelope) Reset()         { *m = TopicEventEnvelope{} }
+func (m *TopicEventEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TopicEventEnvelope) ProtoMessage()    {}
+func (*TopicEventEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{52}
+}
+
+func (m *TopicEventEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopicEventEnvelope.Unmarshal(m, b)
+}
+func (m *TopicEventEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopicEventEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TopicEventEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopicEventEnvelope.Merge(m, src)
+}
+func (m *TopicEventEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TopicEventEnvelope.Size(m)
+}
+func (m *TopicEventEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopicEventEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopicEventEnvelope proto.InternalMessageInfo
+
+func (m *TopicEventEnvelope) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TopicEventEnvelope) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *TopicEventEnvelope) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type State struct {
+	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                *any.Any          `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Etag                 string            `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Options              *StateOptions     `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *State) Reset()         { *m = State{} }
+func (m *State) String() string { return proto.CompactTextString(m) }
+func (*State) ProtoMessage()    {}
+func (*State) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{53}
+}
+
+func (m *State) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_State.Unmarshal(m, b)
+}
+func (m *State) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_State.Marshal(b, m, deterministic)
+}
+func (m *State) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_State.Merge(m, src)
+}
+func (m *State) XXX_Size() int {
+	return xxx_messageInfo_State.Size(m)
+}
+func (m *State) XXX_DiscardUnknown() {
+	xxx_messageInfo_State.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_State proto.InternalMessageInfo
+
+func (m *State) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *State) GetValue() *any.Any {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *State) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *State) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *State) GetOptions() *StateOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type StateOptions struct {
+	Concurrency          string       `protobuf:"bytes,1,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	Consistency          string       `protobuf:"bytes,2,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	RetryPolicy          *RetryPolicy `protobuf:"bytes,3,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StateOptions) Reset()         { *m = StateOptions{} }
+func (m *StateOptions) String() string { return proto.CompactTextString(m) }
+func (*StateOptions) ProtoMessage()    {}
+func (*StateOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{54}
+}
+
+func (m *StateOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateOptions.Unmarshal(m, b)
+}
+func (m *StateOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateOptions.Marshal(b, m, deterministic)
+}
+func (m *StateOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateOptions.Merge(m, src)
+}
+func (m *StateOptions) XXX_Size() int {
+	return xxx_messageInfo_StateOptions.Size(m)
+}
+func (m *StateOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateOptions proto.InternalMessageInfo
+
+func (m *StateOptions) GetConcurrency() string {
+	if m != nil {
+		return m.Concurrency
+	}
+	return ""
+}
+
+func (m *StateOptions) GetConsistency() string {
+	if m != nil {
+		return m.Consistency
+	}
+	return ""
+}
+
+func (m *StateOptions) GetRetryPolicy() *RetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+type RetryPolicy struct {
+	Threshold            int32              `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Pattern              string             `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Interval             *duration.Duration `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *RetryPolicy) Reset()         { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()    {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{55}
+}
+
+func (m *RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetryPolicy.Unmarshal(m, b)
+}
+func (m *RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetryPolicy.Marshal(b, m, deterministic)
+}
+func (m *RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetryPolicy.Merge(m, src)
+}
+func (m *RetryPolicy) XXX_Size() int {
+	return xxx_messageInfo_RetryPolicy.Size(m)
+}
+func (m *RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetryPolicy proto.InternalMessageInfo
+
+func (m *RetryPolicy) GetThreshold() int32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *RetryPolicy) GetInterval() *duration.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return nil
+}
+
+type StateRequest struct {
+	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                *any.Any          `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Etag                 string            `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Options              *StateOptions     `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *StateRequest) Reset()         { *m = StateRequest{} }
+func (m *StateRequest) String() string { return proto.CompactTextString(m) }
+func (*StateRequest) ProtoMessage()    {}
+func (*StateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f3c232bd8a4c7dd, []int{56}
+}
+
+func (m *StateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateRequest.Unmarshal(m, b)
+}
+func (m *StateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateRequest.Marshal(b, m, deterministic)
+}
+func (m *StateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateRequest.Merge(m, src)
+}
+func (m *StateRequest) XXX_Size() int {
+	return xxx_messageInfo_StateRequest.Size(m)
+}
+func (m *StateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateRequest proto.InternalMessageInfo
+
+func (m *StateRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateRequest) GetValue() *any.Any {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *StateRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *StateRequest) GetOptions() *StateOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("dapr.proto.dapr.v1.UnlockResponseEnvelope_Status", UnlockResponseEnvelope_Status_name, UnlockResponseEnvelope_Status_value)
+	proto.RegisterEnum("dapr.proto.dapr.v1.TopicEventAckEnvelope_Status", TopicEventAckEnvelope_Status_name, TopicEventAckEnvelope_Status_value)
+	proto.RegisterType((*InvokeServiceRequest)(nil), "dapr.proto.dapr.v1.InvokeServiceRequest")
+	proto.RegisterType((*InvokeServiceStreamRequest)(nil), "dapr.proto.dapr.v1.InvokeServiceStreamRequest")
+	proto.RegisterType((*InvokeServiceStreamResponse)(nil), "dapr.proto.dapr.v1.InvokeServiceStreamResponse")
+	proto.RegisterType((*DeleteStateEnvelope)(nil), "dapr.proto.dapr.v1.DeleteStateEnvelope")
+	proto.RegisterType((*SaveStateEnvelope)(nil), "dapr.proto.dapr.v1.SaveStateEnvelope")
+	proto.RegisterType((*GetStateEnvelope)(nil), "dapr.proto.dapr.v1.GetStateEnvelope")
+	proto.RegisterType((*GetStateResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetStateResponseEnvelope")
+	proto.RegisterType((*GetBulkStateEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkStateEnvelope")
+	proto.RegisterType((*GetBulkStateResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkStateResponseEnvelope")
+	proto.RegisterType((*BulkStateItem)(nil), "dapr.proto.dapr.v1.BulkStateItem")
+	proto.RegisterType((*DeleteBulkStateEnvelope)(nil), "dapr.proto.dapr.v1.DeleteBulkStateEnvelope")
+	proto.RegisterType((*ExecuteStateTransactionEnvelope)(nil), "dapr.proto.dapr.v1.ExecuteStateTransactionEnvelope")
+	proto.RegisterType((*TransactionalStateOperation)(nil), "dapr.proto.dapr.v1.TransactionalStateOperation")
+	proto.RegisterType((*DeleteStateByPrefixEnvelope)(nil), "dapr.proto.dapr.v1.DeleteStateByPrefixEnvelope")
+	proto.RegisterType((*DeleteStateByPrefixProgressEnvelope)(nil), "dapr.proto.dapr.v1.DeleteStateByPrefixProgressEnvelope")
+	proto.RegisterType((*QueryStateEnvelope)(nil), "dapr.proto.dapr.v1.QueryStateEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.QueryStateEnvelope.MetadataEntry")
+	proto.RegisterType((*QueryStateResponseEnvelope)(nil), "dapr.proto.dapr.v1.QueryStateResponseEnvelope")
+	proto.RegisterType((*QueryStateItem)(nil), "dapr.proto.dapr.v1.QueryStateItem")
+	proto.RegisterType((*SubscribeStateEnvelope)(nil), "dapr.proto.dapr.v1.SubscribeStateEnvelope")
+	proto.RegisterType((*StateChangeEnvelope)(nil), "dapr.proto.dapr.v1.StateChangeEnvelope")
+	proto.RegisterType((*GetNextIDEnvelope)(nil), "dapr.proto.dapr.v1.GetNextIDEnvelope")
+	proto.RegisterType((*GetNextIDResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetNextIDResponseEnvelope")
+	proto.RegisterType((*GenerateIDEnvelope)(nil), "dapr.proto.dapr.v1.GenerateIDEnvelope")
+	proto.RegisterType((*GenerateIDResponseEnvelope)(nil), "dapr.proto.dapr.v1.GenerateIDResponseEnvelope")
+	proto.RegisterType((*CampaignEnvelope)(nil), "dapr.proto.dapr.v1.CampaignEnvelope")
+	proto.RegisterType((*ResignEnvelope)(nil), "dapr.proto.dapr.v1.ResignEnvelope")
+	proto.RegisterType((*ObserveEnvelope)(nil), "dapr.proto.dapr.v1.ObserveEnvelope")
+	proto.RegisterType((*LeaderEnvelope)(nil), "dapr.proto.dapr.v1.LeaderEnvelope")
+	proto.RegisterType((*TryLockEnvelope)(nil), "dapr.proto.dapr.v1.TryLockEnvelope")
+	proto.RegisterType((*TryLockResponseEnvelope)(nil), "dapr.proto.dapr.v1.TryLockResponseEnvelope")
+	proto.RegisterType((*UnlockEnvelope)(nil), "dapr.proto.dapr.v1.UnlockEnvelope")
+	proto.RegisterType((*UnlockResponseEnvelope)(nil), "dapr.proto.dapr.v1.UnlockResponseEnvelope")
+	proto.RegisterType((*GetConfigurationEnvelope)(nil), "dapr.proto.dapr.v1.GetConfigurationEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetConfigurationEnvelope.MetadataEntry")
+	proto.RegisterType((*GetConfigurationResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetConfigurationResponseEnvelope")
+	proto.RegisterType((*ConfigurationItem)(nil), "dapr.proto.dapr.v1.ConfigurationItem")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.ConfigurationItem.MetadataEntry")
+	proto.RegisterType((*SubscribeConfigurationEnvelope)(nil), "dapr.proto.dapr.v1.SubscribeConfigurationEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.SubscribeConfigurationEnvelope.MetadataEntry")
+	proto.RegisterType((*SubscribeConfigurationResponseEnvelope)(nil), "dapr.proto.dapr.v1.SubscribeConfigurationResponseEnvelope")
+	proto.RegisterType((*GetComponentCapabilitiesResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetComponentCapabilitiesResponseEnvelope")
+	proto.RegisterType((*ComponentCapabilities)(nil), "dapr.proto.dapr.v1.ComponentCapabilities")
+	proto.RegisterType((*GetMetadataResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetMetadataResponseEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetMetadataResponseEnvelope.ExtendedMetadataEntry")
+	proto.RegisterType((*ActiveActorsCount)(nil), "dapr.proto.dapr.v1.ActiveActorsCount")
+	proto.RegisterType((*SetMetadataEnvelope)(nil), "dapr.proto.dapr.v1.SetMetadataEnvelope")
+	proto.RegisterType((*GetSecretEnvelope)(nil), "dapr.proto.dapr.v1.GetSecretEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetSecretEnvelope.MetadataEntry")
+	proto.RegisterType((*GetSecretResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetSecretResponseEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetSecretResponseEnvelope.DataEntry")
+	proto.RegisterType((*GetBulkSecretEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkSecretEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetBulkSecretEnvelope.MetadataEntry")
+	proto.RegisterType((*SecretResponse)(nil), "dapr.proto.dapr.v1.SecretResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.SecretResponse.SecretsEntry")
+	proto.RegisterType((*GetBulkSecretResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkSecretResponseEnvelope")
+	proto.RegisterMapType((map[string]*SecretResponse)(nil), "dapr.proto.dapr.v1.GetBulkSecretResponseEnvelope.DataEntry")
+	proto.RegisterType((*InvokeBindingEnvelope)(nil), "dapr.proto.dapr.v1.InvokeBindingEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.InvokeBindingEnvelope.MetadataEntry")
+	proto.RegisterType((*PublishEventEnvelope)(nil), "dapr.proto.dapr.v1.PublishEventEnvelope")
+	proto.RegisterType((*SubscribeTopicEventsEnvelope)(nil), "dapr.proto.dapr.v1.SubscribeTopicEventsEnvelope")
+	proto.RegisterType((*SubscribeTopicEventsInitialEnvelope)(nil), "dapr.proto.dapr.v1.SubscribeTopicEventsInitialEnvelope")
+	proto.RegisterType((*TopicEventAckEnvelope)(nil), "dapr.proto.dapr.v1.TopicEventAckEnvelope")
+	proto.RegisterType((*TopicEventEnvelope)(nil), "dapr.proto.dapr.v1.TopicEventEnvelope")
+	proto.RegisterType((*State)(nil), "dapr.proto.dapr.v1.State")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.State.MetadataEntry")
+	proto.RegisterType((*StateOptions)(nil), "dapr.proto.dapr.v1.StateOptions")
+	proto.RegisterType((*RetryPolicy)(nil), "dapr.proto.dapr.v1.RetryPolicy")
+	proto.RegisterType((*StateRequest)(nil), "dapr.proto.dapr.v1.StateRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.StateRequest.MetadataEntry")
+}
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/dapr.proto", fileDescriptor_0f3c232bd8a4c7dd) }
+
+var fileDescriptor_0f3c232bd8a4c7dd = []byte{
+	// 2679 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0x4b, 0x73, 0xdc, 0xc6,
+	0xf1, 0x27, 0x96, 0x5c, 0x72, 0xb7, 0xf9, 0x10, 0x39, 0x7c, 0x88, 0x02, 0x2d, 0x9b, 0x1e, 0x59,
+	0xfa, 0xd3, 0x92, 0xbc, 0x7c, 0xc8, 0xff, 0x92, 0x4b, 0x8f, 0x2a, 0xf3, 0xb1, 0xa1, 0x68, 0x33,
+	0x5a, 0x06, 0x4b, 0xc9, 0x8a, 0x9d, 0x18, 0x06, 0xb1, 0xc3, 0x25, 0x6a, 0xb1, 0x00, 0x0c, 0x0c,
+	0xd6, 0x5a, 0x97, 0xab, 0x92, 0xab, 0xab, 0x72, 0xc9, 0xc5, 0xbe, 0xc4, 0x87, 0x1c, 0x72, 0xc9,
+	0x25, 0x9f, 0x24, 0x97, 0x9c, 0x72, 0xc8, 0x25, 0xc9, 0x21, 0x87, 0x54, 0xe5, 0x33, 0xa4, 0x30,
+	0x78, 0x2c, 0xb0, 0x98, 0x05, 0xb1, 0x12, 0xe9, 0xca, 0x85, 0xc4, 0xcc, 0xf4, 0x74, 0xf7, 0x74,
+	0xf7, 0xf4, 0xf4, 0xcc, 0x6f, 0xe1, 0x7a, 0x43, 0xb1, 0xec, 0x75, 0xcb, 0x36, 0xa9, 0xb9, 0xce,
+	0x3e, 0x3b, 0x9b, 0xec, 0x7f, 0x85, 0x75, 0x21, 0xd4, 0xfb, 0xae, 0xb0, 0xcf, 0xce, 0xa6, 0x78,
+	0xad, 0x69, 0x9a, 0x4d, 0x9d, 0xf8, 0x93, 0x4e, 0xdc, 0xd3, 0x75, 0xc5, 0xe8, 0xfa, 0x24, 0xe2,
+	0x4a, 0xff, 0x10, 0x69, 0x5b, 0x34, 0x1c, 0x7c, 0xb3, 0x7f, 0xb0, 0xe1, 0xda, 0x0a, 0xd5, 0x4c,
+	0x23, 0x18, 0x7f, 0x3b, 0xa6, 0x8a, 0x6a, 0xb6, 0xdb, 0xa6, 0xe1, 0x29, 0xe3, 0x7f, 0xf9, 0x24,
+	0x98, 0xc0, 0xc2, 0x81, 0xd1, 0x31, 0x5b, 0xa4, 0x4e, 0xec, 0x8e, 0xa6, 0x12, 0x89, 0x7c, 0xe9,
+	0x12, 0x87, 0xa2, 0x19, 0x28, 0x68, 0x8d, 0x65, 0x61, 0x55, 0x58, 0x2b, 0x4b, 0x05, 0xad, 0x81,
+	0x1e, 0xc3, 0x44, 0x9b, 0x38, 0x8e, 0xd2, 0x24, 0xcb, 0xa3, 0xab, 0xc2, 0xda, 0xe4, 0xd6, 0x8d,
+	0x4a, 0x6c, 0x21, 0x01, 0xcb, 0xce, 0x66, 0xc5, 0x67, 0x16, 0x70, 0x91, 0xc2, 0x39, 0xb8, 0x03,
+	0x62, 0x42, 0x4c, 0x9d, 0xda, 0x44, 0x69, 0x87, 0xc2, 0x76, 0x60, 0xc2, 0xf6, 0x3f, 0x99, 0xc4,
+	0xc9, 0xad, 0xb5, 0x4a, 0xda, 0x4a, 0x15, 0x9e, 0x9e, 0x52, 0x38, 0x11, 0x2d, 0x40, 0x51, 0x3d,
+	0x73, 0x8d, 0xd6, 0x72, 0x61, 0x55, 0x58, 0x9b, 0x92, 0xfc, 0x06, 0x76, 0x61, 0x85, 0x2b, 0xd7,
+	0xb1, 0x4c, 0xc3, 0x21, 0xe8, 0x43, 0x28, 0xd9, 0xc1, 0x77, 0x20, 0xf9, 0x9d, 0xec, 0x65, 0xf9,
+	0xb4, 0x52, 0x34, 0x6b, 0x80, 0xd8, 0xef, 0x04, 0x98, 0xdf, 0x23, 0x3a, 0xa1, 0xa4, 0x4e, 0x15,
+	0x4a, 0xaa, 0x46, 0x87, 0xe8, 0xa6, 0x45, 0xd0, 0x75, 0x00, 0x87, 0x9a, 0x36, 0x91, 0x0d, 0xa5,
+	0x4d, 0x02, 0xeb, 0x96, 0x59, 0xcf, 0x53, 0xa5, 0x4d, 0xd0, 0x2c, 0x8c, 0xb6, 0x48, 0x97, 0xb1,
+	0x2a, 0x4b, 0xde, 0x27, 0x42, 0x30, 0x46, 0xa8, 0xd2, 0x64, 0x36, 0x2f, 0x4b, 0xec, 0x1b, 0x3d,
+	0x80, 0x09, 0xd3, 0xf2, 0xbc, 0xec, 0x2c, 0x8f, 0x31, 0x9d, 0x57, 0x79, 0xd6, 0x62, 0x82, 0x6b,
+	0x3e, 0x9d, 0x14, 0x4e, 0xc0, 0x16, 0xcc, 0xd5, 0x95, 0xce, 0x70, 0x5a, 0x3d, 0x82, 0x52, 0x60,
+	0x64, 0x67, 0xb9, 0xb0, 0x3a, 0x9a, 0x29, 0x30, 0x74, 0x4b, 0x34, 0x03, 0x13, 0x98, 0xdd, 0x27,
+	0xf4, 0x35, 0xcd, 0xb0, 0x0a, 0x93, 0xaa, 0x69, 0x38, 0x9a, 0x43, 0x89, 0xa1, 0x76, 0x03, 0x6b,
+	0xc4, 0xbb, 0xf0, 0x0b, 0x58, 0x0e, 0xc5, 0x84, 0x5e, 0x8a, 0xc4, 0xad, 0xc1, 0x58, 0x43, 0xa1,
+	0x4a, 0xe0, 0xe1, 0x85, 0x8a, 0xbf, 0x6b, 0x2a, 0xe1, 0xae, 0xa9, 0x6c, 0x1b, 0x5d, 0x89, 0x51,
+	0x44, 0xe6, 0x2e, 0xf4, 0xcc, 0x8d, 0x5b, 0xb0, 0xb0, 0x4f, 0xe8, 0x8e, 0xab, 0xb7, 0x86, 0x5a,
+	0x04, 0x82, 0xb1, 0x16, 0xe9, 0xfa, 0x16, 0x2b, 0x4b, 0xec, 0xdb, 0x5b, 0x86, 0xa5, 0xd8, 0x8a,
+	0xae, 0x13, 0x5d, 0x73, 0xda, 0x6c, 0x19, 0x45, 0x29, 0xde, 0x85, 0x3f, 0x81, 0x37, 0xe2, 0xc2,
+	0x52, 0x4b, 0xb9, 0x0f, 0x45, 0x8d, 0x92, 0xb6, 0xb3, 0x2c, 0x30, 0x47, 0xbc, 0xcd, 0x73, 0x44,
+	0x34, 0xfb, 0x80, 0x92, 0xb6, 0xe4, 0xd3, 0x63, 0x17, 0xa6, 0x13, 0xfd, 0xa1, 0x91, 0x85, 0x9e,
+	0x91, 0x43, 0x33, 0x15, 0x72, 0x9b, 0x29, 0x1e, 0x95, 0x0b, 0x50, 0x24, 0xb6, 0x6d, 0xda, 0x2c,
+	0x26, 0xcb, 0x92, 0xdf, 0xc0, 0x1d, 0xb8, 0xea, 0xef, 0x83, 0xa1, 0xed, 0xf7, 0x7a, 0x51, 0xf7,
+	0x5b, 0x01, 0xde, 0xaa, 0xbe, 0x24, 0xaa, 0x1b, 0xec, 0xc0, 0x63, 0x5b, 0x31, 0x1c, 0x45, 0xf5,
+	0x36, 0x41, 0x5e, 0x05, 0x6a, 0x00, 0xa6, 0x45, 0xfc, 0x7c, 0x1a, 0xaa, 0xb0, 0xce, 0x53, 0x21,
+	0xc6, 0x5b, 0xd1, 0x83, 0x6d, 0x17, 0xcc, 0x93, 0x62, 0x2c, 0xf0, 0xaf, 0x05, 0x58, 0xc9, 0xa0,
+	0x45, 0x37, 0x61, 0x26, 0xa2, 0x96, 0x69, 0xd7, 0x0a, 0x75, 0x9a, 0x8e, 0x7a, 0x8f, 0xbb, 0x16,
+	0xf1, 0xb6, 0x7f, 0x98, 0x2c, 0x0b, 0xab, 0x42, 0x2e, 0xbb, 0x84, 0x13, 0xf0, 0xb7, 0x02, 0xac,
+	0xc4, 0xf2, 0xd2, 0x4e, 0xf7, 0xc8, 0x26, 0xa7, 0xda, 0xcb, 0xbc, 0x26, 0x59, 0x82, 0x71, 0x8b,
+	0x4d, 0x08, 0x36, 0x48, 0xd0, 0xf2, 0xfa, 0x55, 0xd7, 0x76, 0x4c, 0x3b, 0x88, 0x88, 0xa0, 0x85,
+	0x56, 0xa0, 0x6c, 0x29, 0x4d, 0x22, 0x3b, 0xda, 0xd7, 0x84, 0xc5, 0x45, 0x51, 0x2a, 0x79, 0x1d,
+	0x75, 0xed, 0x6b, 0x82, 0x3f, 0x81, 0x1b, 0x1c, 0x55, 0x8e, 0x6c, 0xb3, 0x69, 0x13, 0xc7, 0x89,
+	0x54, 0x5a, 0x86, 0x89, 0x06, 0x23, 0xf3, 0x4f, 0xa3, 0x51, 0x29, 0x6c, 0xc6, 0xa4, 0x16, 0xe2,
+	0x52, 0xf1, 0x5f, 0x05, 0x40, 0x3f, 0x73, 0x89, 0xdd, 0x1d, 0x2a, 0xde, 0x16, 0xa0, 0xf8, 0xa5,
+	0x37, 0x29, 0x60, 0xe6, 0x37, 0xd0, 0x11, 0x94, 0xda, 0x84, 0x2a, 0x6c, 0x5f, 0x8c, 0xb2, 0x10,
+	0x78, 0x9f, 0x67, 0xed, 0xb4, 0xb8, 0xca, 0x4f, 0x83, 0x69, 0x55, 0x83, 0xda, 0x5d, 0x29, 0xe2,
+	0x22, 0x3e, 0x84, 0xe9, 0xc4, 0x10, 0x67, 0x23, 0x2e, 0x40, 0xb1, 0xa3, 0xe8, 0x2e, 0x09, 0x55,
+	0x61, 0x8d, 0x07, 0x85, 0x0f, 0x04, 0x6c, 0x81, 0xd8, 0x13, 0x95, 0x4a, 0x0e, 0x8f, 0xbc, 0xc8,
+	0x70, 0x5c, 0x9d, 0x86, 0xe9, 0x01, 0x67, 0xeb, 0xca, 0xf2, 0x43, 0x38, 0xc5, 0x93, 0x4a, 0xcd,
+	0x16, 0x31, 0x42, 0xa9, 0xac, 0x81, 0xbf, 0x80, 0x99, 0xe4, 0x84, 0x8b, 0x4e, 0x1c, 0xd8, 0x80,
+	0xa5, 0xba, 0x7b, 0xe2, 0xa8, 0xb6, 0x76, 0x42, 0x5e, 0x3b, 0xc3, 0xbe, 0x0d, 0x53, 0x2d, 0xd2,
+	0x95, 0xfd, 0xb8, 0x24, 0x0e, 0xf3, 0x59, 0x59, 0x9a, 0x6c, 0x91, 0x20, 0xbc, 0x88, 0x83, 0x7f,
+	0x05, 0xf3, 0x4c, 0xcc, 0xee, 0x99, 0x62, 0x34, 0x7b, 0xc2, 0x2e, 0x3a, 0x1f, 0xc6, 0xe2, 0xd6,
+	0x8b, 0xfc, 0x52, 0x14, 0xb7, 0x78, 0x0f, 0xe6, 0xf6, 0x09, 0x7d, 0x4a, 0x5e, 0xd2, 0x83, 0xbd,
+	0x57, 0x3e, 0x12, 0xf1, 0x1d, 0xb8, 0x16, 0x71, 0x49, 0x45, 0x42, 0xaf, 0x7a, 0x1b, 0xf5, 0xaa,
+	0x37, 0xbc, 0x06, 0x68, 0x9f, 0x18, 0xc4, 0xf6, 0x7c, 0xd8, 0x93, 0xe9, 0x19, 0x50, 0x33, 0xc2,
+	0x2a, 0x8f, 0x7d, 0xe3, 0xbb, 0x20, 0xf6, 0x28, 0x33, 0xf8, 0xb2, 0xaa, 0xd0, 0x4b, 0xb3, 0xb3,
+	0xbb, 0x4a, 0xdb, 0x52, 0xb4, 0x66, 0xee, 0xbc, 0x2a, 0x42, 0x89, 0xe8, 0x84, 0xa5, 0xc0, 0x60,
+	0x3d, 0x51, 0x1b, 0xbd, 0x01, 0x65, 0x55, 0x31, 0x1a, 0x5a, 0x43, 0xa1, 0x24, 0xb0, 0x66, 0xaf,
+	0x03, 0xbd, 0x03, 0x33, 0x94, 0xea, 0xb2, 0x66, 0xc8, 0x0e, 0x51, 0x4d, 0xa3, 0xe1, 0xd7, 0x3f,
+	0xa3, 0xd2, 0x14, 0xa5, 0xfa, 0x81, 0x51, 0xf7, 0xfb, 0xb0, 0x06, 0x33, 0x12, 0x71, 0x7e, 0x0c,
+	0x85, 0xf0, 0x21, 0x5c, 0xa9, 0x9d, 0x38, 0xc4, 0xee, 0x90, 0x0b, 0x90, 0x85, 0xf7, 0x60, 0xe6,
+	0x90, 0x28, 0x0d, 0x62, 0x47, 0xcc, 0xe2, 0xd4, 0x42, 0x9f, 0x66, 0x4b, 0x30, 0xae, 0x33, 0xea,
+	0x30, 0xfb, 0xf9, 0x2d, 0xfc, 0x3b, 0x01, 0xae, 0x1c, 0xdb, 0xdd, 0x43, 0x53, 0x6d, 0xe5, 0x55,
+	0xea, 0x2d, 0x98, 0xb4, 0x89, 0x63, 0xba, 0xb6, 0x4a, 0x64, 0xad, 0x11, 0xf0, 0x83, 0xb0, 0xeb,
+	0xa0, 0xe1, 0xcd, 0xd7, 0x4d, 0xb5, 0x25, 0x9b, 0x5f, 0x19, 0x24, 0xcc, 0xf1, 0x65, 0xaf, 0xa7,
+	0xe6, 0x75, 0xa0, 0xdb, 0x30, 0x47, 0x5e, 0x5a, 0x9a, 0xdd, 0xed, 0x77, 0x4d, 0x51, 0xba, 0xe2,
+	0x0f, 0xf4, 0xbc, 0xf3, 0x02, 0xae, 0x06, 0xda, 0xa5, 0x82, 0x6b, 0x19, 0x26, 0x1c, 0x57, 0x55,
+	0x89, 0xe3, 0x30, 0x15, 0x4b, 0x52, 0xd8, 0x44, 0x37, 0x60, 0xfa, 0x94, 0x18, 0xaa, 0x66, 0x34,
+	0xe5, 0x5e, 0x8a, 0x1a, 0x95, 0xa6, 0x82, 0xce, 0x63, 0x96, 0xa9, 0x4c, 0x98, 0x79, 0x66, 0xe8,
+	0x3f, 0xde, 0xb2, 0xf1, 0x9f, 0x04, 0x58, 0xf2, 0x25, 0xa6, 0x96, 0x72, 0x00, 0xe3, 0x0e, 0x55,
+	0xa8, 0xeb, 0xaf, 0x64, 0x66, 0x6b, 0x93, 0x97, 0x88, 0xf9, 0x73, 0xd9, 0xc9, 0xed, 0x3a, 0x52,
+	0xc0, 0x00, 0x7f, 0x04, 0xe3, 0x7e, 0x0f, 0x9a, 0x84, 0x89, 0xfa, 0xb3, 0xdd, 0xdd, 0x6a, 0xbd,
+	0x3e, 0x3b, 0x82, 0xae, 0xc2, 0xfc, 0x61, 0x6d, 0xf7, 0x63, 0x79, 0xaf, 0x56, 0xad, 0xcb, 0x4f,
+	0x6b, 0xc7, 0x72, 0xf5, 0xc5, 0x41, 0xfd, 0x78, 0x56, 0x40, 0x22, 0x2c, 0xb1, 0x81, 0x9d, 0xea,
+	0x61, 0xed, 0xe9, 0x7e, 0x5d, 0x3e, 0xae, 0xc9, 0xb5, 0xe3, 0x27, 0x55, 0xa9, 0x3e, 0x5b, 0xc0,
+	0xff, 0x10, 0x58, 0x95, 0xbc, 0x6b, 0x1a, 0xa7, 0x5a, 0x33, 0xb8, 0x2a, 0xbe, 0x4e, 0xb6, 0x7d,
+	0x9e, 0x3a, 0x1d, 0x1f, 0xf0, 0x16, 0x3a, 0x48, 0xe4, 0xe5, 0x9c, 0x91, 0x32, 0xac, 0xf6, 0x0b,
+	0x4c, 0xf9, 0xe7, 0x61, 0xb2, 0x8c, 0xbe, 0xc9, 0xd3, 0x3a, 0xc1, 0x21, 0x5e, 0x4a, 0xff, 0x53,
+	0x80, 0xb9, 0xd4, 0x60, 0x5e, 0x15, 0xbd, 0x28, 0xef, 0x10, 0xdb, 0xf1, 0xb6, 0xb4, 0x1f, 0x51,
+	0x61, 0x13, 0xd5, 0x62, 0xd6, 0x1c, 0x63, 0x7a, 0xdd, 0xcb, 0xa5, 0xd7, 0xe5, 0x98, 0xf1, 0x3f,
+	0x02, 0xbc, 0x19, 0x9d, 0xcb, 0x17, 0x16, 0x31, 0xbf, 0x48, 0x45, 0xcc, 0x87, 0xdc, 0xea, 0x35,
+	0x53, 0xf0, 0xe5, 0x2c, 0x98, 0xc0, 0x2d, 0xbe, 0xd8, 0x8b, 0x8d, 0x1e, 0x17, 0xd6, 0x58, 0x78,
+	0xb6, 0x2d, 0xd3, 0x20, 0x06, 0xdd, 0x55, 0x2c, 0xe5, 0x44, 0xd3, 0x35, 0xaa, 0x11, 0x87, 0x93,
+	0x46, 0x40, 0x0d, 0x09, 0x43, 0x69, 0xef, 0xf2, 0xa5, 0xf1, 0xd8, 0xc5, 0x26, 0xe3, 0xcf, 0x60,
+	0x91, 0x4b, 0xe4, 0x79, 0x29, 0xe6, 0x3e, 0xf6, 0xed, 0xf5, 0xb1, 0xfb, 0x47, 0x70, 0x0d, 0xa6,
+	0x5d, 0xff, 0x2c, 0x3a, 0x25, 0x0a, 0x75, 0xed, 0xa8, 0xaa, 0x8a, 0xda, 0xf8, 0x87, 0x51, 0x58,
+	0xd9, 0x27, 0x34, 0xb4, 0xfd, 0x79, 0x65, 0x03, 0x7a, 0x06, 0xf3, 0xde, 0x1d, 0xa8, 0x43, 0x64,
+	0x45, 0xa5, 0xa6, 0xed, 0xc8, 0xaa, 0xe9, 0x1a, 0x74, 0xb9, 0x30, 0xd8, 0x9c, 0xdb, 0x8c, 0x7c,
+	0x9b, 0x51, 0xef, 0x7a, 0xc4, 0xd2, 0x9c, 0xd2, 0xdf, 0x85, 0x3e, 0x87, 0x45, 0x9b, 0x34, 0x35,
+	0x87, 0x12, 0x9b, 0x34, 0xe4, 0x98, 0xe5, 0x46, 0x87, 0xb5, 0xdc, 0x42, 0x8f, 0x4f, 0x44, 0xe0,
+	0x20, 0xdb, 0x3b, 0xe7, 0x28, 0x31, 0x1a, 0xa4, 0x21, 0xf7, 0xed, 0xd4, 0xea, 0x80, 0xbc, 0x37,
+	0xc8, 0x24, 0x95, 0x6a, 0xc0, 0x28, 0x19, 0xca, 0xb3, 0xa4, 0xaf, 0x5b, 0xdc, 0x85, 0x45, 0x2e,
+	0xe9, 0x50, 0xa1, 0xfd, 0x18, 0xe6, 0x52, 0x06, 0x8c, 0x9c, 0x2c, 0xc4, 0x9c, 0xec, 0xbd, 0x66,
+	0x05, 0xae, 0xf0, 0x4e, 0x6f, 0xbf, 0x81, 0x1f, 0xc3, 0x7c, 0xbd, 0xb7, 0x94, 0x8c, 0x8a, 0x99,
+	0xab, 0x01, 0xfe, 0x8b, 0xc0, 0x0a, 0xde, 0x3a, 0x51, 0x6d, 0x42, 0x5f, 0xfd, 0x0d, 0xa8, 0x96,
+	0x4a, 0x1d, 0xf7, 0x06, 0x18, 0x3d, 0x29, 0xe9, 0x72, 0xb2, 0xc5, 0xef, 0x05, 0xb8, 0x16, 0x89,
+	0x4a, 0x05, 0xfc, 0xc7, 0xd1, 0x8b, 0x93, 0xa7, 0xe7, 0xfd, 0x4c, 0x3d, 0x53, 0xa1, 0xb1, 0x17,
+	0xe9, 0xca, 0x98, 0x88, 0xf7, 0xa1, 0xbc, 0xf7, 0x4a, 0x3a, 0xfe, 0x4d, 0x80, 0xc5, 0xf0, 0x35,
+	0x69, 0x28, 0xe3, 0xf3, 0x32, 0x77, 0x3d, 0x65, 0xfe, 0x41, 0xcb, 0x4a, 0xcb, 0xbb, 0x1c, 0x17,
+	0x7c, 0x2f, 0xc0, 0x4c, 0xd2, 0x84, 0xe8, 0x00, 0x26, 0x1c, 0xd6, 0x13, 0x66, 0x4b, 0xee, 0x83,
+	0x4d, 0x72, 0x52, 0xd0, 0x74, 0x7c, 0xdd, 0xc2, 0xf9, 0xe2, 0x03, 0x98, 0x8a, 0x0f, 0x0c, 0xa5,
+	0xd9, 0x9f, 0x05, 0xb8, 0x9e, 0x30, 0x44, 0x2a, 0x40, 0x6a, 0x89, 0x00, 0x79, 0x78, 0xae, 0x25,
+	0xcf, 0x0d, 0x92, 0xcf, 0xb2, 0x83, 0xe4, 0x83, 0xb8, 0xae, 0x03, 0x1e, 0x06, 0x92, 0x92, 0xe2,
+	0xeb, 0xf9, 0x97, 0x00, 0x8b, 0xfe, 0x0b, 0xf8, 0x8e, 0x66, 0x34, 0x34, 0xa3, 0x19, 0xbf, 0x42,
+	0xa6, 0x4e, 0x8f, 0xfc, 0xf7, 0xe6, 0x9c, 0x31, 0xc5, 0x15, 0x7d, 0x39, 0x31, 0xf5, 0x1c, 0x16,
+	0x8e, 0xdc, 0x13, 0x5d, 0x73, 0xce, 0xaa, 0x1d, 0x62, 0xf4, 0x36, 0x0c, 0x7b, 0x1c, 0xb1, 0x34,
+	0x35, 0xe0, 0xe2, 0x37, 0xf2, 0xaf, 0x14, 0xff, 0x5b, 0x80, 0x37, 0xa2, 0xea, 0xe2, 0xd8, 0x9b,
+	0xcc, 0xf8, 0xf7, 0x9e, 0xb9, 0x4e, 0xe0, 0x8a, 0x66, 0x68, 0x54, 0x53, 0x74, 0x39, 0x09, 0x85,
+	0xdc, 0xcf, 0xac, 0x8f, 0x62, 0xac, 0x0e, 0xfc, 0xe9, 0x21, 0xc7, 0x27, 0x23, 0xd2, 0x4c, 0xc0,
+	0x31, 0x84, 0x59, 0x9e, 0x40, 0x99, 0x78, 0xa4, 0xb2, 0xa2, 0xb6, 0x02, 0x9d, 0xb9, 0x67, 0x62,
+	0x8f, 0xe9, 0x76, 0xef, 0x36, 0xf5, 0x64, 0x44, 0x2a, 0x91, 0xa0, 0x6f, 0xe7, 0x3a, 0xac, 0x38,
+	0xa1, 0x0a, 0x32, 0xb3, 0x85, 0xcc, 0xc6, 0x1c, 0xf6, 0x6e, 0x89, 0x1f, 0xc3, 0x8d, 0x1c, 0x1a,
+	0x7a, 0x57, 0x58, 0x36, 0xd7, 0xdf, 0xac, 0x65, 0x29, 0x68, 0xe1, 0x1f, 0x04, 0x58, 0xe4, 0xea,
+	0x90, 0x2a, 0x24, 0x9e, 0x44, 0xf7, 0xac, 0x02, 0xbb, 0x67, 0x6d, 0xe4, 0x5e, 0x4e, 0xff, 0x35,
+	0xeb, 0x36, 0xff, 0x9a, 0x55, 0x86, 0xa2, 0x54, 0x3d, 0x96, 0x7e, 0x3e, 0x2b, 0xa0, 0x12, 0x8c,
+	0xed, 0x49, 0xb5, 0xa3, 0xd9, 0x02, 0x7e, 0x0a, 0xa8, 0xc7, 0x73, 0xa0, 0x6e, 0x51, 0xc8, 0x14,
+	0xe2, 0x21, 0x83, 0x82, 0x90, 0x19, 0x65, 0x70, 0x91, 0x1f, 0x1c, 0xdf, 0x17, 0xa0, 0xe8, 0x09,
+	0xe7, 0x1d, 0xa9, 0xb7, 0x93, 0x1b, 0x97, 0x1f, 0x63, 0x3e, 0x09, 0xf7, 0x19, 0x6a, 0x37, 0x75,
+	0xa9, 0xf8, 0xbf, 0x81, 0xcf, 0xc5, 0x83, 0xb6, 0x54, 0x1c, 0x71, 0x2a, 0x0e, 0x89, 0x38, 0xbd,
+	0xde, 0x76, 0xfc, 0x4e, 0x80, 0xa9, 0x38, 0xdb, 0x00, 0x08, 0x52, 0x5d, 0xdb, 0x66, 0x40, 0x90,
+	0x10, 0x01, 0x41, 0x61, 0x57, 0x3f, 0x54, 0x54, 0x48, 0x41, 0x45, 0x68, 0x07, 0xa6, 0x6c, 0x42,
+	0xed, 0xae, 0x6c, 0x99, 0xba, 0x16, 0xa0, 0x49, 0x93, 0x5b, 0x6f, 0xf1, 0x96, 0x24, 0x79, 0x74,
+	0x47, 0x8c, 0x4c, 0x9a, 0xb4, 0x7b, 0x0d, 0xfc, 0x0d, 0x4c, 0xc6, 0xc6, 0xbc, 0x67, 0x22, 0x7a,
+	0x66, 0x13, 0xe7, 0xcc, 0xd4, 0xfd, 0x10, 0x28, 0x4a, 0xbd, 0x0e, 0xef, 0xca, 0x67, 0x29, 0x94,
+	0x12, 0x3b, 0x7c, 0xf3, 0x09, 0x9b, 0xe8, 0xff, 0xa1, 0xa4, 0x19, 0x94, 0xd8, 0x1d, 0x45, 0x0f,
+	0xd4, 0xb8, 0x96, 0x72, 0xf0, 0x5e, 0x78, 0x0d, 0x89, 0x48, 0xf1, 0x1f, 0x0a, 0x81, 0x59, 0xc2,
+	0x9d, 0x7d, 0xf1, 0x71, 0xf3, 0x51, 0x2a, 0x6e, 0x2a, 0xe7, 0xc1, 0x0c, 0xff, 0x73, 0xe1, 0xb3,
+	0xf5, 0xf7, 0x25, 0x18, 0xdb, 0x53, 0x2c, 0x1b, 0x49, 0x30, 0x15, 0x4f, 0xeb, 0x88, 0x8b, 0x2f,
+	0xf3, 0x12, 0xbf, 0xb8, 0x94, 0x32, 0x5c, 0xd5, 0x03, 0xe0, 0xf1, 0x08, 0xea, 0xc0, 0x02, 0x2f,
+	0xc9, 0xa1, 0x8d, 0xbc, 0x09, 0x3b, 0x92, 0x71, 0x2b, 0x3b, 0x6b, 0x85, 0x74, 0x78, 0x64, 0x4d,
+	0xd8, 0x10, 0x90, 0x02, 0xd3, 0x09, 0x48, 0x1b, 0xe5, 0x06, 0xcb, 0xc5, 0x5c, 0xe0, 0x36, 0x1e,
+	0x41, 0xdf, 0xc0, 0x3c, 0x07, 0x35, 0x47, 0x95, 0x73, 0x05, 0x25, 0x60, 0x7d, 0x71, 0x3d, 0x37,
+	0x7d, 0x28, 0x99, 0x2d, 0xf0, 0x18, 0xa6, 0x13, 0x27, 0x3e, 0x7a, 0x37, 0x77, 0x51, 0x90, 0xe1,
+	0xae, 0x2f, 0xa0, 0x14, 0x02, 0xc4, 0xe8, 0x9d, 0x41, 0x05, 0x79, 0x1c, 0x7e, 0x10, 0xef, 0x66,
+	0x51, 0xf5, 0x17, 0x64, 0x78, 0x04, 0xe9, 0x30, 0x15, 0xc7, 0x6e, 0xf9, 0x7e, 0xe1, 0x41, 0xc9,
+	0xe2, 0xc6, 0x79, 0x94, 0x1c, 0x69, 0x2a, 0x94, 0xa3, 0x2b, 0x04, 0xba, 0x99, 0xeb, 0x26, 0x24,
+	0xbe, 0x37, 0xd4, 0x45, 0x04, 0x8f, 0x20, 0x13, 0xa6, 0x13, 0x65, 0x28, 0xdf, 0x15, 0xdc, 0x9a,
+	0x5f, 0xdc, 0x1c, 0xba, 0xa8, 0xc5, 0x23, 0xe8, 0x10, 0xca, 0xd1, 0xef, 0x13, 0xf8, 0xab, 0x4a,
+	0xfd, 0x7c, 0x21, 0xc3, 0xe7, 0x47, 0x30, 0x19, 0x83, 0x18, 0x11, 0xf7, 0xe4, 0xe3, 0xfc, 0x4c,
+	0x23, 0x83, 0xe3, 0x0b, 0xb8, 0xd2, 0x87, 0x67, 0xa3, 0x3b, 0x83, 0xb9, 0xa6, 0x3d, 0x3d, 0x98,
+	0xf3, 0x19, 0x5c, 0x1d, 0x00, 0x58, 0x23, 0xee, 0x3d, 0xf7, 0x1c, 0x74, 0x3b, 0x43, 0x92, 0x0e,
+	0xb3, 0x3d, 0x48, 0x6f, 0x5b, 0xb7, 0xce, 0x94, 0x4d, 0x74, 0x2b, 0x1f, 0xaa, 0x29, 0x56, 0xb2,
+	0xe9, 0x38, 0x1e, 0xfd, 0x56, 0x80, 0x6b, 0x1c, 0x9c, 0x37, 0x90, 0xbb, 0x7e, 0x8e, 0x4b, 0xfa,
+	0x11, 0x6a, 0xf1, 0x7e, 0xce, 0x09, 0xfd, 0x38, 0x32, 0x1e, 0xd9, 0x10, 0x90, 0x06, 0x33, 0x49,
+	0xa8, 0x11, 0xdd, 0xce, 0x4c, 0xd6, 0xc9, 0xb5, 0x0f, 0x2e, 0x9c, 0x92, 0x50, 0x22, 0x13, 0xe5,
+	0x6f, 0x4f, 0x1f, 0x9e, 0x1b, 0xb8, 0x3d, 0x93, 0x18, 0xa0, 0xf8, 0x5e, 0x26, 0x19, 0xc7, 0xb6,
+	0xa7, 0x00, 0x3d, 0xb0, 0x8e, 0xef, 0xc3, 0x34, 0xec, 0x27, 0x56, 0xb2, 0xe9, 0x38, 0x72, 0x5e,
+	0x40, 0x29, 0x44, 0xf9, 0xf8, 0xb9, 0xb3, 0x1f, 0x03, 0x14, 0xb9, 0x17, 0xcc, 0x24, 0xba, 0xc5,
+	0xcc, 0xf4, 0x13, 0x18, 0xf7, 0xc1, 0x3a, 0x84, 0xf9, 0xf5, 0x57, 0x1c, 0xc8, 0xcb, 0x88, 0xe9,
+	0xe7, 0x30, 0x11, 0x20, 0x71, 0xe8, 0x06, 0x8f, 0x51, 0x1f, 0x4c, 0x97, 0x5b, 0xbf, 0x5f, 0xc2,
+	0x44, 0x00, 0x57, 0xf1, 0xf9, 0xf6, 0x21, 0x6d, 0xe2, 0x9d, 0x0c, 0x22, 0x8e, 0x61, 0x3f, 0x85,
+	0x71, 0x1f, 0x05, 0xe2, 0x2f, 0x3f, 0x89, 0x67, 0x89, 0xb7, 0xf3, 0xa3, 0x48, 0x78, 0x04, 0xbd,
+	0x64, 0x3f, 0xbc, 0x4a, 0xbc, 0x43, 0xa3, 0xbb, 0xc3, 0xc0, 0x33, 0xe2, 0xfb, 0x79, 0xa8, 0x39,
+	0x92, 0x7f, 0x23, 0xc4, 0x20, 0xfd, 0xa4, 0x02, 0x5b, 0xc3, 0xbf, 0xf6, 0x8b, 0x0f, 0xf2, 0xcf,
+	0x49, 0x2b, 0xb3, 0x21, 0x20, 0x3b, 0x00, 0xbd, 0x78, 0xaf, 0xdf, 0x03, 0x22, 0x4a, 0x7c, 0x34,
+	0x70, 0xe9, 0x39, 0xde, 0xed, 0xd9, 0x8e, 0x99, 0x8c, 0xbd, 0xfe, 0x0e, 0x14, 0xb3, 0x3e, 0xe4,
+	0xb3, 0xb1, 0x7f, 0xa6, 0xc5, 0x1e, 0x63, 0xf9, 0x67, 0x1a, 0xe7, 0xb5, 0x36, 0x63, 0xef, 0x3c,
+	0x82, 0x52, 0xfd, 0xcc, 0xa5, 0x0d, 0xf3, 0x2b, 0x63, 0xa0, 0xa2, 0x03, 0x67, 0xef, 0x7c, 0x0e,
+	0xa0, 0x45, 0x92, 0x77, 0xc0, 0x2b, 0xb7, 0x8f, 0x3c, 0x1a, 0xe7, 0xd3, 0x5b, 0x4d, 0x8d, 0x9e,
+	0xb9, 0x27, 0x5e, 0xa1, 0xe9, 0xff, 0x10, 0x96, 0xfd, 0xb1, 0x5a, 0xcd, 0xe4, 0x8f, 0x63, 0xff,
+	0x58, 0x58, 0xf1, 0x26, 0x55, 0x76, 0x75, 0x8d, 0x18, 0xb4, 0xb2, 0xed, 0x52, 0xb3, 0x49, 0x8c,
+	0xca, 0xbe, 0x6d, 0xa9, 0x95, 0xce, 0xe6, 0xc9, 0x38, 0x23, 0xbe, 0xf7, 0xdf, 0x01, 0x00, 0xdc,
+	0xb1, 0xa3, 0xb4, 0x57, 0x2b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprClient is the client API for Dapr service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprClient interface {
+	PublishEvent(ctx context.Context, in *PublishEventEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	SubscribeTopicEvents(ctx context.Context, opts ...grpc.CallOption) (Dapr_SubscribeTopicEventsClient, error)
+	InvokeService(ctx context.Context, in *InvokeServiceRequest, opts ...grpc.CallOption) (*v1.InvokeResponse, error)
+	InvokeServiceStream(ctx context.Context, opts ...grpc.CallOption) (Dapr_InvokeServiceStreamClient, error)
+	InvokeBinding(ctx context.Context, in *InvokeBindingEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetState(ctx context.Context, in *GetStateEnvelope, opts ...grpc.CallOption) (*GetStateResponseEnvelope, error)
+	GetBulkState(ctx context.Context, in *GetBulkStateEnvelope, opts ...grpc.CallOption) (*GetBulkStateResponseEnvelope, error)
+	GetSecret(ctx context.Context, in *GetSecretEnvelope, opts ...grpc.CallOption) (*GetSecretResponseEnvelope, error)
+	GetBulkSecret(ctx context.Context, in *GetBulkSecretEnvelope, opts ...grpc.CallOption) (*GetBulkSecretResponseEnvelope, error)
+	SaveState(ctx context.Context, in *SaveStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteState(ctx context.Context, in *DeleteStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteBulkState(ctx context.Context, in *DeleteBulkStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	ExecuteStateTransaction(ctx context.Context, in *ExecuteStateTransactionEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	QueryStateAlpha1(ctx context.Context, in *QueryStateEnvelope, opts ...grpc.CallOption) (*QueryStateResponseEnvelope, error)
+	DeleteStateByPrefixAlpha1(ctx context.Context, in *DeleteStateByPrefixEnvelope, opts ...grpc.CallOption) (Dapr_DeleteStateByPrefixAlpha1Client, error)
+	SubscribeState(ctx context.Context, in *SubscribeStateEnvelope, opts ...grpc.CallOption) (Dapr_SubscribeStateClient, error)
+	GetNextID(ctx context.Context, in *GetNextIDEnvelope, opts ...grpc.CallOption) (*GetNextIDResponseEnvelope, error)
+	GenerateID(ctx context.Context, in *GenerateIDEnvelope, opts ...grpc.CallOption) (*GenerateIDResponseEnvelope, error)
+	Campaign(ctx context.Context, in *CampaignEnvelope, opts ...grpc.CallOption) (Dapr_CampaignClient, error)
+	Resign(ctx context.Context, in *ResignEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	Observe(ctx context.Context, in *ObserveEnvelope, opts ...grpc.CallOption) (Dapr_ObserveClient, error)
+	TryLock(ctx context.Context, in *TryLockEnvelope, opts ...grpc.CallOption) (*TryLockResponseEnvelope, error)
+	Unlock(ctx context.Context, in *UnlockEnvelope, opts ...grpc.CallOption) (*UnlockResponseEnvelope, error)
+	GetConfiguration(ctx context.Context, in *GetConfigurationEnvelope, opts ...grpc.CallOption) (*GetConfigurationResponseEnvelope, error)
+	SubscribeConfiguration(ctx context.Context, in *SubscribeConfigurationEnvelope, opts ...grpc.CallOption) (Dapr_SubscribeConfigurationClient, error)
+	GetComponentCapabilities(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetComponentCapabilitiesResponseEnvelope, error)
+	GetMetadata(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetMetadataResponseEnvelope, error)
+	SetMetadata(ctx context.Context, in *SetMetadataEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	Shutdown(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type daprClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprClient(cc *grpc.ClientConn) DaprClient {
+	return &daprClient{cc}
+}
+
+func (c *daprClient) PublishEvent(ctx context.Context, in *PublishEventEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/PublishEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) SubscribeTopicEvents(ctx context.Context, opts ...grpc.CallOption) (Dapr_SubscribeTopicEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Dapr_serviceDesc.Streams[0], "/dapr.proto.dapr.v1.Dapr/SubscribeTopicEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprSubscribeTopicEventsClient{stream}
+	return x, nil
+}
+
+type Dapr_SubscribeTopicEventsClient interface {
+	Send(*SubscribeTopicEventsEnvelope) error
+	Recv() (*TopicEventEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprSubscribeTopicEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprSubscribeTopicEventsClient) Send(m *SubscribeTopicEventsEnvelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *daprSubscribeTopicEventsClient) Recv() (*TopicEventEnvelope, error) {
+	m := new(TopicEventEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprClient) InvokeService(ctx context.Context, in *InvokeServiceRequest, opts ...grpc.CallOption) (*v1.InvokeResponse, error) {
+	out := new(v1.InvokeResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/InvokeService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) InvokeServiceStream(ctx context.Context, opts ...grpc.CallOption) (Dapr_InvokeServiceStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Dapr_serviceDesc.Streams[1], "/dapr.proto.dapr.v1.Dapr/InvokeServiceStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprInvokeServiceStreamClient{stream}
+	return x, nil
+}
+
+type Dapr_InvokeServiceStreamClient interface {
+	Send(*InvokeServiceStreamRequest) error
+	Recv() (*InvokeServiceStreamResponse, error)
+	grpc.ClientStream
+}
+
+type daprInvokeServiceStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprInvokeServiceStreamClient) Send(m *InvokeServiceStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *daprInvokeServiceStreamClient) Recv() (*InvokeServiceStreamResponse, error) {
+	m := new(InvokeServiceStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprClient) InvokeBinding(ctx context.Context, in *InvokeBindingEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/InvokeBinding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) GetState(ctx context.Context, in *GetStateEnvelope, opts ...grpc.CallOption) (*GetStateResponseEnvelope, error) {
+	out := new(GetStateResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) GetBulkState(ctx context.Context, in *GetBulkStateEnvelope, opts ...grpc.CallOption) (*GetBulkStateResponseEnvelope, error) {
+	out := new(GetBulkStateResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetBulkState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) GetSecret(ctx context.Context, in *GetSecretEnvelope, opts ...grpc.CallOption) (*GetSecretResponseEnvelope, error) {
+	out := new(GetSecretResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) GetBulkSecret(ctx context.Context, in *GetBulkSecretEnvelope, opts ...grpc.CallOption) (*GetBulkSecretResponseEnvelope, error) {
+	out := new(GetBulkSecretResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetBulkSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) SaveState(ctx context.Context, in *SaveStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/SaveState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) DeleteState(ctx context.Context, in *DeleteStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/DeleteState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) DeleteBulkState(ctx context.Context, in *DeleteBulkStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/DeleteBulkState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) ExecuteStateTransaction(ctx context.Context, in *ExecuteStateTransactionEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/ExecuteStateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) QueryStateAlpha1(ctx context.Context, in *QueryStateEnvelope, opts ...grpc.CallOption) (*QueryStateResponseEnvelope, error) {
+	out := new(QueryStateResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/QueryStateAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) DeleteStateByPrefixAlpha1(ctx context.Context, in *DeleteStateByPrefixEnvelope, opts ...grpc.CallOption) (Dapr_DeleteStateByPrefixAlpha1Client, error) {
+	stream, err := c.cc.NewStream(ctx, &_Dapr_serviceDesc.Streams[2], "/dapr.proto.dapr.v1.Dapr/DeleteStateByPrefixAlpha1", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprDeleteStateByPrefixAlpha1Client{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dapr_DeleteStateByPrefixAlpha1Client interface {
+	Recv() (*DeleteStateByPrefixProgressEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprDeleteStateByPrefixAlpha1Client struct {
+	grpc.ClientStream
+}
+
+func (x *daprDeleteStateByPrefixAlpha1Client) Recv() (*DeleteStateByPrefixProgressEnvelope, error) {
+	m := new(DeleteStateByPrefixProgressEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprClient) SubscribeState(ctx context.Context, in *SubscribeStateEnvelope, opts ...grpc.CallOption) (Dapr_SubscribeStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Dapr_serviceDesc.Streams[3], "/dapr.proto.dapr.v1.Dapr/SubscribeState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprSubscribeStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dapr_SubscribeStateClient interface {
+	Recv() (*StateChangeEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprSubscribeStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprSubscribeStateClient) Recv() (*StateChangeEnvelope, error) {
+	m := new(StateChangeEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprClient) GetNextID(ctx context.Context, in *GetNextIDEnvelope, opts ...grpc.CallOption) (*GetNextIDResponseEnvelope, error) {
+	out := new(GetNextIDResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetNextID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) GenerateID(ctx context.Context, in *GenerateIDEnvelope, opts ...grpc.CallOption) (*GenerateIDResponseEnvelope, error) {
+	out := new(GenerateIDResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GenerateID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) Campaign(ctx context.Context, in *CampaignEnvelope, opts ...grpc.CallOption) (Dapr_CampaignClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Dapr_serviceDesc.Streams[4], "/dapr.proto.dapr.v1.Dapr/Campaign", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprCampaignClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dapr_CampaignClient interface {
+	Recv() (*LeaderEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprCampaignClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprCampaignClient) Recv() (*LeaderEnvelope, error) {
+	m := new(LeaderEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprClient) Resign(ctx context.Context, in *ResignEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/Resign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) Observe(ctx context.Context, in *ObserveEnvelope, opts ...grpc.CallOption) (Dapr_ObserveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Dapr_serviceDesc.Streams[5], "/dapr.proto.dapr.v1.Dapr/Observe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprObserveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dapr_ObserveClient interface {
+	Recv() (*LeaderEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprObserveClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprObserveClient) Recv() (*LeaderEnvelope, error) {
+	m := new(LeaderEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprClient) TryLock(ctx context.Context, in *TryLockEnvelope, opts ...grpc.CallOption) (*TryLockResponseEnvelope, error) {
+	out := new(TryLockResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/TryLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) Unlock(ctx context.Context, in *UnlockEnvelope, opts ...grpc.CallOption) (*UnlockResponseEnvelope, error) {
+	out := new(UnlockResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/Unlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) GetConfiguration(ctx context.Context, in *GetConfigurationEnvelope, opts ...grpc.CallOption) (*GetConfigurationResponseEnvelope, error) {
+	out := new(GetConfigurationResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) SubscribeConfiguration(ctx context.Context, in *SubscribeConfigurationEnvelope, opts ...grpc.CallOption) (Dapr_SubscribeConfigurationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Dapr_serviceDesc.Streams[6], "/dapr.proto.dapr.v1.Dapr/SubscribeConfiguration", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprSubscribeConfigurationClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dapr_SubscribeConfigurationClient interface {
+	Recv() (*SubscribeConfigurationResponseEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprSubscribeConfigurationClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprSubscribeConfigurationClient) Recv() (*SubscribeConfigurationResponseEnvelope, error) {
+	m := new(SubscribeConfigurationResponseEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprClient) GetComponentCapabilities(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetComponentCapabilitiesResponseEnvelope, error) {
+	out := new(GetComponentCapabilitiesResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetComponentCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) GetMetadata(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetMetadataResponseEnvelope, error) {
+	out := new(GetMetadataResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) SetMetadata(ctx context.Context, in *SetMetadataEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/SetMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprClient) Shutdown(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprServer is the server API for Dapr service.
+type DaprServer interface {
+	PublishEvent(context.Context, *PublishEventEnvelope) (*empty.Empty, error)
+	SubscribeTopicEvents(Dapr_SubscribeTopicEventsServer) error
+	InvokeService(context.Context, *InvokeServiceRequest) (*v1.InvokeResponse, error)
+	InvokeServiceStream(Dapr_InvokeServiceStreamServer) error
+	InvokeBinding(context.Context, *InvokeBindingEnvelope) (*empty.Empty, error)
+	GetState(context.Context, *GetStateEnvelope) (*GetStateResponseEnvelope, error)
+	GetBulkState(context.Context, *GetBulkStateEnvelope) (*GetBulkStateResponseEnvelope, error)
+	GetSecret(context.Context, *GetSecretEnvelope) (*GetSecretResponseEnvelope, error)
+	GetBulkSecret(context.Context, *GetBulkSecretEnvelope) (*GetBulkSecretResponseEnvelope, error)
+	SaveState(context.Context, *SaveStateEnvelope) (*empty.Empty, error)
+	DeleteState(context.Context, *DeleteStateEnvelope) (*empty.Empty, error)
+	DeleteBulkState(context.Context, *DeleteBulkStateEnvelope) (*empty.Empty, error)
+	ExecuteStateTransaction(context.Context, *ExecuteStateTransactionEnvelope) (*empty.Empty, error)
+	QueryStateAlpha1(context.Context, *QueryStateEnvelope) (*QueryStateResponseEnvelope, error)
+	DeleteStateByPrefixAlpha1(*DeleteStateByPrefixEnvelope, Dapr_DeleteStateByPrefixAlpha1Server) error
+	SubscribeState(*SubscribeStateEnvelope, Dapr_SubscribeStateServer) error
+	GetNextID(context.Context, *GetNextIDEnvelope) (*GetNextIDResponseEnvelope, error)
+	GenerateID(context.Context, *GenerateIDEnvelope) (*GenerateIDResponseEnvelope, error)
+	Campaign(*CampaignEnvelope, Dapr_CampaignServer) error
+	Resign(context.Context, *ResignEnvelope) (*empty.Empty, error)
+	Observe(*ObserveEnvelope, Dapr_ObserveServer) error
+	TryLock(context.Context, *TryLockEnvelope) (*TryLockResponseEnvelope, error)
+	Unlock(context.Context, *UnlockEnvelope) (*UnlockResponseEnvelope, error)
+	GetConfiguration(context.Context, *GetConfigurationEnvelope) (*GetConfigurationResponseEnvelope, error)
+	SubscribeConfiguration(*SubscribeConfigurationEnvelope, Dapr_SubscribeConfigurationServer) error
+	GetComponentCapabilities(context.Context, *empty.Empty) (*GetComponentCapabilitiesResponseEnvelope, error)
+	GetMetadata(context.Context, *empty.Empty) (*GetMetadataResponseEnvelope, error)
+	SetMetadata(context.Context, *SetMetadataEnvelope) (*empty.Empty, error)
+	Shutdown(context.Context, *empty.Empty) (*empty.Empty, error)
+}
+
+// UnimplementedDaprServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprServer struct {
+}
+
+func (*UnimplementedDaprServer) PublishEvent(ctx context.Context, req *PublishEventEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishEvent not implemented")
+}
+func (*UnimplementedDaprServer) SubscribeTopicEvents(srv Dapr_SubscribeTopicEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTopicEvents not implemented")
+}
+func (*UnimplementedDaprServer) InvokeService(ctx context.Context, req *InvokeServiceRequest) (*v1.InvokeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeService not implemented")
+}
+func (*UnimplementedDaprServer) InvokeServiceStream(srv Dapr_InvokeServiceStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method InvokeServiceStream not implemented")
+}
+func (*UnimplementedDaprServer) InvokeBinding(ctx context.Context, req *InvokeBindingEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeBinding not implemented")
+}
+func (*UnimplementedDaprServer) GetState(ctx context.Context, req *GetStateEnvelope) (*GetStateResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (*UnimplementedDaprServer) GetBulkState(ctx context.Context, req *GetBulkStateEnvelope) (*GetBulkStateResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBulkState not implemented")
+}
+func (*UnimplementedDaprServer) GetSecret(ctx context.Context, req *GetSecretEnvelope) (*GetSecretResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecret not implemented")
+}
+func (*UnimplementedDaprServer) GetBulkSecret(ctx context.Context, req *GetBulkSecretEnvelope) (*GetBulkSecretResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBulkSecret not implemented")
+}
+func (*UnimplementedDaprServer) SaveState(ctx context.Context, req *SaveStateEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveState not implemented")
+}
+func (*UnimplementedDaprServer) DeleteState(ctx context.Context, req *DeleteStateEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteState not implemented")
+}
+func (*UnimplementedDaprServer) DeleteBulkState(ctx context.Context, req *DeleteBulkStateEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBulkState not implemented")
+}
+func (*UnimplementedDaprServer) ExecuteStateTransaction(ctx context.Context, req *ExecuteStateTransactionEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteStateTransaction not implemented")
+}
+func (*UnimplementedDaprServer) QueryStateAlpha1(ctx context.Context, req *QueryStateEnvelope) (*QueryStateResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryStateAlpha1 not implemented")
+}
+func (*UnimplementedDaprServer) DeleteStateByPrefixAlpha1(req *DeleteStateByPrefixEnvelope, srv Dapr_DeleteStateByPrefixAlpha1Server) error {
+	return status.Errorf(codes.Unimplemented, "method DeleteStateByPrefixAlpha1 not implemented")
+}
+func (*UnimplementedDaprServer) SubscribeState(req *SubscribeStateEnvelope, srv Dapr_SubscribeStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeState not implemented")
+}
+func (*UnimplementedDaprServer) GetNextID(ctx context.Context, req *GetNextIDEnvelope) (*GetNextIDResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextID not implemented")
+}
+func (*UnimplementedDaprServer) GenerateID(ctx context.Context, req *GenerateIDEnvelope) (*GenerateIDResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateID not implemented")
+}
+func (*UnimplementedDaprServer) Campaign(req *CampaignEnvelope, srv Dapr_CampaignServer) error {
+	return status.Errorf(codes.Unimplemented, "method Campaign not implemented")
+}
+func (*UnimplementedDaprServer) Resign(ctx context.Context, req *ResignEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resign not implemented")
+}
+func (*UnimplementedDaprServer) Observe(req *ObserveEnvelope, srv Dapr_ObserveServer) error {
+	return status.Errorf(codes.Unimplemented, "method Observe not implemented")
+}
+func (*UnimplementedDaprServer) TryLock(ctx context.Context, req *TryLockEnvelope) (*TryLockResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TryLock not implemented")
+}
+func (*UnimplementedDaprServer) Unlock(ctx context.Context, req *UnlockEnvelope) (*UnlockResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
+func (*UnimplementedDaprServer) GetConfiguration(ctx context.Context, req *GetConfigurationEnvelope) (*GetConfigurationResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguration not implemented")
+}
+func (*UnimplementedDaprServer) SubscribeConfiguration(req *SubscribeConfigurationEnvelope, srv Dapr_SubscribeConfigurationServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeConfiguration not implemented")
+}
+func (*UnimplementedDaprServer) GetComponentCapabilities(ctx context.Context, req *empty.Empty) (*GetComponentCapabilitiesResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComponentCapabilities not implemented")
+}
+func (*UnimplementedDaprServer) GetMetadata(ctx context.Context, req *empty.Empty) (*GetMetadataResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetadata not implemented")
+}
+func (*UnimplementedDaprServer) SetMetadata(ctx context.Context, req *SetMetadataEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMetadata not implemented")
+}
+func (*UnimplementedDaprServer) Shutdown(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+
+func RegisterDaprServer(s *grpc.Server, srv DaprServer) {
+	s.RegisterService(&_Dapr_serviceDesc, srv)
+}
+
+func _Dapr_PublishEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishEventEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).PublishEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/PublishEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).PublishEvent(ctx, req.(*PublishEventEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_SubscribeTopicEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DaprServer).SubscribeTopicEvents(&daprSubscribeTopicEventsServer{stream})
+}
+
+type Dapr_SubscribeTopicEventsServer interface {
+	Send(*TopicEventEnvelope) error
+	Recv() (*SubscribeTopicEventsEnvelope, error)
+	grpc.ServerStream
+}
+
+type daprSubscribeTopicEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprSubscribeTopicEventsServer) Send(m *TopicEventEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *daprSubscribeTopicEventsServer) Recv() (*SubscribeTopicEventsEnvelope, error) {
+	m := new(SubscribeTopicEventsEnvelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Dapr_InvokeService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).InvokeService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/InvokeService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).InvokeService(ctx, req.(*InvokeServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_InvokeServiceStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DaprServer).InvokeServiceStream(&daprInvokeServiceStreamServer{stream})
+}
+
+type Dapr_InvokeServiceStreamServer interface {
+	Send(*InvokeServiceStreamResponse) error
+	Recv() (*InvokeServiceStreamRequest, error)
+	grpc.ServerStream
+}
+
+type daprInvokeServiceStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprInvokeServiceStreamServer) Send(m *InvokeServiceStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *daprInvokeServiceStreamServer) Recv() (*InvokeServiceStreamRequest, error) {
+	m := new(InvokeServiceStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Dapr_InvokeBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeBindingEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).InvokeBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/InvokeBinding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).InvokeBinding(ctx, req.(*InvokeBindingEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).GetState(ctx, req.(*GetStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_GetBulkState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).GetBulkState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetBulkState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).GetBulkState(ctx, req.(*GetBulkStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).GetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).GetSecret(ctx, req.(*GetSecretEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_GetBulkSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkSecretEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).GetBulkSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetBulkSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).GetBulkSecret(ctx, req.(*GetBulkSecretEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_SaveState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).SaveState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/SaveState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).SaveState(ctx, req.(*SaveStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_DeleteState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).DeleteState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/DeleteState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).DeleteState(ctx, req.(*DeleteStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_DeleteBulkState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBulkStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).DeleteBulkState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/DeleteBulkState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).DeleteBulkState(ctx, req.(*DeleteBulkStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_ExecuteStateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteStateTransactionEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprServer).ExecuteStateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/ExecuteStateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).ExecuteStateTransaction(ctx, req.(*ExecuteStateTransactionEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dapr_QueryStateAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return nil
+	if interceptor == nil {
+		return srv.(DaprServer).QueryStateAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/QueryStateAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).QueryStateAlpha1(ctx, req.(*QueryStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *StateRequest) GetOptions() *StateOptions {
-	if m != nil {
-		return m.Options
+func _Dapr_DeleteStateByPrefixAlpha1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeleteStateByPrefixEnvelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return nil
+	return srv.(DaprServer).DeleteStateByPrefixAlpha1(m, &daprDeleteStateByPrefixAlpha1Server{stream})
 }
 
-func init() {
-	proto.RegisterType((*InvokeServiceRequest)(nil), "dapr.proto.dapr.v1.InvokeServiceRequest")
-	proto.RegisterType((*DeleteStateEnvelope)(nil), "dapr.proto.dapr.v1.DeleteStateEnvelope")
-	proto.RegisterType((*SaveStateEnvelope)(nil), "dapr.proto.dapr.v1.SaveStateEnvelope")
-	proto.RegisterType((*GetStateEnvelope)(nil), "dapr.proto.dapr.v1.GetStateEnvelope")
-	proto.RegisterType((*GetStateResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetStateResponseEnvelope")
-	proto.RegisterType((*GetSecretEnvelope)(nil), "dapr.proto.dapr.v1.GetSecretEnvelope")
-	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetSecretEnvelope.MetadataEntry")
-	proto.RegisterType((*GetSecretResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetSecretResponseEnvelope")
-	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetSecretResponseEnvelope.DataEntry")
-	proto.RegisterType((*InvokeBindingEnvelope)(nil), "dapr.proto.dapr.v1.InvokeBindingEnvelope")
-	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.InvokeBindingEnvelope.MetadataEntry")
-	proto.RegisterType((*PublishEventEnvelope)(nil), "dapr.proto.dapr.v1.PublishEventEnvelope")
-	proto.RegisterType((*State)(nil), "dapr.proto.dapr.v1.State")
-	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.State.MetadataEntry")
-	proto.RegisterType((*StateOptions)(nil), "dapr.proto.dapr.v1.StateOptions")
-	proto.RegisterType((*RetryPolicy)(nil), "dapr.proto.dapr.v1.RetryPolicy")
-	proto.RegisterType((*StateRequest)(nil), "dapr.proto.dapr.v1.StateRequest")
-	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.StateRequest.MetadataEntry")
+type Dapr_DeleteStateByPrefixAlpha1Server interface {
+	Send(*DeleteStateByPrefixProgressEnvelope) error
+	grpc.ServerStream
 }
 
-func init() { proto.RegisterFile("dapr/proto/dapr/v1/dapr.proto", fileDescriptor_0f3c232bd8a4c7dd) }
-
-var fileDescriptor_0f3c232bd8a4c7dd = []byte{
-	// 896 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xdd, 0x6e, 0xdc, 0x44,
-	0x14, 0x8e, 0x9d, 0x5d, 0x92, 0x3d, 0x9b, 0xa2, 0x76, 0x58, 0xd0, 0xc6, 0xa5, 0xb0, 0x98, 0x02,
-	0x01, 0xc1, 0x44, 0x49, 0x85, 0x8a, 0x0a, 0x5c, 0x34, 0xdd, 0xa8, 0xe2, 0xb7, 0x91, 0x83, 0x10,
-	0xe2, 0x82, 0x32, 0xf1, 0x1e, 0xbc, 0x56, 0xbc, 0x33, 0x66, 0x3c, 0xb6, 0xb4, 0x12, 0xcf, 0x51,
-	0xae, 0xb9, 0xe0, 0x86, 0xc7, 0xe1, 0x25, 0x78, 0x07, 0xae, 0x90, 0x67, 0x6c, 0xaf, 0xb3, 0xf6,
-	0x6e, 0x93, 0x16, 0x24, 0xae, 0x3c, 0x9e, 0x39, 0x3f, 0xdf, 0x7c, 0x33, 0xf3, 0x9d, 0x03, 0xb7,
-	0x26, 0x2c, 0x96, 0xfb, 0xb1, 0x14, 0x4a, 0xec, 0xeb, 0x61, 0x76, 0xa0, 0xbf, 0x54, 0x4f, 0x11,
-	0xb2, 0x18, 0x53, 0x3d, 0xcc, 0x0e, 0x9c, 0xdd, 0x40, 0x88, 0x20, 0x42, 0xe3, 0x74, 0x96, 0xfe,
-	0xb4, 0xcf, 0xf8, 0xdc, 0x98, 0x38, 0x37, 0x97, 0x97, 0x70, 0x16, 0xab, 0x72, 0xf1, 0xb5, 0xe5,
-	0xc5, 0x49, 0x2a, 0x99, 0x0a, 0x05, 0x2f, 0xd6, 0xdf, 0xa8, 0x41, 0xf1, 0xc5, 0x6c, 0x26, 0x78,
-	0x0e, 0xc6, 0x8c, 0x8c, 0x89, 0x8b, 0x30, 0xf8, 0x8c, 0x67, 0xe2, 0x1c, 0x4f, 0x51, 0x66, 0xa1,
-	0x8f, 0x1e, 0xfe, 0x9c, 0x62, 0xa2, 0xc8, 0x8b, 0x60, 0x87, 0x93, 0xa1, 0x35, 0xb2, 0xf6, 0x7a,
-	0x9e, 0x1d, 0x4e, 0xc8, 0xa7, 0xb0, 0x35, 0xc3, 0x24, 0x61, 0x01, 0x0e, 0x37, 0x47, 0xd6, 0x5e,
-	0xff, 0xf0, 0x4d, 0x5a, 0xdb, 0x48, 0x11, 0x32, 0x3b, 0xa0, 0x26, 0x58, 0x11, 0xc5, 0x2b, 0x7d,
-	0xdc, 0x27, 0x16, 0xbc, 0x34, 0xc6, 0x08, 0x15, 0x9e, 0x2a, 0xa6, 0xf0, 0x98, 0x67, 0x18, 0x89,
-	0x18, 0xc9, 0x2d, 0x80, 0x44, 0x09, 0x89, 0x8f, 0x39, 0x9b, 0x61, 0x91, 0xae, 0xa7, 0x67, 0xbe,
-	0x66, 0x33, 0x24, 0xd7, 0x61, 0xf3, 0x1c, 0xe7, 0x43, 0x5b, 0xcf, 0xe7, 0x43, 0x42, 0xa0, 0x83,
-	0x8a, 0x05, 0x1a, 0x44, 0xcf, 0xd3, 0x63, 0x72, 0x0f, 0xb6, 0x44, 0x9c, 0x6f, 0x3b, 0x19, 0x76,
-	0x34, 0xb6, 0x11, 0x6d, 0x92, 0x4c, 0x75, 0xe2, 0x47, 0xc6, 0xce, 0x2b, 0x1d, 0xdc, 0x18, 0x6e,
-	0x9c, 0xb2, 0xec, 0x6a, 0xa8, 0x3e, 0x81, 0x6d, 0x69, 0x36, 0x98, 0x0c, 0xed, 0xd1, 0xe6, 0xda,
-	0x84, 0x25, 0x13, 0x95, 0x87, 0x8b, 0x70, 0xfd, 0x21, 0xaa, 0xe7, 0xa4, 0x61, 0x04, 0x7d, 0x5f,
-	0xf0, 0x24, 0x4c, 0x14, 0x72, 0x7f, 0x5e, 0xb0, 0x51, 0x9f, 0x72, 0xbf, 0x83, 0x61, 0x99, 0xc6,
-	0xc3, 0x24, 0x16, 0x3c, 0x59, 0xa4, 0xdb, 0x83, 0xce, 0x84, 0x29, 0xa6, 0x13, 0xf5, 0x0f, 0x07,
-	0xd4, 0x5c, 0x23, 0x5a, 0x5e, 0x23, 0x7a, 0x9f, 0xcf, 0x3d, 0x6d, 0x51, 0xd1, 0x6d, 0x2f, 0xe8,
-	0x76, 0xff, 0xb4, 0xe0, 0x46, 0x1e, 0x1a, 0x7d, 0x89, 0xea, 0xd9, 0xb7, 0xf0, 0x08, 0xb6, 0x67,
-	0xa8, 0x98, 0x06, 0xb2, 0xa9, 0x59, 0xbc, 0xd3, 0xc6, 0x62, 0x23, 0x13, 0xfd, 0xaa, 0xf0, 0x3a,
-	0xe6, 0x4a, 0xce, 0xbd, 0x2a, 0x88, 0xf3, 0x31, 0x5c, 0xbb, 0xb0, 0x54, 0xe6, 0xb4, 0x16, 0x39,
-	0x07, 0xd0, 0xcd, 0x58, 0x94, 0x62, 0x81, 0xc3, 0xfc, 0xdc, 0xb3, 0x3f, 0xb2, 0xdc, 0xdf, 0x2c,
-	0xd8, 0xad, 0x52, 0x35, 0x08, 0xfb, 0xa2, 0x22, 0x2c, 0xc7, 0x79, 0x77, 0x2d, 0xce, 0x65, 0x67,
-	0x3a, 0xae, 0xb0, 0xea, 0x20, 0xce, 0x5d, 0xe8, 0x8d, 0x9f, 0x09, 0xe3, 0x5f, 0x16, 0xbc, 0x6c,
-	0xde, 0xd7, 0x51, 0xc8, 0x27, 0x21, 0x0f, 0x2a, 0x7c, 0x04, 0x3a, 0x35, 0xda, 0xf5, 0xb8, 0x3a,
-	0x64, 0xfb, 0xa9, 0x87, 0x7c, 0xda, 0x38, 0x89, 0xd6, 0x1d, 0xb6, 0xa6, 0xfe, 0x6f, 0x4e, 0xe3,
-	0x5b, 0x18, 0x9c, 0xa4, 0x67, 0x51, 0x98, 0x4c, 0x8f, 0x33, 0xe4, 0x8b, 0x4b, 0x36, 0x80, 0xae,
-	0x12, 0x71, 0xe8, 0x17, 0x51, 0xcc, 0xcf, 0xe5, 0x77, 0xea, 0xfe, 0x6a, 0x43, 0x57, 0x3f, 0x89,
-	0x16, 0x34, 0xef, 0xd5, 0xd1, 0xac, 0x0a, 0x63, 0x4c, 0x5a, 0x55, 0xe8, 0x41, 0x8d, 0xc5, 0x8e,
-	0x66, 0xf1, 0x9d, 0x95, 0xaa, 0xb0, 0x8a, 0xb5, 0xba, 0x94, 0x75, 0xaf, 0x28, 0x65, 0xcf, 0xc7,
-	0xf8, 0x13, 0x0b, 0x76, 0xea, 0x61, 0x0b, 0x85, 0xf1, 0x53, 0x29, 0xb5, 0xc2, 0x58, 0x95, 0xc2,
-	0x94, 0x53, 0xcb, 0x1a, 0x64, 0x37, 0x34, 0x88, 0x1c, 0xc1, 0x8e, 0x44, 0x25, 0xe7, 0x8f, 0x63,
-	0x11, 0x85, 0x85, 0x4c, 0xf5, 0x0f, 0x5f, 0x6f, 0xdb, 0x92, 0x97, 0xdb, 0x9d, 0x68, 0x33, 0xaf,
-	0x2f, 0x17, 0x3f, 0xee, 0x2f, 0xd0, 0xaf, 0xad, 0x91, 0x57, 0xa1, 0xa7, 0xa6, 0x12, 0x93, 0xa9,
-	0x88, 0x4c, 0x79, 0xea, 0x7a, 0x8b, 0x09, 0x32, 0x84, 0xad, 0x98, 0x29, 0x85, 0x92, 0x17, 0x70,
-	0xca, 0x5f, 0xf2, 0x21, 0x6c, 0x87, 0x5c, 0xa1, 0xcc, 0x58, 0x54, 0xc0, 0xd8, 0x6d, 0x1c, 0xf0,
-	0xb8, 0xa8, 0x9e, 0x5e, 0x65, 0xea, 0xfe, 0x6e, 0x17, 0xb4, 0x94, 0x75, 0xf1, 0xdf, 0xbf, 0x37,
-	0x9f, 0x37, 0xee, 0x0d, 0x7d, 0x5a, 0x35, 0xf9, 0xdf, 0x5d, 0x9f, 0xc3, 0xbf, 0x3b, 0xd0, 0x19,
-	0xb3, 0x58, 0x12, 0x0f, 0x76, 0xea, 0x2f, 0x97, 0xec, 0xb5, 0x01, 0x68, 0x7b, 0xdb, 0xce, 0x2b,
-	0x0d, 0xe2, 0x8e, 0xf3, 0x56, 0xc7, 0xdd, 0x20, 0x0c, 0xae, 0x5d, 0xe8, 0x51, 0xda, 0x83, 0xb6,
-	0xb5, 0x31, 0xce, 0xed, 0xf5, 0x5d, 0x8a, 0x51, 0x6a, 0x77, 0x83, 0x7c, 0x53, 0xa6, 0x28, 0xe4,
-	0x8d, 0xbc, 0x7b, 0x69, 0x05, 0x5c, 0x03, 0xfc, 0x47, 0xd8, 0x2e, 0x6b, 0x30, 0xb9, 0xbd, 0xaa,
-	0x68, 0xd4, 0x1b, 0x01, 0xe7, 0xfd, 0x75, 0x56, 0xcb, 0x95, 0xc5, 0xdd, 0x20, 0x3e, 0xf4, 0xaa,
-	0xc2, 0x43, 0xde, 0xba, 0x54, 0xfd, 0x74, 0x3e, 0xb8, 0x52, 0xf9, 0x72, 0x37, 0xc8, 0x97, 0xd0,
-	0xab, 0x7a, 0xa4, 0xf6, 0x24, 0x8d, 0x16, 0x6a, 0x0d, 0x29, 0x27, 0xd0, 0xaf, 0x75, 0x82, 0xa4,
-	0x55, 0x24, 0x5b, 0x5a, 0xc5, 0xd5, 0x11, 0x8f, 0x7e, 0x00, 0x08, 0x2b, 0xdf, 0x23, 0xc8, 0xef,
-	0xe1, 0x49, 0x6e, 0x93, 0x7c, 0xff, 0x76, 0x10, 0xaa, 0x69, 0x7a, 0x96, 0x9f, 0xbc, 0xe9, 0xc5,
-	0x4d, 0x43, 0x7c, 0x1e, 0x5c, 0xec, 0xcf, 0xff, 0xb0, 0x6f, 0xe6, 0x4e, 0xf4, 0x41, 0x14, 0x22,
-	0x57, 0xf4, 0x7e, 0xaa, 0x44, 0x80, 0x9c, 0x3e, 0x94, 0xb1, 0x4f, 0xb3, 0x83, 0xb3, 0x17, 0xb4,
-	0xf1, 0x9d, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x41, 0x75, 0x5a, 0xfa, 0xda, 0x0b, 0x00, 0x00,
+type daprDeleteStateByPrefixAlpha1Server struct {
+	grpc.ServerStream
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (x *daprDeleteStateByPrefixAlpha1Server) Send(m *DeleteStateByPrefixProgressEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func _Dapr_SubscribeState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeStateEnvelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprServer).SubscribeState(m, &daprSubscribeStateServer{stream})
+}
 
-// DaprClient is the client API for Dapr service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DaprClient interface {
-	PublishEvent(ctx context.Context, in *PublishEventEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
-	InvokeService(ctx context.Context, in *InvokeServiceRequest, opts ...grpc.CallOption) (*v1.InvokeResponse, error)
-	InvokeBinding(ctx context.Context, in *InvokeBindingEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
-	GetState(ctx context.Context, in *GetStateEnvelope, opts ...grpc.CallOption) (*GetStateResponseEnvelope, error)
-	GetSecret(ctx context.Context, in *GetSecretEnvelope, opts ...grpc.CallOption) (*GetSecretResponseEnvelope, error)
-	SaveState(ctx context.Context, in *SaveStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
-	DeleteState(ctx context.Context, in *DeleteStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+type Dapr_SubscribeStateServer interface {
+	Send(*StateChangeEnvelope) error
+	grpc.ServerStream
 }
 
-type daprClient struct {
-	cc *grpc.ClientConn
+type daprSubscribeStateServer struct {
+	grpc.ServerStream
 }
 
-func NewDaprClient(cc *grpc.ClientConn) DaprClient {
-	return &daprClient{cc}
+func (x *daprSubscribeStateServer) Send(m *StateChangeEnvelope) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *daprClient) PublishEvent(ctx context.Context, in *PublishEventEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/PublishEvent", in, out, opts...)
-	if err != nil {
+func _Dapr_GetNextID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNextIDEnvelope)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DaprServer).GetNextID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetNextID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).GetNextID(ctx, req.(*GetNextIDEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *daprClient) InvokeService(ctx context.Context, in *InvokeServiceRequest, opts ...grpc.CallOption) (*v1.InvokeResponse, error) {
-	out := new(v1.InvokeResponse)
-	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/InvokeService", in, out, opts...)
-	if err != nil {
+func _Dapr_GenerateID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateIDEnvelope)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DaprServer).GenerateID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GenerateID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).GenerateID(ctx, req.(*GenerateIDEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *daprClient) InvokeBinding(ctx context.Context, in *InvokeBindingEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/InvokeBinding", in, out, opts...)
-	if err != nil {
-		return nil, err
+func _Dapr_Campaign_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CampaignEnvelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(DaprServer).Campaign(m, &daprCampaignServer{stream})
 }
 
-func (c *daprClient) GetState(ctx context.Context, in *GetStateEnvelope, opts ...grpc.CallOption) (*GetStateResponseEnvelope, error) {
-	out := new(GetStateResponseEnvelope)
-	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetState", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type Dapr_CampaignServer interface {
+	Send(*LeaderEnvelope) error
+	grpc.ServerStream
 }
 
-func (c *daprClient) GetSecret(ctx context.Context, in *GetSecretEnvelope, opts ...grpc.CallOption) (*GetSecretResponseEnvelope, error) {
-	out := new(GetSecretResponseEnvelope)
-	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/GetSecret", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type daprCampaignServer struct {
+	grpc.ServerStream
 }
 
-func (c *daprClient) SaveState(ctx context.Context, in *SaveStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/SaveState", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (x *daprCampaignServer) Send(m *LeaderEnvelope) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *daprClient) DeleteState(ctx context.Context, in *DeleteStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.Dapr/DeleteState", in, out, opts...)
-	if err != nil {
+func _Dapr_Resign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResignEnvelope)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DaprServer).Resign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/Resign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprServer).Resign(ctx, req.(*ResignEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// DaprServer is the server API for Dapr service.
-type DaprServer interface {
-	PublishEvent(context.Context, *PublishEventEnvelope) (*empty.Empty, error)
-	InvokeService(context.Context, *InvokeServiceRequest) (*v1.InvokeResponse, error)
-	InvokeBinding(context.Context, *InvokeBindingEnvelope) (*empty.Empty, error)
-	GetState(context.Context, *GetStateEnvelope) (*GetStateResponseEnvelope, error)
-	GetSecret(context.Context, *GetSecretEnvelope) (*GetSecretResponseEnvelope, error)
-	SaveState(context.Context, *SaveStateEnvelope) (*empty.Empty, error)
-	DeleteState(context.Context, *DeleteStateEnvelope) (*empty.Empty, error)
+func _Dapr_Observe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ObserveEnvelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprServer).Observe(m, &daprObserveServer{stream})
 }
 
-// UnimplementedDaprServer can be embedded to have forward compatible implementations.
-type UnimplementedDaprServer struct {
+type Dapr_ObserveServer interface {
+	Send(*LeaderEnvelope) error
+	grpc.ServerStream
 }
 
-func (*UnimplementedDaprServer) PublishEvent(ctx context.Context, req *PublishEventEnvelope) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PublishEvent not implemented")
-}
-func (*UnimplementedDaprServer) InvokeService(ctx context.Context, req *InvokeServiceRequest) (*v1.InvokeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method InvokeService not implemented")
-}
-func (*UnimplementedDaprServer) InvokeBinding(ctx context.Context, req *InvokeBindingEnvelope) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method InvokeBinding not implemented")
-}
-func (*UnimplementedDaprServer) GetState(ctx context.Context, req *GetStateEnvelope) (*GetStateResponseEnvelope, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
-}
-func (*UnimplementedDaprServer) GetSecret(ctx context.Context, req *GetSecretEnvelope) (*GetSecretResponseEnvelope, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSecret not implemented")
-}
-func (*UnimplementedDaprServer) SaveState(ctx context.Context, req *SaveStateEnvelope) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SaveState not implemented")
-}
-func (*UnimplementedDaprServer) DeleteState(ctx context.Context, req *DeleteStateEnvelope) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteState not implemented")
+type daprObserveServer struct {
+	grpc.ServerStream
 }
 
-func RegisterDaprServer(s *grpc.Server, srv DaprServer) {
-	s.RegisterService(&_Dapr_serviceDesc, srv)
+func (x *daprObserveServer) Send(m *LeaderEnvelope) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _Dapr_PublishEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PublishEventEnvelope)
+func _Dapr_TryLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TryLockEnvelope)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaprServer).PublishEvent(ctx, in)
+		return srv.(DaprServer).TryLock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dapr.proto.dapr.v1.Dapr/PublishEvent",
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/TryLock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaprServer).PublishEvent(ctx, req.(*PublishEventEnvelope))
+		return srv.(DaprServer).TryLock(ctx, req.(*TryLockEnvelope))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dapr_InvokeService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InvokeServiceRequest)
+func _Dapr_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockEnvelope)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaprServer).InvokeService(ctx, in)
+		return srv.(DaprServer).Unlock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dapr.proto.dapr.v1.Dapr/InvokeService",
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/Unlock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaprServer).InvokeService(ctx, req.(*InvokeServiceRequest))
+		return srv.(DaprServer).Unlock(ctx, req.(*UnlockEnvelope))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dapr_InvokeBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InvokeBindingEnvelope)
+func _Dapr_GetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigurationEnvelope)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaprServer).InvokeBinding(ctx, in)
+		return srv.(DaprServer).GetConfiguration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dapr.proto.dapr.v1.Dapr/InvokeBinding",
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetConfiguration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaprServer).InvokeBinding(ctx, req.(*InvokeBindingEnvelope))
+		return srv.(DaprServer).GetConfiguration(ctx, req.(*GetConfigurationEnvelope))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dapr_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetStateEnvelope)
+func _Dapr_SubscribeConfiguration_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeConfigurationEnvelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprServer).SubscribeConfiguration(m, &daprSubscribeConfigurationServer{stream})
+}
+
+type Dapr_SubscribeConfigurationServer interface {
+	Send(*SubscribeConfigurationResponseEnvelope) error
+	grpc.ServerStream
+}
+
+type daprSubscribeConfigurationServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprSubscribeConfigurationServer) Send(m *SubscribeConfigurationResponseEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Dapr_GetComponentCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaprServer).GetState(ctx, in)
+		return srv.(DaprServer).GetComponentCapabilities(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetState",
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetComponentCapabilities",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaprServer).GetState(ctx, req.(*GetStateEnvelope))
+		return srv.(DaprServer).GetComponentCapabilities(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dapr_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetSecretEnvelope)
+func _Dapr_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaprServer).GetSecret(ctx, in)
+		return srv.(DaprServer).GetMetadata(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetSecret",
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/GetMetadata",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaprServer).GetSecret(ctx, req.(*GetSecretEnvelope))
+		return srv.(DaprServer).GetMetadata(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dapr_SaveState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SaveStateEnvelope)
+func _Dapr_SetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMetadataEnvelope)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaprServer).SaveState(ctx, in)
+		return srv.(DaprServer).SetMetadata(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dapr.proto.dapr.v1.Dapr/SaveState",
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/SetMetadata",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaprServer).SaveState(ctx, req.(*SaveStateEnvelope))
+		return srv.(DaprServer).SetMetadata(ctx, req.(*SetMetadataEnvelope))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dapr_DeleteState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteStateEnvelope)
+func _Dapr_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaprServer).DeleteState(ctx, in)
+		return srv.(DaprServer).Shutdown(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dapr.proto.dapr.v1.Dapr/DeleteState",
+		FullMethod: "/dapr.proto.dapr.v1.Dapr/Shutdown",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaprServer).DeleteState(ctx, req.(*DeleteStateEnvelope))
+		return srv.(DaprServer).Shutdown(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1104,10 +4514,18 @@ var _Dapr_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetState",
 			Handler:    _Dapr_GetState_Handler,
 		},
+		{
+			MethodName: "GetBulkState",
+			Handler:    _Dapr_GetBulkState_Handler,
+		},
 		{
 			MethodName: "GetSecret",
 			Handler:    _Dapr_GetSecret_Handler,
 		},
+		{
+			MethodName: "GetBulkSecret",
+			Handler:    _Dapr_GetBulkSecret_Handler,
+		},
 		{
 			MethodName: "SaveState",
 			Handler:    _Dapr_SaveState_Handler,
@@ -1116,7 +4534,97 @@ var _Dapr_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteState",
 			Handler:    _Dapr_DeleteState_Handler,
 		},
+		{
+			MethodName: "DeleteBulkState",
+			Handler:    _Dapr_DeleteBulkState_Handler,
+		},
+		{
+			MethodName: "ExecuteStateTransaction",
+			Handler:    _Dapr_ExecuteStateTransaction_Handler,
+		},
+		{
+			MethodName: "QueryStateAlpha1",
+			Handler:    _Dapr_QueryStateAlpha1_Handler,
+		},
+		{
+			MethodName: "GetNextID",
+			Handler:    _Dapr_GetNextID_Handler,
+		},
+		{
+			MethodName: "GenerateID",
+			Handler:    _Dapr_GenerateID_Handler,
+		},
+		{
+			MethodName: "Resign",
+			Handler:    _Dapr_Resign_Handler,
+		},
+		{
+			MethodName: "TryLock",
+			Handler:    _Dapr_TryLock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _Dapr_Unlock_Handler,
+		},
+		{
+			MethodName: "GetConfiguration",
+			Handler:    _Dapr_GetConfiguration_Handler,
+		},
+		{
+			MethodName: "GetComponentCapabilities",
+			Handler:    _Dapr_GetComponentCapabilities_Handler,
+		},
+		{
+			MethodName: "GetMetadata",
+			Handler:    _Dapr_GetMetadata_Handler,
+		},
+		{
+			MethodName: "SetMetadata",
+			Handler:    _Dapr_SetMetadata_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _Dapr_Shutdown_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTopicEvents",
+			Handler:       _Dapr_SubscribeTopicEvents_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "InvokeServiceStream",
+			Handler:       _Dapr_InvokeServiceStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DeleteStateByPrefixAlpha1",
+			Handler:       _Dapr_DeleteStateByPrefixAlpha1_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeState",
+			Handler:       _Dapr_SubscribeState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Campaign",
+			Handler:       _Dapr_Campaign_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Observe",
+			Handler:       _Dapr_Observe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeConfiguration",
+			Handler:       _Dapr_SubscribeConfiguration_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "dapr/proto/dapr/v1/dapr.proto",
 }
@@ -99,7 +99,12 @@ type InternalInvokeRequest struct {
 	// actor service invocation.
 	//
 	// This field is optional.
-	Actor                *Actor   `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Actor *Actor `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// target_app_id is the app id the caller resolved the callee from. The callee
+	// rejects the requests for other apps.
+	//
+	// This field is optional.
+	TargetAppId          string   `protobuf:"bytes,5,opt,name=target_app_id,json=targetAppId,proto3" json:"target_app_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -158,6 +163,13 @@ func (m *InternalInvokeRequest) GetActor() *Actor {
 	return nil
 }
 
+func (m *InternalInvokeRequest) GetTargetAppId() string {
+	if m != nil {
+		return m.TargetAppId
+	}
+	return ""
+}
+
 // InternalInvokeResponse is the message to transfer callee's response to caller
 // for service invocaton.
 type InternalInvokeResponse struct {
@@ -236,6 +248,110 @@ func (m *InternalInvokeResponse) GetMessage() *any.Any {
 	return nil
 }
 
+// InternalInvokeRequestStream is a message of a streamed service invocation.
+// The first message holds the request without data, the following ones hold
+// the chunks of the data.
+type InternalInvokeRequestStream struct {
+	// request is the invocation request, set in the first message only.
+	Request *InternalInvokeRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// chunk is the next chunk of the request data.
+	Chunk                []byte   `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InternalInvokeRequestStream) Reset()         { *m = InternalInvokeRequestStream{} }
+func (m *InternalInvokeRequestStream) String() string { return proto.CompactTextString(m) }
+func (*InternalInvokeRequestStream) ProtoMessage()    {}
+func (*InternalInvokeRequestStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c6da3b6bd4beea4, []int{3}
+}
+
+func (m *InternalInvokeRequestStream) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InternalInvokeRequestStream.Unmarshal(m, b)
+}
+func (m *InternalInvokeRequestStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InternalInvokeRequestStream.Marshal(b, m, deterministic)
+}
+func (m *InternalInvokeRequestStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InternalInvokeRequestStream.Merge(m, src)
+}
+func (m *InternalInvokeRequestStream) XXX_Size() int {
+	return xxx_messageInfo_InternalInvokeRequestStream.Size(m)
+}
+func (m *InternalInvokeRequestStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_InternalInvokeRequestStream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InternalInvokeRequestStream proto.InternalMessageInfo
+
+func (m *InternalInvokeRequestStream) GetRequest() *InternalInvokeRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *InternalInvokeRequestStream) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+// InternalInvokeResponseStream is a message of a streamed service invocation
+// response. The first message holds the response without data, the following
+// ones hold the chunks of the data.
+type InternalInvokeResponseStream struct {
+	// response is the invocation response, set in the first message only.
+	Response *InternalInvokeResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// chunk is the next chunk of the response data.
+	Chunk                []byte   `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InternalInvokeResponseStream) Reset()         { *m = InternalInvokeResponseStream{} }
+func (m *InternalInvokeResponseStream) String() string { return proto.CompactTextString(m) }
+func (*InternalInvokeResponseStream) ProtoMessage()    {}
+func (*InternalInvokeResponseStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c6da3b6bd4beea4, []int{4}
+}
+
+func (m *InternalInvokeResponseStream) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InternalInvokeResponseStream.Unmarshal(m, b)
+}
+func (m *InternalInvokeResponseStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InternalInvokeResponseStream.Marshal(b, m, deterministic)
+}
+func (m *InternalInvokeResponseStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InternalInvokeResponseStream.Merge(m, src)
+}
+func (m *InternalInvokeResponseStream) XXX_Size() int {
+	return xxx_messageInfo_InternalInvokeResponseStream.Size(m)
+}
+func (m *InternalInvokeResponseStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_InternalInvokeResponseStream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InternalInvokeResponseStream proto.InternalMessageInfo
+
+func (m *InternalInvokeResponseStream) GetResponse() *InternalInvokeResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *InternalInvokeResponseStream) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Actor)(nil), "dapr.proto.daprinternal.v1.Actor")
 	proto.RegisterType((*InternalInvokeRequest)(nil), "dapr.proto.daprinternal.v1.InternalInvokeRequest")
@@ -243,6 +359,8 @@ func init() {
 	proto.RegisterType((*InternalInvokeResponse)(nil), "dapr.proto.daprinternal.v1.InternalInvokeResponse")
 	proto.RegisterMapType((map[string]*_struct.ListValue)(nil), "dapr.proto.daprinternal.v1.InternalInvokeResponse.HeadersEntry")
 	proto.RegisterMapType((map[string]*_struct.ListValue)(nil), "dapr.proto.daprinternal.v1.InternalInvokeResponse.TrailersEntry")
+	proto.RegisterType((*InternalInvokeRequestStream)(nil), "dapr.proto.daprinternal.v1.InternalInvokeRequestStream")
+	proto.RegisterType((*InternalInvokeResponseStream)(nil), "dapr.proto.daprinternal.v1.InternalInvokeResponseStream")
 }
 
 func init() {
@@ -250,39 +368,46 @@ func init() {
 }
 
 var fileDescriptor_3c6da3b6bd4beea4 = []byte{
-	// 507 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x54, 0xdf, 0x6b, 0xd4, 0x40,
-	0x10, 0x36, 0x97, 0x5e, 0xef, 0x6e, 0xae, 0x8a, 0x2c, 0x2a, 0x69, 0x50, 0x38, 0xf3, 0xa0, 0x07,
-	0xe2, 0x5e, 0x2f, 0x3e, 0x58, 0xfa, 0xa2, 0xe7, 0x0f, 0xf0, 0xa0, 0x82, 0xc4, 0x72, 0xe2, 0x0f,
-	0x90, 0xbd, 0xcb, 0x9a, 0x86, 0xcb, 0x65, 0xe3, 0xee, 0x26, 0x90, 0x7f, 0x5a, 0x7c, 0xf6, 0x49,
-	0x76, 0x37, 0x91, 0xa6, 0xd6, 0x60, 0x4b, 0xe9, 0x4b, 0x98, 0xec, 0x7c, 0xf3, 0xcd, 0x37, 0xdf,
-	0xb0, 0x0b, 0x8f, 0x43, 0x92, 0xf1, 0x49, 0xc6, 0x99, 0x64, 0x13, 0x15, 0xc6, 0xa9, 0xa4, 0x3c,
-	0x25, 0xc9, 0xa4, 0x98, 0x36, 0xfe, 0xb1, 0x86, 0x20, 0x57, 0x9d, 0x99, 0x18, 0x37, 0xd2, 0xc5,
-	0xd4, 0xdd, 0x8d, 0x18, 0x8b, 0x12, 0x6a, 0xc8, 0x96, 0xf9, 0xb7, 0x09, 0x49, 0x4b, 0x03, 0x75,
-	0xef, 0x9e, 0x4e, 0x09, 0xc9, 0xf3, 0x95, 0xac, 0xb2, 0x8f, 0x5a, 0x34, 0x90, 0x2c, 0x2e, 0x28,
-	0x17, 0x31, 0x4b, 0x2b, 0xf0, 0xc3, 0x16, 0xb0, 0x90, 0x44, 0xe6, 0xc2, 0x00, 0xbd, 0x19, 0x74,
-	0x67, 0x2b, 0xc9, 0x38, 0xba, 0x07, 0x40, 0x54, 0xf0, 0x55, 0x96, 0x19, 0x75, 0xac, 0x91, 0x35,
-	0x1e, 0x04, 0x03, 0x7d, 0x72, 0x54, 0x66, 0x14, 0xed, 0x42, 0xdf, 0xa4, 0xe3, 0xd0, 0xe9, 0xe8,
-	0x64, 0x4f, 0xff, 0xcf, 0x43, 0xef, 0x67, 0x07, 0x6e, 0xcf, 0x2b, 0xfe, 0x79, 0x5a, 0xb0, 0x35,
-	0x0d, 0xe8, 0xf7, 0x9c, 0x0a, 0x89, 0xf6, 0xc1, 0x2e, 0x28, 0xd7, 0x64, 0x37, 0xfc, 0x07, 0xf8,
-	0xdf, 0xae, 0xe0, 0xd9, 0xbb, 0xf9, 0xc2, 0x0c, 0x10, 0xa8, 0x12, 0xf4, 0x19, 0xfa, 0x1b, 0x2a,
-	0x49, 0x48, 0x24, 0x71, 0x3a, 0x23, 0x7b, 0x3c, 0xf4, 0x9f, 0xb5, 0x95, 0x9f, 0xd9, 0x1e, 0xbf,
-	0xad, 0x18, 0x5e, 0xa7, 0x92, 0x97, 0xc1, 0x1f, 0x42, 0x84, 0xa1, 0xb7, 0xa1, 0x42, 0x90, 0x88,
-	0x3a, 0xf6, 0xc8, 0x1a, 0x0f, 0xfd, 0x5b, 0xd8, 0x38, 0x8f, 0x6b, 0xe7, 0xf1, 0x2c, 0x2d, 0x83,
-	0x1a, 0x84, 0x9e, 0x42, 0x57, 0xcf, 0xea, 0x6c, 0x69, 0xf4, 0xfd, 0xd6, 0x41, 0x14, 0x30, 0x30,
-	0x78, 0xf7, 0x03, 0x5c, 0x6f, 0x68, 0x40, 0x37, 0xc1, 0x5e, 0xd3, 0xb2, 0x72, 0x57, 0x85, 0x68,
-	0x0f, 0xba, 0x05, 0x49, 0x72, 0xaa, 0x4d, 0x1d, 0xfa, 0xee, 0x5f, 0x4a, 0x0e, 0x63, 0x21, 0x17,
-	0x0a, 0x11, 0x18, 0xe0, 0x41, 0x67, 0xdf, 0xf2, 0x7e, 0xd8, 0x70, 0xe7, 0xf4, 0xcc, 0x22, 0x63,
-	0xa9, 0xa0, 0xe8, 0x00, 0xb6, 0xcd, 0x82, 0x75, 0x97, 0xa1, 0xef, 0xb5, 0xa9, 0x7d, 0xaf, 0x91,
-	0x41, 0x55, 0x81, 0x3e, 0x42, 0xef, 0x98, 0x92, 0x90, 0x72, 0x71, 0x11, 0xd3, 0x8d, 0x00, 0xfc,
-	0xc6, 0x30, 0x18, 0xd3, 0x6b, 0x3e, 0xf4, 0x05, 0xfa, 0x92, 0x93, 0x38, 0x51, 0xdc, 0xb6, 0xe6,
-	0x7e, 0x7e, 0x01, 0xee, 0xa3, 0x8a, 0xa2, 0xda, 0x68, 0xcd, 0x78, 0x72, 0xa3, 0x5b, 0xff, 0xb1,
-	0x51, 0x77, 0x01, 0x3b, 0x27, 0x65, 0x5e, 0xd6, 0x5e, 0xd4, 0xc2, 0x1b, 0x12, 0x2f, 0x8b, 0xd8,
-	0xff, 0x65, 0xc1, 0xce, 0x2b, 0x92, 0xf1, 0xda, 0x17, 0x24, 0x61, 0xf0, 0x92, 0x24, 0x89, 0xb9,
-	0xbb, 0xd3, 0x73, 0xdf, 0x0d, 0xd7, 0x3f, 0xbf, 0xfb, 0xde, 0xb5, 0xba, 0xeb, 0x21, 0x5b, 0x91,
-	0xe4, 0xca, 0xba, 0xbe, 0xd8, 0xfb, 0x84, 0xa3, 0x58, 0x1e, 0xe7, 0x4b, 0xbc, 0x62, 0x1b, 0xfd,
-	0x9c, 0x99, 0x4f, 0xb6, 0x8e, 0xce, 0x7e, 0xe2, 0x96, 0xdb, 0xfa, 0xf8, 0xc9, 0xef, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x98, 0x62, 0x70, 0x65, 0xb8, 0x05, 0x00, 0x00,
+	// 613 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xad, 0xeb, 0xa6, 0x49, 0x27, 0x2d, 0xa0, 0x55, 0x41, 0xa9, 0x29, 0x52, 0xf1, 0x01, 0x22,
+	0x21, 0x36, 0x8d, 0x39, 0x34, 0xea, 0x05, 0xc2, 0x87, 0x44, 0x44, 0x41, 0xc8, 0xad, 0x82, 0xf8,
+	0x90, 0xaa, 0x4d, 0xbc, 0x38, 0x56, 0x1c, 0x7b, 0x59, 0xaf, 0x2d, 0xf9, 0xc6, 0x01, 0x09, 0x89,
+	0x33, 0xbf, 0x93, 0xdf, 0x80, 0xbc, 0xbb, 0x8e, 0x9a, 0x12, 0x22, 0x12, 0x55, 0x5c, 0xa2, 0xdd,
+	0x99, 0x37, 0x6f, 0xde, 0xcc, 0x8b, 0x6d, 0x78, 0xe8, 0x11, 0xc6, 0x5b, 0x8c, 0xc7, 0x22, 0x6e,
+	0x15, 0xc7, 0x20, 0x12, 0x94, 0x47, 0x24, 0x6c, 0x65, 0xed, 0x99, 0x3b, 0x96, 0x10, 0x64, 0x15,
+	0x31, 0x75, 0xc6, 0x33, 0xe9, 0xac, 0x6d, 0xed, 0xf9, 0x71, 0xec, 0x87, 0x54, 0x91, 0x0d, 0xd2,
+	0xcf, 0x2d, 0x12, 0xe5, 0x0a, 0x6a, 0xed, 0x5f, 0x4e, 0x25, 0x82, 0xa7, 0x43, 0xa1, 0xb3, 0x0f,
+	0x16, 0x68, 0x20, 0x2c, 0xc8, 0x28, 0x4f, 0x82, 0x38, 0xd2, 0xe0, 0xfb, 0x0b, 0xc0, 0x89, 0x20,
+	0x22, 0x4d, 0x14, 0xd0, 0xee, 0x42, 0xa5, 0x3b, 0x14, 0x31, 0x47, 0x77, 0x00, 0x48, 0x71, 0x38,
+	0x17, 0x39, 0xa3, 0x0d, 0xe3, 0xc0, 0x68, 0x6e, 0xb9, 0x5b, 0x32, 0x72, 0x96, 0x33, 0x8a, 0xf6,
+	0xa0, 0xa6, 0xd2, 0x81, 0xd7, 0x58, 0x97, 0xc9, 0xaa, 0xbc, 0xf7, 0x3c, 0xfb, 0xa7, 0x09, 0x37,
+	0x7b, 0x9a, 0xbf, 0x17, 0x65, 0xf1, 0x98, 0xba, 0xf4, 0x4b, 0x4a, 0x13, 0x81, 0x3a, 0x60, 0x66,
+	0x94, 0x4b, 0xb2, 0x6b, 0xce, 0x3d, 0xfc, 0xf7, 0xad, 0xe0, 0xee, 0xdb, 0x5e, 0x5f, 0x0d, 0xe0,
+	0x16, 0x25, 0xe8, 0x23, 0xd4, 0x26, 0x54, 0x10, 0x8f, 0x08, 0xd2, 0x58, 0x3f, 0x30, 0x9b, 0x75,
+	0xe7, 0xf1, 0xa2, 0xf2, 0xb9, 0xed, 0xf1, 0x6b, 0xcd, 0xf0, 0x22, 0x12, 0x3c, 0x77, 0xa7, 0x84,
+	0x08, 0x43, 0x75, 0x42, 0x93, 0x84, 0xf8, 0xb4, 0x61, 0x1e, 0x18, 0xcd, 0xba, 0xb3, 0x8b, 0xd5,
+	0xe6, 0x71, 0xb9, 0x79, 0xdc, 0x8d, 0x72, 0xb7, 0x04, 0xa1, 0x23, 0xa8, 0xc8, 0x59, 0x1b, 0x1b,
+	0x12, 0x7d, 0x77, 0xe1, 0x20, 0x05, 0xd0, 0x55, 0x78, 0x64, 0xc3, 0x8e, 0x20, 0xdc, 0xa7, 0xe2,
+	0x9c, 0x30, 0x56, 0x6c, 0xae, 0x22, 0x37, 0x57, 0x57, 0xc1, 0x2e, 0x63, 0x3d, 0xcf, 0x7a, 0x07,
+	0x3b, 0x33, 0x3a, 0xd1, 0x0d, 0x30, 0xc7, 0x34, 0xd7, 0x0e, 0x14, 0x47, 0x74, 0x08, 0x95, 0x8c,
+	0x84, 0x29, 0x95, 0x8b, 0xaf, 0x3b, 0xd6, 0x1f, 0x6a, 0x4f, 0x82, 0x44, 0xf4, 0x0b, 0x84, 0xab,
+	0x80, 0xc7, 0xeb, 0x1d, 0xc3, 0xfe, 0x65, 0xc2, 0xad, 0xcb, 0x7b, 0x49, 0x58, 0x1c, 0x25, 0x14,
+	0x1d, 0xc3, 0xa6, 0xfa, 0x13, 0xc8, 0x2e, 0x75, 0xc7, 0x5e, 0x34, 0xd1, 0xa9, 0x44, 0xba, 0xba,
+	0x02, 0xbd, 0x87, 0xea, 0x88, 0x12, 0x8f, 0xf2, 0x64, 0x15, 0x63, 0x94, 0x00, 0xfc, 0x52, 0x31,
+	0x28, 0x63, 0x4a, 0x3e, 0xf4, 0x09, 0x6a, 0x82, 0x93, 0x20, 0x2c, 0xb8, 0x4d, 0xc9, 0xfd, 0x64,
+	0x05, 0xee, 0x33, 0x4d, 0xa1, 0x5d, 0x2f, 0x19, 0x2f, 0xba, 0xbe, 0xf1, 0x0f, 0xae, 0x5b, 0x7d,
+	0xd8, 0xbe, 0x28, 0xf3, 0xaa, 0x7c, 0x29, 0x0c, 0x9f, 0x91, 0x78, 0x65, 0x86, 0x7f, 0x35, 0xe0,
+	0xf6, 0xdc, 0x07, 0xe1, 0x54, 0x70, 0x4a, 0x26, 0xe8, 0x15, 0x54, 0xb9, 0x0a, 0x68, 0xdb, 0xdb,
+	0x4b, 0x3f, 0x52, 0x6e, 0xc9, 0x80, 0x76, 0xa1, 0x32, 0x1c, 0xa5, 0xd1, 0x58, 0x4a, 0xdc, 0x76,
+	0xd5, 0xc5, 0xfe, 0x66, 0xc0, 0xfe, 0x7c, 0x5b, 0xb4, 0x86, 0x37, 0x50, 0xe3, 0x3a, 0xa2, 0x45,
+	0x38, 0xcb, 0x5b, 0xec, 0x4e, 0x39, 0xe6, 0xcb, 0x70, 0xbe, 0x9b, 0xb0, 0xfd, 0x9c, 0x30, 0x5e,
+	0x96, 0x23, 0x01, 0x5b, 0xcf, 0x48, 0x18, 0xaa, 0x37, 0xdd, 0xf2, 0x63, 0x5b, 0x2b, 0x88, 0xb4,
+	0xd7, 0xca, 0xae, 0x27, 0xf1, 0x90, 0x84, 0xff, 0xaf, 0xeb, 0x0f, 0x03, 0xae, 0x4f, 0xdb, 0xea,
+	0xb5, 0x1f, 0x2d, 0xdd, 0x5c, 0x15, 0x5a, 0x9d, 0xe5, 0x25, 0xa8, 0x4a, 0x7b, 0xad, 0x69, 0x1c,
+	0x1a, 0x4f, 0x0f, 0x3f, 0x60, 0x3f, 0x10, 0xa3, 0x74, 0x80, 0x87, 0xf1, 0x44, 0x7e, 0x89, 0xd4,
+	0x0f, 0x1b, 0xfb, 0xf3, 0xbf, 0x4e, 0x83, 0x4d, 0x19, 0x7e, 0xf4, 0x7b, 0x00, 0xfa, 0x47, 0xcc,
+	0x9d, 0x73, 0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -299,6 +424,7 @@ const _ = grpc.SupportPackageIsVersion4
 type DaprInternalClient interface {
 	CallActor(ctx context.Context, in *InternalInvokeRequest, opts ...grpc.CallOption) (*InternalInvokeResponse, error)
 	CallLocal(ctx context.Context, in *InternalInvokeRequest, opts ...grpc.CallOption) (*InternalInvokeResponse, error)
+	CallLocalStream(ctx context.Context, opts ...grpc.CallOption) (DaprInternal_CallLocalStreamClient, error)
 }
 
 type daprInternalClient struct {
@@ -327,10 +453,42 @@ func (c *daprInternalClient) CallLocal(ctx context.Context, in *InternalInvokeRe
 	return out, nil
 }
 
+func (c *daprInternalClient) CallLocalStream(ctx context.Context, opts ...grpc.CallOption) (DaprInternal_CallLocalStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaprInternal_serviceDesc.Streams[0], "/dapr.proto.daprinternal.v1.DaprInternal/CallLocalStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprInternalCallLocalStreamClient{stream}
+	return x, nil
+}
+
+type DaprInternal_CallLocalStreamClient interface {
+	Send(*InternalInvokeRequestStream) error
+	Recv() (*InternalInvokeResponseStream, error)
+	grpc.ClientStream
+}
+
+type daprInternalCallLocalStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprInternalCallLocalStreamClient) Send(m *InternalInvokeRequestStream) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *daprInternalCallLocalStreamClient) Recv() (*InternalInvokeResponseStream, error) {
+	m := new(InternalInvokeResponseStream)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DaprInternalServer is the server API for DaprInternal service.
 type DaprInternalServer interface {
 	CallActor(context.Context, *InternalInvokeRequest) (*InternalInvokeResponse, error)
 	CallLocal(context.Context, *InternalInvokeRequest) (*InternalInvokeResponse, error)
+	CallLocalStream(DaprInternal_CallLocalStreamServer) error
 }
 
 // UnimplementedDaprInternalServer can be embedded to have forward compatible implementations.
@@ -343,6 +501,9 @@ func (*UnimplementedDaprInternalServer) CallActor(ctx context.Context, req *Inte
 func (*UnimplementedDaprInternalServer) CallLocal(ctx context.Context, req *InternalInvokeRequest) (*InternalInvokeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CallLocal not implemented")
 }
+func (*UnimplementedDaprInternalServer) CallLocalStream(srv DaprInternal_CallLocalStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method CallLocalStream not implemented")
+}
 
 func RegisterDaprInternalServer(s *grpc.Server, srv DaprInternalServer) {
 	s.RegisterService(&_DaprInternal_serviceDesc, srv)
@@ -384,6 +545,32 @@ func _DaprInternal_CallLocal_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaprInternal_CallLocalStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DaprInternalServer).CallLocalStream(&daprInternalCallLocalStreamServer{stream})
+}
+
+type DaprInternal_CallLocalStreamServer interface {
+	Send(*InternalInvokeResponseStream) error
+	Recv() (*InternalInvokeRequestStream, error)
+	grpc.ServerStream
+}
+
+type daprInternalCallLocalStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprInternalCallLocalStreamServer) Send(m *InternalInvokeResponseStream) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *daprInternalCallLocalStreamServer) Recv() (*InternalInvokeRequestStream, error) {
+	m := new(InternalInvokeRequestStream)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _DaprInternal_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "dapr.proto.daprinternal.v1.DaprInternal",
 	HandlerType: (*DaprInternalServer)(nil),
@@ -397,6 +584,13 @@ var _DaprInternal_serviceDesc = grpc.ServiceDesc{
 			Handler:    _DaprInternal_CallLocal_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CallLocalStream",
+			Handler:       _DaprInternal_CallLocalStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "dapr/proto/daprinternal/v1/daprinternal.proto",
 }
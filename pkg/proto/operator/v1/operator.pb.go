@@ -190,11 +190,215 @@ func (m *GetConfigurationResponse) GetConfiguration() *any.Any {
 	return nil
 }
 
+type ReportComponentStatusRequest struct {
+	// The name of the component
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The namespace of the component and the pod
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The name of the pod of the sidecar
+	Pod string `protobuf:"bytes,3,opt,name=pod,proto3" json:"pod,omitempty"`
+	// The Dapr ID of the sidecar
+	AppId string `protobuf:"bytes,4,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// Whether the sidecar loaded the component
+	Loaded bool `protobuf:"varint,5,opt,name=loaded,proto3" json:"loaded,omitempty"`
+	// The error loading the component, if any
+	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportComponentStatusRequest) Reset()         { *m = ReportComponentStatusRequest{} }
+func (m *ReportComponentStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportComponentStatusRequest) ProtoMessage()    {}
+func (*ReportComponentStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e6e6e3126ef3d27, []int{4}
+}
+
+func (m *ReportComponentStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportComponentStatusRequest.Unmarshal(m, b)
+}
+func (m *ReportComponentStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportComponentStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportComponentStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportComponentStatusRequest.Merge(m, src)
+}
+func (m *ReportComponentStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportComponentStatusRequest.Size(m)
+}
+func (m *ReportComponentStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportComponentStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportComponentStatusRequest proto.InternalMessageInfo
+
+func (m *ReportComponentStatusRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReportComponentStatusRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ReportComponentStatusRequest) GetPod() string {
+	if m != nil {
+		return m.Pod
+	}
+	return ""
+}
+
+func (m *ReportComponentStatusRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *ReportComponentStatusRequest) GetLoaded() bool {
+	if m != nil {
+		return m.Loaded
+	}
+	return false
+}
+
+func (m *ReportComponentStatusRequest) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type SetTopicPausedRequest struct {
+	// The namespace of the sidecars
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The Dapr ID of the sidecars. All the sidecars of the namespace when empty.
+	AppId string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// The topic to pause or resume
+	Topic string `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Whether to pause or resume the delivery of the topic
+	Paused               bool     `protobuf:"varint,4,opt,name=paused,proto3" json:"paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTopicPausedRequest) Reset()         { *m = SetTopicPausedRequest{} }
+func (m *SetTopicPausedRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTopicPausedRequest) ProtoMessage()    {}
+func (*SetTopicPausedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e6e6e3126ef3d27, []int{5}
+}
+
+func (m *SetTopicPausedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTopicPausedRequest.Unmarshal(m, b)
+}
+func (m *SetTopicPausedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTopicPausedRequest.Marshal(b, m, deterministic)
+}
+func (m *SetTopicPausedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTopicPausedRequest.Merge(m, src)
+}
+func (m *SetTopicPausedRequest) XXX_Size() int {
+	return xxx_messageInfo_SetTopicPausedRequest.Size(m)
+}
+func (m *SetTopicPausedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTopicPausedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTopicPausedRequest proto.InternalMessageInfo
+
+func (m *SetTopicPausedRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *SetTopicPausedRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *SetTopicPausedRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *SetTopicPausedRequest) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+type SetTopicPausedResponse struct {
+	// The pods of the sidecars that paused or resumed the topic
+	Pods []string `protobuf:"bytes,1,rep,name=pods,proto3" json:"pods,omitempty"`
+	// The errors of the sidecars that failed to, prefixed with their pod
+	Errors               []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTopicPausedResponse) Reset()         { *m = SetTopicPausedResponse{} }
+func (m *SetTopicPausedResponse) String() string { return proto.CompactTextString(m) }
+func (*SetTopicPausedResponse) ProtoMessage()    {}
+func (*SetTopicPausedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e6e6e3126ef3d27, []int{6}
+}
+
+func (m *SetTopicPausedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTopicPausedResponse.Unmarshal(m, b)
+}
+func (m *SetTopicPausedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTopicPausedResponse.Marshal(b, m, deterministic)
+}
+func (m *SetTopicPausedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTopicPausedResponse.Merge(m, src)
+}
+func (m *SetTopicPausedResponse) XXX_Size() int {
+	return xxx_messageInfo_SetTopicPausedResponse.Size(m)
+}
+func (m *SetTopicPausedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTopicPausedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTopicPausedResponse proto.InternalMessageInfo
+
+func (m *SetTopicPausedResponse) GetPods() []string {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
+func (m *SetTopicPausedResponse) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ComponentUpdateEvent)(nil), "dapr.proto.operator.v1.ComponentUpdateEvent")
 	proto.RegisterType((*GetComponentResponse)(nil), "dapr.proto.operator.v1.GetComponentResponse")
 	proto.RegisterType((*GetConfigurationRequest)(nil), "dapr.proto.operator.v1.GetConfigurationRequest")
 	proto.RegisterType((*GetConfigurationResponse)(nil), "dapr.proto.operator.v1.GetConfigurationResponse")
+	proto.RegisterType((*ReportComponentStatusRequest)(nil), "dapr.proto.operator.v1.ReportComponentStatusRequest")
+	proto.RegisterType((*SetTopicPausedRequest)(nil), "dapr.proto.operator.v1.SetTopicPausedRequest")
+	proto.RegisterType((*SetTopicPausedResponse)(nil), "dapr.proto.operator.v1.SetTopicPausedResponse")
 }
 
 func init() {
@@ -202,29 +406,40 @@ func init() {
 }
 
 var fileDescriptor_4e6e6e3126ef3d27 = []byte{
-	// 343 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x51, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xd7, 0xfd, 0xff, 0x88, 0xbb, 0x32, 0x94, 0x30, 0x66, 0xad, 0x3e, 0x8c, 0x82, 0x20,
-	0x32, 0x92, 0x6d, 0xfa, 0xe4, 0x9b, 0xca, 0x10, 0x54, 0x10, 0x0a, 0x2a, 0xe8, 0x53, 0xd6, 0xdd,
-	0xd5, 0xa1, 0x4d, 0x62, 0x93, 0x4e, 0xf6, 0xd1, 0xfc, 0x76, 0xb2, 0x74, 0xeb, 0xe6, 0x3a, 0x87,
-	0x3e, 0xf5, 0x72, 0xcf, 0xe9, 0xaf, 0xe9, 0x3d, 0xb9, 0x70, 0xd8, 0xe7, 0x2a, 0x61, 0x2a, 0x91,
-	0x46, 0x32, 0xa9, 0x30, 0xe1, 0x46, 0x26, 0x6c, 0xd4, 0xce, 0x6b, 0x6a, 0x25, 0x52, 0x9f, 0xd8,
-	0xb2, 0x9a, 0xe6, 0xd2, 0xa8, 0xed, 0xed, 0x45, 0x52, 0x46, 0x6f, 0x98, 0x01, 0x7a, 0xe9, 0x80,
-	0x71, 0x31, 0xce, 0x6c, 0xde, 0xfe, 0xb2, 0x84, 0xb1, 0x32, 0x53, 0xd1, 0xbf, 0x86, 0xda, 0xa5,
-	0x8c, 0x95, 0x14, 0x28, 0xcc, 0xbd, 0xea, 0x73, 0x83, 0xdd, 0x11, 0x0a, 0x43, 0x3a, 0x50, 0x09,
-	0x67, 0x7d, 0xd7, 0x69, 0x38, 0x47, 0x5b, 0x9d, 0x1a, 0xcd, 0x40, 0x74, 0x06, 0xa2, 0xe7, 0x62,
-	0x1c, 0xcc, 0x6d, 0xfe, 0x2d, 0xd4, 0xae, 0xd0, 0xe4, 0xb8, 0x00, 0xb5, 0x92, 0x42, 0x23, 0x39,
-	0x05, 0xc8, 0x4d, 0xda, 0x75, 0x1a, 0xff, 0x7e, 0x84, 0x2d, 0xf8, 0xfc, 0x1b, 0xd8, 0xb5, 0x34,
-	0x31, 0x18, 0x46, 0x69, 0xc2, 0xcd, 0x50, 0x8a, 0x00, 0xdf, 0x53, 0xd4, 0x86, 0x10, 0xf8, 0x2f,
-	0x78, 0x8c, 0xf6, 0x5c, 0x95, 0xc0, 0xd6, 0xe4, 0x00, 0x2a, 0x93, 0xa7, 0x56, 0x3c, 0x44, 0xb7,
-	0x6c, 0x85, 0x79, 0xc3, 0x7f, 0x00, 0xb7, 0x08, 0x9b, 0x1e, 0xef, 0x0c, 0xaa, 0xe1, 0xa2, 0xb0,
-	0xf6, 0x77, 0xbf, 0x5b, 0x3b, 0x9f, 0x65, 0xd8, 0xbc, 0x9b, 0xc6, 0x40, 0x9e, 0x61, 0x7b, 0x69,
-	0x96, 0xa4, 0x5e, 0x80, 0x74, 0x27, 0xc3, 0xf7, 0x9a, 0x74, 0x75, 0x8e, 0x74, 0x55, 0x18, 0x7e,
-	0xa9, 0xe5, 0x90, 0x47, 0xa8, 0x2e, 0x0e, 0x57, 0xff, 0x1d, 0xbd, 0x2a, 0x1b, 0xbf, 0x44, 0x3e,
-	0x60, 0x67, 0x79, 0x34, 0x84, 0xad, 0x65, 0x14, 0x13, 0xf1, 0x5a, 0xbf, 0x7f, 0x61, 0xf6, 0xe1,
-	0x8b, 0xe6, 0xd3, 0x71, 0x34, 0x34, 0x2f, 0x69, 0x8f, 0x86, 0x32, 0x66, 0xf6, 0xfa, 0x67, 0x3b,
-	0xf0, 0x1a, 0x15, 0xf7, 0xa0, 0xb7, 0x61, 0x5b, 0x27, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xea,
-	0xf2, 0xe0, 0x80, 0x28, 0x03, 0x00, 0x00,
+	// 521 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0x8d, 0xf3, 0x52, 0x72, 0x51, 0xa1, 0xba, 0x4a, 0x82, 0x31, 0x5d, 0x44, 0x23, 0x21, 0x55,
+	0xa8, 0xd8, 0x6d, 0xe8, 0x8a, 0x1d, 0x8f, 0x0a, 0xf1, 0x90, 0x40, 0x2e, 0x0f, 0x09, 0x16, 0x68,
+	0x62, 0x4f, 0x8d, 0x45, 0xe3, 0xb9, 0xb5, 0xc7, 0x41, 0xe5, 0x3f, 0xf8, 0x06, 0x7e, 0x13, 0x79,
+	0xec, 0x38, 0x89, 0xe3, 0x44, 0x85, 0x4d, 0x72, 0x1f, 0xc7, 0xe7, 0x9e, 0x99, 0x39, 0x33, 0xf0,
+	0xc0, 0xe7, 0x14, 0x3b, 0x14, 0x4b, 0x25, 0x1d, 0x49, 0x22, 0xe6, 0x4a, 0xc6, 0xce, 0xfc, 0xa4,
+	0x8c, 0x6d, 0xdd, 0xc2, 0x51, 0x06, 0xcb, 0x63, 0xbb, 0x6c, 0xcd, 0x4f, 0xac, 0x7b, 0x81, 0x94,
+	0xc1, 0xa5, 0xc8, 0x09, 0xa6, 0xe9, 0x85, 0xc3, 0xa3, 0xeb, 0x1c, 0x66, 0xdd, 0xaf, 0xb6, 0xc4,
+	0x8c, 0x54, 0xd1, 0x64, 0xaf, 0x61, 0xf0, 0x5c, 0xce, 0x48, 0x46, 0x22, 0x52, 0x1f, 0xc9, 0xe7,
+	0x4a, 0x9c, 0xcd, 0x45, 0xa4, 0x70, 0x02, 0x7d, 0x6f, 0x51, 0x37, 0x8d, 0xb1, 0x71, 0x78, 0x6b,
+	0x32, 0xb0, 0x73, 0x22, 0x7b, 0x41, 0x64, 0x3f, 0x8d, 0xae, 0xdd, 0x25, 0x8c, 0xbd, 0x85, 0xc1,
+	0x4b, 0xa1, 0x4a, 0x3a, 0x57, 0x24, 0x24, 0xa3, 0x44, 0xe0, 0x29, 0x40, 0x09, 0x4a, 0x4c, 0x63,
+	0xdc, 0xda, 0x4a, 0xb6, 0x82, 0x63, 0x6f, 0xe0, 0xae, 0x66, 0x8b, 0x2e, 0xc2, 0x20, 0x8d, 0xb9,
+	0x0a, 0x65, 0xe4, 0x8a, 0xab, 0x54, 0x24, 0x0a, 0x11, 0xda, 0x11, 0x9f, 0x09, 0xad, 0xab, 0xef,
+	0xea, 0x18, 0x0f, 0xa0, 0x9f, 0xfd, 0x27, 0xc4, 0x3d, 0x61, 0x36, 0x75, 0x63, 0x59, 0x60, 0x9f,
+	0xc0, 0xdc, 0x24, 0x2b, 0xe4, 0x3d, 0x81, 0x3d, 0x6f, 0xb5, 0xb1, 0x73, 0xb9, 0xeb, 0x50, 0xf6,
+	0xc7, 0x80, 0x03, 0x57, 0x90, 0x8c, 0x97, 0xcb, 0x3e, 0x57, 0x5c, 0xa5, 0xc9, 0x7f, 0x4b, 0xc5,
+	0x7d, 0x68, 0x91, 0xf4, 0xcd, 0x96, 0xae, 0x67, 0x21, 0x0e, 0xa1, 0xcb, 0x89, 0xbe, 0x85, 0xbe,
+	0xd9, 0xd6, 0xc5, 0x0e, 0x27, 0x7a, 0xe5, 0xe3, 0x08, 0xba, 0x97, 0x92, 0xfb, 0xc2, 0x37, 0x3b,
+	0x63, 0xe3, 0xb0, 0xe7, 0x16, 0x19, 0x0e, 0xa0, 0x23, 0xe2, 0x58, 0xc6, 0x66, 0x37, 0x47, 0xeb,
+	0x84, 0xfd, 0x82, 0xe1, 0xb9, 0x50, 0x1f, 0x24, 0x85, 0xde, 0x7b, 0x9e, 0x26, 0xc2, 0x5f, 0x28,
+	0x5c, 0x53, 0x63, 0x54, 0xd5, 0x2c, 0x67, 0x37, 0x57, 0x67, 0x0f, 0xa0, 0xa3, 0x32, 0xaa, 0x42,
+	0x66, 0x9e, 0x64, 0x8a, 0x48, 0x73, 0x6b, 0xa1, 0x3d, 0xb7, 0xc8, 0xd8, 0x0b, 0x18, 0x55, 0x67,
+	0x17, 0x7b, 0x8f, 0xd0, 0x26, 0xe9, 0xe7, 0xa6, 0xe8, 0xbb, 0x3a, 0xce, 0x58, 0xb4, 0xe4, 0xc4,
+	0x6c, 0xea, 0x6a, 0x91, 0x4d, 0x7e, 0xb7, 0xa1, 0xf7, 0xae, 0xb0, 0x3c, 0x7e, 0x85, 0x3b, 0x15,
+	0xdf, 0xe2, 0x68, 0xe3, 0xc0, 0xce, 0x32, 0xa3, 0x5b, 0x47, 0x76, 0xfd, 0x9d, 0xb1, 0xeb, 0x8c,
+	0xcf, 0x1a, 0xc7, 0x06, 0x7e, 0x86, 0xbd, 0x55, 0x23, 0x27, 0xff, 0x4e, 0x5d, 0x77, 0x0f, 0x58,
+	0x03, 0x7f, 0xc2, 0x7e, 0xd5, 0x86, 0xe8, 0xec, 0xe4, 0xd8, 0x74, 0xbf, 0x75, 0x7c, 0xf3, 0x0f,
+	0xca, 0xc1, 0x01, 0x0c, 0x6b, 0x6d, 0x8a, 0xa7, 0xdb, 0xc8, 0x76, 0xb9, 0xda, 0xda, 0xb2, 0x1f,
+	0xac, 0x81, 0x57, 0x70, 0x7b, 0xfd, 0xa8, 0xf1, 0xd1, 0xb6, 0x09, 0xb5, 0x76, 0xb4, 0xec, 0x9b,
+	0xc2, 0x17, 0x6b, 0x7b, 0x76, 0xf4, 0xe5, 0x61, 0x10, 0xaa, 0xef, 0xe9, 0xd4, 0xf6, 0xe4, 0xcc,
+	0xd1, 0xcf, 0xa8, 0xfe, 0xa1, 0x1f, 0xc1, 0xe6, 0x7b, 0x3a, 0xed, 0xea, 0xd2, 0xe3, 0xbf, 0x03,
+	0x00, 0x03, 0x59, 0x59, 0xf9, 0x70, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -245,6 +460,10 @@ type OperatorClient interface {
 	GetComponents(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetComponentResponse, error)
 	// GetConfiguration returns a given configuration by name
 	GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*GetConfigurationResponse, error)
+	// ReportComponentStatus reports whether a Dapr sidecar loaded a component
+	ReportComponentStatus(ctx context.Context, in *ReportComponentStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// SetTopicPaused pauses or resumes the delivery of a topic on the Dapr sidecars of a namespace
+	SetTopicPaused(ctx context.Context, in *SetTopicPausedRequest, opts ...grpc.CallOption) (*SetTopicPausedResponse, error)
 }
 
 type operatorClient struct {
@@ -305,6 +524,24 @@ func (c *operatorClient) GetConfiguration(ctx context.Context, in *GetConfigurat
 	return out, nil
 }
 
+func (c *operatorClient) ReportComponentStatus(ctx context.Context, in *ReportComponentStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.operator.v1.Operator/ReportComponentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorClient) SetTopicPaused(ctx context.Context, in *SetTopicPausedRequest, opts ...grpc.CallOption) (*SetTopicPausedResponse, error) {
+	out := new(SetTopicPausedResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.operator.v1.Operator/SetTopicPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OperatorServer is the server API for Operator service.
 type OperatorServer interface {
 	// ComponentUpdate sends events to Dapr sidecars upon component changes.
@@ -313,6 +550,10 @@ type OperatorServer interface {
 	GetComponents(context.Context, *empty.Empty) (*GetComponentResponse, error)
 	// GetConfiguration returns a given configuration by name
 	GetConfiguration(context.Context, *GetConfigurationRequest) (*GetConfigurationResponse, error)
+	// ReportComponentStatus reports whether a Dapr sidecar loaded a component
+	ReportComponentStatus(context.Context, *ReportComponentStatusRequest) (*empty.Empty, error)
+	// SetTopicPaused pauses or resumes the delivery of a topic on the Dapr sidecars of a namespace
+	SetTopicPaused(context.Context, *SetTopicPausedRequest) (*SetTopicPausedResponse, error)
 }
 
 // UnimplementedOperatorServer can be embedded to have forward compatible implementations.
@@ -328,6 +569,12 @@ func (*UnimplementedOperatorServer) GetComponents(ctx context.Context, req *empt
 func (*UnimplementedOperatorServer) GetConfiguration(ctx context.Context, req *GetConfigurationRequest) (*GetConfigurationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguration not implemented")
 }
+func (*UnimplementedOperatorServer) ReportComponentStatus(ctx context.Context, req *ReportComponentStatusRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportComponentStatus not implemented")
+}
+func (*UnimplementedOperatorServer) SetTopicPaused(ctx context.Context, req *SetTopicPausedRequest) (*SetTopicPausedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTopicPaused not implemented")
+}
 
 func RegisterOperatorServer(s *grpc.Server, srv OperatorServer) {
 	s.RegisterService(&_Operator_serviceDesc, srv)
@@ -390,6 +637,42 @@ func _Operator_GetConfiguration_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Operator_ReportComponentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportComponentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorServer).ReportComponentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.operator.v1.Operator/ReportComponentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorServer).ReportComponentStatus(ctx, req.(*ReportComponentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Operator_SetTopicPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTopicPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorServer).SetTopicPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.operator.v1.Operator/SetTopicPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorServer).SetTopicPaused(ctx, req.(*SetTopicPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Operator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "dapr.proto.operator.v1.Operator",
 	HandlerType: (*OperatorServer)(nil),
@@ -402,6 +685,14 @@ var _Operator_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfiguration",
 			Handler:    _Operator_GetConfiguration_Handler,
 		},
+		{
+			MethodName: "ReportComponentStatus",
+			Handler:    _Operator_ReportComponentStatus_Handler,
+		},
+		{
+			MethodName: "SetTopicPaused",
+			Handler:    _Operator_SetTopicPaused_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,87 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/components"
+	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+)
+
+// ComponentCapabilities returns the features of the loaded components, sorted by name
+func (a *DaprRuntime) ComponentCapabilities() []components.Capabilities {
+	a.componentsLock.RLock()
+	defer a.componentsLock.RUnlock()
+
+	result := []components.Capabilities{}
+	for _, c := range a.components {
+		name := c.ObjectMeta.Name
+		var features, aliases []string
+		loaded := false
+
+		switch {
+		case strings.Index(c.Spec.Type, "state") == 0:
+			if _, ok := a.stateStores[name]; ok {
+				features, aliases, loaded = a.stateFeatures[name], a.stateStoreAliasesOf(name), true
+			}
+		case strings.Index(c.Spec.Type, "pubsub") == 0:
+			if p, ok := a.pubSubs[name]; ok {
+				features, loaded = componentFeatures(p, components.FeatureStreaming), true
+			}
+		case strings.Index(c.Spec.Type, "bindings") == 0:
+			if b, ok := a.inputBindings[name]; ok {
+				features, loaded = componentFeatures(b, components.FeatureStreaming), true
+			}
+			if b, ok := a.outputBindings[name]; ok && !loaded {
+				features, loaded = componentFeatures(b), true
+			}
+		case strings.Index(c.Spec.Type, "configuration") == 0:
+			if s, ok := a.configurationStores[name]; ok {
+				features, loaded = componentFeatures(s, components.FeatureStreaming), true
+			}
+		case strings.Index(c.Spec.Type, "secretstores") == 0:
+			if s, ok := a.secretStores[name]; ok {
+				features, loaded = secretStoreFeatures(secretstores_loader.Unwrap(s)), true
+			}
+		}
+		if !loaded {
+			continue
+		}
+
+		if features == nil {
+			features = []string{}
+		}
+		result = append(result, components.Capabilities{
+			Name:     name,
+			Type:     c.Spec.Type,
+			Features: features,
+			Aliases:  aliases,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// secretStoreFeatures returns the features of the secret store, declared or detected
+func secretStoreFeatures(store interface{}) []string {
+	if _, ok := store.(secretstores_loader.BulkGetter); ok {
+		return componentFeatures(store, components.FeatureBulkGet)
+	}
+	return componentFeatures(store)
+}
+
+// componentFeatures returns the features the component declares, or the detected features otherwise
+func componentFeatures(component interface{}, detected ...string) []string {
+	if p, ok := component.(components.FeaturesProvider); ok {
+		return p.Features()
+	}
+	return detected
+}
@@ -6,17 +6,25 @@
 package runtime
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	goruntime "runtime"
 	"strconv"
+	"strings"
 
+	"github.com/dapr/dapr/pkg/channel"
 	global_config "github.com/dapr/dapr/pkg/config"
+	modes_config "github.com/dapr/dapr/pkg/config/modes"
+	"github.com/dapr/dapr/pkg/conformance"
 	"github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	"github.com/dapr/dapr/pkg/grpc"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/metrics"
 	"github.com/dapr/dapr/pkg/modes"
+	"github.com/dapr/dapr/pkg/namedpipe"
 	"github.com/dapr/dapr/pkg/operator/client"
 	"github.com/dapr/dapr/pkg/runtime/security"
 	"github.com/dapr/dapr/pkg/version"
@@ -42,9 +50,33 @@ func FromFlags() (*DaprRuntime, error) {
 	runtimeVersion := flag.Bool("version", false, "Prints the runtime version")
 	maxConcurrency := flag.Int("max-concurrency", -1, "Controls the concurrency level when forwarding requests to user code")
 	enableMTLS := flag.Bool("enable-mtls", false, "Enables automatic mTLS for daprd to daprd communication channels")
+	certifyStateStores := flag.Bool("certify-state-stores", false, "Runs contention and consistency checks against the configured state stores and exits")
+	runConformance := flag.String("run-conformance", "", "Path to a component file. Runs the conformance suite of the component's building block against it and exits")
+	internalGRPCCompression := flag.String("internal-grpc-compression", grpc.CompressionNone, "Compression for calls to other Dapr sidecars: none, gzip or zstd")
+	listenAddresses := flag.String("listen-addresses", "", "Comma separated IPv4 or IPv6 addresses the Dapr servers listen on. Listens on all interfaces when empty")
+	internalAdvertiseAddress := flag.String("dapr-internal-advertise-address", "", "Host or host:port registered with placement and name resolution for other sidecars to reach the internal gRPC server. Defaults to the host IP and internal gRPC port")
+	walPath := flag.String("wal-path", DefaultWALPath, "Path for the write-ahead logs of writes queued for retry")
+	appContainer := flag.String("app-container", "", "Name of the app container of a Kubernetes Job pod. The sidecar exits when the app container terminates, so that the Job completes")
+	appInvocationTimeout := flag.Duration("app-invocation-timeout", channel.DefaultChannelRequestTimeout, "Timeout of service invocations of the app")
+	appPubSubTimeout := flag.Duration("app-pubsub-timeout", channel.DefaultChannelRequestTimeout, "Timeout of pub/sub deliveries to the app. Subscriptions override it with their timeout metadata")
+	appBindingTimeout := flag.Duration("app-binding-timeout", channel.DefaultChannelRequestTimeout, "Timeout of input binding events delivered to the app")
+	appActorTimeout := flag.Duration("app-actor-timeout", channel.DefaultChannelRequestTimeout, "Timeout of actor method, reminder and timer calls of the app")
+	enableInternalGRPCChannelz := flag.Bool("enable-internal-grpc-channelz", false, "Serves the gRPC channelz service on the internal gRPC server, to diagnose the connections between Dapr sidecars")
+	conformanceReportFormat := flag.String("conformance-report-format", conformance.FormatText, "Format of the conformance report: text, json or junit")
+	configServiceAddress := flag.String("config-service-address", "", "Address of a config service the configuration and the components are fetched from instead of the local files: http(s)://host:port/path, or grpc(s)://host:port for a config service serving the operator API. Standalone mode only")
+	configServiceCacheDir := flag.String("config-service-cache-dir", DefaultConfigServiceCacheDir, "Dir of the last configuration and components fetched from the config service, used when it can't be reached")
+	daprHTTPPipe := flag.String("dapr-http-pipe", "", `Windows named pipe the HTTP API is also served on, e.g. \\.\pipe\dapr-http`)
+	daprAPIGRPCPipe := flag.String("dapr-grpc-pipe", "", `Windows named pipe the gRPC API is also served on, e.g. \\.\pipe\dapr-grpc`)
+	appPipe := flag.String("app-pipe", "", `Windows named pipe the application is listening on instead of the app port, e.g. \\.\pipe\app. The actor and app health checks still need the app port`)
+	waitForAddresses := flag.String("wait-for-addresses", "", "Comma separated host:port addresses. Waits until they accept connections and exits, e.g. in the init container of a pod waiting for the control plane")
+	waitForSidecar := flag.Bool("wait-for-sidecar", false, "Waits until the sidecar listening on the Dapr HTTP port is ready and exits, e.g. in the post start hook of the sidecar holding the app until it's ready")
+	appStartsAfterSidecar := flag.Bool("app-starts-after-sidecar", false, "The app is held until the sidecar is ready, e.g. by the post start hook of the sidecar. The sidecar doesn't wait for the app and loads the app configuration and subscriptions once the app is reachable")
+	waitTimeout := flag.Duration("wait-timeout", DefaultWaitTimeout, "Time to wait for the addresses or the sidecar before exiting with an error")
+	profile := flag.String("profile", "", fmt.Sprintf("Profile whose overrides are layered on the components and the configuration file, e.g. prod. Standalone mode only. Defaults to the %s environment variable", ProfileEnvVar))
 
 	loggerOptions := logger.DefaultOptions()
 	loggerOptions.AttachCmdFlags(flag.StringVar, flag.BoolVar)
+	fips.AttachCmdFlags(flag.BoolVar)
 
 	metricsExporter := metrics.NewExporter(metrics.DefaultMetricNamespace)
 	metricsExporter.Options().AttachCmdFlags(flag.StringVar, flag.BoolVar)
@@ -62,8 +94,28 @@ func FromFlags() (*DaprRuntime, error) {
 		return nil, err
 	}
 
+	if *waitForAddresses != "" || *waitForSidecar {
+		var err error
+		if *waitForAddresses != "" {
+			err = WaitForAddresses(strings.Split(*waitForAddresses, ","), *waitTimeout)
+		}
+		if err == nil && *waitForSidecar {
+			err = WaitForSidecar(fmt.Sprintf("127.0.0.1:%s", *daprHTTPPort), *waitTimeout)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
 	log.Infof("starting Dapr Runtime -- version %s -- commit %s", version.Version(), version.Commit())
 	log.Infof("log level set to: %s", loggerOptions.OutputLevel)
+	if fips.Enabled() {
+		log.Info("fips mode enabled")
+	}
+
+	addresses := parseListenAddresses(*listenAddresses)
+	metricsExporter.Options().ListenAddresses = addresses
 
 	// Initialize dapr metrics exporter
 	if metricsExporter.Options().MetricsEnabled {
@@ -103,6 +155,12 @@ func FromFlags() (*DaprRuntime, error) {
 		}
 	}
 
+	for flagName, pipe := range map[string]string{"dapr-http-pipe": *daprHTTPPipe, "dapr-grpc-pipe": *daprAPIGRPCPipe, "app-pipe": *appPipe} {
+		if err := validatePipe(pipe); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", flagName, err)
+		}
+	}
+
 	var applicationPort int
 	if *appPort != "" {
 		applicationPort, err = strconv.Atoi(*appPort)
@@ -114,6 +172,35 @@ func FromFlags() (*DaprRuntime, error) {
 	runtimeConfig := NewRuntimeConfig(*appID, *placementServiceAddress, *controlPlaneAddress, *allowedOrigins, *config, *componentsPath,
 		*appProtocol, *mode, daprHTTP, daprInternalGRPC, daprAPIGRPC, applicationPort, profPort, *enableProfiling, *maxConcurrency, *enableMTLS, *sentryAddress)
 
+	runtimeConfig.CertifyStateStores = *certifyStateStores
+	runtimeConfig.ConformanceComponent = *runConformance
+	runtimeConfig.ConformanceReportFormat = *conformanceReportFormat
+	runtimeConfig.InternalGRPCCompression = *internalGRPCCompression
+	runtimeConfig.ListenAddresses = addresses
+	runtimeConfig.InternalAdvertiseAddress = *internalAdvertiseAddress
+	runtimeConfig.WALPath = *walPath
+	runtimeConfig.AppContainer = *appContainer
+	runtimeConfig.EnableInternalGRPCChannelz = *enableInternalGRPCChannelz
+	runtimeConfig.HTTPPipe = *daprHTTPPipe
+	runtimeConfig.APIGRPCPipe = *daprAPIGRPCPipe
+	runtimeConfig.ApplicationPipe = *appPipe
+	runtimeConfig.AppStartsAfterSidecar = *appStartsAfterSidecar
+	runtimeConfig.Standalone.Profile = *profile
+	if runtimeConfig.Standalone.Profile == "" {
+		runtimeConfig.Standalone.Profile = os.Getenv(ProfileEnvVar)
+	}
+	runtimeConfig.Standalone.ConfigService = modes_config.ConfigServiceConfig{
+		Address:  *configServiceAddress,
+		Token:    os.Getenv(ConfigServiceTokenEnvVar),
+		CacheDir: *configServiceCacheDir,
+	}
+	runtimeConfig.AppChannelTimeouts = channel.Timeouts{
+		channel.OperationInvocation: *appInvocationTimeout,
+		channel.OperationPubSub:     *appPubSubTimeout,
+		channel.OperationBinding:    *appBindingTimeout,
+		channel.OperationActor:      *appActorTimeout,
+	}
+
 	var globalConfig *global_config.Configuration
 	var configErr error
 
@@ -124,7 +211,8 @@ func FromFlags() (*DaprRuntime, error) {
 		}
 	}
 
-	if *config != "" {
+	// in Kubernetes mode the operator is asked for the cluster default configuration even when no config is set
+	if *config != "" || modes.DaprMode(*mode) == modes.KubernetesMode {
 		switch modes.DaprMode(*mode) {
 		case modes.KubernetesMode:
 			client, conn, clientErr := client.GetOperatorClient(*controlPlaneAddress, security.TLSServerName, runtimeConfig.CertChain)
@@ -133,9 +221,16 @@ func FromFlags() (*DaprRuntime, error) {
 			}
 			defer conn.Close()
 
+			// tracing is not configured until the configuration is loaded, so only the call latency is recorded here
+			_, call := diagnostics.StartControlPlaneCall(context.Background(), diagnostics.ControlPlaneOperator, "GetConfiguration", global_config.LoadDefaultConfiguration().Spec.TracingSpec)
 			globalConfig, configErr = global_config.LoadKubernetesConfiguration(*config, os.Getenv("NAMESPACE"), client)
+			call.End(configErr)
 		case modes.StandaloneMode:
-			globalConfig, configErr = global_config.LoadStandaloneConfiguration(*config)
+			if runtimeConfig.Standalone.ConfigService.Address != "" {
+				globalConfig, configErr = loadRemoteConfiguration(runtimeConfig.Standalone.ConfigService, *config, *appID)
+				break
+			}
+			globalConfig, configErr = global_config.LoadStandaloneProfileConfiguration(*config, runtimeConfig.Standalone.Profile)
 		}
 	}
 
@@ -148,3 +243,40 @@ func FromFlags() (*DaprRuntime, error) {
 	}
 	return NewDaprRuntime(runtimeConfig, globalConfig), nil
 }
+
+// loadRemoteConfiguration loads the configuration of the given name from the config service
+func loadRemoteConfiguration(spec modes_config.ConfigServiceConfig, name, appID string) (*global_config.Configuration, error) {
+	client, err := global_config.NewConfigServiceClient(spec, appID)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return global_config.LoadRemoteConfiguration(client, name)
+}
+
+// parseListenAddresses splits a comma separated list of listen addresses.
+// IPv6 addresses may be given with or without brackets.
+func parseListenAddresses(addresses string) []string {
+	parsed := []string{}
+	for _, address := range strings.Split(addresses, ",") {
+		address = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(address), "["), "]")
+		if address != "" {
+			parsed = append(parsed, address)
+		}
+	}
+	return parsed
+}
+
+// validatePipe checks the path of a Windows named pipe flag, when set
+func validatePipe(pipe string) error {
+	if pipe == "" {
+		return nil
+	}
+	if goruntime.GOOS != "windows" {
+		return namedpipe.ErrNotSupported
+	}
+	if !namedpipe.IsPipe(pipe) {
+		return fmt.Errorf(`%s isn't the path of a local pipe, \\.\pipe\<name>`, pipe)
+	}
+	return nil
+}
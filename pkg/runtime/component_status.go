@@ -0,0 +1,53 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"time"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/modes"
+	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
+)
+
+const componentStatusTimeout = 5 * time.Second
+
+// reportComponentStatus reports to the operator whether the component loaded, so that it shows in the status of the
+// component. It returns the error of loading the component.
+func (a *DaprRuntime) reportComponentStatus(c components_v1alpha1.Component, err error) error {
+	if a.runtimeConfig.Mode != modes.KubernetesMode || a.operatorClient == nil {
+		return err
+	}
+
+	req := &operatorv1pb.ReportComponentStatusRequest{
+		Name:      c.ObjectMeta.Name,
+		Namespace: a.namespace,
+		Pod:       os.Getenv(PodNameEnvVar),
+		AppId:     a.runtimeConfig.ID,
+		Loaded:    err == nil,
+	}
+	if req.Pod == "" {
+		return err
+	}
+	if err != nil {
+		req.Error = err.Error()
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), componentStatusTimeout)
+		defer cancel()
+		ctx, call := diag.StartControlPlaneCall(ctx, diag.ControlPlaneOperator, "ReportComponentStatus", a.globalConfig.Spec.TracingSpec)
+		_, reportErr := a.operatorClient.ReportComponentStatus(ctx, req)
+		call.End(reportErr)
+		if reportErr != nil {
+			log.Debugf("failed to report status of component %s: %s", req.Name, reportErr)
+		}
+	}()
+	return err
+}
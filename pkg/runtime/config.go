@@ -6,6 +6,7 @@
 package runtime
 
 import (
+	"github.com/dapr/dapr/pkg/channel"
 	config "github.com/dapr/dapr/pkg/config/modes"
 	"github.com/dapr/dapr/pkg/credentials"
 	"github.com/dapr/dapr/pkg/modes"
@@ -29,8 +30,16 @@ const (
 	DefaultMetricsPort = 9090
 	// DefaultComponentsPath is the default dir for Dapr components (standalone mode)
 	DefaultComponentsPath = "./components"
+	// DefaultWALPath is the default dir of the write-ahead logs of queued writes
+	DefaultWALPath = "./.dapr/wal"
 	// DefaultAllowedOrigins is the default origins allowed for the Dapr HTTP servers
 	DefaultAllowedOrigins = "*"
+	// ProfileEnvVar is the environment variable of the standalone profile, used when the profile flag isn't set
+	ProfileEnvVar = "DAPR_PROFILE"
+	// DefaultConfigServiceCacheDir is the default dir of the configuration and components fetched from the config service
+	DefaultConfigServiceCacheDir = "./.dapr/config-service"
+	// ConfigServiceTokenEnvVar is the environment variable of the bearer token sent to the config service
+	ConfigServiceTokenEnvVar = "DAPR_CONFIG_SERVICE_TOKEN"
 )
 
 // Config holds the Dapr Runtime configuration
@@ -53,6 +62,29 @@ type Config struct {
 	mtlsEnabled             bool
 	SentryServiceAddress    string
 	CertChain               *credentials.CertChain
+	CertifyStateStores      bool
+	ConformanceComponent    string
+	ConformanceReportFormat string
+	InternalGRPCCompression string
+	ListenAddresses         []string
+	// InternalAdvertiseAddress is the host or host:port other sidecars use to reach the internal gRPC server
+	InternalAdvertiseAddress string
+	// WALPath is the dir of the write-ahead logs of queued writes
+	WALPath string
+	// AppContainer is the name of the app container of a Job pod. The sidecar exits when it terminates.
+	AppContainer string
+	// AppChannelTimeouts are the timeouts of the app callbacks per operation
+	AppChannelTimeouts channel.Timeouts
+	// EnableInternalGRPCChannelz serves channelz on the internal gRPC server
+	EnableInternalGRPCChannelz bool
+	// HTTPPipe and APIGRPCPipe are the Windows named pipes the Dapr APIs are also served on
+	HTTPPipe    string
+	APIGRPCPipe string
+	// ApplicationPipe is the Windows named pipe the app listens on instead of the app port
+	ApplicationPipe string
+	// AppStartsAfterSidecar is set when the app is held until the sidecar is ready, so the sidecar doesn't wait for
+	// the app and loads the app configuration and subscriptions once the app is reachable
+	AppStartsAfterSidecar bool
 }
 
 // NewRuntimeConfig returns a new runtime config
@@ -0,0 +1,101 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"flag"
+	goruntime "runtime"
+	"strings"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/version"
+)
+
+const redactedValue = "<redacted>"
+
+// sensitiveNames are substrings of flag and metadata names whose values are redacted from config dumps
+var sensitiveNames = []string{"password", "secret", "token", "key", "connectionstring", "credential", "auth", "cert", "sas"}
+
+// ConfigDump is a sanitized snapshot of the effective configuration of the sidecar, attached to support tickets
+type ConfigDump struct {
+	Version       VersionInfo              `json:"version"`
+	Flags         map[string]string        `json:"flags"`
+	Configuration config.ConfigurationSpec `json:"configuration"`
+	Components    []ComponentDump          `json:"components"`
+}
+
+// VersionInfo holds the versions the sidecar was built from
+type VersionInfo struct {
+	Dapr   string `json:"dapr"`
+	Commit string `json:"commit"`
+	Go     string `json:"go"`
+}
+
+// ComponentDump describes a loaded component. Secret and sensitive metadata values are redacted.
+type ComponentDump struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Type      string            `json:"type"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+// ConfigDump returns the sanitized effective configuration of the sidecar
+func (a *DaprRuntime) ConfigDump() interface{} {
+	dump := ConfigDump{
+		Version: VersionInfo{
+			Dapr:   version.Version(),
+			Commit: version.Commit(),
+			Go:     goruntime.Version(),
+		},
+		Flags:      map[string]string{},
+		Components: []ComponentDump{},
+	}
+
+	flag.VisitAll(func(f *flag.Flag) {
+		value := f.Value.String()
+		if isSensitiveName(f.Name) && value != "" {
+			value = redactedValue
+		}
+		dump.Flags[f.Name] = value
+	})
+
+	if a.globalConfig != nil {
+		dump.Configuration = a.globalConfig.Spec
+	}
+
+	for _, c := range a.components {
+		dump.Components = append(dump.Components, dumpComponent(c))
+	}
+	return dump
+}
+
+func dumpComponent(c components_v1alpha1.Component) ComponentDump {
+	d := ComponentDump{
+		Name:      c.ObjectMeta.Name,
+		Namespace: c.ObjectMeta.Namespace,
+		Type:      c.Spec.Type,
+		Metadata:  map[string]string{},
+	}
+	for _, m := range c.Spec.Metadata {
+		value := m.Value
+		if m.SecretKeyRef.Name != "" || isSensitiveName(m.Name) {
+			value = redactedValue
+		}
+		d.Metadata[m.Name] = value
+	}
+	return d
+}
+
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
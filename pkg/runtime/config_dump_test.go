@@ -0,0 +1,46 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"testing"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigDump(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.components = []components_v1alpha1.Component{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: "statestore",
+			},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type: "state.redis",
+				Metadata: []components_v1alpha1.MetadataItem{
+					{Name: "redisHost", Value: "localhost:6379"},
+					{Name: "redisPassword", Value: "plain"},
+					{Name: "actorStateStore", Value: "resolved", SecretKeyRef: components_v1alpha1.SecretKeyRef{Name: "redis", Key: "store"}},
+				},
+			},
+		},
+	}
+
+	dump := rt.ConfigDump().(ConfigDump)
+
+	assert.Len(t, dump.Components, 1)
+	c := dump.Components[0]
+	assert.Equal(t, "statestore", c.Name)
+	assert.Equal(t, "state.redis", c.Type)
+	assert.Equal(t, "localhost:6379", c.Metadata["redisHost"])
+	assert.Equal(t, redactedValue, c.Metadata["redisPassword"])
+	assert.Equal(t, redactedValue, c.Metadata["actorStateStore"])
+	assert.NotEmpty(t, dump.Version.Go)
+	assert.Equal(t, rt.globalConfig.Spec, dump.Configuration)
+}
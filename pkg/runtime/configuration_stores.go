@@ -0,0 +1,74 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	configuration_loader "github.com/dapr/dapr/pkg/components/configuration"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+func (a *DaprRuntime) initConfigurationStores() error {
+	for _, c := range a.components {
+		if strings.Index(c.Spec.Type, "configuration") != 0 {
+			continue
+		}
+
+		component := c
+		init := func() error {
+			return a.reportComponentStatus(component, a.initConfigurationStore(component))
+		}
+		if err := init(); err != nil {
+			if err = a.handleComponentInitFailure(c, err, init); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *DaprRuntime) initConfigurationStore(c components_v1alpha1.Component) error {
+	store, err := a.configurationRegistry.Create(c.Spec.Type, c.Spec.Version)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
+		return fmt.Errorf("failed creating configuration store %s: %s", c.Spec.Type, err)
+	}
+
+	err = store.Init(configuration_loader.Metadata{
+		Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
+	})
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("failed to init configuration store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
+	}
+
+	a.componentsLock.Lock()
+	a.configurationStores[c.ObjectMeta.Name] = store
+	a.componentsLock.Unlock()
+	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+	return nil
+}
+
+// updateConfigurationStore initializes a loaded store again with the metadata of the updated component, so that its
+// subscriptions get the changes, or initializes a new store
+func (a *DaprRuntime) updateConfigurationStore(c components_v1alpha1.Component) error {
+	a.componentsLock.RLock()
+	store, ok := a.configurationStores[c.ObjectMeta.Name]
+	a.componentsLock.RUnlock()
+	if !ok {
+		return a.initConfigurationStore(c)
+	}
+	err := store.Init(configuration_loader.Metadata{
+		Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
+	}
+	return nil
+}
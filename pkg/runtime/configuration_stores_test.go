@@ -0,0 +1,58 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	configuration_loader "github.com/dapr/dapr/pkg/components/configuration"
+	"github.com/dapr/dapr/pkg/components/configuration/inmemory"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigurationStores(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.configurationRegistry.Register(configuration_loader.New("in-memory", func() configuration_loader.Store {
+		return inmemory.NewStore()
+	}))
+	component := components_v1alpha1.Component{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "flags"},
+		Spec: components_v1alpha1.ComponentSpec{
+			Type:     "configuration.in-memory",
+			Metadata: []components_v1alpha1.MetadataItem{{Name: "checkout", Value: "on"}},
+		},
+	}
+	rt.components = []components_v1alpha1.Component{component}
+	get := func(key string) string {
+		resp, err := rt.configurationStores["flags"].Get(context.Background(), &configuration_loader.GetRequest{Keys: []string{key}})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 1)
+		return resp.Items[0].Value
+	}
+
+	t.Run("stores are initialized with the metadata", func(t *testing.T) {
+		require.NoError(t, rt.initConfigurationStores())
+		assert.Equal(t, "on", get("checkout"))
+	})
+
+	t.Run("updates initialize the loaded store again", func(t *testing.T) {
+		store := rt.configurationStores["flags"]
+		component.Spec.Metadata = []components_v1alpha1.MetadataItem{{Name: "checkout", Value: "off"}}
+		require.NoError(t, rt.updateConfigurationStore(component))
+		assert.Equal(t, store, rt.configurationStores["flags"])
+		assert.Equal(t, "off", get("checkout"))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		err := rt.initConfigurationStore(components_v1alpha1.Component{Spec: components_v1alpha1.ComponentSpec{Type: "configuration.redis"}})
+		assert.Error(t, err)
+	})
+}
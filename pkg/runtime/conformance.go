@@ -0,0 +1,195 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/components"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/conformance"
+)
+
+// ConformanceMode returns true if the runtime was started to certify components instead of serving requests
+func (a *DaprRuntime) ConformanceMode() bool {
+	return a.runtimeConfig.CertifyStateStores || a.runtimeConfig.ConformanceComponent != ""
+}
+
+// WriteConformanceReport writes a conformance report in the configured report format
+func (a *DaprRuntime) WriteConformanceReport(w io.Writer, report *conformance.Report) error {
+	return report.Write(w, a.runtimeConfig.ConformanceReportFormat)
+}
+
+// RunConformance loads the configured components and runs the conformance checks against them.
+// No servers are started and the app channel is not opened.
+func (a *DaprRuntime) RunConformance(opts ...Option) (*conformance.Report, error) {
+	var o runtimeOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	a.stateStoreRegistry.Register(o.states...)
+	a.pubSubRegistry.Register(o.pubsubs...)
+	a.bindingsRegistry.RegisterInputBindings(o.inputBindings...)
+	a.bindingsRegistry.RegisterOutputBindings(o.outputBindings...)
+
+	report := &conformance.Report{}
+	if a.runtimeConfig.ConformanceComponent != "" {
+		err := a.runComponentConformance(a.runtimeConfig.ConformanceComponent, report)
+		if err != nil {
+			return nil, err
+		}
+		return report, nil
+	}
+
+	err := a.establishSecurity(a.runtimeConfig.SentryServiceAddress)
+	if err != nil {
+		return nil, err
+	}
+	a.namespace = a.getNamespace()
+	a.operatorClient, err = a.getOperatorClient()
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.loadComponents(&o)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.runtimeConfig.CertifyStateStores {
+		err = a.initState(a.stateStoreRegistry)
+		if err != nil {
+			return nil, err
+		}
+		a.certifyStateStores(report)
+	}
+	return report, nil
+}
+
+func (a *DaprRuntime) certifyStateStores(report *conformance.Report) {
+	names := make([]string, 0, len(a.stateStores))
+	for name := range a.stateStores {
+		if _, isAlias := a.stateStoreAliases[name]; !isAlias {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		log.Warn("no state stores found to certify")
+	}
+
+	for _, name := range names {
+		log.Infof("running state store checks against %s", name)
+		conformance.CheckStateStore(name, a.stateStores[name], stateConformanceOptions(a.stateFeatures[name]), report)
+	}
+}
+
+// stateConformanceOptions returns the options of the state store checks, with the checks of the store features enabled
+func stateConformanceOptions(features []string) conformance.StateOptions {
+	opts := conformance.DefaultStateOptions()
+	opts.TTL = contains(features, components.FeatureTTL)
+	return opts
+}
+
+// runComponentConformance loads a single component from file and runs the conformance suite of its building block
+func (a *DaprRuntime) runComponentConformance(file string, report *conformance.Report) error {
+	loader := components.NewStandaloneComponents(a.runtimeConfig.Standalone)
+	comps, err := loader.LoadComponentsFromFile(file)
+	if err != nil {
+		return fmt.Errorf("error loading component file %s: %s", file, err)
+	}
+	if len(comps) != 1 {
+		return fmt.Errorf("expected a single component in %s, found %d", file, len(comps))
+	}
+
+	c := comps[0]
+	log.Infof("running conformance suite against %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
+
+	switch {
+	case strings.Index(c.Spec.Type, "state") == 0:
+		return a.runStateConformance(c, report)
+	case strings.Index(c.Spec.Type, "pubsub") == 0:
+		return a.runPubSubConformance(c, report)
+	case strings.Index(c.Spec.Type, "bindings") == 0:
+		return a.runBindingsConformance(c, report)
+	default:
+		return fmt.Errorf("no conformance suite for component type %s", c.Spec.Type)
+	}
+}
+
+func (a *DaprRuntime) runStateConformance(c components_v1alpha1.Component, report *conformance.Report) error {
+	store, err := a.stateStoreRegistry.CreateStateStore(c.Spec.Type, c.Spec.Version)
+	if err != nil {
+		return err
+	}
+	err = store.Init(state.Metadata{
+		Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
+	})
+	if err != nil {
+		return fmt.Errorf("error initializing state store %s: %s", c.Spec.Type, err)
+	}
+
+	opts := stateConformanceOptions(state_loader.Features(c.Spec.Type, store))
+	conformance.CheckStateStore(c.ObjectMeta.Name, store, opts, report)
+	return nil
+}
+
+func (a *DaprRuntime) runPubSubConformance(c components_v1alpha1.Component, report *conformance.Report) error {
+	ps, err := a.pubSubRegistry.Create(c.Spec.Type, c.Spec.Version)
+	if err != nil {
+		return err
+	}
+
+	properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
+	properties["consumerID"] = a.runtimeConfig.ID
+	err = ps.Init(pubsub.Metadata{
+		Properties: properties,
+	})
+	if err != nil {
+		return fmt.Errorf("error initializing pub sub %s: %s", c.Spec.Type, err)
+	}
+
+	conformance.CheckPubSub(c.ObjectMeta.Name, ps, conformance.DefaultPubSubOptions(), report)
+	return nil
+}
+
+func (a *DaprRuntime) runBindingsConformance(c components_v1alpha1.Component, report *conformance.Report) error {
+	metadata := bindings.Metadata{
+		Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
+		Name:       c.ObjectMeta.Name,
+	}
+
+	var output bindings.OutputBinding
+	var input bindings.InputBinding
+
+	if b, err := a.bindingsRegistry.CreateOutputBinding(c.Spec.Type, c.Spec.Version); err == nil {
+		if err = b.Init(metadata); err != nil {
+			return fmt.Errorf("error initializing output binding %s: %s", c.Spec.Type, err)
+		}
+		output = b
+	}
+	if b, err := a.bindingsRegistry.CreateInputBinding(c.Spec.Type, c.Spec.Version); err == nil {
+		if err = b.Init(metadata); err != nil {
+			return fmt.Errorf("error initializing input binding %s: %s", c.Spec.Type, err)
+		}
+		input = b
+	}
+	if output == nil && input == nil {
+		return fmt.Errorf("couldn't find binding %s", c.Spec.Type)
+	}
+
+	conformance.CheckBindings(c.ObjectMeta.Name, output, input, 0, report)
+	return nil
+}
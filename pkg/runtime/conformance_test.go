@@ -0,0 +1,26 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/dapr/dapr/pkg/components"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateConformanceOptions(t *testing.T) {
+	t.Run("ttl check for stores expiring keys", func(t *testing.T) {
+		opts := stateConformanceOptions([]string{components.FeatureETag, components.FeatureTTL})
+		assert.True(t, opts.TTL)
+	})
+
+	t.Run("no ttl check for other stores", func(t *testing.T) {
+		opts := stateConformanceOptions([]string{components.FeatureETag})
+		assert.False(t, opts.TTL)
+		assert.False(t, stateConformanceOptions(nil).TTL)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -20,25 +22,59 @@ func GetHostAddress() (string, error) {
 
 	// Use udp so no handshake is made.
 	// Any IP can be used, since connection is not established, but we used a known DNS IP.
-	conn, err := net.Dial("udp", "8.8.8.8:80")
-	if err != nil {
-		// Could not find one via a  UDP connection, so we fallback to the "old" way: try first non-loopback IPv4:
-		addrs, err := net.InterfaceAddrs()
+	// The IPv6 address of the same DNS is tried for hosts without IPv4 connectivity.
+	for _, dnsAddress := range []string{"8.8.8.8:80", "[2001:4860:4860::8888]:80"} {
+		conn, err := net.Dial("udp", dnsAddress)
 		if err != nil {
-			return "", fmt.Errorf("error getting interface IP addresses: %s", err)
+			continue
 		}
+		defer conn.Close()
+		return conn.LocalAddr().(*net.UDPAddr).IP.String(), nil
+	}
 
-		for _, addr := range addrs {
-			if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
-				if ipnet.IP.To4() != nil {
-					return ipnet.IP.String(), nil
-				}
+	// Could not find one via a  UDP connection, so we fallback to the "old" way: try first non-loopback IPv4,
+	// then the first global unicast IPv6 address:
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "", fmt.Errorf("error getting interface IP addresses: %s", err)
+	}
+
+	var ipv6 string
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
+			if ipnet.IP.To4() != nil {
+				return ipnet.IP.String(), nil
+			}
+			if ipv6 == "" && ipnet.IP.IsGlobalUnicast() {
+				ipv6 = ipnet.IP.String()
 			}
 		}
+	}
+	if ipv6 != "" {
+		return ipv6, nil
+	}
+
+	return "", errors.New("could not determine host IP address")
+}
 
-		return "", errors.New("could not determine host IP address")
+// splitAdvertiseAddress returns the host and port other sidecars use to reach the internal gRPC server.
+// The address may omit the port, in which case the internal gRPC port is advertised. An empty address advertises the host address.
+func splitAdvertiseAddress(address, hostAddress string, port int) (string, int, error) {
+	if address == "" {
+		return hostAddress, port, nil
 	}
 
-	defer conn.Close()
-	return conn.LocalAddr().(*net.UDPAddr).IP.String(), nil
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		// no port given, IPv6 addresses may still be in brackets
+		return strings.TrimSuffix(strings.TrimPrefix(address, "["), "]"), port, nil
+	}
+	advertisePort, err := strconv.Atoi(portStr)
+	if err != nil || advertisePort <= 0 {
+		return "", 0, fmt.Errorf("invalid port in advertise address %s", address)
+	}
+	if host == "" {
+		host = hostAddress
+	}
+	return host, advertisePort, nil
 }
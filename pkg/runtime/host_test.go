@@ -24,3 +24,43 @@ func TestGetHostAdress(t *testing.T) {
 		assert.NotEmpty(t, address)
 	})
 }
+
+func TestSplitAdvertiseAddress(t *testing.T) {
+	t.Run("defaults to host address and internal port", func(t *testing.T) {
+		host, port, err := splitAdvertiseAddress("", "10.0.0.1", 50002)
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.1", host)
+		assert.Equal(t, 50002, port)
+	})
+
+	t.Run("host only", func(t *testing.T) {
+		host, port, err := splitAdvertiseAddress("192.168.1.10", "10.0.0.1", 50002)
+		assert.NoError(t, err)
+		assert.Equal(t, "192.168.1.10", host)
+		assert.Equal(t, 50002, port)
+	})
+
+	t.Run("host and port", func(t *testing.T) {
+		host, port, err := splitAdvertiseAddress("node1.example.com:30002", "10.0.0.1", 50002)
+		assert.NoError(t, err)
+		assert.Equal(t, "node1.example.com", host)
+		assert.Equal(t, 30002, port)
+	})
+
+	t.Run("ipv6 with and without port", func(t *testing.T) {
+		host, port, err := splitAdvertiseAddress("[fd00::10]:30002", "10.0.0.1", 50002)
+		assert.NoError(t, err)
+		assert.Equal(t, "fd00::10", host)
+		assert.Equal(t, 30002, port)
+
+		host, port, err = splitAdvertiseAddress("fd00::10", "10.0.0.1", 50002)
+		assert.NoError(t, err)
+		assert.Equal(t, "fd00::10", host)
+		assert.Equal(t, 50002, port)
+	})
+
+	t.Run("invalid port", func(t *testing.T) {
+		_, _, err := splitAdvertiseAddress("10.0.0.2:http", "10.0.0.1", 50002)
+		assert.Error(t, err)
+	})
+}
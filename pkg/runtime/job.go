@@ -0,0 +1,78 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// PodNameEnvVar is the environment variable of the name of the pod of the sidecar
+	PodNameEnvVar = "POD_NAME"
+
+	appContainerPollInterval = 2 * time.Second
+)
+
+// AppExited returns a channel that receives the exit code of the sidecar when the app container of a Job pod
+// terminated, so that the pod completes. It's nil when the sidecar doesn't watch an app container.
+func (a *DaprRuntime) AppExited() <-chan int {
+	return a.appExited
+}
+
+// watchAppContainer polls the status of the pod of the sidecar until the app container terminated.
+// The service account of the pod needs permission to get its pod.
+func (a *DaprRuntime) watchAppContainer() {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		log.Warnf("failed to watch app container %s: %s", a.runtimeConfig.AppContainer, err)
+		return
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Warnf("failed to watch app container %s: %s", a.runtimeConfig.AppContainer, err)
+		return
+	}
+
+	podName := os.Getenv(PodNameEnvVar)
+	ticker := time.NewTicker(appContainerPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		pod, err := client.CoreV1().Pods(a.namespace).Get(podName, meta_v1.GetOptions{})
+		if err != nil {
+			log.Debugf("failed to get pod %s: %s", podName, err)
+			continue
+		}
+		if code, exited := appContainerExitCode(pod, a.runtimeConfig.AppContainer); exited {
+			log.Infof("app container %s terminated, exiting with code %v", a.runtimeConfig.AppContainer, code)
+			a.appExited <- code
+			return
+		}
+	}
+}
+
+// appContainerExitCode returns the exit code of the sidecar if the app container terminated for good:
+// 0 when the app succeeded, and 1 when the app failed and the pod doesn't restart it
+func appContainerExitCode(pod *corev1.Pod, container string) (int, bool) {
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name != container || s.State.Terminated == nil {
+			continue
+		}
+		if s.State.Terminated.ExitCode == 0 {
+			return 0, true
+		}
+		if pod.Spec.RestartPolicy == corev1.RestartPolicyNever {
+			return 1, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,49 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAppContainerExitCode(t *testing.T) {
+	pod := func(restartPolicy corev1.RestartPolicy, terminated *corev1.ContainerStateTerminated) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{RestartPolicy: restartPolicy},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "daprd", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					{Name: "app", State: corev1.ContainerState{Terminated: terminated}},
+				},
+			},
+		}
+	}
+
+	t.Run("app running", func(t *testing.T) {
+		_, exited := appContainerExitCode(pod(corev1.RestartPolicyNever, nil), "app")
+		assert.False(t, exited)
+	})
+
+	t.Run("app succeeded", func(t *testing.T) {
+		code, exited := appContainerExitCode(pod(corev1.RestartPolicyOnFailure, &corev1.ContainerStateTerminated{ExitCode: 0}), "app")
+		assert.True(t, exited)
+		assert.Equal(t, 0, code)
+	})
+
+	t.Run("app failed and is restarted", func(t *testing.T) {
+		_, exited := appContainerExitCode(pod(corev1.RestartPolicyOnFailure, &corev1.ContainerStateTerminated{ExitCode: 2}), "app")
+		assert.False(t, exited)
+	})
+
+	t.Run("app failed and is not restarted", func(t *testing.T) {
+		code, exited := appContainerExitCode(pod(corev1.RestartPolicyNever, &corev1.ContainerStateTerminated{ExitCode: 2}), "app")
+		assert.True(t, exited)
+		assert.Equal(t, 1, code)
+	})
+}
@@ -2,7 +2,9 @@ package runtime
 
 import (
 	"github.com/dapr/dapr/pkg/components/bindings"
+	"github.com/dapr/dapr/pkg/components/configuration"
 	"github.com/dapr/dapr/pkg/components/exporters"
+	"github.com/dapr/dapr/pkg/components/middleware/actor"
 	"github.com/dapr/dapr/pkg/components/middleware/http"
 	"github.com/dapr/dapr/pkg/components/pubsub"
 	"github.com/dapr/dapr/pkg/components/secretstores"
@@ -14,6 +16,7 @@ type (
 	// runtimeOpts encapsulates the components to include in the runtime.
 	runtimeOpts struct {
 		secretStores     []secretstores.SecretStore
+		configurations   []configuration.Configuration
 		states           []state.State
 		pubsubs          []pubsub.PubSub
 		exporters        []exporters.Exporter
@@ -21,6 +24,7 @@ type (
 		inputBindings    []bindings.InputBinding
 		outputBindings   []bindings.OutputBinding
 		httpMiddleware   []http.Middleware
+		actorMiddleware  []actor.Middleware
 	}
 
 	// Option is a function that customizes the runtime.
@@ -34,6 +38,13 @@ func WithSecretStores(secretStores ...secretstores.SecretStore) Option {
 	}
 }
 
+// WithConfigurations adds configuration store components to the runtime.
+func WithConfigurations(configurations ...configuration.Configuration) Option {
+	return func(o *runtimeOpts) {
+		o.configurations = append(o.configurations, configurations...)
+	}
+}
+
 // WithStates adds state store components to the runtime.
 func WithStates(states ...state.State) Option {
 	return func(o *runtimeOpts) {
@@ -82,3 +93,10 @@ func WithHTTPMiddleware(httpMiddleware ...http.Middleware) Option {
 		o.httpMiddleware = append(o.httpMiddleware, httpMiddleware...)
 	}
 }
+
+// WithActorMiddleware adds actor middleware components to the runtime.
+func WithActorMiddleware(actorMiddleware ...actor.Middleware) Option {
+	return func(o *runtimeOpts) {
+		o.actorMiddleware = append(o.actorMiddleware, actorMiddleware...)
+	}
+}
@@ -12,7 +12,10 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -28,24 +31,34 @@ import (
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/actors"
 	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/bulkhead"
 	"github.com/dapr/dapr/pkg/channel"
 	http_channel "github.com/dapr/dapr/pkg/channel/http"
 	"github.com/dapr/dapr/pkg/components"
 	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	configuration_loader "github.com/dapr/dapr/pkg/components/configuration"
+	configuration_inmemory "github.com/dapr/dapr/pkg/components/configuration/inmemory"
 	exporter_loader "github.com/dapr/dapr/pkg/components/exporters"
+	actor_middleware_loader "github.com/dapr/dapr/pkg/components/middleware/actor"
 	http_middleware_loader "github.com/dapr/dapr/pkg/components/middleware/http"
 	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
+	pubsub_inmemory "github.com/dapr/dapr/pkg/components/pubsub/inmemory"
 	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
 	servicediscovery_loader "github.com/dapr/dapr/pkg/components/servicediscovery"
 	state_loader "github.com/dapr/dapr/pkg/components/state"
+	state_inmemory "github.com/dapr/dapr/pkg/components/state/inmemory"
+	"github.com/dapr/dapr/pkg/concurrency"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/discovery"
 	"github.com/dapr/dapr/pkg/grpc"
 	"github.com/dapr/dapr/pkg/http"
+	"github.com/dapr/dapr/pkg/leadership"
 	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/memorybudget"
 	"github.com/dapr/dapr/pkg/messaging"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	actor_middleware "github.com/dapr/dapr/pkg/middleware/actor"
 	http_middleware "github.com/dapr/dapr/pkg/middleware/http"
 	"github.com/dapr/dapr/pkg/modes"
 	"github.com/dapr/dapr/pkg/operator/client"
@@ -53,16 +66,27 @@ import (
 	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
 	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
 	"github.com/dapr/dapr/pkg/runtime/security"
+	"github.com/dapr/dapr/pkg/saga"
 	"github.com/dapr/dapr/pkg/scopes"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
 	appConfigEndpoint   = "dapr/config"
 	parallelConcurrency = "parallel"
 	actorStateStore     = "actorStateStore"
+
+	defaultDrainGracePeriod = 5 * time.Second
 )
 
 var log = logger.NewLogger("dapr.runtime")
@@ -81,17 +105,41 @@ type DaprRuntime struct {
 	exporterRegistry         exporter_loader.Registry
 	serviceDiscoveryRegistry servicediscovery_loader.Registry
 	stateStores              map[string]state.Store
+	stateWatchers            map[string]state_loader.Watcher
+	stateFeatures            map[string][]string
+	stateStoreAliases        map[string]string
 	actor                    actors.Actors
+	sagas                    *saga.Coordinator
 	bindingsRegistry         bindings_loader.Registry
 	inputBindings            map[string]bindings.InputBinding
 	outputBindings           map[string]bindings.OutputBinding
 	secretStores             map[string]secretstores.SecretStore
+	configurationRegistry    configuration_loader.Registry
+	configurationStores      map[string]configuration_loader.Store
 	pubSubRegistry           pubsub_loader.Registry
 	pubSub                   pubsub.PubSub
+	pubSubs                  map[string]pubsub.PubSub
+	deduplicator             *pubsub_loader.Deduplicator
+	exactlyOnce              *pubsub_loader.ExactlyOnce
+	maxRetryAfter            time.Duration
+	pauser                   *pubsub_loader.Pauser
+	appReady                 chan struct{}
+	appStartLock             sync.Mutex
+	appStarting              bool
+	appStartedFns            []func()
+	subscriptions            *pubsub_loader.Subscriptions
+	replays                  *pubsub_loader.Replays
+	transformer              *pubsub_loader.Transformer
+	bulkheads                bulkhead.Bulkheads
+	memoryBudget             *memorybudget.Budget
+	stopMemoryBudget         chan struct{}
 	servicediscoveryResolver servicediscovery.Resolver
 	json                     jsoniter.API
 	httpMiddlewareRegistry   http_middleware_loader.Registry
+	actorMiddlewareRegistry  actor_middleware_loader.Registry
 	hostAddress              string
+	advertiseHost            string
+	advertisePort            int
 	actorStateStoreName      string
 	actorStateStoreCount     int
 	authenticator            security.Authenticator
@@ -99,29 +147,60 @@ type DaprRuntime struct {
 	scopedPublishings        []string
 	allowedTopics            []string
 	daprHTTPAPI              http.API
+	internalServer           grpc.Server
 	operatorClient           operatorv1pb.OperatorClient
 	topicRoutes              map[string]string
+	topicTimeouts            map[string]time.Duration
+	appExited                chan int
+	shutdownRequested        chan struct{}
+	pubSubName               string
+	scopedSubscriptions      []string
+	streams                  *pubsub_loader.Streams
+	streamTopics             map[string]bool
+	streamsLock              sync.Mutex
+	shutdownOnce             sync.Once
+	componentsLock           sync.RWMutex
+	// extendedMetadata are the labels of the sidecar set through the HTTP and gRPC APIs
+	extendedMetadata sync.Map
 }
 
 // NewDaprRuntime returns a new runtime with the given runtime config and global config
 func NewDaprRuntime(runtimeConfig *Config, globalConfig *config.Configuration) *DaprRuntime {
+	// the events and responses the runtime decodes come from brokers and apps, which may add fields of their own,
+	// so the unknown field policy of the request envelopes doesn't apply to them
+	jsonSpec := globalConfig.Spec.JSONSpec
+	jsonSpec.UnknownFields = ""
 	return &DaprRuntime{
 		runtimeConfig:            runtimeConfig,
 		globalConfig:             globalConfig,
 		grpc:                     grpc.NewGRPCManager(runtimeConfig.Mode),
-		json:                     jsoniter.ConfigFastest,
+		json:                     config.NewJSONAPI(jsonSpec),
 		inputBindings:            map[string]bindings.InputBinding{},
 		outputBindings:           map[string]bindings.OutputBinding{},
 		secretStores:             map[string]secretstores.SecretStore{},
+		configurationStores:      map[string]configuration_loader.Store{},
 		stateStores:              map[string]state.Store{},
+		stateWatchers:            map[string]state_loader.Watcher{},
+		stateFeatures:            map[string][]string{},
+		stateStoreAliases:        map[string]string{},
+		pubSubs:                  map[string]pubsub.PubSub{},
+		pauser:                   pubsub_loader.NewPauser(),
+		subscriptions:            pubsub_loader.NewSubscriptions(),
+		replays:                  pubsub_loader.NewReplays(),
 		stateStoreRegistry:       state_loader.NewRegistry(),
 		bindingsRegistry:         bindings_loader.NewRegistry(),
 		pubSubRegistry:           pubsub_loader.NewRegistry(),
 		secretStoresRegistry:     secretstores_loader.NewRegistry(),
+		configurationRegistry:    configuration_loader.NewRegistry(),
 		exporterRegistry:         exporter_loader.NewRegistry(),
 		serviceDiscoveryRegistry: servicediscovery_loader.NewRegistry(),
 		httpMiddlewareRegistry:   http_middleware_loader.NewRegistry(),
+		actorMiddlewareRegistry:  actor_middleware_loader.NewRegistry(),
 		topicRoutes:              map[string]string{},
+		topicTimeouts:            map[string]time.Duration{},
+		shutdownRequested:        make(chan struct{}),
+		streams:                  pubsub_loader.NewStreams(),
+		streamTopics:             map[string]bool{},
 	}
 }
 
@@ -149,6 +228,11 @@ func (a *DaprRuntime) Run(opts ...Option) error {
 	d := time.Since(start).Seconds() * 1000
 	log.Infof("dapr initialized. Status: Running. Init Elapsed %vms", d)
 
+	if a.runtimeConfig.Mode == modes.KubernetesMode && a.runtimeConfig.AppContainer != "" {
+		a.appExited = make(chan int, 1)
+		go a.watchAppContainer()
+	}
+
 	if a.daprHTTPAPI != nil {
 		// gRPC server start failure is logged as Fatal in initRuntime method. Setting the status only when runtime is initialized.
 		a.daprHTTPAPI.MarkStatusAsReady()
@@ -173,7 +257,20 @@ func (a *DaprRuntime) getOperatorClient() (operatorv1pb.OperatorClient, error) {
 }
 
 func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
-	err := a.establishSecurity(a.runtimeConfig.SentryServiceAddress)
+	err := validateStartupSpec(a.startupSpec())
+	if err != nil {
+		return err
+	}
+
+	err = a.establishSecurity(a.runtimeConfig.SentryServiceAddress)
+	if err != nil {
+		return err
+	}
+	err = a.grpc.SetCompression(a.runtimeConfig.InternalGRPCCompression)
+	if err != nil {
+		return err
+	}
+	err = a.grpc.SetClientTimeouts(a.globalConfig.Spec.GRPCClientSpec)
 	if err != nil {
 		return err
 	}
@@ -185,19 +282,26 @@ func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
 
 	err = a.loadComponents(opts)
 	if err != nil {
-		log.Warnf("failed to load components: %s", err)
+		return err
 	}
 	err = a.beginComponentsUpdates()
 	if err != nil {
 		log.Warnf("failed to watch component updates: %s", err)
 	}
 
-	a.blockUntilAppIsReady()
+	err = a.waitForApp()
+	if err != nil {
+		return err
+	}
 
 	a.hostAddress, err = GetHostAddress()
 	if err != nil {
 		return fmt.Errorf("failed to determine host address: %s", err)
 	}
+	a.advertiseHost, a.advertisePort, err = splitAdvertiseAddress(a.runtimeConfig.InternalAdvertiseAddress, a.hostAddress, a.runtimeConfig.InternalGRPCPort)
+	if err != nil {
+		return err
+	}
 
 	err = a.createAppChannel()
 	if err != nil {
@@ -205,26 +309,44 @@ func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
 	}
 
 	a.loadAppConfiguration()
+	a.startAppStartWait()
+	a.startAppHealthWait()
 
-	// Register and initialize state stores
+	// Register and initialize state stores. The built-in in-memory store can be replaced by a registered one.
+	a.stateStoreRegistry.Register(state_loader.New("in-memory", func() state.Store {
+		return state_inmemory.NewStateStore()
+	}))
 	a.stateStoreRegistry.Register(opts.states...)
 	err = a.initState(a.stateStoreRegistry)
 	if err != nil {
-		log.Warnf("failed to init state: %s", err)
+		return err
 	}
 
-	// Register and initialize pub/sub
+	// Register and initialize pub/sub. The built-in in-memory pub/sub can be replaced by a registered one.
+	a.pubSubRegistry.Register(pubsub_loader.New("in-memory", func() pubsub.PubSub {
+		return pubsub_inmemory.NewPubSub()
+	}))
 	a.pubSubRegistry.Register(opts.pubsubs...)
 	err = a.initPubSub()
 	if err != nil {
-		log.Warnf("failed to init pubsub: %s", err)
+		return err
+	}
+
+	// Register and initialize configuration stores. The built-in in-memory store can be replaced by a registered one.
+	a.configurationRegistry.Register(configuration_loader.New("in-memory", func() configuration_loader.Store {
+		return configuration_inmemory.NewStore()
+	}))
+	a.configurationRegistry.Register(opts.configurations...)
+	err = a.initConfigurationStores()
+	if err != nil {
+		return err
 	}
 
 	// Register and initialize exporters
 	a.exporterRegistry.Register(opts.exporters...)
 	err = a.initExporters()
 	if err != nil {
-		log.Warnf("failed to init exporters: %s", err)
+		return err
 	}
 
 	// Register and initialize service discovery
@@ -237,13 +359,26 @@ func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
 	// Register and initialize bindings
 	a.bindingsRegistry.RegisterInputBindings(opts.inputBindings...)
 	a.bindingsRegistry.RegisterOutputBindings(opts.outputBindings...)
-	a.initBindings()
+	err = a.initBindings()
+	if err != nil {
+		return err
+	}
 	a.initDirectMessaging(a.servicediscoveryResolver)
 
+	err = a.waitForPlacement()
+	if err != nil {
+		return err
+	}
+	a.actorMiddlewareRegistry.Register(opts.actorMiddleware...)
 	err = a.initActors()
 	if err != nil {
 		log.Warnf("failed to init actors: %s", err)
 	}
+	a.afterAppStarted(a.registerAppActorTypes)
+	err = a.initSagas()
+	if err != nil {
+		log.Warnf("failed to init sagas: %s", err)
+	}
 
 	// Register and initialize HTTP middleware
 	a.httpMiddlewareRegistry.Register(opts.httpMiddleware...)
@@ -252,6 +387,15 @@ func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
 		log.Warnf("failed to build HTTP pipeline: %s", err)
 	}
 
+	a.bulkheads, err = bulkhead.NewBulkheads(a.globalConfig.Spec.Bulkheads)
+	if err != nil {
+		return err
+	}
+	err = a.initMemoryBudget()
+	if err != nil {
+		return err
+	}
+
 	// Create and start internal and external gRPC servers
 	grpcAPI := a.getGRPCAPI()
 	err = a.startGRPCAPIServer(grpcAPI, a.runtimeConfig.APIGRPCPort)
@@ -291,7 +435,7 @@ func (a *DaprRuntime) buildHTTPPipeline() (http_middleware.Pipeline, error) {
 					middlewareSpec.Name,
 					middlewareSpec.Type)
 			}
-			handler, err := a.httpMiddlewareRegistry.Create(middlewareSpec.Type,
+			handler, err := a.httpMiddlewareRegistry.Create(middlewareSpec.Type, component.Spec.Version,
 				middleware.Metadata{Properties: a.convertMetadataItemsToProperties(component.Spec.Metadata)})
 			if err != nil {
 				return http_middleware.Pipeline{}, err
@@ -303,26 +447,52 @@ func (a *DaprRuntime) buildHTTPPipeline() (http_middleware.Pipeline, error) {
 	return http_middleware.Pipeline{Handlers: handlers}, nil
 }
 
-func (a *DaprRuntime) initBindings() {
-	err := a.initOutputBindings(a.bindingsRegistry)
-	if err != nil {
-		log.Errorf("failed to init output bindings: %s", err)
+// buildActorPipeline returns the middleware pipeline of the actor method calls
+func (a *DaprRuntime) buildActorPipeline() (actor_middleware.Pipeline, error) {
+	var handlers []actor_middleware.Middleware
+
+	if a.globalConfig != nil {
+		for _, middlewareSpec := range a.globalConfig.Spec.ActorPipelineSpec.Handlers {
+			component := a.getComponent(middlewareSpec.Type, middlewareSpec.Name)
+			if component == nil {
+				return actor_middleware.Pipeline{}, fmt.Errorf("couldn't find middleware component with name %s and type %s",
+					middlewareSpec.Name,
+					middlewareSpec.Type)
+			}
+			handler, err := a.actorMiddlewareRegistry.Create(middlewareSpec.Type, component.Spec.Version,
+				middleware.Metadata{Properties: a.convertMetadataItemsToProperties(component.Spec.Metadata)})
+			if err != nil {
+				return actor_middleware.Pipeline{}, err
+			}
+			log.Infof("enabled %s actor middleware", middlewareSpec.Type)
+			handlers = append(handlers, handler)
+		}
 	}
+	return actor_middleware.Pipeline{Handlers: handlers}, nil
+}
 
-	err = a.initInputBindings(a.bindingsRegistry)
+func (a *DaprRuntime) initBindings() error {
+	err := a.initOutputBindings(a.bindingsRegistry)
 	if err != nil {
-		log.Errorf("failed to init input bindings: %s", err)
+		return err
 	}
+	return a.initInputBindings(a.bindingsRegistry)
 }
 
 func (a *DaprRuntime) beginReadInputBindings() error {
 	for key, b := range a.inputBindings {
-		go func(name string, binding bindings.InputBinding) {
-			err := a.readFromBinding(name, binding)
-			if err != nil {
-				log.Errorf("error reading from input binding %s: %s", name, err)
-			}
-		}(key, b)
+		name, binding := key, b
+		a.afterAppStarted(func() {
+			go func() {
+				if a.appReady != nil {
+					<-a.appReady
+				}
+				err := a.readFromBinding(name, binding)
+				if err != nil {
+					log.Errorf("error reading from input binding %s: %s", name, err)
+				}
+			}()
+		})
 	}
 
 	return nil
@@ -335,8 +505,9 @@ func (a *DaprRuntime) initDirectMessaging(resolver servicediscovery.Resolver) {
 		a.runtimeConfig.InternalGRPCPort,
 		a.runtimeConfig.Mode,
 		a.appChannel,
-		a.grpc.GetGRPCConnection,
+		a.grpc.GetGRPCConnectionWithPriority,
 		resolver,
+		a.globalConfig.Spec.InvocationSpec.PriorityClasses,
 		a.globalConfig.Spec.TracingSpec)
 }
 
@@ -346,7 +517,9 @@ func (a *DaprRuntime) beginComponentsUpdates() error {
 	}
 
 	go func() {
-		stream, err := a.operatorClient.ComponentUpdate(context.Background(), &empty.Empty{})
+		ctx, call := diag.StartControlPlaneCall(context.Background(), diag.ControlPlaneOperator, "ComponentUpdate", a.globalConfig.Spec.TracingSpec)
+		stream, err := a.operatorClient.ComponentUpdate(ctx, &empty.Empty{})
+		call.End(err)
 		if err != nil {
 			log.Errorf("error from operator stream: %s", err)
 			return
@@ -372,6 +545,10 @@ func (a *DaprRuntime) beginComponentsUpdates() error {
 }
 
 func (a *DaprRuntime) onComponentUpdated(component components_v1alpha1.Component) {
+	if !a.isResidencyAllowed(component) {
+		return
+	}
+
 	update := false
 
 	for i, c := range a.components {
@@ -391,35 +568,61 @@ func (a *DaprRuntime) onComponentUpdated(component components_v1alpha1.Component
 	}
 
 	if strings.Index(component.Spec.Type, "state") == 0 {
-		store, err := a.stateStoreRegistry.CreateStateStore(component.Spec.Type)
+		store, err := a.stateStoreRegistry.CreateStateStore(component.Spec.Type, component.Spec.Version)
 		if err != nil {
 			log.Errorf("error creating state store: %s", err)
 			return
 		}
 
-		err = store.Init(state.Metadata{
-			Properties: a.convertMetadataItemsToProperties(component.Spec.Metadata),
-		})
+		props := a.convertMetadataItemsToProperties(component.Spec.Metadata)
+		err = a.reportComponentStatus(component, store.Init(state.Metadata{
+			Properties: props,
+		}))
+		if err != nil {
+			log.Errorf("error on init state store: %s", err)
+			return
+		}
+		store, err = a.wrapStateStore(component, store, props)
 		if err != nil {
 			log.Errorf("error on init state store: %s", err)
 		} else {
-			a.stateStores[component.ObjectMeta.Name] = store
+			a.componentsLock.RLock()
+			old, ok := a.stateStores[component.ObjectMeta.Name]
+			a.componentsLock.RUnlock()
+			a.setStateStore(component.ObjectMeta.Name, store, props)
+			// the retries of the new store start once the retries of the replaced store on their write-ahead log stop
+			if w, found := state_loader.WriteBehind(old); ok && found {
+				w.Close()
+			}
+		}
+	} else if strings.Index(component.Spec.Type, "configuration") == 0 {
+		if err := a.reportComponentStatus(component, a.updateConfigurationStore(component)); err != nil {
+			log.Errorf("error on init configuration store: %s", err)
 		}
 	} else if strings.Index(component.Spec.Type, "bindings") == 0 {
 		//TODO: implement update for input bindings too
-		binding, err := a.bindingsRegistry.CreateOutputBinding(component.Spec.Type)
+		binding, err := a.bindingsRegistry.CreateOutputBinding(component.Spec.Type, component.Spec.Version)
 		if err != nil {
 			log.Errorf("failed to create output binding: %s", err)
 			return
 		}
 
-		err = binding.Init(bindings.Metadata{
-			Properties: a.convertMetadataItemsToProperties(component.Spec.Metadata),
+		properties := a.convertMetadataItemsToProperties(component.Spec.Metadata)
+		err = a.reportComponentStatus(component, binding.Init(bindings.Metadata{
+			Properties: properties,
 			Name:       component.ObjectMeta.Name,
-		})
-		if err == nil {
-			a.outputBindings[component.ObjectMeta.Name] = binding
+		}))
+		if err != nil {
+			return
+		}
+		policy, err := a.egressPolicy()
+		if err != nil {
+			log.Errorf("failed to update output binding: %s", err)
+			return
 		}
+		a.componentsLock.Lock()
+		a.outputBindings[component.ObjectMeta.Name] = bindings_loader.WithEgressPolicy(binding, properties, policy)
+		a.componentsLock.Unlock()
 	}
 }
 
@@ -449,7 +652,10 @@ func (a *DaprRuntime) sendBatchOutputBindingsSequential(to []string, data []byte
 }
 
 func (a *DaprRuntime) sendToOutputBinding(name string, req *bindings.WriteRequest) error {
-	if binding, ok := a.outputBindings[name]; ok {
+	a.componentsLock.RLock()
+	binding, ok := a.outputBindings[name]
+	a.componentsLock.RUnlock()
+	if ok {
 		err := binding.Write(req)
 		return err
 	}
@@ -486,10 +692,14 @@ func (a *DaprRuntime) onAppResponse(response *bindings.AppResponse) error {
 
 func (a *DaprRuntime) sendBindingEventToApp(bindingName string, data []byte, metadata map[string]string) error {
 	var response bindings.AppResponse
+	// TODO: Propagate Context
+	ctx := channel.WithOperation(context.Background(), channel.OperationBinding)
 
 	if a.runtimeConfig.ApplicationProtocol == GRPCProtocol {
+		ctx, cancel := context.WithTimeout(ctx, a.runtimeConfig.AppChannelTimeouts.Timeout(ctx))
+		defer cancel()
 		client := daprclientv1pb.NewDaprClientClient(a.grpc.AppClient)
-		resp, err := client.OnBindingEvent(context.Background(), &daprclientv1pb.BindingEventEnvelope{
+		resp, err := client.OnBindingEvent(ctx, &daprclientv1pb.BindingEventEnvelope{
 			Name: bindingName,
 			Data: &any.Any{
 				Value: data,
@@ -525,8 +735,9 @@ func (a *DaprRuntime) sendBindingEventToApp(bindingName string, data []byte, met
 		req := invokev1.NewInvokeMethodRequest(bindingName)
 		req.WithHTTPExtension(nethttp.MethodPost, "")
 		req.WithRawData(data, invokev1.JSONContentType)
-		// TODO: Propagate Context
-		ctx := context.Background()
+		if partition, ok := metadata[bindings_loader.PartitionIDHeader]; ok {
+			req.WithMetadata(map[string][]string{bindings_loader.PartitionIDHeader: {partition}})
+		}
 		resp, err := a.appChannel.InvokeMethod(ctx, req)
 		if err != nil {
 			return fmt.Errorf("error invoking app: %s", err)
@@ -564,30 +775,109 @@ func (a *DaprRuntime) readFromBinding(name string, binding bindings.InputBinding
 	return err
 }
 
+// initMemoryBudget checks the memory used by the sidecar against its budget. Under pressure the caches are flushed,
+// and when critical the delivery of the events of every topic is paused until the pressure drops.
+func (a *DaprRuntime) initMemoryBudget() error {
+	spec := a.globalConfig.Spec.MemoryBudgetSpec
+	budget, err := memorybudget.New(spec)
+	if err != nil || budget == nil {
+		return err
+	}
+
+	budget.OnShrink(a.flushCaches)
+	budget.OnLevelChange(func(from, to memorybudget.Level, used uint64) {
+		if to == memorybudget.LevelCritical && a.pauser.PauseAll() {
+			log.Warn("paused the delivery of every topic under critical memory pressure")
+		} else if to < memorybudget.LevelCritical && a.pauser.ResumeAll() {
+			log.Info("resumed the delivery of every topic")
+		}
+	})
+	if spec.Topic != "" {
+		if publishFn := a.getPublishAdapter(); publishFn != nil {
+			memorybudget.PublishEvents(budget, spec.Topic, a.runtimeConfig.ID, publishFn)
+		} else {
+			log.Warnf("a pub/sub component is required to publish memory pressure events to topic %s", spec.Topic)
+		}
+	}
+
+	a.memoryBudget = budget
+	a.stopMemoryBudget = make(chan struct{})
+	go budget.Run(a.stopMemoryBudget)
+	log.Infof("memory budget of %d bytes enabled", budget.Limit())
+	return nil
+}
+
+// flushCaches empties the caches of the name resolver and of the schemas of the pub/subs
+func (a *DaprRuntime) flushCaches() {
+	if r, ok := a.servicediscoveryResolver.(*discovery.DNSResolver); ok {
+		r.Flush()
+	}
+	for _, ps := range a.pubSubs {
+		pubsub_loader.FlushSchemas(ps)
+	}
+}
+
 func (a *DaprRuntime) startHTTPServer(port, profilePort int, allowedOrigins string, pipeline http_middleware.Pipeline) {
-	a.daprHTTPAPI = http.NewAPI(a.runtimeConfig.ID, a.appChannel, a.directMessaging, a.stateStores, a.secretStores, a.getPublishAdapter(), a.actor, a.sendToOutputBinding, a.globalConfig.Spec.TracingSpec)
+	a.daprHTTPAPI = http.NewAPI(a.runtimeConfig.ID, a.appChannel, a.directMessaging, a.stateStores, a.secretStores, &a.componentsLock, &a.extendedMetadata, a.getPublishAdapter(), a.actor, a.sendToOutputBinding, a.sagas, a.pauser, a.subscriptions, a.replayTopic, a.ConfigDump, a.ComponentCapabilities, a.globalConfig.Spec.JSONSpec, a.globalConfig.Spec.TracingSpec)
 	serverConf := http.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port, profilePort, allowedOrigins, a.runtimeConfig.EnableProfiling)
+	serverConf.ListenAddresses = a.runtimeConfig.ListenAddresses
+	serverConf.Pipe = a.runtimeConfig.HTTPPipe
+	serverConf.Bulkheads = a.bulkheads
+	serverConf.MemoryBudget = a.memoryBudget
 
 	server := http.NewServer(a.daprHTTPAPI, serverConf, a.globalConfig.Spec.TracingSpec, pipeline)
 	server.StartNonBlocking()
 }
 
+// invokePayloadLimits returns the limits of the Any payloads of the invocations from other sidecars
+func (a *DaprRuntime) invokePayloadLimits() invokev1.AnyLimits {
+	spec := a.globalConfig.Spec.GRPCServerSpec.InvokePayload
+	limits := invokev1.AnyLimits{MaxDepth: spec.MaxDepth}
+	if spec.MaxSize != "" {
+		if size, err := resource.ParseQuantity(spec.MaxSize); err == nil {
+			limits.MaxSize = int(size.Value())
+		}
+	}
+	return limits
+}
+
 func (a *DaprRuntime) startGRPCInternalServer(api grpc.API, port int) error {
 	serverConf := grpc.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port)
+	serverConf.Limits = a.globalConfig.Spec.GRPCServerSpec.Internal
+	serverConf.ListenAddresses = a.runtimeConfig.ListenAddresses
+	serverConf.EnableChannelz = a.runtimeConfig.EnableInternalGRPCChannelz
+	serverConf.Bulkheads = a.bulkheads
+	serverConf.MemoryBudget = a.memoryBudget
+	serverConf.CallLocal = a.globalConfig.Spec.GRPCServerSpec.CallLocal
+	serverConf.AnyLimits = a.invokePayloadLimits()
+	if a.globalConfig.Spec.InvocationSpec.GRPCProxy {
+		serverConf.Proxy = grpc.NewInternalProxyDirector(a.grpc.AppClient)
+	}
 	server := grpc.NewInternalServer(api, serverConf, a.globalConfig.Spec.TracingSpec, a.authenticator)
-	err := server.StartNonBlocking()
-	return err
+	if err := server.StartNonBlocking(); err != nil {
+		return err
+	}
+	a.internalServer = server
+	return nil
 }
 
 func (a *DaprRuntime) startGRPCAPIServer(api grpc.API, port int) error {
 	serverConf := grpc.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port)
+	serverConf.Limits = a.globalConfig.Spec.GRPCServerSpec.API
+	serverConf.ListenAddresses = a.runtimeConfig.ListenAddresses
+	serverConf.Pipe = a.runtimeConfig.APIGRPCPipe
+	serverConf.Bulkheads = a.bulkheads
+	serverConf.MemoryBudget = a.memoryBudget
+	if a.globalConfig.Spec.InvocationSpec.GRPCProxy {
+		serverConf.Proxy = grpc.NewAPIProxyDirector(a.runtimeConfig.ID, a.grpc.AppClient, a.directMessaging)
+	}
 	server := grpc.NewAPIServer(api, serverConf, a.globalConfig.Spec.TracingSpec)
 	err := server.StartNonBlocking()
 	return err
 }
 
 func (a *DaprRuntime) getGRPCAPI() grpc.API {
-	return grpc.NewAPI(a.runtimeConfig.ID, a.appChannel, a.stateStores, a.secretStores, a.getPublishAdapter(), a.directMessaging, a.actor, a.sendToOutputBinding, a.globalConfig.Spec.TracingSpec)
+	return grpc.NewAPI(a.runtimeConfig.ID, a.appChannel, a.stateStores, a.stateWatchers, a.secretStores, a.configurationStores, &a.componentsLock, &a.extendedMetadata, a.getPublishAdapter(), a.subscribeStream, a.directMessaging, a.actor, a.sendToOutputBinding, a.ComponentCapabilities, a.Shutdown, a.globalConfig.Spec.JSONSpec, a.globalConfig.Spec.TracingSpec)
 }
 
 func (a *DaprRuntime) getPublishAdapter() func(*pubsub.PublishRequest) error {
@@ -632,7 +922,8 @@ func (a *DaprRuntime) isAppSubscribedToBinding(binding string, bindingsList []st
 
 func (a *DaprRuntime) initInputBindings(registry bindings_loader.Registry) error {
 	if a.appChannel == nil {
-		return fmt.Errorf("app channel not initialized")
+		log.Warn("app channel not initialized, skipping input bindings")
+		return nil
 	}
 
 	bindingsList := []string{}
@@ -647,90 +938,141 @@ func (a *DaprRuntime) initInputBindings(registry bindings_loader.Registry) error
 				continue
 			}
 
-			binding, err := registry.CreateInputBinding(c.Spec.Type)
-			if err != nil {
-				log.Errorf("failed to create input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-				continue
+			component := c
+			init := func() error {
+				return a.reportComponentStatus(component, a.initInputBinding(registry, component))
 			}
-			err = binding.Init(bindings.Metadata{
-				Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
-				Name:       c.ObjectMeta.Name,
-			})
-			if err != nil {
-				log.Errorf("failed to init input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
-				continue
+			if err := init(); err != nil {
+				if err = a.handleComponentInitFailure(c, err, init); err != nil {
+					return err
+				}
 			}
-
-			log.Infof("successful init for input binding %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
-			a.inputBindings[c.ObjectMeta.Name] = binding
-			diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
 		}
 	}
 	return nil
 }
 
+func (a *DaprRuntime) initInputBinding(registry bindings_loader.Registry, c components_v1alpha1.Component) error {
+	binding, err := registry.CreateInputBinding(c.Spec.Type, c.Spec.Version)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
+		return fmt.Errorf("failed to create input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+	}
+	properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
+	err = binding.Init(bindings.Metadata{
+		Properties: properties,
+		Name:       c.ObjectMeta.Name,
+	})
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("failed to init input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+	}
+
+	singleton, err := a.singletonInputBinding(c.ObjectMeta.Name, binding, properties)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("failed to init input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+	}
+
+	log.Infof("successful init for input binding %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
+	a.componentsLock.Lock()
+	a.inputBindings[c.ObjectMeta.Name] = bindings_loader.WithPartitionOrdering(singleton, properties)
+	a.componentsLock.Unlock()
+	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+	return nil
+}
+
+// singletonInputBinding returns the input binding read by the one sidecar of the app elected with leases in the state
+// store named by the component, if any
+func (a *DaprRuntime) singletonInputBinding(name string, binding bindings.InputBinding, properties map[string]string) (bindings.InputBinding, error) {
+	storeName := properties[bindings_loader.SingletonStoreMetadataKey]
+	if storeName == "" {
+		return binding, nil
+	}
+	a.componentsLock.RLock()
+	store, ok := a.stateStores[storeName]
+	a.componentsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("state store %s electing the sidecar reading the binding not found", storeName)
+	}
+	if !components.HasFeature(a.ComponentCapabilities(), storeName, components.FeatureInsert) {
+		return nil, fmt.Errorf("state store %s electing the sidecar reading the binding can't create leases only if they don't exist", storeName)
+	}
+
+	ttl := leadership.DefaultTTL
+	if val := properties[bindings_loader.SingletonTTLMetadataKey]; val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", bindings_loader.SingletonTTLMetadataKey, val)
+		}
+		ttl = d
+	}
+
+	election := fmt.Sprintf("%s||bindings||%s", a.runtimeConfig.ID, name)
+	candidate := net.JoinHostPort(a.advertiseHost, strconv.Itoa(a.advertisePort))
+	return bindings_loader.WithSingleton(binding, leadership.NewElector(store), election, candidate, ttl), nil
+}
+
 func (a *DaprRuntime) initOutputBindings(registry bindings_loader.Registry) error {
 	for _, c := range a.components {
 		if strings.Index(c.Spec.Type, "bindings") == 0 {
-			binding, err := registry.CreateOutputBinding(c.Spec.Type)
-			if err != nil {
-				log.Errorf("failed to create output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-				continue
+			component := c
+			init := func() error {
+				return a.reportComponentStatus(component, a.initOutputBinding(registry, component))
 			}
-
-			if binding != nil {
-				err := binding.Init(bindings.Metadata{
-					Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
-					Name:       c.ObjectMeta.Name,
-				})
-				if err != nil {
-					log.Errorf("failed to init output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
-					diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
-					continue
+			if err := init(); err != nil {
+				if err = a.handleComponentInitFailure(c, err, init); err != nil {
+					return err
 				}
-				log.Infof("successful init for output binding %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
-				a.outputBindings[c.ObjectMeta.Name] = binding
-				diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
 			}
 		}
 	}
 	return nil
 }
 
+func (a *DaprRuntime) initOutputBinding(registry bindings_loader.Registry, c components_v1alpha1.Component) error {
+	binding, err := registry.CreateOutputBinding(c.Spec.Type, c.Spec.Version)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
+		return fmt.Errorf("failed to create output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+	}
+
+	if binding != nil {
+		properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
+		err := binding.Init(bindings.Metadata{
+			Properties: properties,
+			Name:       c.ObjectMeta.Name,
+		})
+		if err != nil {
+			diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+			return fmt.Errorf("failed to init output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+		}
+		policy, err := a.egressPolicy()
+		if err != nil {
+			diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+			return fmt.Errorf("failed to init output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+		}
+		log.Infof("successful init for output binding %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
+		a.componentsLock.Lock()
+		a.outputBindings[c.ObjectMeta.Name] = bindings_loader.WithEgressPolicy(binding, properties, policy)
+		a.componentsLock.Unlock()
+		diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+	}
+	return nil
+}
+
 // Refer for state store api decision  https://github.com/dapr/dapr/blob/master/docs/decision_records/api/API-008-multi-state-store-api-design.md
 func (a *DaprRuntime) initState(registry state_loader.Registry) error {
 	for _, s := range a.components {
 		if strings.Index(s.Spec.Type, "state") == 0 {
-			store, err := registry.CreateStateStore(s.Spec.Type)
-			if err != nil {
-				log.Warnf("error creating state store %s: %s", s.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "creation")
-				continue
+			component := s
+			init := func() error {
+				return a.reportComponentStatus(component, a.initStateStore(registry, component))
 			}
-			if store != nil {
-				props := a.convertMetadataItemsToProperties(s.Spec.Metadata)
-				err := store.Init(state.Metadata{
-					Properties: props,
-				})
-				if err != nil {
-					diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "init")
-					log.Warnf("error initializing state store %s: %s", s.Spec.Type, err)
-					continue
+			if err := init(); err != nil {
+				if err = a.handleComponentInitFailure(s, err, init); err != nil {
+					return err
 				}
-
-				a.stateStores[s.ObjectMeta.Name] = store
-
-				// set specified actor store if "actorStateStore" is true in the spec.
-				actorStoreSpecified := props[actorStateStore]
-				if actorStoreSpecified == "true" {
-					if a.actorStateStoreCount++; a.actorStateStoreCount == 1 {
-						a.actorStateStoreName = s.ObjectMeta.Name
-					}
-				}
-				diag.DefaultMonitoring.ComponentInitialized(s.Spec.Type)
 			}
 		}
 	}
@@ -742,10 +1084,156 @@ func (a *DaprRuntime) initState(registry state_loader.Registry) error {
 	return nil
 }
 
-func (a *DaprRuntime) getTopicRoutes() map[string]string {
+// wrapStateStore adds the versioning, write-behind queue and JSON schema validation enabled in the component metadata to
+// the store.
+// It also sets up the watcher of the keys of the store.
+func (a *DaprRuntime) wrapStateStore(c components_v1alpha1.Component, store state.Store, props map[string]string) (state.Store, error) {
+	name := c.ObjectMeta.Name
+	features := state_loader.Features(c.Spec.Type, store)
+	if state_loader.DeleteByPrefixEnabled(store, props) {
+		features = append(features, components.FeatureDeleteByPrefix)
+	}
+	watcher, err := state_loader.NewWatcher(store, props)
+	if err != nil {
+		return nil, err
+	}
+	store, err = state_loader.WithVersioning(store, props)
+	if err != nil {
+		return nil, err
+	}
+	store, err = state_loader.WithWriteBehind(store, props, a.walDir("state", name))
+	if err != nil {
+		return nil, err
+	}
+	// the retries of the queue stop when the store isn't registered, so that those of the store it replaces go on
+	writeBehind, _ := state_loader.WriteBehind(store)
+	defer func() {
+		if err != nil && writeBehind != nil {
+			writeBehind.Close()
+		}
+	}()
+	store, err = state_loader.WithSchemaValidation(store, props)
+	if err != nil {
+		return nil, err
+	}
+	lookup := func(name string) (state.Store, bool) {
+		a.componentsLock.RLock()
+		defer a.componentsLock.RUnlock()
+		s, ok := a.stateStores[name]
+		return s, ok
+	}
+	store, err = state_loader.WithHedgedReads(store, props, name, lookup)
+	if err != nil {
+		return nil, err
+	}
+	store, err = state_loader.WithMirroring(store, props, name, lookup)
+	if err != nil {
+		return nil, err
+	}
+	a.componentsLock.Lock()
+	a.stateWatchers[name] = watcher
+	a.stateFeatures[name] = features
+	a.componentsLock.Unlock()
+	return store, nil
+}
+
+// walDir returns the dir of the write-ahead log of a component
+func (a *DaprRuntime) walDir(kind, name string) string {
+	walPath := a.runtimeConfig.WALPath
+	if walPath == "" {
+		walPath = DefaultWALPath
+	}
+	return filepath.Join(walPath, a.runtimeConfig.ID, kind, name)
+}
+
+func (a *DaprRuntime) initStateStore(registry state_loader.Registry, s components_v1alpha1.Component) error {
+	store, err := registry.CreateStateStore(s.Spec.Type, s.Spec.Version)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "creation")
+		return fmt.Errorf("error creating state store %s: %s", s.Spec.Type, err)
+	}
+	if store != nil {
+		props := a.convertMetadataItemsToProperties(s.Spec.Metadata)
+		err := store.Init(state.Metadata{
+			Properties: props,
+		})
+		if err != nil {
+			diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "init")
+			return fmt.Errorf("error initializing state store %s: %s", s.Spec.Type, err)
+		}
+		store, err = a.wrapStateStore(s, store, props)
+		if err != nil {
+			diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "init")
+			return fmt.Errorf("error initializing state store %s: %s", s.Spec.Type, err)
+		}
+
+		a.setStateStore(s.ObjectMeta.Name, store, props)
+
+		// set specified actor store if "actorStateStore" is true in the spec.
+		actorStoreSpecified := props[actorStateStore]
+		if actorStoreSpecified == "true" {
+			if a.actorStateStoreCount++; a.actorStateStoreCount == 1 {
+				a.actorStateStoreName = s.ObjectMeta.Name
+			}
+		}
+		diag.DefaultMonitoring.ComponentInitialized(s.Spec.Type)
+	}
+	return nil
+}
+
+// setStateStore registers the state store and its aliases. The background retries of the startup policies and the
+// component updates register the stores while the APIs read them.
+func (a *DaprRuntime) setStateStore(name string, store state.Store, props map[string]string) {
+	a.componentsLock.Lock()
+	defer a.componentsLock.Unlock()
+	a.stateStores[name] = store
+	a.registerStateStoreAliases(name, store, props)
+}
+
+// registerStateStoreAliases registers the state store under its aliases, and as the default store when marked so,
+// replacing its former aliases. An alias already taken by another store is ignored.
+func (a *DaprRuntime) registerStateStoreAliases(name string, store state.Store, props map[string]string) {
+	delete(a.stateStoreAliases, name)
+	for alias, owner := range a.stateStoreAliases {
+		if owner == name {
+			delete(a.stateStores, alias)
+			delete(a.stateStoreAliases, alias)
+		}
+	}
+	for _, alias := range state_loader.Aliases(name, props) {
+		if _, taken := a.stateStores[alias]; taken {
+			owner := a.stateStoreAliases[alias]
+			if alias == state_loader.DefaultStoreName {
+				log.Warnf("state store %s is not the default state store: %s is already the default", name, owner)
+			} else {
+				log.Warnf("alias %s of state store %s is ignored: the name is already taken", alias, name)
+			}
+			continue
+		}
+		a.stateStores[alias] = store
+		a.stateStoreAliases[alias] = name
+	}
+}
+
+// stateStoreAliasesOf returns the aliases of the state store
+func (a *DaprRuntime) stateStoreAliasesOf(name string) []string {
+	var aliases []string
+	for alias, owner := range a.stateStoreAliases {
+		if owner == name {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// getTopicRoutes returns the routes of the topics the app subscribes to, and the delivery timeouts of the subscriptions
+// that have one
+func (a *DaprRuntime) getTopicRoutes() (map[string]string, map[string]time.Duration) {
 	topicRoutes := map[string]string{}
+	topicTimeouts := map[string]time.Duration{}
 	if a.appChannel == nil {
-		return topicRoutes
+		return topicRoutes, topicTimeouts
 	}
 
 	var subscriptions []runtime_pubsub.Subscription
@@ -758,6 +1246,14 @@ func (a *DaprRuntime) getTopicRoutes() map[string]string {
 
 	for _, s := range subscriptions {
 		topicRoutes[s.Topic] = s.Route
+		if val := s.Metadata[channel.TimeoutMetadataKey]; val != "" {
+			timeout, err := time.ParseDuration(val)
+			if err != nil || timeout <= 0 {
+				log.Warnf("invalid timeout %s of the subscription to topic %s, using the pub/sub timeout", val, s.Topic)
+				continue
+			}
+			topicTimeouts[s.Topic] = timeout
+		}
 	}
 
 	if len(topicRoutes) > 0 {
@@ -767,69 +1263,185 @@ func (a *DaprRuntime) getTopicRoutes() map[string]string {
 		}
 		log.Infof("app is subscribed to the following topics: %v", topics)
 	}
-	return topicRoutes
+	return topicRoutes, topicTimeouts
+}
+
+// topicDeliveryContext returns the context of the deliveries of the messages of the topic to the app
+func (a *DaprRuntime) topicDeliveryContext(topic string) context.Context {
+	// TODO Propagate Context
+	ctx := channel.WithOperation(context.Background(), channel.OperationPubSub)
+	if timeout, ok := a.topicTimeouts[topic]; ok {
+		ctx = channel.WithRequestTimeout(ctx, timeout)
+	}
+	return ctx
 }
 
 func (a *DaprRuntime) initExporters() error {
 	for _, c := range a.components {
 		if strings.Index(c.Spec.Type, "exporter") == 0 {
-			exporter, err := a.exporterRegistry.Create(c.Spec.Type)
-			if err != nil {
-				log.Warnf("error creating exporter %s: %s", c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-				continue
+			component := c
+			init := func() error {
+				return a.reportComponentStatus(component, a.initExporter(component))
 			}
-
-			properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
-
-			err = exporter.Init(a.runtimeConfig.ID, a.hostAddress, exporters.Metadata{
-				Properties: properties,
-			})
-			if err != nil {
-				log.Warnf("error initializing exporter %s: %s", c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
-				continue
+			if err := init(); err != nil {
+				if err = a.handleComponentInitFailure(c, err, init); err != nil {
+					return err
+				}
 			}
-			diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
 		}
 	}
 	return nil
 }
 
-func (a *DaprRuntime) initPubSub() error {
-	var scopedSubscriptions []string
+func (a *DaprRuntime) initExporter(c components_v1alpha1.Component) error {
+	exporter, err := a.exporterRegistry.Create(c.Spec.Type, c.Spec.Version)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
+		return fmt.Errorf("error creating exporter %s: %s", c.Spec.Type, err)
+	}
 
-	for _, c := range a.components {
-		if strings.Index(c.Spec.Type, "pubsub") == 0 {
-			pubSub, err := a.pubSubRegistry.Create(c.Spec.Type)
-			if err != nil {
-				log.Warnf("error creating pub sub %s: %s", c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-				continue
-			}
+	properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
 
-			properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
-			properties["consumerID"] = a.runtimeConfig.ID
+	err = exporter.Init(a.runtimeConfig.ID, a.hostAddress, exporters.Metadata{
+		Properties: properties,
+	})
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("error initializing exporter %s: %s", c.Spec.Type, err)
+	}
+	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+	return nil
+}
 
-			err = pubSub.Init(pubsub.Metadata{
-				Properties: properties,
-			})
+func (a *DaprRuntime) initPubSub() error {
+	a.pubSub = nil
+	a.pubSubs = map[string]pubsub.PubSub{}
+	a.deduplicator = nil
+	if dualRead := a.dualReadSpec(); dualRead.PubSub != "" {
+		window := pubsub_loader.DefaultDeduplicationWindow
+		if dualRead.DeduplicationWindow != "" {
+			d, err := time.ParseDuration(dualRead.DeduplicationWindow)
 			if err != nil {
-				log.Warnf("error initializing pub sub %s: %s", c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
-				continue
+				return fmt.Errorf("invalid dual-read deduplication window %s: %s", dualRead.DeduplicationWindow, err)
+			}
+			window = d
+		}
+		a.deduplicator = pubsub_loader.NewDeduplicator(window)
+	}
+	a.exactlyOnce = nil
+	if exactlyOnce := a.exactlyOnceSpec(); exactlyOnce.StateStore != "" {
+		store, ok := a.stateStores[exactlyOnce.StateStore]
+		if !ok {
+			return fmt.Errorf("state store %s of exactly-once delivery not found", exactlyOnce.StateStore)
+		}
+		window := pubsub_loader.DefaultDeduplicationWindow
+		if exactlyOnce.Window != "" {
+			d, err := time.ParseDuration(exactlyOnce.Window)
+			if err != nil {
+				return fmt.Errorf("invalid exactly-once window %s: %s", exactlyOnce.Window, err)
+			}
+			window = d
+		}
+		a.exactlyOnce = pubsub_loader.NewExactlyOnce(store, a.runtimeConfig.ID, window)
+	}
+	a.maxRetryAfter = pubsub_loader.DefaultMaxRetryAfter
+	if a.globalConfig != nil && a.globalConfig.Spec.PubSubSpec.MaxRetryAfter != "" {
+		maxRetryAfter := a.globalConfig.Spec.PubSubSpec.MaxRetryAfter
+		d, err := time.ParseDuration(maxRetryAfter)
+		if err != nil {
+			return fmt.Errorf("invalid max retry after %s: %s", maxRetryAfter, err)
+		}
+		a.maxRetryAfter = d
+	}
+	transformer, err := pubsub_loader.NewTransformer(a.transformSpecs())
+	if err != nil {
+		return err
+	}
+	a.transformer = transformer
+	for _, c := range a.components {
+		if strings.Index(c.Spec.Type, "pubsub") == 0 {
+			component := c
+			init := func() error {
+				return a.reportComponentStatus(component, a.initPubSubComponent(component))
 			}
+			if err := init(); err != nil {
+				if err = a.handleComponentInitFailure(c, err, init); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
 
-			scopedSubscriptions = scopes.GetScopedTopics(scopes.SubscriptionScopes, a.runtimeConfig.ID, properties)
-			a.scopedPublishings = scopes.GetScopedTopics(scopes.PublishingScopes, a.runtimeConfig.ID, properties)
-			a.allowedTopics = scopes.GetAllowedTopics(properties)
+// initPubSubComponent initializes a pub/sub component and subscribes the app to its topics
+func (a *DaprRuntime) initPubSubComponent(c components_v1alpha1.Component) error {
+	pubSub, err := a.pubSubRegistry.Create(c.Spec.Type, c.Spec.Version)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
+		return fmt.Errorf("error creating pub sub %s: %s", c.Spec.Type, err)
+	}
 
-			a.pubSub = pubSub
-			diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
-			break
-		}
+	properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
+	properties["consumerID"] = a.runtimeConfig.ID
+
+	err = pubSub.Init(pubsub.Metadata{
+		Properties: properties,
+	})
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("error initializing pub sub %s: %s", c.Spec.Type, err)
+	}
+	pubSub, err = pubsub_loader.WithSpool(c.ObjectMeta.Name, pubSub, properties, a.walDir("pubsub", c.ObjectMeta.Name))
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("error initializing pub sub %s: %s", c.Spec.Type, err)
+	}
+	// events are signed before they're spooled, so that replayed events are signed too
+	pubSub, err = pubsub_loader.WithSigning(c.ObjectMeta.Name, pubSub, properties)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("error initializing pub sub %s: %s", c.Spec.Type, err)
+	}
+	// schemas are enforced before events are signed, so that their schema attribute is signed too
+	pubSub, err = pubsub_loader.WithSchemaRegistry(c.ObjectMeta.Name, pubSub, properties)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("error initializing pub sub %s: %s", c.Spec.Type, err)
 	}
 
+	a.componentsLock.Lock()
+	a.pubSubs[c.ObjectMeta.Name] = pubSub
+	a.componentsLock.Unlock()
+	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+
+	scopedSubscriptions := scopes.GetScopedTopics(scopes.SubscriptionScopes, a.runtimeConfig.ID, properties)
+
+	// the dual-read pub/sub is only subscribed to, in addition to the default one
+	dualRead := a.dualReadSpec()
+	if c.ObjectMeta.Name == dualRead.PubSub {
+		a.subscribeTopics(c.ObjectMeta.Name, pubSub, scopedSubscriptions, dualRead.Topics)
+		return nil
+	}
+
+	// the first initialized pub/sub is the default one apps publish and subscribe to.
+	// the others are only published to by fan-outs.
+	if a.pubSub != nil {
+		return nil
+	}
+
+	a.scopedPublishings = scopes.GetScopedTopics(scopes.PublishingScopes, a.runtimeConfig.ID, properties)
+	a.allowedTopics = scopes.GetAllowedTopics(properties)
+
+	a.pubSub = pubSub
+	a.pubSubName = c.ObjectMeta.Name
+	a.scopedSubscriptions = scopedSubscriptions
+	a.subscribeTopics(c.ObjectMeta.Name, pubSub, scopedSubscriptions, nil)
+	return nil
+}
+
+// subscribeTopics subscribes the pub/sub to the topics of the app, or to the given topics of the app if any
+func (a *DaprRuntime) subscribeTopics(name string, pubSub pubsub.PubSub, scopedSubscriptions []string, topics []string) {
 	var publishFunc func(msg *pubsub.NewMessage) error
 	switch a.runtimeConfig.ApplicationProtocol {
 	case HTTPProtocol:
@@ -838,25 +1450,168 @@ func (a *DaprRuntime) initPubSub() error {
 		publishFunc = a.publishMessageGRPC
 	}
 
-	if a.pubSub != nil && a.appChannel != nil {
-		a.topicRoutes = a.getTopicRoutes()
+	if pubSub == nil || a.appChannel == nil {
+		return
+	}
+	a.afterAppStarted(func() {
+		a.topicRoutes, a.topicTimeouts = a.getTopicRoutes()
 
 		for t := range a.topicRoutes {
+			if len(topics) > 0 && !contains(topics, t) {
+				continue
+			}
+
 			allowed := a.isPubSubOperationAllowed(t, scopedSubscriptions)
 			if !allowed {
 				log.Warnf("subscription to topic %s is not allowed", t)
 				continue
 			}
 
-			err := a.pubSub.Subscribe(pubsub.SubscribeRequest{
-				Topic: t,
-			}, publishFunc)
-			if err != nil {
-				log.Warnf("failed to subscribe to topic %s: %s", t, err)
-			}
+			handler := a.topicHandler(name, t, publishFunc)
+			topic := t
+			a.afterAppReady(func() {
+				err := pubSub.Subscribe(pubsub.SubscribeRequest{
+					Topic: topic,
+				}, handler)
+				a.subscriptions.Subscribed(name, pubSub, topic, err)
+				if err != nil {
+					log.Warnf("failed to subscribe to topic %s: %s", topic, err)
+				}
+			})
 		}
+	})
+}
+
+// topicHandler returns the handler of the events of the topic of the pub/sub, delivering them with the publish func
+func (a *DaprRuntime) topicHandler(name, topic string, publishFunc func(msg *pubsub.NewMessage) error) func(msg *pubsub.NewMessage) error {
+	handler := pubsub_loader.WithRetryAfter(publishFunc, a.maxRetryAfter)
+	if a.transformer != nil {
+		handler = a.transformer.Wrap(handler)
 	}
-	return nil
+	if a.deduplicator != nil && a.isDualRead(topic) {
+		handler = a.deduplicator.Wrap(handler)
+	}
+	if a.exactlyOnce != nil && a.isExactlyOnce(topic) {
+		handler = a.exactlyOnce.Wrap(name, handler)
+	}
+	handler = a.pauser.Wrap(handler)
+	return a.subscriptions.Wrap(name, topic, handler)
+}
+
+// subscribeStream subscribes the default pub/sub to the topics of a stream opened by the app, and opens the stream.
+// The topics are subscribed to once, their events are delivered to the streams open when they arrive.
+func (a *DaprRuntime) subscribeStream(topics []string) (*pubsub_loader.Stream, error) {
+	if a.pubSub == nil {
+		return nil, errors.New("no pub/sub is configured")
+	}
+	a.streamsLock.Lock()
+	defer a.streamsLock.Unlock()
+
+	for _, t := range topics {
+		if _, ok := a.topicRoutes[t]; ok {
+			return nil, fmt.Errorf("the app is already subscribed to topic %s by its callbacks", t)
+		}
+		if !a.isPubSubOperationAllowed(t, a.scopedSubscriptions) {
+			return nil, fmt.Errorf("subscription to topic %s is not allowed", t)
+		}
+	}
+	for _, t := range topics {
+		if a.streamTopics[t] {
+			continue
+		}
+		handler := a.topicHandler(a.pubSubName, t, a.publishMessageStream)
+		err := a.pubSub.Subscribe(pubsub.SubscribeRequest{Topic: t}, handler)
+		a.subscriptions.Subscribed(a.pubSubName, a.pubSub, t, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to topic %s: %s", t, err)
+		}
+		a.streamTopics[t] = true
+	}
+	return a.streams.Open(topics), nil
+}
+
+// replayTopic rewinds the subscriptions of the app to the topic on the pub/subs it reads the topic from.
+// The deliveries of the topic are paused during the seek, and carry the id of the replay for the hint window.
+func (a *DaprRuntime) replayTopic(req pubsub_loader.SeekRequest, hintWindow time.Duration) (string, error) {
+	if a.pubSub == nil {
+		return "", errors.New("no pub sub is configured")
+	}
+	if _, ok := a.topicRoutes[req.Topic]; !ok {
+		return "", fmt.Errorf("app is not subscribed to topic %s", req.Topic)
+	}
+
+	pubSubs := []pubsub.PubSub{a.pubSub}
+	if a.isDualRead(req.Topic) {
+		if dualRead, ok := a.pubSubs[a.dualReadSpec().PubSub]; ok {
+			pubSubs = append(pubSubs, dualRead)
+		}
+	}
+	seekers := make([]pubsub_loader.Seeker, 0, len(pubSubs))
+	for _, p := range pubSubs {
+		seeker, ok := p.(pubsub_loader.Seeker)
+		if !ok {
+			return "", pubsub_loader.ErrSeekNotSupported
+		}
+		seekers = append(seekers, seeker)
+	}
+
+	// a topic paused by an operator stays paused after the seek
+	if a.pauser.Pause(req.Topic) {
+		defer a.pauser.Resume(req.Topic)
+	}
+	for _, s := range seekers {
+		if err := s.Seek(req); err != nil {
+			return "", fmt.Errorf("error seeking topic %s: %s", req.Topic, err)
+		}
+	}
+
+	id := uuid.New().String()
+	a.replays.Start(req.Topic, id, hintWindow)
+	log.Infof("replaying topic %s, replay id %s", req.Topic, id)
+	return id, nil
+}
+
+func (a *DaprRuntime) dualReadSpec() config.DualReadSpec {
+	if a.globalConfig == nil {
+		return config.DualReadSpec{}
+	}
+	return a.globalConfig.Spec.PubSubSpec.DualRead
+}
+
+func (a *DaprRuntime) exactlyOnceSpec() config.ExactlyOnceSpec {
+	if a.globalConfig == nil {
+		return config.ExactlyOnceSpec{}
+	}
+	return a.globalConfig.Spec.PubSubSpec.ExactlyOnce
+}
+
+// transformSpecs returns the transforms of the events of the topics
+func (a *DaprRuntime) transformSpecs() []config.TransformSpec {
+	if a.globalConfig == nil {
+		return nil
+	}
+	return a.globalConfig.Spec.PubSubSpec.Transforms
+}
+
+// isDualRead returns whether the topic is read from both the default and the dual-read pub/sub
+func (a *DaprRuntime) isDualRead(topic string) bool {
+	dualRead := a.dualReadSpec()
+	return dualRead.PubSub != "" && (len(dualRead.Topics) == 0 || contains(dualRead.Topics, topic))
+}
+
+// isExactlyOnce returns whether the redeliveries of the events of the topic are dropped
+func (a *DaprRuntime) isExactlyOnce(topic string) bool {
+	exactlyOnce := a.exactlyOnceSpec()
+	return exactlyOnce.StateStore != "" && (len(exactlyOnce.Topics) == 0 || contains(exactlyOnce.Topics, topic))
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
 }
 
 // Publish is an adapter method for the runtime to pre-validate publish requests
@@ -866,9 +1621,44 @@ func (a *DaprRuntime) Publish(req *pubsub.PublishRequest) error {
 	if allowed := a.isPubSubOperationAllowed(req.Topic, a.scopedPublishings); !allowed {
 		return fmt.Errorf("topic %s is not allowed for app id %s", req.Topic, a.runtimeConfig.ID)
 	}
+	if a.transformer != nil {
+		if err := a.transformer.TransformPublish(req); err != nil {
+			return err
+		}
+	}
+	if targets := a.fanOutTargets(req.Topic); len(targets) > 0 {
+		return pubsub_loader.FanOut(req, targets, a.publishTo)
+	}
 	return a.pubSub.Publish(req)
 }
 
+// fanOutTargets returns the targets the events of a topic are fanned out to
+func (a *DaprRuntime) fanOutTargets(topic string) []config.PublishTarget {
+	if a.globalConfig == nil {
+		return nil
+	}
+	for _, f := range a.globalConfig.Spec.PubSubSpec.FanOut {
+		if f.Topic == topic {
+			return f.Targets
+		}
+	}
+	return nil
+}
+
+// publishTo publishes to a pub/sub component by name, or to the default one when name is empty
+func (a *DaprRuntime) publishTo(name string, req *pubsub.PublishRequest) error {
+	a.componentsLock.RLock()
+	ps, ok := a.pubSubs[name]
+	a.componentsLock.RUnlock()
+	if name == "" {
+		ps, ok = a.pubSub, a.pubSub != nil
+	}
+	if !ok {
+		return fmt.Errorf("pub/sub %s is not configured", name)
+	}
+	return ps.Publish(req)
+}
+
 func (a *DaprRuntime) isPubSubOperationAllowed(topic string, scopedTopics []string) bool {
 	inAllowedTopics := false
 
@@ -900,26 +1690,42 @@ func (a *DaprRuntime) isPubSubOperationAllowed(topic string, scopedTopics []stri
 }
 
 func (a *DaprRuntime) initServiceDiscovery() error {
-	var resolver servicediscovery.Resolver
-	var err error
+	var name string
 	switch a.runtimeConfig.Mode {
 	case modes.KubernetesMode:
-		resolver, err = a.serviceDiscoveryRegistry.Create("kubernetes")
+		name = "kubernetes"
 	case modes.StandaloneMode:
-		resolver, err = a.serviceDiscoveryRegistry.Create("mdns")
+		name = "mdns"
 
 	default:
 		return fmt.Errorf("remote calls not supported for %s mode", string(a.runtimeConfig.Mode))
 	}
 
+	spec := a.globalConfig.Spec.NameResolutionSpec
+	if spec.Resolver != "" {
+		name = spec.Resolver
+	}
+
+	resolver, err := a.serviceDiscoveryRegistry.Create(name)
+	if err != nil {
+		log.Warnf("error creating service discovery resolver %s: %s", name, err)
+		return err
+	}
+
+	switch r := resolver.(type) {
+	case *discovery.DNSResolver:
+		err = r.Init(spec.DNS)
+	case *discovery.ConsulResolver:
+		err = r.Init(spec.Consul)
+	}
 	if err != nil {
-		log.Warnf("error creating service discovery resolver %s: %s", a.runtimeConfig.Mode, err)
+		log.Warnf("error initializing service discovery resolver %s: %s", name, err)
 		return err
 	}
 
 	a.servicediscoveryResolver = resolver
 
-	log.Infof("Initialized service discovery to %s", a.runtimeConfig.Mode)
+	log.Infof("Initialized service discovery to %s", name)
 	return nil
 }
 
@@ -928,22 +1734,47 @@ func (a *DaprRuntime) publishMessageHTTP(msg *pubsub.NewMessage) error {
 	req := invokev1.NewInvokeMethodRequest(route)
 	req.WithHTTPExtension(nethttp.MethodPost, "")
 	req.WithRawData(msg.Data, pubsub.ContentType)
+	md := map[string][]string{}
+	if id, ok := a.replays.Active(msg.Topic); ok {
+		md[pubsub_loader.ReplayHeader] = []string{id}
+	}
+	if id, ok := pubsub_loader.EventSchemaID(msg.Data); ok {
+		md[pubsub_loader.SchemaIDHeader] = []string{id}
+	}
+	if len(md) > 0 {
+		req.WithMetadata(md)
+	}
 
-	// TODO Propagate Context
-	ctx := context.Background()
-	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	resp, err := a.appChannel.InvokeMethod(a.topicDeliveryContext(msg.Topic), req)
 	if err != nil {
 		return fmt.Errorf("error from app channel while sending pub/sub event to app: %s", err)
 	}
 
 	if resp.Status().Code != nethttp.StatusOK {
 		_, errorMsg := resp.RawData()
-		return fmt.Errorf("error returned from app while processing pub/sub event: %s. status code returned: %v", errorMsg, resp.Status().Code)
+		err = fmt.Errorf("error returned from app while processing pub/sub event: %s. status code returned: %v", errorMsg, resp.Status().Code)
+		for k, v := range resp.Headers() {
+			if !strings.EqualFold(k, pubsub_loader.RetryAfterHeader) || len(v.GetValues()) == 0 {
+				continue
+			}
+			if after, ok := pubsub_loader.ParseRetryAfter(v.GetValues()[0].GetStringValue(), time.Now()); ok {
+				return &pubsub_loader.RetryAfterError{Err: err, After: after}
+			}
+		}
+		return err
 	}
 
 	return nil
 }
 
+// publishMessageStream delivers the event to a stream of the app subscribed to its topic
+func (a *DaprRuntime) publishMessageStream(msg *pubsub.NewMessage) error {
+	ctx := a.topicDeliveryContext(msg.Topic)
+	ctx, cancel := context.WithTimeout(ctx, a.runtimeConfig.AppChannelTimeouts.Timeout(ctx))
+	defer cancel()
+	return a.streams.Deliver(ctx, msg)
+}
+
 func (a *DaprRuntime) publishMessageGRPC(msg *pubsub.NewMessage) error {
 	var cloudEvent pubsub.CloudEventsEnvelope
 	err := a.json.Unmarshal(msg.Data, &cloudEvent)
@@ -973,24 +1804,117 @@ func (a *DaprRuntime) publishMessageGRPC(msg *pubsub.NewMessage) error {
 		}
 	}
 
+	ctx := a.topicDeliveryContext(msg.Topic)
+	ctx, cancel := context.WithTimeout(ctx, a.runtimeConfig.AppChannelTimeouts.Timeout(ctx))
+	defer cancel()
+	if id, ok := a.replays.Active(msg.Topic); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, pubsub_loader.ReplayHeader, id)
+	}
+	if id, ok := pubsub_loader.EventSchemaID(msg.Data); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, pubsub_loader.SchemaIDHeader, id)
+	}
 	clientV1 := daprclientv1pb.NewDaprClientClient(a.grpc.AppClient)
-	if _, err = clientV1.OnTopicEvent(context.Background(), envelope); err != nil {
+	var header, trailer metadata.MD
+	if _, err = clientV1.OnTopicEvent(ctx, envelope, grpc_go.Header(&header), grpc_go.Trailer(&trailer)); err != nil {
+		after, ok := grpcRetryAfter(err, header, trailer)
 		err = fmt.Errorf("error from app while processing pub/sub event: %s", err)
 		log.Debug(err)
+		if ok {
+			return &pubsub_loader.RetryAfterError{Err: err, After: after}
+		}
 		return err
 	}
 	return nil
 }
 
+// grpcRetryAfter returns the delay the app asked before the redelivery of a failed event, as retry-after metadata or
+// as the retry info details of the status
+func grpcRetryAfter(err error, header, trailer metadata.MD) (time.Duration, bool) {
+	for _, md := range []metadata.MD{header, trailer} {
+		if v := md.Get(pubsub_loader.RetryAfterHeader); len(v) > 0 {
+			if after, ok := pubsub_loader.ParseRetryAfter(v[0], time.Now()); ok {
+				return after, true
+			}
+		}
+	}
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+			if after, err := ptypes.Duration(info.RetryDelay); err == nil && after >= 0 {
+				return after, true
+			}
+		}
+	}
+	return 0, false
+}
+
 func (a *DaprRuntime) initActors() error {
-	actorConfig := actors.NewConfig(a.hostAddress, a.runtimeConfig.ID, a.runtimeConfig.PlacementServiceAddress, a.appConfig.Entities,
-		a.runtimeConfig.InternalGRPCPort, a.appConfig.ActorScanInterval, a.appConfig.ActorIdleTimeout, a.appConfig.DrainOngoingCallTimeout, a.appConfig.DrainRebalancedActors)
-	act := actors.NewActors(a.stateStores[a.actorStateStoreName], a.appChannel, a.grpc.GetGRPCConnection, actorConfig, a.runtimeConfig.CertChain, a.globalConfig.Spec.TracingSpec)
-	err := act.Init()
+	actorConfig := actors.NewConfig(a.advertiseHost, a.runtimeConfig.ID, a.runtimeConfig.PlacementServiceAddress, a.appConfig.Entities,
+		a.advertisePort, a.appConfig.ActorScanInterval, a.appConfig.ActorIdleTimeout, a.appConfig.DrainOngoingCallTimeout, a.appConfig.DrainRebalancedActors)
+	actorConfig.LifecycleEventsTopic = a.globalConfig.Spec.ActorLifecycleSpec.Topic
+	actorConfig.StateCache = a.globalConfig.Spec.ActorStateSpec.Cache
+	for _, r := range a.globalConfig.Spec.ActorStateSpec.StaleReads {
+		d, err := time.ParseDuration(r.MaxStaleness)
+		if err != nil {
+			return fmt.Errorf("invalid max staleness %s of actor type %s: %s", r.MaxStaleness, r.ActorType, err)
+		}
+		if actorConfig.MaxStaleness == nil {
+			actorConfig.MaxStaleness = map[string]time.Duration{}
+		}
+		actorConfig.MaxStaleness[r.ActorType] = d
+	}
+	if threshold := a.globalConfig.Spec.ActorTurnsSpec.SlowTurnThreshold; threshold != "" {
+		d, err := time.ParseDuration(threshold)
+		if err != nil {
+			return fmt.Errorf("invalid slow actor turn threshold %s: %s", threshold, err)
+		}
+		actorConfig.SlowTurnThreshold = d
+	}
+	policies, err := actors.NewPolicies(a.globalConfig.Spec.ActorResiliency)
+	if err != nil {
+		return err
+	}
+	actorConfig.Policies = policies
+	actorConfig.Pipeline, err = a.buildActorPipeline()
+	if err != nil {
+		return fmt.Errorf("failed to build actor pipeline: %s", err)
+	}
+	act := actors.NewActors(a.stateStores[a.actorStateStoreName], a.appChannel, a.grpc.GetGRPCConnection, actorConfig, a.runtimeConfig.CertChain, a.getPublishAdapter(), a.globalConfig.Spec.TracingSpec)
+	err = act.Init()
 	a.actor = act
 	return err
 }
 
+// initSagas persists the sagas of the app in the actor state store, and resumes the sagas interrupted by a restart
+func (a *DaprRuntime) initSagas() error {
+	store, ok := a.stateStores[a.actorStateStoreName]
+	if !ok || a.appChannel == nil {
+		return nil
+	}
+	a.sagas = saga.NewCoordinator(store, a.runtimeConfig.ID, a.invokeSagaStep)
+	return a.sagas.Resume()
+}
+
+func (a *DaprRuntime) invokeSagaStep(method string, data []byte) error {
+	req := invokev1.NewInvokeMethodRequest(method)
+	req.WithHTTPExtension(nethttp.MethodPost, "")
+	req.WithRawData(data, invokev1.JSONContentType)
+
+	// TODO Propagate Context
+	ctx := context.Background()
+	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	if err != nil {
+		return fmt.Errorf("error from app channel while invoking saga step %s: %s", method, err)
+	}
+
+	code := resp.Status().Code
+	if (a.runtimeConfig.ApplicationProtocol == HTTPProtocol && code != nethttp.StatusOK) ||
+		(a.runtimeConfig.ApplicationProtocol == GRPCProtocol && code != int32(codes.OK)) {
+		_, errorMsg := resp.RawData()
+		return fmt.Errorf("error returned from app while invoking saga step %s: %s. status code returned: %v", method, errorMsg, code)
+	}
+	return nil
+}
+
 func (a *DaprRuntime) getAuthorizedComponents(components []components_v1alpha1.Component) []components_v1alpha1.Component {
 	authorized := []components_v1alpha1.Component{}
 
@@ -1010,35 +1934,92 @@ func (a *DaprRuntime) getAuthorizedComponents(components []components_v1alpha1.C
 					continue
 				}
 			}
+			if !a.isResidencyAllowed(c) {
+				continue
+			}
 			authorized = append(authorized, c)
 		}
 	}
 	return authorized
 }
 
-func (a *DaprRuntime) loadComponents(opts *runtimeOpts) error {
-	var loader components.ComponentLoader
+func (a *DaprRuntime) dataResidencySpec() config.DataResidencySpec {
+	if a.globalConfig == nil {
+		return config.DataResidencySpec{}
+	}
+	return a.globalConfig.Spec.DataResidencySpec
+}
 
+// egressPolicy returns the policy restricting the destinations of the output bindings
+func (a *DaprRuntime) egressPolicy() (*bindings_loader.EgressPolicy, error) {
+	if a.globalConfig == nil {
+		return bindings_loader.NewEgressPolicy(nil, nil)
+	}
+	egress := a.globalConfig.Spec.EgressSpec
+	return bindings_loader.NewEgressPolicy(egress.AllowedHosts, egress.AllowedCIDRs)
+}
+
+// isResidencyAllowed returns false if the data of the component resides outside of the regions the app may use,
+// and the data residency policy rejects it
+func (a *DaprRuntime) isResidencyAllowed(c components_v1alpha1.Component) bool {
+	residency := a.dataResidencySpec()
+	if residency.Region == "" || c.Residency == "" || c.Residency == residency.Region || contains(residency.AllowedRegions, c.Residency) {
+		return true
+	}
+
+	if residency.Policy == config.ResidencyPolicyWarn {
+		log.Warnf("component %s resides in region %s, outside of the region %s of the app", c.ObjectMeta.Name, c.Residency, residency.Region)
+		return true
+	}
+	log.Errorf("component %s is not loaded: it resides in region %s, outside of the region %s of the app", c.ObjectMeta.Name, c.Residency, residency.Region)
+	return false
+}
+
+func (a *DaprRuntime) getComponentLoader() (components.ComponentLoader, error) {
 	switch a.runtimeConfig.Mode {
 	case modes.KubernetesMode:
-		loader = components.NewKubernetesComponents(a.runtimeConfig.Kubernetes, a.operatorClient)
+		return components.NewKubernetesComponents(a.runtimeConfig.Kubernetes, a.operatorClient, a.globalConfig.Spec.TracingSpec), nil
 	case modes.StandaloneMode:
-		loader = components.NewStandaloneComponents(a.runtimeConfig.Standalone)
+		if a.runtimeConfig.Standalone.ConfigService.Address != "" {
+			return components.NewRemoteComponents(a.runtimeConfig.Standalone.ConfigService, a.runtimeConfig.ID), nil
+		}
+		return components.NewStandaloneComponents(a.runtimeConfig.Standalone), nil
 	default:
-		return fmt.Errorf("components loader for mode %s not found", a.runtimeConfig.Mode)
+		return nil, fmt.Errorf("components loader for mode %s not found", a.runtimeConfig.Mode)
 	}
+}
 
-	comps, err := loader.LoadComponents()
+func (a *DaprRuntime) loadComponents(opts *runtimeOpts) error {
+	loader, err := a.getComponentLoader()
 	if err != nil {
 		return err
 	}
+
+	comps, err := loader.LoadComponents()
+	if err != nil {
+		if a.runtimeConfig.Mode != modes.KubernetesMode {
+			log.Warnf("failed to load components: %s", err)
+		} else {
+			policy := startupPolicyOrDefault(a.startupSpec().Operator, config.StartupPolicyWarn)
+			retry := func() error {
+				comps, err = loader.LoadComponents()
+				return err
+			}
+			if policy == config.StartupPolicyRetry {
+				retry = a.reloadComponents
+			}
+			if err = handleStartupFailure("operator", policy, a.startupRetryInterval(), err, retry); err != nil {
+				return err
+			}
+		}
+	}
 	a.components = a.getAuthorizedComponents(comps)
 
 	// Register and initialize secret stores
 	a.secretStoresRegistry.Register(opts.secretStores...)
 	err = a.initSecretStores()
 	if err != nil {
-		log.Warnf("failed to init secret stores: %s", err)
+		return err
 	}
 
 	var wg sync.WaitGroup
@@ -1057,9 +2038,52 @@ func (a *DaprRuntime) loadComponents(opts *runtimeOpts) error {
 	return nil
 }
 
+// reloadComponents loads the components after the runtime has started and initializes them as component updates
+func (a *DaprRuntime) reloadComponents() error {
+	loader, err := a.getComponentLoader()
+	if err != nil {
+		return err
+	}
+
+	comps, err := loader.LoadComponents()
+	if err != nil {
+		return err
+	}
+	for _, c := range a.getAuthorizedComponents(comps) {
+		a.onComponentUpdated(a.processComponentSecrets(c))
+	}
+	return nil
+}
+
 // Stop allows for a graceful shutdown of all runtime internal operations or components
 func (a *DaprRuntime) Stop() {
 	log.Info("stop command issued. Shutting down all operations")
+
+	// peers stop finding this sidecar before its internal server is drained
+	if consulResolver, ok := a.servicediscoveryResolver.(*discovery.ConsulResolver); ok {
+		if err := consulResolver.Deregister(); err != nil {
+			log.Warn(err)
+		}
+	}
+	if a.actor != nil {
+		a.actor.Stop()
+	}
+	if a.stopMemoryBudget != nil {
+		close(a.stopMemoryBudget)
+		a.stopMemoryBudget = nil
+	}
+
+	if a.internalServer != nil {
+		a.internalServer.Drain(a.drainGracePeriod())
+	}
+	a.closeComponents()
+}
+
+func (a *DaprRuntime) drainGracePeriod() time.Duration {
+	if d, err := time.ParseDuration(a.globalConfig.Spec.GRPCServerSpec.DrainGracePeriod); err == nil && d >= 0 {
+		return d
+	}
+	return defaultDrainGracePeriod
 }
 
 func (a *DaprRuntime) processComponentSecrets(component components_v1alpha1.Component) components_v1alpha1.Component {
@@ -1115,27 +2139,10 @@ func (a *DaprRuntime) getSecretStore(storeName string) secretstores.SecretStore
 			return nil
 		}
 	}
-	return a.secretStores[storeName]
-}
-
-func (a *DaprRuntime) blockUntilAppIsReady() {
-	if a.runtimeConfig.ApplicationPort <= 0 {
-		return
-	}
-
-	log.Infof("application protocol: %s. waiting on port %v.  This will block until the app is listening on that port.", string(a.runtimeConfig.ApplicationProtocol), a.runtimeConfig.ApplicationPort)
-
-	for {
-		conn, _ := net.DialTimeout("tcp", net.JoinHostPort("localhost", fmt.Sprintf("%v", a.runtimeConfig.ApplicationPort)), time.Millisecond*500)
-		if conn != nil {
-			conn.Close()
-			break
-		}
-		// prevents overwhelming the OS with open connections
-		time.Sleep(time.Millisecond * 50)
-	}
-
-	log.Infof("application discovered on port %v", a.runtimeConfig.ApplicationPort)
+	// the secrets of the components aren't restricted by the scope of the app
+	a.componentsLock.RLock()
+	defer a.componentsLock.RUnlock()
+	return secretstores_loader.Unwrap(a.secretStores[storeName])
 }
 
 func (a *DaprRuntime) loadAppConfiguration() {
@@ -1202,35 +2209,71 @@ func (a *DaprRuntime) getConfigurationGRPC() (*config.ApplicationConfig, error)
 }
 
 func (a *DaprRuntime) createAppChannel() error {
-	if a.runtimeConfig.ApplicationPort > 0 {
-		var channelCreatorFn func(port, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error)
+	if a.runtimeConfig.ApplicationPort > 0 || a.runtimeConfig.ApplicationPipe != "" {
+		var channelCreatorFn func(port, maxConcurrency int, timeouts channel.Timeouts, spec config.TracingSpec) (channel.AppChannel, error)
+		var pipeChannelCreatorFn func(pipe string, maxConcurrency int, timeouts channel.Timeouts, spec config.TracingSpec) (channel.AppChannel, error)
 
 		switch a.runtimeConfig.ApplicationProtocol {
 		case GRPCProtocol:
 			channelCreatorFn = a.grpc.CreateLocalChannel
+			pipeChannelCreatorFn = a.grpc.CreatePipeChannel
 		case HTTPProtocol:
 			channelCreatorFn = http_channel.CreateLocalChannel
+			pipeChannelCreatorFn = http_channel.CreatePipeChannel
 		default:
 			return fmt.Errorf("cannot create app channel for protocol %s", string(a.runtimeConfig.ApplicationProtocol))
 		}
 
-		ch, err := channelCreatorFn(a.runtimeConfig.ApplicationPort, a.runtimeConfig.MaxConcurrency, a.globalConfig.Spec.TracingSpec)
+		var ch channel.AppChannel
+		var err error
+		if a.runtimeConfig.ApplicationPipe != "" {
+			ch, err = pipeChannelCreatorFn(a.runtimeConfig.ApplicationPipe, a.runtimeConfig.MaxConcurrency, a.runtimeConfig.AppChannelTimeouts, a.globalConfig.Spec.TracingSpec)
+		} else {
+			ch, err = channelCreatorFn(a.runtimeConfig.ApplicationPort, a.runtimeConfig.MaxConcurrency, a.runtimeConfig.AppChannelTimeouts, a.globalConfig.Spec.TracingSpec)
+		}
 		if err != nil {
 			return err
 		}
 		if a.runtimeConfig.MaxConcurrency > 0 {
 			log.Infof("app max concurrency set to %v", a.runtimeConfig.MaxConcurrency)
 		}
-		a.appChannel = ch
+		if a.runtimeConfig.ApplicationProtocol == HTTPProtocol {
+			ch, err = http_channel.WithMappings(ch, a.globalConfig.Spec.InvocationSpec.HTTPMappings)
+			if err != nil {
+				return err
+			}
+		}
+		adaptive := a.globalConfig.Spec.InvocationSpec.AdaptiveConcurrency
+		limiter, err := concurrency.New(adaptive)
+		if err != nil {
+			return err
+		}
+		if limiter != nil {
+			log.Infof("app concurrency adapted by the %s algorithm, starting at %v", adaptive.Algorithm, limiter.Limit())
+		}
+		a.appChannel = channel.WithAdaptiveConcurrency(ch, limiter)
 	}
 
 	return nil
 }
 
 func (a *DaprRuntime) announceSelf() error {
+	if consulResolver, ok := a.servicediscoveryResolver.(*discovery.ConsulResolver); ok {
+		err := consulResolver.Register(a.runtimeConfig.ID, a.advertiseHost, a.advertisePort)
+		if err != nil {
+			return err
+		}
+		log.Info("service registered with consul")
+		return nil
+	}
+
 	switch a.runtimeConfig.Mode {
 	case modes.StandaloneMode:
-		err := discovery.RegisterMDNS(a.runtimeConfig.ID, a.runtimeConfig.InternalGRPCPort)
+		var ips []string
+		if a.runtimeConfig.InternalAdvertiseAddress != "" {
+			ips = []string{a.advertiseHost}
+		}
+		err := discovery.RegisterMDNS(a.runtimeConfig.ID, a.advertisePort, ips)
 		if err != nil {
 			return err
 		}
@@ -1243,16 +2286,15 @@ func (a *DaprRuntime) initSecretStores() error {
 	// Preload Kubernetes secretstore
 	switch a.runtimeConfig.Mode {
 	case modes.KubernetesMode:
-		kubeSecretStore, err := a.secretStoresRegistry.Create("secretstores.kubernetes")
-		if err != nil {
-			return err
+		kubeSecretStore, err := a.secretStoresRegistry.Create("secretstores.kubernetes", "")
+		if err == nil {
+			err = kubeSecretStore.Init(secretstores.Metadata{})
 		}
-
-		if err = kubeSecretStore.Init(secretstores.Metadata{}); err != nil {
-			return err
+		if err != nil {
+			log.Warnf("failed to init kubernetes secret store: %s", err)
+		} else {
+			a.secretStores["kubernetes"] = a.scopeSecretStore("kubernetes", kubeSecretStore)
 		}
-
-		a.secretStores["kubernetes"] = kubeSecretStore
 	}
 
 	// Initialize all secretstore components
@@ -1261,32 +2303,55 @@ func (a *DaprRuntime) initSecretStores() error {
 			continue
 		}
 
-		// Look up the secrets to authenticate this secretstore from K8S secret store
-		a.processComponentSecrets(c)
-
-		secretStore, err := a.secretStoresRegistry.Create(c.Spec.Type)
-		if err != nil {
-			log.Warnf("failed creating state store %s: %s", c.Spec.Type, err)
-			diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-			continue
+		component := c
+		init := func() error {
+			return a.reportComponentStatus(component, a.initSecretStore(component))
 		}
-
-		err = secretStore.Init(secretstores.Metadata{
-			Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
-		})
-		if err != nil {
-			log.Warnf("failed to init state store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
-			diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
-			continue
+		if err := init(); err != nil {
+			if err = a.handleComponentInitFailure(c, err, init); err != nil {
+				return err
+			}
 		}
+	}
 
-		a.secretStores[c.ObjectMeta.Name] = secretStore
-		diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+	return nil
+}
+
+func (a *DaprRuntime) initSecretStore(c components_v1alpha1.Component) error {
+	// Look up the secrets to authenticate this secretstore from K8S secret store
+	a.processComponentSecrets(c)
+
+	secretStore, err := a.secretStoresRegistry.Create(c.Spec.Type, c.Spec.Version)
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
+		return fmt.Errorf("failed creating secret store %s: %s", c.Spec.Type, err)
+	}
+
+	err = secretStore.Init(secretstores.Metadata{
+		Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
+	})
+	if err != nil {
+		diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		return fmt.Errorf("failed to init secret store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
 	}
 
+	a.componentsLock.Lock()
+	a.secretStores[c.ObjectMeta.Name] = a.scopeSecretStore(c.ObjectMeta.Name, secretStore)
+	a.componentsLock.Unlock()
+	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
 	return nil
 }
 
+// scopeSecretStore restricts the secrets the app reads from the store to its scope, if any
+func (a *DaprRuntime) scopeSecretStore(name string, store secretstores.SecretStore) secretstores.SecretStore {
+	for _, scope := range a.globalConfig.Spec.SecretsSpec.Scopes {
+		if scope.StoreName == name {
+			return secretstores_loader.WithScope(store, scope)
+		}
+	}
+	return store
+}
+
 func (a *DaprRuntime) convertMetadataItemsToProperties(items []components_v1alpha1.MetadataItem) map[string]string {
 	properties := map[string]string{}
 	for _, c := range items {
@@ -1314,7 +2379,7 @@ func (a *DaprRuntime) establishSecurity(sentryAddress string) error {
 	}
 	log.Info("mTLS enabled. creating sidecar authenticator")
 
-	auth, err := security.GetSidecarAuthenticator(sentryAddress, a.runtimeConfig.CertChain)
+	auth, err := security.GetSidecarAuthenticator(sentryAddress, a.runtimeConfig.CertChain, a.globalConfig.Spec.TracingSpec)
 	if err != nil {
 		return err
 	}
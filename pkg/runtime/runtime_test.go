@@ -6,30 +6,45 @@
 package runtime
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/components-contrib/secretstores"
 	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/channel"
 	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/components"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
 	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
 	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	state_inmemory "github.com/dapr/dapr/pkg/components/state/inmemory"
 	"github.com/dapr/dapr/pkg/config"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	"github.com/dapr/dapr/pkg/modes"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
 	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
 	"github.com/dapr/dapr/pkg/runtime/security"
 	"github.com/dapr/dapr/pkg/scopes"
 	"github.com/dapr/dapr/pkg/sentry/certs"
 	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -143,7 +158,7 @@ func TestInitPubSub(t *testing.T) {
 		subs := getSubscriptionsJSONString([]string{"topic0", "topic1"})
 		fakeResp.WithRawData([]byte(subs), "application/json")
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -170,7 +185,7 @@ func TestInitPubSub(t *testing.T) {
 		sub := getSubscriptionCustom("topic0", "customroute/topic0")
 		fakeResp.WithRawData([]byte(sub), "application/json")
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -193,7 +208,7 @@ func TestInitPubSub(t *testing.T) {
 		fakeReq.WithRawData(nil, "application/json")
 		fakeResp := invokev1.NewInvokeMethodResponse(404, "Not Found", nil)
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -232,7 +247,7 @@ func TestInitPubSub(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		subs := getSubscriptionsJSONString([]string{"topic0"})
 		fakeResp.WithRawData([]byte(subs), "application/json")
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -257,7 +272,7 @@ func TestInitPubSub(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		subs := getSubscriptionsJSONString([]string{"topic0", "topic1"})
 		fakeResp.WithRawData([]byte(subs), "application/json")
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -282,7 +297,7 @@ func TestInitPubSub(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		subs := getSubscriptionsJSONString([]string{"topic3"})
 		fakeResp.WithRawData([]byte(subs), "application/json")
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -307,7 +322,7 @@ func TestInitPubSub(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		subs := getSubscriptionsJSONString([]string{"topic0", "topic3"})
 		fakeResp.WithRawData([]byte(subs), "application/json")
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -332,7 +347,7 @@ func TestInitPubSub(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		subs := getSubscriptionsJSONString([]string{"topic0"})
 		fakeResp.WithRawData([]byte(subs), "application/json")
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -359,7 +374,7 @@ func TestInitPubSub(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		subs := getSubscriptionsJSONString([]string{"topic0"})
 		fakeResp.WithRawData([]byte(subs), "application/json")
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.initPubSub()
@@ -668,7 +683,11 @@ func TestOnNewPublishedMessage(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		fakeResp.WithRawData([]byte("OK"), "application/json")
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		// the delivery is made for the pub/sub operation, with its timeout
+		mockAppChannel.On("InvokeMethod", mock.MatchedBy(func(ctx context.Context) bool {
+			op, _ := channel.OperationFromContext(ctx)
+			return op == channel.OperationPubSub
+		}), fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.publishMessageHTTP(testPubSubMessage)
@@ -686,7 +705,7 @@ func TestOnNewPublishedMessage(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(500, "Internal Error", nil)
 		fakeResp.WithRawData([]byte(clientError.Error()), "application/json")
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		// act
 		err := rt.publishMessageHTTP(testPubSubMessage)
@@ -698,6 +717,41 @@ func TestOnNewPublishedMessage(t *testing.T) {
 	})
 }
 
+func TestPublishRetryAfter(t *testing.T) {
+	t.Run("retry-after header of the app asks a delay", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		rt.appChannel = mockAppChannel
+
+		fakeResp := invokev1.NewInvokeMethodResponse(503, "Service Unavailable", nil)
+		fakeResp.WithHeaders(metadata.Pairs("retry-after", "30"))
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(fakeResp, nil)
+
+		err := rt.publishMessageHTTP(&pubsub.NewMessage{Topic: "topic1", Data: []byte("Test Message")})
+		retryAfter, ok := err.(*pubsub_loader.RetryAfterError)
+		require.True(t, ok)
+		assert.Equal(t, time.Second*30, retryAfter.After)
+	})
+
+	t.Run("retry-after metadata of gRPC apps asks a delay", func(t *testing.T) {
+		after, ok := grpcRetryAfter(status.Error(codes.Unavailable, "throttled"), nil, metadata.Pairs("retry-after", "5"))
+		assert.True(t, ok)
+		assert.Equal(t, time.Second*5, after)
+	})
+
+	t.Run("retry info of gRPC apps asks a delay", func(t *testing.T) {
+		s, err := status.New(codes.ResourceExhausted, "throttled").WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(time.Second * 2)})
+		require.NoError(t, err)
+
+		after, ok := grpcRetryAfter(s.Err(), nil, nil)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second*2, after)
+
+		_, ok = grpcRetryAfter(status.Error(codes.Internal, "failed"), nil, nil)
+		assert.False(t, ok)
+	})
+}
+
 func getFakeProperties() map[string]string {
 	return map[string]string{
 		"host":                    "localhost",
@@ -834,7 +888,11 @@ func TestReadInputBindings(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		fakeResp.WithRawData([]byte("OK"), "application/json")
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		// the event is delivered for the binding operation, with its timeout
+		mockAppChannel.On("InvokeMethod", mock.MatchedBy(func(ctx context.Context) bool {
+			op, _ := channel.OperationFromContext(ctx)
+			return op == channel.OperationBinding
+		}), fakeReq).Return(fakeResp, nil)
 
 		rt.appChannel = mockAppChannel
 
@@ -857,7 +915,7 @@ func TestReadInputBindings(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(500, "Internal Error", nil)
 		fakeResp.WithRawData([]byte("Internal Error"), "application/json")
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 
 		rt.appChannel = mockAppChannel
 
@@ -880,7 +938,7 @@ func TestReadInputBindings(t *testing.T) {
 		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
 		fakeResp.WithRawData([]byte("OK"), "application/json")
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, fakeReq).Return(fakeResp, nil)
 		rt.appChannel = mockAppChannel
 
 		b := mockBinding{}
@@ -890,6 +948,41 @@ func TestReadInputBindings(t *testing.T) {
 	})
 }
 
+func TestSingletonInputBinding(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.components = []components_v1alpha1.Component{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "leases"}, Spec: components_v1alpha1.ComponentSpec{Type: "state.in-memory"}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "cache"}, Spec: components_v1alpha1.ComponentSpec{Type: "state.memcached"}},
+	}
+	rt.stateStores["leases"] = state_inmemory.NewStateStore()
+	rt.stateFeatures["leases"] = []string{components.FeatureETag, components.FeatureInsert}
+	rt.stateStores["cache"] = state_inmemory.NewStateStore()
+	rt.stateFeatures["cache"] = []string{}
+	b := &mockBinding{}
+
+	t.Run("bindings without singleton store are unchanged", func(t *testing.T) {
+		singleton, err := rt.singletonInputBinding("cron", b, map[string]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, b, singleton)
+	})
+
+	t.Run("singleton store", func(t *testing.T) {
+		singleton, err := rt.singletonInputBinding("cron", b, map[string]string{bindings_loader.SingletonStoreMetadataKey: "leases"})
+		assert.NoError(t, err)
+		assert.NotEqual(t, b, singleton)
+	})
+
+	t.Run("unknown singleton store", func(t *testing.T) {
+		_, err := rt.singletonInputBinding("cron", b, map[string]string{bindings_loader.SingletonStoreMetadataKey: "other"})
+		assert.Error(t, err)
+	})
+
+	t.Run("singleton store without the insert feature", func(t *testing.T) {
+		_, err := rt.singletonInputBinding("cron", b, map[string]string{bindings_loader.SingletonStoreMetadataKey: "cache"})
+		assert.Error(t, err)
+	})
+}
+
 func TestNamespace(t *testing.T) {
 	t.Run("empty namespace", func(t *testing.T) {
 		rt := NewTestDaprRuntime(modes.StandaloneMode)
@@ -997,9 +1090,164 @@ func TestAuthorizedComponents(t *testing.T) {
 		comps := rt.getAuthorizedComponents([]components_v1alpha1.Component{component})
 		assert.True(t, len(comps) == 0)
 	})
+
+	t.Run("data residency", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.globalConfig.Spec.DataResidencySpec = config.DataResidencySpec{
+			Region:         "eu-west",
+			AllowedRegions: []string{"eu-central"},
+		}
+
+		component := func(name, residency string) components_v1alpha1.Component {
+			c := components_v1alpha1.Component{Residency: residency}
+			c.ObjectMeta.Name = name
+			return c
+		}
+		comps := rt.getAuthorizedComponents([]components_v1alpha1.Component{
+			component("local", "eu-west"),
+			component("allowed", "eu-central"),
+			component("untagged", ""),
+			component("remote", "us-east"),
+		})
+		assert.Len(t, comps, 3)
+		for _, c := range comps {
+			assert.NotEqual(t, "remote", c.ObjectMeta.Name)
+		}
+
+		rt.globalConfig.Spec.DataResidencySpec.Policy = config.ResidencyPolicyWarn
+		comps = rt.getAuthorizedComponents([]components_v1alpha1.Component{component("remote", "us-east")})
+		assert.Len(t, comps, 1)
+	})
+}
+
+func TestPublishFanOut(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	defaultPubSub := &mockPublishPubSub{}
+	kafka := &mockPublishPubSub{}
+	rt.pubSub = defaultPubSub
+	rt.pubSubs = map[string]pubsub.PubSub{"default": defaultPubSub, "kafka": kafka}
+	rt.globalConfig.Spec.PubSubSpec.FanOut = []config.FanOutSpec{
+		{
+			Topic:   "orders",
+			Targets: []config.PublishTarget{{}, {PubSub: "kafka", Topic: "orders-v2"}},
+		},
+	}
+
+	t.Run("fanned out topic", func(t *testing.T) {
+		err := rt.Publish(&pubsub.PublishRequest{Topic: "orders"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"orders"}, defaultPubSub.topics)
+		assert.Equal(t, []string{"orders-v2"}, kafka.topics)
+	})
+
+	t.Run("other topics publish to the default pub/sub", func(t *testing.T) {
+		err := rt.Publish(&pubsub.PublishRequest{Topic: "payments"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"orders", "payments"}, defaultPubSub.topics)
+		assert.Len(t, kafka.topics, 1)
+	})
+
+	t.Run("unknown target", func(t *testing.T) {
+		rt.globalConfig.Spec.PubSubSpec.FanOut[0].Targets[1].PubSub = "nats"
+		err := rt.Publish(&pubsub.PublishRequest{Topic: "orders"})
+		assert.IsType(t, &pubsub_loader.FanOutError{}, err)
+	})
+}
+
+func TestPublishTransform(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	ps := &mockPublishPubSub{}
+	rt.pubSub = ps
+	transformer, err := pubsub_loader.NewTransformer([]config.TransformSpec{
+		{Topic: "orders", Stage: config.TransformStagePublish, Template: `{"orderId": {{json .id}}}`},
+	})
+	assert.NoError(t, err)
+	rt.transformer = transformer
+
+	event, _ := json.Marshal(pubsub.NewCloudEventsEnvelope("1", "app", "", "", []byte(`{"id": "o1", "total": 10}`)))
+	err = rt.Publish(&pubsub.PublishRequest{Topic: "orders", Data: event})
+	assert.NoError(t, err)
+
+	var published pubsub.CloudEventsEnvelope
+	assert.NoError(t, json.Unmarshal(ps.data[0], &published))
+	assert.Equal(t, "1", published.ID)
+	assert.Equal(t, map[string]interface{}{"orderId": "o1"}, published.Data)
+
+	t.Run("failed transform isn't published", func(t *testing.T) {
+		err := rt.Publish(&pubsub.PublishRequest{Topic: "orders", Data: []byte("plain")})
+		assert.Error(t, err)
+		assert.Len(t, ps.topics, 1)
+	})
+}
+
+func TestIsDualRead(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	assert.False(t, rt.isDualRead("orders"))
+
+	rt.globalConfig.Spec.PubSubSpec.DualRead = config.DualReadSpec{PubSub: "pulsar"}
+	assert.True(t, rt.isDualRead("orders"))
+
+	rt.globalConfig.Spec.PubSubSpec.DualRead.Topics = []string{"payments"}
+	assert.False(t, rt.isDualRead("orders"))
+	assert.True(t, rt.isDualRead("payments"))
+}
+
+func TestExactlyOnce(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	assert.False(t, rt.isExactlyOnce("orders"))
+
+	rt.globalConfig.Spec.PubSubSpec.ExactlyOnce = config.ExactlyOnceSpec{StateStore: "statestore"}
+	assert.True(t, rt.isExactlyOnce("orders"))
+	rt.globalConfig.Spec.PubSubSpec.ExactlyOnce.Topics = []string{"payments"}
+	assert.False(t, rt.isExactlyOnce("orders"))
+	assert.True(t, rt.isExactlyOnce("payments"))
+
+	t.Run("state store must exist", func(t *testing.T) {
+		assert.Error(t, rt.initPubSub())
+		assert.Nil(t, rt.exactlyOnce)
+
+		rt.stateStores["statestore"] = state_inmemory.NewStateStore()
+		assert.NoError(t, rt.initPubSub())
+		assert.NotNil(t, rt.exactlyOnce)
+	})
+}
+
+func TestRegisterStateStoreAliases(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	orders := state_inmemory.NewStateStore()
+	rt.stateStores["orders"] = orders
+	rt.registerStateStoreAliases("orders", orders, map[string]string{
+		state_loader.AliasesMetadataKey:      "statestore",
+		state_loader.DefaultStoreMetadataKey: "true",
+	})
+	assert.Equal(t, orders, rt.stateStores["statestore"])
+	assert.Equal(t, orders, rt.stateStores[state_loader.DefaultStoreName])
+	assert.Equal(t, []string{state_loader.DefaultStoreName, "statestore"}, rt.stateStoreAliasesOf("orders"))
+
+	t.Run("taken aliases are ignored", func(t *testing.T) {
+		payments := state_inmemory.NewStateStore()
+		rt.stateStores["payments"] = payments
+		rt.registerStateStoreAliases("payments", payments, map[string]string{
+			state_loader.AliasesMetadataKey:      "statestore,orders,legacy",
+			state_loader.DefaultStoreMetadataKey: "true",
+		})
+		assert.Equal(t, orders, rt.stateStores["statestore"])
+		assert.Equal(t, orders, rt.stateStores["orders"])
+		assert.Equal(t, orders, rt.stateStores[state_loader.DefaultStoreName])
+		assert.Equal(t, []string{"legacy"}, rt.stateStoreAliasesOf("payments"))
+	})
+
+	t.Run("former aliases are replaced", func(t *testing.T) {
+		rt.registerStateStoreAliases("orders", orders, map[string]string{})
+		assert.NotContains(t, rt.stateStores, "statestore")
+		assert.NotContains(t, rt.stateStores, state_loader.DefaultStoreName)
+		assert.Empty(t, rt.stateStoreAliasesOf("orders"))
+	})
 }
 
 type mockPublishPubSub struct {
+	topics []string
+	data   [][]byte
 }
 
 // Init is a mock initialization method
@@ -1009,6 +1257,8 @@ func (m *mockPublishPubSub) Init(metadata pubsub.Metadata) error {
 
 // Publish is a mock publish method
 func (m *mockPublishPubSub) Publish(req *pubsub.PublishRequest) error {
+	m.topics = append(m.topics, req.Topic)
+	m.data = append(m.data, req.Data)
 	return nil
 }
 
@@ -1016,3 +1266,77 @@ func (m *mockPublishPubSub) Publish(req *pubsub.PublishRequest) error {
 func (m *mockPublishPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
 	return nil
 }
+
+type mockSeekingPubSub struct {
+	mockPublishPubSub
+	seeks []pubsub_loader.SeekRequest
+}
+
+func (m *mockSeekingPubSub) Seek(req pubsub_loader.SeekRequest) error {
+	m.seeks = append(m.seeks, req)
+	return nil
+}
+
+func TestReplayTopic(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.topicRoutes = map[string]string{"orders": "orders"}
+
+	t.Run("no pub sub", func(t *testing.T) {
+		_, err := rt.replayTopic(pubsub_loader.SeekRequest{Topic: "orders", Offset: "10"}, time.Minute)
+		assert.Error(t, err)
+	})
+
+	t.Run("pub sub can't seek", func(t *testing.T) {
+		rt.pubSub = &mockPublishPubSub{}
+		_, err := rt.replayTopic(pubsub_loader.SeekRequest{Topic: "orders", Offset: "10"}, time.Minute)
+		assert.Equal(t, pubsub_loader.ErrSeekNotSupported, err)
+	})
+
+	t.Run("topic isn't subscribed", func(t *testing.T) {
+		rt.pubSub = &mockSeekingPubSub{}
+		_, err := rt.replayTopic(pubsub_loader.SeekRequest{Topic: "payments", Offset: "10"}, time.Minute)
+		assert.Error(t, err)
+	})
+
+	t.Run("seeks and hints the deliveries", func(t *testing.T) {
+		seeker := &mockSeekingPubSub{}
+		rt.pubSub = seeker
+		req := pubsub_loader.SeekRequest{Topic: "orders", Offset: "10"}
+
+		id, err := rt.replayTopic(req, time.Minute)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, id)
+		assert.Equal(t, []pubsub_loader.SeekRequest{req}, seeker.seeks)
+		assert.Empty(t, rt.pauser.Paused())
+
+		active, ok := rt.replays.Active("orders")
+		assert.True(t, ok)
+		assert.Equal(t, id, active)
+	})
+}
+
+func TestParseListenAddresses(t *testing.T) {
+	t.Run("empty listens on all interfaces", func(t *testing.T) {
+		assert.Empty(t, parseListenAddresses(""))
+	})
+
+	t.Run("ipv4 and ipv6 addresses", func(t *testing.T) {
+		addresses := parseListenAddresses("127.0.0.1, [::1],fd00::10,")
+		assert.Equal(t, []string{"127.0.0.1", "::1", "fd00::10"}, addresses)
+	})
+}
+
+func TestExtendedMetadataSharedByAPIs(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+
+	_, err := rt.getGRPCAPI().SetMetadata(context.Background(), &daprv1pb.SetMetadataEnvelope{Key: "team", Value: "payments"})
+	assert.NoError(t, err)
+
+	resp, err := rt.getGRPCAPI().GetMetadata(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "payments", resp.ExtendedMetadata["team"])
+	// the HTTP API reads and writes the same labels
+	value, ok := rt.extendedMetadata.Load("team")
+	assert.True(t, ok)
+	assert.Equal(t, "payments", value)
+}
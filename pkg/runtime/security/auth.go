@@ -10,8 +10,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dapr/dapr/pkg/config"
 	dapr_credentials "github.com/dapr/dapr/pkg/credentials"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	sentryv1pb "github.com/dapr/dapr/pkg/proto/sentry/v1"
 	"github.com/golang/protobuf/ptypes"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -42,6 +44,7 @@ type authenticator struct {
 	sentryAddress     string
 	currentSignedCert *SignedCertificate
 	certMutex         *sync.RWMutex
+	tracingSpec       config.TracingSpec
 }
 
 type SignedCertificate struct {
@@ -51,7 +54,7 @@ type SignedCertificate struct {
 	TrustChain    *x509.CertPool
 }
 
-func newAuthenticator(sentryAddress string, trustAnchors *x509.CertPool, certChainPem, keyPem []byte, genCSRFunc func(id string) ([]byte, []byte, error)) Authenticator {
+func newAuthenticator(sentryAddress string, trustAnchors *x509.CertPool, certChainPem, keyPem []byte, genCSRFunc func(id string) ([]byte, []byte, error), tracingSpec config.TracingSpec) Authenticator {
 	return &authenticator{
 		trustAnchors:  trustAnchors,
 		certChainPem:  certChainPem,
@@ -59,6 +62,7 @@ func newAuthenticator(sentryAddress string, trustAnchors *x509.CertPool, certCha
 		genCSRFunc:    genCSRFunc,
 		sentryAddress: sentryAddress,
 		certMutex:     &sync.RWMutex{},
+		tracingSpec:   tracingSpec,
 	}
 }
 
@@ -104,17 +108,25 @@ func (a *authenticator) CreateSignedWorkloadCert(id string) (*SignedCertificate,
 	defer conn.Close()
 
 	c := sentryv1pb.NewCAClient(conn)
-	resp, err := c.SignCertificate(context.Background(), &sentryv1pb.SignCertificateRequest{
+	ctx, call := diag.StartControlPlaneCall(context.Background(), diag.ControlPlaneSentry, "SignCertificate", a.tracingSpec)
+	resp, err := c.SignCertificate(ctx, &sentryv1pb.SignCertificateRequest{
 		CertificateSigningRequest: certPem,
 		Id:                        getSentryIdentifier(id),
 		Token:                     getToken(),
 	}, grpc_retry.WithMax(sentryMaxRetries), grpc_retry.WithPerRetryTimeout(sentrySignTimeout))
+	call.End(err)
 	if err != nil {
 		diag.DefaultMonitoring.MTLSWorkLoadCertRotationFailed("sign")
 		return nil, fmt.Errorf("error from sentry SignCertificate: %s", err)
 	}
 
 	workloadCert := resp.GetWorkloadCertificate()
+	if fips.Enabled() {
+		if err := fips.ValidateCertificatesPEM(workloadCert); err != nil {
+			diag.DefaultMonitoring.MTLSWorkLoadCertRotationFailed("fips")
+			return nil, fmt.Errorf("invalid workload cert: %w", err)
+		}
+	}
 	validTimestamp := resp.GetValidUntil()
 	expiry, err := ptypes.Timestamp(validTimestamp)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"crypto/x509"
 	"testing"
 
+	"github.com/dapr/dapr/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,7 +13,7 @@ func mockGenCSR(id string) ([]byte, []byte, error) {
 }
 
 func getTestAuthenticator() Authenticator {
-	return newAuthenticator("test", x509.NewCertPool(), nil, nil, mockGenCSR)
+	return newAuthenticator("test", x509.NewCertPool(), nil, nil, mockGenCSR, config.TracingSpec{})
 }
 
 func TestGetTrustAuthAnchors(t *testing.T) {
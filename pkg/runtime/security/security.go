@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/dapr/dapr/pkg/config"
 	"github.com/dapr/dapr/pkg/credentials"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/sentry/certs"
 )
@@ -51,14 +53,19 @@ func GetCertChain() (*credentials.CertChain, error) {
 }
 
 // GetSidecarAuthenticator returns a new authenticator with the extracted trust anchors
-func GetSidecarAuthenticator(sentryAddress string, certChain *credentials.CertChain) (Authenticator, error) {
+func GetSidecarAuthenticator(sentryAddress string, certChain *credentials.CertChain, tracingSpec config.TracingSpec) (Authenticator, error) {
 	trustAnchors, err := CertPool(certChain.RootCA)
 	if err != nil {
 		return nil, err
 	}
+	if fips.Enabled() {
+		if err := fips.ValidateCertChain(certChain.RootCA, certChain.Cert, certChain.Key); err != nil {
+			return nil, err
+		}
+	}
 	log.Info("trust anchors and cert chain extracted successfully")
 
-	return newAuthenticator(sentryAddress, trustAnchors, certChain.Cert, certChain.Key, generateCSRAndPrivateKey), nil
+	return newAuthenticator(sentryAddress, trustAnchors, certChain.Cert, certChain.Key, generateCSRAndPrivateKey, tracingSpec), nil
 }
 
 func generateCSRAndPrivateKey(id string) ([]byte, []byte, error) {
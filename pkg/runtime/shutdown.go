@@ -0,0 +1,64 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"io"
+
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+)
+
+// Shutdown asks for the graceful shutdown of the sidecar, e.g. by a Job or serverless app that is done. It doesn't
+// wait for the shutdown, which is done by Stop once ShutdownRequested is closed.
+func (a *DaprRuntime) Shutdown() {
+	a.shutdownOnce.Do(func() {
+		log.Info("shutdown requested by the app")
+		close(a.shutdownRequested)
+	})
+}
+
+// ShutdownRequested returns a channel that is closed when the app asked for the shutdown of the sidecar
+func (a *DaprRuntime) ShutdownRequested() <-chan struct{} {
+	return a.shutdownRequested
+}
+
+// closeComponents closes the components that hold resources, once no call uses them anymore
+func (a *DaprRuntime) closeComponents() {
+	for name, s := range a.stateStores {
+		// the aliases of a store are closed with the store
+		if _, alias := a.stateStoreAliases[name]; alias {
+			continue
+		}
+		if err := state_loader.Close(s); err != nil {
+			log.Warnf("error closing state store %s: %s", name, err)
+		}
+	}
+
+	closers := map[string]interface{}{}
+	for name, p := range a.pubSubs {
+		closers["pub sub "+name] = p
+	}
+	for name, b := range a.inputBindings {
+		closers["input binding "+name] = b
+	}
+	for name, b := range a.outputBindings {
+		closers["output binding "+name] = b
+	}
+	for name, s := range a.secretStores {
+		closers["secret store "+name] = s
+	}
+	for name, s := range a.configurationStores {
+		closers["configuration store "+name] = s
+	}
+
+	for name, c := range closers {
+		if closer, ok := c.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Warnf("error closing %s: %s", name, err)
+			}
+		}
+	}
+}
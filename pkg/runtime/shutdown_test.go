@@ -0,0 +1,60 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+)
+
+// closingStateStore is a state store that counts its closes
+type closingStateStore struct {
+	state.Store
+	closes int
+}
+
+func (s *closingStateStore) Close() error {
+	s.closes++
+	return nil
+}
+
+// fakeStateStoreWithoutClose is a state store that holds no resources
+type fakeStateStoreWithoutClose struct {
+	state.Store
+}
+
+func TestShutdown(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+
+	select {
+	case <-rt.ShutdownRequested():
+		t.Fatal("shutdown requested before Shutdown")
+	default:
+	}
+
+	rt.Shutdown()
+	rt.Shutdown()
+	_, open := <-rt.ShutdownRequested()
+	assert.False(t, open)
+}
+
+func TestCloseComponents(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	store := &closingStateStore{}
+	rt.stateStores["store"] = store
+	rt.registerStateStoreAliases("store", store, map[string]string{
+		state_loader.AliasesMetadataKey:      "legacy",
+		state_loader.DefaultStoreMetadataKey: "true",
+	})
+	rt.stateStores["other"] = &fakeStateStoreWithoutClose{}
+
+	rt.closeComponents()
+	assert.Equal(t, 1, store.closes)
+}
@@ -0,0 +1,347 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/namedpipe"
+)
+
+const (
+	defaultStartupRetryInterval = time.Second * 5
+	appReadyPollInterval        = time.Millisecond * 50
+	appHealthPollInterval       = time.Millisecond * 500
+	startupProbeTimeout         = time.Millisecond * 500
+)
+
+// startupSpec returns the startup policies from the global configuration
+func (a *DaprRuntime) startupSpec() config.StartupSpec {
+	if a.globalConfig == nil {
+		return config.StartupSpec{}
+	}
+	return a.globalConfig.Spec.StartupSpec
+}
+
+// validateStartupSpec makes sure all configured startup policies are known
+func validateStartupSpec(spec config.StartupSpec) error {
+	policies := map[string]string{
+		"placement":  spec.Placement,
+		"operator":   spec.Operator,
+		"appChannel": spec.AppChannel,
+	}
+	for _, c := range spec.Components {
+		policies[fmt.Sprintf("component %s", c.Name)] = c.Policy
+	}
+
+	for subsystem, policy := range policies {
+		switch policy {
+		case "", config.StartupPolicyRequired, config.StartupPolicyBlock, config.StartupPolicyWarn, config.StartupPolicyRetry:
+		default:
+			return fmt.Errorf("unknown startup policy %s for %s", policy, subsystem)
+		}
+	}
+
+	if spec.RetryInterval != "" {
+		if _, err := time.ParseDuration(spec.RetryInterval); err != nil {
+			return fmt.Errorf("invalid startup retry interval %s: %s", spec.RetryInterval, err)
+		}
+	}
+	if spec.WaitForAppHealth != "" {
+		if _, err := time.ParseDuration(spec.WaitForAppHealth); err != nil {
+			return fmt.Errorf("invalid wait for app health %s: %s", spec.WaitForAppHealth, err)
+		}
+	}
+	return nil
+}
+
+func startupPolicyOrDefault(policy, defaultPolicy string) string {
+	if policy == "" {
+		return defaultPolicy
+	}
+	return policy
+}
+
+func (a *DaprRuntime) startupRetryInterval() time.Duration {
+	if d, err := time.ParseDuration(a.startupSpec().RetryInterval); err == nil && d > 0 {
+		return d
+	}
+	return defaultStartupRetryInterval
+}
+
+func (a *DaprRuntime) componentStartupPolicy(name string) string {
+	for _, c := range a.startupSpec().Components {
+		if c.Name == name {
+			return startupPolicyOrDefault(c.Policy, config.StartupPolicyWarn)
+		}
+	}
+	return config.StartupPolicyWarn
+}
+
+// handleComponentInitFailure applies the startup policy of a component that failed to initialize.
+// An error is returned only when the component is required.
+func (a *DaprRuntime) handleComponentInitFailure(c components_v1alpha1.Component, err error, retry func() error) error {
+	subsystem := fmt.Sprintf("component %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
+	return handleStartupFailure(subsystem, a.componentStartupPolicy(c.ObjectMeta.Name), a.startupRetryInterval(), err, retry)
+}
+
+// handleStartupFailure applies a startup policy after the first initialization attempt of a subsystem failed with err.
+// retry is called to initialize the subsystem again, in the foreground for the block policy and in the background for the retry policy.
+func handleStartupFailure(subsystem, policy string, interval time.Duration, err error, retry func() error) error {
+	switch policy {
+	case config.StartupPolicyRequired:
+		return fmt.Errorf("failed to init required %s: %s", subsystem, err)
+	case config.StartupPolicyBlock:
+		log.Infof("%s is not available yet: %s. blocking until it is", subsystem, err)
+		for {
+			time.Sleep(interval)
+			if err = retry(); err == nil {
+				log.Infof("%s initialized", subsystem)
+				return nil
+			}
+			log.Debugf("failed to init %s: %s", subsystem, err)
+		}
+	case config.StartupPolicyRetry:
+		log.Warnf("failed to init %s: %s. continuing degraded and retrying in the background every %v", subsystem, err, interval)
+		go func() {
+			for {
+				time.Sleep(interval)
+				err := retry()
+				if err == nil {
+					log.Infof("%s initialized after retrying in the background", subsystem)
+					return
+				}
+				log.Debugf("failed to init %s: %s", subsystem, err)
+			}
+		}()
+		return nil
+	default:
+		log.Warnf("failed to init %s: %s", subsystem, err)
+		return nil
+	}
+}
+
+// probeTCP checks that a TCP connection can be opened to the given address
+func probeTCP(address string) error {
+	conn, err := net.DialTimeout("tcp", address, startupProbeTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func probePipe(pipe string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), startupProbeTimeout)
+	defer cancel()
+	conn, err := namedpipe.Dial(ctx, pipe)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (a *DaprRuntime) probeApp() error {
+	if a.runtimeConfig.ApplicationPipe != "" {
+		return probePipe(a.runtimeConfig.ApplicationPipe)
+	}
+	return probeTCP(net.JoinHostPort("localhost", fmt.Sprintf("%v", a.runtimeConfig.ApplicationPort)))
+}
+
+// appAddress describes where the app is listening, for the logs
+func (a *DaprRuntime) appAddress() string {
+	if a.runtimeConfig.ApplicationPipe != "" {
+		return fmt.Sprintf("pipe %s", a.runtimeConfig.ApplicationPipe)
+	}
+	return fmt.Sprintf("port %v", a.runtimeConfig.ApplicationPort)
+}
+
+func (a *DaprRuntime) probePlacement() error {
+	return probeTCP(a.runtimeConfig.PlacementServiceAddress)
+}
+
+// waitForApp applies the app channel startup policy to the app being reachable on its port.
+// The default policy blocks until the app is listening.
+func (a *DaprRuntime) waitForApp() error {
+	if a.runtimeConfig.ApplicationPort <= 0 && a.runtimeConfig.ApplicationPipe == "" {
+		return nil
+	}
+
+	log.Infof("application protocol: %s. waiting on %s.  This will block until the app is listening on it.", string(a.runtimeConfig.ApplicationProtocol), a.appAddress())
+
+	err := a.probeApp()
+	if err == nil {
+		log.Infof("application discovered on %s", a.appAddress())
+		return nil
+	}
+	if a.runtimeConfig.AppStartsAfterSidecar {
+		log.Infof("application is not listening yet on %s: it starts after the sidecar", a.appAddress())
+		return nil
+	}
+
+	policy := startupPolicyOrDefault(a.startupSpec().AppChannel, config.StartupPolicyBlock)
+	interval := a.startupRetryInterval()
+	if policy == config.StartupPolicyBlock {
+		// prevents overwhelming the OS with open connections while keeping startup fast
+		interval = appReadyPollInterval
+	}
+	return handleStartupFailure("app channel", policy, interval, err, func() error {
+		if err := a.probeApp(); err != nil {
+			return err
+		}
+		log.Infof("application discovered on %s", a.appAddress())
+		a.loadAppConfiguration()
+		return nil
+	})
+}
+
+// waitForPlacement applies the placement startup policy to the placement service being reachable.
+// The actor runtime keeps connecting to placement on its own, so the default policy only warns.
+func (a *DaprRuntime) waitForPlacement() error {
+	if a.runtimeConfig.PlacementServiceAddress == "" {
+		return nil
+	}
+
+	err := a.probePlacement()
+	if err == nil {
+		return nil
+	}
+	policy := startupPolicyOrDefault(a.startupSpec().Placement, config.StartupPolicyWarn)
+	return handleStartupFailure("placement", policy, a.startupRetryInterval(), err, a.probePlacement)
+}
+
+// startAppHealthWait defers subscribing to topics and reading input bindings until the health endpoint of the app
+// passes, or the longest wait for its health elapses. gRPC apps have no health endpoint and aren't waited for.
+func (a *DaprRuntime) startAppHealthWait() {
+	wait, err := time.ParseDuration(a.startupSpec().WaitForAppHealth)
+	if err != nil || wait <= 0 {
+		a.appReady = nil
+		return
+	}
+
+	ready := make(chan struct{})
+	a.appReady = ready
+	a.afterAppStarted(func() { go a.waitForAppHealth(ready, wait) })
+}
+
+// waitForAppHealth closes ready once the health endpoint of the app passes, or the wait elapses
+func (a *DaprRuntime) waitForAppHealth(ready chan struct{}, wait time.Duration) {
+	defer close(ready)
+	if a.appChannel == nil || a.runtimeConfig.ApplicationProtocol != HTTPProtocol {
+		return
+	}
+
+	address := fmt.Sprintf("%s/healthz", a.appChannel.GetBaseAddress())
+	log.Infof("waiting up to %v for the app to be healthy before subscribing to topics and reading input bindings", wait)
+	timeout := time.After(wait)
+	ticker := time.NewTicker(appHealthPollInterval)
+	defer ticker.Stop()
+	for {
+		err := probeHTTP(address)
+		if err == nil {
+			log.Info("app is healthy")
+			return
+		}
+		select {
+		case <-timeout:
+			log.Warnf("app is not healthy after %v: %s. subscribing to topics and reading input bindings anyway", wait, err)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startAppStartWait waits in the background for an app starting after the sidecar to be reachable, then loads
+// the app configuration and runs the funcs deferred by afterAppStarted in order
+func (a *DaprRuntime) startAppStartWait() {
+	if !a.runtimeConfig.AppStartsAfterSidecar || (a.runtimeConfig.ApplicationPort <= 0 && a.runtimeConfig.ApplicationPipe == "") {
+		return
+	}
+	if a.probeApp() == nil {
+		return
+	}
+
+	a.appStartLock.Lock()
+	a.appStarting = true
+	a.appStartLock.Unlock()
+	go func() {
+		for a.probeApp() != nil {
+			time.Sleep(appReadyPollInterval)
+		}
+		log.Infof("application discovered on %s", a.appAddress())
+		a.loadAppConfiguration()
+
+		for {
+			a.appStartLock.Lock()
+			fns := a.appStartedFns
+			a.appStartedFns = nil
+			if len(fns) == 0 {
+				a.appStarting = false
+				a.appStartLock.Unlock()
+				return
+			}
+			a.appStartLock.Unlock()
+			for _, f := range fns {
+				f()
+			}
+		}
+	}()
+}
+
+// afterAppStarted runs f right away, or once the app is reachable when it starts after the sidecar and isn't yet
+func (a *DaprRuntime) afterAppStarted(f func()) {
+	a.appStartLock.Lock()
+	if a.appStarting {
+		a.appStartedFns = append(a.appStartedFns, f)
+		a.appStartLock.Unlock()
+		return
+	}
+	a.appStartLock.Unlock()
+	f()
+}
+
+// registerAppActorTypes registers the actor types of the app configuration
+func (a *DaprRuntime) registerAppActorTypes() {
+	if a.actor == nil {
+		return
+	}
+	for _, t := range a.appConfig.Entities {
+		if err := a.actor.RegisterActorType(context.Background(), t); err != nil {
+			log.Warnf("failed to register actor type %s: %s", t, err)
+		}
+	}
+}
+
+// afterAppReady runs f once the app is ready, or right away when the runtime doesn't wait for the health of the app
+func (a *DaprRuntime) afterAppReady(f func()) {
+	if a.appReady == nil {
+		f()
+		return
+	}
+	go func() {
+		<-a.appReady
+		f()
+	}()
+}
+
+// probeHTTP checks that the given address answers 200 OK
+func probeHTTP(address string) error {
+	client := &http.Client{Timeout: startupProbeTimeout}
+	resp, err := client.Get(address)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status code %d", resp.StatusCode)
+	}
+	return nil
+}
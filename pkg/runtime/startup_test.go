@@ -0,0 +1,338 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	"github.com/dapr/dapr/pkg/components/state/inmemory"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/dapr/pkg/modes"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type failingStateStore struct {
+	state.Store
+}
+
+func (f *failingStateStore) Init(metadata state.Metadata) error {
+	return errors.New("unavailable")
+}
+
+func TestValidateStartupSpec(t *testing.T) {
+	t.Run("empty spec", func(t *testing.T) {
+		assert.NoError(t, validateStartupSpec(config.StartupSpec{}))
+	})
+
+	t.Run("known policies", func(t *testing.T) {
+		err := validateStartupSpec(config.StartupSpec{
+			Placement:  config.StartupPolicyRetry,
+			Operator:   config.StartupPolicyRequired,
+			AppChannel: config.StartupPolicyWarn,
+			Components: []config.ComponentStartupSpec{
+				{Name: "statestore", Policy: config.StartupPolicyBlock},
+			},
+			RetryInterval: "2s",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		err := validateStartupSpec(config.StartupSpec{
+			Components: []config.ComponentStartupSpec{
+				{Name: "statestore", Policy: "crash"},
+			},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid retry interval", func(t *testing.T) {
+		assert.Error(t, validateStartupSpec(config.StartupSpec{RetryInterval: "soon"}))
+	})
+}
+
+func TestHandleStartupFailure(t *testing.T) {
+	initErr := errors.New("unavailable")
+
+	t.Run("required fails", func(t *testing.T) {
+		err := handleStartupFailure("test", config.StartupPolicyRequired, time.Millisecond, initErr, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("warn continues", func(t *testing.T) {
+		err := handleStartupFailure("test", config.StartupPolicyWarn, time.Millisecond, initErr, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("block retries until success", func(t *testing.T) {
+		attempts := 0
+		err := handleStartupFailure("test", config.StartupPolicyBlock, time.Millisecond, initErr, func() error {
+			attempts++
+			if attempts < 3 {
+				return initErr
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("retry continues in the background", func(t *testing.T) {
+		var attempts int32
+		done := make(chan struct{})
+		err := handleStartupFailure("test", config.StartupPolicyRetry, time.Millisecond, initErr, func() error {
+			if atomic.AddInt32(&attempts, 1) < 2 {
+				return initErr
+			}
+			close(done)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			assert.Fail(t, "background retry did not succeed")
+		}
+	})
+}
+
+func TestComponentStartupPolicy(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.globalConfig.Spec.StartupSpec.Components = []config.ComponentStartupSpec{
+		{Name: "statestore", Policy: config.StartupPolicyRequired},
+	}
+	rt.stateStoreRegistry.Register(state_loader.New("failing", func() state.Store {
+		return &failingStateStore{}
+	}))
+	component := components_v1alpha1.Component{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "statestore",
+		},
+		Spec: components_v1alpha1.ComponentSpec{
+			Type: "state.failing",
+		},
+	}
+
+	t.Run("required component fails init", func(t *testing.T) {
+		rt.components = []components_v1alpha1.Component{component}
+		err := rt.initState(rt.stateStoreRegistry)
+		assert.Error(t, err)
+	})
+
+	t.Run("optional component only warns", func(t *testing.T) {
+		component.ObjectMeta.Name = "cache"
+		rt.components = []components_v1alpha1.Component{component}
+		err := rt.initState(rt.stateStoreRegistry)
+		assert.NoError(t, err)
+		assert.Equal(t, config.StartupPolicyWarn, rt.componentStartupPolicy("cache"))
+	})
+}
+
+func TestComponentRetriedWhileAPIReads(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.globalConfig.Spec.StartupSpec.RetryInterval = "1ms"
+	rt.globalConfig.Spec.StartupSpec.Components = []config.ComponentStartupSpec{
+		{Name: "statestore", Policy: config.StartupPolicyRetry},
+	}
+	var attempts int32
+	rt.stateStoreRegistry.Register(state_loader.New("flaky", func() state.Store {
+		if atomic.AddInt32(&attempts, 1) < 5 {
+			return &failingStateStore{}
+		}
+		return inmemory.NewStateStore()
+	}))
+	rt.components = []components_v1alpha1.Component{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: "statestore",
+			},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type: "state.flaky",
+			},
+		},
+	}
+	api := rt.getGRPCAPI()
+
+	assert.NoError(t, rt.initState(rt.stateStoreRegistry))
+	assert.Eventually(t, func() bool {
+		rt.ComponentCapabilities()
+		_, err := api.GetState(context.Background(), &daprv1pb.GetStateEnvelope{StoreName: "statestore", Key: "key"})
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStartAppHealthWait(t *testing.T) {
+	var healthy int32
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" || atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer app.Close()
+
+	newRuntime := func(wait string) *DaprRuntime {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("GetBaseAddress").Return(app.URL)
+		rt.appChannel = mockAppChannel
+		rt.globalConfig.Spec.StartupSpec.WaitForAppHealth = wait
+		return rt
+	}
+
+	t.Run("topics and input bindings start right away by default", func(t *testing.T) {
+		rt := newRuntime("")
+		rt.startAppHealthWait()
+		ran := false
+		rt.afterAppReady(func() { ran = true })
+		assert.True(t, ran)
+	})
+
+	t.Run("topics and input bindings wait for the app to be healthy", func(t *testing.T) {
+		rt := newRuntime("1m")
+		rt.startAppHealthWait()
+		ran := make(chan struct{})
+		rt.afterAppReady(func() { close(ran) })
+
+		select {
+		case <-ran:
+			t.Fatal("ran before the app is healthy")
+		case <-time.After(appHealthPollInterval * 2):
+		}
+		atomic.StoreInt32(&healthy, 1)
+		select {
+		case <-ran:
+		case <-time.After(appHealthPollInterval * 4):
+			t.Fatal("didn't run once the app is healthy")
+		}
+		atomic.StoreInt32(&healthy, 0)
+	})
+
+	t.Run("topics and input bindings start after the longest wait", func(t *testing.T) {
+		rt := newRuntime("100ms")
+		rt.startAppHealthWait()
+		select {
+		case <-rt.appReady:
+		case <-time.After(appHealthPollInterval * 4):
+			t.Fatal("app is still waited for")
+		}
+	})
+}
+
+// appStartingChannel answers the configuration and the subscriptions of an app, recording the methods invoked
+type appStartingChannel struct {
+	lock    sync.Mutex
+	methods []string
+}
+
+func (c *appStartingChannel) GetBaseAddress() string {
+	return "http://localhost"
+}
+
+func (c *appStartingChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	c.lock.Lock()
+	c.methods = append(c.methods, req.Message().Method)
+	c.lock.Unlock()
+
+	resp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	switch req.Message().Method {
+	case appConfigEndpoint:
+		resp.WithRawData([]byte(`{"entities":["cat"]}`), "application/json")
+	default:
+		resp.WithRawData([]byte(getSubscriptionsJSONString([]string{"orders"})), "application/json")
+	}
+	return resp, nil
+}
+
+func (c *appStartingChannel) invoked() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]string{}, c.methods...)
+}
+
+// subscribingPubSub records the topics subscribed
+type subscribingPubSub struct {
+	mockPublishPubSub
+	lock   sync.Mutex
+	topics []string
+}
+
+func (s *subscribingPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.topics = append(s.topics, req.Topic)
+	return nil
+}
+
+func (s *subscribingPubSub) subscribed() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string{}, s.topics...)
+}
+
+func TestAppStartsAfterSidecar(t *testing.T) {
+	// a free port the app isn't listening on yet
+	l, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.runtimeConfig.ApplicationPort = port
+	rt.runtimeConfig.AppStartsAfterSidecar = true
+	ch := &appStartingChannel{}
+	rt.appChannel = ch
+	pubSub := &subscribingPubSub{}
+
+	// the sidecar initializes without the app, which is held until the sidecar is ready
+	assert.NoError(t, rt.waitForApp())
+	rt.loadAppConfiguration()
+	rt.startAppStartWait()
+	rt.startAppHealthWait()
+	rt.subscribeTopics("pubsub", pubSub, nil, nil)
+	ran := int32(0)
+	rt.afterAppStarted(func() { atomic.StoreInt32(&ran, 1) })
+
+	time.Sleep(appReadyPollInterval * 4)
+	assert.Equal(t, []string{appConfigEndpoint}, ch.invoked(), "only the init attempt reaches the app")
+	assert.Empty(t, pubSub.subscribed())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&ran))
+
+	app, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	assert.NoError(t, err)
+	defer app.Close()
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&ran) == 1
+	}, time.Second*5, appReadyPollInterval)
+	assert.Equal(t, []string{"orders"}, pubSub.subscribed())
+	assert.Equal(t, []string{"cat"}, rt.appConfig.Entities)
+	assert.Equal(t, []string{appConfigEndpoint, appConfigEndpoint, "dapr/subscribe"}, ch.invoked())
+
+	// the app started, so the funcs run right away
+	ran = 0
+	rt.afterAppStarted(func() { ran = 1 })
+	assert.Equal(t, int32(1), ran)
+}
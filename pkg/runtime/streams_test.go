@@ -0,0 +1,50 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/components/pubsub/inmemory"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeStream(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+
+	t.Run("without pub/sub", func(t *testing.T) {
+		_, err := rt.subscribeStream([]string{"orders"})
+		assert.Error(t, err)
+	})
+
+	rt.pubSub = inmemory.NewPubSub()
+	rt.pubSubName = "pubsub"
+	rt.topicRoutes = map[string]string{"payments": "payments"}
+
+	t.Run("topics of the callbacks", func(t *testing.T) {
+		_, err := rt.subscribeStream([]string{"orders", "payments"})
+		assert.Error(t, err)
+	})
+
+	t.Run("events are delivered to the stream", func(t *testing.T) {
+		stream, err := rt.subscribeStream([]string{"orders"})
+		require.NoError(t, err)
+		defer stream.Close()
+		// the topic is subscribed to once
+		other, err := rt.subscribeStream([]string{"orders"})
+		require.NoError(t, err)
+		other.Close()
+
+		require.NoError(t, rt.pubSub.Publish(&pubsub.PublishRequest{Topic: "orders", Data: []byte("event")}))
+		event := <-stream.Events()
+		assert.Equal(t, []byte("event"), event.Data)
+		assert.True(t, stream.Ack(event.ID, nil))
+		assert.Equal(t, map[string]bool{"orders": true}, rt.streamTopics)
+	})
+}
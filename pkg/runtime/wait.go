@@ -0,0 +1,63 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultWaitTimeout is the time the sidecar waits for the control plane or for the sidecar to be ready
+	DefaultWaitTimeout = 2 * time.Minute
+
+	waitInterval = 500 * time.Millisecond
+)
+
+// WaitForAddresses blocks until each of the host:port addresses accepts TCP connections, e.g. the operator and
+// placement addresses from the init container of a pod, so that the sidecar connects to them as soon as it starts
+func WaitForAddresses(addresses []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for _, address := range addresses {
+		for {
+			conn, err := net.DialTimeout("tcp", address, waitInterval)
+			if err == nil {
+				conn.Close()
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out waiting for %s: %s", address, err)
+			}
+			time.Sleep(waitInterval)
+		}
+	}
+	return nil
+}
+
+// WaitForSidecar blocks until the health endpoint of the sidecar at address reports it's ready, i.e. its components
+// are loaded and it's connected to placement. It's run as the post start hook of the sidecar, so that the containers
+// started after the sidecar don't call it before it's ready.
+func WaitForSidecar(address string, timeout time.Duration) error {
+	client := &http.Client{Timeout: waitInterval}
+	url := fmt.Sprintf("http://%s/v1.0/healthz", address)
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < http.StatusMultipleChoices {
+				return nil
+			}
+			err = fmt.Errorf("status %s", resp.Status)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for the sidecar: %s", err)
+		}
+		time.Sleep(waitInterval)
+	}
+}
@@ -0,0 +1,52 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForAddresses(t *testing.T) {
+	t.Run("listening address", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer lis.Close()
+
+		assert.NoError(t, WaitForAddresses([]string{lis.Addr().String()}, time.Second))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		address := lis.Addr().String()
+		lis.Close()
+
+		assert.Error(t, WaitForAddresses([]string{address}, 10*time.Millisecond))
+	})
+}
+
+func TestWaitForSidecar(t *testing.T) {
+	var ready int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1.0/healthz", r.URL.Path)
+		if atomic.AddInt32(&ready, 1) < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	assert.NoError(t, WaitForSidecar(address, 5*time.Second))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&ready))
+}
@@ -0,0 +1,307 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package saga
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/sequencer"
+)
+
+const (
+	// StatusRunning is the status of a saga running the actions of its steps
+	StatusRunning = "running"
+	// StatusCompleted is the status of a saga whose actions all succeeded
+	StatusCompleted = "completed"
+	// StatusCompensating is the status of a saga running the compensations of its completed steps after an action failed
+	StatusCompensating = "compensating"
+	// StatusCompensated is the status of a saga whose completed steps were all compensated
+	StatusCompensated = "compensated"
+	// StatusFailed is the status of a saga whose compensation failed. Its remaining steps need manual intervention.
+	StatusFailed = "failed"
+
+	separator            = "||"
+	compensationAttempts = 3
+	maxIndexAttempts     = 10
+)
+
+var log = logger.NewLogger("dapr.runtime.saga")
+
+// ErrSagaExists is returned when starting a saga with the id of an existing saga
+var ErrSagaExists = errors.New("saga already exists")
+
+// Step is a step of a saga: an app method and the app method that undoes it
+type Step struct {
+	Name         string          `json:"name"`
+	Action       string          `json:"action"`
+	Compensation string          `json:"compensation,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+}
+
+// Saga is a sequence of steps whose completed steps are compensated in reverse order when one fails
+type Saga struct {
+	ID     string `json:"id"`
+	Steps  []Step `json:"steps"`
+	Status string `json:"status"`
+	// Completed is the number of steps whose action succeeded and that weren't compensated yet
+	Completed int    `json:"completed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// InvokeFn invokes an app method with the data of a step
+type InvokeFn func(method string, data []byte) error
+
+// Coordinator runs sagas and persists their progress in a state store, so that the sagas interrupted by a restart
+// are resumed. Actions and compensations must be idempotent: a step interrupted by a restart runs again.
+type Coordinator struct {
+	store  state.Store
+	prefix string
+	index  string
+	invoke InvokeFn
+}
+
+// NewCoordinator returns a coordinator of the sagas of the app, persisted in the store
+func NewCoordinator(store state.Store, appID string, invoke InvokeFn) *Coordinator {
+	return &Coordinator{
+		store:  store,
+		prefix: appID + separator + "saga" + separator,
+		index:  appID + separator + "sagas",
+		invoke: invoke,
+	}
+}
+
+// Start persists the saga and runs it in the background. A saga without an id gets a ULID.
+func (c *Coordinator) Start(s *Saga) error {
+	if len(s.Steps) == 0 {
+		return errors.New("saga has no steps")
+	}
+	for i, step := range s.Steps {
+		if step.Action == "" {
+			return fmt.Errorf("step %d of saga has no action", i)
+		}
+	}
+	if s.ID == "" {
+		id, err := sequencer.NewULID()
+		if err != nil {
+			return err
+		}
+		s.ID = id
+	}
+
+	existing, _, err := c.load(s.ID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrSagaExists
+	}
+
+	s.Status = StatusRunning
+	s.Completed = 0
+	s.Error = ""
+	etag, err := c.save(s, "")
+	if err != nil {
+		return err
+	}
+	if err := c.updateIndex(s.ID, true); err != nil {
+		return err
+	}
+
+	go c.run(s, etag)
+	return nil
+}
+
+// Get returns the saga with the id, or nil if there's none
+func (c *Coordinator) Get(id string) (*Saga, error) {
+	s, _, err := c.load(id)
+	return s, err
+}
+
+// Resume runs the sagas that didn't complete or compensate in the background
+func (c *Coordinator) Resume() error {
+	ids, _, err := c.loadIndex()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		s, etag, err := c.load(id)
+		if err != nil {
+			log.Warnf("failed to resume saga %s: %s", id, err)
+			continue
+		}
+		if s == nil {
+			continue
+		}
+		log.Infof("resuming saga %s", id)
+		go c.run(s, etag)
+	}
+	return nil
+}
+
+// run runs the steps of the saga until it ends, persisting each step. It stops when another sidecar updated the saga.
+func (c *Coordinator) run(s *Saga, etag string) {
+	for s.Status == StatusRunning || s.Status == StatusCompensating {
+		c.step(s)
+
+		var err error
+		etag, err = c.save(s, etag)
+		if err != nil {
+			log.Warnf("stopping saga %s: %s", s.ID, err)
+			return
+		}
+	}
+
+	log.Debugf("saga %s ended with status %s", s.ID, s.Status)
+	if err := c.updateIndex(s.ID, false); err != nil {
+		log.Warnf("failed to remove saga %s from the running sagas: %s", s.ID, err)
+	}
+}
+
+// step runs the next action or compensation of the saga and updates its status
+func (c *Coordinator) step(s *Saga) {
+	if s.Status == StatusRunning {
+		if s.Completed == len(s.Steps) {
+			s.Status = StatusCompleted
+			return
+		}
+		step := s.Steps[s.Completed]
+		if err := c.invoke(step.Action, step.Data); err != nil {
+			log.Warnf("step %s of saga %s failed, compensating: %s", step.Name, s.ID, err)
+			s.Status = StatusCompensating
+			s.Error = fmt.Sprintf("step %s failed: %s", step.Name, err)
+			return
+		}
+		s.Completed++
+		return
+	}
+
+	if s.Completed == 0 {
+		s.Status = StatusCompensated
+		return
+	}
+	step := s.Steps[s.Completed-1]
+	if step.Compensation != "" {
+		var err error
+		for attempt := 0; attempt < compensationAttempts; attempt++ {
+			if attempt > 0 {
+				time.Sleep(time.Duration(attempt*attempt) * 100 * time.Millisecond)
+			}
+			if err = c.invoke(step.Compensation, step.Data); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			log.Errorf("compensation of step %s of saga %s failed: %s", step.Name, s.ID, err)
+			s.Status = StatusFailed
+			s.Error = fmt.Sprintf("compensation of step %s failed: %s", step.Name, err)
+			return
+		}
+	}
+	s.Completed--
+}
+
+// save persists the saga if it wasn't updated since it had the etag, and returns its new etag
+func (c *Coordinator) save(s *Saga, etag string) (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	err = c.store.Set(&state.SetRequest{
+		Key:   c.prefix + s.ID,
+		Value: b,
+		ETag:  etag,
+		Options: state.SetStateOption{
+			Concurrency: state.FirstWrite,
+			Consistency: state.Strong,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to persist saga %s: %s", s.ID, err)
+	}
+	_, etag, err = c.load(s.ID)
+	return etag, err
+}
+
+func (c *Coordinator) load(id string) (*Saga, string, error) {
+	resp, err := c.store.Get(&state.GetRequest{Key: c.prefix + id, Options: state.GetStateOption{Consistency: state.Strong}})
+	if err != nil {
+		return nil, "", err
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		return nil, "", nil
+	}
+
+	var s Saga
+	if err := json.Unmarshal(resp.Data, &s); err != nil {
+		return nil, "", fmt.Errorf("value of saga %s is invalid: %s", id, err)
+	}
+	return &s, resp.ETag, nil
+}
+
+// updateIndex adds or removes the id to the ids of the running sagas, with compare-and-swap retried on conflicts
+func (c *Coordinator) updateIndex(id string, add bool) error {
+	var err error
+	for attempt := 0; attempt < maxIndexAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Millisecond * time.Duration(attempt*attempt))
+		}
+
+		var ids []string
+		var etag string
+		ids, etag, err = c.loadIndex()
+		if err != nil {
+			return err
+		}
+
+		updated := make([]string, 0, len(ids)+1)
+		for _, i := range ids {
+			if i != id {
+				updated = append(updated, i)
+			}
+		}
+		if add {
+			updated = append(updated, id)
+		}
+
+		b, _ := json.Marshal(updated)
+		err = c.store.Set(&state.SetRequest{
+			Key:   c.index,
+			Value: b,
+			ETag:  etag,
+			Options: state.SetStateOption{
+				Concurrency: state.FirstWrite,
+				Consistency: state.Strong,
+			},
+		})
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to update the running sagas after %d attempts: %s", maxIndexAttempts, err)
+}
+
+func (c *Coordinator) loadIndex() ([]string, string, error) {
+	resp, err := c.store.Get(&state.GetRequest{Key: c.index, Options: state.GetStateOption{Consistency: state.Strong}})
+	if err != nil {
+		return nil, "", err
+	}
+	if resp == nil {
+		return nil, "", nil
+	}
+
+	var ids []string
+	if len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, &ids); err != nil {
+			return nil, "", fmt.Errorf("running sagas are invalid: %s", err)
+		}
+	}
+	return ids, resp.ETag, nil
+}
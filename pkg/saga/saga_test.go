@@ -0,0 +1,129 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package saga
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/components/state/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+// app records the invoked methods and fails the methods in failing
+type app struct {
+	lock    sync.Mutex
+	invoked []string
+	failing map[string]bool
+}
+
+func (a *app) invoke(method string, data []byte) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.invoked = append(a.invoked, method)
+	if a.failing[method] {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+func (a *app) methods() []string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return append([]string{}, a.invoked...)
+}
+
+func waitForStatus(t *testing.T, c *Coordinator, id string, status string) *Saga {
+	var s *Saga
+	assert.Eventually(t, func() bool {
+		var err error
+		s, err = c.Get(id)
+		return err == nil && s != nil && s.Status == status
+	}, time.Second, 10*time.Millisecond)
+	return s
+}
+
+func orderSaga(id string) *Saga {
+	return &Saga{
+		ID: id,
+		Steps: []Step{
+			{Name: "reserve", Action: "reserve", Compensation: "release"},
+			{Name: "charge", Action: "charge", Compensation: "refund"},
+			{Name: "ship", Action: "ship"},
+		},
+	}
+}
+
+func TestSaga(t *testing.T) {
+	t.Run("completes when all actions succeed", func(t *testing.T) {
+		a := &app{}
+		c := NewCoordinator(inmemory.NewStateStore(), "app", a.invoke)
+		assert.NoError(t, c.Start(orderSaga("order-1")))
+
+		s := waitForStatus(t, c, "order-1", StatusCompleted)
+		assert.Equal(t, 3, s.Completed)
+		assert.Equal(t, []string{"reserve", "charge", "ship"}, a.methods())
+	})
+
+	t.Run("compensates completed steps in reverse order", func(t *testing.T) {
+		a := &app{failing: map[string]bool{"ship": true}}
+		c := NewCoordinator(inmemory.NewStateStore(), "app", a.invoke)
+		assert.NoError(t, c.Start(orderSaga("order-1")))
+
+		s := waitForStatus(t, c, "order-1", StatusCompensated)
+		assert.Equal(t, 0, s.Completed)
+		assert.Contains(t, s.Error, "ship")
+		assert.Equal(t, []string{"reserve", "charge", "ship", "refund", "release"}, a.methods())
+	})
+
+	t.Run("fails when a compensation fails", func(t *testing.T) {
+		a := &app{failing: map[string]bool{"ship": true, "refund": true}}
+		c := NewCoordinator(inmemory.NewStateStore(), "app", a.invoke)
+		assert.NoError(t, c.Start(orderSaga("order-1")))
+
+		s := waitForStatus(t, c, "order-1", StatusFailed)
+		assert.Equal(t, 2, s.Completed)
+		assert.Contains(t, s.Error, "charge")
+	})
+
+	t.Run("duplicate and invalid sagas", func(t *testing.T) {
+		a := &app{}
+		c := NewCoordinator(inmemory.NewStateStore(), "app", a.invoke)
+		assert.NoError(t, c.Start(orderSaga("order-1")))
+		assert.Equal(t, ErrSagaExists, c.Start(orderSaga("order-1")))
+		assert.Error(t, c.Start(&Saga{}))
+		assert.Error(t, c.Start(&Saga{Steps: []Step{{Name: "reserve"}}}))
+
+		s := orderSaga("")
+		assert.NoError(t, c.Start(s))
+		assert.Len(t, s.ID, 26)
+	})
+
+	t.Run("interrupted sagas are resumed", func(t *testing.T) {
+		store := inmemory.NewStateStore()
+		interrupted := orderSaga("order-1")
+		interrupted.Status = StatusRunning
+		interrupted.Completed = 1
+		b, _ := json.Marshal(interrupted)
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "app||saga||order-1", Value: b}))
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "app||sagas", Value: []byte(`["order-1"]`)}))
+
+		a := &app{}
+		c := NewCoordinator(store, "app", a.invoke)
+		assert.NoError(t, c.Resume())
+
+		waitForStatus(t, c, "order-1", StatusCompleted)
+		assert.Equal(t, []string{"charge", "ship"}, a.methods())
+		assert.Eventually(t, func() bool {
+			ids, _, err := c.loadIndex()
+			return err == nil && len(ids) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
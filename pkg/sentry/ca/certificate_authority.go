@@ -9,15 +9,18 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/dapr/dapr/pkg/credentials"
+	"github.com/dapr/dapr/pkg/fips"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/sentry/certs"
 	"github.com/dapr/dapr/pkg/sentry/config"
 	"github.com/dapr/dapr/pkg/sentry/csr"
+	"github.com/dapr/dapr/pkg/sentry/identity"
 )
 
 const (
@@ -34,7 +37,7 @@ var log = logger.NewLogger("dapr.sentry.ca")
 type CertificateAuthority interface {
 	LoadOrStoreTrustBundle() error
 	GetCACertBundle() TrustRootBundler
-	SignCSR(csrPem []byte, subject string, ttl time.Duration, isCA bool) (*SignedCertificate, error)
+	SignCSR(csrPem []byte, subject string, identity *identity.Bundle, ttl time.Duration, isCA bool) (*SignedCertificate, error)
 	ValidateCSR(csr *x509.CertificateRequest) error
 }
 
@@ -81,7 +84,9 @@ func (c *defaultCA) GetCACertBundle() TrustRootBundler {
 // SignCSR signs a request with a PEM encoded CSR cert and duration.
 // If isCA is set to true, a CA cert will be issued. If isCA is set to false, a workload
 // Certificate will be issued instead.
-func (c *defaultCA) SignCSR(csrPem []byte, subject string, ttl time.Duration, isCA bool) (*SignedCertificate, error) {
+// SignCSR signs a request for a certificate with the subject. The SPIFFE ID of the identity, if any, is set as URI SAN
+// of the certificate in place of the URIs of the request.
+func (c *defaultCA) SignCSR(csrPem []byte, subject string, identityBundle *identity.Bundle, ttl time.Duration, isCA bool) (*SignedCertificate, error) {
 	c.issuerLock.RLock()
 	defer c.issuerLock.RUnlock()
 
@@ -99,6 +104,15 @@ func (c *defaultCA) SignCSR(csrPem []byte, subject string, ttl time.Duration, is
 	if err != nil {
 		return nil, fmt.Errorf("error parsing csr pem: %s", err)
 	}
+	if fips.Enabled() {
+		if err := fips.ValidatePublicKey(cert.PublicKey); err != nil {
+			return nil, fmt.Errorf("error signing csr: %w", err)
+		}
+	}
+	cert.URIs = nil
+	if identityBundle != nil {
+		cert.URIs = []*url.URL{identityBundle.SPIFFEID()}
+	}
 
 	crtb, err := csr.GenerateCSRCertificate(cert, subject, signingCert, cert.PublicKey, signingKey.Key, certLifetime, isCA)
 	if err != nil {
@@ -177,6 +191,18 @@ func (c *defaultCA) validateAndBuildTrustBundle() (*trustRootBundle, error) {
 		log.Info("self signed certs generated and persisted successfully")
 	}
 
+	if fips.Enabled() {
+		if err := fips.ValidateCertificatesPEM(rootCertBytes); err != nil {
+			return nil, fmt.Errorf("invalid root cert: %w", err)
+		}
+		if err := fips.ValidateCertificatesPEM(issuerCertBytes); err != nil {
+			return nil, fmt.Errorf("invalid issuer cert: %w", err)
+		}
+		if err := fips.ValidatePrivateKey(issuerCreds.PrivateKey.Key); err != nil {
+			return nil, fmt.Errorf("invalid issuer key: %w", err)
+		}
+	}
+
 	// load trust anchors
 	trustAnchors, err := certs.CertPoolFromPEM(rootCertBytes)
 	if err != nil {
@@ -212,7 +238,7 @@ func (c *defaultCA) GenerateSidecarCertificate(subject string) (*certs.Credentia
 		return nil, err
 	}
 
-	signed, err := c.SignCSR(csrPem, subject, -1, false)
+	signed, err := c.SignCSR(csrPem, subject, nil, -1, false)
 	if err != nil {
 		err = fmt.Errorf("error signing csr: %s", err)
 		log.Error(err)
@@ -8,6 +8,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"sync"
 	"testing"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/dapr/dapr/pkg/sentry/certs"
 	"github.com/dapr/dapr/pkg/sentry/config"
+	"github.com/dapr/dapr/pkg/sentry/identity"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -115,12 +117,35 @@ func TestSignCSR(t *testing.T) {
 		certAuth := getTestCertAuth()
 		certAuth.LoadOrStoreTrustBundle()
 
-		resp, err := certAuth.SignCSR(certPem, "test-subject", time.Hour*24, false)
+		resp, err := certAuth.SignCSR(certPem, "test-subject", nil, time.Hour*24, false)
 		assert.Nil(t, err)
 		assert.NotNil(t, resp)
 		assert.Equal(t, time.Now().UTC().AddDate(0, 0, 1).Day(), resp.Certificate.NotAfter.UTC().Day())
 	})
 
+	t.Run("identity", func(t *testing.T) {
+		writeTestCredentialsToDisk()
+		defer cleanupCredentials()
+
+		csr := getTestCSR("test.a.com")
+		csr.URIs = []*url.URL{{Scheme: "spiffe", Host: "evil", Path: "/ns/prod/admin"}}
+		pk, _ := getECDSAPrivateKey()
+		csrb, _ := x509.CreateCertificateRequest(rand.Reader, csr, pk)
+		certPem := pem.EncodeToMemory(&pem.Block{Type: certs.Certificate, Bytes: csrb})
+
+		certAuth := getTestCertAuth()
+		certAuth.LoadOrStoreTrustBundle()
+
+		resp, err := certAuth.SignCSR(certPem, "app", identity.NewBundle("app", "default:apps", "cluster.local"), time.Hour*24, false)
+		assert.Nil(t, err)
+		assert.Len(t, resp.Certificate.URIs, 1)
+		assert.Equal(t, "spiffe://cluster.local/ns/apps/app", resp.Certificate.URIs[0].String())
+
+		bundle, err := identity.FromCertificate(resp.Certificate)
+		assert.Nil(t, err)
+		assert.Equal(t, &identity.Bundle{ID: "app", Namespace: "apps", TrustDomain: "cluster.local"}, bundle)
+	})
+
 	t.Run("invalid csr", func(t *testing.T) {
 		writeTestCredentialsToDisk()
 		defer cleanupCredentials()
@@ -130,7 +155,7 @@ func TestSignCSR(t *testing.T) {
 		certAuth := getTestCertAuth()
 		certAuth.LoadOrStoreTrustBundle()
 
-		_, err := certAuth.SignCSR(certPem, "", time.Hour*24, false)
+		_, err := certAuth.SignCSR(certPem, "", nil, time.Hour*24, false)
 		assert.NotNil(t, err)
 	})
 }
@@ -125,6 +125,7 @@ func GenerateCSRCertificate(csr *x509.CertificateRequest, subject string, signin
 	cert.IsCA = isCA
 	cert.DNSNames = csr.DNSNames
 	cert.IPAddresses = csr.IPAddresses
+	cert.URIs = csr.URIs
 	cert.Extensions = csr.Extensions
 	cert.BasicConstraintsValid = true
 	cert.SignatureAlgorithm = csr.SignatureAlgorithm
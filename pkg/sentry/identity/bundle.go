@@ -0,0 +1,70 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package identity
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	spiffeScheme = "spiffe"
+
+	// DefaultNamespace is the namespace of workloads that don't run in Kubernetes
+	DefaultNamespace = "default"
+)
+
+// Bundle is the identity of a Dapr sidecar, encoded in its workload certificate as the SPIFFE ID
+// spiffe://<trust domain>/ns/<namespace>/<app id>
+type Bundle struct {
+	ID          string
+	Namespace   string
+	TrustDomain string
+}
+
+// NewBundle returns the identity of the sidecar with the sentry ID of the signing request.
+// Kubernetes sidecars are identified by <service account>:<namespace>, others by their app ID.
+func NewBundle(appID, sentryID, trustDomain string) *Bundle {
+	namespace := DefaultNamespace
+	if parts := strings.Split(sentryID, ":"); len(parts) == 2 && parts[1] != "" {
+		namespace = parts[1]
+	}
+	return &Bundle{
+		ID:          appID,
+		Namespace:   namespace,
+		TrustDomain: trustDomain,
+	}
+}
+
+// SPIFFEID returns the SPIFFE ID of the identity
+func (b *Bundle) SPIFFEID() *url.URL {
+	return &url.URL{
+		Scheme: spiffeScheme,
+		Host:   b.TrustDomain,
+		Path:   fmt.Sprintf("/ns/%s/%s", b.Namespace, b.ID),
+	}
+}
+
+// FromCertificate returns the identity of the SPIFFE ID of a workload certificate
+func FromCertificate(cert *x509.Certificate) (*Bundle, error) {
+	for _, uri := range cert.URIs {
+		if uri.Scheme != spiffeScheme {
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+		if len(parts) != 3 || parts[0] != "ns" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("malformed SPIFFE ID %s", uri)
+		}
+		return &Bundle{
+			ID:          parts[2],
+			Namespace:   parts[1],
+			TrustDomain: uri.Host,
+		}, nil
+	}
+	return nil, fmt.Errorf("certificate of %s has no SPIFFE ID", cert.Subject.CommonName)
+}
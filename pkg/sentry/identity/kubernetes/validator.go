@@ -6,12 +6,15 @@ import (
 
 	"github.com/dapr/dapr/pkg/sentry/identity"
 	kauthapi "k8s.io/api/authentication/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s "k8s.io/client-go/kubernetes"
 	kauth "k8s.io/client-go/kubernetes/typed/authentication/v1"
 )
 
 const (
 	errPrefix = "csr validation failed"
+	// appIDAnnotation is the annotation of the app ID of a pod, defaulting to the name of the pod
+	appIDAnnotation = "dapr.io/id"
 )
 
 func NewValidator(client k8s.Interface) identity.Validator {
@@ -58,3 +61,33 @@ func (v *validator) Validate(id, token string) error {
 	}
 	return nil
 }
+
+// ValidateAppID checks that a pod running with the service account of the validated id has the app ID. The pods
+// sharing a service account can request the app IDs of each other.
+func (v *validator) ValidateAppID(id, appID string) error {
+	if appID == "" {
+		return fmt.Errorf("%s: the csr has no app id", errPrefix)
+	}
+	prts := strings.Split(id, ":")
+	if len(prts) != 2 {
+		return fmt.Errorf("%s: malformed id %s", errPrefix, id)
+	}
+	podSa, podNs := prts[0], prts[1]
+
+	pods, err := v.client.CoreV1().Pods(podNs).List(meta_v1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.serviceAccountName=%s", podSa),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to list the pods of service account %s: %s", errPrefix, id, err)
+	}
+	for _, pod := range pods.Items {
+		podAppID, ok := pod.Annotations[appIDAnnotation]
+		if !ok {
+			podAppID = pod.Name
+		}
+		if podAppID == appID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: no pod of service account %s has app id %s", errPrefix, id, appID)
+}
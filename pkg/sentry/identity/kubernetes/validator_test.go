@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	kauthapi "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fake "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
@@ -108,3 +110,44 @@ func TestValidate(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 	})
 }
+
+func TestValidateAppID(t *testing.T) {
+	// the pods of service account sa1 in namespace ns1
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor(
+		"list",
+		"pods",
+		func(action core.Action) (bool, runtime.Object, error) {
+			list := action.(core.ListAction)
+			if list.GetNamespace() != "ns1" || list.GetListRestrictions().Fields.String() != "spec.serviceAccountName=sa1" {
+				return true, &corev1.PodList{}, nil
+			}
+			return true, &corev1.PodList{Items: []corev1.Pod{
+				{ObjectMeta: meta_v1.ObjectMeta{Name: "orders-5d4f", Annotations: map[string]string{appIDAnnotation: "orders"}}},
+				{ObjectMeta: meta_v1.ObjectMeta{Name: "billing"}},
+			}}, nil
+		})
+	v := validator{
+		client: fakeClient,
+		auth:   fakeClient.AuthenticationV1(),
+	}
+
+	t.Run("app id of a pod of the service account", func(t *testing.T) {
+		assert.NoError(t, v.ValidateAppID("sa1:ns1", "orders"))
+	})
+
+	t.Run("pod name without app id annotation", func(t *testing.T) {
+		assert.NoError(t, v.ValidateAppID("sa1:ns1", "billing"))
+		assert.Error(t, v.ValidateAppID("sa1:ns1", "orders-5d4f"))
+	})
+
+	t.Run("app id of no pod of the service account", func(t *testing.T) {
+		assert.Equal(t, fmt.Errorf("%s: no pod of service account sa1:ns1 has app id admin", errPrefix), v.ValidateAppID("sa1:ns1", "admin"))
+		assert.Error(t, v.ValidateAppID("sa2:ns1", "orders"))
+		assert.Error(t, v.ValidateAppID("sa1:ns2", "orders"))
+	})
+
+	t.Run("empty app id", func(t *testing.T) {
+		assert.Equal(t, fmt.Errorf("%s: the csr has no app id", errPrefix), v.ValidateAppID("sa1:ns1", ""))
+	})
+}
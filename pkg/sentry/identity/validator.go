@@ -4,3 +4,9 @@ package identity
 type Validator interface {
 	Validate(id, token string) error
 }
+
+// AppIDValidator is implemented by the validators binding the app ID in a certificate signing request to the
+// validated ID of the requester. The app IDs of the requesters are taken on trust by the other validators.
+type AppIDValidator interface {
+	ValidateAppID(id, appID string) error
+}
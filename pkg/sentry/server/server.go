@@ -7,6 +7,8 @@ import (
 	"net"
 	"time"
 
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fips"
 	"github.com/dapr/dapr/pkg/logger"
 	sentryv1pb "github.com/dapr/dapr/pkg/proto/sentry/v1"
 	"github.com/dapr/dapr/pkg/sentry/ca"
@@ -56,7 +58,9 @@ func (s *server) Run(port int, trustBundler ca.TrustRootBundler) error {
 	}
 
 	tlsOpt := s.tlsServerOption(trustBundler)
-	s.srv = grpc.NewServer(tlsOpt)
+	s.srv = grpc.NewServer(tlsOpt,
+		grpc.UnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(diag.DefaultGRPCMonitoring.StreamServerInterceptor()))
 	sentryv1pb.RegisterCAServer(s.srv, s)
 
 	if err := s.srv.Serve(lis); err != nil {
@@ -85,7 +89,7 @@ func (s *server) tlsServerOption(trustBundler ca.TrustRootBundler) grpc.ServerOp
 			return s.certificate, nil
 		},
 	}
-	return grpc.Creds(credentials.NewTLS(config))
+	return grpc.Creds(credentials.NewTLS(fips.ConfigureTLS(config)))
 }
 
 func (s *server) getServerCertificate() (*tls.Certificate, error) {
@@ -98,7 +102,7 @@ func (s *server) getServerCertificate() (*tls.Certificate, error) {
 	issuerExp := s.certAuth.GetCACertBundle().GetIssuerCertExpiry()
 	serverCertTTL := issuerExp.Sub(now)
 
-	resp, err := s.certAuth.SignCSR(csrPem, s.certAuth.GetCACertBundle().GetTrustDomain(), serverCertTTL, false)
+	resp, err := s.certAuth.SignCSR(csrPem, s.certAuth.GetCACertBundle().GetTrustDomain(), nil, serverCertTTL, false)
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +150,17 @@ func (s *server) SignCertificate(ctx context.Context, req *sentryv1pb.SignCertif
 		return nil, err
 	}
 
-	signed, err := s.certAuth.SignCSR(csrPem, csr.Subject.CommonName, -1, false)
+	appID := csr.Subject.CommonName
+	if v, ok := s.validator.(identity.AppIDValidator); ok {
+		err = v.ValidateAppID(req.GetId(), appID)
+		if err != nil {
+			err = fmt.Errorf("error validating requester app id: %s", err)
+			log.Error(err)
+			monitoring.CertSignFailed("app_id_validation")
+			return nil, err
+		}
+	}
+	signed, err := s.certAuth.SignCSR(csrPem, appID, identity.NewBundle(appID, req.GetId(), s.certAuth.GetCACertBundle().GetTrustDomain()), -1, false)
 	if err != nil {
 		err = fmt.Errorf("error signing csr: %s", err)
 		log.Error(err)
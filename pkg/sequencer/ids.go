@@ -0,0 +1,97 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sequencer
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// UUIDKind generates random (version 4) UUIDs
+	UUIDKind = "uuid"
+	// ULIDKind generates ULIDs, which sort by their creation time
+	ULIDKind = "ulid"
+
+	// crockford's base32 alphabet used by ULIDs
+	ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+var (
+	ulidLock    sync.Mutex
+	lastULIDMs  uint64
+	lastEntropy [10]byte
+)
+
+// GenerateID returns a new id of the given kind
+func GenerateID(kind string) (string, error) {
+	switch kind {
+	case UUIDKind:
+		return uuid.New().String(), nil
+	case ULIDKind:
+		return NewULID()
+	}
+	return "", fmt.Errorf("id kind %s is not supported. supported kinds are %s and %s", kind, UUIDKind, ULIDKind)
+}
+
+// NewULID returns a new ULID. ULIDs generated within the same millisecond by this process increase monotonically.
+func NewULID() (string, error) {
+	ulidLock.Lock()
+	defer ulidLock.Unlock()
+
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	if ms <= lastULIDMs {
+		// increment the entropy of the last ULID, so that ids of the same millisecond stay sorted
+		ms = lastULIDMs
+		i := len(lastEntropy) - 1
+		for ; i >= 0; i-- {
+			lastEntropy[i]++
+			if lastEntropy[i] != 0 {
+				break
+			}
+		}
+		if i < 0 {
+			return "", fmt.Errorf("ULID entropy overflow")
+		}
+	} else {
+		if _, err := rand.Read(lastEntropy[:]); err != nil {
+			return "", err
+		}
+		lastULIDMs = ms
+	}
+
+	var b [16]byte
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> uint(40-8*i))
+	}
+	copy(b[6:], lastEntropy[:])
+	return encodeULID(b), nil
+}
+
+// encodeULID encodes the 128 bits of a ULID as 26 base32 characters, 5 bits each, with 2 leading zero bits
+func encodeULID(b [16]byte) string {
+	out := make([]byte, 26)
+	// walk the bits from the least significant end
+	var acc uint32
+	bits := uint(0)
+	j := 25
+	for i := 15; i >= 0; i-- {
+		acc |= uint32(b[i]) << bits
+		bits += 8
+		for bits >= 5 {
+			out[j] = ulidAlphabet[acc&31]
+			j--
+			acc >>= 5
+			bits -= 5
+		}
+	}
+	out[j] = ulidAlphabet[acc&31]
+	return string(out)
+}
@@ -0,0 +1,52 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sequencer
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateID(t *testing.T) {
+	t.Run("uuid", func(t *testing.T) {
+		id, err := GenerateID(UUIDKind)
+		assert.NoError(t, err)
+		_, err = uuid.Parse(id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ulids are sorted", func(t *testing.T) {
+		last := ""
+		for i := 0; i < 1000; i++ {
+			id, err := GenerateID(ULIDKind)
+			assert.NoError(t, err)
+			assert.Len(t, id, 26)
+			assert.True(t, id > last)
+			last = id
+		}
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		_, err := GenerateID("snowflake")
+		assert.Error(t, err)
+	})
+}
+
+func TestEncodeULID(t *testing.T) {
+	assert.Equal(t, "00000000000000000000000000", encodeULID([16]byte{}))
+
+	var max [16]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", encodeULID(max))
+
+	// 01ARZ3NDEKTSV4RRFFQ69G5FAV is the example ULID of the spec
+	b := [16]byte{0x01, 0x56, 0x3e, 0x3a, 0xb5, 0xd3, 0xd6, 0x76, 0x4c, 0x61, 0xef, 0xb9, 0x93, 0x02, 0xbd, 0x5b}
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", encodeULID(b))
+}
@@ -0,0 +1,92 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sequencer
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+)
+
+const maxAttempts = 10
+
+// Incrementer is implemented by state stores with native atomic counters
+type Incrementer interface {
+	// Increment atomically adds delta to the counter of key and returns its new value
+	Increment(key string, delta int64) (int64, error)
+}
+
+// lockStripes is the number of locks serializing the ids handed out by this sidecar, so that only other sidecars cause
+// conflicts. Keys share the locks so that they don't grow with the keys.
+const lockStripes = 64
+
+var locks [lockStripes]sync.Mutex
+
+// NextID returns the next id of the sequence of key in the store. The ids of a key strictly increase, starting at 1.
+// Stores without native counters create the key only if it doesn't exist and update it with compare-and-swap on its
+// ETag, retried on conflicts, so the store must have the insert feature. The store is written without its wrappers
+// such as the write-behind queue, so that an id is never handed out before it's saved.
+func NextID(store state.Store, key string) (int64, error) {
+	store = state_loader.Unwrap(store)
+	if incrementer, ok := store.(Incrementer); ok {
+		return incrementer.Increment(key, 1)
+	}
+
+	lock := keyLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Millisecond * time.Duration(attempt*attempt))
+		}
+
+		resp, err := store.Get(&state.GetRequest{Key: key, Options: state.GetStateOption{Consistency: state.Strong}})
+		if err != nil {
+			return 0, err
+		}
+
+		var current int64
+		var etag string
+		if resp != nil {
+			etag = resp.ETag
+			if len(resp.Data) > 0 {
+				current, err = strconv.ParseInt(string(resp.Data), 10, 64)
+				if err != nil {
+					return 0, fmt.Errorf("value of key %s is not a sequence: %s", key, err)
+				}
+			}
+		}
+
+		next := current + 1
+		err = state_loader.CompareAndSet(store, &state.SetRequest{
+			Key:   key,
+			Value: []byte(strconv.FormatInt(next, 10)),
+			ETag:  etag,
+			Options: state.SetStateOption{
+				Consistency: state.Strong,
+			},
+		})
+		if err == nil {
+			return next, nil
+		}
+		if err != state_loader.ErrETagMismatch {
+			return 0, fmt.Errorf("failed to update sequence %s: %s", key, err)
+		}
+	}
+	return 0, fmt.Errorf("failed to update sequence %s after %d attempts: %s", key, maxAttempts, state_loader.ErrETagMismatch)
+}
+
+func keyLock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &locks[h.Sum32()%lockStripes]
+}
@@ -0,0 +1,133 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sequencer
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type counterStore struct {
+	state.Store
+	value int64
+}
+
+func (c *counterStore) Increment(key string, delta int64) (int64, error) {
+	c.value += delta
+	return c.value, nil
+}
+
+func TestNextID(t *testing.T) {
+	t.Run("ids increase from 1", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		for i := int64(1); i <= 3; i++ {
+			id, err := NextID(store, "orders")
+			assert.NoError(t, err)
+			assert.Equal(t, i, id)
+		}
+		id, err := NextID(store, "invoices")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), id)
+	})
+
+	t.Run("concurrent calls get distinct ids", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		ids := make(chan int64, 50)
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				id, err := NextID(store, "orders")
+				assert.NoError(t, err)
+				ids <- id
+			}()
+		}
+		wg.Wait()
+		close(ids)
+
+		seen := map[int64]bool{}
+		for id := range ids {
+			assert.False(t, seen[id])
+			seen[id] = true
+		}
+		assert.Len(t, seen, 50)
+	})
+
+	t.Run("conflicts are retried", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		_, err := NextID(store, "orders")
+		assert.NoError(t, err)
+
+		// another sidecar updates the key before the next two writes
+		conflicts := 2
+		store.BeforeSet(func(req *state.SetRequest) {
+			if conflicts > 0 {
+				conflicts--
+				resp, _ := store.StateStore.Get(&state.GetRequest{Key: req.Key})
+				store.StateStore.Set(&state.SetRequest{Key: req.Key, Value: resp.Data})
+			}
+		})
+		id, err := NextID(store, "orders")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), id)
+	})
+
+	t.Run("another sidecar creating the sequence first", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		// another sidecar hands out the first id before the write
+		store.BeforeSet(func(req *state.SetRequest) {
+			store.BeforeSet(nil)
+			store.StateStore.Set(&state.SetRequest{Key: req.Key, Value: []byte("1")})
+		})
+		id, err := NextID(store, "orders")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), id)
+	})
+
+	t.Run("store errors aren't retried", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		store.FailSets(errors.New("connection refused"))
+		_, err := NextID(store, "orders")
+		assert.Error(t, err)
+		assert.Equal(t, 1, store.Sets())
+	})
+
+	t.Run("write-behind queue is bypassed", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		store.FailSets(errors.New("connection refused"))
+		dir, err := ioutil.TempDir("", "sequencer")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		queued, err := state_loader.WithWriteBehind(store, map[string]string{state_loader.WriteBehindMetadataKey: "true"}, dir)
+		require.NoError(t, err)
+
+		_, err = NextID(queued, "orders")
+		assert.Error(t, err)
+	})
+
+	t.Run("native counters", func(t *testing.T) {
+		id, err := NextID(&counterStore{value: 41}, "orders")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), id)
+	})
+
+	t.Run("value is not a sequence", func(t *testing.T) {
+		store := daprt.NewFaultyStateStore()
+		require.NoError(t, store.Set(&state.SetRequest{Key: "orders", Value: []byte("abc")}))
+		_, err := NextID(store, "orders")
+		assert.Error(t, err)
+	})
+}
@@ -206,6 +206,48 @@ func (_m *MockActors) GetReminder(ctx context.Context, req *actors.GetReminderRe
 	return nil, r0
 }
 
+// ListReminders provides a mock function with given fields: req
+func (_m *MockActors) ListReminders(ctx context.Context, req *actors.ListRemindersRequest) (*actors.ListRemindersResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *actors.ListRemindersResponse
+	if rf, ok := ret.Get(0).(func(*actors.ListRemindersRequest) *actors.ListRemindersResponse); ok {
+		r0 = rf(req)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*actors.ListRemindersResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*actors.ListRemindersRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTimers provides a mock function with given fields: req
+func (_m *MockActors) ListTimers(ctx context.Context, req *actors.ListTimersRequest) ([]actors.ActorTimer, error) {
+	ret := _m.Called(req)
+
+	var r0 []actors.ActorTimer
+	if rf, ok := ret.Get(0).(func(*actors.ListTimersRequest) []actors.ActorTimer); ok {
+		r0 = rf(req)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]actors.ActorTimer)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*actors.ListTimersRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetActiveActorsCount provides a mock function
 func (_m *MockActors) GetActiveActorsCount(ctx context.Context) []actors.ActiveActorsCount {
 	_m.Called()
@@ -220,3 +262,50 @@ func (_m *MockActors) GetActiveActorsCount(ctx context.Context) []actors.ActiveA
 		},
 	}
 }
+
+// RegisterActorType provides a mock function with given fields: actorType
+func (_m *MockActors) RegisterActorType(ctx context.Context, actorType string) error {
+	ret := _m.Called(actorType)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(actorType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnregisterActorType provides a mock function with given fields: actorType
+func (_m *MockActors) UnregisterActorType(ctx context.Context, actorType string) error {
+	ret := _m.Called(actorType)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(actorType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetHostedActorTypes provides a mock function
+func (_m *MockActors) GetHostedActorTypes(ctx context.Context) []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]string)
+	}
+
+	return r0
+}
+
+// Stop provides a mock function
+func (_m *MockActors) Stop() {
+	_m.Called()
+}
@@ -0,0 +1,281 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package cluster runs a placement service and sidecars hosting actors in process, to test the placement of actors
+// while hosts leave and join. The sidecars share an in-memory actor state store, and their apps are fake apps
+// checking that every actor is active on a single host at a time.
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	state_inmemory "github.com/dapr/dapr/pkg/components/state/inmemory"
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/dapr/dapr/pkg/placement"
+	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+	"github.com/dapr/dapr/pkg/runtime"
+	"github.com/dapr/dapr/pkg/testing/fakeapp"
+	"google.golang.org/grpc"
+)
+
+// AppID is the app ID of the sidecars, the hosts being replicas of the same app
+const AppID = "cluster"
+
+const stateStoreComponent = `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: statestore
+spec:
+  type: state.in-memory
+  metadata:
+  - name: actorStateStore
+    value: "true"
+`
+
+// Cluster is a placement service and the sidecars connected to it
+type Cluster struct {
+	placementAddress string
+	placementServer  *grpc.Server
+	componentsPath   string
+	store            *state_inmemory.StateStore
+
+	lock  sync.Mutex
+	hosts map[string]*Host
+	// active are the hosts of the active actors, by actor type and ID
+	active     map[string]string
+	violations []string
+}
+
+// Host is a sidecar of the cluster and its app
+type Host struct {
+	Name string
+	// App is the fake app of the host. Its actors answer their calls with the name of the host.
+	App *fakeapp.App
+
+	runtime  *runtime.DaprRuntime
+	httpPort int
+}
+
+// New starts the placement service of a cluster without hosts
+func New() (*Cluster, error) {
+	componentsPath, err := ioutil.TempDir("", "cluster")
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(filepath.Join(componentsPath, "statestore.yaml"), []byte(stateStoreComponent), 0600)
+	if err != nil {
+		os.RemoveAll(componentsPath)
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		os.RemoveAll(componentsPath)
+		return nil, err
+	}
+	server := grpc.NewServer()
+	placementv1pb.RegisterPlacementServiceServer(server, placement.NewPlacementService())
+	go server.Serve(listener)
+
+	return &Cluster{
+		placementAddress: listener.Addr().String(),
+		placementServer:  server,
+		componentsPath:   componentsPath,
+		store:            state_inmemory.NewStateStore(),
+		hosts:            map[string]*Host{},
+		active:           map[string]string{},
+	}, nil
+}
+
+// StartHost starts a sidecar hosting the actor types, and its app
+func (c *Cluster) StartHost(name string, actorTypes ...string) (*Host, error) {
+	c.lock.Lock()
+	_, exists := c.hosts[name]
+	c.lock.Unlock()
+	if exists {
+		return nil, fmt.Errorf("host %s is already running", name)
+	}
+
+	app := fakeapp.New()
+	for _, t := range actorTypes {
+		app.HostActors(t, c.actorHandler(name))
+	}
+	appPort, err := app.StartHTTP()
+	if err != nil {
+		return nil, err
+	}
+	ports, err := freePorts(3)
+	if err != nil {
+		app.Close()
+		return nil, err
+	}
+
+	runtimeConfig := runtime.NewRuntimeConfig(AppID, c.placementAddress, "", "", "", c.componentsPath, string(runtime.HTTPProtocol),
+		string(modes.StandaloneMode), ports[0], ports[1], ports[2], appPort, 0, false, -1, false, "")
+	runtimeConfig.ListenAddresses = []string{"127.0.0.1"}
+	runtimeConfig.InternalAdvertiseAddress = "127.0.0.1"
+	rt := runtime.NewDaprRuntime(runtimeConfig, config.LoadDefaultConfiguration())
+	err = rt.Run(runtime.WithStates(state_loader.New("in-memory", func() state.Store {
+		return c.store
+	})))
+	if err != nil {
+		app.Close()
+		return nil, err
+	}
+
+	h := &Host{Name: name, App: app, runtime: rt, httpPort: ports[0]}
+	c.lock.Lock()
+	c.hosts[name] = h
+	c.lock.Unlock()
+	return h, nil
+}
+
+// KillHost stops a sidecar and its app. The sidecar disconnects from the placement service, and its actors are placed
+// on the other hosts.
+func (c *Cluster) KillHost(name string) error {
+	c.lock.Lock()
+	h, ok := c.hosts[name]
+	delete(c.hosts, name)
+	c.lock.Unlock()
+	if !ok {
+		return fmt.Errorf("host %s isn't running", name)
+	}
+
+	h.App.Close()
+	h.runtime.Stop()
+
+	// the actors of the host died with its app
+	c.lock.Lock()
+	for actor, host := range c.active {
+		if host == name {
+			delete(c.active, actor)
+		}
+	}
+	c.lock.Unlock()
+	return nil
+}
+
+// Host returns a running host, nil if it isn't running
+func (c *Cluster) Host(name string) *Host {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.hosts[name]
+}
+
+// ActiveHost returns the host an actor is active on, empty if it isn't active
+func (c *Cluster) ActiveHost(actorType, actorID string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.active[actorType+"/"+actorID]
+}
+
+// Violations returns the calls of actors while they were active on another host
+func (c *Cluster) Violations() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]string{}, c.violations...)
+}
+
+// Close kills the hosts and stops the placement service
+func (c *Cluster) Close() {
+	c.lock.Lock()
+	names := []string{}
+	for name := range c.hosts {
+		names = append(names, name)
+	}
+	c.lock.Unlock()
+
+	for _, name := range names {
+		c.KillHost(name)
+	}
+	c.placementServer.Stop()
+	os.RemoveAll(c.componentsPath)
+}
+
+// actorHandler tracks the hosts of the actors of the app of a host, and answers their calls with its name
+func (c *Cluster) actorHandler(host string) fakeapp.Handler {
+	return func(call fakeapp.Call) fakeapp.Response {
+		actor := call.ActorType + "/" + call.ActorID
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if call.Kind == fakeapp.CallActorDeactivate {
+			if c.active[actor] == host {
+				delete(c.active, actor)
+			}
+			return fakeapp.Response{}
+		}
+		if other := c.active[actor]; other != "" && other != host {
+			c.violations = append(c.violations, fmt.Sprintf("%s call %s of actor %s on host %s while active on host %s", call.Kind, call.Name, actor, host, other))
+		}
+		c.active[actor] = host
+		return fakeapp.Response{Data: []byte(host)}
+	}
+}
+
+// InvokeActor calls a method of an actor through the sidecar, and returns the name of the host that ran it
+func (h *Host) InvokeActor(actorType, actorID, method string) (string, error) {
+	resp, err := h.request(http.MethodPost, fmt.Sprintf("actors/%s/%s/method/%s", actorType, actorID, method), nil)
+	return string(resp), err
+}
+
+// CreateReminder creates a reminder of an actor through the sidecar
+func (h *Host) CreateReminder(actorType, actorID, name string, dueTime, period time.Duration) error {
+	body, err := json.Marshal(struct {
+		DueTime string `json:"dueTime"`
+		Period  string `json:"period"`
+	}{dueTime.String(), period.String()})
+	if err != nil {
+		return err
+	}
+	_, err = h.request(http.MethodPost, fmt.Sprintf("actors/%s/%s/reminders/%s", actorType, actorID, name), body)
+	return err
+}
+
+func (h *Host) request(method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("http://127.0.0.1:%d/v1.0/%s", h.httpPort, path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s returned status %d: %s", method, path, resp.StatusCode, data)
+	}
+	return data, nil
+}
+
+// freePorts returns free local ports
+func freePorts(count int) ([]int, error) {
+	ports := []int{}
+	for i := 0; i < count; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+		defer l.Close()
+		ports = append(ports, l.Addr().(*net.TCPAddr).Port)
+	}
+	return ports, nil
+}
@@ -0,0 +1,156 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cluster
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/testing/fakeapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	actorType = "cart"
+	actorIDs  = 10
+)
+
+func newTestCluster(t *testing.T, hosts ...string) *Cluster {
+	c, err := New()
+	require.NoError(t, err)
+	for _, name := range hosts {
+		_, err := c.StartHost(name, actorType)
+		require.NoError(t, err)
+	}
+	return c
+}
+
+// waitForPlacement calls the actors through the host until their calls are placed on one of the hosts
+func waitForPlacement(t *testing.T, via *Host, hosts ...string) map[string]string {
+	placed := map[string]string{}
+	require.Eventually(t, func() bool {
+		for i := 0; i < actorIDs; i++ {
+			id := fmt.Sprintf("%d", i)
+			host, err := via.InvokeActor(actorType, id, "get")
+			if err != nil || !contains(hosts, host) {
+				return false
+			}
+			placed[id] = host
+		}
+		return true
+	}, 20*time.Second, 100*time.Millisecond)
+	return placed
+}
+
+func hostsOf(placed map[string]string) []string {
+	hosts := []string{}
+	for _, host := range placed {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+func activations(h *Host, actorID string) []fakeapp.Call {
+	return actorCalls(h, fakeapp.CallActorActivate, actorID)
+}
+
+func deactivations(h *Host, actorID string) []fakeapp.Call {
+	return actorCalls(h, fakeapp.CallActorDeactivate, actorID)
+}
+
+func actorCalls(h *Host, kind, actorID string) []fakeapp.Call {
+	calls := []fakeapp.Call{}
+	for _, call := range h.App.Calls(kind, "") {
+		if call.ActorID == actorID {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPlacementFailover(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts sidecars")
+	}
+	c := newTestCluster(t, "a", "b")
+	defer c.Close()
+
+	// every host places the actors the same way
+	placed := waitForPlacement(t, c.Host("a"), "a", "b")
+	assert.Equal(t, placed, waitForPlacement(t, c.Host("b"), "a", "b"))
+	assert.Contains(t, hostsOf(placed), "a")
+	assert.Contains(t, hostsOf(placed), "b")
+
+	t.Run("actors of a killed host are placed on the other hosts", func(t *testing.T) {
+		require.NoError(t, c.KillHost("a"))
+		waitForPlacement(t, c.Host("b"), "b")
+
+		// the actors already on the surviving host stay active
+		for id, host := range placed {
+			if host == "b" {
+				assert.Len(t, activations(c.Host("b"), id), 1, "actor %s was activated again", id)
+			}
+		}
+	})
+
+	t.Run("actors are moved to a rejoining host and deactivated on their previous host", func(t *testing.T) {
+		_, err := c.StartHost("c", actorType)
+		require.NoError(t, err)
+		moved := waitForPlacement(t, c.Host("c"), "b", "c")
+		assert.Equal(t, moved, waitForPlacement(t, c.Host("b"), "b", "c"))
+
+		for id, host := range moved {
+			if host == "c" {
+				assert.Eventually(t, func() bool {
+					return len(deactivations(c.Host("b"), id)) > 0
+				}, 5*time.Second, 10*time.Millisecond, "actor %s wasn't deactivated on its previous host", id)
+			}
+		}
+	})
+
+	assert.Empty(t, c.Violations())
+}
+
+func TestReminderFailover(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts sidecars")
+	}
+	c := newTestCluster(t, "a", "b")
+	defer c.Close()
+
+	placed := waitForPlacement(t, c.Host("a"), "a", "b")
+	id := ""
+	for actorID, host := range placed {
+		if host == "a" {
+			id = actorID
+			break
+		}
+	}
+	require.NotEmpty(t, id, "no actor placed on host a")
+
+	// actors register their reminders through the sidecar of their host
+	require.NoError(t, c.Host("a").CreateReminder(actorType, id, "checkout", 0, 200*time.Millisecond))
+	_, err := c.Host("a").App.WaitForCalls(fakeapp.CallReminder, "checkout", 2, 5*time.Second)
+	require.NoError(t, err)
+
+	// the reminder keeps firing on the host the actor is placed on after its host is killed
+	require.NoError(t, c.KillHost("a"))
+	_, err = c.Host("b").App.WaitForCalls(fakeapp.CallReminder, "checkout", 2, 10*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", c.ActiveHost(actorType, id))
+	assert.Empty(t, c.Violations())
+}
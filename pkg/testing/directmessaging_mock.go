@@ -4,10 +4,13 @@ package testing
 
 import (
 	context "context"
+	io "io"
 
 	mock "github.com/stretchr/testify/mock"
 
+	messaging "github.com/dapr/dapr/pkg/messaging"
 	v1 "github.com/dapr/dapr/pkg/messaging/v1"
+	grpc "google.golang.org/grpc"
 )
 
 // MockDirectMessaging is an autogenerated mock type for the MockDirectMessaging type
@@ -37,3 +40,79 @@ func (_m *MockDirectMessaging) Invoke(ctx context.Context, targetAppID string, r
 
 	return r0, r1
 }
+
+// InvokeStream provides a mock function with given fields: ctx, targetAppID, req, body
+func (_m *MockDirectMessaging) InvokeStream(ctx context.Context, targetAppID string, req *v1.InvokeMethodRequest, body io.Reader) (*v1.InvokeMethodResponse, io.ReadCloser, error) {
+	ret := _m.Called(ctx, targetAppID, req, body)
+
+	var r0 *v1.InvokeMethodResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.InvokeMethodRequest, io.Reader) *v1.InvokeMethodResponse); ok {
+		r0 = rf(ctx, targetAppID, req, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.InvokeMethodResponse)
+		}
+	}
+
+	var r1 io.ReadCloser
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1.InvokeMethodRequest, io.Reader) io.ReadCloser); ok {
+		r1 = rf(ctx, targetAppID, req, body)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, *v1.InvokeMethodRequest, io.Reader) error); ok {
+		r2 = rf(ctx, targetAppID, req, body)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeliveryStatus provides a mock function with given fields: messageID
+func (_m *MockDirectMessaging) DeliveryStatus(messageID string) (messaging.DeliveryStatus, bool) {
+	ret := _m.Called(messageID)
+
+	var r0 messaging.DeliveryStatus
+	if rf, ok := ret.Get(0).(func(string) messaging.DeliveryStatus); ok {
+		r0 = rf(messageID)
+	} else {
+		r0 = ret.Get(0).(messaging.DeliveryStatus)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(messageID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// RemoteConnection provides a mock function with given fields: targetAppID
+func (_m *MockDirectMessaging) RemoteConnection(targetAppID string) (*grpc.ClientConn, error) {
+	ret := _m.Called(targetAppID)
+
+	var r0 *grpc.ClientConn
+	if rf, ok := ret.Get(0).(func(string) *grpc.ClientConn); ok {
+		r0 = rf(targetAppID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*grpc.ClientConn)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(targetAppID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
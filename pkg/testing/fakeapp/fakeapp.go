@@ -0,0 +1,318 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package fakeapp is a scriptable app implementing the callbacks of the runtime over HTTP and gRPC: service
+// invocation, topic subscriptions, input bindings and actors. Integration tests script its responses, point the
+// runtime at it, and assert the calls it received.
+package fakeapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+)
+
+// Kinds of the calls received by the app
+const (
+	CallInvoke          = "invoke"
+	CallTopic           = "topic"
+	CallBinding         = "binding"
+	CallActor           = "actor"
+	CallReminder        = "reminder"
+	CallTimer           = "timer"
+	CallActorActivate   = "actorActivate"
+	CallActorDeactivate = "actorDeactivate"
+)
+
+// Call is a call received by the app
+type Call struct {
+	Kind string
+	// Name is the method, topic, binding, actor method, reminder or timer of the call
+	Name      string
+	ActorType string
+	ActorID   string
+	Verb      string
+	Data      []byte
+	// ContentType is the content type of the data, the data content type of the cloud event for gRPC topic events
+	ContentType string
+	Metadata    map[string][]string
+}
+
+// Response is the response of the app to a call
+type Response struct {
+	// Status is the HTTP status of the response, 200 if zero. gRPC calls with an error status fail with the matching
+	// code.
+	Status      int
+	Data        []byte
+	ContentType string
+}
+
+// Handler returns the response of the app to a call
+type Handler func(call Call) Response
+
+type subscription struct {
+	route    string
+	metadata map[string]string
+	handler  Handler
+}
+
+// App is a fake app. Its handlers are registered before the runtime starts, and its calls can be asserted while it
+// runs.
+type App struct {
+	lock          sync.Mutex
+	methods       map[string]Handler
+	subscriptions map[string]subscription
+	bindings      map[string]Handler
+	actors        map[string]Handler
+	config        config.ApplicationConfig
+	calls         []Call
+	// received is closed and replaced when a call is received
+	received chan struct{}
+
+	servers []func()
+}
+
+// New returns a fake app without handlers, answering 404 to every call but the runtime configuration
+func New() *App {
+	return &App{
+		methods:       map[string]Handler{},
+		subscriptions: map[string]subscription{},
+		bindings:      map[string]Handler{},
+		actors:        map[string]Handler{},
+		received:      make(chan struct{}),
+	}
+}
+
+// HandleMethod handles the invocations of a method
+func (a *App) HandleMethod(method string, handler Handler) *App {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.methods[strings.Trim(method, "/")] = handler
+	return a
+}
+
+// Subscribe subscribes the app to a topic, receiving its events on route over HTTP
+func (a *App) Subscribe(topic, route string, metadata map[string]string, handler Handler) *App {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.subscriptions[topic] = subscription{route: strings.Trim(route, "/"), metadata: metadata, handler: handler}
+	return a
+}
+
+// HandleBinding subscribes the app to the events of an input binding
+func (a *App) HandleBinding(name string, handler Handler) *App {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.bindings[name] = handler
+	return a
+}
+
+// HostActors registers an actor type hosted by the app. The handler receives the activations, method calls, reminders,
+// timers and deactivations of the actors of the type.
+func (a *App) HostActors(actorType string, handler Handler) *App {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if _, ok := a.actors[actorType]; !ok {
+		a.config.Entities = append(a.config.Entities, actorType)
+	}
+	a.actors[actorType] = handler
+	return a
+}
+
+// Configure sets the actor settings returned to the runtime. The hosted actor types are kept.
+func (a *App) Configure(appConfig config.ApplicationConfig) *App {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	appConfig.Entities = a.config.Entities
+	a.config = appConfig
+	return a
+}
+
+// Calls returns the calls of a kind received by the app, all of them if name is empty
+func (a *App) Calls(kind, name string) []Call {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.matchingCalls(kind, name)
+}
+
+// WaitForCalls waits for the app to receive count calls of a kind, and returns them. It returns an error with the
+// calls received so far if they aren't received within timeout.
+func (a *App) WaitForCalls(kind, name string, count int, timeout time.Duration) ([]Call, error) {
+	deadline := time.After(timeout)
+	for {
+		a.lock.Lock()
+		calls := a.matchingCalls(kind, name)
+		received := a.received
+		a.lock.Unlock()
+
+		if len(calls) >= count {
+			return calls, nil
+		}
+		select {
+		case <-received:
+		case <-deadline:
+			return calls, fmt.Errorf("received %d of %d %s calls %s", len(calls), count, kind, name)
+		}
+	}
+}
+
+// Reset forgets the calls received by the app
+func (a *App) Reset() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.calls = nil
+}
+
+// Close stops the servers of the app
+func (a *App) Close() {
+	a.lock.Lock()
+	servers := a.servers
+	a.servers = nil
+	a.lock.Unlock()
+
+	for _, stop := range servers {
+		stop()
+	}
+}
+
+// matchingCalls returns the received calls of a kind and name. The lock must be held.
+func (a *App) matchingCalls(kind, name string) []Call {
+	calls := []Call{}
+	for _, c := range a.calls {
+		if c.Kind == kind && (name == "" || c.Name == name) {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// handle records a call and returns the response of its handler
+func (a *App) handle(call Call, handler Handler) Response {
+	a.lock.Lock()
+	a.calls = append(a.calls, call)
+	close(a.received)
+	a.received = make(chan struct{})
+	a.lock.Unlock()
+
+	if handler == nil {
+		return Response{Status: http.StatusOK}
+	}
+	resp := handler(call)
+	if resp.Status == 0 {
+		resp.Status = http.StatusOK
+	}
+	return resp
+}
+
+// dispatch routes a call of the runtime to its handler like an HTTP app: method is the path of the call, without
+// the leading slash
+func (a *App) dispatch(verb, method string, data []byte, contentType string, metadata map[string][]string) Response {
+	method = strings.Trim(method, "/")
+	call := Call{Name: method, Verb: verb, Data: data, ContentType: contentType, Metadata: metadata}
+
+	switch {
+	case verb == http.MethodGet && method == "dapr/subscribe":
+		return a.subscriptionsResponse()
+	case verb == http.MethodGet && method == "dapr/config":
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		return jsonResponse(a.config)
+	case verb == http.MethodGet && method == "healthz":
+		return Response{Status: http.StatusOK}
+	case strings.HasPrefix(method, "actors/"):
+		return a.dispatchActor(call)
+	}
+
+	a.lock.Lock()
+	var handler Handler
+	found := false
+	for topic, s := range a.subscriptions {
+		if s.route == method {
+			call.Kind, call.Name, handler, found = CallTopic, topic, s.handler, true
+			break
+		}
+	}
+	if !found {
+		if handler, found = a.bindings[method]; found {
+			call.Kind = CallBinding
+			if verb == http.MethodOptions {
+				a.lock.Unlock()
+				return Response{Status: http.StatusOK}
+			}
+		}
+	}
+	if !found {
+		call.Kind = CallInvoke
+		handler, found = a.methods[method]
+	}
+	a.lock.Unlock()
+
+	if !found {
+		return Response{Status: http.StatusNotFound}
+	}
+	return a.handle(call, handler)
+}
+
+// dispatchActor routes the calls of the runtime to the actors: actors/<type>/<id>/method/<method> for method calls,
+// reminders and timers, and actors/<type>/<id> for activations and deactivations
+func (a *App) dispatchActor(call Call) Response {
+	parts := strings.SplitN(call.Name, "/", 5)
+	if len(parts) < 3 {
+		return Response{Status: http.StatusNotFound}
+	}
+	a.lock.Lock()
+	handler, ok := a.actors[parts[1]]
+	a.lock.Unlock()
+	if !ok {
+		return Response{Status: http.StatusNotFound}
+	}
+
+	call.ActorType, call.ActorID = parts[1], parts[2]
+	switch {
+	case len(parts) == 3 && call.Verb == http.MethodPost:
+		call.Kind, call.Name = CallActorActivate, ""
+	case len(parts) == 3 && call.Verb == http.MethodDelete:
+		call.Kind, call.Name = CallActorDeactivate, ""
+	case len(parts) == 5 && parts[3] == "method":
+		call.Kind, call.Name = CallActor, parts[4]
+		if name := strings.TrimPrefix(parts[4], "remind/"); name != parts[4] {
+			call.Kind, call.Name = CallReminder, name
+		} else if name := strings.TrimPrefix(parts[4], "timer/"); name != parts[4] {
+			call.Kind, call.Name = CallTimer, name
+		}
+	default:
+		return Response{Status: http.StatusNotFound}
+	}
+	return a.handle(call, handler)
+}
+
+func (a *App) subscriptionsResponse() Response {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	subscriptions := []runtime_pubsub.Subscription{}
+	for topic, s := range a.subscriptions {
+		subscriptions = append(subscriptions, runtime_pubsub.Subscription{Topic: topic, Route: s.route, Metadata: s.metadata})
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Topic < subscriptions[j].Topic
+	})
+	return jsonResponse(subscriptions)
+}
+
+func jsonResponse(v interface{}) Response {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return Response{Status: http.StatusInternalServerError, Data: []byte(err.Error())}
+	}
+	return Response{Status: http.StatusOK, Data: b, ContentType: "application/json"}
+}
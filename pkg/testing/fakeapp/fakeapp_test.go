@@ -0,0 +1,168 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package fakeapp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/logger"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	daprclientv1pb "github.com/dapr/dapr/pkg/proto/daprclient/v1"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testLogger = logger.NewLogger("dapr.testing.fakeapp")
+
+func newTestApp() *App {
+	return New().
+		HandleMethod("echo", Echo).
+		Subscribe("orders", "/orders", map[string]string{"timeout": "1s"}, Sequence(Reply(http.StatusInternalServerError, nil), Echo)).
+		HandleBinding("queue", Reply(http.StatusOK, []byte(`"done"`))).
+		HostActors("cart", Echo)
+}
+
+func invoke(app *App, verb, method string, data []byte) *invokev1.InvokeMethodResponse {
+	req := invokev1.NewInvokeMethodRequest(method)
+	req.WithHTTPExtension(verb, "")
+	req.WithRawData(data, invokev1.JSONContentType)
+	resp, _ := app.Channel().InvokeMethod(context.Background(), req)
+	return resp
+}
+
+func TestChannel(t *testing.T) {
+	app := newTestApp()
+
+	t.Run("subscriptions", func(t *testing.T) {
+		subscriptions := runtime_pubsub.GetSubscriptionsHTTP(app.Channel(), testLogger)
+		assert.Equal(t, []runtime_pubsub.Subscription{{Topic: "orders", Route: "orders", Metadata: map[string]string{"timeout": "1s"}}}, subscriptions)
+	})
+
+	t.Run("topic events follow the script", func(t *testing.T) {
+		assert.Equal(t, int32(http.StatusInternalServerError), invoke(app, http.MethodPost, "orders", []byte("1")).Status().Code)
+		assert.Equal(t, int32(http.StatusOK), invoke(app, http.MethodPost, "orders", []byte("1")).Status().Code)
+		assert.Len(t, app.Calls(CallTopic, "orders"), 2)
+	})
+
+	t.Run("bindings", func(t *testing.T) {
+		assert.Equal(t, int32(http.StatusOK), invoke(app, http.MethodOptions, "queue", nil).Status().Code)
+		assert.Equal(t, int32(http.StatusNotFound), invoke(app, http.MethodOptions, "other", nil).Status().Code)
+		_, data := invoke(app, http.MethodPost, "queue", []byte("event")).RawData()
+		assert.Equal(t, `"done"`, string(data))
+		assert.Len(t, app.Calls(CallBinding, "queue"), 1)
+	})
+
+	t.Run("actors", func(t *testing.T) {
+		_, data := invoke(app, http.MethodGet, "dapr/config", nil).RawData()
+		assert.Contains(t, string(data), `"entities":["cart"]`)
+
+		invoke(app, http.MethodPost, "actors/cart/1", nil)
+		invoke(app, http.MethodPut, "actors/cart/1/method/add", []byte("item"))
+		invoke(app, http.MethodPut, "actors/cart/1/method/remind/checkout", nil)
+		invoke(app, http.MethodPut, "actors/cart/1/method/timer/expire", nil)
+		invoke(app, http.MethodDelete, "actors/cart/1", nil)
+		assert.Equal(t, int32(http.StatusNotFound), invoke(app, http.MethodPut, "actors/other/1/method/add", nil).Status().Code)
+
+		calls := app.Calls(CallActor, "add")
+		assert.Len(t, calls, 1)
+		assert.Equal(t, "cart", calls[0].ActorType)
+		assert.Equal(t, "1", calls[0].ActorID)
+		assert.Equal(t, []byte("item"), calls[0].Data)
+		assert.Len(t, app.Calls(CallReminder, "checkout"), 1)
+		assert.Len(t, app.Calls(CallTimer, "expire"), 1)
+		assert.Len(t, app.Calls(CallActorActivate, ""), 1)
+		assert.Len(t, app.Calls(CallActorDeactivate, ""), 1)
+	})
+
+	t.Run("unknown methods", func(t *testing.T) {
+		assert.Equal(t, int32(http.StatusNotFound), invoke(app, http.MethodPost, "unknown", nil).Status().Code)
+		assert.Empty(t, app.Calls(CallInvoke, "unknown"))
+	})
+}
+
+func TestHTTP(t *testing.T) {
+	app := newTestApp()
+	port, err := app.StartHTTP()
+	assert.NoError(t, err)
+	defer app.Close()
+
+	resp, err := http.Post(fmt.Sprintf("http://127.0.0.1:%d/echo", port), "text/plain", bytes.NewBufferString("hello"))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "hello", string(body))
+
+	calls := app.Calls(CallInvoke, "echo")
+	assert.Len(t, calls, 1)
+	assert.Equal(t, "text/plain", calls[0].ContentType)
+}
+
+func TestGRPC(t *testing.T) {
+	app := newTestApp()
+	port, err := app.StartGRPC()
+	assert.NoError(t, err)
+	defer app.Close()
+
+	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := daprclientv1pb.NewDaprClientClient(conn)
+
+	t.Run("subscriptions", func(t *testing.T) {
+		subscriptions := runtime_pubsub.GetSubscriptionsGRPC(client, testLogger)
+		assert.Len(t, subscriptions, 1)
+		assert.Equal(t, "orders", subscriptions[0].Topic)
+
+		bindings, err := client.GetBindingsSubscriptions(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"queue"}, bindings.Bindings)
+	})
+
+	t.Run("topic events follow the script", func(t *testing.T) {
+		event := &daprclientv1pb.CloudEventEnvelope{Topic: "orders", Data: &any.Any{Value: []byte("1")}}
+		_, err := client.OnTopicEvent(context.Background(), event)
+		assert.Equal(t, codes.Unknown, status.Code(err))
+		_, err = client.OnTopicEvent(context.Background(), event)
+		assert.NoError(t, err)
+	})
+
+	t.Run("bindings", func(t *testing.T) {
+		resp, err := client.OnBindingEvent(context.Background(), &daprclientv1pb.BindingEventEnvelope{Name: "queue", Metadata: map[string]string{"k": "v"}})
+		assert.NoError(t, err)
+		assert.Equal(t, `"done"`, string(resp.Data.Value))
+		assert.Equal(t, []string{"v"}, app.Calls(CallBinding, "queue")[0].Metadata["k"])
+	})
+}
+
+func TestWaitForCalls(t *testing.T) {
+	app := newTestApp()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		invoke(app, http.MethodPost, "echo", nil)
+	}()
+
+	calls, err := app.WaitForCalls(CallInvoke, "echo", 1, time.Second)
+	assert.NoError(t, err)
+	assert.Len(t, calls, 1)
+
+	_, err = app.WaitForCalls(CallInvoke, "echo", 2, 10*time.Millisecond)
+	assert.Error(t, err)
+
+	app.Reset()
+	assert.Empty(t, app.Calls(CallInvoke, ""))
+}
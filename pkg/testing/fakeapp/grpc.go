@@ -0,0 +1,129 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package fakeapp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sort"
+
+	"github.com/dapr/dapr/pkg/channel"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	daprclientv1pb "github.com/dapr/dapr/pkg/proto/daprclient/v1"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// StartGRPC serves the app over gRPC on a free local port, and returns the port
+func (a *App) StartGRPC() (int, error) {
+	listener, err := net.Listen("tcp", channel.DefaultChannelAddress+":0")
+	if err != nil {
+		return 0, err
+	}
+	server := grpc.NewServer()
+	daprclientv1pb.RegisterDaprClientServer(server, &grpcApp{app: a})
+	go server.Serve(listener)
+
+	a.lock.Lock()
+	a.servers = append(a.servers, server.Stop)
+	a.lock.Unlock()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// grpcApp answers the calls of the runtime to a gRPC app
+type grpcApp struct {
+	app *App
+}
+
+// OnInvoke answers the invocations like an HTTP app, including the calls to actors
+func (g *grpcApp) OnInvoke(ctx context.Context, req *commonv1pb.InvokeRequest) (*commonv1pb.InvokeResponse, error) {
+	verb := http.MethodPost
+	if ext := req.GetHttpExtension(); ext != nil && ext.GetVerb() != commonv1pb.HTTPExtension_NONE {
+		verb = ext.GetVerb().String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	resp := g.app.dispatch(verb, req.GetMethod(), req.GetData().GetValue(), req.GetContentType(), md)
+	if err := invokev1.ErrorFromHTTPResponseCode(resp.Status, string(resp.Data)); err != nil {
+		return nil, err
+	}
+	return &commonv1pb.InvokeResponse{Data: &any.Any{Value: resp.Data}, ContentType: resp.ContentType}, nil
+}
+
+func (g *grpcApp) GetTopicSubscriptions(ctx context.Context, in *empty.Empty) (*daprclientv1pb.GetTopicSubscriptionsEnvelope, error) {
+	g.app.lock.Lock()
+	defer g.app.lock.Unlock()
+
+	resp := &daprclientv1pb.GetTopicSubscriptionsEnvelope{}
+	for topic, s := range g.app.subscriptions {
+		resp.Subscriptions = append(resp.Subscriptions, &daprclientv1pb.TopicSubscriptionEnvelope{Topic: topic, Metadata: s.metadata})
+	}
+	sort.Slice(resp.Subscriptions, func(i, j int) bool {
+		return resp.Subscriptions[i].Topic < resp.Subscriptions[j].Topic
+	})
+	return resp, nil
+}
+
+func (g *grpcApp) GetBindingsSubscriptions(ctx context.Context, in *empty.Empty) (*daprclientv1pb.GetBindingsSubscriptionsEnvelope, error) {
+	g.app.lock.Lock()
+	defer g.app.lock.Unlock()
+
+	resp := &daprclientv1pb.GetBindingsSubscriptionsEnvelope{}
+	for name := range g.app.bindings {
+		resp.Bindings = append(resp.Bindings, name)
+	}
+	sort.Strings(resp.Bindings)
+	return resp, nil
+}
+
+func (g *grpcApp) OnBindingEvent(ctx context.Context, in *daprclientv1pb.BindingEventEnvelope) (*daprclientv1pb.BindingResponseEnvelope, error) {
+	g.app.lock.Lock()
+	handler, ok := g.app.bindings[in.GetName()]
+	g.app.lock.Unlock()
+	if !ok {
+		return nil, invokev1.ErrorFromHTTPResponseCode(http.StatusNotFound, in.GetName())
+	}
+
+	md := map[string][]string{}
+	for k, v := range in.GetMetadata() {
+		md[k] = []string{v}
+	}
+	resp := g.app.handle(Call{Kind: CallBinding, Name: in.GetName(), Data: in.GetData().GetValue(), Metadata: md}, handler)
+	if err := invokev1.ErrorFromHTTPResponseCode(resp.Status, string(resp.Data)); err != nil {
+		return nil, err
+	}
+
+	out := &daprclientv1pb.BindingResponseEnvelope{}
+	if resp.Data != nil {
+		out.Data = &any.Any{Value: resp.Data}
+	}
+	return out, nil
+}
+
+func (g *grpcApp) OnTopicEvent(ctx context.Context, in *daprclientv1pb.CloudEventEnvelope) (*empty.Empty, error) {
+	g.app.lock.Lock()
+	s, ok := g.app.subscriptions[in.GetTopic()]
+	g.app.lock.Unlock()
+	if !ok {
+		return nil, invokev1.ErrorFromHTTPResponseCode(http.StatusNotFound, in.GetTopic())
+	}
+
+	md := map[string][]string{
+		"id":     {in.GetId()},
+		"source": {in.GetSource()},
+		"type":   {in.GetType()},
+	}
+	call := Call{Kind: CallTopic, Name: in.GetTopic(), Data: in.GetData().GetValue(), ContentType: in.GetDataContentType(), Metadata: md}
+	resp := g.app.handle(call, s.handler)
+	if err := invokev1.ErrorFromHTTPResponseCode(resp.Status, string(resp.Data)); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
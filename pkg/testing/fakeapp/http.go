@@ -0,0 +1,79 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package fakeapp
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"github.com/dapr/dapr/pkg/channel"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+)
+
+// ServeHTTP answers the calls of the runtime to an HTTP app
+func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	resp := a.dispatch(r.Method, r.URL.Path, data, r.Header.Get("Content-Type"), r.Header)
+	if resp.ContentType != "" {
+		w.Header().Set("Content-Type", resp.ContentType)
+	}
+	w.WriteHeader(resp.Status)
+	w.Write(resp.Data)
+}
+
+// StartHTTP serves the app over HTTP on a free local port, and returns the port
+func (a *App) StartHTTP() (int, error) {
+	listener, err := net.Listen("tcp", channel.DefaultChannelAddress+":0")
+	if err != nil {
+		return 0, err
+	}
+	server := &http.Server{Handler: a}
+	go server.Serve(listener)
+
+	a.lock.Lock()
+	a.servers = append(a.servers, func() {
+		server.Shutdown(context.Background())
+	})
+	a.lock.Unlock()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// Channel returns an app channel calling the app in process like an HTTP app, for the tests of the runtime that
+// don't need a server
+func (a *App) Channel() channel.AppChannel {
+	return &appChannel{app: a}
+}
+
+type appChannel struct {
+	app *App
+}
+
+func (c *appChannel) GetBaseAddress() string {
+	return ""
+}
+
+func (c *appChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	metadata := map[string][]string{}
+	invokev1.InternalMetadataToHTTPHeader(req.Metadata(), func(key, value string) {
+		metadata[http.CanonicalHeaderKey(key)] = []string{value}
+	})
+	verb := http.MethodPost
+	if ext := req.Message().GetHttpExtension(); ext != nil && ext.GetVerb() != commonv1pb.HTTPExtension_NONE {
+		verb = ext.GetVerb().String()
+	}
+	contentType, data := req.RawData()
+
+	resp := c.app.dispatch(verb, req.Message().GetMethod(), data, contentType, metadata)
+	return invokev1.NewInvokeMethodResponse(int32(resp.Status), "", nil).WithRawData(resp.Data, resp.ContentType), nil
+}
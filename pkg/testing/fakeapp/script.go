@@ -0,0 +1,47 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package fakeapp
+
+import (
+	"sync"
+	"time"
+)
+
+// Reply returns a handler answering every call with the status and data
+func Reply(status int, data []byte) Handler {
+	return func(Call) Response {
+		return Response{Status: status, Data: data}
+	}
+}
+
+// Echo is a handler answering every call with its data
+func Echo(call Call) Response {
+	return Response{Data: call.Data, ContentType: call.ContentType}
+}
+
+// Sequence returns a handler answering the calls with the handlers in turn, the last one answering the remaining
+// calls. For example, Sequence(Reply(500, nil), Reply(500, nil), Echo) fails twice before succeeding.
+func Sequence(handlers ...Handler) Handler {
+	var lock sync.Mutex
+	next := 0
+	return func(call Call) Response {
+		lock.Lock()
+		handler := handlers[next]
+		if next < len(handlers)-1 {
+			next++
+		}
+		lock.Unlock()
+		return handler(call)
+	}
+}
+
+// Delay returns a handler waiting for d before answering with handler
+func Delay(d time.Duration, handler Handler) Handler {
+	return func(call Call) Response {
+		time.Sleep(d)
+		return handler(call)
+	}
+}
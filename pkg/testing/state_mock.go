@@ -0,0 +1,106 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package testing
+
+import (
+	"sync"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/components/state/inmemory"
+)
+
+// FaultyStateStore is an in-memory state store whose gets can be held and whose calls can fail, to test the callers
+// of state stores under contention and failures
+type FaultyStateStore struct {
+	*inmemory.StateStore
+	lock      sync.Mutex
+	gate      chan struct{}
+	held      chan struct{}
+	getErr    error
+	setErr    error
+	beforeSet func(req *state.SetRequest)
+	sets      int
+}
+
+// NewFaultyStateStore returns an empty store without faults
+func NewFaultyStateStore() *FaultyStateStore {
+	return &FaultyStateStore{StateStore: inmemory.NewStateStore()}
+}
+
+// HoldGets holds the gets after they read the store until release is called, so that callers race on the same ETag.
+// Up to readers held gets are signaled on the returned channel.
+func (f *FaultyStateStore) HoldGets(readers int) (<-chan struct{}, func()) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	gate, held := make(chan struct{}), make(chan struct{}, readers)
+	f.gate, f.held = gate, held
+	return held, func() { close(gate) }
+}
+
+// Fail makes the gets and the sets fail with err, or succeed again if err is nil
+func (f *FaultyStateStore) Fail(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.getErr, f.setErr = err, err
+}
+
+// FailSets makes the sets fail with err, or succeed again if err is nil
+func (f *FaultyStateStore) FailSets(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.setErr = err
+}
+
+// BeforeSet runs hook before each set that doesn't fail, for instance to write the key in the in-memory store like
+// another sidecar would
+func (f *FaultyStateStore) BeforeSet(hook func(req *state.SetRequest)) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.beforeSet = hook
+}
+
+// Sets returns the number of sets, the failed ones included
+func (f *FaultyStateStore) Sets() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.sets
+}
+
+// Get returns the value of a key, or the error of the gets
+func (f *FaultyStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	f.lock.Lock()
+	err, gate, held := f.getErr, f.gate, f.held
+	f.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.StateStore.Get(req)
+	if gate != nil {
+		select {
+		case held <- struct{}{}:
+		default:
+		}
+		<-gate
+	}
+	return resp, err
+}
+
+// Set saves the value of a key, or returns the error of the sets
+func (f *FaultyStateStore) Set(req *state.SetRequest) error {
+	f.lock.Lock()
+	f.sets++
+	err, hook := f.setErr, f.beforeSet
+	f.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if hook != nil {
+		hook(req)
+	}
+	return f.StateStore.Set(req)
+}
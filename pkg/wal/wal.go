@@ -0,0 +1,143 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package wal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const entrySuffix = ".entry"
+
+// Entry is a record of the log
+type Entry struct {
+	ID      string
+	Data    []byte
+	Created time.Time
+}
+
+// Log is a durable local queue. Each entry is written to its own file in the log directory,
+// so appended entries survive restarts until they are removed.
+type Log struct {
+	dir  string
+	lock sync.Mutex
+	last int64
+}
+
+// Open opens the log in dir, creating the directory if it doesn't exist
+func Open(dir string) (*Log, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create log directory %s: %s", dir, err)
+	}
+	return &Log{dir: dir}, nil
+}
+
+// Append durably writes data as a new entry and returns its id. Entries are ordered by their ids.
+func (l *Log) Append(data []byte) (string, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// ids are nanosecond timestamps, made unique and increasing within the process
+	seq := time.Now().UnixNano()
+	if seq <= l.last {
+		seq = l.last + 1
+	}
+	l.last = seq
+	id := fmt.Sprintf("%020d", seq)
+
+	tmp := filepath.Join(l.dir, id+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, filepath.Join(l.dir, id+entrySuffix)); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return id, nil
+}
+
+// Entries returns the entries of the log, oldest first
+func (l *Log) Entries() ([]Entry, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	files, err := ioutil.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), entrySuffix) {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(l.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{
+			ID:      strings.TrimSuffix(f.Name(), entrySuffix),
+			Data:    data,
+			Created: f.ModTime(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+// Read returns the data of the entry with the given id
+func (l *Log) Read(id string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(l.dir, id+entrySuffix))
+}
+
+// Remove deletes the entry with the given id from the log
+func (l *Log) Remove(id string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	err := os.Remove(filepath.Join(l.dir, id+entrySuffix))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Len returns the number of entries in the log
+func (l *Log) Len() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	files, err := ioutil.ReadDir(l.dir)
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), entrySuffix) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,52 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package wal
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := Open(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, l.Len())
+
+	first, err := l.Append([]byte("1"))
+	assert.NoError(t, err)
+	_, err = l.Append([]byte("2"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, l.Len())
+
+	t.Run("entries survive reopening in order", func(t *testing.T) {
+		reopened, err := Open(dir)
+		assert.NoError(t, err)
+
+		entries, err := reopened.Entries()
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, first, entries[0].ID)
+		assert.Equal(t, []byte("1"), entries[0].Data)
+		assert.Equal(t, []byte("2"), entries[1].Data)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		assert.NoError(t, l.Remove(first))
+		assert.NoError(t, l.Remove(first))
+
+		entries, err := l.Entries()
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, []byte("2"), entries[0].Data)
+	})
+}